@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services"
+	"github.com/emiyaio/solana-wallet-service/pkg/database"
+	"github.com/emiyaio/solana-wallet-service/pkg/logger"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+const usage = `Usage: admin [-config path] [-timeout duration] <command> [args]
+
+Commands:
+  sync-token <mint>            Sync market data for a token from SolanaTracker
+  recompute-trader <wallet>    Recompute trader statistics from stored transactions
+  close-room <roomId>          Close a trading room on behalf of its creator
+  reindex-trending [timeframe] Rebuild the trending ranking (default timeframe: 24h)
+  backfill-wallet <address>    Fetch and store recent on-chain transactions for a wallet
+`
+
+func main() {
+	configPath := flag.String("config", "configs/config.yaml", "path to the configuration file")
+	timeout := flag.Duration("timeout", 10*time.Minute, "maximum duration of the command")
+	backfillLimit := flag.Int("limit", 100, "number of signatures to scan for backfill-wallet")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	log, err := logger.InitLogger(cfg.Log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize database
+	dbConn, err := database.NewPostgresConnection(cfg.Database)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to connect to database")
+	}
+	defer dbConn.Close()
+
+	// Initialize Redis; the server instances learn about rooms closed here over its cluster channel
+	redisClient, err := redis.NewRedisClient(cfg.Redis)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to connect to Redis")
+	}
+	defer redisClient.Close()
+
+	repos := repositories.NewRepositories(dbConn.DB)
+	svc := services.NewServices(repos, redisClient, cfg, log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if err := run(ctx, svc, log, args, *backfillLimit); err != nil {
+		log.WithError(err).WithField("command", args[0]).Error("Admin command failed")
+		cancel()
+		redisClient.Close()
+		dbConn.Close()
+		os.Exit(1)
+	}
+}
+
+// run dispatches a single admin subcommand
+func run(ctx context.Context, svc *services.Services, log *logrus.Logger, args []string, backfillLimit int) error {
+	command := args[0]
+
+	switch command {
+	case "sync-token":
+		mint, err := requireArg(args, "mint address")
+		if err != nil {
+			return err
+		}
+		marketData, err := svc.TokenMarket.SyncMarketDataFromExternalAPI(ctx, mint)
+		if err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"mint_address": mint,
+			"price_usd":    marketData.PriceUSD,
+		}).Info("Token synced")
+
+	case "recompute-trader":
+		wallet, err := requireArg(args, "wallet address")
+		if err != nil {
+			return err
+		}
+		trader, err := svc.Trader.RecomputeTraderStats(ctx, wallet)
+		if err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"wallet":       wallet,
+			"total_trades": trader.TotalTrades,
+			"win_rate":     trader.WinRate,
+			"total_pnl":    trader.TotalPnL,
+		}).Info("Trader recomputed")
+
+	case "close-room":
+		roomID, err := requireArg(args, "room ID")
+		if err != nil {
+			return err
+		}
+		room, err := svc.Room.GetRoom(ctx, roomID)
+		if err != nil {
+			return err
+		}
+		if err := svc.Room.CloseRoom(ctx, roomID, room.CreatorAddress); err != nil {
+			return err
+		}
+		if err := svc.WebSocket.AnnounceRoomClosed(ctx, roomID); err != nil {
+			return fmt.Errorf("room closed but the servers were not told: %w", err)
+		}
+		log.WithField("room_id", roomID).Info("Room closed")
+
+	case "reindex-trending":
+		timeframe := "24h"
+		if len(args) > 1 {
+			timeframe = args[1]
+		}
+		indexed, err := svc.TokenMarket.SyncTrendingTokens(ctx, timeframe)
+		if err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"timeframe": timeframe,
+			"indexed":   indexed,
+		}).Info("Trending reindexed")
+
+	case "backfill-wallet":
+		address, err := requireArg(args, "wallet address")
+		if err != nil {
+			return err
+		}
+		stored, err := svc.Trader.BackfillWallet(ctx, address, backfillLimit)
+		if err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"wallet": address,
+			"stored": stored,
+		}).Info("Wallet backfilled")
+
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	return nil
+}
+
+// requireArg returns the first positional argument of a subcommand
+func requireArg(args []string, name string) (string, error) {
+	if len(args) < 2 || args[1] == "" {
+		return "", fmt.Errorf("%s requires a %s argument", args[0], name)
+	}
+	return args[1], nil
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+const usage = `Usage: loadtest [flags]
+
+Creates a room on a running server, connects the simulated members to its WebSocket and broadcasts
+announcements as the creator, then reports how long the announcements took to reach the members and how
+many never did. The room is deleted afterwards.
+`
+
+const (
+	batchSize     = 100 // members added per batch request, the API's limit
+	messagePrefix = "loadtest "
+)
+
+type options struct {
+	server   string
+	clients  int
+	messages int
+	rate     float64
+	drain    time.Duration
+}
+
+type loadTest struct {
+	opts    options
+	http    *http.Client
+	creator string
+	roomID  string
+
+	sentMu sync.Mutex
+	sentAt map[int]time.Time // sequence -> when its broadcast request started
+
+	latencyMu sync.Mutex
+	latencies []time.Duration
+
+	received     int64
+	connected    int64
+	disconnected int64
+	failed       int64 // broadcast requests the server refused
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.server, "server", "http://localhost:8080", "base URL of the server")
+	flag.IntVar(&opts.clients, "clients", 100, "number of simulated room members, at most 999")
+	flag.IntVar(&opts.messages, "messages", 200, "number of announcements to broadcast")
+	flag.Float64Var(&opts.rate, "rate", 20, "announcements per second")
+	flag.DurationVar(&opts.drain, "drain", 5*time.Second, "how long to wait for deliveries after the last broadcast")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if opts.clients < 1 || opts.clients > 999 || opts.messages < 1 || opts.rate <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	lt := &loadTest{
+		opts:    opts,
+		http:    &http.Client{Timeout: 30 * time.Second},
+		creator: randomWallet(),
+		sentAt:  make(map[int]time.Time),
+	}
+	if err := lt.run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Load test failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (lt *loadTest) run() error {
+	if err := lt.createRoom(); err != nil {
+		return err
+	}
+	defer lt.deleteRoom()
+
+	wallets := make([]string, lt.opts.clients)
+	for i := range wallets {
+		wallets[i] = randomWallet()
+	}
+	if err := lt.addMembers(wallets); err != nil {
+		return err
+	}
+
+	conns := lt.connect(wallets)
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	if len(conns) == 0 {
+		return fmt.Errorf("no client could connect")
+	}
+
+	started := time.Now()
+	lt.broadcast()
+	elapsed := time.Since(started)
+	time.Sleep(lt.opts.drain)
+
+	lt.report(len(conns), elapsed)
+	return nil
+}
+
+// createRoom creates the room the simulated members join, with a seat for each and the creator
+func (lt *loadTest) createRoom() error {
+	var room struct {
+		RoomID string `json:"room_id"`
+	}
+	err := lt.request(http.MethodPost, "/api/v1/rooms", nil, map[string]interface{}{
+		"creator_address": lt.creator,
+		"max_members":     lt.opts.clients + 1,
+	}, &room)
+	if err != nil {
+		return fmt.Errorf("failed to create room: %w", err)
+	}
+	lt.roomID = room.RoomID
+	fmt.Printf("Created room %s\n", lt.roomID)
+	return nil
+}
+
+func (lt *loadTest) deleteRoom() {
+	headers := map[string]string{"X-Creator-Address": lt.creator}
+	if err := lt.request(http.MethodDelete, "/api/v1/rooms/"+lt.roomID, headers, nil, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to delete room %s: %v\n", lt.roomID, err)
+	}
+}
+
+func (lt *loadTest) addMembers(wallets []string) error {
+	headers := map[string]string{"X-Creator-Address": lt.creator}
+	for start := 0; start < len(wallets); start += batchSize {
+		end := start + batchSize
+		if end > len(wallets) {
+			end = len(wallets)
+		}
+		err := lt.request(http.MethodPost, "/api/v1/rooms/"+lt.roomID+"/members/batch", headers, map[string]interface{}{
+			"action":           "add",
+			"wallet_addresses": wallets[start:end],
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add members: %w", err)
+		}
+	}
+	return nil
+}
+
+// connect opens a room WebSocket per wallet and starts reading each; wallets that cannot connect are skipped
+func (lt *loadTest) connect(wallets []string) []*websocket.Conn {
+	base := strings.Replace(lt.opts.server, "http", "ws", 1) + "/api/v1/ws/rooms/" + url.PathEscape(lt.roomID) + "?wallet="
+
+	var (
+		mu    sync.Mutex
+		conns []*websocket.Conn
+		wg    sync.WaitGroup
+	)
+	for _, wallet := range wallets {
+		wg.Add(1)
+		go func(wallet string) {
+			defer wg.Done()
+			conn, _, err := websocket.DefaultDialer.Dial(base+url.QueryEscape(wallet), nil)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to connect %s: %v\n", wallet, err)
+				return
+			}
+			atomic.AddInt64(&lt.connected, 1)
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+			go lt.read(conn)
+		}(wallet)
+	}
+	wg.Wait()
+	fmt.Printf("Connected %d of %d clients\n", len(conns), len(wallets))
+	return conns
+}
+
+// read records the delivery latency of each announcement the client receives
+func (lt *loadTest) read(conn *websocket.Conn) {
+	for {
+		var message struct {
+			Type string `json:"type"`
+			Data struct {
+				Title string `json:"title"`
+			} `json:"data"`
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			atomic.AddInt64(&lt.disconnected, 1)
+			return
+		}
+		if message.Type != "announcement" || !strings.HasPrefix(message.Data.Title, messagePrefix) {
+			continue
+		}
+
+		var seq int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(message.Data.Title, messagePrefix), "%d", &seq); err != nil {
+			continue
+		}
+		lt.sentMu.Lock()
+		sentAt, ok := lt.sentAt[seq]
+		lt.sentMu.Unlock()
+		if !ok {
+			continue
+		}
+
+		latency := time.Since(sentAt)
+		atomic.AddInt64(&lt.received, 1)
+		lt.latencyMu.Lock()
+		lt.latencies = append(lt.latencies, latency)
+		lt.latencyMu.Unlock()
+	}
+}
+
+// broadcast sends the announcements at the configured rate; each is timed from the start of its request
+func (lt *loadTest) broadcast() {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / lt.opts.rate))
+	defer ticker.Stop()
+
+	headers := map[string]string{"X-Wallet-Address": lt.creator}
+	var wg sync.WaitGroup
+	for seq := 0; seq < lt.opts.messages; seq++ {
+		<-ticker.C
+		lt.sentMu.Lock()
+		lt.sentAt[seq] = time.Now()
+		lt.sentMu.Unlock()
+
+		wg.Add(1)
+		go func(seq int) {
+			defer wg.Done()
+			err := lt.request(http.MethodPost, "/api/v1/ws/rooms/"+lt.roomID+"/broadcast", headers, map[string]interface{}{
+				"type": "announcement",
+				"data": map[string]string{
+					"title": fmt.Sprintf("%s%d", messagePrefix, seq),
+					"text":  "Broadcast load test",
+				},
+			}, nil)
+			if err != nil {
+				atomic.AddInt64(&lt.failed, 1)
+				fmt.Fprintf(os.Stderr, "Broadcast %d failed: %v\n", seq, err)
+			}
+		}(seq)
+	}
+	wg.Wait()
+}
+
+func (lt *loadTest) report(clients int, elapsed time.Duration) {
+	lt.latencyMu.Lock()
+	latencies := append([]time.Duration(nil), lt.latencies...)
+	lt.latencyMu.Unlock()
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	sent := int64(lt.opts.messages) - atomic.LoadInt64(&lt.failed)
+	expected := sent * int64(clients)
+	received := atomic.LoadInt64(&lt.received)
+	dropRate := 0.0
+	if expected > 0 {
+		dropRate = float64(expected-received) / float64(expected) * 100
+	}
+
+	fmt.Println()
+	fmt.Printf("Clients:         %d connected, %d disconnected during the test\n", clients, atomic.LoadInt64(&lt.disconnected))
+	fmt.Printf("Broadcasts:      %d sent in %s, %d refused\n", sent, elapsed.Round(time.Millisecond), atomic.LoadInt64(&lt.failed))
+	fmt.Printf("Deliveries:      %d of %d (%.2f%% dropped)\n", received, expected, dropRate)
+	if len(latencies) == 0 {
+		return
+	}
+	fmt.Printf("Fanout latency:  p50 %s, p95 %s, p99 %s, max %s\n",
+		percentile(latencies, 50), percentile(latencies, 95), percentile(latencies, 99), latencies[len(latencies)-1])
+}
+
+// percentile returns the p-th percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(float64(len(sorted)-1) * p / 100)
+	return sorted[i].Round(time.Microsecond)
+}
+
+// request sends a JSON request to the server and decodes the data of a successful response into out
+func (lt *loadTest) request(method, path string, headers map[string]string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, lt.opts.server+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := lt.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(raw)))
+	}
+	if out == nil {
+		return nil
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return err
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+// randomWallet returns a random well-formed wallet address
+func randomWallet() string {
+	key := make([]byte, solana.AddressLength)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return solana.EncodeBase58(key)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
 	"github.com/emiyaio/solana-wallet-service/internal/handlers"
 	"github.com/emiyaio/solana-wallet-service/internal/services"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
 	"github.com/emiyaio/solana-wallet-service/pkg/database"
 	"github.com/emiyaio/solana-wallet-service/pkg/logger"
 	"github.com/emiyaio/solana-wallet-service/pkg/redis"
@@ -52,21 +54,7 @@ func main() {
 	log.Info("Redis connected successfully")
 
 	// Auto-migrate database schema
-	if err := dbConn.AutoMigrate(
-		&models.Token{},
-		&models.TokenMarketData{},
-		&models.TokenTrendingRanking{},
-		&models.TokenTopHolders{},
-		&models.TokenTransactionStats{},
-		&models.TradeRoom{},
-		&models.RoomMember{},
-		&models.SharedInfo{},
-		&models.TradeEvent{},
-		&models.Trader{},
-		&models.SmartMoneyTransaction{},
-		&models.TransactionAnalysis{},
-		&models.WalletFollowing{},
-	); err != nil {
+	if err := dbConn.AutoMigrate(models.All()...); err != nil {
 		log.WithError(err).Fatal("Failed to auto-migrate database")
 	}
 	log.Info("Database migration completed")
@@ -76,23 +64,59 @@ func main() {
 	log.Info("Repositories initialized")
 
 	// Initialize services
-	services := services.NewServices(repos, cfg, log)
+	services := services.NewServices(repos, redisClient, cfg, log)
+	defer services.SubscriptionManager.Stop()
 	log.Info("Services initialized")
 
-	// Start WebSocket heartbeat monitoring
+	// Cancelled on shutdown, for work started outside a request
+	appCtx, stopApp := context.WithCancel(context.Background())
+	defer stopApp()
+
+	// Reload token scoring weights when the config file changes; other settings still require a restart
+	config.Watch(func(reloaded *config.Config, err error) {
+		if err != nil {
+			log.WithError(err).Error("Failed to reload config")
+			return
+		}
+		services.TokenAnalysis.UpdateScoring(&reloaded.Scoring)
+	})
+
+	// Start the WebSocket connection supervisor
 	services.WebSocket.StartHeartbeat()
 	defer services.WebSocket.StopHeartbeat()
 
+	// Stream discoveries to the market WebSocket
+	services.MarketStream.Start()
+	defer services.MarketStream.Stop()
+
+	// Consume market data events for the alert and stream consumers
+	services.MarketEvents.Start()
+	defer services.MarketEvents.Stop()
+
 	// Start QuickNode WebSocket connection
 	go func() {
 		if err := services.QuickNode.Connect(); err != nil {
+			// Retried under the reconnect policy; the subscription janitor restores subscriptions once connected
 			log.WithError(err).Error("Failed to connect to QuickNode WebSocket")
+			services.QuickNode.Reconnect()
+			return
+		}
+		// Resubscribe room members whose subscriptions were left behind by a stopped instance
+		if _, err := services.SubscriptionManager.RestoreSubscriptions(appCtx); err != nil {
+			log.WithError(err).Error("Failed to restore wallet subscriptions")
+		}
+		// Then those the database records but no persisted intent covers anymore
+		if _, err := services.SubscriptionManager.ResubscribeMembers(appCtx); err != nil {
+			log.WithError(err).Error("Failed to resubscribe wallets from the database")
 		}
 	}()
 	defer services.QuickNode.Disconnect()
 
+	// Prime caches before serving, so the first requests after a deploy are not slow
+	warmUp(appCtx, services, repos, log)
+
 	// Initialize router and setup routes
-	router := handlers.NewRouter(services, log)
+	router := handlers.NewRouter(services, redisClient, &cfg.Server, &cfg.WebSocket, &cfg.RateLimit, log)
 	router.SetupRoutes()
 	log.Info("Routes configured")
 
@@ -114,7 +138,7 @@ func main() {
 	}()
 
 	// Start background tasks
-	go startBackgroundTasks(services, log, cfg)
+	go startBackgroundTasks(appCtx, services, log, cfg)
 
 	log.Info("Solana Wallet Service started successfully")
 
@@ -125,6 +149,9 @@ func main() {
 
 	log.Info("Shutting down server...")
 
+	// Stop background jobs and connection work started outside a request
+	stopApp()
+
 	// Create a deadline for graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -137,45 +164,445 @@ func main() {
 	}
 }
 
+// warmUpTimeout bounds the cache priming done before the server starts
+const warmUpTimeout = time.Minute
+
+// warmUp preloads the settings of active room members and the latest market data of room-bound tokens;
+// failures are logged and the caches fill on demand instead
+func warmUp(ctx context.Context, services *services.Services, repos *repositories.Repositories, log *logrus.Logger) {
+	ctx, cancel := context.WithTimeout(ctx, warmUpTimeout)
+	defer cancel()
+
+	members, err := services.WebSocket.WarmRooms(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to warm up rooms")
+	}
+
+	tokens := 0
+	mints, err := repos.Room.GetBoundTokenAddresses(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to get room-bound tokens")
+	} else if tokens, err = services.TokenMarket.WarmMarketData(ctx, mints); err != nil {
+		log.WithError(err).Error("Failed to warm up market data")
+	}
+
+	log.WithFields(logrus.Fields{
+		"members": members,
+		"tokens":  tokens,
+	}).Info("Warm-up completed")
+}
+
 // startBackgroundTasks starts various background tasks
-func startBackgroundTasks(services *services.Services, log *logrus.Logger, cfg *config.Config) {
+func startBackgroundTasks(ctx context.Context, services *services.Services, log *logrus.Logger, cfg *config.Config) {
+	// Each job run is bounded, and cancelled on shutdown
+	jobTimeout := cfg.Timeouts.Job
+	if jobTimeout <= 0 {
+		jobTimeout = 30 * time.Minute
+	}
+
 	// Room cleanup ticker
 	roomCleanupTicker := time.NewTicker(cfg.Room.CleanupInterval)
 	defer roomCleanupTicker.Stop()
 
-	// Market data sync ticker - use unified sync interval for now
-	marketSyncTicker := time.NewTicker(cfg.SyncScheduler.UnifiedSyncInterval)
-	defer marketSyncTicker.Stop()
+	// Market data sync tickers, one per token priority tier
+	hotSyncTicker := time.NewTicker(services.TokenSync.Interval(token.SyncTierHot))
+	defer hotSyncTicker.Stop()
+	warmSyncTicker := time.NewTicker(services.TokenSync.Interval(token.SyncTierWarm))
+	defer warmSyncTicker.Stop()
+	coldSyncTicker := time.NewTicker(services.TokenSync.Interval(token.SyncTierCold))
+	defer coldSyncTicker.Stop()
 
 	// Trending tokens sync ticker
 	trendingSyncTicker := time.NewTicker(cfg.SyncScheduler.TrendingTokensInterval)
 	defer trendingSyncTicker.Stop()
 
+	// New token discovery ticker polling the latest-tokens feed
+	latestInterval := cfg.SyncScheduler.LatestTokensInterval
+	if latestInterval <= 0 {
+		latestInterval = time.Minute
+	}
+	latestTokensTicker := time.NewTicker(latestInterval)
+	defer latestTokensTicker.Stop()
+
+	// Portfolio snapshot ticker, daily unless configured otherwise
+	snapshotInterval := cfg.SyncScheduler.PortfolioSnapshotInterval
+	if snapshotInterval <= 0 {
+		snapshotInterval = 24 * time.Hour
+	}
+	portfolioSnapshotTicker := time.NewTicker(snapshotInterval)
+	defer portfolioSnapshotTicker.Stop()
+
+	// Token transaction stats rollup ticker
+	statsInterval := cfg.SyncScheduler.TransactionStatsInterval
+	if statsInterval <= 0 {
+		statsInterval = 5 * time.Minute
+	}
+	transactionStatsTicker := time.NewTicker(statsInterval)
+	defer transactionStatsTicker.Stop()
+
+	// Wallet cluster ticker; each run scans a bounded batch of recently active wallets
+	clusterInterval := cfg.SyncScheduler.WalletClusterInterval
+	if clusterInterval <= 0 {
+		clusterInterval = time.Hour
+	}
+	walletClusterTicker := time.NewTicker(clusterInterval)
+	defer walletClusterTicker.Stop()
+
+	// Room digest ticker; each run fills in yesterday's missing digests
+	digestInterval := cfg.Room.DigestCheckInterval
+	if digestInterval <= 0 {
+		digestInterval = time.Hour
+	}
+	roomDigestTicker := time.NewTicker(digestInterval)
+	defer roomDigestTicker.Stop()
+
+	// Signal evaluation ticker; checkpoints are priced from 5 minute candles
+	signalInterval := cfg.Room.SignalEvaluationInterval
+	if signalInterval <= 0 {
+		signalInterval = 5 * time.Minute
+	}
+	signalEvaluationTicker := time.NewTicker(signalInterval)
+	defer signalEvaluationTicker.Stop()
+
+	// Liquidity monitoring ticker for room-bound tokens
+	liquidityInterval := cfg.Room.Liquidity.CheckInterval
+	if liquidityInterval <= 0 {
+		liquidityInterval = 5 * time.Minute
+	}
+	liquidityCheckTicker := time.NewTicker(liquidityInterval)
+	defer liquidityCheckTicker.Stop()
+
+	// Social ingestion ticker; each run collects the last complete hour of mentions
+	socialInterval := cfg.SyncScheduler.SocialIngestInterval
+	if socialInterval <= 0 {
+		socialInterval = time.Hour
+	}
+	socialIngestTicker := time.NewTicker(socialInterval)
+	defer socialIngestTicker.Stop()
+
+	// Wallet reconciliation ticker; backfills room trades the log subscription missed, e.g. while reconnecting
+	reconcileInterval := cfg.SyncScheduler.WalletReconcileInterval
+	if reconcileInterval <= 0 {
+		reconcileInterval = 5 * time.Minute
+	}
+	walletReconcileTicker := time.NewTicker(reconcileInterval)
+	defer walletReconcileTicker.Stop()
+
+	// Subscription janitor ticker; heartbeats wallet subscriptions and drops those without a room member
+	janitorInterval := cfg.SyncScheduler.SubscriptionJanitorInterval
+	if janitorInterval <= 0 {
+		janitorInterval = time.Minute
+	}
+	subscriptionJanitorTicker := time.NewTicker(janitorInterval)
+	defer subscriptionJanitorTicker.Stop()
+
+	// Finality check ticker; settles trades recorded before finalized commitment
+	finalityInterval := cfg.SyncScheduler.FinalityCheckInterval
+	if finalityInterval <= 0 {
+		finalityInterval = time.Minute
+	}
+	finalityCheckTicker := time.NewTicker(finalityInterval)
+	defer finalityCheckTicker.Stop()
+
+	// Unlock warning ticker; rooms are warned of large unlocks of their token within 48h
+	unlockInterval := cfg.SyncScheduler.UnlockCheckInterval
+	if unlockInterval <= 0 {
+		unlockInterval = time.Hour
+	}
+	unlockCheckTicker := time.NewTicker(unlockInterval)
+	defer unlockCheckTicker.Stop()
+
+	// Price feed ticker; SOL, USDC and USDT prices value native SOL trades server-side
+	priceFeedInterval := cfg.SyncScheduler.PriceFeedInterval
+	if priceFeedInterval <= 0 {
+		priceFeedInterval = 30 * time.Second
+	}
+	priceFeedTicker := time.NewTicker(priceFeedInterval)
+	defer priceFeedTicker.Stop()
+
+	// Notification purge ticker; deletes notifications past their retention
+	purgeInterval := cfg.Notification.PurgeInterval
+	if purgeInterval <= 0 {
+		purgeInterval = 6 * time.Hour
+	}
+	notificationPurgeTicker := time.NewTicker(purgeInterval)
+	defer notificationPurgeTicker.Stop()
+
 	for {
 		select {
+		case <-ctx.Done():
+			return
+
 		case <-roomCleanupTicker.C:
+			jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
 			// Clean up expired rooms
-			if err := services.Room.CleanupExpiredRooms(context.Background()); err != nil {
+			if err := services.Room.CleanupExpiredRooms(jobCtx); err != nil {
 				log.WithError(err).Error("Failed to cleanup expired rooms")
 			}
+			// Warn and remove members inactive past their room's policy
+			if _, err := services.MemberPruner.PruneInactiveMembers(jobCtx); err != nil {
+				log.WithError(err).Error("Failed to prune inactive room members")
+			}
+			// Drop room feed messages past their retention
+			if _, err := services.Room.PruneFeed(jobCtx); err != nil {
+				log.WithError(err).Error("Failed to prune room feed")
+			}
+			// Remove expired export files
+			if _, err := services.Export.CleanupExpiredExports(jobCtx); err != nil {
+				log.WithError(err).Error("Failed to cleanup expired exports")
+			}
+			// Expire limit watches past their expiry
+			if _, err := services.LimitWatch.ExpireWatches(jobCtx); err != nil {
+				log.WithError(err).Error("Failed to expire limit watches")
+			}
+			cancel()
+
+		case <-hotSyncTicker.C:
+			// Sync market data of room-bound and limit-watched tokens
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.TokenSync.SyncTier(jobCtx, token.SyncTierHot)
+				if errors.Is(err, token.ErrSyncInProgress) {
+					return // the previous run is still going
+				}
+				recordJobRun(services, "market_sync_hot", services.TokenSync.Interval(token.SyncTierHot), err)
+				if err != nil {
+					log.WithError(err).Error("Failed to sync hot token market data")
+				}
+			}()
 
-		case <-marketSyncTicker.C:
-			// Sync market data for all tokens
+		case <-warmSyncTicker.C:
+			// Sync market data of trending tokens
 			go func() {
-				if err := services.TokenMarket.SyncAllTokensMarketData(context.Background()); err != nil {
-					log.WithError(err).Error("Failed to sync market data")
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.TokenSync.SyncTier(jobCtx, token.SyncTierWarm)
+				if errors.Is(err, token.ErrSyncInProgress) {
+					return // the previous run is still going
+				}
+				recordJobRun(services, "market_sync_warm", services.TokenSync.Interval(token.SyncTierWarm), err)
+				if err != nil {
+					log.WithError(err).Error("Failed to sync warm token market data")
+				}
+			}()
+
+		case <-coldSyncTicker.C:
+			// Sweep the market data of the remaining tokens
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.TokenSync.SyncTier(jobCtx, token.SyncTierCold)
+				if errors.Is(err, token.ErrSyncInProgress) {
+					return // the previous run is still going
+				}
+				recordJobRun(services, "market_sync_cold", services.TokenSync.Interval(token.SyncTierCold), err)
+				if err != nil {
+					log.WithError(err).Error("Failed to sync cold token market data")
 				}
 			}()
 
 		case <-trendingSyncTicker.C:
-			// Sync trending tokens from SolanaTracker
+			// Rebuild the trending ranking from SolanaTracker; momentum alerts are checked against the previous one
 			go func() {
-				if _, err := services.SolanaTracker.GetTrendingTokens("24h"); err != nil {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.TokenMarket.SyncTrendingTokens(jobCtx, "24h")
+				recordJobRun(services, "trending_sync", cfg.SyncScheduler.TrendingTokensInterval, err)
+				if err != nil {
 					log.WithError(err).Warn("Failed to sync trending tokens")
 				} else {
 					log.Info("Trending tokens synced successfully")
 				}
 			}()
+
+		case <-latestTokensTicker.C:
+			// Record and stream newly listed tokens
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.TokenDiscovery.PollLatestTokens(jobCtx)
+				recordJobRun(services, "latest_tokens", latestInterval, err)
+				if err != nil {
+					log.WithError(err).Warn("Failed to poll latest tokens")
+				}
+			}()
+
+		case <-transactionStatsTicker.C:
+			// Roll trades up into 1h/24h/7d token transaction stats
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.TokenMarket.RollupTransactionStats(jobCtx)
+				recordJobRun(services, "transaction_stats", statsInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to roll up transaction stats")
+				}
+				_, err = services.Analytics.RollupDexStats(jobCtx)
+				recordJobRun(services, "dex_stats", statsInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to roll up DEX stats")
+				}
+				_, err = services.Report.RollupRoomStats(jobCtx)
+				recordJobRun(services, "room_stats", statsInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to roll up room stats")
+				}
+			}()
+
+		case <-walletClusterTicker.C:
+			// Link recently active wallets to wallets of the same entity
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.Cluster.ScanActiveWallets(jobCtx)
+				recordJobRun(services, "wallet_cluster", clusterInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to scan wallet clusters")
+				}
+			}()
+
+		case <-portfolioSnapshotTicker.C:
+			// Snapshot portfolio value of followed wallets and room members
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.Portfolio.SnapshotTrackedWallets(jobCtx)
+				recordJobRun(services, "portfolio_snapshot", snapshotInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to snapshot portfolios")
+				}
+			}()
+
+		case <-roomDigestTicker.C:
+			// Generate daily digests for active rooms
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.Report.GenerateDailyDigests(jobCtx)
+				recordJobRun(services, "room_digests", digestInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to generate room digests")
+				}
+			}()
+
+		case <-signalEvaluationTicker.C:
+			// Evaluate shared signals whose checkpoints have come due
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.SignalTracker.EvaluateSignals(jobCtx)
+				recordJobRun(services, "signal_evaluation", signalInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to evaluate signals")
+				}
+			}()
+
+		case <-liquidityCheckTicker.C:
+			// Snapshot pool liquidity and alert rooms about pulls
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.Liquidity.CheckLiquidity(jobCtx)
+				recordJobRun(services, "liquidity_check", liquidityInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to check token liquidity")
+				}
+			}()
+
+		case <-socialIngestTicker.C:
+			// Collect social mentions of room-bound and trending tokens
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.Social.IngestHour(jobCtx)
+				recordJobRun(services, "social_ingest", socialInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to ingest social mentions")
+				}
+			}()
+
+		case <-walletReconcileTicker.C:
+			// Rescan subscribed wallets for transactions missed by the log subscription
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.SubscriptionManager.ReconcileWallets(jobCtx)
+				recordJobRun(services, "wallet_reconcile", reconcileInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to reconcile wallet transactions")
+				}
+			}()
+
+		case <-subscriptionJanitorTicker.C:
+			// Unsubscribe wallets left without an active room membership and adopt orphaned subscriptions
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.SubscriptionManager.CleanupSubscriptions(jobCtx)
+				recordJobRun(services, "subscription_janitor", janitorInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to clean up wallet subscriptions")
+				}
+			}()
+
+		case <-finalityCheckTicker.C:
+			// Mark provisional trades finalized or reverted
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.Finality.CheckFinality(jobCtx)
+				recordJobRun(services, "finality_check", finalityInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to check trade finality")
+				}
+			}()
+
+		case <-unlockCheckTicker.C:
+			// Warn rooms of upcoming large token unlocks
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.Unlock.WarnUpcomingUnlocks(jobCtx)
+				recordJobRun(services, "unlock_warnings", unlockInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to warn of upcoming token unlocks")
+				}
+			}()
+
+		case <-priceFeedTicker.C:
+			// Record the latest major asset prices
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.PriceFeed.Refresh(jobCtx)
+				recordJobRun(services, "price_feed", priceFeedInterval, err)
+				if err != nil {
+					log.WithError(err).Warn("Failed to refresh price feed")
+				}
+			}()
+
+		case <-notificationPurgeTicker.C:
+			// Delete expired notifications
+			go func() {
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				defer cancel()
+				_, err := services.Notification.PurgeExpired(jobCtx)
+				recordJobRun(services, "notification_purge", purgeInterval, err)
+				if err != nil {
+					log.WithError(err).Error("Failed to purge expired notifications")
+				}
+			}()
 		}
 	}
-}
\ No newline at end of file
+}
+
+// recordJobRunTimeout bounds recording a background job run
+const recordJobRunTimeout = 5 * time.Second
+
+// recordJobRun records a background job run on its own context, so a run cut short by shutdown is still recorded
+func recordJobRun(services *services.Services, job string, interval time.Duration, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), recordJobRunTimeout)
+	defer cancel()
+	services.AdminStats.RecordJobRun(ctx, job, interval, err)
+}
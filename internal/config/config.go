@@ -3,6 +3,7 @@ package config
 import (
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
@@ -16,16 +17,77 @@ type Config struct {
 	SyncScheduler SyncSchedulerConfig `mapstructure:"sync_scheduler"`
 	WebSocket    WebSocketConfig    `mapstructure:"websocket"`
 	Room         RoomConfig         `mapstructure:"room"`
+	Export       ExportConfig       `mapstructure:"export"`
 	RateLimit    RateLimitConfig    `mapstructure:"rate_limit"`
 	Metrics      MetricsConfig      `mapstructure:"metrics"`
+	Scoring      ScoringConfig      `mapstructure:"scoring"`
+	Momentum     MomentumConfig     `mapstructure:"momentum"`
+	MarketStream MarketStreamConfig `mapstructure:"market_stream"`
+	Notification NotificationConfig `mapstructure:"notification"`
+	Email        EmailConfig        `mapstructure:"email"`
+	Admin        AdminConfig        `mapstructure:"admin"`
+	Timeouts     TimeoutsConfig     `mapstructure:"timeouts"`
+	FeatureFlags FeatureFlagsConfig `mapstructure:"feature_flags"`
 }
 
 type ServerConfig struct {
-	Port           string        `mapstructure:"port"`
-	Mode           string        `mapstructure:"mode"`
-	ReadTimeout    time.Duration `mapstructure:"read_timeout"`
-	WriteTimeout   time.Duration `mapstructure:"write_timeout"`
-	MaxHeaderBytes int           `mapstructure:"max_header_bytes"`
+	Port           string            `mapstructure:"port"`
+	Mode           string            `mapstructure:"mode"`
+	ReadTimeout    time.Duration     `mapstructure:"read_timeout"`
+	WriteTimeout   time.Duration     `mapstructure:"write_timeout"`
+	MaxHeaderBytes int               `mapstructure:"max_header_bytes"`
+	BodyLimits     BodyLimitsConfig  `mapstructure:"body_limits"`
+	Compression    CompressionConfig `mapstructure:"compression"`
+	TrustedProxies []string          `mapstructure:"trusted_proxies"` // IPs or CIDRs whose X-Forwarded-For is believed; none by default
+}
+
+// BodyLimitsConfig caps request bodies in bytes; larger requests are refused with a 413. Zero values fall back to defaults.
+type BodyLimitsConfig struct {
+	Default   int64 `mapstructure:"default"`   // any route not listed below; default 1 MiB
+	Batch     int64 `mapstructure:"batch"`     // batch member changes, batch token analysis and unlock imports; default 256 KiB
+	Broadcast int64 `mapstructure:"broadcast"` // room broadcasts; default 16 KiB
+}
+
+// CompressionConfig controls gzip compression of HTTP responses for clients that accept it; zero values fall back to defaults
+type CompressionConfig struct {
+	Disabled  bool `mapstructure:"disabled"`
+	Level     int  `mapstructure:"level"`      // gzip level from 1 (fastest) to 9 (smallest); default 5
+	MinLength int  `mapstructure:"min_length"` // smaller responses are sent as they are; default 1 KiB
+}
+
+// TimeoutsConfig bounds operations started outside an HTTP request, which are also cancelled on shutdown;
+// zero uses the defaults
+type TimeoutsConfig struct {
+	Job          time.Duration `mapstructure:"job"`          // one run of a background job; default 30m
+	WebSocket    time.Duration `mapstructure:"websocket"`    // a store call made for a WebSocket connection or message; default 10s
+	Subscription time.Duration `mapstructure:"subscription"` // the handling of one wallet subscription notification; default 30s
+}
+
+// FeatureFlagsConfig sets this environment's defaults of the feature flags, which apply until an admin sets a
+// flag; flags not listed keep their built-in default
+type FeatureFlagsConfig struct {
+	Defaults map[string]bool `mapstructure:"defaults"`  // flag key -> enabled
+	CacheTTL time.Duration   `mapstructure:"cache_ttl"` // how long the flags are cached in Redis; default 30s
+}
+
+// AdminConfig seeds the admins allowed on admin routes; with none configured the routes are refused
+type AdminConfig struct {
+	APIKeys         []AdminAPIKeyConfig `mapstructure:"api_keys"`
+	Wallets         []AdminWalletConfig `mapstructure:"wallets"`
+	SignatureMaxAge time.Duration       `mapstructure:"signature_max_age"` // how old a signed wallet request may be; default 5m
+}
+
+// AdminAPIKeyConfig is a key sent in the X-Admin-Key header or as a bearer token
+type AdminAPIKeyConfig struct {
+	Name string `mapstructure:"name"` // recorded in the audit log in place of the key
+	Key  string `mapstructure:"key"`
+	Role string `mapstructure:"role"` // viewer, operator or admin
+}
+
+// AdminWalletConfig is a wallet that signs its admin requests
+type AdminWalletConfig struct {
+	Address string `mapstructure:"address"`
+	Role    string `mapstructure:"role"` // viewer, operator or admin
 }
 
 type DatabaseConfig struct {
@@ -39,6 +101,8 @@ type DatabaseConfig struct {
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"` // queries taking longer are logged as slow
 }
 
 type RedisConfig struct {
@@ -63,66 +127,307 @@ type ExternalAPIsConfig struct {
 	QuickNode    QuickNodeConfig    `mapstructure:"quicknode"`
 	SolanaTracker SolanaTrackerConfig `mapstructure:"solana_tracker"`
 	Helius       HeliusConfig       `mapstructure:"helius"`
+	Jupiter      JupiterConfig      `mapstructure:"jupiter"`
+	Birdeye      BirdeyeConfig      `mapstructure:"birdeye"`
+	GeckoTerminal GeckoTerminalConfig `mapstructure:"geckoterminal"`
+	Pyth         PythConfig         `mapstructure:"pyth"`
+	Social       SocialConfig       `mapstructure:"social"`
+	Quotas       QuotaConfig        `mapstructure:"quotas"`
+	RateLimits   map[string]ProviderRateLimitConfig `mapstructure:"rate_limits"` // keyed by provider, e.g. solana_tracker
+	MarketDataProvider string `mapstructure:"market_data_provider"` // solana_tracker or birdeye, serving token info and candles; default solana_tracker
+}
+
+// ProviderRateLimitConfig is the request rate of one external API, shared by all its callers on the instance.
+// Endpoint classes may be limited further; providers with neither a configured nor a built-in rate are not limited.
+type ProviderRateLimitConfig struct {
+	APIRateLimitConfig `mapstructure:",squash"`
+	Endpoints          map[string]APIRateLimitConfig `mapstructure:"endpoints"` // per endpoint class, e.g. token_info or chart, on top of the provider's rate
+}
+
+// APIRateLimitConfig is a token bucket of external API requests; a zero rate leaves the built-in default
+type APIRateLimitConfig struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"` // requests allowed at once; default 1
+}
+
+// QuotaConfig sets the monthly quotas of the metered providers. Usage is counted per UTC day either way;
+// requests are paced once a provider passes the soft limit so its quota lasts to the end of the month.
+type QuotaConfig struct {
+	SolanaTracker    ProviderQuotaConfig `mapstructure:"solana_tracker"`     // in requests
+	QuickNode        ProviderQuotaConfig `mapstructure:"quicknode"`          // in API credits
+	OpenAI           ProviderQuotaConfig `mapstructure:"openai"`             // in tokens
+	Birdeye          ProviderQuotaConfig `mapstructure:"birdeye"`            // in requests
+	SoftLimitPercent float64             `mapstructure:"soft_limit_percent"` // share of a quota from which requests are paced; default 80
+	MaxDelay         time.Duration       `mapstructure:"max_delay"`          // longest a request is held back; default 5s
+}
+
+// ProviderQuotaConfig is one provider's monthly quota; without one usage is only tracked
+type ProviderQuotaConfig struct {
+	Monthly         int64 `mapstructure:"monthly"`
+	UnitsPerRequest int64 `mapstructure:"units_per_request"` // counted per request; defaults to 1 for SolanaTracker and Birdeye and 20 for QuickNode, OpenAI counts response tokens
 }
 
 type OpenAIConfig struct {
+	BaseURL string          `mapstructure:"base_url"`
+	APIKey  string          `mapstructure:"api_key"`
+	Model   string          `mapstructure:"model"`
+	Timeout time.Duration   `mapstructure:"timeout"`
+	Quota   AIQuotaConfig   `mapstructure:"quota"`
+	Models  []AIModelConfig `mapstructure:"models"` // selectable by name on AI requests; Model stays the default
+}
+
+// AIModelConfig is a model callers may choose, with the bounds of their overrides and its pricing.
+// Zero bounds fall back to defaults; models without a price are accounted at no cost.
+type AIModelConfig struct {
+	Name            string  `mapstructure:"name"`             // what callers pass, e.g. fast or deep
+	Model           string  `mapstructure:"model"`            // OpenAI model ID
+	MaxTokens       int     `mapstructure:"max_tokens"`       // highest max_tokens override; default 2000
+	MaxTemperature  float64 `mapstructure:"max_temperature"`  // highest temperature override; default 1
+	PromptPrice     float64 `mapstructure:"prompt_price"`     // USD per million prompt tokens
+	CompletionPrice float64 `mapstructure:"completion_price"` // USD per million completion tokens
+}
+
+// AIQuotaConfig caps the OpenAI tokens each wallet may use; zero values fall back to defaults, negative ones lift the cap
+type AIQuotaConfig struct {
+	Daily   int64 `mapstructure:"daily"`   // tokens per UTC day; default 50000
+	Monthly int64 `mapstructure:"monthly"` // tokens per UTC month; default 1000000
+}
+
+type QuickNodeConfig struct {
+	HTTPUrl         string           `mapstructure:"http_url"`
+	WSSUrl          string           `mapstructure:"wss_url"`
+	APIKey          string           `mapstructure:"api_key"`
+	Timeout         time.Duration    `mapstructure:"timeout"`
+	Commitment      CommitmentConfig `mapstructure:"commitment"`
+	LivenessTimeout time.Duration    `mapstructure:"liveness_timeout"` // reconnect when the WebSocket delivers nothing, not even a pong, for this long; default 2m
+	Reconnect       ReconnectConfig  `mapstructure:"reconnect"`
+}
+
+// ReconnectConfig is the QuickNode reconnect policy and outage alerting; zero values fall back to defaults
+type ReconnectConfig struct {
+	MaxAttempts     int           `mapstructure:"max_attempts"`      // attempts before giving up; default 10
+	Forever         bool          `mapstructure:"forever"`           // never give up, retrying every MaxBackoff once the backoff reached it
+	MaxBackoff      time.Duration `mapstructure:"max_backoff"`       // ceiling of the doubling backoff between attempts; default 30s
+	AlertAfter      time.Duration `mapstructure:"alert_after"`       // raise a critical alert when disconnected this long; default 5m
+	AlertWebhookURL string        `mapstructure:"alert_webhook_url"` // receives outage alerts and recoveries as JSON; alerts are only logged without it
+}
+
+// CommitmentConfig sets the Solana commitment level (processed, confirmed or finalized) per use; empty values fall back to defaults
+type CommitmentConfig struct {
+	Subscription string `mapstructure:"subscription"` // live wallet log notifications, used as early UI hints; default processed
+	Read         string `mapstructure:"read"`         // lookups behind live room broadcasts and analysis; default confirmed
+	Persistence  string `mapstructure:"persistence"`  // transactions stored in the database; default finalized
+}
+
+type SolanaTrackerConfig struct {
 	BaseURL string        `mapstructure:"base_url"`
 	APIKey  string        `mapstructure:"api_key"`
-	Model   string        `mapstructure:"model"`
 	Timeout time.Duration `mapstructure:"timeout"`
 }
 
-type QuickNodeConfig struct {
+type HeliusConfig struct {
 	HTTPUrl string        `mapstructure:"http_url"`
 	WSSUrl  string        `mapstructure:"wss_url"`
 	APIKey  string        `mapstructure:"api_key"`
 	Timeout time.Duration `mapstructure:"timeout"`
 }
 
-type SolanaTrackerConfig struct {
-	BaseURL string        `mapstructure:"base_url"`
+// BirdeyeConfig is the Birdeye market data API. With an API key it is asked for tokens the other market data
+// provider fails on.
+type BirdeyeConfig struct {
+	BaseURL string        `mapstructure:"base_url"` // defaults to the public API
 	APIKey  string        `mapstructure:"api_key"`
 	Timeout time.Duration `mapstructure:"timeout"`
 }
 
-type HeliusConfig struct {
-	HTTPUrl string        `mapstructure:"http_url"`
-	WSSUrl  string        `mapstructure:"wss_url"`
+// GeckoTerminalConfig is the keyless GeckoTerminal API serving per-pool prices, liquidity and volume
+type GeckoTerminalConfig struct {
+	BaseURL string        `mapstructure:"base_url"` // defaults to the public API
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type JupiterConfig struct {
+	BaseURL string        `mapstructure:"base_url"` // defaults to the public swap API
 	APIKey  string        `mapstructure:"api_key"`
 	Timeout time.Duration `mapstructure:"timeout"`
 }
 
+// PythConfig points the price feed at a Pyth Hermes endpoint; Jupiter quotes are the fallback for SOL.
+// Pyth prices are the trusted reference for their mints and DEX prices are checked against them.
+type PythConfig struct {
+	BaseURL             string           `mapstructure:"base_url"` // defaults to the public Hermes API
+	Timeout             time.Duration    `mapstructure:"timeout"`
+	Feeds               []PythFeedConfig `mapstructure:"feeds"`                 // reference feeds beyond SOL, USDC and USDT
+	MaxDeviationPercent float64          `mapstructure:"max_deviation_percent"` // DEX prices further from the reference, beyond its confidence, are flagged; default 5
+	MaxDEXPriceAge      time.Duration    `mapstructure:"max_dex_price_age"`     // DEX prices older than this are flagged stale; default 15m
+}
+
+// PythFeedConfig maps a mint to its Pyth USD price feed
+type PythFeedConfig struct {
+	Symbol string `mapstructure:"symbol"`
+	Mint   string `mapstructure:"mint"`
+	FeedID string `mapstructure:"feed_id"` // hex price feed ID, with or without 0x
+}
+
+// SocialConfig selects the social mention provider; an empty provider disables social ingestion
+type SocialConfig struct {
+	Provider  string        `mapstructure:"provider"` // twitter or aggregator
+	BaseURL   string        `mapstructure:"base_url"` // defaults to the X API for twitter; required for aggregator
+	APIKey    string        `mapstructure:"api_key"`  // bearer token
+	Timeout   time.Duration `mapstructure:"timeout"`
+	Retention time.Duration `mapstructure:"retention"` // how long hourly metrics are kept
+}
+
 type WorkerPoolConfig struct {
 	MaxWorkers   int `mapstructure:"max_workers"`
 	JobQueueSize int `mapstructure:"job_queue_size"`
 }
 
 type SyncSchedulerConfig struct {
-	UnifiedSyncInterval      time.Duration `mapstructure:"unified_sync_interval"`
+	HotTokensInterval        time.Duration `mapstructure:"hot_tokens_interval"`  // market sync of room-bound and limit-watched tokens; default 1m
+	WarmTokensInterval       time.Duration `mapstructure:"warm_tokens_interval"` // market sync of trending tokens; default 5m
+	ColdTokensInterval       time.Duration `mapstructure:"cold_tokens_interval"` // market sync of every other token; default 1h
 	TrendingTokensInterval   time.Duration `mapstructure:"trending_tokens_interval"`
 	VolumeTokensInterval     time.Duration `mapstructure:"volume_tokens_interval"`
 	LatestTokensInterval     time.Duration `mapstructure:"latest_tokens_interval"`
 	APICallInterval          time.Duration `mapstructure:"api_call_interval"`
+	PortfolioSnapshotInterval time.Duration `mapstructure:"portfolio_snapshot_interval"`
+	TransactionStatsInterval  time.Duration `mapstructure:"transaction_stats_interval"` // how often token trade stats are rolled up
+	WalletClusterInterval     time.Duration `mapstructure:"wallet_cluster_interval"`    // how often active wallets are scanned for related wallets
+	SocialIngestInterval      time.Duration `mapstructure:"social_ingest_interval"`     // how often tracked tokens' social mentions are collected
+	WalletReconcileInterval   time.Duration `mapstructure:"wallet_reconcile_interval"`  // how often subscribed wallets are rescanned for trades missed by the log subscription
+	SubscriptionJanitorInterval time.Duration `mapstructure:"subscription_janitor_interval"` // how often wallet subscriptions are heartbeated and checked against room membership; keep below five minutes
+	FinalityCheckInterval     time.Duration `mapstructure:"finality_check_interval"`    // how often provisional trades are checked for finality or reversal
+	UnlockCheckInterval       time.Duration `mapstructure:"unlock_check_interval"`      // how often rooms are warned of upcoming token unlocks
+	PriceFeedInterval         time.Duration `mapstructure:"price_feed_interval"`        // how often SOL, USDC and USDT prices are fetched
+	TokenMetadataMaxAge       time.Duration `mapstructure:"token_metadata_max_age"`     // token metadata older than this is re-fetched on the token's next market sync; default 7 days
 }
 
 type WebSocketConfig struct {
-	ReadBufferSize   int           `mapstructure:"read_buffer_size"`
-	WriteBufferSize  int           `mapstructure:"write_buffer_size"`
+	ReadBufferSize   int           `mapstructure:"read_buffer_size"`  // default 1 KiB
+	WriteBufferSize  int           `mapstructure:"write_buffer_size"` // default 1 KiB
 	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
 	PongWait         time.Duration `mapstructure:"pong_wait"`
 	PingPeriod       time.Duration `mapstructure:"ping_period"`
-	MaxMessageSize   int64         `mapstructure:"max_message_size"`
+	MaxMessageSize   int64         `mapstructure:"max_message_size"` // larger client messages close the connection; default 64 KiB
+	EnableCompression bool         `mapstructure:"enable_compression"` // negotiate permessage-deflate with clients that offer it
+	CompressionLevel int           `mapstructure:"compression_level"`  // flate level from 1 (fastest) to 9 (smallest); default 1
 }
 
 type RoomConfig struct {
-	DefaultRecycleHours int           `mapstructure:"default_recycle_hours"`
-	MaxMembers          int           `mapstructure:"max_members"`
-	CleanupInterval     time.Duration `mapstructure:"cleanup_interval"`
+	DefaultRecycleHours      int             `mapstructure:"default_recycle_hours"`
+	MaxMembers               int             `mapstructure:"max_members"`
+	CleanupInterval          time.Duration   `mapstructure:"cleanup_interval"`
+	TradeValueTolerance      float64         `mapstructure:"trade_value_tolerance"`      // allowed relative gap between client and server trade value
+	DigestCheckInterval      time.Duration   `mapstructure:"digest_check_interval"`      // how often missing daily digests are generated
+	SignalEvaluationInterval time.Duration   `mapstructure:"signal_evaluation_interval"` // how often due signal checkpoints are priced
+	InactivityWarning        time.Duration   `mapstructure:"inactivity_warning"`         // how long before removal inactive members are warned
+	FeedRetention            time.Duration   `mapstructure:"feed_retention"`             // how long room broadcasts are kept for the feed
+	FeedMaxMessages          int             `mapstructure:"feed_max_messages"`          // broadcasts kept per room for the feed
+	Throttle                 ThrottleConfig  `mapstructure:"throttle"`
+	PasswordAttempts         PasswordAttemptConfig `mapstructure:"password_attempts"`
+	TransferTTL              time.Duration   `mapstructure:"transfer_ttl"`               // how long an ownership transfer waits to be accepted
+	MaxWalletWatches         int             `mapstructure:"max_wallet_watches"`         // wallets a user may watch outside of rooms; default 20
+	Liquidity                LiquidityConfig `mapstructure:"liquidity"`
+	Rationale                RationaleConfig `mapstructure:"rationale"`
+}
+
+// RationaleConfig limits AI trade rationales of rooms that enable them; zero values fall back to defaults
+type RationaleConfig struct {
+	DailyTokenBudget int           `mapstructure:"daily_token_budget"` // AI tokens a room may spend on rationales per UTC day
+	Timeout          time.Duration `mapstructure:"timeout"`            // how long a trade broadcast waits for its rationale
+}
+
+// MomentumConfig tunes trending divergence alerts; zero values fall back to defaults
+type MomentumConfig struct {
+	MinRankGain           int           `mapstructure:"min_rank_gain"`            // places a token must climb between trending syncs
+	MaxPriceChangePercent float64       `mapstructure:"max_price_change_percent"` // absolute price move above which the climb is already priced in
+	TopRank               int           `mapstructure:"top_rank"`                 // only tokens ranked this high or better are considered
+	Cooldown              time.Duration `mapstructure:"cooldown"`                 // minimum time between alerts for the same token
+}
+
+// MarketStreamConfig tunes the events of the market WebSocket stream; zero values fall back to defaults
+type MarketStreamConfig struct {
+	PriceMoveThresholdPercent float64       `mapstructure:"price_move_threshold_percent"` // absolute move from the reference price that is streamed
+	PriceMoveWindow           time.Duration `mapstructure:"price_move_window"`            // age after which a reference price is replaced by the current one
+	TopRank                   int           `mapstructure:"top_rank"`                     // only trending changes within this rank are streamed
+}
+
+// NotificationConfig controls notification retention and the external delivery channels; zero values fall back to
+// defaults, and a channel without credentials is not used
+type NotificationConfig struct {
+	Retention             time.Duration `mapstructure:"retention"`                 // how long notifications are kept
+	ReadRetention         time.Duration `mapstructure:"read_retention"`            // how long read notifications are kept
+	PurgeInterval         time.Duration `mapstructure:"purge_interval"`            // how often expired notifications are deleted
+	WhaleAlertMinValueUSD float64       `mapstructure:"whale_alert_min_value_usd"` // trade value from which followers of the wallet are alerted
+	TelegramBotToken      string        `mapstructure:"telegram_bot_token"`
+	WebhookTimeout        time.Duration `mapstructure:"webhook_timeout"`
+}
+
+// EmailConfig is the mail service used for notification and digest emails; email is disabled without a from address
+type EmailConfig struct {
+	Provider        string        `mapstructure:"provider"` // smtp (default) or ses, which sends through the SES SMTP interface
+	Host            string        `mapstructure:"host"`     // SMTP host; derived from the region for ses
+	Port            int           `mapstructure:"port"`     // default 587
+	Username        string        `mapstructure:"username"` // SES SMTP credentials for ses
+	Password        string        `mapstructure:"password"`
+	From            string        `mapstructure:"from"`
+	SESRegion       string        `mapstructure:"ses_region"`       // e.g. us-east-1
+	VerificationTTL time.Duration `mapstructure:"verification_ttl"` // how long an email verification code is valid
+}
+
+// LiquidityConfig controls pool liquidity monitoring of room-bound tokens; zero values fall back to defaults
+type LiquidityConfig struct {
+	CheckInterval time.Duration `mapstructure:"check_interval"` // how often liquidity is sampled
+	DropPercent   float64       `mapstructure:"drop_percent"`   // drop below the window peak that raises an alert
+	Window        time.Duration `mapstructure:"window"`         // how far back the peak is taken; also the per-token alert cooldown
+	Retention     time.Duration `mapstructure:"retention"`      // how long snapshots are kept
+}
+
+// ThrottleConfig limits how often a wallet may act in a room; zero values fall back to defaults
+type ThrottleConfig struct {
+	Share        ThrottleLimit `mapstructure:"share"` // shares other than discussions
+	Chat         ThrottleLimit `mapstructure:"chat"`  // discussion shares
+	TradeEvent   ThrottleLimit `mapstructure:"trade_event"`
+	AIAsk        ThrottleLimit `mapstructure:"ai_ask"`     // room assistant questions
+	MuteAfter    int           `mapstructure:"mute_after"` // throttled attempts within MuteWindow before muting
+	MuteWindow   time.Duration `mapstructure:"mute_window"`
+	MuteDuration time.Duration `mapstructure:"mute_duration"`
+}
+
+// PasswordAttemptConfig limits wrong room password guesses per wallet and per IP; zero values fall back to defaults
+type PasswordAttemptConfig struct {
+	FreeAttempts    int           `mapstructure:"free_attempts"`    // wrong guesses within Window before retries are delayed; default 3
+	BaseDelay       time.Duration `mapstructure:"base_delay"`       // delay after the first delayed guess, doubling with each further one; default 2s
+	MaxDelay        time.Duration `mapstructure:"max_delay"`        // default 1m
+	LockoutAfter    int           `mapstructure:"lockout_after"`    // wrong guesses within Window before the room is locked; default 10
+	Window          time.Duration `mapstructure:"window"`           // how long wrong guesses are counted; default 15m
+	LockoutDuration time.Duration `mapstructure:"lockout_duration"` // default 30m
+}
+
+// ThrottleLimit allows Limit actions per sliding Window
+type ThrottleLimit struct {
+	Limit  int           `mapstructure:"limit"`
+	Window time.Duration `mapstructure:"window"`
+}
+
+type ExportConfig struct {
+	Dir            string        `mapstructure:"dir"`             // where background exports are written
+	AsyncThreshold int64         `mapstructure:"async_threshold"` // exports with more rows are generated in the background
+	TTL            time.Duration `mapstructure:"ttl"`             // how long generated files stay downloadable
 }
 
 type RateLimitConfig struct {
-	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
-	Burst             int     `mapstructure:"burst"`
+	RequestsPerSecond float64               `mapstructure:"requests_per_second"` // per client IP; 0 disables the IP limit
+	Burst             int                   `mapstructure:"burst"`
+	Wallet            WalletRateLimitConfig `mapstructure:"wallet"`
+}
+
+// WalletRateLimitConfig is the per-minute budget of each wallet across all its IPs; zero values fall back to defaults
+type WalletRateLimitConfig struct {
+	Read  int `mapstructure:"read"`  // GET and HEAD requests; default 300
+	Write int `mapstructure:"write"` // other requests; default 60
+	AI    int `mapstructure:"ai"`    // AI endpoints, counted on top of read or write; default 10
 }
 
 type MetricsConfig struct {
@@ -130,6 +435,44 @@ type MetricsConfig struct {
 	Path    string `mapstructure:"path"`
 }
 
+// ScoringConfig tunes token analysis scores and is reloaded when the config file changes; zero values fall back to defaults.
+// Each group of weights is normalized to sum to 1.
+type ScoringConfig struct {
+	Overall            OverallWeights  `mapstructure:"overall"`              // weights of the overall score
+	Momentum           MomentumWeights `mapstructure:"momentum"`             // weights of price changes in the momentum and sentiment scores
+	Risk               RiskWeights     `mapstructure:"risk"`                 // weights of the risk score
+	PriceChangeFactor  float64         `mapstructure:"price_change_factor"`  // price score points per percent of 24h price change
+	VolumeChangeFactor float64         `mapstructure:"volume_change_factor"` // volume score points per percent of 24h volume change
+	ProvenanceWeight   float64         `mapstructure:"provenance_weight"`    // share of provenance risk in the risk score
+	SocialWeight       float64         `mapstructure:"social_weight"`        // share of social sentiment in the sentiment score
+	BuyScore           float64         `mapstructure:"buy_score"`            // overall score at or above which a token is a buy
+	SellScore          float64         `mapstructure:"sell_score"`           // overall score at or below which a token is a sell
+	BuyMaxRisk         float64         `mapstructure:"buy_max_risk"`         // risk score a buy recommendation must stay below
+	SellMinRisk        float64         `mapstructure:"sell_min_risk"`        // risk score above which a token is a sell whatever its score
+	LowRisk            float64         `mapstructure:"low_risk"`             // risk scores below this are low
+	HighRisk           float64         `mapstructure:"high_risk"`            // risk scores at or above this are high
+	BullishSentiment   float64         `mapstructure:"bullish_sentiment"`    // sentiment above this is bullish, below its negative bearish
+}
+
+type OverallWeights struct {
+	Price    float64 `mapstructure:"price"`
+	Volume   float64 `mapstructure:"volume"`
+	Momentum float64 `mapstructure:"momentum"`
+}
+
+type MomentumWeights struct {
+	Change1h  float64 `mapstructure:"change_1h"`
+	Change24h float64 `mapstructure:"change_24h"`
+	Change7d  float64 `mapstructure:"change_7d"`
+}
+
+type RiskWeights struct {
+	Liquidity  float64 `mapstructure:"liquidity"`
+	Volatility float64 `mapstructure:"volatility"`
+	Market     float64 `mapstructure:"market"`
+	Technical  float64 `mapstructure:"technical"`
+}
+
 var globalConfig *Config
 
 func Load(configPath string) (*Config, error) {
@@ -151,4 +494,20 @@ func Load(configPath string) (*Config, error) {
 
 func Get() *Config {
 	return globalConfig
+}
+
+// Watch reloads the config file whenever it changes and passes the result to onChange.
+// A file that fails to parse is reported through err and leaves the previous config in place.
+func Watch(onChange func(config *Config, err error)) {
+	viper.OnConfigChange(func(fsnotify.Event) {
+		config := &Config{}
+		if err := viper.Unmarshal(config); err != nil {
+			onChange(nil, err)
+			return
+		}
+
+		globalConfig = config
+		onChange(config, nil)
+	})
+	viper.WatchConfig()
 }
\ No newline at end of file
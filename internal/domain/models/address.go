@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+// validateAddresses keeps malformed Solana addresses out of the database; create hooks call it
+// so records written by any service are checked, not only those coming through the API
+func validateAddresses(addresses ...string) error {
+	for _, address := range addresses {
+		if err := solana.ValidateAddress(address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdminRole grants access to admin routes; each role includes the ones below it
+type AdminRole string
+
+const (
+	AdminRoleViewer   AdminRole = "viewer"   // read-only dashboards and lists
+	AdminRoleOperator AdminRole = "operator" // also syncs, broadcasts and job runs
+	AdminRoleAdmin    AdminRole = "admin"    // also flags, labels, unlock schedules and the audit log
+)
+
+var adminRoleRanks = map[AdminRole]int{
+	AdminRoleViewer:   1,
+	AdminRoleOperator: 2,
+	AdminRoleAdmin:    3,
+}
+
+func (r AdminRole) IsValid() bool {
+	_, ok := adminRoleRanks[r]
+	return ok
+}
+
+// Allows reports whether the role includes the required one
+func (r AdminRole) Allows(required AdminRole) bool {
+	return r.IsValid() && adminRoleRanks[r] >= adminRoleRanks[required]
+}
+
+// AdminActorType is how an admin authenticated
+type AdminActorType string
+
+const (
+	AdminActorAPIKey AdminActorType = "api_key"
+	AdminActorWallet AdminActorType = "wallet"
+)
+
+// AdminAuditLog records one admin action, whether or not it succeeded
+type AdminAuditLog struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Actor     string         `gorm:"size:64;not null;index" json:"actor"` // API key name or wallet address, never the key itself
+	ActorType AdminActorType `gorm:"size:20;not null" json:"actor_type"`
+	Role      AdminRole      `gorm:"size:20;not null" json:"role"`
+	Method    string         `gorm:"size:10;not null" json:"method"`
+	Route     string         `gorm:"size:200;not null" json:"route"` // route pattern, e.g. /api/v1/admin/tokens/:mintAddress/flag
+	Path      string         `gorm:"size:500;not null" json:"path"`
+	Query     string         `gorm:"size:500" json:"query,omitempty"`
+	Status    int            `gorm:"not null" json:"status"`
+	ClientIP  string         `gorm:"size:64" json:"client_ip"`
+	CreatedAt time.Time      `gorm:"index" json:"created_at"`
+}
+
+func (l *AdminAuditLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AIFeature is the AI endpoint that used tokens
+type AIFeature string
+
+const (
+	AIFeatureAnalyze AIFeature = "analyze"  // token analysis
+	AIFeatureChat    AIFeature = "chat"     // general chat
+	AIFeatureRoomAsk AIFeature = "room_ask" // room assistant questions
+)
+
+// AIUsage records the OpenAI tokens one request used on behalf of a wallet
+type AIUsage struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress    string    `gorm:"size:64;not null;index:idx_ai_usages_wallet_created,priority:1" json:"wallet_address"`
+	SessionID        string    `gorm:"size:64;index" json:"session_id,omitempty"` // client session from X-Session-ID, if sent
+	Feature          AIFeature `gorm:"size:20;not null" json:"feature"`
+	Model            string    `gorm:"size:64" json:"model"`
+	PromptTokens     int       `gorm:"not null;default:0" json:"prompt_tokens"`
+	CompletionTokens int       `gorm:"not null;default:0" json:"completion_tokens"`
+	TotalTokens      int       `gorm:"not null;default:0" json:"total_tokens"`
+	CostUSD          float64   `gorm:"type:decimal(20,8);not null;default:0" json:"cost_usd"` // at the model's configured pricing
+	CreatedAt        time.Time `gorm:"index:idx_ai_usages_wallet_created,priority:2" json:"created_at"`
+}
+
+// AIUsageTotal sums a wallet's usage of one feature
+type AIUsageTotal struct {
+	Feature          AIFeature `json:"feature"`
+	Requests         int64     `json:"requests"`
+	PromptTokens     int64     `json:"prompt_tokens"`
+	CompletionTokens int64     `json:"completion_tokens"`
+	TotalTokens      int64     `json:"total_tokens"`
+	CostUSD          float64   `json:"cost_usd"`
+}
+
+func (u *AIUsage) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
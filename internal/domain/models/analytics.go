@@ -0,0 +1,53 @@
+package models
+
+import "github.com/google/uuid"
+
+// TokenGainer is a room-bound token ranked by price change
+type TokenGainer struct {
+	TokenID     uuid.UUID `json:"token_id"`
+	MintAddress string    `json:"mint_address"`
+	Symbol      string    `json:"symbol"`
+	Name        string    `json:"name"`
+	PriceUSD    float64   `json:"price_usd"`
+	PriceChange float64   `json:"price_change"` // percent over the requested timeframe
+	Volume24h   float64   `gorm:"column:volume_24h" json:"volume_24h"`
+	RoomCount   int       `json:"room_count"` // active rooms bound to the token
+}
+
+// CopiedTrader is a wallet whose buys other wallets repeated shortly afterwards
+type CopiedTrader struct {
+	WalletAddress string `json:"wallet_address"`
+	Copiers       int    `json:"copiers"`     // distinct wallets that bought the same token after the trader
+	CopyTrades    int    `json:"copy_trades"` // buys that followed one of the trader's buys
+	Followers     int    `json:"followers"`
+}
+
+// MarketStats aggregates the latest market data of the tokens updated since a time
+type MarketStats struct {
+	Tokens            int     `json:"tokens"`
+	TotalVolume24h    float64 `gorm:"column:total_volume_24h" json:"total_volume_24h"`
+	Gainers           int     `json:"gainers"` // tokens up over 24h
+	Losers            int     `json:"losers"`  // tokens down over 24h
+	AvgPriceChange24h float64 `gorm:"column:avg_price_change_24h" json:"avg_price_change_24h"`
+}
+
+// BusyRoom is a public room ranked by recent activity
+type BusyRoom struct {
+	RoomID       string  `json:"room_id"`
+	TokenAddress *string `json:"token_address"`
+	Members      int     `json:"members"`
+	TradeEvents  int     `json:"trade_events"`
+	SharedInfos  int     `json:"shared_infos"`
+	Activity     int     `json:"activity"` // trade events plus shared infos
+}
+
+// PlatformExecutionStats aggregates fees and slippage of successful swaps on one DEX platform
+type PlatformExecutionStats struct {
+	Platform               string   `json:"platform"`
+	Trades                 int      `json:"trades"`
+	AvgFeeLamports         float64  `json:"avg_fee_lamports"`
+	AvgPriorityFeeLamports *float64 `json:"avg_priority_fee_lamports"` // nil when no trade recorded its priority fee
+	PriorityFeeSamples     int      `json:"priority_fee_samples"`
+	AvgSlippagePercent     *float64 `json:"avg_slippage_percent"` // positive means worse than market, nil without samples
+	SlippageSamples        int      `json:"slippage_samples"`
+}
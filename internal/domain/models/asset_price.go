@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AssetPrice is a USD price recorded by the price feed: SOL, USDC, USDT and any configured Pyth reference feeds
+type AssetPrice struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	MintAddress string    `gorm:"size:64;not null;index:idx_asset_prices_mint_fetched,priority:1" json:"mint_address"`
+	Symbol      string    `gorm:"size:20;not null" json:"symbol"`
+	PriceUSD    float64   `gorm:"type:decimal(20,10);not null" json:"price_usd"`
+	Confidence  float64   `gorm:"type:decimal(20,10);not null;default:0" json:"confidence"` // Pyth confidence interval in USD, 0 for Jupiter quotes
+	Source      string    `gorm:"size:20;not null" json:"source"`                           // pyth or jupiter
+	FetchedAt   time.Time `gorm:"not null;index:idx_asset_prices_mint_fetched,priority:2,sort:desc;index" json:"fetched_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (ap *AssetPrice) BeforeCreate(tx *gorm.DB) error {
+	if ap.ID == uuid.Nil {
+		ap.ID = uuid.New()
+	}
+	return nil
+}
+
+// ReferencePrice is the oracle price a DEX price is checked against, attached to market data of tokens with a reference feed
+type ReferencePrice struct {
+	Source            string    `json:"source"`
+	PriceUSD          float64   `json:"price_usd"`
+	Confidence        float64   `json:"confidence"` // +/- USD around the price
+	PublishedAt       time.Time `json:"published_at"`
+	DeviationPercent  float64   `json:"deviation_percent"`   // DEX price relative to the reference
+	DEXPriceStale     bool      `json:"dex_price_stale"`     // the DEX price was not updated recently while the reference was
+	DEXPriceDivergent bool      `json:"dex_price_divergent"` // the DEX price is outside the confidence interval plus the allowed deviation
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DexDailyStat is one day of detected smart money trades routed through a DEX platform
+type DexDailyStat struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"-"`
+	Day       time.Time `gorm:"type:date;not null;uniqueIndex:idx_dex_daily_stats_day_platform" json:"day"` // UTC
+	Platform  string    `gorm:"size:50;not null;uniqueIndex:idx_dex_daily_stats_day_platform" json:"platform"`
+	Trades    int       `gorm:"not null;default:0" json:"trades"`
+	BuyCount  int       `gorm:"not null;default:0" json:"buy_count"`
+	SellCount int       `gorm:"not null;default:0" json:"sell_count"`
+	VolumeUSD float64   `gorm:"column:volume_usd;type:decimal(20,4);not null;default:0" json:"volume_usd"`
+	Wallets   int       `gorm:"not null;default:0" json:"wallets"` // distinct wallets trading on the platform that day
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (dds *DexDailyStat) BeforeCreate(tx *gorm.DB) error {
+	if dds.ID == uuid.Nil {
+		dds.ID = uuid.New()
+	}
+	return nil
+}
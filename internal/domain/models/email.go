@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailCategory is a kind of email a wallet can opt out of
+type EmailCategory string
+
+const (
+	EmailCategoryDigest     EmailCategory = "digest" // daily digests of the wallet's rooms
+	EmailCategoryPriceAlert EmailCategory = "price_alert"
+	EmailCategoryRoomInvite EmailCategory = "room_invite"
+	EmailCategoryWhaleAlert EmailCategory = "whale_alert"
+	EmailCategoryFollow     EmailCategory = "follow"
+)
+
+func (ec EmailCategory) IsValid() bool {
+	switch ec {
+	case EmailCategoryDigest, EmailCategoryPriceAlert, EmailCategoryRoomInvite, EmailCategoryWhaleAlert, EmailCategoryFollow:
+		return true
+	}
+	return false
+}
+
+// EmailVerification is a pending binding of an email address to a wallet, confirmed with the code mailed to it
+type EmailVerification struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress string    `gorm:"uniqueIndex;size:64;not null" json:"wallet_address"` // one pending binding per wallet
+	Email         string    `gorm:"size:255;not null" json:"email"`
+	CodeHash      string    `gorm:"size:64;not null" json:"-"` // hex SHA-256 of the code
+	Attempts      int       `gorm:"not null;default:0" json:"attempts"`
+	ExpiresAt     time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (ev *EmailVerification) BeforeCreate(tx *gorm.DB) error {
+	if ev.ID == uuid.Nil {
+		ev.ID = uuid.New()
+	}
+	return nil
+}
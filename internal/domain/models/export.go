@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataExport is an export file generated in the background for later download
+type DataExport struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Kind        ExportKind   `gorm:"type:varchar(30);not null" json:"kind"`
+	SubjectID   string       `gorm:"size:64;not null;index" json:"subject_id"` // room ID or wallet address, depending on kind
+	RequestedBy string       `gorm:"size:64;not null" json:"requested_by"`
+	Format      ExportFormat `gorm:"type:varchar(10);not null" json:"format"`
+	Status      ExportStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	FilePath    string       `gorm:"size:512" json:"-"`
+	FileSize    int64        `json:"file_size"`
+	RowCount    int64        `json:"row_count"`
+	Error       string       `gorm:"type:text" json:"error,omitempty"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time    `gorm:"index" json:"expires_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// ExportKind represents what data an export contains
+type ExportKind string
+
+const (
+	ExportKindRoom ExportKind = "room"
+)
+
+// ExportFormat represents the file format of an export
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// IsValid reports whether the format is supported
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatCSV || f == ExportFormatJSON
+}
+
+// ContentType returns the MIME type of the format
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// ExportStatus represents the generation state of an export
+type ExportStatus string
+
+const (
+	ExportStatusPending   ExportStatus = "pending"
+	ExportStatusCompleted ExportStatus = "completed"
+	ExportStatusFailed    ExportStatus = "failed"
+)
+
+func (de *DataExport) BeforeCreate(tx *gorm.DB) error {
+	if de.ID == uuid.Nil {
+		de.ID = uuid.New()
+	}
+	return nil
+}
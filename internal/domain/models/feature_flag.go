@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FeatureFlag turns a gated feature on or off at runtime. RoomID is empty for the global flag; a room's flag
+// overrides the global one for that room.
+type FeatureFlag struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Key       string    `gorm:"size:50;not null;uniqueIndex:idx_feature_flags_key_room,priority:1" json:"key"`
+	RoomID    string    `gorm:"size:64;not null;default:'';uniqueIndex:idx_feature_flags_key_room,priority:2" json:"room_id,omitempty"`
+	Enabled   bool      `gorm:"not null" json:"enabled"`
+	UpdatedBy string    `gorm:"size:64" json:"updated_by,omitempty"` // admin who last set the flag
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (f *FeatureFlag) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LimitWatchDirection is the way the price has to cross the target
+type LimitWatchDirection string
+
+const (
+	LimitWatchAbove LimitWatchDirection = "above" // triggers when the price rises to the target or higher
+	LimitWatchBelow LimitWatchDirection = "below" // triggers when the price falls to the target or lower
+)
+
+// LimitWatchStatus is the lifecycle state of a limit watch
+type LimitWatchStatus string
+
+const (
+	LimitWatchActive    LimitWatchStatus = "active"
+	LimitWatchTriggered LimitWatchStatus = "triggered"
+	LimitWatchCancelled LimitWatchStatus = "cancelled"
+	LimitWatchExpired   LimitWatchStatus = "expired"
+)
+
+// LimitWatch notifies a wallet once when a token's price crosses a target
+type LimitWatch struct {
+	ID                uuid.UUID           `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress     string              `gorm:"size:64;not null;index:idx_limit_watches_wallet_status" json:"wallet_address"`
+	MintAddress       string              `gorm:"size:64;not null;index:idx_limit_watches_mint_status" json:"mint_address"`
+	TargetPriceUSD    float64             `gorm:"type:decimal(30,18);not null" json:"target_price_usd"`
+	Direction         LimitWatchDirection `gorm:"size:10;not null" json:"direction"`
+	ReferencePriceUSD float64             `gorm:"type:decimal(30,18)" json:"reference_price_usd"` // price when the watch was created, 0 if unknown
+	SwapSide          string              `gorm:"size:10" json:"swap_side,omitempty"`             // buy or sell; empty omits the swap link
+	Note              string              `gorm:"size:200" json:"note,omitempty"`
+	Status            LimitWatchStatus    `gorm:"size:20;not null;default:'active';index:idx_limit_watches_wallet_status;index:idx_limit_watches_mint_status" json:"status"`
+	TriggeredPriceUSD float64             `gorm:"type:decimal(30,18)" json:"triggered_price_usd,omitempty"`
+	TriggeredAt       *time.Time          `json:"triggered_at,omitempty"`
+	ExpiresAt         *time.Time          `json:"expires_at,omitempty"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+}
+
+// Crossed reports whether the price has reached the watch's target in its direction
+func (lw *LimitWatch) Crossed(priceUSD float64) bool {
+	if priceUSD <= 0 {
+		return false
+	}
+	if lw.Direction == LimitWatchBelow {
+		return priceUSD <= lw.TargetPriceUSD
+	}
+	return priceUSD >= lw.TargetPriceUSD
+}
+
+// LimitWatchNotification is the data of the price alert sent to the wallet when one of its watches triggers
+type LimitWatchNotification struct {
+	Watch    *LimitWatch `json:"watch"`
+	PriceUSD float64     `json:"price_usd"`
+	SwapURL  string      `json:"swap_url,omitempty"` // Jupiter swap deep link for the watch's swap side
+}
+
+func (lw *LimitWatch) BeforeCreate(tx *gorm.DB) error {
+	if err := validateAddresses(lw.WalletAddress, lw.MintAddress); err != nil {
+		return err
+	}
+	if lw.ID == uuid.Nil {
+		lw.ID = uuid.New()
+	}
+	return nil
+}
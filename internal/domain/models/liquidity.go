@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LiquiditySnapshot records a token's pool liquidity at a point in time
+type LiquiditySnapshot struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	MintAddress  string    `gorm:"size:64;not null;index:idx_liquidity_snapshots_mint_created" json:"mint_address"`
+	LiquidityUSD float64   `gorm:"type:decimal(20,4)" json:"liquidity_usd"`
+	CreatedAt    time.Time `gorm:"index:idx_liquidity_snapshots_mint_created" json:"created_at"`
+}
+
+// LiquidityAlert is raised when a token's liquidity drops sharply below its recent peak
+type LiquidityAlert struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	MintAddress      string    `gorm:"size:64;not null;index" json:"mint_address"`
+	PeakLiquidityUSD float64   `gorm:"type:decimal(20,4)" json:"peak_liquidity_usd"`
+	PeakAt           time.Time `json:"peak_at"`
+	LiquidityUSD     float64   `gorm:"type:decimal(20,4)" json:"liquidity_usd"`
+	DropPercent      float64   `gorm:"type:decimal(10,4)" json:"drop_percent"`
+	WindowMinutes    int       `json:"window_minutes"` // the peak was taken over this many minutes
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func (ls *LiquiditySnapshot) BeforeCreate(tx *gorm.DB) error {
+	if ls.ID == uuid.Nil {
+		ls.ID = uuid.New()
+	}
+	return nil
+}
+
+func (la *LiquidityAlert) BeforeCreate(tx *gorm.DB) error {
+	if la.ID == uuid.Nil {
+		la.ID = uuid.New()
+	}
+	return nil
+}
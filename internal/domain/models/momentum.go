@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MomentumAlert is raised when a token climbs the trending ranking while its price has barely moved,
+// an early sign of momentum the price has not caught up with
+type MomentumAlert struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TokenID            uuid.UUID `gorm:"type:uuid;not null" json:"token_id"`
+	MintAddress        string    `gorm:"size:64;not null;index" json:"mint_address"`
+	Symbol             string    `gorm:"size:50" json:"symbol"`
+	Timeframe          string    `gorm:"size:10;not null" json:"timeframe"` // trending timeframe the ranks were taken from
+	PreviousRank       int       `json:"previous_rank"`
+	Rank               int       `json:"rank"`
+	PriceUSD           float64   `gorm:"type:decimal(30,18)" json:"price_usd"`
+	PriceChangePercent float64   `gorm:"type:decimal(10,4)" json:"price_change_percent"` // since the previous ranking
+	CreatedAt          time.Time `gorm:"index" json:"created_at"`
+}
+
+// RankGain is how many places the token climbed
+func (ma *MomentumAlert) RankGain() int {
+	return ma.PreviousRank - ma.Rank
+}
+
+func (ma *MomentumAlert) BeforeCreate(tx *gorm.DB) error {
+	if ma.ID == uuid.Nil {
+		ma.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationType is what a notification is about
+type NotificationType string
+
+const (
+	NotificationTypePriceAlert  NotificationType = "price_alert" // a limit watch reached its target
+	NotificationTypeFollow      NotificationType = "follow"      // another wallet followed this one
+	NotificationTypeRoomInvite  NotificationType = "room_invite"
+	NotificationTypeWhaleAlert  NotificationType = "whale_alert"  // a followed wallet made a large trade
+	NotificationTypeWalletWatch NotificationType = "wallet_watch" // a watched wallet traded
+)
+
+// NotificationTypes lists every notification type
+var NotificationTypes = []NotificationType{
+	NotificationTypePriceAlert,
+	NotificationTypeFollow,
+	NotificationTypeRoomInvite,
+	NotificationTypeWhaleAlert,
+	NotificationTypeWalletWatch,
+}
+
+func (nt NotificationType) IsValid() bool {
+	for _, t := range NotificationTypes {
+		if nt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationChannel is a way a notification reaches its wallet
+type NotificationChannel string
+
+const (
+	NotificationChannelWebSocket NotificationChannel = "websocket" // the wallet's open connections
+	NotificationChannelTelegram  NotificationChannel = "telegram"  // the wallet's Telegram chat
+	NotificationChannelWebhook   NotificationChannel = "webhook"   // a JSON POST to the wallet's webhook URL
+	NotificationChannelEmail     NotificationChannel = "email"
+)
+
+func (nc NotificationChannel) IsValid() bool {
+	switch nc {
+	case NotificationChannelWebSocket, NotificationChannelTelegram, NotificationChannelWebhook, NotificationChannelEmail:
+		return true
+	}
+	return false
+}
+
+// Notification is a message to one wallet. It is kept for the notification center whether or not any channel
+// delivered it.
+type Notification struct {
+	ID            uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress string           `gorm:"size:64;not null;index:idx_notifications_wallet_created,priority:1" json:"wallet_address"`
+	Type          NotificationType `gorm:"size:20;not null" json:"type"`
+	Title         string           `gorm:"size:200;not null" json:"title"`
+	Body          string           `gorm:"type:text" json:"body"`
+	Data          string           `gorm:"type:jsonb;not null;default:'{}'" json:"data"`     // JSON of the notification's subject, see NotificationType
+	Channels      string           `gorm:"type:jsonb;not null;default:'[]'" json:"channels"` // JSON array of channels that delivered it
+	ReadAt        *time.Time       `gorm:"index" json:"read_at,omitempty"`
+	CreatedAt     time.Time        `gorm:"index:idx_notifications_wallet_created,priority:2;index" json:"created_at"`
+}
+
+// NotificationRoutes maps notification types to the channels they are delivered on
+type NotificationRoutes map[NotificationType][]NotificationChannel
+
+// DefaultNotificationRoutes delivers every type on the WebSocket only
+func DefaultNotificationRoutes() NotificationRoutes {
+	routes := make(NotificationRoutes, len(NotificationTypes))
+	for _, t := range NotificationTypes {
+		routes[t] = []NotificationChannel{NotificationChannelWebSocket}
+	}
+	return routes
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	if n.Data == "" {
+		n.Data = "{}"
+	}
+	if n.Channels == "" {
+		n.Channels = "[]"
+	}
+	return nil
+}
+
+// SetChannels records the channels that delivered the notification
+func (n *Notification) SetChannels(channels []NotificationChannel) {
+	if channels == nil {
+		channels = []NotificationChannel{}
+	}
+	encoded, _ := json.Marshal(channels)
+	n.Channels = string(encoded)
+}
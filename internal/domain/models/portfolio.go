@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PortfolioSnapshot stores the daily USD value of a wallet's holdings
+type PortfolioSnapshot struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress string    `gorm:"size:64;not null;uniqueIndex:idx_portfolio_snapshots_wallet_date" json:"wallet_address"`
+	SnapshotDate  time.Time `gorm:"type:date;not null;uniqueIndex:idx_portfolio_snapshots_wallet_date" json:"snapshot_date"`
+	SOLBalance    float64   `gorm:"type:decimal(20,9)" json:"sol_balance"`
+	SOLValueUSD   float64   `gorm:"type:decimal(20,4)" json:"sol_value_usd"`
+	TokenValueUSD float64   `gorm:"type:decimal(20,4)" json:"token_value_usd"`
+	TotalValueUSD float64   `gorm:"type:decimal(20,4)" json:"total_value_usd"`
+	TokenCount    int       `json:"token_count"`
+	UnpricedCount int       `json:"unpriced_count"` // holdings without known market data
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (ps *PortfolioSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if ps.ID == uuid.Nil {
+		ps.ID = uuid.New()
+	}
+	return nil
+}
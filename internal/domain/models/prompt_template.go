@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PromptTemplate is a version of an AI system prompt. Each edit of a prompt adds a version; at most one version
+// per key and room is active. RoomID is empty for the global prompt of the use case.
+type PromptTemplate struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Key       string    `gorm:"size:50;not null;uniqueIndex:idx_prompt_templates_version,priority:1" json:"key"`
+	RoomID    string    `gorm:"size:64;not null;default:'';uniqueIndex:idx_prompt_templates_version,priority:2" json:"room_id,omitempty"`
+	Version   int       `gorm:"not null;uniqueIndex:idx_prompt_templates_version,priority:3" json:"version"`
+	Content   string    `gorm:"type:text;not null" json:"content"`
+	Active    bool      `gorm:"not null;default:false" json:"active"`
+	Note      string    `gorm:"size:200" json:"note,omitempty"`
+	CreatedBy string    `gorm:"size:64" json:"created_by,omitempty"` // admin who saved the version
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (t *PromptTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TradeRationaleSource is how the rationalized trade reached the room
+type TradeRationaleSource string
+
+const (
+	TradeRationaleSourceRecorded TradeRationaleSource = "recorded" // trade event posted by a member
+	TradeRationaleSourceDetected TradeRationaleSource = "detected" // on-chain swap of a subscribed member wallet
+)
+
+// TradeRationale is a generated one-line AI rationale of a room trade, with the tokens it cost
+type TradeRationale struct {
+	ID            uuid.UUID            `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RoomID        uuid.UUID            `gorm:"type:uuid;not null;index:idx_trade_rationales_room_created" json:"room_id"`
+	WalletAddress string               `gorm:"size:64;not null" json:"wallet_address"`
+	TokenAddress  string               `gorm:"size:64" json:"token_address"`
+	TxSignature   string               `gorm:"size:128;index" json:"tx_signature"`
+	Source        TradeRationaleSource `gorm:"type:varchar(20);not null" json:"source"`
+	Rationale     string               `gorm:"type:text;not null" json:"rationale"`
+	TokensUsed    int                  `gorm:"not null;default:0" json:"tokens_used"`
+	CreatedAt     time.Time            `gorm:"index:idx_trade_rationales_room_created" json:"created_at"`
+}
+
+func (tr *TradeRationale) BeforeCreate(tx *gorm.DB) error {
+	if tr.ID == uuid.Nil {
+		tr.ID = uuid.New()
+	}
+	return nil
+}
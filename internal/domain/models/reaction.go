@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReactionType is the kind of reaction a member leaves on shared info
+type ReactionType string
+
+const (
+	ReactionTypeFire    ReactionType = "fire"
+	ReactionTypeBear    ReactionType = "bear"
+	ReactionTypeBull    ReactionType = "bull"
+	ReactionTypeWarning ReactionType = "warning"
+)
+
+// ReactionTypes lists every reaction type in display order
+var ReactionTypes = []ReactionType{ReactionTypeFire, ReactionTypeBull, ReactionTypeBear, ReactionTypeWarning}
+
+// IsValid reports whether the reaction type is known
+func (t ReactionType) IsValid() bool {
+	for _, known := range ReactionTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Reaction is one wallet's reaction of one type on a shared info
+type Reaction struct {
+	ID            uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SharedInfoID  uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_reactions_info_wallet_type" json:"shared_info_id"`
+	WalletAddress string       `gorm:"size:64;not null;uniqueIndex:idx_reactions_info_wallet_type" json:"wallet_address"`
+	Type          ReactionType `gorm:"type:varchar(20);not null;uniqueIndex:idx_reactions_info_wallet_type" json:"type"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// ReactionCount is the number of reactions of one type on a shared info
+type ReactionCount struct {
+	Type  ReactionType `json:"type"`
+	Count int          `json:"count"`
+}
+
+func (r *Reaction) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
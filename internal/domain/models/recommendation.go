@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TokenRecommendationRecord keeps each recommendation the analysis engine issued, so it can be backtested later
+type TokenRecommendationRecord struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TokenID      uuid.UUID `gorm:"type:uuid;not null;index:idx_token_recommendation_records_token_time" json:"token_id"`
+	Action       string    `gorm:"size:10;not null" json:"action"` // buy, sell, hold
+	OverallScore float64   `gorm:"type:decimal(10,4)" json:"overall_score"`
+	RiskScore    float64   `gorm:"type:decimal(10,4)" json:"risk_score"`
+	Confidence   float64   `gorm:"type:decimal(5,4)" json:"confidence"`
+	PriceUSD     float64   `gorm:"type:decimal(30,18)" json:"price_usd"` // price when the recommendation was issued
+	CreatedAt    time.Time `gorm:"index:idx_token_recommendation_records_token_time" json:"created_at"`
+}
+
+func (trr *TokenRecommendationRecord) BeforeCreate(tx *gorm.DB) error {
+	if trr.ID == uuid.Nil {
+		trr.ID = uuid.New()
+	}
+	return nil
+}
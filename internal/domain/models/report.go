@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DigestSharerAddress is the sharer recorded on system-generated room posts
+const DigestSharerAddress = "system"
+
+// AssistantSharerAddress is the sharer recorded on room assistant answers posted to the room
+const AssistantSharerAddress = "assistant"
+
+// RoomDigest is the stored daily report of a trading room
+type RoomDigest struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RoomID            uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_room_digests_room_date" json:"room_id"`
+	DigestDate        time.Time  `gorm:"type:date;not null;uniqueIndex:idx_room_digests_room_date" json:"digest_date"`
+	TokenAddress      string     `gorm:"size:64" json:"token_address,omitempty"`
+	MemberCount       int        `json:"member_count"`
+	MemberCountChange int        `json:"member_count_change"` // relative to the previous digest
+	TradeCount        int        `json:"trade_count"`
+	TradeVolumeUSD    float64    `gorm:"type:decimal(20,4)" json:"trade_volume_usd"`
+	TopTrades         string     `gorm:"type:jsonb" json:"top_trades"` // JSON array of DigestTrade
+	TopShares         string     `gorm:"type:jsonb" json:"top_shares"` // JSON array of DigestShare
+	MarketSummary     string     `gorm:"type:text" json:"market_summary,omitempty"`
+	SharedInfoID      *uuid.UUID `gorm:"type:uuid" json:"shared_info_id,omitempty"` // sticky post carrying the digest
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// DigestTrade is a trade event summarized in a digest
+type DigestTrade struct {
+	WalletAddress string         `json:"wallet_address"`
+	TokenAddress  string         `json:"token_address"`
+	EventType     TradeEventType `json:"event_type"`
+	ValueUSD      float64        `json:"value_usd"`
+	Timestamp     time.Time      `json:"timestamp"`
+}
+
+// DigestShare is a shared info summarized in a digest
+type DigestShare struct {
+	ID            uuid.UUID      `json:"id"`
+	SharerAddress string         `json:"sharer_address"`
+	Type          SharedInfoType `json:"type"`
+	Title         string         `json:"title"`
+	LikeCount     int            `json:"like_count"`
+}
+
+func (rd *RoomDigest) BeforeCreate(tx *gorm.DB) error {
+	if rd.ID == uuid.Nil {
+		rd.ID = uuid.New()
+	}
+	return nil
+}
+
+// RoomDailyStat is one UTC day of a room's activity, rolled up from the room's event log
+type RoomDailyStat struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"-"`
+	RoomID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_room_daily_stats_room_day" json:"-"`
+	Day            time.Time `gorm:"type:date;not null;uniqueIndex:idx_room_daily_stats_room_day" json:"day"`
+	Joins          int       `gorm:"not null;default:0" json:"joins"`
+	Leaves         int       `gorm:"not null;default:0" json:"leaves"`   // left, kicked, removed and pruned members
+	Messages       int       `gorm:"not null;default:0" json:"messages"` // discussion shares
+	Shares         int       `gorm:"not null;default:0" json:"shares"`   // shares other than discussions
+	Trades         int       `gorm:"not null;default:0" json:"trades"`
+	TradeVolumeUSD float64   `gorm:"column:trade_volume_usd;type:decimal(20,4);not null;default:0" json:"trade_volume_usd"`
+	ActiveMembers  int       `gorm:"not null;default:0" json:"active_members"` // distinct wallets that shared or traded
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (rds *RoomDailyStat) BeforeCreate(tx *gorm.DB) error {
+	if rds.ID == uuid.Nil {
+		rds.ID = uuid.New()
+	}
+	return nil
+}
+
+// RoomMemberActivity is a wallet's shares and trades in a room over a period
+type RoomMemberActivity struct {
+	WalletAddress  string  `json:"wallet_address"`
+	Messages       int     `json:"messages"`
+	Shares         int     `json:"shares"`
+	Trades         int     `json:"trades"`
+	TradeVolumeUSD float64 `gorm:"column:trade_volume_usd" json:"trade_volume_usd"`
+	Activity       int     `json:"activity"` // messages, shares and trades together
+}
+
+// RoomRetention follows the wallets that joined a room over a period
+type RoomRetention struct {
+	Joined         int     `json:"joined"`
+	Retained       int     `json:"retained"`        // still members
+	Active         int     `json:"active"`          // shared or traded after the day they joined
+	RetentionRate  float64 `json:"retention_rate"`  // percent of joined wallets still members
+	ActivationRate float64 `json:"activation_rate"` // percent of joined wallets active after their first day
+}
@@ -19,7 +19,11 @@ type TradeRoom struct {
 	RecycleHours int          `gorm:"not null;default:24" json:"recycle_hours"`
 	Status       RoomStatus   `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
 	MaxMembers   int          `gorm:"not null;default:100" json:"max_members"`
-	CurrentMembers int        `gorm:"not null;default:1" json:"current_members"`
+	CurrentMembers int        `gorm:"not null;default:0" json:"current_members"`
+	AIRationale  bool         `gorm:"not null;default:false" json:"ai_rationale"` // generate AI rationales for member trades
+	PruneInactiveDays int     `gorm:"not null;default:0" json:"prune_inactive_days"` // remove members inactive this many days; 0 disables
+	PendingOwnerAddress *string  `gorm:"size:64" json:"pending_owner_address,omitempty"` // member offered ownership, until they accept or the offer expires
+	TransferExpiresAt   *time.Time `json:"transfer_expires_at,omitempty"`
 	LastActivity time.Time    `json:"last_activity"`
 	ExpiresAt    time.Time    `json:"expires_at"`
 	CreatedAt    time.Time    `json:"created_at"`
@@ -28,6 +32,7 @@ type TradeRoom struct {
 	// Relationships
 	Members      []RoomMember `gorm:"foreignKey:RoomID;references:ID" json:"members,omitempty"`
 	SharedInfos  []SharedInfo `gorm:"foreignKey:RoomID;references:ID" json:"shared_infos,omitempty"`
+	Basket       []RoomToken  `gorm:"foreignKey:RoomID;references:ID" json:"basket,omitempty"` // tokens watched besides the room's token
 }
 
 // RoomStatus represents the status of a room
@@ -40,41 +45,74 @@ const (
 )
 
 // RoomMember represents a member in a trading room
+
 type RoomMember struct {
-	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	RoomID        uuid.UUID  `gorm:"type:uuid;not null" json:"room_id"`
-	Room          TradeRoom  `gorm:"foreignKey:RoomID;references:ID" json:"room"`
-	WalletAddress string     `gorm:"size:64;not null" json:"wallet_address"`
-	JoinedAt      time.Time  `json:"joined_at"`
-	LastSeen      time.Time  `json:"last_seen"`
-	IsOnline      bool       `gorm:"default:false" json:"is_online"`
-	Role          MemberRole `gorm:"type:varchar(20);not null;default:'member'" json:"role"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	ID                 uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RoomID             uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_room_members_room_wallet,priority:1" json:"room_id"`
+	Room               TradeRoom       `gorm:"foreignKey:RoomID;references:ID" json:"room"`
+	WalletAddress      string          `gorm:"size:64;not null;uniqueIndex:idx_room_members_room_wallet,priority:2" json:"wallet_address"`
+	JoinedAt           time.Time       `json:"joined_at"`
+	LastSeen           time.Time       `json:"last_seen"`
+	IsOnline           bool            `gorm:"default:false" json:"is_online"`
+	Role               MemberRole      `gorm:"type:varchar(20);not null;default:'member'" json:"role"`
+	MessageCount       int             `gorm:"not null;default:0" json:"message_count"` // discussion shares
+	ShareCount         int             `gorm:"not null;default:0" json:"share_count"`   // shares other than discussions
+	TradeCount         int             `gorm:"not null;default:0" json:"trade_count"`   // recorded trade events
+	LastActiveAt       *time.Time      `json:"last_active_at,omitempty"`                // last message, share or trade event
+	InactivityWarnedAt *time.Time      `json:"inactivity_warned_at,omitempty"`          // set when warned about pruning, cleared by activity
+	HideTradeSizes     bool            `gorm:"not null;default:false" json:"hide_trade_sizes"` // detected trades are broadcast with a size bucket instead of amounts
+	Alias              *string         `gorm:"size:24" json:"alias,omitempty"`                 // shown in place of the address to everyone but the creator
+	ActivityScore      float64         `gorm:"-" json:"activity_score"`                 // filled when members are listed
+	SignalAccuracy     *SignalAccuracy `gorm:"-" json:"signal_accuracy,omitempty"`      // filled when members are listed
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
 }
 
 // MemberRole represents the role of a member in a room
 type MemberRole string
 
 const (
-	MemberRoleCreator MemberRole = "creator"
-	MemberRoleMember  MemberRole = "member"
+	MemberRoleCreator   MemberRole = "creator"
+	MemberRoleModerator MemberRole = "moderator" // exempt from inactivity pruning
+	MemberRoleMember    MemberRole = "member"
+)
+
+// MemberActivity is a kind of member action counted towards room activity
+type MemberActivity string
+
+const (
+	MemberActivityMessage MemberActivity = "message"
+	MemberActivityShare   MemberActivity = "share"
+	MemberActivityTrade   MemberActivity = "trade"
 )
 
+// LastActiveTime returns when the member last acted in the room, or when they joined if they never did
+func (rm *RoomMember) LastActiveTime() time.Time {
+	if rm.LastActiveAt != nil {
+		return *rm.LastActiveAt
+	}
+	return rm.JoinedAt
+}
+
+// IsPruneExempt reports whether the member is never removed for inactivity
+func (rm *RoomMember) IsPruneExempt() bool {
+	return rm.Role == MemberRoleCreator || rm.Role == MemberRoleModerator
+}
+
 // SharedInfo represents shared information in a room
 type SharedInfo struct {
 	ID          uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	RoomID      uuid.UUID       `gorm:"type:uuid;not null" json:"room_id"`
+	RoomID      uuid.UUID       `gorm:"type:uuid;not null;index:idx_shared_infos_room_sticky_created,priority:1" json:"room_id"`
 	Room        TradeRoom       `gorm:"foreignKey:RoomID;references:ID" json:"room"`
 	SharerAddress string        `gorm:"size:64;not null" json:"sharer_address"`
 	Type        SharedInfoType  `gorm:"type:varchar(50);not null" json:"type"`
 	Title       string          `gorm:"size:255;not null" json:"title"`
 	Content     string          `gorm:"type:text;not null" json:"content"`
 	Metadata    string          `gorm:"type:jsonb" json:"metadata"` // JSON metadata
-	IsSticky    bool            `gorm:"default:false" json:"is_sticky"`
+	IsSticky    bool            `gorm:"default:false;index:idx_shared_infos_room_sticky_created,priority:2" json:"is_sticky"`
 	ViewCount   int             `gorm:"default:0" json:"view_count"`
-	LikeCount   int             `gorm:"default:0" json:"like_count"`
-	CreatedAt   time.Time       `json:"created_at"`
+	LikeCount   int             `gorm:"default:0" json:"like_count"` // total reactions, see Reaction
+	CreatedAt   time.Time       `gorm:"index:idx_shared_infos_room_sticky_created,priority:3" json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 }
 
@@ -87,22 +125,31 @@ const (
 	SharedInfoTypeNews        SharedInfoType = "news"
 	SharedInfoTypeDiscussion  SharedInfoType = "discussion"
 	SharedInfoTypeAlert       SharedInfoType = "alert"
+	SharedInfoTypeDigest      SharedInfoType = "digest" // system-generated daily digest
 )
 
 // TradeEvent represents trading events in a room
 type TradeEvent struct {
-	ID            uuid.UUID   `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	RoomID        uuid.UUID   `gorm:"type:uuid;not null" json:"room_id"`
-	Room          TradeRoom   `gorm:"foreignKey:RoomID;references:ID" json:"room"`
-	WalletAddress string      `gorm:"size:64;not null" json:"wallet_address"`
-	TokenAddress  string      `gorm:"size:64;not null" json:"token_address"`
-	EventType     TradeEventType `gorm:"type:varchar(20);not null" json:"event_type"`
-	Amount        float64     `gorm:"type:decimal(20,8)" json:"amount"`
-	Price         float64     `gorm:"type:decimal(20,10)" json:"price"`
-	ValueUSD      float64     `gorm:"type:decimal(20,4)" json:"value_usd"`
-	TxSignature   string      `gorm:"size:128" json:"tx_signature"`
-	BlockTime     time.Time   `json:"block_time"`
-	CreatedAt     time.Time   `json:"created_at"`
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RoomID         uuid.UUID      `gorm:"type:uuid;not null;index:idx_trade_events_room_created,priority:1" json:"room_id"`
+	Room           TradeRoom      `gorm:"foreignKey:RoomID;references:ID" json:"room"`
+	WalletAddress  string         `gorm:"size:64;not null" json:"wallet_address"`
+	TokenAddress   string         `gorm:"size:64;not null" json:"token_address"`
+	EventType      TradeEventType `gorm:"type:varchar(20);not null" json:"event_type"`
+	Amount         float64        `gorm:"type:decimal(20,8)" json:"amount"`
+	Price          float64        `gorm:"type:decimal(20,10)" json:"price"`          // client-supplied
+	ValueUSD       float64        `gorm:"type:decimal(20,4)" json:"value_usd"`       // client-supplied
+	SOLAmount      float64        `gorm:"type:decimal(20,9)" json:"sol_amount,omitempty"` // native SOL the trade settled in, if reported
+	ServerPrice    float64        `gorm:"type:decimal(20,10)" json:"server_price"`   // market price at record time, 0 if unknown
+	ServerValueUSD float64        `gorm:"type:decimal(20,4)" json:"server_value_usd"`
+	ValueDeviation float64        `gorm:"type:decimal(10,4)" json:"value_deviation"` // relative difference between client and server value
+	ValueFlagged   bool           `gorm:"default:false" json:"value_flagged"`        // deviation exceeds the configured tolerance
+	TxSignature    string         `gorm:"size:128" json:"tx_signature"`
+	Provisional    bool           `gorm:"not null;default:false" json:"provisional"` // the signature has not reached finalized commitment yet
+	Reverted       bool           `gorm:"not null;default:false" json:"reverted"`          // the signature never finalized or failed on chain
+	Rationale      string         `gorm:"type:text" json:"rationale,omitempty"` // one-line AI rationale, if the room has them enabled
+	BlockTime      time.Time      `json:"block_time"`
+	CreatedAt      time.Time      `gorm:"index:idx_trade_events_room_created,priority:2" json:"created_at"`
 }
 
 // TradeEventType represents the type of trading event
@@ -115,6 +162,14 @@ const (
 
 // BeforeCreate hooks
 func (tr *TradeRoom) BeforeCreate(tx *gorm.DB) error {
+	if err := validateAddresses(tr.CreatorAddress); err != nil {
+		return err
+	}
+	if tr.TokenAddress != nil {
+		if err := validateAddresses(*tr.TokenAddress); err != nil {
+			return err
+		}
+	}
 	if tr.ID == uuid.Nil {
 		tr.ID = uuid.New()
 	}
@@ -127,6 +182,9 @@ func (tr *TradeRoom) BeforeCreate(tx *gorm.DB) error {
 }
 
 func (rm *RoomMember) BeforeCreate(tx *gorm.DB) error {
+	if err := validateAddresses(rm.WalletAddress); err != nil {
+		return err
+	}
 	if rm.ID == uuid.Nil {
 		rm.ID = uuid.New()
 	}
@@ -136,6 +194,11 @@ func (rm *RoomMember) BeforeCreate(tx *gorm.DB) error {
 }
 
 func (si *SharedInfo) BeforeCreate(tx *gorm.DB) error {
+	if si.SharerAddress != DigestSharerAddress && si.SharerAddress != AssistantSharerAddress {
+		if err := validateAddresses(si.SharerAddress); err != nil {
+			return err
+		}
+	}
 	if si.ID == uuid.Nil {
 		si.ID = uuid.New()
 	}
@@ -143,6 +206,9 @@ func (si *SharedInfo) BeforeCreate(tx *gorm.DB) error {
 }
 
 func (te *TradeEvent) BeforeCreate(tx *gorm.DB) error {
+	if err := validateAddresses(te.WalletAddress, te.TokenAddress); err != nil {
+		return err
+	}
 	if te.ID == uuid.Nil {
 		te.ID = uuid.New()
 	}
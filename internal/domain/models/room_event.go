@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomEventType is the kind of room lifecycle event
+type RoomEventType string
+
+const (
+	RoomEventCreated   RoomEventType = "created"   // payload: the room
+	RoomEventJoined    RoomEventType = "joined"    // payload: the member
+	RoomEventLeft      RoomEventType = "left"      // payload: RoomEventLeftPayload
+	RoomEventShare     RoomEventType = "share"     // payload: the shared info
+	RoomEventTrade     RoomEventType = "trade"     // payload: the trade event
+	RoomEventClosed    RoomEventType = "closed"    // payload: RoomEventClosedPayload
+	RoomEventBroadcast RoomEventType = "broadcast" // payload: RoomEventBroadcastPayload
+	RoomEventToken     RoomEventType = "token"     // payload: RoomEventTokenPayload
+	RoomEventBasket    RoomEventType = "basket"    // payload: RoomEventBasketPayload
+	RoomEventTransfer  RoomEventType = "transfer"  // payload: RoomEventTransferPayload
+)
+
+// RoomEvent is an entry of a room's append-only event log; Sequence orders events across all rooms
+type RoomEvent struct {
+	ID            uuid.UUID     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Sequence      int64         `gorm:"autoIncrement;uniqueIndex;not null;index:idx_room_events_room_sequence,priority:2" json:"sequence"`
+	RoomID        uuid.UUID     `gorm:"type:uuid;not null;index:idx_room_events_room_sequence,priority:1" json:"room_id"`
+	Type          RoomEventType `gorm:"type:varchar(20);not null" json:"type"`
+	WalletAddress string        `gorm:"size:64" json:"wallet_address,omitempty"` // acting wallet, empty for system events
+	Payload       string        `gorm:"type:jsonb" json:"payload"`               // JSON of the event's subject, see RoomEventType
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// RoomEventLeftPayload records why a member left
+type RoomEventLeftPayload struct {
+	WalletAddress string `json:"wallet_address"`
+	Reason        string `json:"reason"` // left, kicked, removed or pruned
+}
+
+// RoomEventClosedPayload records how a room ended
+type RoomEventClosedPayload struct {
+	Status RoomStatus `json:"status"` // closed or expired
+}
+
+// RoomEventBroadcastPayload records a message broadcast to the room through the API
+type RoomEventBroadcastPayload struct {
+	MessageType string      `json:"message_type"`
+	Data        interface{} `json:"data"`
+	Admin       string      `json:"admin,omitempty"` // the admin who sent it; the acting wallet is empty then
+}
+
+// RoomEventTokenPayload records the creator changing or clearing the room's token
+type RoomEventTokenPayload struct {
+	PreviousTokenAddress *string `json:"previous_token_address"`
+	TokenAddress         *string `json:"token_address"` // nil when the token was cleared
+}
+
+// RoomEventBasketPayload records tokens the creator added to or removed from the room's watch basket
+type RoomEventBasketPayload struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// RoomEventTransferPayload records a step of handing the room to another member
+type RoomEventTransferPayload struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Status string `json:"status"` // requested, accepted or cancelled
+}
+
+func (re *RoomEvent) BeforeCreate(tx *gorm.DB) error {
+	if re.ID == uuid.Nil {
+		re.ID = uuid.New()
+	}
+	return nil
+}
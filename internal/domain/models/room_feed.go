@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomFeedMessage is a WebSocket broadcast as sent to a room, kept so the live feed can be read back later.
+// Sequence orders messages across all rooms.
+type RoomFeedMessage struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Sequence   int64     `gorm:"autoIncrement;uniqueIndex;not null;index:idx_room_feed_messages_room_sequence,priority:2" json:"sequence"`
+	RoomID     string    `gorm:"size:20;not null;index:idx_room_feed_messages_room_sequence,priority:1" json:"room_id"` // public room ID, as WebSocket connections use it
+	Type       string    `gorm:"type:varchar(30);not null" json:"type"`
+	FromWallet string    `gorm:"size:64" json:"from,omitempty"`
+	Data       string    `gorm:"type:jsonb" json:"data"` // JSON of the message data
+	SentAt     time.Time `gorm:"not null;index" json:"sent_at"`
+}
+
+func (rfm *RoomFeedMessage) BeforeCreate(tx *gorm.DB) error {
+	if rfm.ID == uuid.Nil {
+		rfm.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomPasswordFailure audits a wrong password given to join a room
+type RoomPasswordFailure struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RoomID        uuid.UUID `gorm:"type:uuid;not null;index:idx_room_password_failures_room_created,priority:1" json:"room_id"`
+	WalletAddress string    `gorm:"size:64;not null;index" json:"wallet_address"`
+	ClientIP      string    `gorm:"size:64" json:"client_ip"`
+	Attempts      int       `gorm:"not null" json:"attempts"`   // wrong guesses counted for the wallet or IP, whichever is higher
+	LockedOut     bool      `gorm:"not null" json:"locked_out"` // this guess locked the wallet and IP out of the room
+	CreatedAt     time.Time `gorm:"index:idx_room_password_failures_room_created,priority:2" json:"created_at"`
+}
+
+func (rpf *RoomPasswordFailure) BeforeCreate(tx *gorm.DB) error {
+	if rpf.ID == uuid.Nil {
+		rpf.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomToken is a token of a room's watch basket, watched in addition to the room's own token
+type RoomToken struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RoomID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_room_tokens_room_mint" json:"room_id"`
+	MintAddress string    `gorm:"size:64;not null;uniqueIndex:idx_room_tokens_room_mint;index" json:"mint_address"`
+	AddedBy     string    `gorm:"size:64;not null" json:"added_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (rt *RoomToken) BeforeCreate(tx *gorm.DB) error {
+	if err := validateAddresses(rt.MintAddress); err != nil {
+		return err
+	}
+	if rt.ID == uuid.Nil {
+		rt.ID = uuid.New()
+	}
+	return nil
+}
+
+// BoundTokenAddresses lists the room's own token followed by its basket, without duplicates.
+// Rooms bound by token ID need Token loaded.
+func (tr *TradeRoom) BoundTokenAddresses() []string {
+	var mints []string
+	seen := make(map[string]bool)
+	add := func(mint string) {
+		if mint != "" && !seen[mint] {
+			seen[mint] = true
+			mints = append(mints, mint)
+		}
+	}
+
+	if tr.TokenAddress != nil {
+		add(*tr.TokenAddress)
+	} else if tr.Token != nil {
+		add(tr.Token.MintAddress)
+	}
+	for _, token := range tr.Basket {
+		add(token.MintAddress)
+	}
+	return mints
+}
@@ -0,0 +1,62 @@
+package models
+
+// All returns every model kept in the database, in the order the schema is migrated
+func All() []interface{} {
+	return []interface{}{
+		&Token{},
+		&TokenMarketData{},
+		&TokenMarketLatest{},
+		&TokenTrendingRanking{},
+		&TokenTopHolders{},
+		&TokenHolderSnapshot{},
+		&TokenTransactionStats{},
+		&TokenProvenance{},
+		&TokenCandle{},
+		&TokenFlag{},
+		&TokenFlagEvent{},
+		&TokenMetadataScreen{},
+		&AssetPrice{},
+		&TradeRoom{},
+		&RoomMember{},
+		&SharedInfo{},
+		&Reaction{},
+		&TradeEvent{},
+		&Trader{},
+		&SmartMoneyTransaction{},
+		&TransactionAnalysis{},
+		&WalletFollowing{},
+		&WalletLabel{},
+		&PortfolioSnapshot{},
+		&UserSettings{},
+		&RoomDigest{},
+		&DataExport{},
+		&SignalOutcome{},
+		&LiquiditySnapshot{},
+		&LiquidityAlert{},
+		&WalletFunding{},
+		&WalletLink{},
+		&WalletClusterMember{},
+		&TradeRationale{},
+		&TokenNarrativeTag{},
+		&TokenSocialMetric{},
+		&TokenRecommendationRecord{},
+		&LimitWatch{},
+		&RoomEvent{},
+		&RoomToken{},
+		&RoomDailyStat{},
+		&RoomFeedMessage{},
+		&RoomPasswordFailure{},
+		&WalletWatch{},
+		&ScreenerPreset{},
+		&TokenDiscovery{},
+		&MomentumAlert{},
+		&DexDailyStat{},
+		&TokenUnlockSchedule{},
+		&Notification{},
+		&EmailVerification{},
+		&AdminAuditLog{},
+		&AIUsage{},
+		&PromptTemplate{},
+		&FeatureFlag{},
+	}
+}
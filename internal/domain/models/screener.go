@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScreenerPreset is a named token screener a wallet saved for reuse
+type ScreenerPreset struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress string    `gorm:"size:64;not null;uniqueIndex:idx_screener_presets_wallet_name" json:"wallet_address"`
+	Name          string    `gorm:"size:100;not null;uniqueIndex:idx_screener_presets_wallet_name" json:"name"`
+	Filters       string    `gorm:"size:500;not null" json:"filters"` // screener expression, e.g. marketCap>1M,age<7d
+	Sort          string    `gorm:"size:30" json:"sort,omitempty"`
+	SortOrder     string    `gorm:"size:4" json:"order,omitempty"` // asc or desc
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (sp *ScreenerPreset) BeforeCreate(tx *gorm.DB) error {
+	if err := validateAddresses(sp.WalletAddress); err != nil {
+		return err
+	}
+	if sp.ID == uuid.Nil {
+		sp.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// IsValid reports whether the shared info type is known
+func (t SharedInfoType) IsValid() bool {
+	switch t {
+	case SharedInfoTypeAnalysis, SharedInfoTypeSignal, SharedInfoTypeNews,
+		SharedInfoTypeDiscussion, SharedInfoTypeAlert, SharedInfoTypeDigest:
+		return true
+	}
+	return false
+}
+
+// IsUserShareable reports whether members may share this type; digests are system-generated
+func (t SharedInfoType) IsUserShareable() bool {
+	return t.IsValid() && t != SharedInfoTypeDigest
+}
+
+// SignalSide is the trade direction of a signal
+type SignalSide string
+
+const (
+	SignalSideBuy  SignalSide = "buy"
+	SignalSideSell SignalSide = "sell"
+)
+
+// AlertSeverity is the urgency of an alert
+type AlertSeverity string
+
+const (
+	AlertSeverityInfo     AlertSeverity = "info"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityCritical AlertSeverity = "critical"
+)
+
+// SignalPayload is the required metadata of a signal share
+type SignalPayload struct {
+	Token  string     `json:"token"` // mint address
+	Side   SignalSide `json:"side"`
+	Entry  float64    `json:"entry"`  // USD price
+	Target float64    `json:"target"` // USD price
+	Stop   float64    `json:"stop"`   // USD price
+}
+
+// Validate checks the signal fields and that target and stop lie on the right side of entry
+func (p *SignalPayload) Validate() error {
+	if p.Token == "" {
+		return errors.New("signal token is required")
+	}
+	if p.Entry <= 0 || p.Target <= 0 || p.Stop <= 0 {
+		return errors.New("signal entry, target and stop must be positive")
+	}
+	switch p.Side {
+	case SignalSideBuy:
+		if !(p.Stop < p.Entry && p.Entry < p.Target) {
+			return errors.New("buy signal requires stop < entry < target")
+		}
+	case SignalSideSell:
+		if !(p.Target < p.Entry && p.Entry < p.Stop) {
+			return errors.New("sell signal requires target < entry < stop")
+		}
+	default:
+		return fmt.Errorf("signal side must be %q or %q", SignalSideBuy, SignalSideSell)
+	}
+	return nil
+}
+
+// AlertPayload is the required metadata of an alert share
+type AlertPayload struct {
+	Token    string        `json:"token"` // mint address
+	Severity AlertSeverity `json:"severity"`
+}
+
+// Validate checks the alert fields
+func (p *AlertPayload) Validate() error {
+	if p.Token == "" {
+		return errors.New("alert token is required")
+	}
+	switch p.Severity {
+	case AlertSeverityInfo, AlertSeverityWarning, AlertSeverityCritical:
+		return nil
+	}
+	return fmt.Errorf("alert severity must be %q, %q or %q", AlertSeverityInfo, AlertSeverityWarning, AlertSeverityCritical)
+}
+
+// payloadValidator is implemented by the typed metadata of structured shared info types
+type payloadValidator interface {
+	Validate() error
+}
+
+// newPayload returns an empty typed payload for types with a schema, nil otherwise
+func newPayload(t SharedInfoType) payloadValidator {
+	switch t {
+	case SharedInfoTypeSignal:
+		return &SignalPayload{}
+	case SharedInfoTypeAlert:
+		return &AlertPayload{}
+	}
+	return nil
+}
+
+// ValidateSharedInfoMetadata checks JSON metadata against the schema of the shared info type.
+// Types without a schema accept any metadata.
+func ValidateSharedInfoMetadata(t SharedInfoType, metadata string) error {
+	payload := newPayload(t)
+	if payload == nil {
+		return nil
+	}
+	if metadata == "" {
+		return fmt.Errorf("%s metadata is required", t)
+	}
+	if err := json.Unmarshal([]byte(metadata), payload); err != nil {
+		return fmt.Errorf("invalid %s metadata: %w", t, err)
+	}
+	return payload.Validate()
+}
+
+// SignalPayload decodes the metadata of a signal share
+func (si *SharedInfo) SignalPayload() (*SignalPayload, error) {
+	if si.Type != SharedInfoTypeSignal {
+		return nil, fmt.Errorf("shared info is a %s, not a signal", si.Type)
+	}
+	var payload SignalPayload
+	if err := json.Unmarshal([]byte(si.Metadata), &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// AlertPayload decodes the metadata of an alert share
+func (si *SharedInfo) AlertPayload() (*AlertPayload, error) {
+	if si.Type != SharedInfoTypeAlert {
+		return nil, fmt.Errorf("shared info is a %s, not an alert", si.Type)
+	}
+	var payload AlertPayload
+	if err := json.Unmarshal([]byte(si.Metadata), &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
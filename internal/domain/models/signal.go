@@ -0,0 +1,123 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SignalResult is the outcome of a tracked signal
+type SignalResult string
+
+const (
+	SignalResultPending  SignalResult = "pending"
+	SignalResultHit      SignalResult = "hit"      // target reached at a checkpoint
+	SignalResultStopped  SignalResult = "stopped"  // stop reached at a checkpoint
+	SignalResultMissed   SignalResult = "missed"   // neither reached by the last checkpoint
+	SignalResultUnpriced SignalResult = "unpriced" // no price was available at any checkpoint
+)
+
+// SignalCheckpoints are the delays after sharing at which a signal is evaluated
+var SignalCheckpoints = []time.Duration{time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+
+// SignalOutcome tracks the price performance of a shared signal
+type SignalOutcome struct {
+	ID            uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SharedInfoID  uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex" json:"shared_info_id"`
+	RoomID        uuid.UUID    `gorm:"type:uuid;not null" json:"room_id"`
+	SharerAddress string       `gorm:"size:64;not null;index" json:"sharer_address"`
+	TokenAddress  string       `gorm:"size:64;not null" json:"token_address"`
+	Side          SignalSide   `gorm:"type:varchar(10);not null" json:"side"`
+	Entry         float64      `gorm:"type:decimal(30,12)" json:"entry"`
+	Target        float64      `gorm:"type:decimal(30,12)" json:"target"`
+	Stop          float64      `gorm:"type:decimal(30,12)" json:"stop"`
+	SharePrice    *float64     `gorm:"type:decimal(30,12)" json:"share_price"` // market price when shared, if known
+	SharedAt      time.Time    `gorm:"not null" json:"shared_at"`
+	Price1h       *float64     `gorm:"column:price_1h;type:decimal(30,12)" json:"price_1h"`
+	Price24h      *float64     `gorm:"column:price_24h;type:decimal(30,12)" json:"price_24h"`
+	Price7d       *float64     `gorm:"column:price_7d;type:decimal(30,12)" json:"price_7d"`
+	Checkpoints   int          `gorm:"default:0" json:"checkpoints"`         // number of SignalCheckpoints evaluated
+	NextCheckAt   *time.Time   `gorm:"index" json:"next_check_at,omitempty"` // nil once every checkpoint is evaluated
+	Result        SignalResult `gorm:"type:varchar(20);not null;default:'pending';index" json:"result"`
+	ResolvedAt    *time.Time   `json:"resolved_at,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// checkpointPrice returns a pointer to the price field of checkpoint i
+func (so *SignalOutcome) checkpointPrice(i int) **float64 {
+	switch i {
+	case 0:
+		return &so.Price1h
+	case 1:
+		return &so.Price24h
+	default:
+		return &so.Price7d
+	}
+}
+
+// Evaluate records the price seen at the next checkpoint and resolves the signal once
+// the price crosses its target or stop. A nil price leaves the checkpoint unpriced.
+func (so *SignalOutcome) Evaluate(price *float64, now time.Time) {
+	*so.checkpointPrice(so.Checkpoints) = price
+	so.Checkpoints++
+	so.scheduleNextCheck()
+
+	if so.Result != SignalResultPending {
+		return
+	}
+
+	if price != nil {
+		switch {
+		case so.Side == SignalSideBuy && *price >= so.Target, so.Side == SignalSideSell && *price <= so.Target:
+			so.resolve(SignalResultHit, now)
+		case so.Side == SignalSideBuy && *price <= so.Stop, so.Side == SignalSideSell && *price >= so.Stop:
+			so.resolve(SignalResultStopped, now)
+		}
+	}
+
+	if so.Result == SignalResultPending && so.Checkpoints >= len(SignalCheckpoints) {
+		if so.Price1h == nil && so.Price24h == nil && so.Price7d == nil {
+			so.resolve(SignalResultUnpriced, now)
+		} else {
+			so.resolve(SignalResultMissed, now)
+		}
+	}
+}
+
+// scheduleNextCheck sets NextCheckAt to the next unevaluated checkpoint
+func (so *SignalOutcome) scheduleNextCheck() {
+	if so.Checkpoints >= len(SignalCheckpoints) {
+		so.NextCheckAt = nil
+		return
+	}
+	next := so.SharedAt.Add(SignalCheckpoints[so.Checkpoints])
+	so.NextCheckAt = &next
+}
+
+func (so *SignalOutcome) resolve(result SignalResult, now time.Time) {
+	so.Result = result
+	so.ResolvedAt = &now
+}
+
+// SignalAccuracy summarizes the resolved signals of a sharer
+type SignalAccuracy struct {
+	SharerAddress string  `json:"sharer_address"`
+	Signals       int     `json:"signals"` // all tracked signals
+	Pending       int     `json:"pending"`
+	Hits          int     `json:"hits"`
+	Stopped       int     `json:"stopped"`
+	Missed        int     `json:"missed"`
+	HitRate       float64 `json:"hit_rate"` // hits over hit, stopped and missed signals
+}
+
+func (so *SignalOutcome) BeforeCreate(tx *gorm.DB) error {
+	if so.ID == uuid.Nil {
+		so.ID = uuid.New()
+	}
+	if so.NextCheckAt == nil && so.Checkpoints == 0 {
+		so.scheduleNextCheck()
+	}
+	return nil
+}
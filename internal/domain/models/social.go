@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TokenSocialMetric counts social media mentions of a token during one hour, as reported by one provider
+type TokenSocialMetric struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	MintAddress      string    `gorm:"size:64;not null;uniqueIndex:idx_token_social_metrics_mint_hour_provider" json:"mint_address"`
+	Hour             time.Time `gorm:"not null;uniqueIndex:idx_token_social_metrics_mint_hour_provider;index" json:"hour"` // start of the hour, UTC
+	Provider         string    `gorm:"size:30;not null;uniqueIndex:idx_token_social_metrics_mint_hour_provider" json:"provider"`
+	Mentions         int       `gorm:"not null;default:0" json:"mentions"`
+	PositiveMentions int       `gorm:"not null;default:0" json:"positive_mentions"`
+	NegativeMentions int       `gorm:"not null;default:0" json:"negative_mentions"`
+	SentimentScore   float64   `gorm:"type:decimal(5,4)" json:"sentiment_score"` // -1 to 1
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TokenSocialSummary aggregates a token's social metrics over a period
+type TokenSocialSummary struct {
+	Mentions       int     `json:"mentions"`
+	SentimentScore float64 `json:"sentiment_score"` // mention-weighted, -1 to 1
+	Hours          int     `json:"hours"`           // hours with data
+}
+
+func (tsm *TokenSocialMetric) BeforeCreate(tx *gorm.DB) error {
+	if tsm.ID == uuid.Nil {
+		tsm.ID = uuid.New()
+	}
+	return nil
+}
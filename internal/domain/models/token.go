@@ -14,13 +14,49 @@ type Token struct {
 	Symbol      string    `gorm:"size:50" json:"symbol"`
 	Name        string    `gorm:"size:255" json:"name"`
 	Decimals    int       `gorm:"not null;default:9" json:"decimals"`
-	LogoURI     string    `gorm:"size:500" json:"logo_uri"`
-	Description string    `gorm:"type:text" json:"description"`
-	Website     string    `gorm:"size:500" json:"website"`
-	Twitter     string    `gorm:"size:500" json:"twitter"`
-	Telegram    string    `gorm:"size:500" json:"telegram"`
+	LogoURI     *string   `gorm:"size:500" json:"logo_uri"` // nullable metadata, nil when unknown
+	Description *string   `gorm:"type:text" json:"description"`
+	Website     *string   `gorm:"size:500" json:"website"`
+	Twitter     *string   `gorm:"size:500" json:"twitter"`
+	Telegram    *string   `gorm:"size:500" json:"telegram"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	
+	// Last re-fetch of the metadata above, nil if not refreshed since creation
+	MetadataRefreshedAt *time.Time `json:"metadata_refreshed_at,omitempty"`
+	
+	// Pump.fun launch state, empty for tokens not launched on Pump.fun
+	BondingCurveStage    BondingCurveStage `gorm:"size:20" json:"bonding_curve_stage,omitempty"`
+	BondingCurveProgress float64           `gorm:"type:decimal(7,4);default:0" json:"bonding_curve_progress"` // percent of the curve sold
+	GraduatedTo          string            `gorm:"size:50" json:"graduated_to,omitempty"`                     // market the liquidity migrated to
+	GraduatedAt          *time.Time        `json:"graduated_at,omitempty"`                                    // when the graduation was detected
+	
+	Flag       *TokenFlag       `gorm:"-" json:"flag,omitempty"`       // active scam/honeypot flag, if any
+	Narratives []TokenNarrative `gorm:"-" json:"narratives,omitempty"` // filled on read
+}
+
+// BondingCurveStage is where a Pump.fun token is in its launch
+type BondingCurveStage string
+
+const (
+	BondingCurveStageBonding   BondingCurveStage = "bonding"   // trading on the bonding curve
+	BondingCurveStageGraduated BondingCurveStage = "graduated" // curve completed, liquidity migrated to an AMM
+)
+
+// NullableString returns nil for an empty string, so missing metadata is stored as NULL
+func NullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// StringValue returns the value of a nullable column, or "" when it is NULL
+func StringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
 // TokenMarketData represents real-time market data for tokens
@@ -42,9 +78,94 @@ type TokenMarketData struct {
 	MaxSupply         float64   `gorm:"type:decimal(20,4)" json:"max_supply"`
 	ATH               float64   `gorm:"type:decimal(20,10)" json:"ath"`
 	ATL               float64   `gorm:"type:decimal(20,10)" json:"atl"`
+	HolderCount       int       `json:"holder_count"`
 	LastUpdated       time.Time `json:"last_updated"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
+	
+	Reference *ReferencePrice `gorm:"-" json:"reference,omitempty"` // oracle check of the price, for tokens with a reference feed
+}
+
+// TokenMarketLatest is a copy of each token's most recent market data row, kept up to date on every write so
+// lookups and joins by token need not search the history
+type TokenMarketLatest struct {
+	TokenID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	MarketDataID      uuid.UUID `gorm:"type:uuid;not null"` // the token_market_data row copied
+	Price             float64   `gorm:"type:decimal(20,10)"`
+	PriceUSD          float64   `gorm:"type:decimal(20,10)"`
+	Volume24h         float64   `gorm:"type:decimal(20,4)"`
+	VolumeChange24h   float64   `gorm:"type:decimal(10,4)"`
+	MarketCap         float64   `gorm:"type:decimal(20,4)"`
+	MarketCapRank     int
+	PriceChange1h     float64 `gorm:"type:decimal(10,4)"`
+	PriceChange24h    float64 `gorm:"type:decimal(10,4)"`
+	PriceChange7d     float64 `gorm:"type:decimal(10,4)"`
+	CirculatingSupply float64 `gorm:"type:decimal(20,4)"`
+	TotalSupply       float64 `gorm:"type:decimal(20,4)"`
+	MaxSupply         float64 `gorm:"type:decimal(20,4)"`
+	ATH               float64 `gorm:"type:decimal(20,10)"`
+	ATL               float64 `gorm:"type:decimal(20,10)"`
+	HolderCount       int
+	LastUpdated       time.Time
+	CreatedAt         time.Time // of the copied row
+	UpdatedAt         time.Time `gorm:"index"`
+}
+
+// TableName keeps the table name singular, like the market data history
+func (TokenMarketLatest) TableName() string {
+	return "token_market_latest"
+}
+
+// NewTokenMarketLatest copies a stored market data row
+func NewTokenMarketLatest(data *TokenMarketData) *TokenMarketLatest {
+	return &TokenMarketLatest{
+		TokenID:           data.TokenID,
+		MarketDataID:      data.ID,
+		Price:             data.Price,
+		PriceUSD:          data.PriceUSD,
+		Volume24h:         data.Volume24h,
+		VolumeChange24h:   data.VolumeChange24h,
+		MarketCap:         data.MarketCap,
+		MarketCapRank:     data.MarketCapRank,
+		PriceChange1h:     data.PriceChange1h,
+		PriceChange24h:    data.PriceChange24h,
+		PriceChange7d:     data.PriceChange7d,
+		CirculatingSupply: data.CirculatingSupply,
+		TotalSupply:       data.TotalSupply,
+		MaxSupply:         data.MaxSupply,
+		ATH:               data.ATH,
+		ATL:               data.ATL,
+		HolderCount:       data.HolderCount,
+		LastUpdated:       data.LastUpdated,
+		CreatedAt:         data.CreatedAt,
+		UpdatedAt:         data.UpdatedAt,
+	}
+}
+
+// MarketData returns the copied row as market data
+func (l *TokenMarketLatest) MarketData() *TokenMarketData {
+	return &TokenMarketData{
+		ID:                l.MarketDataID,
+		TokenID:           l.TokenID,
+		Price:             l.Price,
+		PriceUSD:          l.PriceUSD,
+		Volume24h:         l.Volume24h,
+		VolumeChange24h:   l.VolumeChange24h,
+		MarketCap:         l.MarketCap,
+		MarketCapRank:     l.MarketCapRank,
+		PriceChange1h:     l.PriceChange1h,
+		PriceChange24h:    l.PriceChange24h,
+		PriceChange7d:     l.PriceChange7d,
+		CirculatingSupply: l.CirculatingSupply,
+		TotalSupply:       l.TotalSupply,
+		MaxSupply:         l.MaxSupply,
+		ATH:               l.ATH,
+		ATL:               l.ATL,
+		HolderCount:       l.HolderCount,
+		LastUpdated:       l.LastUpdated,
+		CreatedAt:         l.CreatedAt,
+		UpdatedAt:         l.UpdatedAt,
+	}
 }
 
 // TokenTrendingRanking represents trending token rankings
@@ -56,6 +177,7 @@ type TokenTrendingRanking struct {
 	Category    string    `gorm:"size:50;not null" json:"category"` // trending, volume, latest
 	Timeframe   string    `gorm:"size:10;not null" json:"timeframe"` // 1h, 24h, 7d
 	Score       float64   `gorm:"type:decimal(10,4)" json:"score"`
+	PriceUSD    float64   `gorm:"type:decimal(30,18)" json:"price_usd"` // price when ranked, to compare rank moves with price moves
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -66,19 +188,34 @@ type TokenTopHolders struct {
 	TokenID         uuid.UUID `gorm:"type:uuid;not null" json:"token_id"`
 	Token           Token     `gorm:"foreignKey:TokenID;references:ID" json:"token"`
 	HolderAddress   string    `gorm:"size:64;not null" json:"holder_address"`
-	Balance         float64   `gorm:"type:decimal(20,4)" json:"balance"`
-	Percentage      float64   `gorm:"type:decimal(6,4)" json:"percentage"`
+	Balance         float64   `gorm:"type:decimal(20,4)" json:"balance"`    // raw amount as reported, not adjusted for decimals
+	UIBalance       float64   `gorm:"type:decimal(30,9)" json:"ui_balance"` // balance in whole tokens
+	ValueUSD        float64   `gorm:"type:decimal(20,2)" json:"value_usd"`  // ui balance at the token price when written
+	Percentage      float64   `gorm:"type:decimal(6,4)" json:"percentage"`  // percent of total supply
 	Rank            int       `gorm:"not null" json:"rank"`
+	Labels          []string  `gorm:"-" json:"labels,omitempty"`   // known entity labels, filled on read
+	Excluded        bool      `gorm:"-" json:"excluded,omitempty"` // exchange or program account, left out of concentration metrics
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// TokenHolderSnapshot is one top holder as seen by a holder sync; rows of a sync share TakenAt
+type TokenHolderSnapshot struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TokenID       uuid.UUID `gorm:"type:uuid;not null;index:idx_token_holder_snapshots_token_taken" json:"token_id"`
+	TakenAt       time.Time `gorm:"not null;index:idx_token_holder_snapshots_token_taken" json:"taken_at"`
+	HolderAddress string    `gorm:"size:64;not null" json:"holder_address"`
+	Balance       float64   `gorm:"type:decimal(20,4)" json:"balance"`
+	Percentage    float64   `gorm:"type:decimal(6,4)" json:"percentage"`
+	Rank          int       `gorm:"not null" json:"rank"`
+}
+
 // TokenTransactionStats represents transaction statistics
 type TokenTransactionStats struct {
 	ID                uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	TokenID           uuid.UUID `gorm:"type:uuid;not null" json:"token_id"`
+	TokenID           uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_token_transaction_stats_token_timeframe" json:"token_id"`
 	Token             Token     `gorm:"foreignKey:TokenID;references:ID" json:"token"`
-	Timeframe         string    `gorm:"size:10;not null" json:"timeframe"` // 1h, 24h, 7d
+	Timeframe         string    `gorm:"size:10;not null;uniqueIndex:idx_token_transaction_stats_token_timeframe" json:"timeframe"` // 1h, 24h, 7d
 	TransactionCount  int       `json:"transaction_count"`
 	BuyCount          int       `json:"buy_count"`
 	SellCount         int       `json:"sell_count"`
@@ -91,6 +228,35 @@ type TokenTransactionStats struct {
 	UpdatedAt         time.Time `json:"updated_at"`
 }
 
+// TokenProvenance records who deployed a token mint and when it was created
+type TokenProvenance struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	MintAddress       string     `gorm:"uniqueIndex;size:64;not null" json:"mint_address"`
+	DeployerAddress   string     `gorm:"size:64;index" json:"deployer_address"`
+	Symbol            string     `gorm:"size:50" json:"symbol"`
+	Name              string     `gorm:"size:255" json:"name"`
+	CreationSignature string     `gorm:"size:128" json:"creation_signature,omitempty"` // empty until resolved on-chain
+	CreationSlot      int64      `json:"creation_slot"`
+	CreationTime      *time.Time `json:"creation_time,omitempty"`
+	DeployerSyncedAt  *time.Time `json:"deployer_synced_at,omitempty"` // last refresh of the deployer's token list
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// TokenCandle is an OHLCV price bar of a token at a given resolution
+type TokenCandle struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TokenID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_token_candles_token_res_time" json:"token_id"`
+	Resolution string    `gorm:"size:10;not null;uniqueIndex:idx_token_candles_token_res_time" json:"resolution"` // 1m, 5m, 1h, 4h, 1d
+	OpenTime   time.Time `gorm:"not null;uniqueIndex:idx_token_candles_token_res_time" json:"open_time"`
+	Open       float64   `gorm:"type:decimal(30,18)" json:"open"`
+	High       float64   `gorm:"type:decimal(30,18)" json:"high"`
+	Low        float64   `gorm:"type:decimal(30,18)" json:"low"`
+	Close      float64   `gorm:"type:decimal(30,18)" json:"close"`
+	Volume     float64   `gorm:"type:decimal(30,4)" json:"volume"` // USD
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 // BeforeCreate hook for Token
 func (t *Token) BeforeCreate(tx *gorm.DB) error {
 	if t.ID == uuid.Nil {
@@ -121,9 +287,29 @@ func (tth *TokenTopHolders) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+func (ths *TokenHolderSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if ths.ID == uuid.Nil {
+		ths.ID = uuid.New()
+	}
+	return nil
+}
+
 func (tts *TokenTransactionStats) BeforeCreate(tx *gorm.DB) error {
 	if tts.ID == uuid.Nil {
 		tts.ID = uuid.New()
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (tp *TokenProvenance) BeforeCreate(tx *gorm.DB) error {
+	if tp.ID == uuid.Nil {
+		tp.ID = uuid.New()
+	}
+	return nil
+}
+func (tc *TokenCandle) BeforeCreate(tx *gorm.DB) error {
+	if tc.ID == uuid.Nil {
+		tc.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TokenRiskLevel is the outcome of the pre-screen run on newly listed tokens
+type TokenRiskLevel string
+
+const (
+	TokenRiskLow    TokenRiskLevel = "low"
+	TokenRiskMedium TokenRiskLevel = "medium"
+	TokenRiskHigh   TokenRiskLevel = "high"
+)
+
+// IsValid reports whether l is a known risk level
+func (l TokenRiskLevel) IsValid() bool {
+	switch l {
+	case TokenRiskLow, TokenRiskMedium, TokenRiskHigh:
+		return true
+	}
+	return false
+}
+
+// TokenDiscovery records a newly listed mint picked up from the latest-tokens feed
+type TokenDiscovery struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TokenID      uuid.UUID      `gorm:"type:uuid;not null" json:"token_id"`
+	Token        Token          `gorm:"foreignKey:TokenID;references:ID" json:"token"`
+	MintAddress  string         `gorm:"uniqueIndex;size:64;not null" json:"mint_address"`
+	PriceUSD     float64        `gorm:"type:decimal(30,18)" json:"price_usd"`
+	MarketCapUSD float64        `gorm:"type:decimal(20,4)" json:"market_cap_usd"`
+	LiquidityUSD float64        `gorm:"type:decimal(20,4)" json:"liquidity_usd"`
+	HolderCount  int            `json:"holder_count"`
+	ListedAt     *time.Time     `json:"listed_at,omitempty"` // creation time reported by the feed
+	RiskLevel    TokenRiskLevel `gorm:"size:10;not null;index" json:"risk_level"`
+	RiskReasons  string         `gorm:"type:jsonb;not null;default:'[]'" json:"risk_reasons"` // JSON array of pre-screen findings
+	CreatedAt    time.Time      `gorm:"index" json:"created_at"`                              // when the mint was discovered
+}
+
+func (td *TokenDiscovery) BeforeCreate(tx *gorm.DB) error {
+	if err := validateAddresses(td.MintAddress); err != nil {
+		return err
+	}
+	if td.ID == uuid.Nil {
+		td.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TokenFlagType is the kind of problem a token is flagged for
+type TokenFlagType string
+
+const (
+	TokenFlagScam     TokenFlagType = "scam"
+	TokenFlagHoneypot TokenFlagType = "honeypot"
+	TokenFlagRug      TokenFlagType = "rug"
+)
+
+// IsValid reports whether the flag type is one of the supported values
+func (t TokenFlagType) IsValid() bool {
+	switch t {
+	case TokenFlagScam, TokenFlagHoneypot, TokenFlagRug:
+		return true
+	}
+	return false
+}
+
+// TokenFlagSource tells who raised a flag
+type TokenFlagSource string
+
+const (
+	TokenFlagSourceAdmin     TokenFlagSource = "admin"
+	TokenFlagSourceHeuristic TokenFlagSource = "heuristic"
+)
+
+// TokenFlagAction is a change recorded in a token's flag history
+type TokenFlagAction string
+
+const (
+	TokenFlagActionFlagged TokenFlagAction = "flagged"
+	TokenFlagActionCleared TokenFlagAction = "cleared"
+)
+
+// TokenFlag is the current flag state of a token mint; cleared flags are kept inactive
+type TokenFlag struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	MintAddress string          `gorm:"uniqueIndex;size:64;not null" json:"mint_address"`
+	Type        TokenFlagType   `gorm:"type:varchar(20);not null" json:"type"`
+	Reason      string          `gorm:"type:text" json:"reason"`
+	Source      TokenFlagSource `gorm:"type:varchar(20);not null" json:"source"`
+	FlaggedBy   string          `gorm:"size:100" json:"flagged_by,omitempty"`
+	Active      bool            `gorm:"not null;default:true;index" json:"active"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// TokenFlagEvent is one entry of a token's flag reason history
+type TokenFlagEvent struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	MintAddress string          `gorm:"size:64;not null;index" json:"mint_address"`
+	Action      TokenFlagAction `gorm:"type:varchar(20);not null" json:"action"`
+	Type        TokenFlagType   `gorm:"type:varchar(20);not null" json:"type"`
+	Reason      string          `gorm:"type:text" json:"reason"`
+	Source      TokenFlagSource `gorm:"type:varchar(20);not null" json:"source"`
+	Actor       string          `gorm:"size:100" json:"actor,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func (tf *TokenFlag) BeforeCreate(tx *gorm.DB) error {
+	if tf.ID == uuid.Nil {
+		tf.ID = uuid.New()
+	}
+	return nil
+}
+
+func (tfe *TokenFlagEvent) BeforeCreate(tx *gorm.DB) error {
+	if tfe.ID == uuid.Nil {
+		tfe.ID = uuid.New()
+	}
+	return nil
+}
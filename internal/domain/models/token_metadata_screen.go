@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MetadataSignalKind is a kind of scam indication found in a token's metadata
+type MetadataSignalKind string
+
+const (
+	MetadataSignalCopied        MetadataSignalKind = "copied_metadata" // description or links reused from another token
+	MetadataSignalImpersonation MetadataSignalKind = "impersonation"   // symbol or name of a major project on another mint
+	MetadataSignalSuspiciousURL MetadataSignalKind = "suspicious_url"
+	MetadataSignalAI            MetadataSignalKind = "ai_suspicious" // judged deceptive by the AI classifier
+)
+
+// MetadataSignal is one finding of a metadata screen
+type MetadataSignal struct {
+	Kind   MetadataSignalKind `json:"kind"`
+	Detail string             `json:"detail"`
+}
+
+// TokenMetadataScreen is the outcome of screening a token's description, website and socials when it was ingested
+type TokenMetadataScreen struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	MintAddress string    `gorm:"uniqueIndex;size:64;not null" json:"mint_address"`
+	Signals     string    `gorm:"type:jsonb;not null;default:'[]'" json:"signals"` // JSON array of MetadataSignal
+	Suspicious  bool      `gorm:"not null;default:false;index" json:"suspicious"`  // whether the signals flagged the token as a scam
+	AIChecked   bool      `gorm:"not null;default:false" json:"ai_checked"`        // whether the AI classifier gave a verdict
+	ScreenedAt  time.Time `json:"screened_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (tms *TokenMetadataScreen) BeforeCreate(tx *gorm.DB) error {
+	if tms.ID == uuid.Nil {
+		tms.ID = uuid.New()
+	}
+	return nil
+}
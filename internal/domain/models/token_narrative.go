@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TokenNarrative is a market narrative a token belongs to
+type TokenNarrative string
+
+const (
+	TokenNarrativeMeme   TokenNarrative = "meme"
+	TokenNarrativeDeFi   TokenNarrative = "defi"
+	TokenNarrativeAI     TokenNarrative = "ai"
+	TokenNarrativeInfra  TokenNarrative = "infra"
+	TokenNarrativeGaming TokenNarrative = "gaming"
+	TokenNarrativeOther  TokenNarrative = "other" // fits none of the above
+)
+
+// TokenNarratives lists the narrative taxonomy in display order
+var TokenNarratives = []TokenNarrative{
+	TokenNarrativeMeme,
+	TokenNarrativeDeFi,
+	TokenNarrativeAI,
+	TokenNarrativeInfra,
+	TokenNarrativeGaming,
+	TokenNarrativeOther,
+}
+
+func (n TokenNarrative) IsValid() bool {
+	for _, narrative := range TokenNarratives {
+		if n == narrative {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenNarrativeSource is how a token was tagged with a narrative
+type TokenNarrativeSource string
+
+const (
+	TokenNarrativeSourceAI        TokenNarrativeSource = "ai"
+	TokenNarrativeSourceHeuristic TokenNarrativeSource = "heuristic" // keyword match on symbol, name and description
+)
+
+// TokenNarrativeTag assigns a narrative to a token
+type TokenNarrativeTag struct {
+	ID        uuid.UUID            `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TokenID   uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_token_narrative_tags_token_narrative" json:"token_id"`
+	Narrative TokenNarrative       `gorm:"type:varchar(20);not null;uniqueIndex:idx_token_narrative_tags_token_narrative;index" json:"narrative"`
+	Source    TokenNarrativeSource `gorm:"type:varchar(20);not null" json:"source"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+func (tnt *TokenNarrativeTag) BeforeCreate(tx *gorm.DB) error {
+	if tnt.ID == uuid.Nil {
+		tnt.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UnlockCategory is who receives the tokens of an unlock
+type UnlockCategory string
+
+const (
+	UnlockCategoryTeam      UnlockCategory = "team"
+	UnlockCategoryInvestors UnlockCategory = "investors"
+	UnlockCategoryEcosystem UnlockCategory = "ecosystem"
+	UnlockCategoryCommunity UnlockCategory = "community"
+	UnlockCategoryTreasury  UnlockCategory = "treasury"
+	UnlockCategoryOther     UnlockCategory = "other"
+)
+
+func (c UnlockCategory) IsValid() bool {
+	switch c {
+	case UnlockCategoryTeam, UnlockCategoryInvestors, UnlockCategoryEcosystem, UnlockCategoryCommunity,
+		UnlockCategoryTreasury, UnlockCategoryOther:
+		return true
+	}
+	return false
+}
+
+// TokenUnlockSchedule is one scheduled release of vested tokens into circulation
+type TokenUnlockSchedule struct {
+	ID              uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TokenID         uuid.UUID      `gorm:"type:uuid;not null;index" json:"token_id"`
+	Token           *Token         `gorm:"foreignKey:TokenID;references:ID" json:"token,omitempty"`
+	MintAddress     string         `gorm:"size:64;not null" json:"mint_address"`
+	UnlockAt        time.Time      `gorm:"not null;index" json:"unlock_at"`
+	Amount          float64        `gorm:"type:decimal(30,6)" json:"amount"`            // tokens released, 0 if only the share is known
+	PercentOfSupply float64        `gorm:"type:decimal(10,4)" json:"percent_of_supply"` // share of total supply released
+	Category        UnlockCategory `gorm:"size:20;not null;default:'other'" json:"category"`
+	Description     string         `gorm:"type:text" json:"description,omitempty"`
+	Source          string         `gorm:"size:255" json:"source,omitempty"` // where the schedule was taken from
+	WarnedAt        *time.Time     `json:"warned_at,omitempty"`              // when rooms were warned of the unlock
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+func (tus *TokenUnlockSchedule) BeforeCreate(tx *gorm.DB) error {
+	if tus.ID == uuid.Nil {
+		tus.ID = uuid.New()
+	}
+	return nil
+}
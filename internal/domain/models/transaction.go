@@ -12,16 +12,20 @@ type SmartMoneyTransaction struct {
 	ID               uuid.UUID              `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
 	Signature        string                 `gorm:"uniqueIndex;not null;size:128" json:"signature"`
 	Slot             int64                  `gorm:"not null" json:"slot"`
-	BlockTime        time.Time              `json:"block_time"`
-	WalletAddress    string                 `gorm:"size:64;not null;index" json:"wallet_address"`
+	BlockTime        time.Time              `gorm:"index:idx_smart_money_transactions_wallet_block_time,priority:2" json:"block_time"`
+	WalletAddress    string                 `gorm:"size:64;not null;index;index:idx_smart_money_transactions_wallet_block_time,priority:1" json:"wallet_address"`
 	TokenAddress     string                 `gorm:"size:64;not null;index" json:"token_address"`
 	TransactionType  TransactionType        `gorm:"type:varchar(20);not null" json:"transaction_type"`
 	Amount           float64                `gorm:"type:decimal(20,8)" json:"amount"`
 	Price            float64                `gorm:"type:decimal(20,10)" json:"price"`
 	ValueUSD         float64                `gorm:"type:decimal(20,4)" json:"value_usd"`
+	FeeLamports      int64                  `gorm:"default:0" json:"fee_lamports"` // network fee paid by the wallet
+	PriorityFeeLamports *int64              `json:"priority_fee_lamports"`          // part of the fee above the base fee, nil for records stored before it was tracked
+	SlippagePercent  *float64               `gorm:"type:decimal(10,4)" json:"slippage_percent"` // execution price against market price, nil when unknown
 	ProgramID        string                 `gorm:"size:64" json:"program_id"`
 	InstructionType  string                 `gorm:"size:100" json:"instruction_type"`
 	Status           TransactionStatus      `gorm:"type:varchar(20);not null;default:'success'" json:"status"`
+	Finalized        bool                   `gorm:"not null;default:true" json:"finalized"` // false until the transaction reaches finalized commitment
 	PreBalances      string                 `gorm:"type:jsonb" json:"pre_balances"`   // JSON array
 	PostBalances     string                 `gorm:"type:jsonb" json:"post_balances"`  // JSON array
 	PreTokenBalances string                 `gorm:"type:jsonb" json:"pre_token_balances"`  // JSON array
@@ -84,6 +88,7 @@ const (
 	TransactionStatusSuccess TransactionStatus = "success"
 	TransactionStatusFailed  TransactionStatus = "failed"
 	TransactionStatusPending TransactionStatus = "pending"
+	TransactionStatusReverted TransactionStatus = "reverted" // stored before finality, then dropped from the chain
 )
 
 // WalletFollowing represents wallet following relationships
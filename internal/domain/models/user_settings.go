@@ -0,0 +1,120 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultLanguage is used when neither the request nor the wallet's settings specify one
+const DefaultLanguage = "en"
+
+// SupportedLanguages maps language codes to the names used when instructing AI models
+var SupportedLanguages = map[string]string{
+	"en": "English",
+	"zh": "Simplified Chinese",
+	"es": "Spanish",
+	"ja": "Japanese",
+	"ko": "Korean",
+}
+
+// UserSettings stores per-wallet preferences
+type UserSettings struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress string    `gorm:"uniqueIndex;size:64;not null" json:"wallet_address"`
+	Language      string    `gorm:"size:10;not null;default:'en'" json:"language"`
+	Timezone      string    `gorm:"size:64;not null;default:'UTC'" json:"timezone"` // IANA name, e.g. Asia/Shanghai
+
+	// Notification preferences, applied to room WebSocket pushes
+	NotifyMemberActivity bool `gorm:"not null" json:"notify_member_activity"`
+	NotifySharedInfo     bool `gorm:"not null" json:"notify_shared_info"`
+	NotifyTradeEvents    bool `gorm:"not null" json:"notify_trade_events"`
+	NotifyMomentumAlerts bool `gorm:"not null;default:false" json:"notify_momentum_alerts"` // opt-in to trending momentum alerts of any token
+
+	HiddenTokens string `gorm:"type:jsonb;not null;default:'[]'" json:"hidden_tokens"` // JSON array of mint addresses
+
+	// Alert defaults
+	AlertMinValueUSD        float64 `gorm:"type:decimal(20,4);not null;default:0" json:"alert_min_value_usd"`         // trade events below this value are not pushed
+	AlertPriceChangePercent float64 `gorm:"type:decimal(10,4);not null;default:10" json:"alert_price_change_percent"` // default threshold for price alerts
+
+	// Notification center delivery; types left out of the routes are delivered on the WebSocket only
+	NotificationRoutes string `gorm:"type:jsonb;not null;default:'{}'" json:"notification_routes"` // JSON object of type -> channels
+	TelegramChatID     string `gorm:"size:64" json:"telegram_chat_id,omitempty"`
+	WebhookURL         string `gorm:"size:500" json:"webhook_url,omitempty"`
+	NotificationEmail  string `gorm:"size:255" json:"notification_email,omitempty"`           // verified through the email binding flow
+	EmailOptOuts       string `gorm:"type:jsonb;not null;default:'[]'" json:"email_opt_outs"` // JSON array of EmailCategory
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewDefaultUserSettings returns the settings used for wallets that have not saved any
+func NewDefaultUserSettings(walletAddress string) *UserSettings {
+	return &UserSettings{
+		WalletAddress:           walletAddress,
+		Language:                DefaultLanguage,
+		Timezone:                "UTC",
+		NotifyMemberActivity:    true,
+		NotifySharedInfo:        true,
+		NotifyTradeEvents:       true,
+		HiddenTokens:            "[]",
+		AlertPriceChangePercent: 10,
+		NotificationRoutes:      "{}",
+		EmailOptOuts:            "[]",
+	}
+}
+
+// HiddenTokenSet decodes HiddenTokens into a lookup set
+func (us *UserSettings) HiddenTokenSet() map[string]bool {
+	var tokens []string
+	set := make(map[string]bool)
+	if err := json.Unmarshal([]byte(us.HiddenTokens), &tokens); err != nil {
+		return set
+	}
+	for _, token := range tokens {
+		set[token] = true
+	}
+	return set
+}
+
+// Routes decodes NotificationRoutes over the default routing
+func (us *UserSettings) Routes() NotificationRoutes {
+	routes := DefaultNotificationRoutes()
+	var saved NotificationRoutes
+	if err := json.Unmarshal([]byte(us.NotificationRoutes), &saved); err != nil {
+		return routes
+	}
+	for notificationType, channels := range saved {
+		routes[notificationType] = channels
+	}
+	return routes
+}
+
+// EmailOptedOut reports whether the wallet opted out of emails of the category
+func (us *UserSettings) EmailOptedOut(category EmailCategory) bool {
+	var optOuts []EmailCategory
+	if err := json.Unmarshal([]byte(us.EmailOptOuts), &optOuts); err != nil {
+		return false
+	}
+	for _, optOut := range optOuts {
+		if optOut == category {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSupportedLanguage reports whether AI output can be produced in the given language
+func IsSupportedLanguage(code string) bool {
+	_, ok := SupportedLanguages[code]
+	return ok
+}
+
+func (us *UserSettings) BeforeCreate(tx *gorm.DB) error {
+	if us.ID == uuid.Nil {
+		us.ID = uuid.New()
+	}
+	return nil
+}
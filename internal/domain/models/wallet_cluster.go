@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WalletFunding records who funded a wallet and when the wallet was last scanned for related wallets
+type WalletFunding struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress    string     `gorm:"uniqueIndex;size:64;not null" json:"wallet_address"`
+	FunderAddress    string     `gorm:"size:64;index" json:"funder_address,omitempty"` // empty if the funding transaction was not found
+	FundingSignature string     `gorm:"size:128" json:"funding_signature,omitempty"`
+	FundedAt         *time.Time `json:"funded_at,omitempty"`
+	ScannedAt        time.Time  `json:"scanned_at"` // last run of the clustering heuristics for the wallet
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// WalletLinkReason is the heuristic that linked two wallets
+type WalletLinkReason string
+
+const (
+	WalletLinkFundedBy           WalletLinkReason = "funded_by"           // one wallet funded the other
+	WalletLinkCommonFunder       WalletLinkReason = "common_funder"       // both were funded by the same wallet
+	WalletLinkSynchronizedTrades WalletLinkReason = "synchronized_trades" // repeatedly traded the same tokens in the same direction within seconds
+)
+
+// WalletLink ties two wallets that are likely controlled by the same entity.
+// The pair is stored once, with WalletAddress sorting before RelatedAddress.
+type WalletLink struct {
+	ID             uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress  string           `gorm:"size:64;not null;uniqueIndex:idx_wallet_links_pair_reason" json:"wallet_address"`
+	RelatedAddress string           `gorm:"size:64;not null;uniqueIndex:idx_wallet_links_pair_reason;index" json:"related_address"`
+	Reason         WalletLinkReason `gorm:"type:varchar(30);not null;uniqueIndex:idx_wallet_links_pair_reason" json:"reason"`
+	Confidence     float64          `gorm:"type:decimal(5,4)" json:"confidence"` // 0-1
+	Evidence       string           `gorm:"type:text" json:"evidence"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// WalletClusterMember assigns a wallet to a cluster of linked wallets
+type WalletClusterMember struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ClusterID     uuid.UUID `gorm:"type:uuid;not null;index" json:"cluster_id"`
+	WalletAddress string    `gorm:"uniqueIndex;size:64;not null" json:"wallet_address"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// SynchronizedTrader is a wallet that repeatedly traded alongside another wallet
+type SynchronizedTrader struct {
+	WalletAddress string `json:"wallet_address"`
+	SharedTrades  int    `json:"shared_trades"`
+	SharedTokens  int    `json:"shared_tokens"`
+}
+
+func (wf *WalletFunding) BeforeCreate(tx *gorm.DB) error {
+	if wf.ID == uuid.Nil {
+		wf.ID = uuid.New()
+	}
+	return nil
+}
+
+func (wl *WalletLink) BeforeCreate(tx *gorm.DB) error {
+	if wl.ID == uuid.Nil {
+		wl.ID = uuid.New()
+	}
+	return nil
+}
+
+func (wcm *WalletClusterMember) BeforeCreate(tx *gorm.DB) error {
+	if wcm.ID == uuid.Nil {
+		wcm.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WalletLabel tags a wallet address with a known entity type
+type WalletLabel struct {
+	ID            uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress string          `gorm:"size:64;not null;uniqueIndex:idx_wallet_labels_wallet_label" json:"wallet_address"`
+	Label         WalletLabelType `gorm:"type:varchar(30);not null;uniqueIndex:idx_wallet_labels_wallet_label" json:"label"`
+	EntityName    string          `gorm:"size:100" json:"entity_name"` // e.g. Binance, Wintermute
+	Source        string          `gorm:"size:100" json:"source"`
+	Notes         string          `gorm:"type:text" json:"notes"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// WalletLabelType represents the kind of entity behind a wallet
+type WalletLabelType string
+
+const (
+	WalletLabelExchange     WalletLabelType = "exchange"
+	WalletLabelMarketMaker  WalletLabelType = "market_maker"
+	WalletLabelInsider      WalletLabelType = "insider"
+	WalletLabelDeployer     WalletLabelType = "deployer"
+	WalletLabelKnownScammer WalletLabelType = "known_scammer"
+	WalletLabelProgram      WalletLabelType = "program" // program-owned account such as an AMM vault or bonding curve
+)
+
+// IsValid reports whether the label type is one of the supported values
+func (t WalletLabelType) IsValid() bool {
+	switch t {
+	case WalletLabelExchange, WalletLabelMarketMaker, WalletLabelInsider, WalletLabelDeployer, WalletLabelKnownScammer, WalletLabelProgram:
+		return true
+	}
+	return false
+}
+
+func (wl *WalletLabel) BeforeCreate(tx *gorm.DB) error {
+	if err := validateAddresses(wl.WalletAddress); err != nil {
+		return err
+	}
+	if wl.ID == uuid.Nil {
+		wl.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WalletWatch follows another wallet's trades directly, outside of rooms; each trade is sent to the watching
+// wallet as a notification
+type WalletWatch struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WalletAddress  string    `gorm:"size:64;not null;uniqueIndex:idx_wallet_watches_wallet_watched,priority:1" json:"wallet_address"` // the watching wallet
+	WatchedAddress string    `gorm:"size:64;not null;uniqueIndex:idx_wallet_watches_wallet_watched,priority:2;index" json:"watched_address"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (ww *WalletWatch) BeforeCreate(tx *gorm.DB) error {
+	if ww.ID == uuid.Nil {
+		ww.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type adminAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAdminAuditRepository creates a new admin audit repository instance
+func NewAdminAuditRepository(db *gorm.DB) AdminAuditRepository {
+	return &adminAuditRepository{db: db}
+}
+
+func (r *adminAuditRepository) Create(ctx context.Context, entry *models.AdminAuditLog) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+func (r *adminAuditRepository) List(ctx context.Context, actor string, limit, offset int) ([]*models.AdminAuditLog, error) {
+	var entries []*models.AdminAuditLog
+	query := r.db.WithContext(ctx)
+	if actor != "" {
+		query = query.Where("actor = ?", actor)
+	}
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	return entries, err
+}
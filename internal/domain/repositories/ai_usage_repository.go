@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type aiUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewAIUsageRepository creates a new AI usage repository instance
+func NewAIUsageRepository(db *gorm.DB) AIUsageRepository {
+	return &aiUsageRepository{db: db}
+}
+
+func (r *aiUsageRepository) Create(ctx context.Context, usage *models.AIUsage) error {
+	return r.db.WithContext(ctx).Create(usage).Error
+}
+
+func (r *aiUsageRepository) SumTokens(ctx context.Context, walletAddress string, since time.Time) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&models.AIUsage{}).
+		Select("COALESCE(SUM(total_tokens), 0)").
+		Where("wallet_address = ? AND created_at >= ?", walletAddress, since).
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *aiUsageRepository) GetTotals(ctx context.Context, walletAddress string, since time.Time) ([]*models.AIUsageTotal, error) {
+	var totals []*models.AIUsageTotal
+	err := r.db.WithContext(ctx).
+		Model(&models.AIUsage{}).
+		Select("feature, COUNT(*) AS requests, SUM(prompt_tokens) AS prompt_tokens, SUM(completion_tokens) AS completion_tokens, SUM(total_tokens) AS total_tokens, SUM(cost_usd) AS cost_usd").
+		Where("wallet_address = ? AND created_at >= ?", walletAddress, since).
+		Group("feature").
+		Order("feature").
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *aiUsageRepository) List(ctx context.Context, walletAddress string, limit, offset int) ([]*models.AIUsage, error) {
+	var usages []*models.AIUsage
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ?", walletAddress).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&usages).Error
+	return usages, err
+}
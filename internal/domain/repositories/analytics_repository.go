@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsRepository creates a new analytics repository instance
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// priceChangeColumns maps a timeframe to its token_market_latest column as named by AutoMigrate
+var priceChangeColumns = map[string]string{
+	"1h":  "price_change1h",
+	"24h": "price_change24h",
+	"7d":  "price_change7d",
+}
+
+func (r *analyticsRepository) TopGainers(ctx context.Context, timeframe string, limit int) ([]*models.TokenGainer, error) {
+	column, ok := priceChangeColumns[timeframe]
+	if !ok {
+		return nil, fmt.Errorf("unsupported timeframe %q", timeframe)
+	}
+
+	query := fmt.Sprintf(`
+SELECT
+	tokens.id AS token_id, tokens.mint_address, tokens.symbol, tokens.name,
+	latest.price_usd, latest.price_change, latest.volume_24h, room_tokens.room_count
+FROM (
+	SELECT tokens.id AS token_id, COUNT(DISTINCT trade_rooms.id) AS room_count
+	FROM trade_rooms
+	JOIN tokens ON tokens.id = trade_rooms.token_id OR tokens.mint_address = trade_rooms.token_address
+	WHERE trade_rooms.status = @active
+	GROUP BY tokens.id
+) room_tokens
+JOIN tokens ON tokens.id = room_tokens.token_id
+JOIN (
+	SELECT token_id, price_usd, %s AS price_change, volume24h AS volume_24h
+	FROM token_market_latest
+) latest ON latest.token_id = tokens.id
+ORDER BY latest.price_change DESC
+LIMIT @limit`, column)
+
+	var gainers []*models.TokenGainer
+	err := r.db.WithContext(ctx).
+		Raw(query, map[string]interface{}{
+			"active": models.RoomStatusActive,
+			"limit":  limit,
+		}).
+		Scan(&gainers).Error
+	return gainers, err
+}
+
+// copiedTradersQuery pairs each buy with later buys of the same token by other wallets within the copy window
+const copiedTradersQuery = `
+WITH buys AS (
+	SELECT wallet_address, token_address, block_time
+	FROM trade_events
+	WHERE event_type = @event_buy AND block_time >= @since
+	UNION
+	SELECT wallet_address, token_address, block_time
+	FROM smart_money_transactions
+	WHERE transaction_type = @buy AND status = @success AND block_time >= @since
+)
+SELECT
+	leader.wallet_address,
+	COUNT(DISTINCT follower.wallet_address) AS copiers,
+	COUNT(*) AS copy_trades,
+	COALESCE(MAX(followers.count), 0) AS followers
+FROM buys leader
+JOIN buys follower
+	ON follower.token_address = leader.token_address
+	AND follower.wallet_address <> leader.wallet_address
+	AND follower.block_time > leader.block_time
+	AND follower.block_time <= leader.block_time + make_interval(secs => @window)
+LEFT JOIN (
+	SELECT following_address, COUNT(*) AS count
+	FROM wallet_followings
+	GROUP BY following_address
+) followers ON followers.following_address = leader.wallet_address
+GROUP BY leader.wallet_address
+ORDER BY copiers DESC, copy_trades DESC
+LIMIT @limit`
+
+func (r *analyticsRepository) MostCopiedTraders(ctx context.Context, since time.Time, copyWindow time.Duration, limit int) ([]*models.CopiedTrader, error) {
+	var traders []*models.CopiedTrader
+	err := r.db.WithContext(ctx).
+		Raw(copiedTradersQuery, map[string]interface{}{
+			"event_buy": models.TradeEventTypeBuy,
+			"buy":       models.TransactionTypeBuy,
+			"success":   models.TransactionStatusSuccess,
+			"since":     since,
+			"window":    copyWindow.Seconds(),
+			"limit":     limit,
+		}).
+		Scan(&traders).Error
+	return traders, err
+}
+
+// busiestRoomsQuery ranks public active rooms by trade events and member shares since a time
+const busiestRoomsQuery = `
+SELECT
+	trade_rooms.room_id, trade_rooms.token_address, trade_rooms.current_members AS members,
+	COALESCE(events.count, 0) AS trade_events,
+	COALESCE(infos.count, 0) AS shared_infos,
+	COALESCE(events.count, 0) + COALESCE(infos.count, 0) AS activity
+FROM trade_rooms
+LEFT JOIN (
+	SELECT room_id, COUNT(*) AS count FROM trade_events WHERE created_at >= @since GROUP BY room_id
+) events ON events.room_id = trade_rooms.id
+LEFT JOIN (
+	SELECT room_id, COUNT(*) AS count FROM shared_infos WHERE created_at >= @since AND type <> @digest GROUP BY room_id
+) infos ON infos.room_id = trade_rooms.id
+WHERE trade_rooms.status = @active AND trade_rooms.password IS NULL
+ORDER BY activity DESC, members DESC
+LIMIT @limit`
+
+func (r *analyticsRepository) BusiestRooms(ctx context.Context, since time.Time, limit int) ([]*models.BusyRoom, error) {
+	var rooms []*models.BusyRoom
+	err := r.db.WithContext(ctx).
+		Raw(busiestRoomsQuery, map[string]interface{}{
+			"since":  since,
+			"digest": models.SharedInfoTypeDigest,
+			"active": models.RoomStatusActive,
+			"limit":  limit,
+		}).
+		Scan(&rooms).Error
+	return rooms, err
+}
+
+// marketStatsQuery aggregates the latest market data row of every token updated since a time
+const marketStatsQuery = `
+WITH latest AS (
+	SELECT token_id, price_change24h, volume24h
+	FROM token_market_latest
+	WHERE created_at >= @since
+)
+SELECT
+	COUNT(*) AS tokens,
+	COALESCE(SUM(volume24h), 0) AS total_volume_24h,
+	COUNT(*) FILTER (WHERE price_change24h > 0) AS gainers,
+	COUNT(*) FILTER (WHERE price_change24h < 0) AS losers,
+	COALESCE(AVG(price_change24h), 0) AS avg_price_change_24h
+FROM latest`
+
+func (r *analyticsRepository) MarketStats(ctx context.Context, since time.Time) (*models.MarketStats, error) {
+	var stats models.MarketStats
+	err := r.db.WithContext(ctx).
+		Raw(marketStatsQuery, map[string]interface{}{"since": since}).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// dexDailyStatsQuery groups successful smart money trades by UTC day and platform
+const dexDailyStatsQuery = `
+SELECT
+	(block_time AT TIME ZONE 'UTC')::date AS day,
+	COALESCE(NULLIF(instruction_type, ''), 'Unknown') AS platform,
+	COUNT(*) AS trades,
+	COUNT(*) FILTER (WHERE transaction_type = @buy) AS buy_count,
+	COUNT(*) FILTER (WHERE transaction_type = @sell) AS sell_count,
+	COALESCE(SUM(value_usd), 0) AS volume_usd,
+	COUNT(DISTINCT wallet_address) AS wallets
+FROM smart_money_transactions
+WHERE status = @success AND block_time >= @from
+GROUP BY 1, 2`
+
+func (r *analyticsRepository) AggregateDexDailyStats(ctx context.Context, from time.Time) ([]*models.DexDailyStat, error) {
+	var stats []*models.DexDailyStat
+	err := r.db.WithContext(ctx).
+		Raw(dexDailyStatsQuery, map[string]interface{}{
+			"buy":     models.TransactionTypeBuy,
+			"sell":    models.TransactionTypeSell,
+			"success": models.TransactionStatusSuccess,
+			"from":    from,
+		}).
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *analyticsRepository) SaveDexDailyStats(ctx context.Context, stats []*models.DexDailyStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "day"}, {Name: "platform"}},
+			DoUpdates: clause.AssignmentColumns([]string{"trades", "buy_count", "sell_count", "volume_usd", "wallets", "updated_at"}),
+		}).
+		CreateInBatches(stats, 500).Error
+}
+
+func (r *analyticsRepository) GetDexDailyStats(ctx context.Context, from time.Time) ([]*models.DexDailyStat, error) {
+	var stats []*models.DexDailyStat
+	err := r.db.WithContext(ctx).
+		Where("day >= ?", from).
+		Order("day ASC, platform ASC").
+		Find(&stats).Error
+	return stats, err
+}
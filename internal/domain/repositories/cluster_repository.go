@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type clusterRepository struct {
+	db *gorm.DB
+}
+
+// NewClusterRepository creates a new wallet cluster repository instance
+func NewClusterRepository(db *gorm.DB) ClusterRepository {
+	return &clusterRepository{db: db}
+}
+
+// Funding methods
+func (r *clusterRepository) GetFunding(ctx context.Context, walletAddress string) (*models.WalletFunding, error) {
+	var funding models.WalletFunding
+	err := r.db.WithContext(ctx).Where("wallet_address = ?", walletAddress).First(&funding).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &funding, nil
+}
+
+func (r *clusterRepository) SaveFunding(ctx context.Context, funding *models.WalletFunding) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "wallet_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"funder_address", "funding_signature", "funded_at", "scanned_at", "updated_at"}),
+	}).Create(funding).Error
+}
+
+func (r *clusterRepository) GetFundedBy(ctx context.Context, funderAddress string, limit int) ([]*models.WalletFunding, error) {
+	var fundings []*models.WalletFunding
+	err := r.db.WithContext(ctx).
+		Where("funder_address = ?", funderAddress).
+		Order("funded_at ASC").
+		Limit(limit).
+		Find(&fundings).Error
+	return fundings, err
+}
+
+func (r *clusterRepository) GetScannedAt(ctx context.Context, walletAddresses []string) (map[string]time.Time, error) {
+	result := make(map[string]time.Time)
+	if len(walletAddresses) == 0 {
+		return result, nil
+	}
+
+	var fundings []*models.WalletFunding
+	err := r.db.WithContext(ctx).
+		Select("wallet_address", "scanned_at").
+		Where("wallet_address IN ?", walletAddresses).
+		Find(&fundings).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, funding := range fundings {
+		result[funding.WalletAddress] = funding.ScannedAt
+	}
+	return result, nil
+}
+
+// synchronizedTradersQuery pairs the wallet's trades with same-side trades of the same token by other wallets within the window
+const synchronizedTradersQuery = `
+SELECT
+	other.wallet_address,
+	COUNT(DISTINCT mine.id) AS shared_trades,
+	COUNT(DISTINCT mine.token_address) AS shared_tokens
+FROM smart_money_transactions mine
+JOIN smart_money_transactions other
+	ON other.token_address = mine.token_address
+	AND other.transaction_type = mine.transaction_type
+	AND other.wallet_address <> mine.wallet_address
+	AND other.status = @success
+	AND other.block_time BETWEEN mine.block_time - make_interval(secs => @window) AND mine.block_time + make_interval(secs => @window)
+WHERE mine.wallet_address = @wallet
+	AND mine.status = @success
+	AND mine.transaction_type IN (@buy, @sell)
+	AND mine.block_time >= @since
+GROUP BY other.wallet_address
+HAVING COUNT(DISTINCT mine.token_address) >= @min_tokens
+ORDER BY shared_tokens DESC, shared_trades DESC
+LIMIT @limit`
+
+func (r *clusterRepository) FindSynchronizedTraders(ctx context.Context, walletAddress string, since time.Time, window time.Duration, minTokens, limit int) ([]*models.SynchronizedTrader, error) {
+	var traders []*models.SynchronizedTrader
+	err := r.db.WithContext(ctx).
+		Raw(synchronizedTradersQuery, map[string]interface{}{
+			"wallet":     walletAddress,
+			"since":      since,
+			"window":     window.Seconds(),
+			"min_tokens": minTokens,
+			"limit":      limit,
+			"success":    models.TransactionStatusSuccess,
+			"buy":        models.TransactionTypeBuy,
+			"sell":       models.TransactionTypeSell,
+		}).
+		Scan(&traders).Error
+	return traders, err
+}
+
+// Link methods
+func (r *clusterRepository) SaveLink(ctx context.Context, link *models.WalletLink) error {
+	if link.RelatedAddress < link.WalletAddress {
+		link.WalletAddress, link.RelatedAddress = link.RelatedAddress, link.WalletAddress
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "wallet_address"}, {Name: "related_address"}, {Name: "reason"}},
+		DoUpdates: clause.AssignmentColumns([]string{"confidence", "evidence", "updated_at"}),
+	}).Create(link).Error
+}
+
+func (r *clusterRepository) GetLinks(ctx context.Context, walletAddress string) ([]*models.WalletLink, error) {
+	var links []*models.WalletLink
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ? OR related_address = ?", walletAddress, walletAddress).
+		Order("confidence DESC").
+		Find(&links).Error
+	return links, err
+}
+
+// Cluster methods
+func (r *clusterRepository) MergeCluster(ctx context.Context, walletAddresses []string) (uuid.UUID, error) {
+	var clusterID uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []*models.WalletClusterMember
+		if err := tx.Where("wallet_address IN ?", walletAddresses).Order("created_at ASC").Find(&existing).Error; err != nil {
+			return err
+		}
+
+		// Keep the oldest cluster and fold the others into it
+		clusterID = uuid.New()
+		if len(existing) > 0 {
+			clusterID = existing[0].ClusterID
+		}
+		merged := make(map[uuid.UUID]bool)
+		member := make(map[string]bool)
+		for _, m := range existing {
+			member[m.WalletAddress] = true
+			if m.ClusterID != clusterID && !merged[m.ClusterID] {
+				merged[m.ClusterID] = true
+				if err := tx.Model(&models.WalletClusterMember{}).
+					Where("cluster_id = ?", m.ClusterID).
+					Update("cluster_id", clusterID).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, wallet := range walletAddresses {
+			if member[wallet] {
+				continue
+			}
+			member[wallet] = true
+			if err := tx.Create(&models.WalletClusterMember{ClusterID: clusterID, WalletAddress: wallet}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return clusterID, err
+}
+
+func (r *clusterRepository) GetClusterMembers(ctx context.Context, walletAddress string) (*uuid.UUID, []string, error) {
+	var member models.WalletClusterMember
+	err := r.db.WithContext(ctx).Where("wallet_address = ?", walletAddress).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	var wallets []string
+	err = r.db.WithContext(ctx).
+		Model(&models.WalletClusterMember{}).
+		Where("cluster_id = ?", member.ClusterID).
+		Order("created_at ASC").
+		Pluck("wallet_address", &wallets).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return &member.ClusterID, wallets, nil
+}
+
+func (r *clusterRepository) GetClusterIDs(ctx context.Context, walletAddresses []string) (map[string]uuid.UUID, error) {
+	result := make(map[string]uuid.UUID)
+	if len(walletAddresses) == 0 {
+		return result, nil
+	}
+
+	var members []*models.WalletClusterMember
+	if err := r.db.WithContext(ctx).Where("wallet_address IN ?", walletAddresses).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		result[member.WalletAddress] = member.ClusterID
+	}
+	return result, nil
+}
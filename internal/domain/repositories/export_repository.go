@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type exportRepository struct {
+	db *gorm.DB
+}
+
+// NewExportRepository creates a new export repository instance
+func NewExportRepository(db *gorm.DB) ExportRepository {
+	return &exportRepository{db: db}
+}
+
+func (r *exportRepository) Create(ctx context.Context, export *models.DataExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+func (r *exportRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *exportRepository) Update(ctx context.Context, export *models.DataExport) error {
+	return r.db.WithContext(ctx).Save(export).Error
+}
+
+func (r *exportRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.DataExport{}, id).Error
+}
+
+func (r *exportRepository) GetExpired(ctx context.Context, before time.Time) ([]*models.DataExport, error) {
+	var exports []*models.DataExport
+	err := r.db.WithContext(ctx).
+		Where("expires_at < ?", before).
+		Find(&exports).Error
+	return exports, err
+}
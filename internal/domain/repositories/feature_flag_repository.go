@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type featureFlagRepository struct {
+	db *gorm.DB
+}
+
+// NewFeatureFlagRepository creates a new feature flag repository instance
+func NewFeatureFlagRepository(db *gorm.DB) FeatureFlagRepository {
+	return &featureFlagRepository{db: db}
+}
+
+func (r *featureFlagRepository) List(ctx context.Context) ([]*models.FeatureFlag, error) {
+	var flags []*models.FeatureFlag
+	err := r.db.WithContext(ctx).
+		Order("key, room_id").
+		Find(&flags).Error
+	return flags, err
+}
+
+func (r *featureFlagRepository) Set(ctx context.Context, flag *models.FeatureFlag) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}, {Name: "room_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_by", "updated_at"}),
+		}).
+		Create(flag).Error
+	if err != nil {
+		return err
+	}
+	// An existing row keeps its ID and creation time
+	return r.db.WithContext(ctx).
+		Where("key = ? AND room_id = ?", flag.Key, flag.RoomID).
+		First(flag).Error
+}
+
+func (r *featureFlagRepository) Delete(ctx context.Context, key, roomID string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("key = ? AND room_id = ?", key, roomID).
+		Delete(&models.FeatureFlag{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
@@ -13,6 +14,8 @@ type TokenRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Token, error)
 	GetByMintAddress(ctx context.Context, mintAddress string) (*models.Token, error)
 	List(ctx context.Context, limit, offset int) ([]*models.Token, error)
+	Count(ctx context.Context) (int64, error)
+	Find(ctx context.Context, filter TokenFilter, sort TokenSort, limit, offset int) ([]*models.Token, error)
 	Update(ctx context.Context, token *models.Token) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	
@@ -23,18 +26,138 @@ type TokenRepository interface {
 	
 	// Trending methods
 	CreateTrendingRanking(ctx context.Context, ranking *models.TokenTrendingRanking) error
-	GetTrendingTokens(ctx context.Context, category, timeframe string, limit int) ([]*models.TokenTrendingRanking, error)
+	GetTrendingTokens(ctx context.Context, category, timeframe string, narrative models.TokenNarrative, limit int) ([]*models.TokenTrendingRanking, error) // empty narrative matches all
 	UpdateTrendingRanking(ctx context.Context, ranking *models.TokenTrendingRanking) error
+	DeleteTrendingRankings(ctx context.Context, category, timeframe string) error
 	
 	// Top holders methods
 	CreateTopHolder(ctx context.Context, holder *models.TokenTopHolders) error
 	GetTopHolders(ctx context.Context, tokenID uuid.UUID, limit int) ([]*models.TokenTopHolders, error)
 	UpdateTopHolder(ctx context.Context, holder *models.TokenTopHolders) error
+	SaveHolderSnapshot(ctx context.Context, holders []*models.TokenHolderSnapshot) error
+	GetHolderSnapshotTimes(ctx context.Context, tokenID uuid.UUID, until time.Time, limit int) ([]time.Time, error) // newest first
+	GetHolderSnapshot(ctx context.Context, tokenID uuid.UUID, takenAt time.Time) ([]*models.TokenHolderSnapshot, error)
 	
 	// Transaction stats methods
 	CreateTransactionStats(ctx context.Context, stats *models.TokenTransactionStats) error
 	GetTransactionStats(ctx context.Context, tokenID uuid.UUID, timeframe string) (*models.TokenTransactionStats, error)
 	UpdateTransactionStats(ctx context.Context, stats *models.TokenTransactionStats) error
+	AggregateTradeStats(ctx context.Context, since time.Time) ([]*models.TokenTransactionStats, error) // counts and volumes per known token
+	SaveTransactionStats(ctx context.Context, stats []*models.TokenTransactionStats) error              // upserts by token and timeframe
+	ResetTransactionStats(ctx context.Context, timeframe string, updatedBefore time.Time) (int64, error)
+	
+	// Provenance methods
+	GetProvenance(ctx context.Context, mintAddress string) (*models.TokenProvenance, error)
+	GetProvenanceByDeployer(ctx context.Context, deployerAddress string, limit int) ([]*models.TokenProvenance, error)
+	SaveProvenance(ctx context.Context, provenance *models.TokenProvenance) error
+	
+	// Flag methods
+	GetFlag(ctx context.Context, mintAddress string) (*models.TokenFlag, error)                    // includes cleared flags
+	GetActiveFlags(ctx context.Context, mintAddresses []string) ([]*models.TokenFlag, error)
+	ListFlags(ctx context.Context, activeOnly bool, limit, offset int) ([]*models.TokenFlag, error)
+	SaveFlag(ctx context.Context, flag *models.TokenFlag, event *models.TokenFlagEvent) error     // upserts on mint and appends the event
+	GetFlagHistory(ctx context.Context, mintAddress string, limit int) ([]*models.TokenFlagEvent, error)
+	
+	// Metadata screen methods
+	GetMetadataScreen(ctx context.Context, mintAddress string) (*models.TokenMetadataScreen, error)
+	SaveMetadataScreen(ctx context.Context, screen *models.TokenMetadataScreen) error // upserts on mint
+	FindByMetadata(ctx context.Context, excludeMint, description string, links []string, limit int) ([]*models.Token, error) // tokens sharing the description or any link
+	
+	// Asset price methods
+	CreateAssetPrices(ctx context.Context, prices []*models.AssetPrice) error
+	GetLatestAssetPrice(ctx context.Context, mintAddress string) (*models.AssetPrice, error)
+	DeleteAssetPricesBefore(ctx context.Context, before time.Time) (int64, error)
+	
+	// Candle methods
+	SaveCandles(ctx context.Context, candles []*models.TokenCandle) error // upserts on token, resolution and open time
+	GetCandles(ctx context.Context, tokenID uuid.UUID, resolution string, from, to time.Time) ([]*models.TokenCandle, error)
+	
+	// Narrative methods
+	GetNarratives(ctx context.Context, tokenIDs []uuid.UUID) ([]*models.TokenNarrativeTag, error)
+	SaveNarratives(ctx context.Context, tokenID uuid.UUID, tags []*models.TokenNarrativeTag) error // replaces the token's narratives
+	
+	// Recommendation methods
+	CreateRecommendationRecord(ctx context.Context, record *models.TokenRecommendationRecord) error
+	GetRecommendationRecords(ctx context.Context, tokenID uuid.UUID, from, to time.Time) ([]*models.TokenRecommendationRecord, error) // oldest first
+	
+	// Screener preset methods
+	GetScreenerPreset(ctx context.Context, id uuid.UUID) (*models.ScreenerPreset, error)
+	ListScreenerPresets(ctx context.Context, walletAddress string) ([]*models.ScreenerPreset, error) // ordered by name
+	CountScreenerPresets(ctx context.Context, walletAddress string) (int64, error)
+	SaveScreenerPreset(ctx context.Context, preset *models.ScreenerPreset) error // upserts on wallet and name
+	DeleteScreenerPreset(ctx context.Context, id uuid.UUID) error
+	
+	// Discovery methods
+	GetDiscoveredMints(ctx context.Context, mintAddresses []string) ([]string, error)
+	CreateDiscovery(ctx context.Context, discovery *models.TokenDiscovery) (bool, error) // false if the mint was already discovered
+	ListDiscoveries(ctx context.Context, riskLevel models.TokenRiskLevel, limit, offset int) ([]*models.TokenDiscovery, error) // newest first; empty level matches all
+	
+	// Momentum alert methods
+	CreateMomentumAlert(ctx context.Context, alert *models.MomentumAlert) error
+	GetLatestMomentumAlerts(ctx context.Context, mintAddresses []string, since time.Time) ([]*models.MomentumAlert, error)
+	ListMomentumAlerts(ctx context.Context, limit, offset int) ([]*models.MomentumAlert, error) // newest first
+	
+	// Unlock schedule methods, soonest first
+	CreateUnlocks(ctx context.Context, unlocks []*models.TokenUnlockSchedule) error
+	GetUnlockByID(ctx context.Context, id uuid.UUID) (*models.TokenUnlockSchedule, error)
+	UpdateUnlock(ctx context.Context, unlock *models.TokenUnlockSchedule) error
+	DeleteUnlock(ctx context.Context, id uuid.UUID) error
+	GetUnlocksBetween(ctx context.Context, from, to time.Time, limit int) ([]*models.TokenUnlockSchedule, error) // with the token loaded
+	GetTokenUnlocks(ctx context.Context, tokenID uuid.UUID, from time.Time) ([]*models.TokenUnlockSchedule, error)
+	GetUnwarnedUnlocks(ctx context.Context, from, to time.Time, minPercent float64) ([]*models.TokenUnlockSchedule, error)
+	MarkUnlockWarned(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// TokenFilter narrows token queries; zero fields are ignored
+type TokenFilter struct {
+	Query           string // case-insensitive match on symbol or name, or an exact mint address
+	Narrative       models.TokenNarrative
+	MinLiquidityUSD float64 // compared against the latest liquidity snapshot of the mint
+	Conditions      []ScreenerCondition // all must hold
+}
+
+// ScreenerField names a token metric screener conditions compare
+type ScreenerField string
+
+const (
+	ScreenerMarketCap      ScreenerField = "marketCap"
+	ScreenerVolume24h      ScreenerField = "volume24h"
+	ScreenerPriceChange24h ScreenerField = "priceChange24h"
+	ScreenerHolderCount    ScreenerField = "holderCount"
+	ScreenerLiquidity      ScreenerField = "liquidity"
+	ScreenerAge            ScreenerField = "age" // seconds since the mint was created
+)
+
+// ScreenerCondition compares a token metric against a value, e.g. marketCap > 1000000
+type ScreenerCondition struct {
+	Field    ScreenerField `json:"field"`
+	Operator string        `json:"operator"` // >, >=, <, <= or =
+	Value    float64       `json:"value"`
+}
+
+// TokenSortField names a column token listings can be ordered by
+type TokenSortField string
+
+const (
+	TokenSortCreatedAt      TokenSortField = "created_at"
+	TokenSortMarketCap      TokenSortField = "market_cap"
+	TokenSortVolume24h      TokenSortField = "volume_24h"
+	TokenSortPriceChange24h TokenSortField = "price_change_24h"
+)
+
+// IsValid reports whether f is a supported sort field
+func (f TokenSortField) IsValid() bool {
+	switch f {
+	case TokenSortCreatedAt, TokenSortMarketCap, TokenSortVolume24h, TokenSortPriceChange24h:
+		return true
+	}
+	return false
+}
+
+// TokenSort orders token queries; market fields use each token's latest market data, tokens without any last
+type TokenSort struct {
+	Field     TokenSortField // empty sorts by creation time
+	Ascending bool
 }
 
 // RoomRepository defines the interface for room data access
@@ -44,32 +167,100 @@ type RoomRepository interface {
 	GetByRoomID(ctx context.Context, roomID string) (*models.TradeRoom, error)
 	GetByCreator(ctx context.Context, creatorAddress string, limit, offset int) ([]*models.TradeRoom, error)
 	List(ctx context.Context, status models.RoomStatus, limit, offset int) ([]*models.TradeRoom, error)
-	Update(ctx context.Context, room *models.TradeRoom) error
+	CountByStatus(ctx context.Context, status models.RoomStatus) (int64, error)
+	Update(ctx context.Context, room *models.TradeRoom) error // leaves max_members, current_members and the associations alone
+	UpdateToken(ctx context.Context, id uuid.UUID, tokenAddress *string, tokenID *uuid.UUID) error
+	UpdateMaxMembers(ctx context.Context, id uuid.UUID, maxMembers int) (bool, error) // false if the room holds more members
+	OfferTransfer(ctx context.Context, id uuid.UUID, creatorAddress, targetAddress string, expiresAt time.Time) (bool, error) // false if the wallet no longer owns the room
+	AcceptTransfer(ctx context.Context, id uuid.UUID, walletAddress string, now time.Time) (bool, error)                       // false unless the room is offered to the wallet until after now
+	CancelTransfer(ctx context.Context, id uuid.UUID, targetAddress string) (bool, error)                                     // false unless the room is offered to the target
 	Delete(ctx context.Context, id uuid.UUID) error
 	UpdateLastActivity(ctx context.Context, roomID uuid.UUID) error
-	GetExpiredRooms(ctx context.Context) ([]*models.TradeRoom, error)
+	GetExpiredRooms(ctx context.Context, now time.Time) ([]*models.TradeRoom, error) // active rooms that expired before now
+	GetActiveByToken(ctx context.Context, mintAddress string) ([]*models.TradeRoom, error) // bound by token_address, token_id or the basket
+	GetBoundTokenAddresses(ctx context.Context) ([]string, error)                          // distinct mints bound to active rooms, baskets included
+	
+	// Basket methods
+	GetBasketTokens(ctx context.Context, roomID uuid.UUID) ([]*models.RoomToken, error)
+	AddBasketTokens(ctx context.Context, tokens []*models.RoomToken) error                     // tokens already in the basket are skipped
+	RemoveBasketToken(ctx context.Context, roomID uuid.UUID, mintAddress string) (bool, error) // false if the token was not in the basket
 	
 	// Member methods
-	AddMember(ctx context.Context, member *models.RoomMember) error
+	AddMember(ctx context.Context, member *models.RoomMember) (bool, error) // false if the wallet is already a member, ErrRoomAtCapacity if the room is full
 	RemoveMember(ctx context.Context, roomID uuid.UUID, walletAddress string) error
 	GetMembers(ctx context.Context, roomID uuid.UUID) ([]*models.RoomMember, error)
 	GetMemberByAddress(ctx context.Context, roomID uuid.UUID, walletAddress string) (*models.RoomMember, error)
 	UpdateMemberStatus(ctx context.Context, roomID uuid.UUID, walletAddress string, isOnline bool) error
 	UpdateMemberLastSeen(ctx context.Context, roomID uuid.UUID, walletAddress string) error
+	UpdateMemberRole(ctx context.Context, roomID uuid.UUID, walletAddress string, role models.MemberRole) error
+	UpdateMemberPrivacy(ctx context.Context, roomID uuid.UUID, walletAddress string, hideTradeSizes bool) error
+	UpdateMemberAlias(ctx context.Context, roomID uuid.UUID, walletAddress string, alias *string) error // nil clears it
+	GetMemberByAlias(ctx context.Context, roomID uuid.UUID, alias string) (*models.RoomMember, error)    // case-insensitive
+	GetMemberAlias(ctx context.Context, roomID, walletAddress string) (*MemberAlias, error)               // by public room ID; nil if the room is not found
+	AddMembers(ctx context.Context, roomID uuid.UUID, walletAddresses []string) ([]*models.RoomMember, bool, error) // adds the wallets that are not members yet; false, adding none, if they do not all fit
+	RemoveMembers(ctx context.Context, roomID uuid.UUID, walletAddresses []string) ([]string, error)                // removes non-creator members, returning the wallets removed
+	
+	// Member activity methods; recording activity clears any inactivity warning
+	RecordMemberActivity(ctx context.Context, roomID uuid.UUID, walletAddress string, activity models.MemberActivity) error
+	GetPruningRooms(ctx context.Context) ([]*models.TradeRoom, error)                                         // active rooms with an inactivity policy
+	GetInactiveMembers(ctx context.Context, roomID uuid.UUID, before time.Time) ([]*models.RoomMember, error) // plain members without activity since before
+	MarkMemberWarned(ctx context.Context, memberID uuid.UUID, at time.Time) error
 	
 	// Shared info methods
 	CreateSharedInfo(ctx context.Context, info *models.SharedInfo) error
 	GetSharedInfos(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*models.SharedInfo, error)
+	GetSharedInfosBetween(ctx context.Context, roomID uuid.UUID, from, to time.Time) ([]*models.SharedInfo, error)
+	FindSharedInfos(ctx context.Context, filter SharedInfoFilter, limit, offset int) ([]*models.SharedInfo, error)
 	GetSharedInfoByID(ctx context.Context, id uuid.UUID) (*models.SharedInfo, error)
 	UpdateSharedInfo(ctx context.Context, info *models.SharedInfo) error
 	DeleteSharedInfo(ctx context.Context, id uuid.UUID) error
 	IncrementViewCount(ctx context.Context, id uuid.UUID) error
-	IncrementLikeCount(ctx context.Context, id uuid.UUID) error
+	
+	// Reaction methods; adding and removing keep the shared info's like_count in sync
+	AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) // false if the wallet already reacted with this type
+	RemoveReaction(ctx context.Context, infoID uuid.UUID, walletAddress string, reactionType models.ReactionType) (bool, error)
+	CountReactions(ctx context.Context, infoID uuid.UUID) ([]*models.ReactionCount, error)
+	GetWalletReactions(ctx context.Context, infoID uuid.UUID, walletAddress string) ([]models.ReactionType, error)
+	GetReactions(ctx context.Context, infoID uuid.UUID, reactionType models.ReactionType, limit, offset int) ([]*models.Reaction, error)
 	
 	// Trade event methods
 	CreateTradeEvent(ctx context.Context, event *models.TradeEvent) error
 	GetTradeEvents(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*models.TradeEvent, error)
+	GetTradeEventsBetween(ctx context.Context, roomID uuid.UUID, from, to time.Time) ([]*models.TradeEvent, error)
 	GetTradeEventsByWallet(ctx context.Context, walletAddress string, limit, offset int) ([]*models.TradeEvent, error)
+	GetProvisionalTradeEvents(ctx context.Context, limit int) ([]*models.TradeEvent, error) // oldest first, with the room loaded
+	SetTradeEventFinality(ctx context.Context, id uuid.UUID, reverted bool) error
+	
+	// Export methods, oldest first
+	CountTradeEvents(ctx context.Context, roomID uuid.UUID) (int64, error)
+	CountSharedInfos(ctx context.Context, roomID uuid.UUID) (int64, error)
+	GetTradeEventsBatch(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*models.TradeEvent, error)
+	GetSharedInfosBatch(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*models.SharedInfo, error)
+	
+	// Trade rationale methods
+	CreateTradeRationale(ctx context.Context, rationale *models.TradeRationale) error
+	SumRationaleTokens(ctx context.Context, roomID uuid.UUID, since time.Time) (int, error) // tokens spent on rationales since the given time
+	
+	// Room event methods; the log is append-only
+	AppendEvent(ctx context.Context, event *models.RoomEvent) error
+	GetEventsAfter(ctx context.Context, roomID uuid.UUID, afterSequence int64, limit int) ([]*models.RoomEvent, error) // oldest first
+	
+	// Room feed methods; the feed is keyed by public room ID
+	AppendFeedMessage(ctx context.Context, message *models.RoomFeedMessage) error
+	GetFeedBefore(ctx context.Context, roomID string, beforeSequence int64, limit int) ([]*models.RoomFeedMessage, error) // newest first; 0 starts at the latest
+	PruneFeed(ctx context.Context, before time.Time, keepPerRoom int) (int64, error)                                     // drops messages sent before the cutoff or past each room's newest keepPerRoom
+	
+	// Password audit methods
+	RecordPasswordFailure(ctx context.Context, failure *models.RoomPasswordFailure) error
+}
+
+// SharedInfoFilter narrows shared info queries; zero fields are ignored
+type SharedInfoFilter struct {
+	RoomID     *uuid.UUID
+	Type       models.SharedInfoType
+	Token      string            // metadata token of signals and alerts
+	Side       models.SignalSide // metadata side of signals
+	PublicOnly bool              // only active rooms without a password
 }
 
 // TransactionRepository defines the interface for transaction data access
@@ -84,6 +275,10 @@ type TransactionRepository interface {
 	Update(ctx context.Context, tx *models.SmartMoneyTransaction) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetRecentTransactions(ctx context.Context, hours int, limit int) ([]*models.SmartMoneyTransaction, error)
+	GetByWalletBetween(ctx context.Context, walletAddress string, from, to time.Time, limit, offset int) ([]*models.SmartMoneyTransaction, error) // oldest first
+	GetUnfinalized(ctx context.Context, limit int) ([]*models.SmartMoneyTransaction, error) // oldest first
+	SetFinality(ctx context.Context, id uuid.UUID, reverted bool) error
+	GetExecutionStats(ctx context.Context, walletAddress string, since time.Time) ([]*models.PlatformExecutionStats, error) // all wallets when walletAddress is empty
 	
 	// Analysis methods
 	CreateAnalysis(ctx context.Context, analysis *models.TransactionAnalysis) error
@@ -110,4 +305,223 @@ type TraderRepository interface {
 	GetFollowing(ctx context.Context, followerAddress string, limit, offset int) ([]*models.WalletFollowing, error)
 	GetFollowers(ctx context.Context, followingAddress string, limit, offset int) ([]*models.WalletFollowing, error)
 	IsFollowing(ctx context.Context, followerAddress, followingAddress string) (bool, error)
-}
\ No newline at end of file
+}
+
+// WalletLabelRepository defines the interface for wallet label data access
+type WalletLabelRepository interface {
+	Create(ctx context.Context, label *models.WalletLabel) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.WalletLabel, error)
+	GetByWallet(ctx context.Context, walletAddress string) ([]*models.WalletLabel, error)
+	GetByWallets(ctx context.Context, walletAddresses []string) ([]*models.WalletLabel, error)
+	List(ctx context.Context, label models.WalletLabelType, limit, offset int) ([]*models.WalletLabel, error)
+	Update(ctx context.Context, label *models.WalletLabel) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// PortfolioRepository defines the interface for portfolio snapshot data access
+type PortfolioRepository interface {
+	SaveSnapshot(ctx context.Context, snapshot *models.PortfolioSnapshot) error
+	GetSnapshot(ctx context.Context, walletAddress string, date time.Time) (*models.PortfolioSnapshot, error)
+	GetSnapshots(ctx context.Context, walletAddress string, since time.Time) ([]*models.PortfolioSnapshot, error)
+	GetTrackedWallets(ctx context.Context) ([]string, error) // wallets with followers or room memberships
+}
+
+// ReportRepository defines the interface for room digest data access
+type ReportRepository interface {
+	SaveDigest(ctx context.Context, digest *models.RoomDigest) error
+	GetDigest(ctx context.Context, roomID uuid.UUID, date time.Time) (*models.RoomDigest, error)
+	GetLatestDigest(ctx context.Context, roomID uuid.UUID) (*models.RoomDigest, error)
+	ListDigests(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*models.RoomDigest, error)
+	
+	// Room daily stats, days are UTC
+	AggregateRoomDailyStats(ctx context.Context, from time.Time) ([]*models.RoomDailyStat, error) // from room events
+	SaveRoomDailyStats(ctx context.Context, stats []*models.RoomDailyStat) error
+	GetRoomDailyStats(ctx context.Context, roomID uuid.UUID, from time.Time) ([]*models.RoomDailyStat, error) // oldest first
+	GetMostActiveMembers(ctx context.Context, roomID uuid.UUID, since time.Time, limit int) ([]*models.RoomMemberActivity, error)
+	GetRetention(ctx context.Context, roomID uuid.UUID, since time.Time) (*models.RoomRetention, error) // rates are left for the caller
+}
+
+// ExportRepository defines the interface for data export job access
+type ExportRepository interface {
+	Create(ctx context.Context, export *models.DataExport) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.DataExport, error)
+	Update(ctx context.Context, export *models.DataExport) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetExpired(ctx context.Context, before time.Time) ([]*models.DataExport, error)
+}
+
+// SignalRepository defines the interface for signal outcome data access
+type SignalRepository interface {
+	CreateOutcome(ctx context.Context, outcome *models.SignalOutcome) error // no-op if the signal is already tracked
+	UpdateOutcome(ctx context.Context, outcome *models.SignalOutcome) error
+	GetDueOutcomes(ctx context.Context, now time.Time, limit int) ([]*models.SignalOutcome, error) // next checkpoint at or before now
+	GetOutcomesBySharer(ctx context.Context, sharerAddress string, limit, offset int) ([]*models.SignalOutcome, error)
+	GetAccuracies(ctx context.Context, sharerAddresses []string) ([]*models.SignalAccuracy, error) // HitRate is left for the caller
+}
+
+// AnalyticsRepository defines the read-only analytical queries behind the dashboard endpoints
+type AnalyticsRepository interface {
+	TopGainers(ctx context.Context, timeframe string, limit int) ([]*models.TokenGainer, error) // timeframe is 1h, 24h or 7d
+	MostCopiedTraders(ctx context.Context, since time.Time, copyWindow time.Duration, limit int) ([]*models.CopiedTrader, error)
+	BusiestRooms(ctx context.Context, since time.Time, limit int) ([]*models.BusyRoom, error)
+	MarketStats(ctx context.Context, since time.Time) (*models.MarketStats, error) // over each token's latest market data
+	
+	// DEX daily stats, days are UTC
+	AggregateDexDailyStats(ctx context.Context, from time.Time) ([]*models.DexDailyStat, error) // from smart money transactions
+	SaveDexDailyStats(ctx context.Context, stats []*models.DexDailyStat) error
+	GetDexDailyStats(ctx context.Context, from time.Time) ([]*models.DexDailyStat, error) // oldest first
+}
+
+// LiquidityRepository defines the interface for pool liquidity history and alert access
+type LiquidityRepository interface {
+	CreateSnapshot(ctx context.Context, snapshot *models.LiquiditySnapshot) error
+	GetPeakSnapshot(ctx context.Context, mintAddress string, since time.Time) (*models.LiquiditySnapshot, error)
+	GetSnapshots(ctx context.Context, mintAddress string, since time.Time) ([]*models.LiquiditySnapshot, error)
+	DeleteSnapshotsBefore(ctx context.Context, before time.Time) (int64, error)
+	CreateAlert(ctx context.Context, alert *models.LiquidityAlert) error
+	GetLatestAlert(ctx context.Context, mintAddress string) (*models.LiquidityAlert, error)
+	GetAlerts(ctx context.Context, mintAddress string, limit, offset int) ([]*models.LiquidityAlert, error)
+}
+
+// LimitWatchRepository defines the interface for price limit watch data access
+type LimitWatchRepository interface {
+	Create(ctx context.Context, watch *models.LimitWatch) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.LimitWatch, error)
+	ListByWallet(ctx context.Context, walletAddress string, status models.LimitWatchStatus, limit, offset int) ([]*models.LimitWatch, error) // empty status matches all
+	CountActive(ctx context.Context, walletAddress string) (int64, error)
+	GetActiveByMint(ctx context.Context, mintAddress string, now time.Time) ([]*models.LimitWatch, error) // excludes watches expired by now
+	GetActiveMints(ctx context.Context, now time.Time) ([]string, error)                                  // distinct mints watched by active watches
+	MarkTriggered(ctx context.Context, id uuid.UUID, priceUSD float64, at time.Time) (bool, error)        // false if the watch was no longer active
+	Cancel(ctx context.Context, id uuid.UUID) (bool, error)                                               // false if the watch was no longer active
+	ExpireBefore(ctx context.Context, now time.Time) (int64, error)
+}
+
+// WalletWatchRepository defines the interface for personal wallet watch data access
+type WalletWatchRepository interface {
+	Create(ctx context.Context, watch *models.WalletWatch) (bool, error) // false if the wallet already watches the address
+	Get(ctx context.Context, walletAddress, watchedAddress string) (*models.WalletWatch, error)
+	ListByWallet(ctx context.Context, walletAddress string) ([]*models.WalletWatch, error) // newest first
+	List(ctx context.Context, limit, offset int) ([]*models.WalletWatch, error)            // oldest first
+	CountByWallet(ctx context.Context, walletAddress string) (int64, error)
+	Delete(ctx context.Context, walletAddress, watchedAddress string) (bool, error) // false if the wallet did not watch the address
+}
+
+// NotificationRepository defines the interface for notification center data access
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	UpdateChannels(ctx context.Context, id uuid.UUID, channels string) error
+	ListByWallet(ctx context.Context, walletAddress string, unreadOnly bool, limit, offset int) ([]*models.Notification, error) // newest first
+	CountUnread(ctx context.Context, walletAddress string) (int64, error)
+	MarkRead(ctx context.Context, walletAddress string, id uuid.UUID, at time.Time) (bool, error) // false if the wallet has no such notification
+	MarkAllRead(ctx context.Context, walletAddress string, at time.Time) (int64, error)
+	DeleteExpired(ctx context.Context, createdBefore, readBefore time.Time) (int64, error) // also deletes notifications read before readBefore
+}
+
+// AdminAuditRepository defines the interface for admin action audit log access
+type AdminAuditRepository interface {
+	Create(ctx context.Context, entry *models.AdminAuditLog) error
+	List(ctx context.Context, actor string, limit, offset int) ([]*models.AdminAuditLog, error) // newest first; empty actor lists all
+}
+
+// AIUsageRepository defines the interface for per-wallet AI token usage access
+type AIUsageRepository interface {
+	Create(ctx context.Context, usage *models.AIUsage) error
+	SumTokens(ctx context.Context, walletAddress string, since time.Time) (int64, error)
+	GetTotals(ctx context.Context, walletAddress string, since time.Time) ([]*models.AIUsageTotal, error) // per feature
+	List(ctx context.Context, walletAddress string, limit, offset int) ([]*models.AIUsage, error)          // newest first
+}
+
+// PromptTemplateRepository defines the interface for versioned AI prompt template access
+type PromptTemplateRepository interface {
+	CreateVersion(ctx context.Context, template *models.PromptTemplate) error // numbers the version and makes it the active one
+	GetActive(ctx context.Context, key, roomID string) (*models.PromptTemplate, error)
+	ListActive(ctx context.Context) ([]*models.PromptTemplate, error)
+	ListVersions(ctx context.Context, key, roomID string) ([]*models.PromptTemplate, error) // newest first
+	Activate(ctx context.Context, key, roomID string, version int) (*models.PromptTemplate, error)
+	Deactivate(ctx context.Context, key, roomID string) error // falls back to the global or built-in prompt
+}
+
+// FeatureFlagRepository defines the interface for runtime feature flag access
+type FeatureFlagRepository interface {
+	List(ctx context.Context) ([]*models.FeatureFlag, error)
+	Set(ctx context.Context, flag *models.FeatureFlag) error      // upserts on key and room
+	Delete(ctx context.Context, key, roomID string) (bool, error) // false if the flag was not set
+}
+
+// SocialRepository defines the interface for hourly token social metrics access
+type SocialRepository interface {
+	SaveMetric(ctx context.Context, metric *models.TokenSocialMetric) error // upserts on mint, hour and provider
+	GetMetrics(ctx context.Context, mintAddress string, since time.Time) ([]*models.TokenSocialMetric, error)
+	GetSummary(ctx context.Context, mintAddress string, since time.Time) (*models.TokenSocialSummary, error)
+	GetIngestedMints(ctx context.Context, hour time.Time, provider string) ([]string, error)
+	DeleteMetricsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ClusterRepository defines the interface for related-wallet detection data access
+type ClusterRepository interface {
+	// Funding methods
+	GetFunding(ctx context.Context, walletAddress string) (*models.WalletFunding, error)
+	SaveFunding(ctx context.Context, funding *models.WalletFunding) error // upserts on wallet_address
+	GetFundedBy(ctx context.Context, funderAddress string, limit int) ([]*models.WalletFunding, error)
+	GetScannedAt(ctx context.Context, walletAddresses []string) (map[string]time.Time, error) // wallets never scanned are omitted
+	FindSynchronizedTraders(ctx context.Context, walletAddress string, since time.Time, window time.Duration, minTokens, limit int) ([]*models.SynchronizedTrader, error)
+	
+	// Link methods
+	SaveLink(ctx context.Context, link *models.WalletLink) error // upserts on the wallet pair and reason
+	GetLinks(ctx context.Context, walletAddress string) ([]*models.WalletLink, error)
+	
+	// Cluster methods
+	MergeCluster(ctx context.Context, walletAddresses []string) (uuid.UUID, error) // joins the wallets and their existing clusters into one
+	GetClusterMembers(ctx context.Context, walletAddress string) (*uuid.UUID, []string, error) // nil cluster ID if the wallet is not clustered
+	GetClusterIDs(ctx context.Context, walletAddresses []string) (map[string]uuid.UUID, error) // unclustered wallets are omitted
+}
+
+// WalletPurge counts what a wallet purge removed, or would remove, and anonymized
+type WalletPurge struct {
+	Memberships         int64    `json:"memberships"`
+	Reactions           int64    `json:"reactions"`
+	Follows             int64    `json:"follows"`               // followed and following
+	Settings            int64    `json:"settings"`
+	Screeners           int64    `json:"screeners"`
+	EmailBindings       int64    `json:"email_bindings"`
+	Notifications       int64    `json:"notifications"`
+	PriceWatches        int64    `json:"price_watches"`
+	WalletWatches       int64    `json:"wallet_watches"`        // wallets it watched
+	PasswordFailures    int64    `json:"password_failures"`
+	SharedInfos         int64    `json:"shared_infos"`          // anonymized, like the counts below
+	Signals             int64    `json:"signals"`
+	TradeEvents         int64    `json:"trade_events"`
+	RoomEvents          int64    `json:"room_events"`
+	FeedMessages        int64    `json:"feed_messages"`
+	RoomEventPayloads   int64    `json:"room_event_payloads"`   // events whose JSON payload named the wallet
+	FeedMessagePayloads int64    `json:"feed_message_payloads"` // feed messages whose JSON data named the wallet
+	OwnedRooms          int64    `json:"owned_rooms"`           // closed or expired rooms the wallet created
+	RoomIDs             []string `json:"room_ids"`              // public IDs of the rooms the wallet was a member of
+}
+
+// MemberAlias is a member's alias in a room, empty without one, with the creator who sees through it
+type MemberAlias struct {
+	Alias          string
+	CreatorAddress string
+}
+
+// PurgeRepository removes a wallet's personal data
+type PurgeRepository interface {
+	// PurgeWallet removes the wallet's memberships, reactions, follows, settings, notifications and watches and
+	// anonymizes the room content it posted; ErrWalletOwnsRooms while it owns active rooms. A dry run
+	// changes nothing and returns what would be purged.
+	PurgeWallet(ctx context.Context, walletAddress string, dryRun bool) (*WalletPurge, error)
+}
+
+// UserSettingsRepository defines the interface for user settings data access
+type UserSettingsRepository interface {
+	GetByWallet(ctx context.Context, walletAddress string) (*models.UserSettings, error)
+	GetByWallets(ctx context.Context, walletAddresses []string) ([]*models.UserSettings, error) // wallets without saved settings are left out
+	Save(ctx context.Context, settings *models.UserSettings) error
+	
+	// Email binding
+	SaveEmailVerification(ctx context.Context, verification *models.EmailVerification) error // replaces the wallet's pending verification
+	GetEmailVerification(ctx context.Context, walletAddress string) (*models.EmailVerification, error)
+	IncrementEmailVerificationAttempts(ctx context.Context, id uuid.UUID) error
+	DeleteEmailVerification(ctx context.Context, walletAddress string) error
+}
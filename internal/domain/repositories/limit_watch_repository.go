@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type limitWatchRepository struct {
+	db *gorm.DB
+}
+
+// NewLimitWatchRepository creates a new limit watch repository instance
+func NewLimitWatchRepository(db *gorm.DB) LimitWatchRepository {
+	return &limitWatchRepository{db: db}
+}
+
+func (r *limitWatchRepository) Create(ctx context.Context, watch *models.LimitWatch) error {
+	return r.db.WithContext(ctx).Create(watch).Error
+}
+
+func (r *limitWatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.LimitWatch, error) {
+	var watch models.LimitWatch
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&watch).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &watch, nil
+}
+
+func (r *limitWatchRepository) ListByWallet(ctx context.Context, walletAddress string, status models.LimitWatchStatus, limit, offset int) ([]*models.LimitWatch, error) {
+	var watches []*models.LimitWatch
+	query := r.db.WithContext(ctx).Where("wallet_address = ?", walletAddress)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&watches).Error
+	return watches, err
+}
+
+func (r *limitWatchRepository) CountActive(ctx context.Context, walletAddress string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.LimitWatch{}).
+		Where("wallet_address = ? AND status = ?", walletAddress, models.LimitWatchActive).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *limitWatchRepository) GetActiveByMint(ctx context.Context, mintAddress string, now time.Time) ([]*models.LimitWatch, error) {
+	var watches []*models.LimitWatch
+	err := r.db.WithContext(ctx).
+		Where("mint_address = ? AND status = ? AND (expires_at IS NULL OR expires_at > ?)", mintAddress, models.LimitWatchActive, now).
+		Find(&watches).Error
+	return watches, err
+}
+
+func (r *limitWatchRepository) GetActiveMints(ctx context.Context, now time.Time) ([]string, error) {
+	var mints []string
+	err := r.db.WithContext(ctx).
+		Model(&models.LimitWatch{}).
+		Select("DISTINCT mint_address").
+		Where("status = ? AND (expires_at IS NULL OR expires_at > ?)", models.LimitWatchActive, now).
+		Scan(&mints).Error
+	return mints, err
+}
+
+func (r *limitWatchRepository) MarkTriggered(ctx context.Context, id uuid.UUID, priceUSD float64, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.LimitWatch{}).
+		Where("id = ? AND status = ?", id, models.LimitWatchActive).
+		Updates(map[string]interface{}{
+			"status":              models.LimitWatchTriggered,
+			"triggered_price_usd": priceUSD,
+			"triggered_at":        at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *limitWatchRepository) Cancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.LimitWatch{}).
+		Where("id = ? AND status = ?", id, models.LimitWatchActive).
+		Update("status", models.LimitWatchCancelled)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *limitWatchRepository) ExpireBefore(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.LimitWatch{}).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", models.LimitWatchActive, now).
+		Update("status", models.LimitWatchExpired)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type liquidityRepository struct {
+	db *gorm.DB
+}
+
+// NewLiquidityRepository creates a new liquidity repository instance
+func NewLiquidityRepository(db *gorm.DB) LiquidityRepository {
+	return &liquidityRepository{db: db}
+}
+
+func (r *liquidityRepository) CreateSnapshot(ctx context.Context, snapshot *models.LiquiditySnapshot) error {
+	return r.db.WithContext(ctx).Create(snapshot).Error
+}
+
+func (r *liquidityRepository) GetPeakSnapshot(ctx context.Context, mintAddress string, since time.Time) (*models.LiquiditySnapshot, error) {
+	var snapshot models.LiquiditySnapshot
+	err := r.db.WithContext(ctx).
+		Where("mint_address = ? AND created_at >= ?", mintAddress, since).
+		Order("liquidity_usd DESC").
+		First(&snapshot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func (r *liquidityRepository) GetSnapshots(ctx context.Context, mintAddress string, since time.Time) ([]*models.LiquiditySnapshot, error) {
+	var snapshots []*models.LiquiditySnapshot
+	err := r.db.WithContext(ctx).
+		Where("mint_address = ? AND created_at >= ?", mintAddress, since).
+		Order("created_at ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
+
+func (r *liquidityRepository) DeleteSnapshotsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&models.LiquiditySnapshot{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *liquidityRepository) CreateAlert(ctx context.Context, alert *models.LiquidityAlert) error {
+	return r.db.WithContext(ctx).Create(alert).Error
+}
+
+func (r *liquidityRepository) GetLatestAlert(ctx context.Context, mintAddress string) (*models.LiquidityAlert, error) {
+	var alert models.LiquidityAlert
+	err := r.db.WithContext(ctx).
+		Where("mint_address = ?", mintAddress).
+		Order("created_at DESC").
+		First(&alert).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &alert, nil
+}
+
+func (r *liquidityRepository) GetAlerts(ctx context.Context, mintAddress string, limit, offset int) ([]*models.LiquidityAlert, error) {
+	var alerts []*models.LiquidityAlert
+	err := r.db.WithContext(ctx).
+		Where("mint_address = ?", mintAddress).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&alerts).Error
+	return alerts, err
+}
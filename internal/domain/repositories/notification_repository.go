@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository instance
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
+
+func (r *notificationRepository) UpdateChannels(ctx context.Context, id uuid.UUID, channels string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("id = ?", id).
+		Update("channels", channels).Error
+}
+
+func (r *notificationRepository) ListByWallet(ctx context.Context, walletAddress string, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	query := r.db.WithContext(ctx).Where("wallet_address = ?", walletAddress)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, walletAddress string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("wallet_address = ? AND read_at IS NULL", walletAddress).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, walletAddress string, id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("id = ? AND wallet_address = ?", id, walletAddress).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", at))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, walletAddress string, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("wallet_address = ? AND read_at IS NULL", walletAddress).
+		Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
+
+func (r *notificationRepository) DeleteExpired(ctx context.Context, createdBefore, readBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("created_at < ? OR (read_at IS NOT NULL AND read_at < ?)", createdBefore, readBefore).
+		Delete(&models.Notification{})
+	return result.RowsAffected, result.Error
+}
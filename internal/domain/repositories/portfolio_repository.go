@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type portfolioRepository struct {
+	db *gorm.DB
+}
+
+// NewPortfolioRepository creates a new portfolio repository instance
+func NewPortfolioRepository(db *gorm.DB) PortfolioRepository {
+	return &portfolioRepository{db: db}
+}
+
+func (r *portfolioRepository) SaveSnapshot(ctx context.Context, snapshot *models.PortfolioSnapshot) error {
+	return r.db.WithContext(ctx).Save(snapshot).Error
+}
+
+func (r *portfolioRepository) GetSnapshot(ctx context.Context, walletAddress string, date time.Time) (*models.PortfolioSnapshot, error) {
+	var snapshot models.PortfolioSnapshot
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ? AND snapshot_date = ?", walletAddress, date).
+		First(&snapshot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func (r *portfolioRepository) GetSnapshots(ctx context.Context, walletAddress string, since time.Time) ([]*models.PortfolioSnapshot, error) {
+	var snapshots []*models.PortfolioSnapshot
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ? AND snapshot_date >= ?", walletAddress, since).
+		Order("snapshot_date ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
+
+func (r *portfolioRepository) GetTrackedWallets(ctx context.Context) ([]string, error) {
+	var wallets []string
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT following_address FROM wallet_followings
+		UNION
+		SELECT wallet_address FROM room_members`).
+		Scan(&wallets).Error
+	return wallets, err
+}
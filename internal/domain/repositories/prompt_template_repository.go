@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type promptTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewPromptTemplateRepository creates a new prompt template repository instance
+func NewPromptTemplateRepository(db *gorm.DB) PromptTemplateRepository {
+	return &promptTemplateRepository{db: db}
+}
+
+func (r *promptTemplateRepository) CreateVersion(ctx context.Context, template *models.PromptTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		if err := tx.Model(&models.PromptTemplate{}).
+			Select("COALESCE(MAX(version), 0)").
+			Where("key = ? AND room_id = ?", template.Key, template.RoomID).
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PromptTemplate{}).
+			Where("key = ? AND room_id = ? AND active = ?", template.Key, template.RoomID, true).
+			Update("active", false).Error; err != nil {
+			return err
+		}
+
+		template.Version = latest + 1
+		template.Active = true
+		return tx.Create(template).Error
+	})
+}
+
+func (r *promptTemplateRepository) GetActive(ctx context.Context, key, roomID string) (*models.PromptTemplate, error) {
+	var template models.PromptTemplate
+	err := r.db.WithContext(ctx).
+		Where("key = ? AND room_id = ? AND active = ?", key, roomID, true).
+		First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *promptTemplateRepository) ListActive(ctx context.Context) ([]*models.PromptTemplate, error) {
+	var templates []*models.PromptTemplate
+	err := r.db.WithContext(ctx).
+		Where("active = ?", true).
+		Order("key, room_id").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *promptTemplateRepository) ListVersions(ctx context.Context, key, roomID string) ([]*models.PromptTemplate, error) {
+	var templates []*models.PromptTemplate
+	err := r.db.WithContext(ctx).
+		Where("key = ? AND room_id = ?", key, roomID).
+		Order("version DESC").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *promptTemplateRepository) Activate(ctx context.Context, key, roomID string, version int) (*models.PromptTemplate, error) {
+	var template models.PromptTemplate
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("key = ? AND room_id = ? AND version = ?", key, roomID, version).First(&template).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PromptTemplate{}).
+			Where("key = ? AND room_id = ? AND active = ?", key, roomID, true).
+			Update("active", false).Error; err != nil {
+			return err
+		}
+		template.Active = true
+		return tx.Model(&template).Update("active", true).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *promptTemplateRepository) Deactivate(ctx context.Context, key, roomID string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.PromptTemplate{}).
+		Where("key = ? AND room_id = ? AND active = ?", key, roomID, true).
+		Update("active", false).Error
+}
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// ErrWalletOwnsRooms is returned by PurgeWallet while the wallet still owns active rooms
+var ErrWalletOwnsRooms = errors.New("wallet owns active rooms")
+
+// PurgedWalletAddress replaces a purged wallet's address in the room content that is kept
+const PurgedWalletAddress = "purged"
+
+// errPurgeDryRun rolls back a dry run once everything is counted
+var errPurgeDryRun = errors.New("purge dry run")
+
+type purgeRepository struct {
+	db *gorm.DB
+}
+
+// NewPurgeRepository creates a new purge repository instance
+func NewPurgeRepository(db *gorm.DB) PurgeRepository {
+	return &purgeRepository{db: db}
+}
+
+// PurgeWallet runs the purge in one transaction. A dry run rolls it back, so its counts are exactly what a
+// real purge would remove and anonymize at that moment.
+func (r *purgeRepository) PurgeWallet(ctx context.Context, walletAddress string, dryRun bool) (*WalletPurge, error) {
+	purge := &WalletPurge{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := purgeWallet(tx, walletAddress, purge); err != nil {
+			return err
+		}
+		if dryRun {
+			return errPurgeDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errPurgeDryRun) {
+		return nil, err
+	}
+	return purge, nil
+}
+
+func purgeWallet(tx *gorm.DB, walletAddress string, purge *WalletPurge) error {
+	var ownedActive int64
+	err := tx.Model(&models.TradeRoom{}).
+		Where("creator_address = ? AND status = ?", walletAddress, models.RoomStatusActive).
+		Count(&ownedActive).Error
+	if err != nil {
+		return err
+	}
+	if ownedActive > 0 {
+		return ErrWalletOwnsRooms
+	}
+
+	// Memberships, keeping the member counts of the rooms left in step
+	err = tx.Model(&models.RoomMember{}).
+		Joins("JOIN trade_rooms ON trade_rooms.id = room_members.room_id").
+		Where("room_members.wallet_address = ?", walletAddress).
+		Pluck("trade_rooms.room_id", &purge.RoomIDs).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Exec(`UPDATE trade_rooms SET current_members = current_members - 1
+		WHERE id IN (SELECT room_id FROM room_members WHERE wallet_address = ?)`, walletAddress).Error
+	if err != nil {
+		return err
+	}
+	result := tx.Where("wallet_address = ?", walletAddress).Delete(&models.RoomMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	purge.Memberships = result.RowsAffected
+
+	// Reactions, keeping the like counts of the shared infos in step
+	err = tx.Exec(`UPDATE shared_infos SET like_count = like_count - r.reactions
+		FROM (SELECT shared_info_id, COUNT(*) AS reactions FROM reactions WHERE wallet_address = ? GROUP BY shared_info_id) r
+		WHERE shared_infos.id = r.shared_info_id`, walletAddress).Error
+	if err != nil {
+		return err
+	}
+	result = tx.Where("wallet_address = ?", walletAddress).Delete(&models.Reaction{})
+	if result.Error != nil {
+		return result.Error
+	}
+	purge.Reactions = result.RowsAffected
+
+	// Follows both ways, keeping the follower counts of the wallets it followed in step
+	err = tx.Exec(`UPDATE traders SET follower_count = follower_count - 1
+		WHERE wallet_address IN (SELECT following_address FROM wallet_followings WHERE follower_address = ?)`, walletAddress).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&models.Trader{}).Where("wallet_address = ?", walletAddress).Update("follower_count", 0).Error
+	if err != nil {
+		return err
+	}
+	result = tx.Where("follower_address = ? OR following_address = ?", walletAddress, walletAddress).Delete(&models.WalletFollowing{})
+	if result.Error != nil {
+		return result.Error
+	}
+	purge.Follows = result.RowsAffected
+
+	// Settings, saved screeners, email bindings, notifications, price and wallet watches and password
+	// audits are removed outright
+	deletions := []struct {
+		model interface{}
+		count *int64
+	}{
+		{&models.UserSettings{}, &purge.Settings},
+		{&models.ScreenerPreset{}, &purge.Screeners},
+		{&models.EmailVerification{}, &purge.EmailBindings},
+		{&models.Notification{}, &purge.Notifications},
+		{&models.LimitWatch{}, &purge.PriceWatches},
+		{&models.WalletWatch{}, &purge.WalletWatches},
+		{&models.RoomPasswordFailure{}, &purge.PasswordFailures},
+	}
+	for _, deletion := range deletions {
+		result := tx.Where("wallet_address = ?", walletAddress).Delete(deletion.model)
+		if result.Error != nil {
+			return result.Error
+		}
+		*deletion.count = result.RowsAffected
+	}
+
+	// Room content stays readable for the other members, without the wallet's address
+	anonymizations := []struct {
+		model  interface{}
+		column string
+		count  *int64
+	}{
+		{&models.SharedInfo{}, "sharer_address", &purge.SharedInfos},
+		{&models.SignalOutcome{}, "sharer_address", &purge.Signals},
+		{&models.TradeEvent{}, "wallet_address", &purge.TradeEvents},
+		{&models.RoomEvent{}, "wallet_address", &purge.RoomEvents},
+		{&models.RoomFeedMessage{}, "from_wallet", &purge.FeedMessages},
+		{&models.TradeRoom{}, "creator_address", &purge.OwnedRooms},
+	}
+	for _, anonymization := range anonymizations {
+		result := tx.Model(anonymization.model).
+			Where(anonymization.column+" = ?", walletAddress).
+			Update(anonymization.column, PurgedWalletAddress)
+		if result.Error != nil {
+			return result.Error
+		}
+		*anonymization.count = result.RowsAffected
+	}
+
+	// Event and feed payloads, e.g. the serialized membership of a joined event, name the wallet in their JSON
+	payloadScrubs := []struct {
+		table  string
+		column string
+		count  *int64
+	}{
+		{"room_events", "payload", &purge.RoomEventPayloads},
+		{"room_feed_messages", "data", &purge.FeedMessagePayloads},
+	}
+	for _, scrub := range payloadScrubs {
+		result := tx.Exec(`UPDATE `+scrub.table+` SET `+scrub.column+` = replace(`+scrub.column+`::text, ?, ?)::jsonb
+			WHERE strpos(`+scrub.column+`::text, ?) > 0`, walletAddress, PurgedWalletAddress, walletAddress)
+		if result.Error != nil {
+			return result.Error
+		}
+		*scrub.count = result.RowsAffected
+	}
+
+	// Ownership offers to the wallet lapse
+	return tx.Model(&models.TradeRoom{}).
+		Where("pending_owner_address = ?", walletAddress).
+		Updates(map[string]interface{}{"pending_owner_address": nil, "transfer_expires_at": nil}).Error
+}
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRepository creates a new report repository instance
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) SaveDigest(ctx context.Context, digest *models.RoomDigest) error {
+	return r.db.WithContext(ctx).Save(digest).Error
+}
+
+func (r *reportRepository) GetDigest(ctx context.Context, roomID uuid.UUID, date time.Time) (*models.RoomDigest, error) {
+	var digest models.RoomDigest
+	err := r.db.WithContext(ctx).
+		Where("room_id = ? AND digest_date = ?", roomID, date).
+		First(&digest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &digest, nil
+}
+
+func (r *reportRepository) GetLatestDigest(ctx context.Context, roomID uuid.UUID) (*models.RoomDigest, error) {
+	var digest models.RoomDigest
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("digest_date DESC").
+		First(&digest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &digest, nil
+}
+
+func (r *reportRepository) ListDigests(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*models.RoomDigest, error) {
+	var digests []*models.RoomDigest
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("digest_date DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&digests).Error
+	return digests, err
+}
+
+// tradeEventValueSQL is the USD value of a trade event payload, preferring the server-side valuation
+const tradeEventValueSQL = `COALESCE(NULLIF((payload->>'server_value_usd')::numeric, 0), (payload->>'value_usd')::numeric, 0)`
+
+// roomDailyStatsQuery groups room events by room and UTC day; events of deleted rooms are skipped
+const roomDailyStatsQuery = `
+SELECT
+	room_id,
+	(created_at AT TIME ZONE 'UTC')::date AS day,
+	COUNT(*) FILTER (WHERE type = @joined) AS joins,
+	COUNT(*) FILTER (WHERE type = @left) AS leaves,
+	COUNT(*) FILTER (WHERE type = @share AND payload->>'type' = @discussion) AS messages,
+	COUNT(*) FILTER (WHERE type = @share AND payload->>'type' <> @discussion) AS shares,
+	COUNT(*) FILTER (WHERE type = @trade) AS trades,
+	COALESCE(SUM(` + tradeEventValueSQL + `) FILTER (WHERE type = @trade), 0) AS trade_volume_usd,
+	COUNT(DISTINCT wallet_address) FILTER (WHERE type IN (@share, @trade)) AS active_members
+FROM room_events
+WHERE created_at >= @from AND room_id IN (SELECT id FROM trade_rooms)
+GROUP BY 1, 2`
+
+func (r *reportRepository) AggregateRoomDailyStats(ctx context.Context, from time.Time) ([]*models.RoomDailyStat, error) {
+	var stats []*models.RoomDailyStat
+	err := r.db.WithContext(ctx).
+		Raw(roomDailyStatsQuery, map[string]interface{}{
+			"joined":     models.RoomEventJoined,
+			"left":       models.RoomEventLeft,
+			"share":      models.RoomEventShare,
+			"trade":      models.RoomEventTrade,
+			"discussion": models.SharedInfoTypeDiscussion,
+			"from":       from,
+		}).
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *reportRepository) SaveRoomDailyStats(ctx context.Context, stats []*models.RoomDailyStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "room_id"}, {Name: "day"}},
+			DoUpdates: clause.AssignmentColumns([]string{"joins", "leaves", "messages", "shares", "trades", "trade_volume_usd", "active_members", "updated_at"}),
+		}).
+		CreateInBatches(stats, 500).Error
+}
+
+func (r *reportRepository) GetRoomDailyStats(ctx context.Context, roomID uuid.UUID, from time.Time) ([]*models.RoomDailyStat, error) {
+	var stats []*models.RoomDailyStat
+	err := r.db.WithContext(ctx).
+		Where("room_id = ? AND day >= ?", roomID, from).
+		Order("day ASC").
+		Find(&stats).Error
+	return stats, err
+}
+
+// mostActiveMembersQuery ranks the wallets of a room by their shares and trades, leaving out system posts
+const mostActiveMembersQuery = `
+SELECT
+	wallet_address,
+	COUNT(*) FILTER (WHERE type = @share AND payload->>'type' = @discussion) AS messages,
+	COUNT(*) FILTER (WHERE type = @share AND payload->>'type' <> @discussion) AS shares,
+	COUNT(*) FILTER (WHERE type = @trade) AS trades,
+	COALESCE(SUM(` + tradeEventValueSQL + `) FILTER (WHERE type = @trade), 0) AS trade_volume_usd,
+	COUNT(*) AS activity
+FROM room_events
+WHERE room_id = @room AND type IN (@share, @trade) AND created_at >= @since
+	AND wallet_address NOT IN ('', @digest, @assistant)
+GROUP BY wallet_address
+ORDER BY activity DESC, trade_volume_usd DESC
+LIMIT @limit`
+
+func (r *reportRepository) GetMostActiveMembers(ctx context.Context, roomID uuid.UUID, since time.Time, limit int) ([]*models.RoomMemberActivity, error) {
+	var members []*models.RoomMemberActivity
+	err := r.db.WithContext(ctx).
+		Raw(mostActiveMembersQuery, map[string]interface{}{
+			"share":      models.RoomEventShare,
+			"trade":      models.RoomEventTrade,
+			"discussion": models.SharedInfoTypeDiscussion,
+			"digest":     models.DigestSharerAddress,
+			"assistant":  models.AssistantSharerAddress,
+			"room":       roomID,
+			"since":      since,
+			"limit":      limit,
+		}).
+		Scan(&members).Error
+	return members, err
+}
+
+// retentionQuery follows the wallets that joined a room since a time: whether they are still
+// members, and whether they shared or traded on a later UTC day than the one they joined
+const retentionQuery = `
+WITH joined AS (
+	SELECT payload->>'wallet_address' AS wallet_address, MIN(created_at) AS joined_at
+	FROM room_events
+	WHERE room_id = @room AND type = @joined AND created_at >= @since
+	GROUP BY 1
+)
+SELECT
+	COUNT(*) AS joined,
+	COUNT(*) FILTER (WHERE EXISTS (
+		SELECT 1 FROM room_members
+		WHERE room_members.room_id = @room AND room_members.wallet_address = joined.wallet_address
+	)) AS retained,
+	COUNT(*) FILTER (WHERE EXISTS (
+		SELECT 1 FROM room_events
+		WHERE room_events.room_id = @room AND room_events.type IN (@share, @trade)
+			AND room_events.wallet_address = joined.wallet_address
+			AND (room_events.created_at AT TIME ZONE 'UTC')::date > (joined.joined_at AT TIME ZONE 'UTC')::date
+	)) AS active
+FROM joined`
+
+func (r *reportRepository) GetRetention(ctx context.Context, roomID uuid.UUID, since time.Time) (*models.RoomRetention, error) {
+	var retention models.RoomRetention
+	err := r.db.WithContext(ctx).
+		Raw(retentionQuery, map[string]interface{}{
+			"joined": models.RoomEventJoined,
+			"share":  models.RoomEventShare,
+			"trade":  models.RoomEventTrade,
+			"room":   roomID,
+			"since":  since,
+		}).
+		Scan(&retention).Error
+	if err != nil {
+		return nil, err
+	}
+	return &retention, nil
+}
@@ -1,21 +1,85 @@
 package repositories
 
-import "gorm.io/gorm"
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
 
 // Repositories holds all repository instances
 type Repositories struct {
-	Token       TokenRepository
-	Room        RoomRepository
-	Transaction TransactionRepository
-	Trader      TraderRepository
+	Token        TokenRepository
+	Room         RoomRepository
+	Transaction  TransactionRepository
+	Trader       TraderRepository
+	WalletLabel  WalletLabelRepository
+	Portfolio    PortfolioRepository
+	UserSettings UserSettingsRepository
+	Report       ReportRepository
+	Export       ExportRepository
+	Signal       SignalRepository
+	Analytics    AnalyticsRepository
+	Liquidity    LiquidityRepository
+	Cluster      ClusterRepository
+	Social       SocialRepository
+	LimitWatch   LimitWatchRepository
+	WalletWatch  WalletWatchRepository
+	Notification NotificationRepository
+	AdminAudit   AdminAuditRepository
+	AIUsage      AIUsageRepository
+	Prompt       PromptTemplateRepository
+	FeatureFlag  FeatureFlagRepository
+	Purge        PurgeRepository
+	UnitOfWork   UnitOfWork
 }
 
 // NewRepositories creates and returns all repository instances
 func NewRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		Token:       NewTokenRepository(db),
-		Room:        NewRoomRepository(db),
-		Transaction: NewTransactionRepository(db),
-		Trader:      NewTraderRepository(db),
+		Token:        NewTokenRepository(db),
+		Room:         NewRoomRepository(db),
+		Transaction:  NewTransactionRepository(db),
+		Trader:       NewTraderRepository(db),
+		WalletLabel:  NewWalletLabelRepository(db),
+		Portfolio:    NewPortfolioRepository(db),
+		UserSettings: NewUserSettingsRepository(db),
+		Report:       NewReportRepository(db),
+		Export:       NewExportRepository(db),
+		Signal:       NewSignalRepository(db),
+		Analytics:    NewAnalyticsRepository(db),
+		Liquidity:    NewLiquidityRepository(db),
+		Cluster:      NewClusterRepository(db),
+		Social:       NewSocialRepository(db),
+		LimitWatch:   NewLimitWatchRepository(db),
+		WalletWatch:  NewWalletWatchRepository(db),
+		Notification: NewNotificationRepository(db),
+		AdminAudit:   NewAdminAuditRepository(db),
+		AIUsage:      NewAIUsageRepository(db),
+		Prompt:       NewPromptTemplateRepository(db),
+		FeatureFlag:  NewFeatureFlagRepository(db),
+		Purge:        NewPurgeRepository(db),
+		UnitOfWork:   NewUnitOfWork(db),
 	}
-}
\ No newline at end of file
+}
+
+// UnitOfWork runs operations spanning several repositories in one database transaction
+type UnitOfWork interface {
+	// Do calls fn with repositories bound to a new transaction, committed if fn returns nil and rolled back
+	// otherwise; inside another unit of work it runs as a savepoint
+	Do(ctx context.Context, fn func(repos *Repositories) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a unit of work over the database
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos *Repositories) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
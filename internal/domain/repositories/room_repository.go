@@ -3,13 +3,19 @@ package repositories
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrRoomAtCapacity is returned by AddMember when the room has no free seat
+var ErrRoomAtCapacity = errors.New("room is at capacity")
+
 type roomRepository struct {
 	db *gorm.DB
 }
@@ -29,6 +35,7 @@ func (r *roomRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Tra
 	err := r.db.WithContext(ctx).
 		Preload("Token").
 		Preload("Members").
+		Preload("Basket").
 		Where("id = ?", id).
 		First(&room).Error
 	if err != nil {
@@ -45,6 +52,7 @@ func (r *roomRepository) GetByRoomID(ctx context.Context, roomID string) (*model
 	err := r.db.WithContext(ctx).
 		Preload("Token").
 		Preload("Members").
+		Preload("Basket").
 		Where("room_id = ?", roomID).
 		First(&room).Error
 	if err != nil {
@@ -72,6 +80,7 @@ func (r *roomRepository) List(ctx context.Context, status models.RoomStatus, lim
 	var rooms []*models.TradeRoom
 	query := r.db.WithContext(ctx).
 		Preload("Token").
+		Preload("Basket").
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset)
@@ -84,8 +93,68 @@ func (r *roomRepository) List(ctx context.Context, status models.RoomStatus, lim
 	return rooms, err
 }
 
+func (r *roomRepository) CountByStatus(ctx context.Context, status models.RoomStatus) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.TradeRoom{}).
+		Where("status = ?", status).
+		Count(&count).Error
+	return count, err
+}
+
+// Update saves the room's own fields except its capacity, which member changes move under conditional
+// updates; a stale copy must not write it back. Preloaded associations are not saved.
 func (r *roomRepository) Update(ctx context.Context, room *models.TradeRoom) error {
-	return r.db.WithContext(ctx).Save(room).Error
+	return r.db.WithContext(ctx).Omit(clause.Associations, "max_members", "current_members").Save(room).Error
+}
+
+// UpdateToken binds the room to a token, or clears it with nils, leaving its other fields alone
+func (r *roomRepository) UpdateToken(ctx context.Context, id uuid.UUID, tokenAddress *string, tokenID *uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TradeRoom{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"token_address": tokenAddress, "token_id": tokenID}).Error
+}
+
+// UpdateMaxMembers resizes the room unless it already holds more members than the new size
+func (r *roomRepository) UpdateMaxMembers(ctx context.Context, id uuid.UUID, maxMembers int) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TradeRoom{}).
+		Where("id = ? AND current_members <= ?", id, maxMembers).
+		Update("max_members", maxMembers)
+	return result.RowsAffected > 0, result.Error
+}
+
+// OfferTransfer replaces any pending transfer, provided the wallet still owns the room
+func (r *roomRepository) OfferTransfer(ctx context.Context, id uuid.UUID, creatorAddress, targetAddress string, expiresAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TradeRoom{}).
+		Where("id = ? AND creator_address = ? AND status = ?", id, creatorAddress, models.RoomStatusActive).
+		Updates(map[string]interface{}{"pending_owner_address": targetAddress, "transfer_expires_at": expiresAt})
+	return result.RowsAffected > 0, result.Error
+}
+
+// AcceptTransfer hands the room to the wallet only if the transfer offered to it is still pending, so a
+// concurrent cancel or new offer wins over the accept
+func (r *roomRepository) AcceptTransfer(ctx context.Context, id uuid.UUID, walletAddress string, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TradeRoom{}).
+		Where("id = ? AND status = ? AND pending_owner_address = ? AND transfer_expires_at > ?", id, models.RoomStatusActive, walletAddress, now).
+		Updates(map[string]interface{}{
+			"creator_address":       walletAddress,
+			"pending_owner_address": nil,
+			"transfer_expires_at":   nil,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CancelTransfer withdraws the transfer only if it is still offered to the target
+func (r *roomRepository) CancelTransfer(ctx context.Context, id uuid.UUID, targetAddress string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TradeRoom{}).
+		Where("id = ? AND pending_owner_address = ?", id, targetAddress).
+		Updates(map[string]interface{}{"pending_owner_address": nil, "transfer_expires_at": nil})
+	return result.RowsAffected > 0, result.Error
 }
 
 func (r *roomRepository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -99,27 +168,115 @@ func (r *roomRepository) UpdateLastActivity(ctx context.Context, roomID uuid.UUI
 		Update("last_activity", time.Now()).Error
 }
 
-func (r *roomRepository) GetExpiredRooms(ctx context.Context) ([]*models.TradeRoom, error) {
+func (r *roomRepository) GetExpiredRooms(ctx context.Context, now time.Time) ([]*models.TradeRoom, error) {
 	var rooms []*models.TradeRoom
 	err := r.db.WithContext(ctx).
-		Where("expires_at < ? AND status = 'active'", time.Now()).
+		Where("expires_at < ? AND status = 'active'", now).
 		Find(&rooms).Error
 	return rooms, err
 }
 
+func (r *roomRepository) GetActiveByToken(ctx context.Context, mintAddress string) ([]*models.TradeRoom, error) {
+	var rooms []*models.TradeRoom
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.RoomStatusActive).
+		Where("token_address = ? OR token_id IN (?) OR id IN (?)", mintAddress,
+			r.db.Model(&models.Token{}).Select("id").Where("mint_address = ?", mintAddress),
+			r.db.Model(&models.RoomToken{}).Select("room_id").Where("mint_address = ?", mintAddress)).
+		Find(&rooms).Error
+	return rooms, err
+}
+
+func (r *roomRepository) GetBoundTokenAddresses(ctx context.Context) ([]string, error) {
+	var mints []string
+	err := r.db.WithContext(ctx).
+		Table("trade_rooms").
+		Select("DISTINCT COALESCE(NULLIF(trade_rooms.token_address, ''), tokens.mint_address)").
+		Joins("LEFT JOIN tokens ON tokens.id = trade_rooms.token_id").
+		Where("trade_rooms.status = ?", models.RoomStatusActive).
+		Where("COALESCE(NULLIF(trade_rooms.token_address, ''), tokens.mint_address) IS NOT NULL").
+		Scan(&mints).Error
+	if err != nil {
+		return nil, err
+	}
+	
+	var basketMints []string
+	err = r.db.WithContext(ctx).
+		Model(&models.RoomToken{}).
+		Distinct("room_tokens.mint_address").
+		Joins("JOIN trade_rooms ON trade_rooms.id = room_tokens.room_id").
+		Where("trade_rooms.status = ?", models.RoomStatusActive).
+		Pluck("room_tokens.mint_address", &basketMints).Error
+	if err != nil {
+		return nil, err
+	}
+	
+	seen := make(map[string]bool, len(mints))
+	for _, mint := range mints {
+		seen[mint] = true
+	}
+	for _, mint := range basketMints {
+		if !seen[mint] {
+			seen[mint] = true
+			mints = append(mints, mint)
+		}
+	}
+	return mints, nil
+}
+
+// Basket methods
+func (r *roomRepository) GetBasketTokens(ctx context.Context, roomID uuid.UUID) ([]*models.RoomToken, error) {
+	var tokens []*models.RoomToken
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("created_at ASC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *roomRepository) AddBasketTokens(ctx context.Context, tokens []*models.RoomToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&tokens).Error
+}
+
+func (r *roomRepository) RemoveBasketToken(ctx context.Context, roomID uuid.UUID, mintAddress string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("room_id = ? AND mint_address = ?", roomID, mintAddress).
+		Delete(&models.RoomToken{})
+	return result.RowsAffected > 0, result.Error
+}
+
 // Member methods
-func (r *roomRepository) AddMember(ctx context.Context, member *models.RoomMember) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create member
-		if err := tx.Create(member).Error; err != nil {
-			return err
+func (r *roomRepository) AddMember(ctx context.Context, member *models.RoomMember) (bool, error) {
+	added := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Create member; a concurrent join of the same wallet loses on the unique index instead of duplicating the row
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "room_id"}, {Name: "wallet_address"}},
+			DoNothing: true,
+		}).Create(member)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
 		}
 		
-		// Update room member count
-		return tx.Model(&models.TradeRoom{}).
-			Where("id = ?", member.RoomID).
-			Update("current_members", gorm.Expr("current_members + 1")).Error
+		// Take a seat; the count is only raised while below capacity, so concurrent joins cannot overfill the room
+		result = tx.Model(&models.TradeRoom{}).
+			Where("id = ? AND current_members < max_members", member.RoomID).
+			Update("current_members", gorm.Expr("current_members + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRoomAtCapacity // rolls the member row back
+		}
+		added = true
+		return nil
 	})
+	return added, err
 }
 
 func (r *roomRepository) RemoveMember(ctx context.Context, roomID uuid.UUID, walletAddress string) error {
@@ -182,6 +339,199 @@ func (r *roomRepository) UpdateMemberLastSeen(ctx context.Context, roomID uuid.U
 		}).Error
 }
 
+func (r *roomRepository) UpdateMemberRole(ctx context.Context, roomID uuid.UUID, walletAddress string, role models.MemberRole) error {
+	return r.db.WithContext(ctx).
+		Model(&models.RoomMember{}).
+		Where("room_id = ? AND wallet_address = ?", roomID, walletAddress).
+		Update("role", role).Error
+}
+
+func (r *roomRepository) UpdateMemberAlias(ctx context.Context, roomID uuid.UUID, walletAddress string, alias *string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.RoomMember{}).
+		Where("room_id = ? AND wallet_address = ?", roomID, walletAddress).
+		Update("alias", alias).Error
+}
+
+func (r *roomRepository) GetMemberByAlias(ctx context.Context, roomID uuid.UUID, alias string) (*models.RoomMember, error) {
+	var member models.RoomMember
+	err := r.db.WithContext(ctx).
+		Where("room_id = ? AND LOWER(alias) = LOWER(?)", roomID, alias).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *roomRepository) GetMemberAlias(ctx context.Context, roomID, walletAddress string) (*MemberAlias, error) {
+	var rows []struct {
+		Alias          *string
+		CreatorAddress string
+	}
+	err := r.db.WithContext(ctx).
+		Table("trade_rooms").
+		Select("room_members.alias, trade_rooms.creator_address").
+		Joins("LEFT JOIN room_members ON room_members.room_id = trade_rooms.id AND room_members.wallet_address = ?", walletAddress).
+		Where("trade_rooms.room_id = ?", roomID).
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	
+	alias := &MemberAlias{CreatorAddress: rows[0].CreatorAddress}
+	if rows[0].Alias != nil {
+		alias.Alias = *rows[0].Alias
+	}
+	return alias, nil
+}
+
+func (r *roomRepository) UpdateMemberPrivacy(ctx context.Context, roomID uuid.UUID, walletAddress string, hideTradeSizes bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.RoomMember{}).
+		Where("room_id = ? AND wallet_address = ?", roomID, walletAddress).
+		Update("hide_trade_sizes", hideTradeSizes).Error
+}
+
+// errBatchDoesNotFit rolls back a batch add that does not fit the room
+var errBatchDoesNotFit = errors.New("batch does not fit the room")
+
+// AddMembers takes its locks like AddMember, the member rows before the room, so the two cannot deadlock.
+// Wallets are inserted in sorted order for the same reason between batches.
+func (r *roomRepository) AddMembers(ctx context.Context, roomID uuid.UUID, walletAddresses []string) ([]*models.RoomMember, bool, error) {
+	sorted := append([]string(nil), walletAddresses...)
+	sort.Strings(sorted)
+	
+	var added []*models.RoomMember
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		added = nil
+		
+		// Wallets that are already members, or join concurrently, lose on the unique index and are skipped
+		for _, address := range sorted {
+			member := &models.RoomMember{
+				RoomID:        roomID,
+				WalletAddress: address,
+				Role:          models.MemberRoleMember,
+			}
+			result := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "room_id"}, {Name: "wallet_address"}},
+				DoNothing: true,
+			}).Create(member)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				added = append(added, member)
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		
+		// Take the seats of the rows actually inserted, only if they all fit
+		result := tx.Model(&models.TradeRoom{}).
+			Where("id = ? AND current_members + ? <= max_members", roomID, len(added)).
+			Updates(map[string]interface{}{
+				"current_members": gorm.Expr("current_members + ?", len(added)),
+				"last_activity":   time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errBatchDoesNotFit // rolls the member rows back
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchDoesNotFit) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return added, true, nil
+}
+
+func (r *roomRepository) RemoveMembers(ctx context.Context, roomID uuid.UUID, walletAddresses []string) ([]string, error) {
+	var removed []*models.RoomMember
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Returning{Columns: []clause.Column{{Name: "wallet_address"}}}).
+			Where("room_id = ? AND wallet_address IN ? AND role <> ?", roomID, walletAddresses, models.MemberRoleCreator).
+			Delete(&removed)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return tx.Model(&models.TradeRoom{}).
+			Where("id = ?", roomID).
+			Update("current_members", gorm.Expr("current_members - ?", result.RowsAffected)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	addresses := make([]string, 0, len(removed))
+	for _, member := range removed {
+		addresses = append(addresses, member.WalletAddress)
+	}
+	return addresses, nil
+}
+
+// Member activity methods
+func (r *roomRepository) RecordMemberActivity(ctx context.Context, roomID uuid.UUID, walletAddress string, activity models.MemberActivity) error {
+	var counter string
+	switch activity {
+	case models.MemberActivityMessage:
+		counter = "message_count"
+	case models.MemberActivityShare:
+		counter = "share_count"
+	case models.MemberActivityTrade:
+		counter = "trade_count"
+	default:
+		return fmt.Errorf("unknown member activity: %s", activity)
+	}
+	
+	return r.db.WithContext(ctx).
+		Model(&models.RoomMember{}).
+		Where("room_id = ? AND wallet_address = ?", roomID, walletAddress).
+		Updates(map[string]interface{}{
+			counter:                gorm.Expr(counter + " + 1"),
+			"last_active_at":       time.Now(),
+			"inactivity_warned_at": nil,
+		}).Error
+}
+
+func (r *roomRepository) GetPruningRooms(ctx context.Context) ([]*models.TradeRoom, error) {
+	var rooms []*models.TradeRoom
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND prune_inactive_days > 0", models.RoomStatusActive).
+		Find(&rooms).Error
+	return rooms, err
+}
+
+func (r *roomRepository) GetInactiveMembers(ctx context.Context, roomID uuid.UUID, before time.Time) ([]*models.RoomMember, error) {
+	var members []*models.RoomMember
+	err := r.db.WithContext(ctx).
+		Where("room_id = ? AND role = ?", roomID, models.MemberRoleMember).
+		Where("COALESCE(last_active_at, joined_at) < ?", before).
+		Order("COALESCE(last_active_at, joined_at) ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *roomRepository) MarkMemberWarned(ctx context.Context, memberID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.RoomMember{}).
+		Where("id = ?", memberID).
+		Update("inactivity_warned_at", at).Error
+}
+
 // Shared info methods
 func (r *roomRepository) CreateSharedInfo(ctx context.Context, info *models.SharedInfo) error {
 	return r.db.WithContext(ctx).Create(info).Error
@@ -198,6 +548,44 @@ func (r *roomRepository) GetSharedInfos(ctx context.Context, roomID uuid.UUID, l
 	return infos, err
 }
 
+func (r *roomRepository) GetSharedInfosBetween(ctx context.Context, roomID uuid.UUID, from, to time.Time) ([]*models.SharedInfo, error) {
+	var infos []*models.SharedInfo
+	err := r.db.WithContext(ctx).
+		Where("room_id = ? AND created_at >= ? AND created_at < ?", roomID, from, to).
+		Order("created_at ASC").
+		Find(&infos).Error
+	return infos, err
+}
+
+func (r *roomRepository) FindSharedInfos(ctx context.Context, filter SharedInfoFilter, limit, offset int) ([]*models.SharedInfo, error) {
+	var infos []*models.SharedInfo
+	query := r.db.WithContext(ctx).
+		Order("shared_infos.created_at DESC").
+		Limit(limit).
+		Offset(offset)
+	
+	if filter.RoomID != nil {
+		query = query.Where("shared_infos.room_id = ?", *filter.RoomID)
+	}
+	if filter.Type != "" {
+		query = query.Where("shared_infos.type = ?", filter.Type)
+	}
+	if filter.Token != "" {
+		query = query.Where("shared_infos.metadata->>'token' = ?", filter.Token)
+	}
+	if filter.Side != "" {
+		query = query.Where("shared_infos.metadata->>'side' = ?", filter.Side)
+	}
+	if filter.PublicOnly {
+		query = query.
+			Joins("JOIN trade_rooms ON trade_rooms.id = shared_infos.room_id").
+			Where("trade_rooms.password IS NULL AND trade_rooms.status = ?", models.RoomStatusActive)
+	}
+	
+	err := query.Find(&infos).Error
+	return infos, err
+}
+
 func (r *roomRepository) GetSharedInfoByID(ctx context.Context, id uuid.UUID) (*models.SharedInfo, error) {
 	var info models.SharedInfo
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&info).Error
@@ -215,7 +603,12 @@ func (r *roomRepository) UpdateSharedInfo(ctx context.Context, info *models.Shar
 }
 
 func (r *roomRepository) DeleteSharedInfo(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.SharedInfo{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shared_info_id = ?", id).Delete(&models.Reaction{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.SharedInfo{}, id).Error
+	})
 }
 
 func (r *roomRepository) IncrementViewCount(ctx context.Context, id uuid.UUID) error {
@@ -225,11 +618,80 @@ func (r *roomRepository) IncrementViewCount(ctx context.Context, id uuid.UUID) e
 		Update("view_count", gorm.Expr("view_count + 1")).Error
 }
 
-func (r *roomRepository) IncrementLikeCount(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).
-		Model(&models.SharedInfo{}).
-		Where("id = ?", id).
-		Update("like_count", gorm.Expr("like_count + 1")).Error
+// Reaction methods
+func (r *roomRepository) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	added := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+		if result.Error != nil {
+			return result.Error
+		}
+		added = result.RowsAffected > 0
+		if !added {
+			return nil
+		}
+		return syncLikeCount(tx, reaction.SharedInfoID)
+	})
+	return added, err
+}
+
+func (r *roomRepository) RemoveReaction(ctx context.Context, infoID uuid.UUID, walletAddress string, reactionType models.ReactionType) (bool, error) {
+	removed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Where("shared_info_id = ? AND wallet_address = ? AND type = ?", infoID, walletAddress, reactionType).
+			Delete(&models.Reaction{})
+		if result.Error != nil {
+			return result.Error
+		}
+		removed = result.RowsAffected > 0
+		if !removed {
+			return nil
+		}
+		return syncLikeCount(tx, infoID)
+	})
+	return removed, err
+}
+
+// syncLikeCount sets the shared info's like_count to its number of reactions
+func syncLikeCount(tx *gorm.DB, infoID uuid.UUID) error {
+	return tx.Model(&models.SharedInfo{}).
+		Where("id = ?", infoID).
+		Update("like_count", gorm.Expr("(SELECT COUNT(*) FROM reactions WHERE shared_info_id = ?)", infoID)).Error
+}
+
+func (r *roomRepository) CountReactions(ctx context.Context, infoID uuid.UUID) ([]*models.ReactionCount, error) {
+	var counts []*models.ReactionCount
+	err := r.db.WithContext(ctx).
+		Model(&models.Reaction{}).
+		Select("type, COUNT(*) AS count").
+		Where("shared_info_id = ?", infoID).
+		Group("type").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *roomRepository) GetWalletReactions(ctx context.Context, infoID uuid.UUID, walletAddress string) ([]models.ReactionType, error) {
+	var types []models.ReactionType
+	err := r.db.WithContext(ctx).
+		Model(&models.Reaction{}).
+		Where("shared_info_id = ? AND wallet_address = ?", infoID, walletAddress).
+		Pluck("type", &types).Error
+	return types, err
+}
+
+func (r *roomRepository) GetReactions(ctx context.Context, infoID uuid.UUID, reactionType models.ReactionType, limit, offset int) ([]*models.Reaction, error) {
+	var reactions []*models.Reaction
+	query := r.db.WithContext(ctx).Where("shared_info_id = ?", infoID)
+	if reactionType != "" {
+		query = query.Where("type = ?", reactionType)
+	}
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reactions).Error
+	return reactions, err
 }
 
 // Trade event methods
@@ -248,6 +710,15 @@ func (r *roomRepository) GetTradeEvents(ctx context.Context, roomID uuid.UUID, l
 	return events, err
 }
 
+func (r *roomRepository) GetTradeEventsBetween(ctx context.Context, roomID uuid.UUID, from, to time.Time) ([]*models.TradeEvent, error) {
+	var events []*models.TradeEvent
+	err := r.db.WithContext(ctx).
+		Where("room_id = ? AND created_at >= ? AND created_at < ?", roomID, from, to).
+		Order("created_at ASC").
+		Find(&events).Error
+	return events, err
+}
+
 func (r *roomRepository) GetTradeEventsByWallet(ctx context.Context, walletAddress string, limit, offset int) ([]*models.TradeEvent, error) {
 	var events []*models.TradeEvent
 	err := r.db.WithContext(ctx).
@@ -257,4 +728,137 @@ func (r *roomRepository) GetTradeEventsByWallet(ctx context.Context, walletAddre
 		Offset(offset).
 		Find(&events).Error
 	return events, err
-}
\ No newline at end of file
+}
+
+func (r *roomRepository) GetProvisionalTradeEvents(ctx context.Context, limit int) ([]*models.TradeEvent, error) {
+	var events []*models.TradeEvent
+	err := r.db.WithContext(ctx).
+		Preload("Room").
+		Where("provisional = ?", true).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *roomRepository) SetTradeEventFinality(ctx context.Context, id uuid.UUID, reverted bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TradeEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"provisional": false,
+			"reverted":    reverted,
+		}).Error
+}
+
+// Export methods
+func (r *roomRepository) CountTradeEvents(ctx context.Context, roomID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.TradeEvent{}).
+		Where("room_id = ?", roomID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *roomRepository) CountSharedInfos(ctx context.Context, roomID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.SharedInfo{}).
+		Where("room_id = ?", roomID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *roomRepository) GetTradeEventsBatch(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*models.TradeEvent, error) {
+	var events []*models.TradeEvent
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *roomRepository) GetSharedInfosBatch(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*models.SharedInfo, error) {
+	var infos []*models.SharedInfo
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&infos).Error
+	return infos, err
+}
+// Trade rationale methods
+func (r *roomRepository) CreateTradeRationale(ctx context.Context, rationale *models.TradeRationale) error {
+	return r.db.WithContext(ctx).Create(rationale).Error
+}
+
+func (r *roomRepository) SumRationaleTokens(ctx context.Context, roomID uuid.UUID, since time.Time) (int, error) {
+	var total int
+	err := r.db.WithContext(ctx).
+		Model(&models.TradeRationale{}).
+		Select("COALESCE(SUM(tokens_used), 0)").
+		Where("room_id = ? AND created_at >= ?", roomID, since).
+		Scan(&total).Error
+	return total, err
+}
+
+// Room event methods
+func (r *roomRepository) AppendEvent(ctx context.Context, event *models.RoomEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *roomRepository) GetEventsAfter(ctx context.Context, roomID uuid.UUID, afterSequence int64, limit int) ([]*models.RoomEvent, error) {
+	var events []*models.RoomEvent
+	err := r.db.WithContext(ctx).
+		Where("room_id = ? AND sequence > ?", roomID, afterSequence).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// Room feed methods
+func (r *roomRepository) AppendFeedMessage(ctx context.Context, message *models.RoomFeedMessage) error {
+	return r.db.WithContext(ctx).Create(message).Error
+}
+
+func (r *roomRepository) GetFeedBefore(ctx context.Context, roomID string, beforeSequence int64, limit int) ([]*models.RoomFeedMessage, error) {
+	var messages []*models.RoomFeedMessage
+	query := r.db.WithContext(ctx).Where("room_id = ?", roomID)
+	if beforeSequence > 0 {
+		query = query.Where("sequence < ?", beforeSequence)
+	}
+	err := query.Order("sequence DESC").Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+func (r *roomRepository) PruneFeed(ctx context.Context, before time.Time, keepPerRoom int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("sent_at < ?", before).
+		Delete(&models.RoomFeedMessage{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	pruned := result.RowsAffected
+
+	result = r.db.WithContext(ctx).Exec(`
+		DELETE FROM room_feed_messages WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY room_id ORDER BY sequence DESC) AS position
+				FROM room_feed_messages
+			) ranked WHERE position > ?
+		)`, keepPerRoom)
+	if result.Error != nil {
+		return pruned, result.Error
+	}
+	return pruned + result.RowsAffected, nil
+}
+
+// Password audit methods
+func (r *roomRepository) RecordPasswordFailure(ctx context.Context, failure *models.RoomPasswordFailure) error {
+	return r.db.WithContext(ctx).Create(failure).Error
+}
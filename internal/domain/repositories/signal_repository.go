@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type signalRepository struct {
+	db *gorm.DB
+}
+
+// NewSignalRepository creates a new signal repository instance
+func NewSignalRepository(db *gorm.DB) SignalRepository {
+	return &signalRepository{db: db}
+}
+
+func (r *signalRepository) CreateOutcome(ctx context.Context, outcome *models.SignalOutcome) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "shared_info_id"}}, DoNothing: true}).
+		Create(outcome).Error
+}
+
+func (r *signalRepository) UpdateOutcome(ctx context.Context, outcome *models.SignalOutcome) error {
+	return r.db.WithContext(ctx).Save(outcome).Error
+}
+
+func (r *signalRepository) GetDueOutcomes(ctx context.Context, now time.Time, limit int) ([]*models.SignalOutcome, error) {
+	var outcomes []*models.SignalOutcome
+	err := r.db.WithContext(ctx).
+		Where("next_check_at <= ?", now).
+		Order("next_check_at ASC").
+		Limit(limit).
+		Find(&outcomes).Error
+	return outcomes, err
+}
+
+func (r *signalRepository) GetOutcomesBySharer(ctx context.Context, sharerAddress string, limit, offset int) ([]*models.SignalOutcome, error) {
+	var outcomes []*models.SignalOutcome
+	err := r.db.WithContext(ctx).
+		Where("sharer_address = ?", sharerAddress).
+		Order("shared_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&outcomes).Error
+	return outcomes, err
+}
+
+func (r *signalRepository) GetAccuracies(ctx context.Context, sharerAddresses []string) ([]*models.SignalAccuracy, error) {
+	var accuracies []*models.SignalAccuracy
+	if len(sharerAddresses) == 0 {
+		return accuracies, nil
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.SignalOutcome{}).
+		Select(`sharer_address,
+			COUNT(*) AS signals,
+			COUNT(*) FILTER (WHERE result = ?) AS pending,
+			COUNT(*) FILTER (WHERE result = ?) AS hits,
+			COUNT(*) FILTER (WHERE result = ?) AS stopped,
+			COUNT(*) FILTER (WHERE result = ?) AS missed`,
+			models.SignalResultPending, models.SignalResultHit, models.SignalResultStopped, models.SignalResultMissed).
+		Where("sharer_address IN ?", sharerAddresses).
+		Group("sharer_address").
+		Scan(&accuracies).Error
+	return accuracies, err
+}
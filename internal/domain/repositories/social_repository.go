@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type socialRepository struct {
+	db *gorm.DB
+}
+
+// NewSocialRepository creates a new social metrics repository instance
+func NewSocialRepository(db *gorm.DB) SocialRepository {
+	return &socialRepository{db: db}
+}
+
+func (r *socialRepository) SaveMetric(ctx context.Context, metric *models.TokenSocialMetric) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "mint_address"}, {Name: "hour"}, {Name: "provider"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mentions", "positive_mentions", "negative_mentions", "sentiment_score", "updated_at"}),
+	}).Create(metric).Error
+}
+
+func (r *socialRepository) GetMetrics(ctx context.Context, mintAddress string, since time.Time) ([]*models.TokenSocialMetric, error) {
+	var metrics []*models.TokenSocialMetric
+	err := r.db.WithContext(ctx).
+		Where("mint_address = ? AND hour >= ?", mintAddress, since).
+		Order("hour ASC").
+		Find(&metrics).Error
+	return metrics, err
+}
+
+func (r *socialRepository) GetSummary(ctx context.Context, mintAddress string, since time.Time) (*models.TokenSocialSummary, error) {
+	var summary models.TokenSocialSummary
+	err := r.db.WithContext(ctx).
+		Model(&models.TokenSocialMetric{}).
+		Select(`COALESCE(SUM(mentions), 0) AS mentions,
+			COALESCE(SUM(sentiment_score * mentions) / NULLIF(SUM(mentions), 0), 0) AS sentiment_score,
+			COUNT(DISTINCT hour) AS hours`).
+		Where("mint_address = ? AND hour >= ?", mintAddress, since).
+		Scan(&summary).Error
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func (r *socialRepository) GetIngestedMints(ctx context.Context, hour time.Time, provider string) ([]string, error) {
+	var mints []string
+	err := r.db.WithContext(ctx).
+		Model(&models.TokenSocialMetric{}).
+		Where("hour = ? AND provider = ?", hour, provider).
+		Pluck("mint_address", &mints).Error
+	return mints, err
+}
+
+func (r *socialRepository) DeleteMetricsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("hour < ?", before).Delete(&models.TokenSocialMetric{})
+	return result.RowsAffected, result.Error
+}
@@ -3,10 +3,14 @@ package repositories
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type tokenRepository struct {
@@ -57,6 +61,118 @@ func (r *tokenRepository) List(ctx context.Context, limit, offset int) ([]*model
 	return tokens, err
 }
 
+func (r *tokenRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Token{}).Count(&count).Error
+	return count, err
+}
+
+// latestMarketDataJoin exposes each token's most recent market data row as md
+const latestMarketDataJoin = "LEFT JOIN token_market_latest md ON md.token_id = tokens.id"
+
+// provenanceJoin exposes the on-chain creation time of each token's mint
+const provenanceJoin = "LEFT JOIN token_provenances ON token_provenances.mint_address = tokens.mint_address"
+
+// latestLiquidity is the most recent liquidity snapshot of each token's mint
+const latestLiquidity = `(SELECT liquidity_usd FROM liquidity_snapshots
+	WHERE liquidity_snapshots.mint_address = tokens.mint_address
+	ORDER BY liquidity_snapshots.created_at DESC LIMIT 1)`
+
+// tokenSortColumns maps market sort fields to columns of latestMarketDataJoin
+var tokenSortColumns = map[TokenSortField]string{
+	TokenSortMarketCap:      "md.market_cap",
+	TokenSortVolume24h:      "md.volume24h",
+	TokenSortPriceChange24h: "md.price_change24h",
+}
+
+// screenerColumns maps screener fields to the expressions they compare; age is compiled separately
+var screenerColumns = map[ScreenerField]string{
+	ScreenerMarketCap:      "md.market_cap",
+	ScreenerVolume24h:      "md.volume24h",
+	ScreenerPriceChange24h: "md.price_change24h",
+	ScreenerHolderCount:    "md.holder_count",
+	ScreenerLiquidity:      latestLiquidity,
+}
+
+// screenerOperators are the comparisons conditions may use, with their mirror for swapped operands
+var screenerOperators = map[string]string{
+	">":  "<",
+	">=": "<=",
+	"<":  ">",
+	"<=": ">=",
+	"=":  "=",
+}
+
+func (r *tokenRepository) Find(ctx context.Context, filter TokenFilter, sort TokenSort, limit, offset int) ([]*models.Token, error) {
+	var tokens []*models.Token
+	query := r.db.WithContext(ctx).
+		Model(&models.Token{}).
+		Select("tokens.*").
+		Limit(limit).
+		Offset(offset)
+	
+	sortColumn, sortByMarket := tokenSortColumns[sort.Field]
+	joinMarket, joinProvenance := sortByMarket, false
+	for _, cond := range filter.Conditions {
+		switch cond.Field {
+		case ScreenerAge:
+			joinProvenance = true
+		case ScreenerMarketCap, ScreenerVolume24h, ScreenerPriceChange24h, ScreenerHolderCount:
+			joinMarket = true
+		}
+	}
+	if joinMarket {
+		query = query.Joins(latestMarketDataJoin)
+	}
+	if joinProvenance {
+		query = query.Joins(provenanceJoin)
+	}
+	
+	direction := "DESC"
+	if sort.Ascending {
+		direction = "ASC"
+	}
+	if sortByMarket {
+		query = query.Order(sortColumn + " " + direction + " NULLS LAST").Order("tokens.created_at DESC")
+	} else {
+		query = query.Order("tokens.created_at " + direction)
+	}
+	query = query.Order("tokens.id")
+	
+	if filter.Query != "" {
+		pattern := "%" + strings.ReplaceAll(strings.ReplaceAll(filter.Query, "%", `\%`), "_", `\_`) + "%"
+		query = query.Where("tokens.symbol ILIKE ? OR tokens.name ILIKE ? OR tokens.mint_address = ?", pattern, pattern, filter.Query)
+	}
+	if filter.Narrative != "" {
+		query = query.Where("tokens.id IN (SELECT token_id FROM token_narrative_tags WHERE narrative = ?)", filter.Narrative)
+	}
+	if filter.MinLiquidityUSD > 0 {
+		query = query.Where(latestLiquidity+" >= ?", filter.MinLiquidityUSD)
+	}
+	
+	now := time.Now()
+	for _, cond := range filter.Conditions {
+		mirrored, ok := screenerOperators[cond.Operator]
+		if !ok {
+			return nil, fmt.Errorf("unsupported screener operator %q", cond.Operator)
+		}
+		if cond.Field == ScreenerAge {
+			// Compare creation times rather than ages: age < 7d means created after now - 7d
+			createdAt := now.Add(-time.Duration(cond.Value * float64(time.Second)))
+			query = query.Where("COALESCE(token_provenances.creation_time, tokens.created_at) "+mirrored+" ?", createdAt)
+			continue
+		}
+		column, ok := screenerColumns[cond.Field]
+		if !ok {
+			return nil, fmt.Errorf("unsupported screener field %q", cond.Field)
+		}
+		query = query.Where(column+" "+cond.Operator+" ?", cond.Value)
+	}
+	
+	err := query.Find(&tokens).Error
+	return tokens, err
+}
+
 func (r *tokenRepository) Update(ctx context.Context, token *models.Token) error {
 	return r.db.WithContext(ctx).Save(token).Error
 }
@@ -67,12 +183,30 @@ func (r *tokenRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 // Market data methods
 func (r *tokenRepository) CreateMarketData(ctx context.Context, data *models.TokenMarketData) error {
-	return r.db.WithContext(ctx).Create(data).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(data).Error; err != nil {
+			return err
+		}
+		return saveLatestMarketData(tx, data)
+	})
 }
 
+// GetLatestMarketData reads the token's row of token_market_latest, falling back to the history for tokens
+// written before the table was kept
 func (r *tokenRepository) GetLatestMarketData(ctx context.Context, tokenID uuid.UUID) (*models.TokenMarketData, error) {
-	var data models.TokenMarketData
+	var latest models.TokenMarketLatest
 	err := r.db.WithContext(ctx).
+		Where("token_id = ?", tokenID).
+		First(&latest).Error
+	if err == nil {
+		return latest.MarketData(), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var data models.TokenMarketData
+	err = r.db.WithContext(ctx).
 		Where("token_id = ?", tokenID).
 		Order("created_at DESC").
 		First(&data).Error
@@ -86,7 +220,22 @@ func (r *tokenRepository) GetLatestMarketData(ctx context.Context, tokenID uuid.
 }
 
 func (r *tokenRepository) UpdateMarketData(ctx context.Context, data *models.TokenMarketData) error {
-	return r.db.WithContext(ctx).Save(data).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(data).Error; err != nil {
+			return err
+		}
+		return saveLatestMarketData(tx, data)
+	})
+}
+
+// saveLatestMarketData copies a written market data row to token_market_latest unless the token already has
+// a newer row there
+func saveLatestMarketData(tx *gorm.DB, data *models.TokenMarketData) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token_id"}},
+		Where:     clause.Where{Exprs: []clause.Expression{gorm.Expr("token_market_latest.created_at <= excluded.created_at")}},
+		UpdateAll: true,
+	}).Create(models.NewTokenMarketLatest(data)).Error
 }
 
 // Trending methods
@@ -94,13 +243,16 @@ func (r *tokenRepository) CreateTrendingRanking(ctx context.Context, ranking *mo
 	return r.db.WithContext(ctx).Create(ranking).Error
 }
 
-func (r *tokenRepository) GetTrendingTokens(ctx context.Context, category, timeframe string, limit int) ([]*models.TokenTrendingRanking, error) {
+func (r *tokenRepository) GetTrendingTokens(ctx context.Context, category, timeframe string, narrative models.TokenNarrative, limit int) ([]*models.TokenTrendingRanking, error) {
 	var rankings []*models.TokenTrendingRanking
 	query := r.db.WithContext(ctx).
 		Preload("Token").
 		Where("category = ? AND timeframe = ?", category, timeframe).
 		Order("rank ASC").
 		Limit(limit)
+	if narrative != "" {
+		query = query.Where("token_id IN (SELECT token_id FROM token_narrative_tags WHERE narrative = ?)", narrative)
+	}
 	
 	err := query.Find(&rankings).Error
 	return rankings, err
@@ -110,6 +262,12 @@ func (r *tokenRepository) UpdateTrendingRanking(ctx context.Context, ranking *mo
 	return r.db.WithContext(ctx).Save(ranking).Error
 }
 
+func (r *tokenRepository) DeleteTrendingRankings(ctx context.Context, category, timeframe string) error {
+	return r.db.WithContext(ctx).
+		Where("category = ? AND timeframe = ?", category, timeframe).
+		Delete(&models.TokenTrendingRanking{}).Error
+}
+
 // Top holders methods
 func (r *tokenRepository) CreateTopHolder(ctx context.Context, holder *models.TokenTopHolders) error {
 	return r.db.WithContext(ctx).Create(holder).Error
@@ -129,6 +287,34 @@ func (r *tokenRepository) UpdateTopHolder(ctx context.Context, holder *models.To
 	return r.db.WithContext(ctx).Save(holder).Error
 }
 
+func (r *tokenRepository) SaveHolderSnapshot(ctx context.Context, holders []*models.TokenHolderSnapshot) error {
+	if len(holders) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(holders, 500).Error
+}
+
+func (r *tokenRepository) GetHolderSnapshotTimes(ctx context.Context, tokenID uuid.UUID, until time.Time, limit int) ([]time.Time, error) {
+	var times []time.Time
+	err := r.db.WithContext(ctx).
+		Model(&models.TokenHolderSnapshot{}).
+		Distinct("taken_at").
+		Where("token_id = ? AND taken_at <= ?", tokenID, until).
+		Order("taken_at DESC").
+		Limit(limit).
+		Pluck("taken_at", &times).Error
+	return times, err
+}
+
+func (r *tokenRepository) GetHolderSnapshot(ctx context.Context, tokenID uuid.UUID, takenAt time.Time) ([]*models.TokenHolderSnapshot, error) {
+	var holders []*models.TokenHolderSnapshot
+	err := r.db.WithContext(ctx).
+		Where("token_id = ? AND taken_at = ?", tokenID, takenAt).
+		Order("rank ASC").
+		Find(&holders).Error
+	return holders, err
+}
+
 // Transaction stats methods
 func (r *tokenRepository) CreateTransactionStats(ctx context.Context, stats *models.TokenTransactionStats) error {
 	return r.db.WithContext(ctx).Create(stats).Error
@@ -150,4 +336,476 @@ func (r *tokenRepository) GetTransactionStats(ctx context.Context, tokenID uuid.
 
 func (r *tokenRepository) UpdateTransactionStats(ctx context.Context, stats *models.TokenTransactionStats) error {
 	return r.db.WithContext(ctx).Save(stats).Error
-}
\ No newline at end of file
+}
+
+// tradeStatsQuery rolls up successful smart money buys and sells together with room trade
+// events. Room events of a transaction already indexed, or shared in several rooms, count once.
+const tradeStatsQuery = `
+WITH trades AS (
+	SELECT token_address, wallet_address, transaction_type AS side, value_usd
+	FROM smart_money_transactions
+	WHERE block_time >= @since AND status = @success AND transaction_type IN (@buy, @sell)
+	UNION ALL
+	SELECT token_address, wallet_address, side, value_usd
+	FROM (
+		SELECT DISTINCT ON (te.tx_signature)
+			te.token_address, te.wallet_address, te.event_type AS side,
+			CASE WHEN te.server_value_usd > 0 THEN te.server_value_usd ELSE te.value_usd END AS value_usd
+		FROM trade_events te
+		WHERE te.block_time >= @since
+			AND NOT EXISTS (SELECT 1 FROM smart_money_transactions smt WHERE smt.signature = te.tx_signature)
+		ORDER BY te.tx_signature, te.created_at
+	) room_trades
+)
+SELECT
+	tokens.id AS token_id,
+	COUNT(*) FILTER (WHERE trades.side = @buy) AS buy_count,
+	COUNT(*) FILTER (WHERE trades.side = @sell) AS sell_count,
+	COUNT(DISTINCT trades.wallet_address) AS unique_traders,
+	COALESCE(SUM(trades.value_usd) FILTER (WHERE trades.side = @buy), 0) AS buy_volume,
+	COALESCE(SUM(trades.value_usd) FILTER (WHERE trades.side = @sell), 0) AS sell_volume
+FROM trades
+JOIN tokens ON tokens.mint_address = trades.token_address
+GROUP BY tokens.id`
+
+func (r *tokenRepository) AggregateTradeStats(ctx context.Context, since time.Time) ([]*models.TokenTransactionStats, error) {
+	var stats []*models.TokenTransactionStats
+	err := r.db.WithContext(ctx).
+		Raw(tradeStatsQuery, map[string]interface{}{
+			"since":   since,
+			"success": models.TransactionStatusSuccess,
+			"buy":     models.TransactionTypeBuy,
+			"sell":    models.TransactionTypeSell,
+		}).
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *tokenRepository) SaveTransactionStats(ctx context.Context, stats []*models.TokenTransactionStats) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "token_id"}, {Name: "timeframe"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"transaction_count", "buy_count", "sell_count", "unique_traders",
+				"buy_volume", "sell_volume", "net_volume", "average_trade_size", "updated_at",
+			}),
+		}).
+		CreateInBatches(stats, 500).Error
+}
+
+func (r *tokenRepository) ResetTransactionStats(ctx context.Context, timeframe string, updatedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TokenTransactionStats{}).
+		Where("timeframe = ? AND updated_at < ? AND transaction_count > 0", timeframe, updatedBefore).
+		Updates(map[string]interface{}{
+			"transaction_count":  0,
+			"buy_count":          0,
+			"sell_count":         0,
+			"unique_traders":     0,
+			"buy_volume":         0,
+			"sell_volume":        0,
+			"net_volume":         0,
+			"average_trade_size": 0,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// Provenance methods
+func (r *tokenRepository) GetProvenance(ctx context.Context, mintAddress string) (*models.TokenProvenance, error) {
+	var provenance models.TokenProvenance
+	err := r.db.WithContext(ctx).Where("mint_address = ?", mintAddress).First(&provenance).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &provenance, nil
+}
+
+func (r *tokenRepository) GetProvenanceByDeployer(ctx context.Context, deployerAddress string, limit int) ([]*models.TokenProvenance, error) {
+	var provenances []*models.TokenProvenance
+	err := r.db.WithContext(ctx).
+		Where("deployer_address = ?", deployerAddress).
+		Order("creation_time DESC NULLS LAST").
+		Limit(limit).
+		Find(&provenances).Error
+	return provenances, err
+}
+
+func (r *tokenRepository) SaveProvenance(ctx context.Context, provenance *models.TokenProvenance) error {
+	return r.db.WithContext(ctx).Save(provenance).Error
+}
+
+// Flag methods
+func (r *tokenRepository) GetFlag(ctx context.Context, mintAddress string) (*models.TokenFlag, error) {
+	var flag models.TokenFlag
+	err := r.db.WithContext(ctx).Where("mint_address = ?", mintAddress).First(&flag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (r *tokenRepository) GetActiveFlags(ctx context.Context, mintAddresses []string) ([]*models.TokenFlag, error) {
+	var flags []*models.TokenFlag
+	if len(mintAddresses) == 0 {
+		return flags, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("mint_address IN ? AND active = ?", mintAddresses, true).
+		Find(&flags).Error
+	return flags, err
+}
+
+func (r *tokenRepository) ListFlags(ctx context.Context, activeOnly bool, limit, offset int) ([]*models.TokenFlag, error) {
+	var flags []*models.TokenFlag
+	query := r.db.WithContext(ctx)
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.
+		Order("updated_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&flags).Error
+	return flags, err
+}
+
+func (r *tokenRepository) SaveFlag(ctx context.Context, flag *models.TokenFlag, event *models.TokenFlagEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "mint_address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"type", "reason", "source", "flagged_by", "active", "updated_at"}),
+		}).Create(flag).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+}
+
+func (r *tokenRepository) GetFlagHistory(ctx context.Context, mintAddress string, limit int) ([]*models.TokenFlagEvent, error) {
+	var events []*models.TokenFlagEvent
+	err := r.db.WithContext(ctx).
+		Where("mint_address = ?", mintAddress).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// Metadata screen methods
+func (r *tokenRepository) GetMetadataScreen(ctx context.Context, mintAddress string) (*models.TokenMetadataScreen, error) {
+	var screen models.TokenMetadataScreen
+	err := r.db.WithContext(ctx).Where("mint_address = ?", mintAddress).First(&screen).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &screen, nil
+}
+
+func (r *tokenRepository) SaveMetadataScreen(ctx context.Context, screen *models.TokenMetadataScreen) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "mint_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"signals", "suspicious", "ai_checked", "screened_at", "updated_at"}),
+	}).Create(screen).Error
+}
+
+func (r *tokenRepository) FindByMetadata(ctx context.Context, excludeMint, description string, links []string, limit int) ([]*models.Token, error) {
+	var tokens []*models.Token
+	if description == "" && len(links) == 0 {
+		return tokens, nil
+	}
+
+	match := r.db.Where("1 = 0")
+	if description != "" {
+		match = match.Or("description = ?", description)
+	}
+	if len(links) > 0 {
+		match = match.Or("website IN ?", links).Or("twitter IN ?", links).Or("telegram IN ?", links)
+	}
+	err := r.db.WithContext(ctx).
+		Where("mint_address <> ?", excludeMint).
+		Where(match).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// Asset price methods
+func (r *tokenRepository) CreateAssetPrices(ctx context.Context, prices []*models.AssetPrice) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&prices).Error
+}
+
+func (r *tokenRepository) GetLatestAssetPrice(ctx context.Context, mintAddress string) (*models.AssetPrice, error) {
+	var price models.AssetPrice
+	err := r.db.WithContext(ctx).
+		Where("mint_address = ?", mintAddress).
+		Order("fetched_at DESC").
+		First(&price).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &price, nil
+}
+
+func (r *tokenRepository) DeleteAssetPricesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("fetched_at < ?", before).Delete(&models.AssetPrice{})
+	return result.RowsAffected, result.Error
+}
+
+// Candle methods
+func (r *tokenRepository) SaveCandles(ctx context.Context, candles []*models.TokenCandle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "token_id"}, {Name: "resolution"}, {Name: "open_time"}},
+			DoUpdates: clause.AssignmentColumns([]string{"open", "high", "low", "close", "volume"}),
+		}).
+		CreateInBatches(candles, 500).Error
+}
+
+func (r *tokenRepository) GetCandles(ctx context.Context, tokenID uuid.UUID, resolution string, from, to time.Time) ([]*models.TokenCandle, error) {
+	var candles []*models.TokenCandle
+	err := r.db.WithContext(ctx).
+		Where("token_id = ? AND resolution = ? AND open_time >= ? AND open_time < ?", tokenID, resolution, from, to).
+		Order("open_time ASC").
+		Find(&candles).Error
+	return candles, err
+}
+
+// Narrative methods
+func (r *tokenRepository) GetNarratives(ctx context.Context, tokenIDs []uuid.UUID) ([]*models.TokenNarrativeTag, error) {
+	var tags []*models.TokenNarrativeTag
+	if len(tokenIDs) == 0 {
+		return tags, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("token_id IN ?", tokenIDs).
+		Order("created_at ASC").
+		Find(&tags).Error
+	return tags, err
+}
+
+func (r *tokenRepository) SaveNarratives(ctx context.Context, tokenID uuid.UUID, tags []*models.TokenNarrativeTag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("token_id = ?", tokenID).Delete(&models.TokenNarrativeTag{}).Error; err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		for _, tag := range tags {
+			tag.TokenID = tokenID
+		}
+		return tx.Create(&tags).Error
+	})
+}
+
+// Recommendation methods
+func (r *tokenRepository) CreateRecommendationRecord(ctx context.Context, record *models.TokenRecommendationRecord) error {
+	return r.db.WithContext(ctx).Create(record).Error
+}
+
+func (r *tokenRepository) GetRecommendationRecords(ctx context.Context, tokenID uuid.UUID, from, to time.Time) ([]*models.TokenRecommendationRecord, error) {
+	var records []*models.TokenRecommendationRecord
+	err := r.db.WithContext(ctx).
+		Where("token_id = ? AND created_at >= ? AND created_at < ?", tokenID, from, to).
+		Order("created_at ASC").
+		Find(&records).Error
+	return records, err
+}
+
+// Screener preset methods
+func (r *tokenRepository) GetScreenerPreset(ctx context.Context, id uuid.UUID) (*models.ScreenerPreset, error) {
+	var preset models.ScreenerPreset
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&preset).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &preset, nil
+}
+
+func (r *tokenRepository) ListScreenerPresets(ctx context.Context, walletAddress string) ([]*models.ScreenerPreset, error) {
+	var presets []*models.ScreenerPreset
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ?", walletAddress).
+		Order("name ASC").
+		Find(&presets).Error
+	return presets, err
+}
+
+func (r *tokenRepository) CountScreenerPresets(ctx context.Context, walletAddress string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.ScreenerPreset{}).
+		Where("wallet_address = ?", walletAddress).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *tokenRepository) SaveScreenerPreset(ctx context.Context, preset *models.ScreenerPreset) error {
+	// Returning reloads the stored row, so an overwritten preset keeps its original ID
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "wallet_address"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"filters", "sort", "sort_order", "updated_at"}),
+		}, clause.Returning{}).
+		Create(preset).Error
+}
+
+func (r *tokenRepository) DeleteScreenerPreset(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.ScreenerPreset{}, "id = ?", id).Error
+}
+
+// Discovery methods
+func (r *tokenRepository) GetDiscoveredMints(ctx context.Context, mintAddresses []string) ([]string, error) {
+	var mints []string
+	if len(mintAddresses) == 0 {
+		return mints, nil
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.TokenDiscovery{}).
+		Where("mint_address IN ?", mintAddresses).
+		Pluck("mint_address", &mints).Error
+	return mints, err
+}
+
+func (r *tokenRepository) CreateDiscovery(ctx context.Context, discovery *models.TokenDiscovery) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "mint_address"}}, DoNothing: true}).
+		Create(discovery)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *tokenRepository) ListDiscoveries(ctx context.Context, riskLevel models.TokenRiskLevel, limit, offset int) ([]*models.TokenDiscovery, error) {
+	var discoveries []*models.TokenDiscovery
+	query := r.db.WithContext(ctx).
+		Preload("Token").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset)
+	if riskLevel != "" {
+		query = query.Where("risk_level = ?", riskLevel)
+	}
+	
+	err := query.Find(&discoveries).Error
+	return discoveries, err
+}
+
+// Momentum alert methods
+func (r *tokenRepository) CreateMomentumAlert(ctx context.Context, alert *models.MomentumAlert) error {
+	return r.db.WithContext(ctx).Create(alert).Error
+}
+
+func (r *tokenRepository) GetLatestMomentumAlerts(ctx context.Context, mintAddresses []string, since time.Time) ([]*models.MomentumAlert, error) {
+	var alerts []*models.MomentumAlert
+	if len(mintAddresses) == 0 {
+		return alerts, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("mint_address IN ? AND created_at >= ?", mintAddresses, since).
+		Order("created_at DESC").
+		Find(&alerts).Error
+	return alerts, err
+}
+
+func (r *tokenRepository) ListMomentumAlerts(ctx context.Context, limit, offset int) ([]*models.MomentumAlert, error) {
+	var alerts []*models.MomentumAlert
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// Unlock schedule methods
+func (r *tokenRepository) CreateUnlocks(ctx context.Context, unlocks []*models.TokenUnlockSchedule) error {
+	if len(unlocks) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Omit(clause.Associations).
+		CreateInBatches(unlocks, 500).Error
+}
+
+func (r *tokenRepository) GetUnlockByID(ctx context.Context, id uuid.UUID) (*models.TokenUnlockSchedule, error) {
+	var unlock models.TokenUnlockSchedule
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&unlock).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &unlock, nil
+}
+
+func (r *tokenRepository) UpdateUnlock(ctx context.Context, unlock *models.TokenUnlockSchedule) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(unlock).Error
+}
+
+func (r *tokenRepository) DeleteUnlock(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.TokenUnlockSchedule{}, id).Error
+}
+
+func (r *tokenRepository) GetUnlocksBetween(ctx context.Context, from, to time.Time, limit int) ([]*models.TokenUnlockSchedule, error) {
+	var unlocks []*models.TokenUnlockSchedule
+	err := r.db.WithContext(ctx).
+		Preload("Token").
+		Where("unlock_at >= ? AND unlock_at < ?", from, to).
+		Order("unlock_at ASC").
+		Limit(limit).
+		Find(&unlocks).Error
+	return unlocks, err
+}
+
+func (r *tokenRepository) GetTokenUnlocks(ctx context.Context, tokenID uuid.UUID, from time.Time) ([]*models.TokenUnlockSchedule, error) {
+	var unlocks []*models.TokenUnlockSchedule
+	err := r.db.WithContext(ctx).
+		Where("token_id = ? AND unlock_at >= ?", tokenID, from).
+		Order("unlock_at ASC").
+		Find(&unlocks).Error
+	return unlocks, err
+}
+
+func (r *tokenRepository) GetUnwarnedUnlocks(ctx context.Context, from, to time.Time, minPercent float64) ([]*models.TokenUnlockSchedule, error) {
+	var unlocks []*models.TokenUnlockSchedule
+	err := r.db.WithContext(ctx).
+		Preload("Token").
+		Where("warned_at IS NULL AND unlock_at >= ? AND unlock_at < ? AND percent_of_supply >= ?", from, to, minPercent).
+		Order("unlock_at ASC").
+		Find(&unlocks).Error
+	return unlocks, err
+}
+
+func (r *tokenRepository) MarkUnlockWarned(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TokenUnlockSchedule{}).
+		Where("id = ?", id).
+		Update("warned_at", at).Error
+}
@@ -111,6 +111,69 @@ func (r *transactionRepository) GetRecentTransactions(ctx context.Context, hours
 	return transactions, err
 }
 
+func (r *transactionRepository) GetByWalletBetween(ctx context.Context, walletAddress string, from, to time.Time, limit, offset int) ([]*models.SmartMoneyTransaction, error) {
+	var transactions []*models.SmartMoneyTransaction
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ? AND block_time >= ? AND block_time < ?", walletAddress, from, to).
+		Order("block_time ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *transactionRepository) GetUnfinalized(ctx context.Context, limit int) ([]*models.SmartMoneyTransaction, error) {
+	var transactions []*models.SmartMoneyTransaction
+	err := r.db.WithContext(ctx).
+		Where("finalized = ?", false).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// SetFinality marks a transaction finalized; a reverted one keeps its record with the reverted status
+func (r *transactionRepository) SetFinality(ctx context.Context, id uuid.UUID, reverted bool) error {
+	updates := map[string]interface{}{"finalized": true}
+	if reverted {
+		updates["status"] = models.TransactionStatusReverted
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.SmartMoneyTransaction{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+}
+
+// executionStatsQuery averages fees and slippage of successful swaps per platform; AVG skips rows where
+// the priority fee or slippage is unknown
+const executionStatsQuery = `
+SELECT
+	COALESCE(NULLIF(instruction_type, ''), 'Unknown') AS platform,
+	COUNT(*) AS trades,
+	AVG(fee_lamports) AS avg_fee_lamports,
+	AVG(priority_fee_lamports) AS avg_priority_fee_lamports,
+	COUNT(priority_fee_lamports) AS priority_fee_samples,
+	AVG(slippage_percent) AS avg_slippage_percent,
+	COUNT(slippage_percent) AS slippage_samples
+FROM smart_money_transactions
+WHERE status = @success
+	AND block_time >= @since
+	AND (@wallet = '' OR wallet_address = @wallet)
+GROUP BY 1
+ORDER BY trades DESC`
+
+func (r *transactionRepository) GetExecutionStats(ctx context.Context, walletAddress string, since time.Time) ([]*models.PlatformExecutionStats, error) {
+	var stats []*models.PlatformExecutionStats
+	err := r.db.WithContext(ctx).
+		Raw(executionStatsQuery, map[string]interface{}{
+			"success": models.TransactionStatusSuccess,
+			"since":   since,
+			"wallet":  walletAddress,
+		}).
+		Scan(&stats).Error
+	return stats, err
+}
+
 // Analysis methods
 func (r *transactionRepository) CreateAnalysis(ctx context.Context, analysis *models.TransactionAnalysis) error {
 	return r.db.WithContext(ctx).Create(analysis).Error
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type userSettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewUserSettingsRepository creates a new user settings repository instance
+func NewUserSettingsRepository(db *gorm.DB) UserSettingsRepository {
+	return &userSettingsRepository{db: db}
+}
+
+func (r *userSettingsRepository) GetByWallet(ctx context.Context, walletAddress string) (*models.UserSettings, error) {
+	var settings models.UserSettings
+	err := r.db.WithContext(ctx).Where("wallet_address = ?", walletAddress).First(&settings).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *userSettingsRepository) Save(ctx context.Context, settings *models.UserSettings) error {
+	return r.db.WithContext(ctx).Save(settings).Error
+}
+
+func (r *userSettingsRepository) GetByWallets(ctx context.Context, walletAddresses []string) ([]*models.UserSettings, error) {
+	var settings []*models.UserSettings
+	if len(walletAddresses) == 0 {
+		return settings, nil
+	}
+	err := r.db.WithContext(ctx).Where("wallet_address IN ?", walletAddresses).Find(&settings).Error
+	return settings, err
+}
+
+// Email verification methods
+func (r *userSettingsRepository) SaveEmailVerification(ctx context.Context, verification *models.EmailVerification) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "wallet_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "code_hash", "attempts", "expires_at", "created_at"}),
+	}).Create(verification).Error
+}
+
+func (r *userSettingsRepository) GetEmailVerification(ctx context.Context, walletAddress string) (*models.EmailVerification, error) {
+	var verification models.EmailVerification
+	err := r.db.WithContext(ctx).Where("wallet_address = ?", walletAddress).First(&verification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &verification, nil
+}
+
+func (r *userSettingsRepository) IncrementEmailVerificationAttempts(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.EmailVerification{}).
+		Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+func (r *userSettingsRepository) DeleteEmailVerification(ctx context.Context, walletAddress string) error {
+	return r.db.WithContext(ctx).
+		Where("wallet_address = ?", walletAddress).
+		Delete(&models.EmailVerification{}).Error
+}
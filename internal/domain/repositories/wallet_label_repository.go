@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// ErrLabelExists is returned by Create and Update when the wallet already carries the label
+var ErrLabelExists = errors.New("wallet already has this label")
+
+// uniqueViolation is the Postgres error code of a unique constraint violation
+const uniqueViolation = "23505"
+
+type walletLabelRepository struct {
+	db *gorm.DB
+}
+
+// NewWalletLabelRepository creates a new wallet label repository instance
+func NewWalletLabelRepository(db *gorm.DB) WalletLabelRepository {
+	return &walletLabelRepository{db: db}
+}
+
+func (r *walletLabelRepository) Create(ctx context.Context, label *models.WalletLabel) error {
+	return translateLabelError(r.db.WithContext(ctx).Create(label).Error)
+}
+
+func (r *walletLabelRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WalletLabel, error) {
+	var label models.WalletLabel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&label).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *walletLabelRepository) GetByWallet(ctx context.Context, walletAddress string) ([]*models.WalletLabel, error) {
+	var labels []*models.WalletLabel
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ?", walletAddress).
+		Order("created_at ASC").
+		Find(&labels).Error
+	return labels, err
+}
+
+func (r *walletLabelRepository) GetByWallets(ctx context.Context, walletAddresses []string) ([]*models.WalletLabel, error) {
+	var labels []*models.WalletLabel
+	if len(walletAddresses) == 0 {
+		return labels, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("wallet_address IN ?", walletAddresses).
+		Find(&labels).Error
+	return labels, err
+}
+
+func (r *walletLabelRepository) List(ctx context.Context, label models.WalletLabelType, limit, offset int) ([]*models.WalletLabel, error) {
+	var labels []*models.WalletLabel
+	query := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset)
+
+	if label != "" {
+		query = query.Where("label = ?", label)
+	}
+
+	err := query.Find(&labels).Error
+	return labels, err
+}
+
+func (r *walletLabelRepository) Update(ctx context.Context, label *models.WalletLabel) error {
+	return translateLabelError(r.db.WithContext(ctx).Save(label).Error)
+}
+
+// translateLabelError reports a hit on the wallet and label unique index as ErrLabelExists
+func translateLabelError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrLabelExists
+	}
+	return err
+}
+
+func (r *walletLabelRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.WalletLabel{}, id).Error
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type walletWatchRepository struct {
+	db *gorm.DB
+}
+
+// NewWalletWatchRepository creates a new wallet watch repository instance
+func NewWalletWatchRepository(db *gorm.DB) WalletWatchRepository {
+	return &walletWatchRepository{db: db}
+}
+
+func (r *walletWatchRepository) Create(ctx context.Context, watch *models.WalletWatch) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(watch)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *walletWatchRepository) Get(ctx context.Context, walletAddress, watchedAddress string) (*models.WalletWatch, error) {
+	var watch models.WalletWatch
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ? AND watched_address = ?", walletAddress, watchedAddress).
+		First(&watch).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &watch, nil
+}
+
+func (r *walletWatchRepository) ListByWallet(ctx context.Context, walletAddress string) ([]*models.WalletWatch, error) {
+	var watches []*models.WalletWatch
+	err := r.db.WithContext(ctx).
+		Where("wallet_address = ?", walletAddress).
+		Order("created_at DESC").
+		Find(&watches).Error
+	return watches, err
+}
+
+func (r *walletWatchRepository) List(ctx context.Context, limit, offset int) ([]*models.WalletWatch, error) {
+	var watches []*models.WalletWatch
+	err := r.db.WithContext(ctx).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&watches).Error
+	return watches, err
+}
+
+func (r *walletWatchRepository) CountByWallet(ctx context.Context, walletAddress string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.WalletWatch{}).
+		Where("wallet_address = ?", walletAddress).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *walletWatchRepository) Delete(ctx context.Context, walletAddress, watchedAddress string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("wallet_address = ? AND watched_address = ?", walletAddress, watchedAddress).
+		Delete(&models.WalletWatch{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
+	"github.com/emiyaio/solana-wallet-service/internal/services/admin"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// AdminHandler handles HTTP requests for the admin dashboard
+type AdminHandler struct {
+	statsService  admin.StatsService
+	accessService admin.AccessService
+	quickNode     blockchain.QuickNodeService
+	wsService     room.WebSocketService
+	adminGuard    *middleware.AdminGuard
+	logger        *logrus.Logger
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(statsService admin.StatsService, accessService admin.AccessService, quickNode blockchain.QuickNodeService, wsService room.WebSocketService, adminGuard *middleware.AdminGuard, logger *logrus.Logger) *AdminHandler {
+	return &AdminHandler{
+		statsService:  statsService,
+		accessService: accessService,
+		quickNode:     quickNode,
+		wsService:     wsService,
+		adminGuard:    adminGuard,
+		logger:        logger,
+	}
+}
+
+// GetStats returns live service counts: rooms, connections, subscriptions, tokens, job lag and external API error rates
+func (h *AdminHandler) GetStats(c *gin.Context) {
+	stats, err := h.statsService.GetStats(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to get admin stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+// ListAuditLogs lists admin actions, newest first (query: actor, limit, offset)
+func (h *AdminHandler) ListAuditLogs(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	entries, err := h.accessService.ListAuditLogs(c.Request.Context(), c.Query("actor"), limit, offset)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list admin audit logs")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    entries,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(entries),
+		},
+	})
+}
+
+// ReconnectQuickNode drops this instance's QuickNode connection and reconnects it, also after reconnecting gave up.
+// The reconnect runs in the background; the returned status is the state before it.
+func (h *AdminHandler) ReconnectQuickNode(c *gin.Context) {
+	status := h.quickNode.Status()
+	h.quickNode.Reconnect()
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    status,
+	})
+}
+
+// Drain stops this instance from accepting WebSocket connections and tells its clients to reconnect, which
+// reaches another instance; connections left after the grace period are closed (query: grace_seconds)
+func (h *AdminHandler) Drain(c *gin.Context) {
+	var grace time.Duration
+	if raw := c.Query("grace_seconds"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "grace_seconds must be a positive number of seconds"})
+			return
+		}
+		grace = time.Duration(seconds) * time.Second
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    h.wsService.Drain(grace),
+	})
+}
+
+// GetDrainStatus reports whether this instance is draining and the connections it still holds; drained is true
+// once none are left
+func (h *AdminHandler) GetDrainStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.wsService.DrainStatus(),
+	})
+}
+
+// CancelDrain makes this instance accept WebSocket connections again
+func (h *AdminHandler) CancelDrain(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.wsService.CancelDrain(),
+	})
+}
+
+// RegisterRoutes registers admin dashboard API routes
+func (h *AdminHandler) RegisterRoutes(router *gin.RouterGroup) {
+	dashboard := router.Group("/admin")
+	{
+		dashboard.GET("/stats", h.adminGuard.Require(models.AdminRoleViewer), h.GetStats)
+		dashboard.GET("/audit", h.adminGuard.Require(models.AdminRoleAdmin), h.ListAuditLogs)
+		dashboard.POST("/quicknode/reconnect", h.adminGuard.Require(models.AdminRoleOperator), h.ReconnectQuickNode)
+		dashboard.POST("/drain", h.adminGuard.Require(models.AdminRoleOperator), h.Drain)
+		dashboard.GET("/drain", h.adminGuard.Require(models.AdminRoleViewer), h.GetDrainStatus)
+		dashboard.DELETE("/drain", h.adminGuard.Require(models.AdminRoleOperator), h.CancelDrain)
+	}
+}
@@ -1,24 +1,30 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
 	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
 )
 
 // AIHandler handles AI-related API requests
 type AIHandler struct {
-	aiService ai.LangChainService
-	logger    *logrus.Logger
+	aiService    ai.LangChainService
+	usageService ai.UsageService
+	logger       *logrus.Logger
 }
 
 // NewAIHandler creates a new AI handler
-func NewAIHandler(aiService ai.LangChainService, logger *logrus.Logger) *AIHandler {
+func NewAIHandler(aiService ai.LangChainService, usageService ai.UsageService, logger *logrus.Logger) *AIHandler {
 	return &AIHandler{
-		aiService: aiService,
-		logger:    logger,
+		aiService:    aiService,
+		usageService: usageService,
+		logger:       logger,
 	}
 }
 
@@ -29,8 +35,14 @@ func NewAIHandler(aiService ai.LangChainService, logger *logrus.Logger) *AIHandl
 // @Accept json
 // @Produce json
 // @Param token_identifier path string true "Token mint address or symbol"
+// @Param lang query string false "Response language (en, zh, es, ja, ko)"
+// @Param wallet query string false "Wallet whose saved language and timezone are applied"
+// @Param model query string false "Configured model name, see /api/v1/ai/models"
+// @Param temperature query number false "Temperature override within the model's bounds"
+// @Param max_tokens query int false "Max tokens override within the model's bounds"
 // @Success 200 {object} ai.TokenAnalysisResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/ai/analyze/{token_identifier} [get]
 func (h *AIHandler) AnalyzeToken(c *gin.Context) {
@@ -43,19 +55,28 @@ func (h *AIHandler) AnalyzeToken(c *gin.Context) {
 		return
 	}
 
-	result, err := h.aiService.AnalyzeToken(c.Request.Context(), tokenIdentifier)
+	opts, ok := h.modelOptions(c, c.Query("model"), c.Query("temperature"), c.Query("max_tokens"))
+	if !ok {
+		return
+	}
+
+	wallet := c.Query("wallet")
+	prefs, ok := h.resolvePreferences(c, c.Query("lang"), wallet)
+	if !ok {
+		return
+	}
+	prefs.Model = opts
+	if err := checkAIQuota(c, h.usageService, wallet); err != nil {
+		h.respondError(c, h.logger.WithField("wallet", wallet), err, "Failed to check AI quota")
+		return
+	}
+
+	result, err := h.aiService.AnalyzeToken(c.Request.Context(), tokenIdentifier, prefs)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":            err,
-			"token_identifier": tokenIdentifier,
-		}).Error("Failed to analyze token")
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to analyze token",
-		})
+		h.respondError(c, h.logger.WithField("token_identifier", tokenIdentifier), err, "Failed to analyze token")
 		return
 	}
+	recordAIUsage(c, h.usageService, h.logger, wallet, models.AIFeatureAnalyze, result.Model, result.Usage)
 
 	c.JSON(http.StatusOK, result)
 }
@@ -69,15 +90,12 @@ func (h *AIHandler) AnalyzeToken(c *gin.Context) {
 // @Param request body ChatRequest true "Chat request"
 // @Success 200 {object} ai.ChatResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/ai/chat [post]
 func (h *AIHandler) ChatCompletion(c *gin.Context) {
 	var req ChatRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request format",
-		})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 
@@ -89,29 +107,127 @@ func (h *AIHandler) ChatCompletion(c *gin.Context) {
 		return
 	}
 
-	result, err := h.aiService.GetChatCompletion(c.Request.Context(), req.Message)
+	prefs, ok := h.resolvePreferences(c, req.Language, req.WalletAddress)
+	if !ok {
+		return
+	}
+	prefs.Model = &ai.ModelOptions{
+		Model:       req.Model,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	}
+	if err := checkAIQuota(c, h.usageService, req.WalletAddress); err != nil {
+		h.respondError(c, h.logger.WithField("wallet", req.WalletAddress), err, "Failed to check AI quota")
+		return
+	}
+
+	result, err := h.aiService.GetChatCompletion(c.Request.Context(), req.Message, prefs)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":   err,
-			"message": req.Message,
-		}).Error("Failed to get chat completion")
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to process chat request",
-		})
+		h.respondError(c, h.logger.WithField("message", req.Message), err, "Failed to process chat request")
 		return
 	}
+	recordAIUsage(c, h.usageService, h.logger, req.WalletAddress, models.AIFeatureChat, result.Model, result.Usage)
 
 	c.JSON(http.StatusOK, result)
 }
 
+// ListModels returns the models callers may choose and the bounds of their temperature and max tokens overrides
+func (h *AIHandler) ListModels(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.aiService.ListModels(),
+	})
+}
+
+// GetUsage returns a wallet's AI token quota, this month's usage per feature and its latest AI requests
+// (query: limit, offset)
+func (h *AIHandler) GetUsage(c *gin.Context) {
+	address := c.Param("address")
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	summary, err := h.usageService.GetUsage(c.Request.Context(), address, limit, offset)
+	if err != nil {
+		respondError(c, h.logger.WithField("wallet", address), err, "Failed to get AI usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+// modelOptions parses model overrides from query parameters and writes the error response if they are malformed
+func (h *AIHandler) modelOptions(c *gin.Context, model, temperature, maxTokens string) (*ai.ModelOptions, bool) {
+	opts := &ai.ModelOptions{Model: model}
+	if temperature != "" {
+		value, err := strconv.ParseFloat(temperature, 64)
+		if err != nil {
+			h.respondError(c, h.logger, fmt.Errorf("%w: temperature must be a number", ai.ErrInvalidModelParams), "Invalid temperature")
+			return nil, false
+		}
+		opts.Temperature = &value
+	}
+	if maxTokens != "" {
+		value, err := strconv.Atoi(maxTokens)
+		if err != nil {
+			h.respondError(c, h.logger, fmt.Errorf("%w: max_tokens must be an integer", ai.ErrInvalidModelParams), "Invalid max tokens")
+			return nil, false
+		}
+		opts.MaxTokens = value
+	}
+	return opts, true
+}
+
+// resolvePreferences determines the response preferences and writes the error response if the language is unsupported
+func (h *AIHandler) resolvePreferences(c *gin.Context, requested, walletAddress string) (*ai.Preferences, bool) {
+	prefs, err := h.aiService.ResolvePreferences(c.Request.Context(), requested, walletAddress)
+	if err != nil {
+		h.respondError(c, h.logger.WithField("language", requested), err, "Failed to resolve preferences")
+		return nil, false
+	}
+	return prefs, true
+}
+
+// respondError is the shared error mapping in the AI handler's ErrorResponse shape
+func (h *AIHandler) respondError(c *gin.Context, logger logrus.FieldLogger, err error, message string) {
+	if m, ok := lookupError(err); ok {
+		c.JSON(m.status, ErrorResponse{
+			Error:   http.StatusText(m.status),
+			Message: m.text(),
+			Code:    m.code,
+		})
+		return
+	}
+
+	logger.WithField("error", err).Error(message)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Internal Server Error",
+		Message: message,
+		Code:    codeInternal,
+	})
+}
+
 // Request/Response structures
 type ChatRequest struct {
-	Message string `json:"message" binding:"required"`
+	Message       string   `json:"message" binding:"required"`
+	Language      string   `json:"language,omitempty"`
+	WalletAddress string   `json:"wallet_address,omitempty"`
+	Model         string   `json:"model,omitempty"`       // configured model name, see /api/v1/ai/models
+	Temperature   *float64 `json:"temperature,omitempty"` // within the model's bounds
+	MaxTokens     int      `json:"max_tokens,omitempty"`  // within the model's bounds
 }
 
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
+	Code    string `json:"code,omitempty"` // machine-readable, see errorMappings
 }
\ No newline at end of file
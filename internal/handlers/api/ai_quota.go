@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
+)
+
+// aiSessionHeader optionally groups a wallet's AI usage by client session
+const aiSessionHeader = "X-Session-ID"
+
+// checkAIQuota sets the wallet's quota headers and returns ai.ErrQuotaExceeded when it is spent.
+// Requests naming no wallet are not metered.
+func checkAIQuota(c *gin.Context, usageService ai.UsageService, walletAddress string) error {
+	if walletAddress == "" {
+		return nil
+	}
+
+	quota, err := usageService.CheckQuota(c.Request.Context(), walletAddress)
+	if quota != nil {
+		setQuotaHeaders(c, quota)
+	}
+	if errors.Is(err, ai.ErrQuotaExceeded) {
+		c.Header("Retry-After", strconv.Itoa(int(time.Until(quota.ResetAt()).Seconds())+1))
+	}
+	return err
+}
+
+// recordAIUsage accounts the tokens an answer used and refreshes the quota headers; the answer stands
+// even if accounting fails
+func recordAIUsage(c *gin.Context, usageService ai.UsageService, logger *logrus.Logger, walletAddress string, feature models.AIFeature, model string, usage ai.Usage) {
+	if walletAddress == "" {
+		return
+	}
+
+	// The request may be cancelled once the answer is written, so accounting does not use its context
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	quota, err := usageService.RecordUsage(ctx, &models.AIUsage{
+		WalletAddress:    walletAddress,
+		SessionID:        truncate(c.GetHeader(aiSessionHeader), 64),
+		Feature:          feature,
+		Model:            model,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":   err,
+			"wallet":  walletAddress,
+			"feature": feature,
+		}).Error("Failed to record AI usage")
+		return
+	}
+	setQuotaHeaders(c, quota)
+}
+
+// setQuotaHeaders reports the remaining daily and monthly tokens of capped quotas
+func setQuotaHeaders(c *gin.Context, quota *ai.Quota) {
+	if quota.DailyLimit >= 0 {
+		c.Header("X-AI-Quota-Daily-Limit", strconv.FormatInt(quota.DailyLimit, 10))
+		c.Header("X-AI-Quota-Daily-Remaining", strconv.FormatInt(quota.DailyRemaining, 10))
+	}
+	if quota.MonthlyLimit >= 0 {
+		c.Header("X-AI-Quota-Monthly-Limit", strconv.FormatInt(quota.MonthlyLimit, 10))
+		c.Header("X-AI-Quota-Monthly-Remaining", strconv.FormatInt(quota.MonthlyRemaining, 10))
+	}
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
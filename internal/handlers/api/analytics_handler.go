@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/analytics"
+)
+
+// AnalyticsHandler handles HTTP requests for curated analytics queries
+type AnalyticsHandler struct {
+	analyticsService analytics.AnalyticsService
+	logger           *logrus.Logger
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(analyticsService analytics.AnalyticsService, logger *logrus.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
+
+// ListQueries lists the available queries and their params
+func (h *AnalyticsHandler) ListQueries(c *gin.Context) {
+	queries := h.analyticsService.ListQueries()
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    queries,
+		"count":   len(queries),
+	})
+}
+
+// RunQuery runs a query by name with params taken from the query string
+func (h *AnalyticsHandler) RunQuery(c *gin.Context) {
+	name := c.Param("name")
+
+	params := make(map[string]string)
+	for key, values := range c.Request.URL.Query() {
+		if len(values) > 0 {
+			params[key] = values[0]
+		}
+	}
+
+	result, err := h.analyticsService.RunQuery(c.Request.Context(), name, params)
+	if err != nil {
+		if errors.Is(err, analytics.ErrUnknownQuery) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Query not found"})
+			return
+		}
+		if errors.Is(err, analytics.ErrInvalidParam) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": name,
+		}).Error("Failed to run analytics query")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run analytics query"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetDexShare returns each DEX platform's share of detected trades over a timeframe
+func (h *AnalyticsHandler) GetDexShare(c *gin.Context) {
+	share, err := h.analyticsService.GetDexShare(c.Request.Context(), c.DefaultQuery("timeframe", "7d"))
+	if err != nil {
+		if errors.Is(err, analytics.ErrInvalidParam) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to get DEX share")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get DEX share"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    share,
+	})
+}
+
+// RegisterRoutes registers analytics API routes
+func (h *AnalyticsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	analyticsGroup := router.Group("/analytics")
+	{
+		analyticsGroup.GET("/queries", h.ListQueries)
+		analyticsGroup.GET("/queries/:name", h.RunQuery)
+		analyticsGroup.GET("/dex-share", h.GetDexShare)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
+	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
+	"github.com/emiyaio/solana-wallet-service/internal/services/assistant"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// AssistantHandler handles HTTP requests for the room AI assistant
+type AssistantHandler struct {
+	assistantService assistant.RoomAssistantService
+	usageService     ai.UsageService
+	walletLimiter    *middleware.WalletRateLimiter
+	logger           *logrus.Logger
+}
+
+// NewAssistantHandler creates a new room assistant handler
+func NewAssistantHandler(assistantService assistant.RoomAssistantService, usageService ai.UsageService, walletLimiter *middleware.WalletRateLimiter, logger *logrus.Logger) *AssistantHandler {
+	return &AssistantHandler{
+		assistantService: assistantService,
+		usageService:     usageService,
+		walletLimiter:    walletLimiter,
+		logger:           logger,
+	}
+}
+
+// Ask answers a room member's question from the room's token, trades and shares
+func (h *AssistantHandler) Ask(c *gin.Context) {
+	roomID := c.Param("roomId")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room ID is required"})
+		return
+	}
+
+	var req assistant.AskRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	req.RoomID = roomID
+
+	err := checkAIQuota(c, h.usageService, req.WalletAddress)
+	var result *assistant.AskResult
+	if err == nil {
+		result, err = h.assistantService.Ask(c.Request.Context(), &req)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, ai.ErrQuotaExceeded):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "code": "ai_quota_exceeded"})
+		case errors.Is(err, assistant.ErrEmptyQuestion), errors.Is(err, assistant.ErrQuestionTooLong),
+			errors.Is(err, ai.ErrUnsupportedLanguage):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, room.ErrRoomNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+		case errors.Is(err, room.ErrRoomClosed):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Room is not active"})
+		case errors.Is(err, room.ErrNotMember):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			if respondThrottled(c, err) {
+				return
+			}
+			h.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"room_id": roomID,
+				"wallet":  req.WalletAddress,
+			}).Error("Failed to answer room question")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to answer question"})
+		}
+		return
+	}
+	recordAIUsage(c, h.usageService, h.logger, req.WalletAddress, models.AIFeatureRoomAsk, result.Model, result.Usage)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// RegisterRoutes registers room assistant API routes
+func (h *AssistantHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/rooms/:roomId/ai/ask", h.walletLimiter.AI(), h.Ask)
+}
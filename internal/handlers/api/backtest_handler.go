@@ -0,0 +1,66 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+// BacktestHandler handles HTTP requests for recommendation backtests
+type BacktestHandler struct {
+	backtestService token.BacktestService
+	logger          *logrus.Logger
+}
+
+// NewBacktestHandler creates a new backtest handler
+func NewBacktestHandler(backtestService token.BacktestService, logger *logrus.Logger) *BacktestHandler {
+	return &BacktestHandler{
+		backtestService: backtestService,
+		logger:          logger,
+	}
+}
+
+// Backtest computes the hypothetical returns of following the engine's calls for a token over a period
+func (h *BacktestHandler) Backtest(c *gin.Context) {
+	var req token.BacktestRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.backtestService.Backtest(c.Request.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, token.ErrTokenNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Token not found"})
+		case errors.Is(err, token.ErrInvalidBacktestPeriod):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Period must be at most 365 days and 5000 steps, with from before to"})
+		case errors.Is(err, token.ErrInvalidBacktestSource):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Source must be replay or recorded"})
+		case errors.Is(err, token.ErrInvalidInterval):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Interval must be 1h, 4h or 1d"})
+		case errors.Is(err, token.ErrInsufficientBacktestData):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Not enough stored candles or recommendations for the period"})
+		default:
+			h.logger.WithFields(logrus.Fields{
+				"error": err,
+				"token": req.Token,
+			}).Error("Failed to backtest recommendations")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to backtest recommendations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// RegisterRoutes registers backtest API routes
+func (h *BacktestHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/analysis/backtest", h.Backtest)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/cluster"
+)
+
+// ClusterHandler handles HTTP requests for related-wallet detection
+type ClusterHandler struct {
+	clusterService cluster.ClusterService
+	logger         *logrus.Logger
+}
+
+// NewClusterHandler creates a new cluster handler
+func NewClusterHandler(clusterService cluster.ClusterService, logger *logrus.Logger) *ClusterHandler {
+	return &ClusterHandler{
+		clusterService: clusterService,
+		logger:         logger,
+	}
+}
+
+// GetRelatedWallets returns wallets likely controlled by the same entity (query: refresh=true rescans first)
+func (h *ClusterHandler) GetRelatedWallets(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	refresh := c.Query("refresh") == "true"
+
+	related, err := h.clusterService.GetRelatedWallets(c.Request.Context(), address, refresh)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": address,
+		}).Error("Failed to get related wallets")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get related wallets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    related,
+	})
+}
+
+// RegisterRoutes registers cluster API routes
+func (h *ClusterHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/wallets/:address/related", h.GetRelatedWallets)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+// How often an idle discovery stream sends a keep-alive event
+const discoveryStreamKeepAlive = 15 * time.Second
+
+// DiscoveryHandler handles HTTP requests for newly listed tokens
+type DiscoveryHandler struct {
+	discoveryService token.DiscoveryService
+	logger           *logrus.Logger
+}
+
+// NewDiscoveryHandler creates a new discovery handler
+func NewDiscoveryHandler(discoveryService token.DiscoveryService, logger *logrus.Logger) *DiscoveryHandler {
+	return &DiscoveryHandler{
+		discoveryService: discoveryService,
+		logger:           logger,
+	}
+}
+
+// ListNewTokens lists discovered tokens, newest first (query: risk, limit, offset)
+func (h *DiscoveryHandler) ListNewTokens(c *gin.Context) {
+	riskLevel, ok := discoveryRiskLevel(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	discoveries, err := h.discoveryService.ListNewTokens(c.Request.Context(), riskLevel, limit, offset)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list new tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    discoveries,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(discoveries),
+		},
+	})
+}
+
+// StreamNewTokens streams discoveries as server-sent "new_token" events until the client disconnects (query: risk)
+func (h *DiscoveryHandler) StreamNewTokens(c *gin.Context) {
+	riskLevel, ok := discoveryRiskLevel(c)
+	if !ok {
+		return
+	}
+
+	// Streams outlive the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.WithError(err).Debug("Failed to clear write deadline of discovery stream")
+	}
+
+	discoveries, unsubscribe := h.discoveryService.Subscribe()
+	defer unsubscribe()
+
+	keepAlive := time.NewTicker(discoveryStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // keep reverse proxies from buffering events
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case discovery, ok := <-discoveries:
+			if !ok {
+				return false
+			}
+			if riskLevel == "" || discovery.RiskLevel == riskLevel {
+				c.SSEvent("new_token", discovery)
+			}
+			return true
+		case now := <-keepAlive.C:
+			c.SSEvent("ping", now.Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// discoveryRiskLevel reads the optional risk filter, writing a 400 if it is unknown
+func discoveryRiskLevel(c *gin.Context) (models.TokenRiskLevel, bool) {
+	riskLevel := models.TokenRiskLevel(c.Query("risk"))
+	if riskLevel != "" && !riskLevel.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "risk must be one of low, medium, high"})
+		return "", false
+	}
+	return riskLevel, true
+}
+
+// RegisterRoutes registers new token discovery API routes
+func (h *DiscoveryHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/tokens/new", h.ListNewTokens)
+	router.GET("/tokens/new/stream", h.StreamNewTokens)
+}
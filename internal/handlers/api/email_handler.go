@@ -0,0 +1,114 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/services/email"
+)
+
+// EmailHandler handles HTTP requests for binding email addresses to wallets
+type EmailHandler struct {
+	emailService email.EmailService
+	logger       *logrus.Logger
+}
+
+// NewEmailHandler creates a new email handler
+func NewEmailHandler(emailService email.EmailService, logger *logrus.Logger) *EmailHandler {
+	return &EmailHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// BindEmailRequest is the address to bind to a wallet
+type BindEmailRequest struct {
+	Email string `json:"email" binding:"required,max=254"`
+}
+
+// VerifyEmailRequest is the code mailed to the address being bound
+type VerifyEmailRequest struct {
+	Code string `json:"code" binding:"required,max=16"`
+}
+
+// BindEmail mails a verification code to the address; it is bound once the code is confirmed
+func (h *EmailHandler) BindEmail(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	var req BindEmailRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	verification, err := h.emailService.StartVerification(c.Request.Context(), address, req.Email)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to send verification email")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data": gin.H{
+			"email":      verification.Email,
+			"expires_at": verification.ExpiresAt,
+		},
+	})
+}
+
+// VerifyEmail confirms the mailed code and binds the address to the wallet
+func (h *EmailHandler) VerifyEmail(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	var req VerifyEmailRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	settings, err := h.emailService.ConfirmVerification(c.Request.Context(), address, req.Code)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to verify email")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    settings,
+	})
+}
+
+// UnbindEmail removes the wallet's email address and any pending verification
+func (h *EmailHandler) UnbindEmail(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	settings, err := h.emailService.Unbind(c.Request.Context(), address)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to unbind email")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    settings,
+	})
+}
+
+// RegisterRoutes registers email binding API routes
+func (h *EmailHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/users/:address/email", h.BindEmail)
+	router.POST("/users/:address/email/verify", h.VerifyEmail)
+	router.DELETE("/users/:address/email", h.UnbindEmail)
+}
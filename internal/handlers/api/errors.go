@@ -0,0 +1,170 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
+	"github.com/emiyaio/solana-wallet-service/internal/services/email"
+	"github.com/emiyaio/solana-wallet-service/internal/services/feature"
+	"github.com/emiyaio/solana-wallet-service/internal/services/notification"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+	"github.com/emiyaio/solana-wallet-service/internal/services/unlock"
+	"github.com/emiyaio/solana-wallet-service/internal/services/user"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+// Error codes not tied to a single domain error
+const (
+	codeInternal    = "internal_error"
+	codeRateLimited = "rate_limited"
+)
+
+// errorMapping ties a domain error to its response status and machine-readable code
+type errorMapping struct {
+	err     error
+	status  int
+	code    string
+	message string // replaces the error text when set
+}
+
+func (m errorMapping) text() string {
+	if m.message != "" {
+		return m.message
+	}
+	return m.err.Error()
+}
+
+// errorMappings is matched with errors.Is, so wrapped errors map like their sentinel
+var errorMappings = []errorMapping{
+	// Rooms
+	{err: room.ErrRoomNotFound, status: http.StatusNotFound, code: "room_not_found"},
+	{err: room.ErrSharedInfoNotFound, status: http.StatusNotFound, code: "shared_info_not_found"},
+	{err: room.ErrInvalidPassword, status: http.StatusForbidden, code: "invalid_password"},
+	{err: room.ErrNotMember, status: http.StatusForbidden, code: "not_member"},
+	{err: room.ErrInsufficientPermission, status: http.StatusForbidden, code: "insufficient_permission"},
+	{err: room.ErrTokenFlagged, status: http.StatusForbidden, code: "token_flagged"},
+	{err: room.ErrRoomFull, status: http.StatusConflict, code: "room_full"},
+	{err: room.ErrMaxMembersTooLow, status: http.StatusConflict, code: "max_members_too_low"},
+	{err: room.ErrRoomClosed, status: http.StatusConflict, code: "room_closed"},
+	{err: room.ErrRoomExpired, status: http.StatusConflict, code: "room_expired"},
+	{err: room.ErrAlreadyMember, status: http.StatusConflict, code: "already_member"},
+	{err: room.ErrInvalidInfoType, status: http.StatusUnprocessableEntity, code: "invalid_info_type"},
+	{err: room.ErrInvalidPayload, status: http.StatusUnprocessableEntity, code: "invalid_payload"},
+	{err: room.ErrInvalidReaction, status: http.StatusUnprocessableEntity, code: "invalid_reaction", message: "reaction type must be fire, bull, bear or warning"},
+	{err: room.ErrInvalidRole, status: http.StatusUnprocessableEntity, code: "invalid_role"},
+	{err: room.ErrInvalidPrunePolicy, status: http.StatusUnprocessableEntity, code: "invalid_prune_policy"},
+	{err: room.ErrInvalidBatchAction, status: http.StatusUnprocessableEntity, code: "invalid_batch_action"},
+	{err: room.ErrBatchTooLarge, status: http.StatusUnprocessableEntity, code: "batch_too_large"},
+	{err: room.ErrBasketFull, status: http.StatusConflict, code: "basket_full"},
+	{err: room.ErrTokenNotInBasket, status: http.StatusNotFound, code: "token_not_in_basket"},
+	{err: room.ErrNoPendingTransfer, status: http.StatusNotFound, code: "no_pending_transfer"},
+	{err: room.ErrInvalidAlias, status: http.StatusUnprocessableEntity, code: "invalid_alias"},
+	{err: room.ErrAliasTaken, status: http.StatusConflict, code: "alias_taken"},
+
+	// Wallet watches
+	{err: room.ErrWatchNotFound, status: http.StatusNotFound, code: "wallet_watch_not_found"},
+	{err: room.ErrTooManyWatches, status: http.StatusConflict, code: "too_many_wallet_watches"},
+	{err: room.ErrWatchSelf, status: http.StatusUnprocessableEntity, code: "cannot_watch_self"},
+
+	// Tokens
+	{err: token.ErrTokenNotFound, status: http.StatusNotFound, code: "token_not_found"},
+	{err: token.ErrFlagNotFound, status: http.StatusNotFound, code: "flag_not_found"},
+	{err: token.ErrInvalidFlagType, status: http.StatusUnprocessableEntity, code: "invalid_flag_type", message: "type must be one of scam, honeypot, rug"},
+	{err: token.ErrInvalidInterval, status: http.StatusUnprocessableEntity, code: "invalid_interval", message: "interval must be one of 1h, 24h, 7d, 30d, 1y"},
+	{err: token.ErrScreenerPresetNotFound, status: http.StatusNotFound, code: "screener_preset_not_found"},
+	{err: token.ErrTooManyScreenerPresets, status: http.StatusConflict, code: "too_many_screener_presets"},
+	{err: token.ErrInvalidScreenerFilter, status: http.StatusUnprocessableEntity, code: "invalid_screener_filter"},
+	{err: token.ErrPriceUnavailable, status: http.StatusServiceUnavailable, code: "price_unavailable"},
+
+	// Token unlocks
+	{err: unlock.ErrUnlockNotFound, status: http.StatusNotFound, code: "unlock_not_found"},
+	{err: unlock.ErrInvalidUnlock, status: http.StatusUnprocessableEntity, code: "invalid_unlock"},
+
+	// Notifications
+	{err: notification.ErrNotificationNotFound, status: http.StatusNotFound, code: "notification_not_found"},
+
+	// Email binding
+	{err: email.ErrEmailDisabled, status: http.StatusServiceUnavailable, code: "email_disabled"},
+	{err: email.ErrInvalidEmail, status: http.StatusUnprocessableEntity, code: "invalid_email"},
+	{err: email.ErrVerificationNotFound, status: http.StatusNotFound, code: "email_verification_not_found"},
+	{err: email.ErrInvalidVerificationCode, status: http.StatusUnprocessableEntity, code: "invalid_verification_code"},
+	{err: email.ErrTooManyAttempts, status: http.StatusTooManyRequests, code: "too_many_verification_attempts"},
+	{err: email.ErrVerificationCooldown, status: http.StatusTooManyRequests, code: "verification_cooldown"},
+
+	// Wallet purge
+	{err: user.ErrInvalidPurgeSignature, status: http.StatusUnauthorized, code: "invalid_signature"},
+	{err: user.ErrPurgeSignatureExpired, status: http.StatusUnauthorized, code: "signature_expired"},
+	{err: user.ErrPurgeSignatureUsed, status: http.StatusUnauthorized, code: "signature_used"},
+	{err: user.ErrWalletOwnsRooms, status: http.StatusConflict, code: "wallet_owns_rooms"},
+
+	// AI
+	{err: ai.ErrUnsupportedLanguage, status: http.StatusUnprocessableEntity, code: "unsupported_language"},
+	{err: ai.ErrQuotaExceeded, status: http.StatusTooManyRequests, code: "ai_quota_exceeded"},
+	{err: ai.ErrUnknownModel, status: http.StatusUnprocessableEntity, code: "unknown_model"},
+	{err: ai.ErrInvalidModelParams, status: http.StatusUnprocessableEntity, code: "invalid_model_params"},
+	{err: ai.ErrUnknownPrompt, status: http.StatusNotFound, code: "unknown_prompt"},
+	{err: ai.ErrPromptVersionNotFound, status: http.StatusNotFound, code: "prompt_version_not_found"},
+	{err: ai.ErrInvalidPrompt, status: http.StatusUnprocessableEntity, code: "invalid_prompt"},
+
+	// Feature flags
+	{err: feature.ErrFeatureDisabled, status: http.StatusServiceUnavailable, code: "feature_disabled"},
+	{err: feature.ErrUnknownFlag, status: http.StatusNotFound, code: "unknown_feature_flag"},
+	{err: feature.ErrInvalidFlagScope, status: http.StatusUnprocessableEntity, code: "invalid_feature_flag_scope"},
+
+	// Addresses rejected past request validation
+	{err: solana.ErrInvalidAddress, status: http.StatusUnprocessableEntity, code: "invalid_address"},
+}
+
+// lookupError finds the mapping of a domain error
+func lookupError(err error) (errorMapping, bool) {
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return m, true
+		}
+	}
+	return errorMapping{}, false
+}
+
+// respondError writes the status and code mapped to err. Unmapped errors are logged and answered
+// with a 500 carrying message, so internal details do not leak to clients.
+func respondError(c *gin.Context, logger logrus.FieldLogger, err error, message string) {
+	if respondThrottled(c, err) || respondPasswordAttempts(c, err) {
+		return
+	}
+	if m, ok := lookupError(err); ok {
+		c.JSON(m.status, gin.H{"error": m.text(), "code": m.code})
+		return
+	}
+
+	logger.WithField("error", err).Error(message)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message, "code": codeInternal})
+}
+
+// respondThrottled writes a 429 with the limit details if err is a throttle rejection
+func respondThrottled(c *gin.Context, err error) bool {
+	var throttleErr *room.ThrottleError
+	if !errors.As(err, &throttleErr) {
+		return false
+	}
+
+	c.Header("Retry-After", strconv.Itoa(throttleErr.RetryAfterSeconds()))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": throttleErr.Error(), "code": codeRateLimited, "throttle": throttleErr.Details()})
+	return true
+}
+
+// respondPasswordAttempts writes a 429 with the delay or lockout if err refuses a room password guess
+func respondPasswordAttempts(c *gin.Context, err error) bool {
+	var attemptErr *room.PasswordAttemptError
+	if !errors.As(err, &attemptErr) {
+		return false
+	}
+
+	c.Header("Retry-After", strconv.Itoa(attemptErr.RetryAfterSeconds()))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": attemptErr.Error(), "code": "password_attempts_exceeded", "password_attempts": attemptErr.Details()})
+	return true
+}
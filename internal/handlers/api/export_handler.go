@@ -0,0 +1,213 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/export"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// ExportHandler handles HTTP requests for data exports
+type ExportHandler struct {
+	exportService export.ExportService
+	logger        *logrus.Logger
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(exportService export.ExportService, logger *logrus.Logger) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// ExportRoom exports a room's trade events and shared infos (query: format=csv|json).
+// Small rooms are streamed directly; larger ones are generated in the background.
+func (h *ExportHandler) ExportRoom(c *gin.Context) {
+	roomID := c.Param("roomId")
+	creatorAddress := c.GetHeader("X-Creator-Address")
+
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room ID is required"})
+		return
+	}
+
+	if creatorAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator address is required"})
+		return
+	}
+
+	format := models.ExportFormat(strings.ToLower(c.DefaultQuery("format", "csv")))
+
+	result, err := h.exportService.RequestRoomExport(c.Request.Context(), roomID, creatorAddress, format)
+	if err != nil {
+		switch {
+		case errors.Is(err, export.ErrInvalidFormat):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or json"})
+		case errors.Is(err, room.ErrRoomNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+		case errors.Is(err, export.ErrInsufficientPermission):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the room creator can export the room"})
+		default:
+			h.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"room_id": roomID,
+			}).Error("Failed to export room")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export room"})
+		}
+		return
+	}
+
+	if result.Job != nil {
+		c.JSON(http.StatusAccepted, gin.H{
+			"success":      true,
+			"data":         result.Job,
+			"download_url": downloadURL(result.Job),
+		})
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="room-%s.%s"`, result.Room.RoomID, format))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so failures can only be logged
+	if _, err := h.exportService.WriteRoomExport(c.Request.Context(), result.Room, format, c.Writer); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": roomID,
+		}).Error("Failed to stream room export")
+	}
+}
+
+// ExportWalletTransactions streams a wallet's transaction history as CSV for tax reporting
+// (query: from, to as YYYY-MM-DD, both inclusive)
+func (h *ExportHandler) ExportWalletTransactions(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	from := time.Unix(0, 0).UTC()
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be formatted as YYYY-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+
+	to := time.Now().UTC()
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be formatted as YYYY-MM-DD"})
+			return
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+
+	c.Header("Content-Type", models.ExportFormatCSV.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="wallet-%s-transactions.csv"`, address))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so failures can only be logged
+	if _, err := h.exportService.WriteWalletTransactions(c.Request.Context(), address, from, to, c.Writer); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": address,
+		}).Error("Failed to stream wallet transaction export")
+	}
+}
+
+// GetExport returns the status of a background export
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	job, ok := h.getExport(c)
+	if !ok {
+		return
+	}
+
+	response := gin.H{
+		"success": true,
+		"data":    job,
+	}
+	if job.Status == models.ExportStatusCompleted {
+		response["download_url"] = downloadURL(job)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DownloadExport serves the file of a completed background export
+func (h *ExportHandler) DownloadExport(c *gin.Context) {
+	job, ok := h.getExport(c)
+	if !ok {
+		return
+	}
+
+	if job.Status != models.ExportStatusCompleted {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":  "Export is not ready",
+			"status": job.Status,
+		})
+		return
+	}
+
+	c.Header("Content-Type", job.Format.ContentType())
+	c.FileAttachment(job.FilePath, fmt.Sprintf("%s-%s.%s", job.Kind, job.SubjectID, job.Format))
+}
+
+// getExport loads the export named in the path for the wallet in X-Wallet-Address, writing the error response on failure
+func (h *ExportHandler) getExport(c *gin.Context) (*models.DataExport, bool) {
+	exportID, err := uuid.Parse(c.Param("exportId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export ID"})
+		return nil, false
+	}
+
+	walletAddress := c.GetHeader("X-Wallet-Address")
+	if walletAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "wallet address is required"})
+		return nil, false
+	}
+
+	job, err := h.exportService.GetExport(c.Request.Context(), exportID, walletAddress)
+	if err != nil {
+		switch {
+		case errors.Is(err, export.ErrExportNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Export not found"})
+		case errors.Is(err, export.ErrInsufficientPermission):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Export belongs to another wallet"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get export"})
+		}
+		return nil, false
+	}
+	return job, true
+}
+
+func downloadURL(job *models.DataExport) string {
+	return fmt.Sprintf("/api/v1/exports/%s/download", job.ID)
+}
+
+// RegisterRoutes registers export API routes
+func (h *ExportHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/rooms/:roomId/export", h.ExportRoom)
+	router.GET("/wallets/:address/transactions/export", h.ExportWalletTransactions)
+	router.GET("/exports/:exportId", h.GetExport)
+	router.GET("/exports/:exportId/download", h.DownloadExport)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
+	"github.com/emiyaio/solana-wallet-service/internal/services/feature"
+)
+
+// FeatureFlagHandler handles admin HTTP requests for runtime feature flags
+type FeatureFlagHandler struct {
+	flagService feature.FlagService
+	adminGuard  *middleware.AdminGuard
+	logger      *logrus.Logger
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler
+func NewFeatureFlagHandler(flagService feature.FlagService, adminGuard *middleware.AdminGuard, logger *logrus.Logger) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		flagService: flagService,
+		adminGuard:  adminGuard,
+		logger:      logger,
+	}
+}
+
+// SetFeatureFlagRequest turns a flag on or off
+type SetFeatureFlagRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required"`
+	RoomID  string `json:"room_id,omitempty"` // sets the flag for one room only
+}
+
+// ListFlags lists every feature flag with its default, global value and room overrides
+func (h *FeatureFlagHandler) ListFlags(c *gin.Context) {
+	flags, err := h.flagService.ListFlags(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list feature flags")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flags,
+	})
+}
+
+// SetFlag turns a feature flag on or off, globally or for one room
+func (h *FeatureFlagHandler) SetFlag(c *gin.Context) {
+	var req SetFeatureFlagRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	set := &feature.SetFlagRequest{
+		Flag:    feature.Flag(c.Param("key")),
+		RoomID:  req.RoomID,
+		Enabled: *req.Enabled,
+	}
+	if principal, ok := middleware.AdminPrincipal(c); ok {
+		set.UpdatedBy = principal.Name
+	}
+
+	flag, err := h.flagService.SetFlag(c.Request.Context(), set)
+	if err != nil {
+		respondError(c, h.logger.WithField("flag", set.Flag), err, "Failed to set feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flag,
+	})
+}
+
+// ClearFlag drops what was set for the flag, so the global value or the default applies again (query: room_id)
+func (h *FeatureFlagHandler) ClearFlag(c *gin.Context) {
+	flag := feature.Flag(c.Param("key"))
+	if err := h.flagService.ClearFlag(c.Request.Context(), flag, c.Query("room_id")); err != nil {
+		respondError(c, h.logger.WithField("flag", flag), err, "Failed to clear feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Feature flag cleared",
+	})
+}
+
+// RegisterRoutes registers feature flag admin routes
+func (h *FeatureFlagHandler) RegisterRoutes(router *gin.RouterGroup) {
+	flags := router.Group("/admin/feature-flags")
+	{
+		flags.GET("", h.adminGuard.Require(models.AdminRoleViewer), h.ListFlags)
+		flags.PUT("/:key", h.adminGuard.Require(models.AdminRoleAdmin), h.SetFlag)
+		flags.DELETE("/:key", h.adminGuard.Require(models.AdminRoleAdmin), h.ClearFlag)
+	}
+}
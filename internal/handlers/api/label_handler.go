@@ -0,0 +1,187 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
+	"github.com/emiyaio/solana-wallet-service/internal/services/label"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+// LabelHandler handles HTTP requests for wallet labels
+type LabelHandler struct {
+	labelService label.LabelService
+	adminGuard   *middleware.AdminGuard
+	logger       *logrus.Logger
+}
+
+// NewLabelHandler creates a new label handler
+func NewLabelHandler(labelService label.LabelService, adminGuard *middleware.AdminGuard, logger *logrus.Logger) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+		adminGuard:   adminGuard,
+		logger:       logger,
+	}
+}
+
+// CreateLabel tags a wallet with an entity label
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	var req label.CreateLabelRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	walletLabel, err := h.labelService.CreateLabel(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, label.ErrInvalidLabelType) || errors.Is(err, solana.ErrInvalidAddress) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, label.ErrLabelExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": req.WalletAddress,
+		}).Error("Failed to create wallet label")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create wallet label"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    walletLabel,
+	})
+}
+
+// ListLabels lists wallet labels, optionally filtered by label type
+func (h *LabelHandler) ListLabels(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	labelType := models.WalletLabelType(c.Query("label"))
+	labels, err := h.labelService.ListLabels(c.Request.Context(), labelType, limit, offset)
+	if err != nil {
+		if errors.Is(err, label.ErrInvalidLabelType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list wallet labels"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    labels,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(labels),
+		},
+	})
+}
+
+// UpdateLabel updates an existing wallet label
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	labelID, err := uuid.Parse(c.Param("labelId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return
+	}
+
+	var req label.UpdateLabelRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	walletLabel, err := h.labelService.UpdateLabel(c.Request.Context(), labelID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, label.ErrLabelNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, label.ErrInvalidLabelType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, label.ErrLabelExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update wallet label"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    walletLabel,
+	})
+}
+
+// DeleteLabel removes a wallet label
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	labelID, err := uuid.Parse(c.Param("labelId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return
+	}
+
+	if err := h.labelService.DeleteLabel(c.Request.Context(), labelID); err != nil {
+		if errors.Is(err, label.ErrLabelNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete wallet label"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Wallet label deleted successfully",
+	})
+}
+
+// GetWalletLabels returns the public labels attached to a wallet
+func (h *LabelHandler) GetWalletLabels(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	labels, err := h.labelService.GetWalletLabels(c.Request.Context(), address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get wallet labels"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    labels,
+	})
+}
+
+// RegisterRoutes registers wallet label API routes
+func (h *LabelHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin/labels")
+	{
+		admin.POST("", h.adminGuard.Require(models.AdminRoleAdmin), h.CreateLabel)
+		admin.GET("", h.adminGuard.Require(models.AdminRoleViewer), h.ListLabels)
+		admin.PUT("/:labelId", h.adminGuard.Require(models.AdminRoleAdmin), h.UpdateLabel)
+		admin.DELETE("/:labelId", h.adminGuard.Require(models.AdminRoleAdmin), h.DeleteLabel)
+	}
+
+	router.GET("/wallets/:address/labels", h.GetWalletLabels)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/services/limitwatch"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+// LimitWatchHandler handles HTTP requests for price limit watches
+type LimitWatchHandler struct {
+	limitWatchService limitwatch.LimitWatchService
+	logger            *logrus.Logger
+}
+
+// NewLimitWatchHandler creates a new limit watch handler
+func NewLimitWatchHandler(limitWatchService limitwatch.LimitWatchService, logger *logrus.Logger) *LimitWatchHandler {
+	return &LimitWatchHandler{
+		limitWatchService: limitWatchService,
+		logger:            logger,
+	}
+}
+
+// CreateWatch registers a price target for a wallet
+func (h *LimitWatchHandler) CreateWatch(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	var req limitwatch.CreateWatchRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	req.WalletAddress = address
+
+	watch, err := h.limitWatchService.CreateWatch(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err, address, "Failed to create limit watch")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    watch,
+	})
+}
+
+// ListWatches lists a wallet's watches, newest first (query: status, limit, offset)
+func (h *LimitWatchHandler) ListWatches(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	status := models.LimitWatchStatus(c.Query("status"))
+	watches, err := h.limitWatchService.ListWatches(c.Request.Context(), address, status, limit, offset)
+	if err != nil {
+		h.handleError(c, err, address, "Failed to list limit watches")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    watches,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(watches),
+		},
+	})
+}
+
+// CancelWatch cancels one of the wallet's active watches
+func (h *LimitWatchHandler) CancelWatch(c *gin.Context) {
+	address := c.Param("address")
+	watchID, err := uuid.Parse(c.Param("watchId"))
+	if address == "" || err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "valid address and watch ID are required"})
+		return
+	}
+
+	if err := h.limitWatchService.CancelWatch(c.Request.Context(), address, watchID); err != nil {
+		h.handleError(c, err, address, "Failed to cancel limit watch")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Limit watch cancelled",
+	})
+}
+
+func (h *LimitWatchHandler) handleError(c *gin.Context, err error, address, message string) {
+	switch {
+	case errors.Is(err, limitwatch.ErrWatchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Limit watch not found"})
+	case errors.Is(err, limitwatch.ErrTooManyWatches), errors.Is(err, limitwatch.ErrAlreadyCrossed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, limitwatch.ErrInvalidTargetPrice), errors.Is(err, limitwatch.ErrInvalidDirection),
+		errors.Is(err, limitwatch.ErrInvalidSwapSide), errors.Is(err, limitwatch.ErrInvalidExpiry),
+		errors.Is(err, limitwatch.ErrPriceUnknown), errors.Is(err, solana.ErrInvalidAddress):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": address,
+		}).Error(message)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterRoutes registers limit watch API routes
+func (h *LimitWatchHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:address/watches", h.ListWatches)
+	router.POST("/users/:address/watches", h.CreateWatch)
+	router.DELETE("/users/:address/watches/:watchId", h.CancelWatch)
+}
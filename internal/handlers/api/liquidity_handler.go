@@ -0,0 +1,108 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/liquidity"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// LiquidityHandler handles HTTP requests for pool liquidity monitoring
+type LiquidityHandler struct {
+	liquidityService liquidity.LiquidityService
+	logger           *logrus.Logger
+}
+
+// NewLiquidityHandler creates a new liquidity handler
+func NewLiquidityHandler(liquidityService liquidity.LiquidityService, logger *logrus.Logger) *LiquidityHandler {
+	return &LiquidityHandler{
+		liquidityService: liquidityService,
+		logger:           logger,
+	}
+}
+
+// GetRoomLiquidity returns the liquidity history of a room's token with its recent alerts (query: hours, default 24, max 168)
+func (h *LiquidityHandler) GetRoomLiquidity(c *gin.Context) {
+	roomID := c.Param("roomId")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room ID is required"})
+		return
+	}
+
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours <= 0 || hours > 168 {
+		hours = 24
+	}
+
+	result, err := h.liquidityService.GetRoomLiquidity(c.Request.Context(), roomID, time.Now().Add(-time.Duration(hours)*time.Hour))
+	if err != nil {
+		h.handleError(c, err, roomID, "Failed to get room liquidity")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetRoomAlerts lists liquidity alerts of a room's token, newest first
+func (h *LiquidityHandler) GetRoomAlerts(c *gin.Context) {
+	roomID := c.Param("roomId")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room ID is required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	alerts, err := h.liquidityService.GetRoomAlerts(c.Request.Context(), roomID, limit, offset)
+	if err != nil {
+		h.handleError(c, err, roomID, "Failed to get liquidity alerts")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    alerts,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(alerts),
+		},
+	})
+}
+
+func (h *LiquidityHandler) handleError(c *gin.Context, err error, roomID, message string) {
+	switch {
+	case errors.Is(err, room.ErrRoomNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+	case errors.Is(err, liquidity.ErrRoomHasNoToken):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Room is not bound to a token"})
+	default:
+		h.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": roomID,
+		}).Error(message)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterRoutes registers liquidity API routes
+func (h *LiquidityHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/rooms/:roomId/liquidity", h.GetRoomLiquidity)
+	router.GET("/rooms/:roomId/liquidity/alerts", h.GetRoomAlerts)
+}
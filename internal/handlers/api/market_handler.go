@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/analytics"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+// MarketHandler handles HTTP requests for market-wide summaries
+type MarketHandler struct {
+	analyticsService analytics.AnalyticsService
+	priceFeed        token.PriceFeedService
+	logger           *logrus.Logger
+}
+
+// NewMarketHandler creates a new market handler
+func NewMarketHandler(analyticsService analytics.AnalyticsService, priceFeed token.PriceFeedService, logger *logrus.Logger) *MarketHandler {
+	return &MarketHandler{
+		analyticsService: analyticsService,
+		priceFeed:        priceFeed,
+		logger:           logger,
+	}
+}
+
+// GetOverview returns aggregate stats of the tracked tokens, the most active rooms and the SOL price
+func (h *MarketHandler) GetOverview(c *gin.Context) {
+	overview, err := h.analyticsService.GetMarketOverview(c.Request.Context())
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get market overview")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get market overview"})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=60")
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    overview,
+	})
+}
+
+// GetSOLPrice returns the latest SOL price recorded by the price feed, with the stablecoin prices
+func (h *MarketHandler) GetSOLPrice(c *gin.Context) {
+	price, err := h.priceFeed.GetSOLPrice(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to get SOL price")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    price,
+	})
+}
+
+// RegisterRoutes registers market API routes
+func (h *MarketHandler) RegisterRoutes(router *gin.RouterGroup) {
+	marketGroup := router.Group("/market")
+	{
+		marketGroup.GET("/overview", h.GetOverview)
+		marketGroup.GET("/sol", h.GetSOLPrice)
+	}
+}
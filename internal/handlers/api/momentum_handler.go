@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/momentum"
+)
+
+// MomentumHandler handles HTTP requests for trending momentum alerts
+type MomentumHandler struct {
+	momentumService momentum.MomentumService
+	logger          *logrus.Logger
+}
+
+// NewMomentumHandler creates a new momentum handler
+func NewMomentumHandler(momentumService momentum.MomentumService, logger *logrus.Logger) *MomentumHandler {
+	return &MomentumHandler{
+		momentumService: momentumService,
+		logger:          logger,
+	}
+}
+
+// ListAlerts lists recent early momentum alerts, newest first (query: limit, offset)
+func (h *MomentumHandler) ListAlerts(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	alerts, err := h.momentumService.ListAlerts(c.Request.Context(), limit, offset)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list momentum alerts")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    alerts,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(alerts),
+		},
+	})
+}
+
+// RegisterRoutes registers momentum alert API routes
+func (h *MomentumHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/tokens/momentum", h.ListAlerts)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/notification"
+)
+
+// NotificationHandler handles HTTP requests for the notification center
+type NotificationHandler struct {
+	notificationService notification.NotificationService
+	logger              *logrus.Logger
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationService notification.NotificationService, logger *logrus.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// ListNotifications lists a wallet's notifications, newest first (query: unread, limit, offset)
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	unreadOnly := c.Query("unread") == "true"
+	notifications, err := h.notificationService.ListNotifications(c.Request.Context(), address, unreadOnly, limit, offset)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list notifications")
+		return
+	}
+
+	unread, err := h.notificationService.CountUnread(c.Request.Context(), address)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to count unread notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    notifications,
+		"unread":  unread,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(notifications),
+		},
+	})
+}
+
+// MarkRead marks one of the wallet's notifications read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	address := c.Param("address")
+	notificationID, err := uuid.Parse(c.Param("notificationId"))
+	if address == "" || err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "valid address and notification ID are required"})
+		return
+	}
+
+	if err := h.notificationService.MarkRead(c.Request.Context(), address, notificationID); err != nil {
+		respondError(c, h.logger, err, "Failed to mark notification read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Notification marked read",
+	})
+}
+
+// MarkAllRead marks every unread notification of the wallet read
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	marked, err := h.notificationService.MarkAllRead(c.Request.Context(), address)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to mark notifications read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"count":   marked,
+	})
+}
+
+// RegisterRoutes registers notification center API routes
+func (h *NotificationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:address/notifications", h.ListNotifications)
+	router.POST("/users/:address/notifications/read", h.MarkAllRead)
+	router.POST("/users/:address/notifications/:notificationId/read", h.MarkRead)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/portfolio"
+)
+
+// PortfolioHandler handles HTTP requests for wallet portfolio performance
+type PortfolioHandler struct {
+	portfolioService portfolio.PortfolioService
+	logger           *logrus.Logger
+}
+
+// NewPortfolioHandler creates a new portfolio handler
+func NewPortfolioHandler(portfolioService portfolio.PortfolioService, logger *logrus.Logger) *PortfolioHandler {
+	return &PortfolioHandler{
+		portfolioService: portfolioService,
+		logger:           logger,
+	}
+}
+
+// GetPerformance returns the portfolio value series and drawdown stats of a wallet
+func (h *PortfolioHandler) GetPerformance(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days <= 0 || days > 365 {
+		days = 30
+	}
+
+	performance, err := h.portfolioService.GetPerformance(c.Request.Context(), address, days)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": address,
+		}).Error("Failed to get portfolio performance")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get portfolio performance"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    performance,
+	})
+}
+
+// RegisterRoutes registers portfolio API routes
+func (h *PortfolioHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/wallets/:address/performance", h.GetPerformance)
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
+	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
+)
+
+// PromptHandler handles admin HTTP requests for AI prompt templates
+type PromptHandler struct {
+	promptService ai.PromptService
+	aiService     ai.LangChainService
+	adminGuard    *middleware.AdminGuard
+	logger        *logrus.Logger
+}
+
+// NewPromptHandler creates a new prompt template handler
+func NewPromptHandler(promptService ai.PromptService, aiService ai.LangChainService, adminGuard *middleware.AdminGuard, logger *logrus.Logger) *PromptHandler {
+	return &PromptHandler{
+		promptService: promptService,
+		aiService:     aiService,
+		adminGuard:    adminGuard,
+		logger:        logger,
+	}
+}
+
+// SavePromptRequest is the body of a new prompt version
+type SavePromptRequest struct {
+	Content string `json:"content" binding:"required"`
+	RoomID  string `json:"room_id,omitempty"` // overrides the prompt for one room only
+	Note    string `json:"note,omitempty" binding:"max=200"`
+}
+
+// TestPromptRequest is a sample input to run a prompt against; without content the prompt in use is tested
+type TestPromptRequest struct {
+	Content     string   `json:"content,omitempty"`
+	RoomID      string   `json:"room_id,omitempty"`
+	Input       string   `json:"input" binding:"required"`
+	Model       string   `json:"model,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+}
+
+// ListPrompts lists every AI use case with its built-in prompt, active version and room overrides
+func (h *PromptHandler) ListPrompts(c *gin.Context) {
+	prompts, err := h.promptService.ListPrompts(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list prompts")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    prompts,
+	})
+}
+
+// ListVersions lists a prompt's versions, newest first (query: room_id)
+func (h *PromptHandler) ListVersions(c *gin.Context) {
+	key := ai.PromptKey(c.Param("key"))
+	versions, err := h.promptService.ListVersions(c.Request.Context(), key, c.Query("room_id"))
+	if err != nil {
+		respondError(c, h.logger.WithField("prompt", key), err, "Failed to list prompt versions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    versions,
+	})
+}
+
+// SaveVersion saves a new version of a prompt and makes it active
+func (h *PromptHandler) SaveVersion(c *gin.Context) {
+	var req SavePromptRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	save := &ai.SavePromptRequest{
+		Key:     ai.PromptKey(c.Param("key")),
+		RoomID:  req.RoomID,
+		Content: req.Content,
+		Note:    req.Note,
+	}
+	if principal, ok := middleware.AdminPrincipal(c); ok {
+		save.CreatedBy = principal.Name
+	}
+
+	template, err := h.promptService.SaveVersion(c.Request.Context(), save)
+	if err != nil {
+		respondError(c, h.logger.WithField("prompt", save.Key), err, "Failed to save prompt")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    template,
+	})
+}
+
+// ActivateVersion makes a saved version of a prompt the active one (query: room_id)
+func (h *PromptHandler) ActivateVersion(c *gin.Context) {
+	key := ai.PromptKey(c.Param("key"))
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version must be a positive integer", "code": "invalid_request"})
+		return
+	}
+
+	template, err := h.promptService.ActivateVersion(c.Request.Context(), key, c.Query("room_id"), version)
+	if err != nil {
+		respondError(c, h.logger.WithField("prompt", key), err, "Failed to activate prompt version")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    template,
+	})
+}
+
+// ResetPrompt falls back to the global or built-in prompt (query: room_id)
+func (h *PromptHandler) ResetPrompt(c *gin.Context) {
+	key := ai.PromptKey(c.Param("key"))
+	if err := h.promptService.Reset(c.Request.Context(), key, c.Query("room_id")); err != nil {
+		respondError(c, h.logger.WithField("prompt", key), err, "Failed to reset prompt")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Prompt reset",
+	})
+}
+
+// TestPrompt runs a draft or the prompt in use against a sample input without saving anything
+func (h *PromptHandler) TestPrompt(c *gin.Context) {
+	var req TestPromptRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	key := ai.PromptKey(c.Param("key"))
+	if err := ai.ValidatePromptScope(key, req.RoomID); err != nil {
+		respondError(c, h.logger.WithField("prompt", key), err, "Invalid prompt")
+		return
+	}
+
+	content := req.Content
+	if content == "" {
+		content = h.promptService.Resolve(ctx, key, req.RoomID)
+	}
+	if err := ai.ValidatePromptContent(content); err != nil {
+		respondError(c, h.logger, err, "Invalid prompt")
+		return
+	}
+
+	result, err := h.aiService.TestPrompt(ctx, content, req.Input, &ai.ModelOptions{
+		Model:       req.Model,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	})
+	if err != nil {
+		respondError(c, h.logger.WithField("prompt", key), err, "Failed to test prompt")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"prompt": content,
+			"output": result,
+		},
+	})
+}
+
+// RegisterRoutes registers prompt template admin routes
+func (h *PromptHandler) RegisterRoutes(router *gin.RouterGroup) {
+	prompts := router.Group("/admin/prompts")
+	{
+		prompts.GET("", h.adminGuard.Require(models.AdminRoleViewer), h.ListPrompts)
+		prompts.GET("/:key/versions", h.adminGuard.Require(models.AdminRoleViewer), h.ListVersions)
+		prompts.PUT("/:key", h.adminGuard.Require(models.AdminRoleAdmin), h.SaveVersion)
+		prompts.POST("/:key/versions/:version/activate", h.adminGuard.Require(models.AdminRoleAdmin), h.ActivateVersion)
+		prompts.DELETE("/:key", h.adminGuard.Require(models.AdminRoleAdmin), h.ResetPrompt)
+		prompts.POST("/:key/test", h.adminGuard.Require(models.AdminRoleOperator), h.TestPrompt)
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
+	"github.com/emiyaio/solana-wallet-service/internal/services/report"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// ReportHandler handles HTTP requests for room reports
+type ReportHandler struct {
+	reportService report.ReportService
+	adminGuard    *middleware.AdminGuard
+	logger        *logrus.Logger
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportService report.ReportService, adminGuard *middleware.AdminGuard, logger *logrus.Logger) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		adminGuard:    adminGuard,
+		logger:        logger,
+	}
+}
+
+// GetDigests lists past daily digests of a room, newest first
+func (h *ReportHandler) GetDigests(c *gin.Context) {
+	roomID := c.Param("roomId")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room ID is required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	digests, err := h.reportService.GetDigests(c.Request.Context(), roomID, limit, offset)
+	if err != nil {
+		if errors.Is(err, room.ErrRoomNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get room digests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    digests,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(digests),
+		},
+	})
+}
+
+// GenerateDigest builds a room's digest for a given day (query: date=YYYY-MM-DD, default yesterday)
+func (h *ReportHandler) GenerateDigest(c *gin.Context) {
+	roomID := c.Param("roomId")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room ID is required"})
+		return
+	}
+
+	date := time.Now().UTC().AddDate(0, 0, -1)
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date must be formatted as YYYY-MM-DD"})
+			return
+		}
+		date = parsed
+	}
+
+	digest, err := h.reportService.GenerateRoomDigest(c.Request.Context(), roomID, date)
+	if err != nil {
+		if errors.Is(err, room.ErrRoomNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+			return
+		}
+		h.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": roomID,
+		}).Error("Failed to generate room digest")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate room digest"})
+		return
+	}
+
+	if digest == nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": "No room activity on that day",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    digest,
+	})
+}
+
+// GetRoomAnalytics summarizes a room's growth and activity for its creator (query: days, default 30, max 90)
+func (h *ReportHandler) GetRoomAnalytics(c *gin.Context) {
+	roomID := c.Param("roomId")
+	creatorAddress := c.GetHeader("X-Creator-Address")
+	if creatorAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator address is required"})
+		return
+	}
+
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+
+	analytics, err := h.reportService.GetRoomAnalytics(c.Request.Context(), roomID, creatorAddress, days)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to get room analytics")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    analytics,
+	})
+}
+
+// RegisterRoutes registers report API routes
+func (h *ReportHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/rooms/:roomId/digests", h.GetDigests)
+	router.GET("/rooms/:roomId/analytics", h.GetRoomAnalytics)
+	router.POST("/admin/rooms/:roomId/digests", h.adminGuard.Require(models.AdminRoleOperator), h.GenerateDigest)
+}
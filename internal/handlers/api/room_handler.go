@@ -8,46 +8,47 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
 	"github.com/emiyaio/solana-wallet-service/internal/services/room"
 )
 
 // RoomHandler handles HTTP requests for room management
 type RoomHandler struct {
-	roomService room.RoomService
-	wsService   room.WebSocketService
-	logger      *logrus.Logger
+	roomService         room.RoomService
+	wsService           room.WebSocketService
+	subscriptionManager room.SubscriptionManager
+	idempotency         *middleware.Idempotency
+	logger              *logrus.Logger
 }
 
 // NewRoomHandler creates a new room handler
-func NewRoomHandler(roomService room.RoomService, wsService room.WebSocketService, logger *logrus.Logger) *RoomHandler {
+func NewRoomHandler(roomService room.RoomService, wsService room.WebSocketService, subscriptionManager room.SubscriptionManager, idempotency *middleware.Idempotency, logger *logrus.Logger) *RoomHandler {
 	return &RoomHandler{
-		roomService: roomService,
-		wsService:   wsService,
-		logger:      logger,
+		roomService:         roomService,
+		wsService:           wsService,
+		subscriptionManager: subscriptionManager,
+		idempotency:         idempotency,
+		logger:              logger,
 	}
 }
 
 // CreateRoom creates a new trading room
 func (h *RoomHandler) CreateRoom(c *gin.Context) {
 	var req room.CreateRoomRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
-	room, err := h.roomService.CreateRoom(c.Request.Context(), &req)
+	createdRoom, err := h.roomService.CreateRoom(c.Request.Context(), &req)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":   err,
-			"creator": req.CreatorAddress,
-		}).Error("Failed to create room")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create room"})
+		respondError(c, h.logger.WithField("creator", req.CreatorAddress), err, "Failed to create room")
 		return
 	}
 	
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    room,
+		"data":    createdRoom,
 	})
 }
 
@@ -59,19 +60,15 @@ func (h *RoomHandler) GetRoom(c *gin.Context) {
 		return
 	}
 	
-	room, err := h.roomService.GetRoom(c.Request.Context(), roomID)
+	tradeRoom, err := h.roomService.GetRoom(c.Request.Context(), roomID)
 	if err != nil {
-		if err == room.ErrRoomNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to get room")
 		return
 	}
 	
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    room,
+		"data":    tradeRoom,
 	})
 }
 
@@ -98,7 +95,7 @@ func (h *RoomHandler) ListRooms(c *gin.Context) {
 	
 	rooms, err := h.roomService.ListRooms(c.Request.Context(), status, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list rooms"})
+		respondError(c, h.logger, err, "Failed to list rooms")
 		return
 	}
 	
@@ -136,7 +133,7 @@ func (h *RoomHandler) GetUserRooms(c *gin.Context) {
 	
 	rooms, err := h.roomService.GetUserRooms(c.Request.Context(), creatorAddress, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user rooms"})
+		respondError(c, h.logger.WithField("creator", creatorAddress), err, "Failed to get user rooms")
 		return
 	}
 	
@@ -160,14 +157,13 @@ func (h *RoomHandler) UpdateRoom(c *gin.Context) {
 	}
 	
 	var req room.UpdateRoomRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
 	updatedRoom, err := h.roomService.UpdateRoom(c.Request.Context(), roomID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to update room")
 		return
 	}
 	
@@ -180,6 +176,97 @@ func (h *RoomHandler) UpdateRoom(c *gin.Context) {
 	})
 }
 
+// SetRoomToken changes or clears the token a room is bound to, creator only
+func (h *RoomHandler) SetRoomToken(c *gin.Context) {
+	roomID := c.Param("roomId")
+	creatorAddress := c.GetHeader("X-Creator-Address")
+	
+	if creatorAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator address is required"})
+		return
+	}
+	
+	var req room.SetRoomTokenRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	req.RoomID = roomID
+	req.CreatorAddress = creatorAddress
+	
+	updatedRoom, err := h.roomService.SetRoomToken(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to change room token")
+		return
+	}
+	
+	// Members' trades are now matched against the new token
+	h.subscriptionManager.HandleRoomTokensChanged(roomID, updatedRoom.BoundTokenAddresses())
+	h.wsService.NotifyRoomUpdate(roomID, updatedRoom)
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    updatedRoom,
+	})
+}
+
+// AddBasketTokens adds tokens to a room's watch basket, creator only
+func (h *RoomHandler) AddBasketTokens(c *gin.Context) {
+	roomID := c.Param("roomId")
+	creatorAddress := c.GetHeader("X-Creator-Address")
+	
+	if creatorAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator address is required"})
+		return
+	}
+	
+	var req room.BasketTokensRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	req.RoomID = roomID
+	req.CreatorAddress = creatorAddress
+	
+	updatedRoom, err := h.roomService.AddBasketTokens(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to add basket tokens")
+		return
+	}
+	
+	h.subscriptionManager.HandleRoomTokensChanged(roomID, updatedRoom.BoundTokenAddresses())
+	h.wsService.NotifyRoomUpdate(roomID, updatedRoom)
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    updatedRoom,
+	})
+}
+
+// RemoveBasketToken removes a token from a room's watch basket, creator only
+func (h *RoomHandler) RemoveBasketToken(c *gin.Context) {
+	roomID := c.Param("roomId")
+	mintAddress := c.Param("mintAddress")
+	creatorAddress := c.GetHeader("X-Creator-Address")
+	
+	if creatorAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator address is required"})
+		return
+	}
+	
+	updatedRoom, err := h.roomService.RemoveBasketToken(c.Request.Context(), roomID, creatorAddress, mintAddress)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to remove basket token")
+		return
+	}
+	
+	h.subscriptionManager.HandleRoomTokensChanged(roomID, updatedRoom.BoundTokenAddresses())
+	h.wsService.NotifyRoomUpdate(roomID, updatedRoom)
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    updatedRoom,
+	})
+}
+
 // CloseRoom closes a trading room
 func (h *RoomHandler) CloseRoom(c *gin.Context) {
 	roomID := c.Param("roomId")
@@ -196,7 +283,7 @@ func (h *RoomHandler) CloseRoom(c *gin.Context) {
 	}
 	
 	if err := h.roomService.CloseRoom(c.Request.Context(), roomID, creatorAddress); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to close room")
 		return
 	}
 	
@@ -222,7 +309,7 @@ func (h *RoomHandler) DeleteRoom(c *gin.Context) {
 	}
 	
 	if err := h.roomService.DeleteRoom(c.Request.Context(), roomID, creatorAddress); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to delete room")
 		return
 	}
 	
@@ -237,18 +324,17 @@ func (h *RoomHandler) JoinRoom(c *gin.Context) {
 	roomID := c.Param("roomId")
 	
 	var req struct {
-		WalletAddress string `json:"wallet_address" binding:"required"`
+		WalletAddress string `json:"wallet_address" binding:"required,solana_address"`
 		Password      string `json:"password"`
 	}
 	
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
-	member, err := h.roomService.JoinRoom(c.Request.Context(), roomID, req.WalletAddress, req.Password)
+	member, err := h.roomService.JoinRoom(c.Request.Context(), roomID, req.WalletAddress, req.Password, c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to join room")
 		return
 	}
 	
@@ -274,7 +360,7 @@ func (h *RoomHandler) LeaveRoom(c *gin.Context) {
 	}
 	
 	if err := h.roomService.LeaveRoom(c.Request.Context(), roomID, walletAddress); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to leave room")
 		return
 	}
 	
@@ -292,9 +378,10 @@ func (h *RoomHandler) GetRoomMembers(c *gin.Context) {
 		return
 	}
 	
-	members, err := h.roomService.GetRoomMembers(c.Request.Context(), roomID)
+	// Aliased members are listed by alias, except to the creator
+	members, err := h.roomService.ListRoomMembers(c.Request.Context(), roomID, c.GetHeader("X-Wallet-Address"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get room members"})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to get room members")
 		return
 	}
 	
@@ -327,7 +414,7 @@ func (h *RoomHandler) KickMember(c *gin.Context) {
 	}
 	
 	if err := h.roomService.KickMember(c.Request.Context(), roomID, creatorAddress, targetAddress); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to kick member")
 		return
 	}
 	
@@ -337,13 +424,219 @@ func (h *RoomHandler) KickMember(c *gin.Context) {
 	})
 }
 
+// BatchMembers adds or removes up to 100 members in one request, creator only
+func (h *RoomHandler) BatchMembers(c *gin.Context) {
+	roomID := c.Param("roomId")
+	creatorAddress := c.GetHeader("X-Creator-Address")
+	
+	if creatorAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator address is required"})
+		return
+	}
+	
+	var req room.BatchMembersRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	req.RoomID = roomID
+	req.CreatorAddress = creatorAddress
+	
+	result, err := h.roomService.BatchMembers(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to update room members")
+		return
+	}
+	
+	// Notify the room; wallet subscriptions follow the membership change in the room service
+	for _, member := range result.Added {
+		h.wsService.NotifyMemberJoined(roomID, member)
+	}
+	for _, address := range result.Removed {
+		h.wsService.NotifyMemberLeft(roomID, address)
+		h.wsService.DisconnectClient(roomID, address)
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// SetMemberRole promotes a member to moderator or demotes a moderator; moderators are exempt from inactivity pruning
+func (h *RoomHandler) SetMemberRole(c *gin.Context) {
+	roomID := c.Param("roomId")
+	targetAddress := c.Param("address")
+	creatorAddress := c.GetHeader("X-Creator-Address")
+	
+	if creatorAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator address is required"})
+		return
+	}
+	
+	var req struct {
+		Role models.MemberRole `json:"role" binding:"required"`
+	}
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	
+	if err := h.roomService.SetMemberRole(c.Request.Context(), roomID, creatorAddress, targetAddress, req.Role); err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to set member role")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Member role updated",
+	})
+}
+
+// SetTradePrivacy lets a member hide the sizes of their detected trades from the room; the path address must
+// be the caller's
+func (h *RoomHandler) SetTradePrivacy(c *gin.Context) {
+	roomID := c.Param("roomId")
+	address := c.Param("address")
+	walletAddress := c.GetHeader("X-Wallet-Address")
+	
+	if walletAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "wallet address is required"})
+		return
+	}
+	if walletAddress != address {
+		respondError(c, h.logger, room.ErrInsufficientPermission, "Failed to set trade privacy")
+		return
+	}
+	
+	var req struct {
+		HideTradeSizes *bool `json:"hide_trade_sizes" binding:"required"`
+	}
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	
+	if err := h.roomService.SetTradePrivacy(c.Request.Context(), roomID, walletAddress, *req.HideTradeSizes); err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to set trade privacy")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Trade privacy updated",
+	})
+}
+
+// SetMemberAlias sets or clears the alias a member shows in the room in place of their address; the path
+// address must be the caller's
+func (h *RoomHandler) SetMemberAlias(c *gin.Context) {
+	roomID := c.Param("roomId")
+	address := c.Param("address")
+	walletAddress := c.GetHeader("X-Wallet-Address")
+	
+	if walletAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "wallet address is required"})
+		return
+	}
+	if walletAddress != address {
+		respondError(c, h.logger, room.ErrInsufficientPermission, "Failed to set member alias")
+		return
+	}
+	
+	var req struct {
+		Alias string `json:"alias"`
+	}
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	
+	if err := h.roomService.SetMemberAlias(c.Request.Context(), roomID, walletAddress, req.Alias); err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to set member alias")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Member alias updated",
+	})
+}
+
+// RequestTransfer offers the room to another member; creator only
+func (h *RoomHandler) RequestTransfer(c *gin.Context) {
+	roomID := c.Param("roomId")
+	creatorAddress := c.GetHeader("X-Creator-Address")
+	
+	if creatorAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "creator address is required"})
+		return
+	}
+	
+	var req struct {
+		WalletAddress string `json:"wallet_address" binding:"required,solana_address"`
+	}
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	
+	tradeRoom, err := h.roomService.RequestTransfer(c.Request.Context(), roomID, creatorAddress, req.WalletAddress)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to request ownership transfer")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tradeRoom,
+	})
+}
+
+// AcceptTransfer makes the member offered the room its creator
+func (h *RoomHandler) AcceptTransfer(c *gin.Context) {
+	roomID := c.Param("roomId")
+	walletAddress := c.GetHeader("X-Wallet-Address")
+	
+	if walletAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "wallet address is required"})
+		return
+	}
+	
+	tradeRoom, err := h.roomService.AcceptTransfer(c.Request.Context(), roomID, walletAddress)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to accept ownership transfer")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tradeRoom,
+	})
+}
+
+// CancelTransfer withdraws or declines a pending ownership transfer
+func (h *RoomHandler) CancelTransfer(c *gin.Context) {
+	roomID := c.Param("roomId")
+	walletAddress := c.GetHeader("X-Wallet-Address")
+	
+	if walletAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "wallet address is required"})
+		return
+	}
+	
+	if err := h.roomService.CancelTransfer(c.Request.Context(), roomID, walletAddress); err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to cancel ownership transfer")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Ownership transfer cancelled",
+	})
+}
+
 // ShareInfo shares information in a room
 func (h *RoomHandler) ShareInfo(c *gin.Context) {
 	roomID := c.Param("roomId")
 	
 	var req room.ShareInfoRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
@@ -351,7 +644,7 @@ func (h *RoomHandler) ShareInfo(c *gin.Context) {
 	
 	info, err := h.roomService.ShareInfo(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to share info")
 		return
 	}
 	
@@ -364,7 +657,7 @@ func (h *RoomHandler) ShareInfo(c *gin.Context) {
 	})
 }
 
-// GetSharedInfos gets shared information from a room
+// GetSharedInfos gets shared information from a room (query: type, token)
 func (h *RoomHandler) GetSharedInfos(c *gin.Context) {
 	roomID := c.Param("roomId")
 	
@@ -381,9 +674,21 @@ func (h *RoomHandler) GetSharedInfos(c *gin.Context) {
 		offset = 0
 	}
 	
-	infos, err := h.roomService.GetSharedInfos(c.Request.Context(), roomID, limit, offset)
+	infoType := models.SharedInfoType(c.Query("type"))
+	if infoType != "" && !infoType.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shared info type"})
+		return
+	}
+	token := c.Query("token")
+	
+	var infos []*models.SharedInfo
+	if infoType != "" || token != "" {
+		infos, err = h.roomService.FilterSharedInfos(c.Request.Context(), roomID, infoType, token, limit, offset)
+	} else {
+		infos, err = h.roomService.GetSharedInfos(c.Request.Context(), roomID, limit, offset)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get shared information"})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to get shared information")
 		return
 	}
 	
@@ -398,6 +703,47 @@ func (h *RoomHandler) GetSharedInfos(c *gin.Context) {
 	})
 }
 
+// GetSignals lists signals for a token shared in public rooms (query: token, side)
+func (h *RoomHandler) GetSignals(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token is required"})
+		return
+	}
+	
+	side := models.SignalSide(c.Query("side"))
+	if side != "" && side != models.SignalSideBuy && side != models.SignalSideSell {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "side must be buy or sell"})
+		return
+	}
+	
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	
+	signals, err := h.roomService.GetSignals(c.Request.Context(), token, side, limit, offset)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to get signals")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    signals,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(signals),
+		},
+	})
+}
+
 // UpdateSharedInfo updates shared information
 func (h *RoomHandler) UpdateSharedInfo(c *gin.Context) {
 	infoIDStr := c.Param("infoId")
@@ -409,14 +755,13 @@ func (h *RoomHandler) UpdateSharedInfo(c *gin.Context) {
 	}
 	
 	var req room.UpdateSharedInfoRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
 	info, err := h.roomService.UpdateSharedInfo(c.Request.Context(), infoID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("info_id", infoID), err, "Failed to update shared info")
 		return
 	}
 	
@@ -443,7 +788,7 @@ func (h *RoomHandler) DeleteSharedInfo(c *gin.Context) {
 	}
 	
 	if err := h.roomService.DeleteSharedInfo(c.Request.Context(), infoID, sharerAddress); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("info_id", infoID), err, "Failed to delete shared info")
 		return
 	}
 	
@@ -453,24 +798,123 @@ func (h *RoomHandler) DeleteSharedInfo(c *gin.Context) {
 	})
 }
 
-// LikeSharedInfo likes shared information
-func (h *RoomHandler) LikeSharedInfo(c *gin.Context) {
-	infoIDStr := c.Param("infoId")
+// ReactRequest is the body of a reaction request
+type ReactRequest struct {
+	Type models.ReactionType `json:"type" binding:"required"`
+}
+
+// React adds the wallet's reaction to shared information
+func (h *RoomHandler) React(c *gin.Context) {
+	infoID, err := uuid.Parse(c.Param("infoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid info ID"})
+		return
+	}
 	
-	infoID, err := uuid.Parse(infoIDStr)
+	walletAddress := c.GetHeader("X-Wallet-Address")
+	if walletAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "wallet address is required"})
+		return
+	}
+	
+	var req ReactRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	
+	summary, err := h.roomService.React(c.Request.Context(), infoID, walletAddress, req.Type)
+	if err != nil {
+		respondError(c, h.logger.WithField("info_id", infoID), err, "Failed to add reaction")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+// Unreact removes the wallet's reaction from shared information
+func (h *RoomHandler) Unreact(c *gin.Context) {
+	infoID, err := uuid.Parse(c.Param("infoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid info ID"})
+		return
+	}
+	
+	walletAddress := c.GetHeader("X-Wallet-Address")
+	if walletAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "wallet address is required"})
+		return
+	}
+	
+	reactionType := models.ReactionType(c.Param("type"))
+	summary, err := h.roomService.Unreact(c.Request.Context(), infoID, walletAddress, reactionType)
+	if err != nil {
+		respondError(c, h.logger.WithField("info_id", infoID), err, "Failed to remove reaction")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+// GetReactionSummary gets reaction counts of shared information, plus the caller's own
+// reactions when X-Wallet-Address is set
+func (h *RoomHandler) GetReactionSummary(c *gin.Context) {
+	infoID, err := uuid.Parse(c.Param("infoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid info ID"})
+		return
+	}
+	
+	summary, err := h.roomService.GetReactionSummary(c.Request.Context(), infoID, c.GetHeader("X-Wallet-Address"))
+	if err != nil {
+		respondError(c, h.logger.WithField("info_id", infoID), err, "Failed to get reactions")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+// GetReactions lists who reacted to shared information (query: type)
+func (h *RoomHandler) GetReactions(c *gin.Context) {
+	infoID, err := uuid.Parse(c.Param("infoId"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid info ID"})
 		return
 	}
 	
-	if err := h.roomService.LikeSharedInfo(c.Request.Context(), infoID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like shared info"})
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	
+	reactionType := models.ReactionType(c.Query("type"))
+	reactions, err := h.roomService.GetReactions(c.Request.Context(), infoID, reactionType, limit, offset)
+	if err != nil {
+		respondError(c, h.logger.WithField("info_id", infoID), err, "Failed to get reactions")
 		return
 	}
 	
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Liked successfully",
+		"data":    reactions,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(reactions),
+		},
 	})
 }
 
@@ -479,8 +923,7 @@ func (h *RoomHandler) RecordTradeEvent(c *gin.Context) {
 	roomID := c.Param("roomId")
 	
 	var req room.TradeEventRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
@@ -488,7 +931,7 @@ func (h *RoomHandler) RecordTradeEvent(c *gin.Context) {
 	
 	event, err := h.roomService.RecordTradeEvent(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to record trade event")
 		return
 	}
 	
@@ -520,7 +963,7 @@ func (h *RoomHandler) GetTradeEvents(c *gin.Context) {
 	
 	events, err := h.roomService.GetTradeEvents(c.Request.Context(), roomID, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get trade events"})
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to get trade events")
 		return
 	}
 	
@@ -535,15 +978,73 @@ func (h *RoomHandler) GetTradeEvents(c *gin.Context) {
 	})
 }
 
+// ReplayEvents returns the room's lifecycle events after a sequence number, oldest first (query: since, limit)
+func (h *RoomHandler) ReplayEvents(c *gin.Context) {
+	roomID := c.Param("roomId")
+	
+	since, err := strconv.ParseInt(c.DefaultQuery("since", "0"), 10, 64)
+	if err != nil || since < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "since must be a non-negative event sequence"})
+		return
+	}
+	
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "500"))
+	if err != nil {
+		limit = 0
+	}
+	
+	replay, err := h.roomService.ReplayEvents(c.Request.Context(), roomID, since, limit)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to replay room events")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    replay,
+	})
+}
+
+// GetFeed returns the room's WebSocket broadcasts as clients saw them live, oldest first (query: before, limit)
+func (h *RoomHandler) GetFeed(c *gin.Context) {
+	roomID := c.Param("roomId")
+	
+	before, err := strconv.ParseInt(c.DefaultQuery("before", "0"), 10, 64)
+	if err != nil || before < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "before must be a non-negative feed sequence"})
+		return
+	}
+	
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		limit = 0
+	}
+	
+	feed, err := h.roomService.GetFeed(c.Request.Context(), roomID, before, limit)
+	if err != nil {
+		respondError(c, h.logger.WithField("room_id", roomID), err, "Failed to get room feed")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    feed,
+	})
+}
+
 // RegisterRoutes registers room API routes
 func (h *RoomHandler) RegisterRoutes(router *gin.RouterGroup) {
 	rooms := router.Group("/rooms")
 	{
 		// Room management
-		rooms.POST("", h.CreateRoom)
+		// Retried creates, shares and trade events with an Idempotency-Key get the original response
+		rooms.POST("", h.idempotency.Middleware(), h.CreateRoom)
 		rooms.GET("", h.ListRooms)
 		rooms.GET("/:roomId", h.GetRoom)
 		rooms.PUT("/:roomId", h.UpdateRoom)
+		rooms.PUT("/:roomId/token", h.SetRoomToken)
+		rooms.POST("/:roomId/tokens", h.AddBasketTokens)
+		rooms.DELETE("/:roomId/tokens/:mintAddress", h.RemoveBasketToken)
 		rooms.DELETE("/:roomId", h.DeleteRoom)
 		rooms.POST("/:roomId/close", h.CloseRoom)
 		
@@ -552,19 +1053,36 @@ func (h *RoomHandler) RegisterRoutes(router *gin.RouterGroup) {
 		rooms.POST("/:roomId/leave", h.LeaveRoom)
 		rooms.GET("/:roomId/members", h.GetRoomMembers)
 		rooms.DELETE("/:roomId/members/:address", h.KickMember)
+		rooms.PUT("/:roomId/members/:address/role", h.SetMemberRole)
+		rooms.PUT("/:roomId/members/:address/privacy", h.SetTradePrivacy)
+		rooms.PUT("/:roomId/members/:address/alias", h.SetMemberAlias)
+		rooms.POST("/:roomId/members/batch", h.BatchMembers)
+		
+		// Ownership transfer
+		rooms.POST("/:roomId/transfer", h.RequestTransfer)
+		rooms.POST("/:roomId/transfer/accept", h.AcceptTransfer)
+		rooms.DELETE("/:roomId/transfer", h.CancelTransfer)
 		
 		// Content management
-		rooms.POST("/:roomId/share", h.ShareInfo)
+		rooms.POST("/:roomId/share", h.idempotency.Middleware(), h.ShareInfo)
 		rooms.GET("/:roomId/shares", h.GetSharedInfos)
 		rooms.PUT("/shares/:infoId", h.UpdateSharedInfo)
 		rooms.DELETE("/shares/:infoId", h.DeleteSharedInfo)
-		rooms.POST("/shares/:infoId/like", h.LikeSharedInfo)
+		rooms.GET("/shares/:infoId/reactions", h.GetReactionSummary)
+		rooms.GET("/shares/:infoId/reactions/users", h.GetReactions)
+		rooms.POST("/shares/:infoId/reactions", h.React)
+		rooms.DELETE("/shares/:infoId/reactions/:type", h.Unreact)
 		
 		// Trade events
-		rooms.POST("/:roomId/events", h.RecordTradeEvent)
+		rooms.POST("/:roomId/events", h.idempotency.Middleware(), h.RecordTradeEvent)
 		rooms.GET("/:roomId/events", h.GetTradeEvents)
+		rooms.GET("/:roomId/events/replay", h.ReplayEvents)
+		rooms.GET("/:roomId/feed", h.GetFeed)
 	}
 	
+	// Structured signal queries across public rooms
+	router.GET("/signals", h.GetSignals)
+	
 	// User-specific routes
 	users := router.Group("/users")
 	{
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+// ScreenerHandler handles HTTP requests for the token screener and its saved presets
+type ScreenerHandler struct {
+	screenerService token.ScreenerService
+	logger          *logrus.Logger
+}
+
+// NewScreenerHandler creates a new screener handler
+func NewScreenerHandler(screenerService token.ScreenerService, logger *logrus.Logger) *ScreenerHandler {
+	return &ScreenerHandler{
+		screenerService: screenerService,
+		logger:          logger,
+	}
+}
+
+// Screen lists tokens matching a screener expression
+// (query: filters, e.g. marketCap>1M,volume24h>100k,age<7d; sort; order; limit; offset)
+func (h *ScreenerHandler) Screen(c *gin.Context) {
+	limit, offset := screenerPage(c)
+
+	// Fetch one extra row to tell whether another page follows
+	tokens, err := h.screenerService.Screen(c.Request.Context(), c.Query("filters"), c.Query("sort"), c.Query("order"), limit+1, offset)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to screen tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, screenerResults(tokens, limit, offset))
+}
+
+// RunPreset lists tokens matching one of the wallet's saved screeners (query: limit, offset)
+func (h *ScreenerHandler) RunPreset(c *gin.Context) {
+	presetID, err := uuid.Parse(c.Param("presetId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid preset ID"})
+		return
+	}
+	limit, offset := screenerPage(c)
+
+	tokens, preset, err := h.screenerService.RunPreset(c.Request.Context(), c.Param("address"), presetID, limit+1, offset)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to run screener preset")
+		return
+	}
+
+	response := screenerResults(tokens, limit, offset)
+	response["preset"] = preset
+	c.JSON(http.StatusOK, response)
+}
+
+// SavePreset saves a screener for the wallet; saving an existing name overwrites it
+func (h *ScreenerHandler) SavePreset(c *gin.Context) {
+	var req token.SaveScreenerPresetRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	preset, err := h.screenerService.SavePreset(c.Request.Context(), c.Param("address"), &req)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to save screener preset")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    preset,
+	})
+}
+
+// ListPresets lists the wallet's saved screeners by name
+func (h *ScreenerHandler) ListPresets(c *gin.Context) {
+	presets, err := h.screenerService.ListPresets(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list screener presets")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    presets,
+	})
+}
+
+// DeletePreset deletes one of the wallet's saved screeners
+func (h *ScreenerHandler) DeletePreset(c *gin.Context) {
+	presetID, err := uuid.Parse(c.Param("presetId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid preset ID"})
+		return
+	}
+
+	if err := h.screenerService.DeletePreset(c.Request.Context(), c.Param("address"), presetID); err != nil {
+		respondError(c, h.logger, err, "Failed to delete screener preset")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Screener preset deleted",
+	})
+}
+
+func screenerPage(c *gin.Context) (limit, offset int) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err = strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// screenerResults trims the extra row fetched past limit into has_more
+func screenerResults(tokens []*models.Token, limit, offset int) gin.H {
+	hasMore := len(tokens) > limit
+	if hasMore {
+		tokens = tokens[:limit]
+	}
+	return gin.H{
+		"success": true,
+		"data":    tokens,
+		"pagination": gin.H{
+			"limit":    limit,
+			"offset":   offset,
+			"count":    len(tokens),
+			"has_more": hasMore,
+		},
+	}
+}
+
+// RegisterRoutes registers screener API routes
+func (h *ScreenerHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/tokens/screener", h.Screen)
+	router.GET("/users/:address/screeners", h.ListPresets)
+	router.POST("/users/:address/screeners", h.SavePreset)
+	router.GET("/users/:address/screeners/:presetId/tokens", h.RunPreset)
+	router.DELETE("/users/:address/screeners/:presetId", h.DeletePreset)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/social"
+)
+
+// SocialHandler handles HTTP requests for token social metrics
+type SocialHandler struct {
+	socialService social.SocialService
+	logger        *logrus.Logger
+}
+
+// NewSocialHandler creates a new social handler
+func NewSocialHandler(socialService social.SocialService, logger *logrus.Logger) *SocialHandler {
+	return &SocialHandler{
+		socialService: socialService,
+		logger:        logger,
+	}
+}
+
+// GetTokenSocial returns a token's hourly social mentions and their summary (query: hours, default 24, max 168)
+func (h *SocialHandler) GetTokenSocial(c *gin.Context) {
+	mintAddress := c.Param("mintAddress")
+	if mintAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mint address is required"})
+		return
+	}
+
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours <= 0 || hours > 168 {
+		hours = 24
+	}
+
+	result, err := h.socialService.GetTokenSocial(c.Request.Context(), mintAddress, hours)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": mintAddress,
+		}).Error("Failed to get token social metrics")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get token social metrics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// RegisterRoutes registers social API routes
+func (h *SocialHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/tokens/mint/:mintAddress/social", h.GetTokenSocial)
+}
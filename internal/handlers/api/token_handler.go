@@ -3,44 +3,54 @@ package api
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
 	"github.com/emiyaio/solana-wallet-service/internal/services/token"
 )
 
 // TokenHandler handles HTTP requests for token operations
 type TokenHandler struct {
-	marketService   token.MarketService
-	analysisService token.AnalysisService
-	logger          *logrus.Logger
+	marketService     token.MarketService
+	analysisService   token.AnalysisService
+	provenanceService token.ProvenanceService
+	chartService      token.ChartService
+	flagService       token.FlagService
+	sellability       token.SellabilityService
+	adminGuard        *middleware.AdminGuard
+	logger            *logrus.Logger
 }
 
 // NewTokenHandler creates a new token handler
-func NewTokenHandler(marketService token.MarketService, analysisService token.AnalysisService, logger *logrus.Logger) *TokenHandler {
+func NewTokenHandler(marketService token.MarketService, analysisService token.AnalysisService, provenanceService token.ProvenanceService, chartService token.ChartService, flagService token.FlagService, sellability token.SellabilityService, adminGuard *middleware.AdminGuard, logger *logrus.Logger) *TokenHandler {
 	return &TokenHandler{
-		marketService:   marketService,
-		analysisService: analysisService,
-		logger:          logger,
+		marketService:     marketService,
+		analysisService:   analysisService,
+		provenanceService: provenanceService,
+		chartService:      chartService,
+		flagService:       flagService,
+		sellability:       sellability,
+		adminGuard:        adminGuard,
+		logger:            logger,
 	}
 }
 
 // CreateToken creates a new token
 func (h *TokenHandler) CreateToken(c *gin.Context) {
 	var req token.CreateTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
 	token, err := h.marketService.CreateToken(c.Request.Context(), &req)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":        err,
-			"mint_address": req.MintAddress,
-		}).Error("Failed to create token")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create token"})
+		respondError(c, h.logger.WithField("mint_address", req.MintAddress), err, "Failed to create token")
 		return
 	}
 	
@@ -60,11 +70,7 @@ func (h *TokenHandler) GetToken(c *gin.Context) {
 	
 	token, err := h.marketService.GetToken(c.Request.Context(), mintAddress)
 	if err != nil {
-		if err.Error() == "token not found: "+mintAddress {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Token not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get token"})
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to get token")
 		return
 	}
 	
@@ -74,7 +80,8 @@ func (h *TokenHandler) GetToken(c *gin.Context) {
 	})
 }
 
-// ListTokens lists all tokens with pagination
+// ListTokens lists tokens with pagination (query: q matches symbol, name or mint; category (alias narrative);
+// min_liquidity in USD; sort: created_at, market_cap, volume_24h, price_change_24h; order: asc, desc)
 func (h *TokenHandler) ListTokens(c *gin.Context) {
 	limitStr := c.DefaultQuery("limit", "20")
 	offsetStr := c.DefaultQuery("offset", "0")
@@ -89,19 +96,58 @@ func (h *TokenHandler) ListTokens(c *gin.Context) {
 		offset = 0
 	}
 	
-	tokens, err := h.marketService.ListTokens(c.Request.Context(), limit, offset)
+	category := c.Query("category")
+	if category == "" {
+		category = c.Query("narrative")
+	}
+	narrative := models.TokenNarrative(category)
+	if narrative != "" && !narrative.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category"})
+		return
+	}
+	filter := repositories.TokenFilter{Query: c.Query("q"), Narrative: narrative}
+	
+	if minLiquidity := c.Query("min_liquidity"); minLiquidity != "" {
+		filter.MinLiquidityUSD, err = strconv.ParseFloat(minLiquidity, 64)
+		if err != nil || filter.MinLiquidityUSD < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_liquidity"})
+			return
+		}
+	}
+	
+	sort := repositories.TokenSort{Field: repositories.TokenSortField(c.DefaultQuery("sort", string(repositories.TokenSortCreatedAt)))}
+	if !sort.Field.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of created_at, market_cap, volume_24h, price_change_24h"})
+		return
+	}
+	order := c.DefaultQuery("order", "desc")
+	if order != "asc" && order != "desc" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order must be asc or desc"})
+		return
+	}
+	sort.Ascending = order == "asc"
+	
+	// Fetch one extra row to tell whether another page follows
+	tokens, err := h.marketService.SearchTokens(c.Request.Context(), filter, sort, limit+1, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tokens"})
+		respondError(c, h.logger, err, "Failed to list tokens")
 		return
 	}
+	hasMore := len(tokens) > limit
+	if hasMore {
+		tokens = tokens[:limit]
+	}
 	
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    tokens,
 		"pagination": gin.H{
-			"limit":  limit,
-			"offset": offset,
-			"count":  len(tokens),
+			"limit":    limit,
+			"offset":   offset,
+			"count":    len(tokens),
+			"has_more": hasMore,
+			"sort":     sort.Field,
+			"order":    order,
 		},
 	})
 }
@@ -117,7 +163,7 @@ func (h *TokenHandler) GetMarketData(c *gin.Context) {
 	
 	marketData, err := h.marketService.GetLatestMarketData(c.Request.Context(), tokenID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get market data"})
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to get market data")
 		return
 	}
 	
@@ -132,6 +178,34 @@ func (h *TokenHandler) GetMarketData(c *gin.Context) {
 	})
 }
 
+// GetChart gets a downsampled price/volume chart for a token
+func (h *TokenHandler) GetChart(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := uuid.Parse(tokenIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+	
+	interval := c.DefaultQuery("interval", "24h")
+	
+	points, err := strconv.Atoi(c.DefaultQuery("points", strconv.Itoa(token.DefaultChartPoints)))
+	if err != nil || points <= 0 || points > token.MaxChartPoints {
+		points = token.DefaultChartPoints
+	}
+	
+	chart, err := h.chartService.GetChart(c.Request.Context(), tokenID, interval, points)
+	if err != nil {
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to get token chart")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    chart,
+	})
+}
+
 // SyncMarketData syncs market data from external API
 func (h *TokenHandler) SyncMarketData(c *gin.Context) {
 	mintAddress := c.Param("mintAddress")
@@ -142,11 +216,7 @@ func (h *TokenHandler) SyncMarketData(c *gin.Context) {
 	
 	marketData, err := h.marketService.SyncMarketDataFromExternalAPI(c.Request.Context(), mintAddress)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":        err,
-			"mint_address": mintAddress,
-		}).Error("Failed to sync market data")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sync market data"})
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to sync market data")
 		return
 	}
 	
@@ -156,14 +226,51 @@ func (h *TokenHandler) SyncMarketData(c *gin.Context) {
 	})
 }
 
+// GetPools gets the pools a token trades in with their price, liquidity and volume
+func (h *TokenHandler) GetPools(c *gin.Context) {
+	mintAddress := c.Param("mintAddress")
+	if mintAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mint_address is required"})
+		return
+	}
+	
+	pools, err := h.marketService.GetPools(c.Request.Context(), mintAddress)
+	if err != nil {
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to get token pools")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    pools,
+	})
+}
+
+// RefreshMetadata re-fetches a token's name, logo and socials regardless of their age
+func (h *TokenHandler) RefreshMetadata(c *gin.Context) {
+	mintAddress := c.Param("mintAddress")
+	if mintAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mint_address is required"})
+		return
+	}
+	
+	token, err := h.marketService.RefreshMetadata(c.Request.Context(), mintAddress)
+	if err != nil {
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to refresh token metadata")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    token,
+	})
+}
+
 // SyncAllMarketData syncs market data for all tokens
 func (h *TokenHandler) SyncAllMarketData(c *gin.Context) {
 	err := h.marketService.SyncAllTokensMarketData(c.Request.Context())
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error": err,
-		}).Error("Failed to sync all market data")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sync all market data"})
+		respondError(c, h.logger, err, "Failed to sync all market data")
 		return
 	}
 	
@@ -173,7 +280,7 @@ func (h *TokenHandler) SyncAllMarketData(c *gin.Context) {
 	})
 }
 
-// GetTrendingTokens gets trending tokens by category
+// GetTrendingTokens gets trending tokens by category, optionally limited to a narrative
 func (h *TokenHandler) GetTrendingTokens(c *gin.Context) {
 	category := c.DefaultQuery("category", "general")
 	timeframe := c.DefaultQuery("timeframe", "24h")
@@ -184,9 +291,15 @@ func (h *TokenHandler) GetTrendingTokens(c *gin.Context) {
 		limit = 50
 	}
 	
-	rankings, err := h.marketService.GetTrendingTokens(c.Request.Context(), category, timeframe, limit)
+	narrative := models.TokenNarrative(c.Query("narrative"))
+	if narrative != "" && !narrative.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid narrative"})
+		return
+	}
+	
+	rankings, err := h.marketService.GetTrendingTokens(c.Request.Context(), category, timeframe, narrative, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get trending tokens"})
+		respondError(c, h.logger, err, "Failed to get trending tokens")
 		return
 	}
 	
@@ -195,6 +308,7 @@ func (h *TokenHandler) GetTrendingTokens(c *gin.Context) {
 		"data": gin.H{
 			"category":  category,
 			"timeframe": timeframe,
+			"narrative": narrative,
 			"rankings":  rankings,
 		},
 	})
@@ -217,7 +331,7 @@ func (h *TokenHandler) GetTopHolders(c *gin.Context) {
 	
 	holders, err := h.marketService.GetTopHolders(c.Request.Context(), tokenID, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get top holders"})
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to get top holders")
 		return
 	}
 	
@@ -227,6 +341,182 @@ func (h *TokenHandler) GetTopHolders(c *gin.Context) {
 	})
 }
 
+// GetHolderChanges gets top holders that entered, exited or moved between holder snapshots
+// (query: hours, compare against the snapshot at least that old instead of the previous one;
+// include_excluded, count exchange and program accounts in the net change)
+func (h *TokenHandler) GetHolderChanges(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := uuid.Parse(tokenIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+	
+	var since time.Time
+	if hoursStr := c.Query("hours"); hoursStr != "" {
+		hours, err := strconv.Atoi(hoursStr)
+		if err != nil || hours <= 0 || hours > 24*30 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "hours must be between 1 and 720"})
+			return
+		}
+		since = time.Now().Add(-time.Duration(hours) * time.Hour)
+	}
+	
+	includeExcluded := c.Query("include_excluded") == "true"
+	
+	changes, err := h.marketService.GetHolderChanges(c.Request.Context(), tokenID, since, includeExcluded)
+	if err != nil {
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to get holder changes")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    changes,
+	})
+}
+
+// GetProvenance gets the deployer and creation history of a token
+func (h *TokenHandler) GetProvenance(c *gin.Context) {
+	mintAddress := c.Param("mintAddress")
+	if mintAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mint_address is required"})
+		return
+	}
+	
+	provenance, err := h.provenanceService.GetProvenance(c.Request.Context(), mintAddress)
+	if err != nil {
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to get token provenance")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    provenance,
+	})
+}
+
+// GetFlag gets a token's scam/honeypot flag and its reason history
+func (h *TokenHandler) GetFlag(c *gin.Context) {
+	mintAddress := c.Param("mintAddress")
+	if mintAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mint_address is required"})
+		return
+	}
+	
+	flag, err := h.flagService.GetFlag(c.Request.Context(), mintAddress)
+	if err != nil {
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to get token flag")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flag,
+	})
+}
+
+// FlagToken marks a token as a scam, honeypot or rug
+func (h *TokenHandler) FlagToken(c *gin.Context) {
+	mintAddress := c.Param("mintAddress")
+	
+	var req token.FlagTokenRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+	
+	flag, err := h.flagService.FlagToken(c.Request.Context(), mintAddress, &req)
+	if err != nil {
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to flag token")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flag,
+	})
+}
+
+// ClearFlag lifts a token's active flag; the flag stays in its history
+func (h *TokenHandler) ClearFlag(c *gin.Context) {
+	mintAddress := c.Param("mintAddress")
+	
+	req := token.ClearFlagRequest{
+		Reason:    c.Query("reason"),
+		ClearedBy: c.Query("cleared_by"),
+	}
+	
+	flag, err := h.flagService.ClearFlag(c.Request.Context(), mintAddress, &req)
+	if err != nil {
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to clear token flag")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flag,
+	})
+}
+
+// ListFlags lists flagged tokens, most recently changed first
+func (h *TokenHandler) ListFlags(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	
+	activeOnly := c.DefaultQuery("active", "true") != "false"
+	flags, err := h.flagService.ListFlags(c.Request.Context(), activeOnly, limit, offset)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list token flags")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    flags,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(flags),
+		},
+	})
+}
+
+// CheckSellability simulates buying and selling a token to detect honeypots.
+// The path segment is shared with the token ID routes, so it accepts a mint address or a token ID.
+func (h *TokenHandler) CheckSellability(c *gin.Context) {
+	mintAddress := c.Param("tokenId")
+	if tokenID, err := uuid.Parse(mintAddress); err == nil {
+		t, err := h.marketService.GetTokenByID(c.Request.Context(), tokenID)
+		if err != nil {
+			respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to get token")
+			return
+		}
+		if t == nil {
+			respondError(c, h.logger, token.ErrTokenNotFound, "Failed to get token")
+			return
+		}
+		mintAddress = t.MintAddress
+	}
+	
+	result, err := h.sellability.CheckSellability(c.Request.Context(), mintAddress)
+	if err != nil {
+		respondError(c, h.logger.WithField("mint_address", mintAddress), err, "Failed to check token sellability")
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
 // GetTransactionStats gets transaction statistics for a token
 func (h *TokenHandler) GetTransactionStats(c *gin.Context) {
 	tokenIDStr := c.Param("tokenId")
@@ -240,7 +530,7 @@ func (h *TokenHandler) GetTransactionStats(c *gin.Context) {
 	
 	stats, err := h.marketService.GetTransactionStats(c.Request.Context(), tokenID, timeframe)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get transaction stats"})
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to get transaction stats")
 		return
 	}
 	
@@ -266,11 +556,7 @@ func (h *TokenHandler) AnalyzeToken(c *gin.Context) {
 	
 	analysis, err := h.analysisService.AnalyzeTokenMarketData(c.Request.Context(), tokenID)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":    err,
-			"token_id": tokenID,
-		}).Error("Failed to analyze token")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to analyze token"})
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to analyze token")
 		return
 	}
 	
@@ -293,12 +579,10 @@ func (h *TokenHandler) AnalyzeTrends(c *gin.Context) {
 	
 	trends, err := h.analysisService.AnalyzeTokenTrends(c.Request.Context(), tokenID, timeframe)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":     err,
+		respondError(c, h.logger.WithFields(logrus.Fields{
 			"token_id":  tokenID,
 			"timeframe": timeframe,
-		}).Error("Failed to analyze trends")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to analyze trends"})
+		}), err, "Failed to analyze trends")
 		return
 	}
 	
@@ -319,11 +603,7 @@ func (h *TokenHandler) AnalyzeSentiment(c *gin.Context) {
 	
 	sentiment, err := h.analysisService.AnalyzeMarketSentiment(c.Request.Context(), tokenID)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":    err,
-			"token_id": tokenID,
-		}).Error("Failed to analyze sentiment")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to analyze sentiment"})
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to analyze sentiment")
 		return
 	}
 	
@@ -344,11 +624,7 @@ func (h *TokenHandler) AssessRisk(c *gin.Context) {
 	
 	riskAssessment, err := h.analysisService.AssessTokenRisk(c.Request.Context(), tokenID)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":    err,
-			"token_id": tokenID,
-		}).Error("Failed to assess risk")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assess risk"})
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to assess risk")
 		return
 	}
 	
@@ -369,11 +645,7 @@ func (h *TokenHandler) GetVolatilityMetrics(c *gin.Context) {
 	
 	volatility, err := h.analysisService.CalculateVolatilityMetrics(c.Request.Context(), tokenID)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":    err,
-			"token_id": tokenID,
-		}).Error("Failed to calculate volatility")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate volatility"})
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to calculate volatility")
 		return
 	}
 	
@@ -394,11 +666,7 @@ func (h *TokenHandler) GetRecommendation(c *gin.Context) {
 	
 	recommendation, err := h.analysisService.GenerateTokenRecommendation(c.Request.Context(), tokenID)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":    err,
-			"token_id": tokenID,
-		}).Error("Failed to generate recommendation")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate recommendation"})
+		respondError(c, h.logger.WithField("token_id", tokenID), err, "Failed to generate recommendation")
 		return
 	}
 	
@@ -414,8 +682,7 @@ func (h *TokenHandler) BatchAnalyzeTokens(c *gin.Context) {
 		TokenIDs []string `json:"token_ids" binding:"required"`
 	}
 	
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
@@ -437,11 +704,7 @@ func (h *TokenHandler) BatchAnalyzeTokens(c *gin.Context) {
 	
 	results, err := h.analysisService.BatchAnalyzeTokens(c.Request.Context(), tokenIDs)
 	if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error": err,
-			"count": len(tokenIDs),
-		}).Error("Failed to perform batch analysis")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to perform batch analysis"})
+		respondError(c, h.logger.WithField("count", len(tokenIDs)), err, "Failed to perform batch analysis")
 		return
 	}
 	
@@ -454,6 +717,14 @@ func (h *TokenHandler) BatchAnalyzeTokens(c *gin.Context) {
 	})
 }
 
+// GetNarratives lists the narrative taxonomy tokens are tagged with
+func (h *TokenHandler) GetNarratives(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    models.TokenNarratives,
+	})
+}
+
 // RegisterRoutes registers token API routes
 func (h *TokenHandler) RegisterRoutes(router *gin.RouterGroup) {
 	tokens := router.Group("/tokens")
@@ -461,16 +732,23 @@ func (h *TokenHandler) RegisterRoutes(router *gin.RouterGroup) {
 		// Token management
 		tokens.POST("", h.CreateToken)
 		tokens.GET("", h.ListTokens)
+		tokens.GET("/narratives", h.GetNarratives)
 		tokens.GET("/mint/:mintAddress", h.GetToken)
+		tokens.GET("/mint/:mintAddress/provenance", h.GetProvenance)
+		tokens.GET("/mint/:mintAddress/flag", h.GetFlag)
+		tokens.GET("/mint/:mintAddress/pools", h.GetPools)
 		
 		// Market data
 		tokens.GET("/:tokenId/market", h.GetMarketData)
+		tokens.GET("/:tokenId/chart", h.GetChart)
 		tokens.POST("/mint/:mintAddress/sync", h.SyncMarketData)
-		tokens.POST("/sync-all", h.SyncAllMarketData)
+		tokens.POST("/mint/:mintAddress/refresh-metadata", h.RefreshMetadata)
+		tokens.POST("/sync-all", h.adminGuard.Require(models.AdminRoleOperator), h.SyncAllMarketData)
 		
 		// Trending and stats
 		tokens.GET("/trending", h.GetTrendingTokens)
 		tokens.GET("/:tokenId/holders", h.GetTopHolders)
+		tokens.GET("/:tokenId/holder-changes", h.GetHolderChanges)
 		tokens.GET("/:tokenId/stats", h.GetTransactionStats)
 		
 		// Analysis endpoints
@@ -478,10 +756,18 @@ func (h *TokenHandler) RegisterRoutes(router *gin.RouterGroup) {
 		tokens.GET("/:tokenId/trends", h.AnalyzeTrends)
 		tokens.GET("/:tokenId/sentiment", h.AnalyzeSentiment)
 		tokens.GET("/:tokenId/risk", h.AssessRisk)
+		tokens.GET("/:tokenId/sellability", h.CheckSellability)
 		tokens.GET("/:tokenId/volatility", h.GetVolatilityMetrics)
 		tokens.GET("/:tokenId/recommendation", h.GetRecommendation)
 		
 		// Batch operations
 		tokens.POST("/batch/analyze", h.BatchAnalyzeTokens)
 	}
+	
+	admin := router.Group("/admin/tokens")
+	{
+		admin.GET("/flags", h.adminGuard.Require(models.AdminRoleViewer), h.ListFlags)
+		admin.PUT("/:mintAddress/flag", h.adminGuard.Require(models.AdminRoleAdmin), h.FlagToken)
+		admin.DELETE("/:mintAddress/flag", h.adminGuard.Require(models.AdminRoleAdmin), h.ClearFlag)
+	}
 }
\ No newline at end of file
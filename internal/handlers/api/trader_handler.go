@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/trader"
+)
+
+// TraderHandler handles HTTP requests for trader profiles
+type TraderHandler struct {
+	traderService trader.TraderService
+	signalTracker trader.SignalTracker
+	logger        *logrus.Logger
+}
+
+// NewTraderHandler creates a new trader handler
+func NewTraderHandler(traderService trader.TraderService, signalTracker trader.SignalTracker, logger *logrus.Logger) *TraderHandler {
+	return &TraderHandler{
+		traderService: traderService,
+		signalTracker: signalTracker,
+		logger:        logger,
+	}
+}
+
+// GetProfile returns a wallet's trading stats and signal accuracy
+func (h *TraderHandler) GetProfile(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	profile, err := h.traderService.GetProfile(c.Request.Context(), address)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": address,
+		}).Error("Failed to get trader profile")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get trader profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    profile,
+	})
+}
+
+// GetSignals returns the tracked signals a wallet has shared, newest first
+func (h *TraderHandler) GetSignals(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	outcomes, err := h.signalTracker.GetOutcomes(c.Request.Context(), address, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get signal outcomes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    outcomes,
+		"count":   len(outcomes),
+	})
+}
+
+// GetExecutionStats returns a wallet's average fees and slippage per DEX platform next to all tracked wallets
+func (h *TraderHandler) GetExecutionStats(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days <= 0 || days > 365 {
+		days = 30
+	}
+
+	stats, err := h.traderService.GetExecutionStats(c.Request.Context(), address, days)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": address,
+		}).Error("Failed to get execution stats")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get execution stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+// RegisterRoutes registers trader API routes
+func (h *TraderHandler) RegisterRoutes(router *gin.RouterGroup) {
+	traders := router.Group("/traders")
+	{
+		traders.GET("/:address", h.GetProfile)
+		traders.GET("/:address/signals", h.GetSignals)
+	}
+	router.GET("/wallets/:address/execution-stats", h.GetExecutionStats)
+}
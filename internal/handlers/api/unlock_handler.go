@@ -0,0 +1,164 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
+	"github.com/emiyaio/solana-wallet-service/internal/services/unlock"
+)
+
+// UnlockHandler handles HTTP requests for token unlock schedules
+type UnlockHandler struct {
+	unlockService unlock.UnlockService
+	adminGuard    *middleware.AdminGuard
+	logger        *logrus.Logger
+}
+
+// NewUnlockHandler creates a new unlock handler
+func NewUnlockHandler(unlockService unlock.UnlockService, adminGuard *middleware.AdminGuard, logger *logrus.Logger) *UnlockHandler {
+	return &UnlockHandler{
+		unlockService: unlockService,
+		adminGuard:    adminGuard,
+		logger:        logger,
+	}
+}
+
+// ImportUnlocksRequest is a batch of unlocks, e.g. a vesting schedule taken from a project's docs
+type ImportUnlocksRequest struct {
+	Unlocks []*unlock.UnlockRequest `json:"unlocks" binding:"required,dive"`
+}
+
+// CreateUnlock adds one unlock to a token's schedule
+func (h *UnlockHandler) CreateUnlock(c *gin.Context) {
+	var req unlock.UnlockRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	created, err := h.unlockService.CreateUnlock(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to create unlock schedule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    created,
+	})
+}
+
+// ImportUnlocks stores a batch of unlocks; nothing is stored if any entry is invalid
+func (h *UnlockHandler) ImportUnlocks(c *gin.Context) {
+	var req ImportUnlocksRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	imported, err := h.unlockService.ImportUnlocks(c.Request.Context(), req.Unlocks)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to import unlock schedules")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    imported,
+		"count":   len(imported),
+	})
+}
+
+// UpdateUnlock replaces an unlock; rescheduling it re-arms the room warning
+func (h *UnlockHandler) UpdateUnlock(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("unlockId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unlock ID"})
+		return
+	}
+
+	var req unlock.UnlockRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	updated, err := h.unlockService.UpdateUnlock(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to update unlock schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    updated,
+	})
+}
+
+// DeleteUnlock removes an unlock
+func (h *UnlockHandler) DeleteUnlock(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("unlockId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unlock ID"})
+		return
+	}
+
+	if err := h.unlockService.DeleteUnlock(c.Request.Context(), id); err != nil {
+		respondError(c, h.logger, err, "Failed to delete unlock schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Unlock schedule deleted",
+	})
+}
+
+// GetTokenUnlocks lists a token's upcoming unlocks
+func (h *UnlockHandler) GetTokenUnlocks(c *gin.Context) {
+	unlocks, err := h.unlockService.GetTokenUnlocks(c.Request.Context(), c.Param("mintAddress"))
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to get token unlocks")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    unlocks,
+		"count":   len(unlocks),
+	})
+}
+
+// GetCalendar lists upcoming unlocks of all tokens by day (query: days, limit)
+func (h *UnlockHandler) GetCalendar(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	calendar, err := h.unlockService.GetCalendar(c.Request.Context(), days, limit)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to get unlock calendar")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    calendar,
+	})
+}
+
+// RegisterRoutes registers unlock schedule API routes
+func (h *UnlockHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin/unlocks", h.adminGuard.Require(models.AdminRoleAdmin))
+	{
+		admin.POST("", h.CreateUnlock)
+		admin.POST("/import", h.ImportUnlocks)
+		admin.PUT("/:unlockId", h.UpdateUnlock)
+		admin.DELETE("/:unlockId", h.DeleteUnlock)
+	}
+
+	router.GET("/unlocks/upcoming", h.GetCalendar)
+	router.GET("/tokens/mint/:mintAddress/unlocks", h.GetTokenUnlocks)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/internal/services/user"
+)
+
+// UserHandler handles HTTP requests for user settings
+type UserHandler struct {
+	settingsService user.SettingsService
+	purgeService    user.PurgeService
+	wsService       room.WebSocketService
+	logger          *logrus.Logger
+}
+
+// NewUserHandler creates a new user handler
+func NewUserHandler(settingsService user.SettingsService, purgeService user.PurgeService, wsService room.WebSocketService, logger *logrus.Logger) *UserHandler {
+	return &UserHandler{
+		settingsService: settingsService,
+		purgeService:    purgeService,
+		wsService:       wsService,
+		logger:          logger,
+	}
+}
+
+// GetSettings returns a wallet's settings
+func (h *UserHandler) GetSettings(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	settings, err := h.settingsService.GetSettings(c.Request.Context(), address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user settings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    settings,
+	})
+}
+
+// UpdateSettings updates a wallet's settings
+func (h *UserHandler) UpdateSettings(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	var req user.UpdateSettingsRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	settings, err := h.settingsService.UpdateSettings(c.Request.Context(), address, &req)
+	if err != nil {
+		if errors.Is(err, user.ErrUnsupportedLanguage) || errors.Is(err, user.ErrInvalidTimezone) || errors.Is(err, user.ErrInvalidSetting) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": address,
+		}).Error("Failed to update user settings")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user settings"})
+		return
+	}
+
+	// Apply new notification preferences to open room connections
+	h.wsService.ApplyUserSettings(settings)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    settings,
+	})
+}
+
+// Purge removes a wallet's personal data, or with dry_run reports what would be removed
+func (h *UserHandler) Purge(c *gin.Context) {
+	address := c.Param("address")
+
+	var req user.PurgeRequest
+	if !validation.BindJSON(c, &req) {
+		return
+	}
+
+	purge, err := h.purgeService.Purge(c.Request.Context(), address, &req)
+	if err != nil {
+		respondError(c, h.logger.WithField("wallet", address), err, "Failed to purge wallet data")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"dry_run": req.DryRun,
+		"data":    purge,
+	})
+}
+
+// RegisterRoutes registers user settings API routes
+func (h *UserHandler) RegisterRoutes(router *gin.RouterGroup) {
+	users := router.Group("/users")
+	{
+		users.GET("/:address/settings", h.GetSettings)
+		users.PUT("/:address/settings", h.UpdateSettings)
+		users.POST("/:address/purge", h.Purge)
+	}
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// WalletWatchHandler handles HTTP requests for personal wallet watches; the watching wallet is given in
+// X-Wallet-Address and trades are delivered to its notification channels
+type WalletWatchHandler struct {
+	subscriptionManager room.SubscriptionManager
+	logger              *logrus.Logger
+}
+
+// NewWalletWatchHandler creates a new wallet watch handler
+func NewWalletWatchHandler(subscriptionManager room.SubscriptionManager, logger *logrus.Logger) *WalletWatchHandler {
+	return &WalletWatchHandler{
+		subscriptionManager: subscriptionManager,
+		logger:              logger,
+	}
+}
+
+// WatchWallet subscribes the caller to the trades of the wallet in the path
+func (h *WalletWatchHandler) WatchWallet(c *gin.Context) {
+	address := c.Param("address")
+	watcherAddress := c.GetHeader("X-Wallet-Address")
+	if address == "" || watcherAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address and X-Wallet-Address are required"})
+		return
+	}
+
+	watch, err := h.subscriptionManager.WatchWallet(c.Request.Context(), watcherAddress, address)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to watch wallet")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    watch,
+	})
+}
+
+// UnwatchWallet removes the caller's watch of the wallet in the path
+func (h *WalletWatchHandler) UnwatchWallet(c *gin.Context) {
+	address := c.Param("address")
+	watcherAddress := c.GetHeader("X-Wallet-Address")
+	if address == "" || watcherAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address and X-Wallet-Address are required"})
+		return
+	}
+
+	if err := h.subscriptionManager.UnwatchWallet(c.Request.Context(), watcherAddress, address); err != nil {
+		respondError(c, h.logger, err, "Failed to unwatch wallet")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Wallet unwatched",
+	})
+}
+
+// ListWatches lists the wallets the caller watches, newest first
+func (h *WalletWatchHandler) ListWatches(c *gin.Context) {
+	watcherAddress := c.GetHeader("X-Wallet-Address")
+	if watcherAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "X-Wallet-Address is required"})
+		return
+	}
+
+	watches, err := h.subscriptionManager.ListWatches(c.Request.Context(), watcherAddress)
+	if err != nil {
+		respondError(c, h.logger, err, "Failed to list wallet watches")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    watches,
+	})
+}
+
+// RegisterRoutes registers wallet watch API routes
+func (h *WalletWatchHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/watch/wallets", h.ListWatches)
+	router.POST("/watch/wallets/:address", h.WatchWallet)
+	router.DELETE("/watch/wallets/:address", h.UnwatchWallet)
+}
@@ -3,48 +3,154 @@ package handlers
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
 	"github.com/emiyaio/solana-wallet-service/internal/handlers/api"
 	"github.com/emiyaio/solana-wallet-service/internal/handlers/websocket"
 	"github.com/emiyaio/solana-wallet-service/internal/middleware"
 	"github.com/emiyaio/solana-wallet-service/internal/services"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
 )
 
 // Router holds all route handlers
 type Router struct {
-	engine          *gin.Engine
-	services        *services.Services
-	logger          *logrus.Logger
-	roomHandler     *api.RoomHandler
-	tokenHandler    *api.TokenHandler
-	aiHandler       *api.AIHandler
-	wsRoomHandler   *websocket.RoomWebSocketHandler
+	engine              *gin.Engine
+	services            *services.Services
+	logger              *logrus.Logger
+	roomHandler         *api.RoomHandler
+	tokenHandler        *api.TokenHandler
+	aiHandler           *api.AIHandler
+	labelHandler        *api.LabelHandler
+	portfolioHandler    *api.PortfolioHandler
+	userHandler         *api.UserHandler
+	reportHandler       *api.ReportHandler
+	exportHandler       *api.ExportHandler
+	traderHandler       *api.TraderHandler
+	analyticsHandler    *api.AnalyticsHandler
+	marketHandler       *api.MarketHandler
+	liquidityHandler    *api.LiquidityHandler
+	clusterHandler      *api.ClusterHandler
+	assistantHandler    *api.AssistantHandler
+	socialHandler       *api.SocialHandler
+	backtestHandler     *api.BacktestHandler
+	limitWatchHandler   *api.LimitWatchHandler
+	walletWatchHandler  *api.WalletWatchHandler
+	screenerHandler     *api.ScreenerHandler
+	discoveryHandler    *api.DiscoveryHandler
+	momentumHandler     *api.MomentumHandler
+	unlockHandler       *api.UnlockHandler
+	notificationHandler *api.NotificationHandler
+	emailHandler        *api.EmailHandler
+	adminHandler        *api.AdminHandler
+	promptHandler       *api.PromptHandler
+	featureFlagHandler  *api.FeatureFlagHandler
+	wsRoomHandler       *websocket.RoomWebSocketHandler
+	wsMarketHandler     *websocket.MarketWebSocketHandler
+	walletLimiter       *middleware.WalletRateLimiter
 }
 
-// NewRouter creates a new router instance
-func NewRouter(services *services.Services, logger *logrus.Logger) *Router {
+// NewRouter creates a new router instance; redisClient may be nil, which disables idempotency keys and
+// per-wallet rate limits
+func NewRouter(services *services.Services, redisClient *redis.Client, server *config.ServerConfig, wsConfig *config.WebSocketConfig, rateLimit *config.RateLimitConfig, logger *logrus.Logger) *Router {
 	// Create Gin engine
 	gin.SetMode(gin.ReleaseMode) // Set to release mode
 	engine := gin.New()
 	
+	// Client IPs key rate limits and password lockouts, so forwarded addresses are only believed from
+	// the configured proxies
+	if err := engine.SetTrustedProxies(server.TrustedProxies); err != nil {
+		logger.WithError(err).Error("Invalid trusted proxies, trusting none")
+		engine.SetTrustedProxies(nil)
+	}
+	
 	// Add global middleware
 	engine.Use(gin.Recovery())
 	engine.Use(middleware.Logger(logger))
 	engine.Use(middleware.CORS())
+	engine.Use(middleware.Compression(&server.Compression))
+	engine.Use(middleware.SolanaAddressParams("address", "mintAddress"))
+	
+	// Request bodies are capped per route; batch endpoints and broadcasts have their own limits
+	engine.Use(middleware.BodyLimit(&server.BodyLimits, map[string]middleware.BodyLimitClass{
+		"/api/v1/rooms/:roomId/members/batch": middleware.BodyLimitBatch,
+		"/api/v1/tokens/batch/analyze":        middleware.BodyLimitBatch,
+		"/api/v1/admin/unlocks/import":        middleware.BodyLimitBatch,
+		"/api/v1/ws/rooms/:roomId/broadcast":  middleware.BodyLimitBroadcast,
+	}))
+	
+	// Rate limits per client IP and, layered on top, per wallet
+	if rateLimit.RequestsPerSecond > 0 {
+		engine.Use(middleware.NewRateLimiter(int(rateLimit.RequestsPerSecond * 60)).Middleware())
+	}
+	walletLimiter := middleware.NewWalletRateLimiter(redisClient, &rateLimit.Wallet, logger)
+	engine.Use(walletLimiter.Middleware())
 	
 	// Create handlers
-	roomHandler := api.NewRoomHandler(services.Room, services.WebSocket, logger)
-	tokenHandler := api.NewTokenHandler(services.TokenMarket, services.TokenAnalysis, logger)
-	aiHandler := api.NewAIHandler(services.LangChain, logger)
-	wsRoomHandler := websocket.NewRoomWebSocketHandler(services.WebSocket, logger)
+	idempotency := middleware.NewIdempotency(redisClient, logger)
+	adminGuard := middleware.NewAdminGuard(services.AdminAccess, logger)
+	roomHandler := api.NewRoomHandler(services.Room, services.WebSocket, services.SubscriptionManager, idempotency, logger)
+	tokenHandler := api.NewTokenHandler(services.TokenMarket, services.TokenAnalysis, services.TokenProvenance, services.TokenChart, services.TokenFlag, services.Sellability, adminGuard, logger)
+	aiHandler := api.NewAIHandler(services.LangChain, services.AIUsage, logger)
+	labelHandler := api.NewLabelHandler(services.Label, adminGuard, logger)
+	portfolioHandler := api.NewPortfolioHandler(services.Portfolio, logger)
+	userHandler := api.NewUserHandler(services.UserSettings, services.UserPurge, services.WebSocket, logger)
+	reportHandler := api.NewReportHandler(services.Report, adminGuard, logger)
+	exportHandler := api.NewExportHandler(services.Export, logger)
+	traderHandler := api.NewTraderHandler(services.Trader, services.SignalTracker, logger)
+	analyticsHandler := api.NewAnalyticsHandler(services.Analytics, logger)
+	marketHandler := api.NewMarketHandler(services.Analytics, services.PriceFeed, logger)
+	liquidityHandler := api.NewLiquidityHandler(services.Liquidity, logger)
+	clusterHandler := api.NewClusterHandler(services.Cluster, logger)
+	assistantHandler := api.NewAssistantHandler(services.RoomAssistant, services.AIUsage, walletLimiter, logger)
+	socialHandler := api.NewSocialHandler(services.Social, logger)
+	backtestHandler := api.NewBacktestHandler(services.TokenBacktest, logger)
+	limitWatchHandler := api.NewLimitWatchHandler(services.LimitWatch, logger)
+	walletWatchHandler := api.NewWalletWatchHandler(services.SubscriptionManager, logger)
+	screenerHandler := api.NewScreenerHandler(services.TokenScreener, logger)
+	discoveryHandler := api.NewDiscoveryHandler(services.TokenDiscovery, logger)
+	momentumHandler := api.NewMomentumHandler(services.Momentum, logger)
+	unlockHandler := api.NewUnlockHandler(services.Unlock, adminGuard, logger)
+	notificationHandler := api.NewNotificationHandler(services.Notification, logger)
+	emailHandler := api.NewEmailHandler(services.Email, logger)
+	adminHandler := api.NewAdminHandler(services.AdminStats, services.AdminAccess, services.QuickNode, services.WebSocket, adminGuard, logger)
+	promptHandler := api.NewPromptHandler(services.Prompt, services.LangChain, adminGuard, logger)
+	featureFlagHandler := api.NewFeatureFlagHandler(services.FeatureFlags, adminGuard, logger)
+	wsRoomHandler := websocket.NewRoomWebSocketHandler(services.WebSocket, adminGuard, wsConfig, logger)
+	wsMarketHandler := websocket.NewMarketWebSocketHandler(services.WebSocket, wsConfig, logger)
 	
 	return &Router{
-		engine:        engine,
-		services:      services,
-		logger:        logger,
-		roomHandler:   roomHandler,
-		tokenHandler:  tokenHandler,
-		aiHandler:     aiHandler,
-		wsRoomHandler: wsRoomHandler,
+		engine:              engine,
+		services:            services,
+		logger:              logger,
+		roomHandler:         roomHandler,
+		tokenHandler:        tokenHandler,
+		aiHandler:           aiHandler,
+		labelHandler:        labelHandler,
+		portfolioHandler:    portfolioHandler,
+		userHandler:         userHandler,
+		reportHandler:       reportHandler,
+		exportHandler:       exportHandler,
+		traderHandler:       traderHandler,
+		analyticsHandler:    analyticsHandler,
+		marketHandler:       marketHandler,
+		liquidityHandler:    liquidityHandler,
+		clusterHandler:      clusterHandler,
+		assistantHandler:    assistantHandler,
+		socialHandler:       socialHandler,
+		backtestHandler:     backtestHandler,
+		limitWatchHandler:   limitWatchHandler,
+		walletWatchHandler:  walletWatchHandler,
+		screenerHandler:     screenerHandler,
+		discoveryHandler:    discoveryHandler,
+		momentumHandler:     momentumHandler,
+		unlockHandler:       unlockHandler,
+		notificationHandler: notificationHandler,
+		emailHandler:        emailHandler,
+		adminHandler:        adminHandler,
+		promptHandler:       promptHandler,
+		featureFlagHandler:  featureFlagHandler,
+		wsRoomHandler:       wsRoomHandler,
+		wsMarketHandler:     wsMarketHandler,
+		walletLimiter:       walletLimiter,
 	}
 }
 
@@ -64,14 +170,85 @@ func (r *Router) SetupRoutes() {
 		r.tokenHandler.RegisterRoutes(v1)
 		
 		// AI API routes
-		aiGroup := v1.Group("/ai")
+		aiGroup := v1.Group("/ai", r.walletLimiter.AI())
 		{
 			aiGroup.GET("/analyze/:token_identifier", r.aiHandler.AnalyzeToken)
 			aiGroup.POST("/chat", r.aiHandler.ChatCompletion)
 		}
+		v1.GET("/ai/models", r.aiHandler.ListModels)
+		v1.GET("/users/:address/ai-usage", r.aiHandler.GetUsage)
+		
+		// Wallet label routes
+		r.labelHandler.RegisterRoutes(v1)
+		
+		// Portfolio API routes
+		r.portfolioHandler.RegisterRoutes(v1)
+		
+		// User settings routes
+		r.userHandler.RegisterRoutes(v1)
+		
+		// Room report routes
+		r.reportHandler.RegisterRoutes(v1)
+		
+		// Data export routes
+		r.exportHandler.RegisterRoutes(v1)
+		
+		// Trader profile routes
+		r.traderHandler.RegisterRoutes(v1)
+		
+		// Curated analytics routes
+		r.analyticsHandler.RegisterRoutes(v1)
+		
+		// Market summary routes
+		r.marketHandler.RegisterRoutes(v1)
+		
+		// Liquidity monitoring routes
+		r.liquidityHandler.RegisterRoutes(v1)
+		
+		// Related wallet routes
+		r.clusterHandler.RegisterRoutes(v1)
+		
+		// Room AI assistant routes
+		r.assistantHandler.RegisterRoutes(v1)
+		
+		// Token social metrics routes
+		r.socialHandler.RegisterRoutes(v1)
+		
+		// Recommendation backtest routes
+		r.backtestHandler.RegisterRoutes(v1)
+		
+		// Price limit watch routes
+		r.limitWatchHandler.RegisterRoutes(v1)
+		
+		// Personal wallet watch routes
+		r.walletWatchHandler.RegisterRoutes(v1)
+		
+		// Token screener routes
+		r.screenerHandler.RegisterRoutes(v1)
+		
+		// New token discovery routes
+		r.discoveryHandler.RegisterRoutes(v1)
+		
+		// Trending momentum alert routes
+		r.momentumHandler.RegisterRoutes(v1)
+		
+		// Token unlock schedule routes
+		r.unlockHandler.RegisterRoutes(v1)
+		
+		// Notification center routes
+		r.notificationHandler.RegisterRoutes(v1)
+		
+		// Email binding routes
+		r.emailHandler.RegisterRoutes(v1)
+		
+		// Admin dashboard routes
+		r.adminHandler.RegisterRoutes(v1)
+		r.promptHandler.RegisterRoutes(v1)
+		r.featureFlagHandler.RegisterRoutes(v1)
 		
 		// WebSocket routes
 		r.wsRoomHandler.RegisterRoutes(v1)
+		r.wsMarketHandler.RegisterRoutes(v1)
 	}
 	
 	// API documentation endpoint
@@ -83,8 +260,15 @@ func (r *Router) GetEngine() *gin.Engine {
 	return r.engine
 }
 
-// healthCheck endpoint
+// healthCheck endpoint; a draining instance reports 503 so load balancers stop routing to it
 func (r *Router) healthCheck(c *gin.Context) {
+	if r.services.WebSocket.DrainStatus().Draining {
+		c.JSON(503, gin.H{
+			"status":  "draining",
+			"service": "solana-wallet-service",
+		})
+		return
+	}
 	c.JSON(200, gin.H{
 		"status":    "healthy",
 		"service":   "solana-wallet-service",
@@ -100,54 +284,201 @@ func (r *Router) apiDocs(c *gin.Context) {
 		"version": "1.0.0",
 		"endpoints": map[string]interface{}{
 			"rooms": map[string]interface{}{
-				"POST /api/v1/rooms":                    "Create a new trading room",
+				"POST /api/v1/rooms":                    "Create a new trading room (header: Idempotency-Key, optional)",
 				"GET /api/v1/rooms":                     "List all rooms",
 				"GET /api/v1/rooms/{roomId}":            "Get room details",
-				"PUT /api/v1/rooms/{roomId}":            "Update room settings",
+				"PUT /api/v1/rooms/{roomId}":            "Update room settings (password, recycle_hours, max_members, ai_rationale, prune_inactive_days)",
+				"PUT /api/v1/rooms/{roomId}/token":      "Change the room's token, or clear it with an empty token_address, creator only (header: X-Creator-Address); members get a room_update",
+				"POST /api/v1/rooms/{roomId}/tokens":    "Add up to 20 tokens to the room's watch basket; trade filtering, digests and alerts cover the room's token and its basket (body: token_addresses, header: X-Creator-Address)",
+				"DELETE /api/v1/rooms/{roomId}/tokens/{mintAddress}": "Remove a token from the room's watch basket (header: X-Creator-Address)",
 				"DELETE /api/v1/rooms/{roomId}":         "Delete room",
 				"POST /api/v1/rooms/{roomId}/join":      "Join a room",
 				"POST /api/v1/rooms/{roomId}/leave":     "Leave a room",
-				"GET /api/v1/rooms/{roomId}/members":    "Get room members",
-				"POST /api/v1/rooms/{roomId}/share":     "Share information in room",
-				"GET /api/v1/rooms/{roomId}/shares":     "Get shared information",
-				"POST /api/v1/rooms/{roomId}/events":    "Record trade event",
+				"GET /api/v1/rooms/{roomId}/members":    "Get room members with their signal accuracy and activity score; aliased members are listed by alias except to the creator (header: X-Wallet-Address, optional)",
+				"PUT /api/v1/rooms/{roomId}/members/{address}/role": "Set a member's role to moderator or member, creator only (header: X-Creator-Address)",
+				"PUT /api/v1/rooms/{roomId}/members/{address}/alias": "Show an alias instead of your address in the room's broadcasts and member list; the creator still sees the address (body: alias, 3 to 24 characters, empty to clear; header: X-Wallet-Address, must match address)",
+				"PUT /api/v1/rooms/{roomId}/members/{address}/privacy": "Broadcast your detected trades with a size bucket (small, medium, large) instead of amounts (body: hide_trade_sizes; header: X-Wallet-Address, must match address)",
+				"POST /api/v1/rooms/{roomId}/members/batch": "Add or remove up to 100 members in one transaction, creator only (header: X-Creator-Address; body: action=add|remove, wallet_addresses)",
+				"POST /api/v1/rooms/{roomId}/share":     "Share information in room (header: Idempotency-Key, optional)",
+				"GET /api/v1/rooms/{roomId}/shares":     "Get shared information (query: type, token)",
+				"GET /api/v1/rooms/shares/{infoId}/reactions":        "Get reaction counts, and the caller's reactions with X-Wallet-Address",
+				"GET /api/v1/rooms/shares/{infoId}/reactions/users":  "List who reacted (query: type)",
+				"POST /api/v1/rooms/shares/{infoId}/reactions":       "React to shared information (body: type=fire|bull|bear|warning)",
+				"DELETE /api/v1/rooms/shares/{infoId}/reactions/{type}": "Remove a reaction",
+				"GET /api/v1/signals":                   "Get signals for a token from public rooms (query: token, side)",
+				"POST /api/v1/rooms/{roomId}/events":    "Record trade event; sol_amount, if given, values it server-side at the SOL price (header: Idempotency-Key, optional)",
 				"GET /api/v1/rooms/{roomId}/events":     "Get trade events",
+				"GET /api/v1/rooms/{roomId}/events/replay": "Replay room lifecycle events (created, joined, left, share, trade, closed, broadcast, token, basket) after a sequence (query: since, limit)",
+				"GET /api/v1/rooms/{roomId}/feed":          "Get the room's WebSocket broadcasts as seen live, oldest first (query: before, limit)",
+				"GET /api/v1/rooms/{roomId}/digests":    "Get past daily digests",
+				"GET /api/v1/rooms/{roomId}/analytics":  "Get member growth, daily messages, shares and trade volume, most active members and retention, creator only (query: days, default 30, max 90; header: X-Creator-Address)",
+				"POST /api/v1/admin/rooms/{roomId}/digests": "Generate a room digest (query: date) (operator)",
+				"GET /api/v1/rooms/{roomId}/liquidity": "Get the liquidity history and recent pull alerts of the room's token (query: hours)",
+				"GET /api/v1/rooms/{roomId}/liquidity/alerts": "Get liquidity pull alerts of the room's token",
+				"POST /api/v1/rooms/{roomId}/ai/ask":    "Ask the room AI assistant about the room's token and activity, members only (body: wallet_address, question, language, post_to_room)",
+				"GET /api/v1/rooms/{roomId}/export":     "Export trade events and shared info, creator only (query: format=csv|json)",
 				"GET /api/v1/users/{address}/rooms":     "Get user's rooms",
+				"GET /api/v1/users/{address}/settings":  "Get user settings",
+				"PUT /api/v1/users/{address}/settings":  "Update user settings (language, timezone, notifications, momentum alert opt-in, hidden tokens, alert defaults, notification routes per type and telegram_chat_id, webhook_url, email_opt_outs)",
+				"POST /api/v1/users/{address}/purge":    "Remove the wallet's memberships, reactions, follows, settings, watches and notifications and anonymize its room content; owned active rooms must be transferred or closed first (body: signature, the base58 ed25519 signature of \"solana-wallet-service purge\\n{address}\\ndry_run: {dry_run}\\n{timestamp}\", single-use; timestamp in unix seconds, dry_run to only count)",
+				"GET /api/v1/users/{address}/watches":   "List price limit watches (query: status, limit, offset)",
+				"POST /api/v1/users/{address}/watches":  "Notify when a token crosses a price (body: mint_address, target_price_usd, direction, swap_side, note, expires_in_hours)",
+				"DELETE /api/v1/users/{address}/watches/{watchId}": "Cancel a price limit watch",
+				"GET /api/v1/watch/wallets":             "List the wallets the caller watches (header: X-Wallet-Address)",
+				"POST /api/v1/watch/wallets/{address}":  "Watch a wallet's trades without joining a room; each trade is sent as a wallet_watch notification, up to 20 wallets per watcher by default (header: X-Wallet-Address)",
+				"DELETE /api/v1/watch/wallets/{address}": "Stop watching a wallet (header: X-Wallet-Address)",
+				"GET /api/v1/users/{address}/notifications": "List notifications with the unread count (query: unread, limit, offset)",
+				"POST /api/v1/users/{address}/notifications/read": "Mark all notifications read",
+				"POST /api/v1/users/{address}/notifications/{notificationId}/read": "Mark a notification read",
+				"POST /api/v1/users/{address}/email":        "Mail a verification code to bind an email address (body: email)",
+				"POST /api/v1/users/{address}/email/verify": "Bind the email address with the mailed code (body: code)",
+				"DELETE /api/v1/users/{address}/email":      "Unbind the wallet's email address",
+				"GET /api/v1/users/{address}/screeners":  "List saved token screeners",
+				"POST /api/v1/users/{address}/screeners": "Save a token screener by name, overwriting one with the same name (body: name, filters, sort, order)",
+				"GET /api/v1/users/{address}/screeners/{presetId}/tokens": "Run a saved token screener (query: limit, offset)",
+				"DELETE /api/v1/users/{address}/screeners/{presetId}": "Delete a saved token screener",
 			},
 			"tokens": map[string]interface{}{
 				"POST /api/v1/tokens":                        "Create a new token",
-				"GET /api/v1/tokens":                         "List all tokens",
+				"GET /api/v1/tokens":                         "List tokens (query: q, category, min_liquidity, sort, order)",
+				"GET /api/v1/tokens/narratives":              "List the narrative taxonomy (meme, defi, ai, infra, gaming, other)",
+				"GET /api/v1/tokens/new":                     "List newly listed tokens with their risk pre-screen (query: risk=low|medium|high, limit, offset)",
+				"GET /api/v1/tokens/new/stream":              "Stream newly listed tokens as server-sent new_token events (query: risk)",
+				"GET /api/v1/tokens/momentum":                "List early momentum alerts: tokens climbing the trending ranking while their price is flat",
+				"GET /api/v1/tokens/screener":                "Screen tokens (query: filters, e.g. marketCap>1M,volume24h>100k,priceChange24h>10,holderCount>500,liquidity>50k,age<7d; sort; order)",
 				"GET /api/v1/tokens/mint/{mintAddress}":      "Get token by mint address",
-				"GET /api/v1/tokens/{tokenId}/market":        "Get market data",
+				"GET /api/v1/tokens/mint/{mintAddress}/provenance": "Get token deployer and creation history",
+				"GET /api/v1/tokens/mint/{mintAddress}/flag":  "Get token scam/honeypot flag and reason history",
+				"GET /api/v1/tokens/mint/{mintAddress}/pools": "Get the pools a token trades in with price, liquidity and 24h volume, most liquid first",
+				"GET /api/v1/tokens/mint/{mintAddress}/social": "Get hourly social mentions and sentiment (query: hours)",
+				"GET /api/v1/tokens/mint/{mintAddress}/unlocks": "Get upcoming vesting unlocks of a token",
+				"GET /api/v1/tokens/{tokenId}/market":        "Get market data; tokens with a Pyth feed include the reference price, its confidence and stale or divergent DEX price flags",
+				"GET /api/v1/tokens/{tokenId}/chart":         "Get downsampled price/volume chart (query: interval=1h|24h|7d|30d|1y, points)",
 				"POST /api/v1/tokens/mint/{mintAddress}/sync": "Sync market data",
-				"POST /api/v1/tokens/sync-all":               "Sync all tokens market data",
-				"GET /api/v1/tokens/trending":                "Get trending tokens",
-				"GET /api/v1/tokens/{tokenId}/holders":       "Get top holders",
+				"POST /api/v1/tokens/mint/{mintAddress}/refresh-metadata": "Re-fetch token name, logo and socials now instead of waiting for them to go stale",
+				"POST /api/v1/tokens/sync-all":               "Sync all tokens market data (operator)",
+				"GET /api/v1/tokens/trending":                "Get trending tokens (query: category, timeframe, narrative)",
+				"GET /api/v1/tokens/{tokenId}/holders":       "Get top holders, with exchange and program accounts marked excluded",
+				"GET /api/v1/tokens/{tokenId}/holder-changes": "Get top holder changes between snapshots (query: hours, include_excluded to count exchange and program accounts)",
 				"GET /api/v1/tokens/{tokenId}/stats":         "Get transaction stats",
 				"GET /api/v1/tokens/{tokenId}/analyze":       "Analyze token",
 				"GET /api/v1/tokens/{tokenId}/trends":        "Analyze trends",
-				"GET /api/v1/tokens/{tokenId}/sentiment":     "Analyze sentiment",
+				"GET /api/v1/tokens/{tokenId}/sentiment":     "Analyze sentiment, blending in social mentions of the last 24h",
 				"GET /api/v1/tokens/{tokenId}/risk":          "Assess risk",
+				"GET /api/v1/tokens/{mint}/sellability":      "Simulate a buy and sell through Jupiter to detect honeypots (mint address or token ID)",
 				"GET /api/v1/tokens/{tokenId}/volatility":    "Get volatility metrics",
 				"GET /api/v1/tokens/{tokenId}/recommendation": "Get AI recommendation",
 				"POST /api/v1/tokens/batch/analyze":          "Batch analyze tokens",
 			},
+			"analysis": map[string]interface{}{
+				"POST /api/v1/analysis/backtest": "Backtest the recommendation engine on a token: replay calls from stored candles or use recorded calls, and compare the returns of following them with buying and holding",
+			},
+			"token_flags": map[string]interface{}{
+				"GET /api/v1/admin/tokens/flags":                 "List flagged tokens (query: active) (viewer)",
+				"PUT /api/v1/admin/tokens/{mintAddress}/flag":    "Flag a token as scam, honeypot or rug",
+				"DELETE /api/v1/admin/tokens/{mintAddress}/flag": "Clear a token flag (query: reason, cleared_by)",
+			},
+			"admin": map[string]interface{}{
+				"auth":                    "Admin routes need an X-Admin-Key header (or bearer token), or a wallet signature: X-Admin-Wallet, X-Admin-Timestamp (unix seconds) and X-Admin-Signature, the base58 ed25519 signature of \"solana-wallet-service admin\\n{METHOD} {path}\\n{timestamp}\". Roles: viewer reads, operator also syncs, broadcasts and runs digests, admin also manages flags, labels and unlocks. Every non-GET admin request is audited.",
+				"GET /api/v1/admin/stats":               "Get live counts: active rooms, WebSocket clients, wallet subscriptions, QuickNode connection state, tracked tokens, background job lag, external API error rates and provider quota usage (viewer)",
+				"GET /api/v1/admin/audit":               "List audited admin actions (query: actor, limit, offset) (admin)",
+				"POST /api/v1/admin/quicknode/reconnect": "Force this instance to reconnect to QuickNode, also after it gave up; returns the connection state before the reconnect (operator)",
+				"POST /api/v1/admin/drain":               "Drain this instance before a deploy: refuse new WebSocket connections, send open ones a reconnect message and close those left after the grace period (query: grace_seconds, default 30) (operator)",
+				"GET /api/v1/admin/drain":                "Get this instance's drain status: draining, deadline, open connections and drained once none are left (viewer)",
+				"DELETE /api/v1/admin/drain":             "Cancel the drain and accept WebSocket connections again (operator)",
+			},
+			"ai_prompts": map[string]interface{}{
+				"GET /api/v1/admin/prompts":                                    "List AI prompt use cases with their built-in prompt, active version and room overrides (viewer)",
+				"GET /api/v1/admin/prompts/{key}/versions":                     "List a prompt's versions, newest first (query: room_id) (viewer)",
+				"PUT /api/v1/admin/prompts/{key}":                              "Save and activate a new prompt version (body: content, room_id, note); room_id overrides room_question and trade_rationale for one room (admin)",
+				"POST /api/v1/admin/prompts/{key}/versions/{version}/activate": "Activate a saved version, e.g. to roll back (query: room_id) (admin)",
+				"DELETE /api/v1/admin/prompts/{key}":                           "Deactivate the prompt, falling back to the global or built-in prompt (query: room_id) (admin)",
+				"POST /api/v1/admin/prompts/{key}/test":                        "Run a draft (body: content) or the prompt in use against a sample input without saving (body: input, room_id, model, temperature, max_tokens) (operator)",
+			},
+			"feature_flags": map[string]interface{}{
+				"GET /api/v1/admin/feature-flags":          "List feature flags (ai_rationale, copy_trade_signals, birdeye_market_data, geckoterminal_pools) with this environment's default, the global value and room overrides (viewer)",
+				"PUT /api/v1/admin/feature-flags/{key}":    "Turn a feature flag on or off at runtime (body: enabled, room_id); room_id sets ai_rationale and copy_trade_signals for one room (admin)",
+				"DELETE /api/v1/admin/feature-flags/{key}": "Clear a feature flag, falling back to the global value or the default (query: room_id) (admin)",
+			},
+			"token_unlocks": map[string]interface{}{
+				"POST /api/v1/admin/unlocks":              "Add a token unlock (body: mint_address, unlock_at, amount or percent_of_supply, category, description, source)",
+				"POST /api/v1/admin/unlocks/import":       "Import a batch of token unlocks; nothing is stored if any is invalid (body: unlocks)",
+				"PUT /api/v1/admin/unlocks/{unlockId}":    "Update a token unlock",
+				"DELETE /api/v1/admin/unlocks/{unlockId}": "Delete a token unlock",
+				"GET /api/v1/unlocks/upcoming":            "Get the upcoming unlocks calendar grouped by day, valued at the latest price (query: days, limit)",
+			},
+			"labels": map[string]interface{}{
+				"POST /api/v1/admin/labels":             "Create a wallet label",
+				"GET /api/v1/admin/labels":              "List wallet labels (query: label) (viewer)",
+				"PUT /api/v1/admin/labels/{labelId}":    "Update a wallet label",
+				"DELETE /api/v1/admin/labels/{labelId}": "Delete a wallet label",
+				"GET /api/v1/wallets/{address}/labels":  "Get labels of a wallet",
+			},
+			"wallets": map[string]interface{}{
+				"GET /api/v1/wallets/{address}/performance": "Get portfolio value series and drawdown (query: days)",
+				"GET /api/v1/wallets/{address}/execution-stats": "Get average fees, priority fees and slippage per DEX platform (query: days)",
+				"GET /api/v1/wallets/{address}/related":     "Get wallets likely controlled by the same entity (query: refresh)",
+				"GET /api/v1/wallets/{address}/transactions/export": "Export transaction history as CSV for tax reporting (query: from, to)",
+			},
+			"traders": map[string]interface{}{
+				"GET /api/v1/traders/{address}":         "Get trader profile with signal accuracy",
+				"GET /api/v1/traders/{address}/signals": "Get tracked signal outcomes of a sharer",
+			},
+			"analytics": map[string]interface{}{
+				"GET /api/v1/analytics/queries":        "List curated analytics queries and their params",
+				"GET /api/v1/analytics/queries/{name}": "Run a curated analytics query (top_gainers, most_copied_traders, busiest_rooms)",
+				"GET /api/v1/analytics/dex-share":      "Get DEX platform share of detected trades (query: timeframe 1d, 7d, 30d, 90d)",
+			},
+			"market": map[string]interface{}{
+				"GET /api/v1/market/overview": "Get total 24h volume, gainer and loser counts and average 24h change of tokens updated in the last day, the most active rooms and the SOL price (cached 60s)",
+				"GET /api/v1/market/sol":      "Get the latest SOL price and the USDC and USDT prices from the price feed (Pyth, Jupiter fallback); stale when older than 5 minutes",
+			},
+			"exports": map[string]interface{}{
+				"GET /api/v1/exports/{exportId}":          "Get background export status",
+				"GET /api/v1/exports/{exportId}/download": "Download a completed export",
+			},
 			"ai": map[string]interface{}{
-				"GET /api/v1/ai/analyze/{token_identifier}": "Get AI-powered token analysis",
-				"POST /api/v1/ai/chat":                      "Get AI chat completion for crypto questions",
+				"GET /api/v1/ai/analyze/{token_identifier}": "Get AI-powered token analysis (query: lang, wallet, model, temperature, max_tokens)",
+				"POST /api/v1/ai/chat":                      "Get AI chat completion for crypto questions (body: language, wallet_address, model, temperature, max_tokens)",
+				"GET /api/v1/ai/models":                     "List the models AI requests may choose and the bounds of their temperature and max_tokens overrides",
+				"GET /api/v1/users/{address}/ai-usage":      "Get the wallet's AI token quota, this month's usage per feature and latest AI requests (query: limit, offset)",
+				"quota":                                     "Requests naming a wallet (wallet, wallet_address) count its OpenAI tokens against daily and monthly quotas, reported in X-AI-Quota-Daily-Limit, X-AI-Quota-Daily-Remaining, X-AI-Quota-Monthly-Limit and X-AI-Quota-Monthly-Remaining; a spent quota answers 429 ai_quota_exceeded with Retry-After. An optional X-Session-ID header groups usage by client session.",
 			},
 			"websockets": map[string]interface{}{
 				"GET /api/v1/ws/rooms/{roomId}":              "WebSocket connection for room (query: wallet=address)",
 				"GET /api/v1/ws/rooms/{roomId}/connections":  "Get active connections",
-				"POST /api/v1/ws/rooms/{roomId}/broadcast":   "Broadcast message to room",
+				"POST /api/v1/ws/rooms/{roomId}/broadcast":   "Broadcast an announcement ({type, data: {title, text}}) to a room as its creator or a moderator (X-Wallet-Address) or as an operator admin; recorded in the room's event log",
+				"GET /api/v1/ws/market":                      "Market stream of trending rank changes, newly listed tokens and large price moves (query: topics=trending,new_tokens,price_moves, default all); change topics with subscribe/unsubscribe messages ({type, data: {topics}})",
+				"compression":                                "Client messages over websocket.max_message_size close the connection; with websocket.enable_compression, permessage-deflate is used with clients that offer it. HTTP responses are gzipped for clients sending Accept-Encoding: gzip.",
 			},
 		},
 		"websocket_messages": map[string]interface{}{
 			"client_to_server": []string{
 				"join", "leave", "share_info", "ping",
 			},
+			"market_client_to_server": []string{
+				"subscribe", "unsubscribe", "ping",
+			},
+			"market_server_to_client": []string{
+				"subscribed", "trending_update", "new_token", "price_move", "pong", "error",
+			},
 			"server_to_client": []string{
-				"member_joined", "member_left", "shared_info", "trade_event", "room_update", "pong", "error",
+				"member_joined", "member_left", "shared_info", "trade_event", "trade_pending", "trade_finality", "room_update", "liquidity_alert", "notification", "momentum_alert", "token_graduated", "unlock_warning", "announcement", "inactivity_warning", "member_pruned", "reconnect", "pong", "error",
+			},
+		},
+		"errors": map[string]interface{}{
+			"format": "{\"error\": message, \"code\": machine-readable code}; AI endpoints use {\"error\", \"message\", \"code\"}",
+			"codes": map[string]string{
+				"400": "invalid_request, validation_failed (with field-level details)",
+				"401": "unauthorized (admin routes)",
+				"403": "invalid_password, not_member, insufficient_permission, token_flagged, admin_forbidden",
+				"404": "unknown_feature_flag, unknown_prompt, prompt_version_not_found, room_not_found, shared_info_not_found, token_not_found, token_not_in_basket, flag_not_found, screener_preset_not_found",
+				"409": "room_full, room_closed, room_expired, already_member, basket_full, too_many_screener_presets",
+				"413": "payload_too_large (request body over the route's limit; batch endpoints and broadcasts have smaller limits than other routes)",
+				"422": "invalid_feature_flag_scope, unknown_model, invalid_model_params, invalid_prompt, invalid_info_type, invalid_payload, invalid_reaction, invalid_role, invalid_prune_policy, invalid_batch_action, batch_too_large, invalid_flag_type, invalid_interval, invalid_screener_filter, unsupported_language, invalid_address, broadcast_type_not_allowed, invalid_broadcast, invalid_market_topic",
+				"429": "ai_quota_exceeded, rate_limited (per IP, and per wallet named by X-Wallet-Address, X-Creator-Address or X-Sharer-Address with separate read, write and AI budgets; see X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Class and Retry-After)",
+				"500": "internal_error",
+				"503": "feature_disabled (e.g. sharing signals while copy_trade_signals is off), admin_disabled, email_disabled, price_unavailable, draining (WebSocket connections to an instance being replaced)",
 			},
 		},
 	}
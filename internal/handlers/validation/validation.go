@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+// validate runs the validate tags of request structs; gin's own validator keeps running the binding tags
+var validate = newValidator()
+
+func init() {
+	// Let binding tags use the same field names and custom rules
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		configure(engine)
+	}
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("validate")
+	configure(v)
+	return v
+}
+
+func configure(v *validator.Validate) {
+	v.RegisterTagNameFunc(jsonFieldName)
+	if err := v.RegisterValidation("solana_address", func(fl validator.FieldLevel) bool {
+		return solana.IsValidAddress(fl.Field().String())
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// FieldError describes one field that failed validation
+type FieldError struct {
+	Field   string `json:"field"` // JSON path, e.g. wallet_addresses[2]
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// BindJSON decodes the request body into obj and validates it. On failure it writes a 400 response,
+// with field-level details for validation errors, or a 413 for a body over its limit, and returns false.
+func BindJSON(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		respond(c, err)
+		return false
+	}
+	if err := validate.Struct(obj); err != nil {
+		respond(c, err)
+		return false
+	}
+	return true
+}
+
+func respond(c *gin.Context, err error) {
+	// Bodies read past the route's limit, see middleware.BodyLimit
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body is too large", "code": "payload_too_large", "limit": maxBytesErr.Limit})
+		return
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": "invalid_request"})
+		return
+	}
+
+	details := make([]FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		details = append(details, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: message(fe),
+		})
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   "Validation failed",
+		"code":    "validation_failed",
+		"details": details,
+	})
+}
+
+// fieldPath drops the struct name from the namespace, e.g. CreateRoomRequest.creator_address
+func fieldPath(fe validator.FieldError) string {
+	if i := strings.Index(fe.Namespace(), "."); i >= 0 {
+		return fe.Namespace()[i+1:]
+	}
+	return fe.Field()
+}
+
+func message(fe validator.FieldError) string {
+	field := fe.Field()
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "solana_address":
+		return field + " must be a valid Solana address"
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, fe.Param(), unit(fe.Kind()))
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, fe.Param(), unit(fe.Kind()))
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, fe.Param())
+	default:
+		return fmt.Sprintf("%s failed the %s rule", field, fe.Tag())
+	}
+}
+
+// unit names what min and max count for lengths
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,65 @@
+package websocket
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// MarketWebSocketHandler handles connections to the market stream, which is not bound to a room or wallet
+type MarketWebSocketHandler struct {
+	wsService room.WebSocketService
+	upgrader  *connUpgrader
+	logger    *logrus.Logger
+}
+
+// NewMarketWebSocketHandler creates a new market stream handler
+func NewMarketWebSocketHandler(wsService room.WebSocketService, cfg *config.WebSocketConfig, logger *logrus.Logger) *MarketWebSocketHandler {
+	return &MarketWebSocketHandler{
+		wsService: wsService,
+		upgrader:  newConnUpgrader(cfg),
+		logger:    logger,
+	}
+}
+
+// HandleMarketConnection upgrades to the market stream, subscribed to the topics query (all topics when empty).
+// Clients change their topics with subscribe and unsubscribe messages.
+func (h *MarketWebSocketHandler) HandleMarketConnection(c *gin.Context) {
+	topics, err := room.ParseMarketTopics(c.Query("topics"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "code": "invalid_market_topic"})
+		return
+	}
+
+	// A draining instance is being replaced; the client retries and reaches another one
+	if h.wsService.DrainStatus().Draining {
+		respondDraining(c)
+		return
+	}
+
+	conn, err := h.upgrader.upgrade(c)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to upgrade market stream connection")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upgrade connection"})
+		return
+	}
+
+	if err := h.wsService.HandleMarketConnection(conn, topics); err != nil {
+		h.logger.WithError(err).Error("Failed to handle market stream connection")
+		conn.Close()
+	}
+}
+
+// RegisterRoutes registers the market stream route
+func (h *MarketWebSocketHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/ws/market", h.HandleMarketConnection)
+}
+
+// respondDraining refuses a connection to a draining instance before upgrading it
+func respondDraining(c *gin.Context) {
+	c.Header("Retry-After", "1")
+	c.JSON(http.StatusServiceUnavailable, gin.H{"error": room.ErrDraining.Error(), "code": "draining"})
+}
@@ -1,34 +1,35 @@
 package websocket
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers/validation"
+	"github.com/emiyaio/solana-wallet-service/internal/middleware"
 	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// In production, implement proper origin checking
-		return true
-	},
-}
-
 // RoomWebSocketHandler handles WebSocket connections for trading rooms
 type RoomWebSocketHandler struct {
-	wsService room.WebSocketService
-	logger    *logrus.Logger
+	wsService  room.WebSocketService
+	adminGuard *middleware.AdminGuard
+	upgrader   *connUpgrader
+	logger     *logrus.Logger
 }
 
 // NewRoomWebSocketHandler creates a new WebSocket handler
-func NewRoomWebSocketHandler(wsService room.WebSocketService, logger *logrus.Logger) *RoomWebSocketHandler {
+func NewRoomWebSocketHandler(wsService room.WebSocketService, adminGuard *middleware.AdminGuard, cfg *config.WebSocketConfig, logger *logrus.Logger) *RoomWebSocketHandler {
 	return &RoomWebSocketHandler{
-		wsService: wsService,
-		logger:    logger,
+		wsService:  wsService,
+		adminGuard: adminGuard,
+		upgrader:   newConnUpgrader(cfg),
+		logger:     logger,
 	}
 }
 
@@ -46,9 +47,19 @@ func (h *RoomWebSocketHandler) HandleRoomConnection(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "wallet address is required"})
 		return
 	}
+	if err := solana.ValidateAddress(walletAddress); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	// A draining instance is being replaced; the client retries and reaches another one
+	if h.wsService.DrainStatus().Draining {
+		respondDraining(c)
+		return
+	}
 	
 	// Upgrade HTTP connection to WebSocket
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	conn, err := h.upgrader.upgrade(c)
 	if err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":   err,
@@ -60,7 +71,7 @@ func (h *RoomWebSocketHandler) HandleRoomConnection(c *gin.Context) {
 	}
 	
 	// Handle the WebSocket connection
-	if err := h.wsService.HandleConnection(conn, roomID, walletAddress); err != nil {
+	if err := h.wsService.HandleConnection(c.Request.Context(), conn, roomID, walletAddress); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":   err,
 			"room_id": roomID,
@@ -99,7 +110,8 @@ func (h *RoomWebSocketHandler) GetRoomConnections(c *gin.Context) {
 	})
 }
 
-// BroadcastMessage broadcasts a message to all clients in a room
+// BroadcastMessage broadcasts an allowed message type to all clients in a room. The room's creator and
+// moderators send it with X-Wallet-Address; admins with at least the operator role send it with admin credentials.
 func (h *RoomWebSocketHandler) BroadcastMessage(c *gin.Context) {
 	roomID := c.Param("roomId")
 	
@@ -109,38 +121,78 @@ func (h *RoomWebSocketHandler) BroadcastMessage(c *gin.Context) {
 	}
 	
 	var req struct {
-		Type string      `json:"type" binding:"required"`
-		Data interface{} `json:"data" binding:"required"`
+		Type string          `json:"type" binding:"required"`
+		Data json.RawMessage `json:"data" binding:"required"`
 	}
 	
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !validation.BindJSON(c, &req) {
 		return
 	}
 	
-	message := &room.Message{
+	broadcast := &room.BroadcastRequest{
 		Type: room.MessageType(req.Type),
 		Data: req.Data,
 	}
+	if principal, ok := middleware.AdminPrincipal(c); ok {
+		if !principal.Role.Allows(models.AdminRoleOperator) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "admin role " + string(models.AdminRoleOperator) + " required",
+				"code":  "admin_forbidden",
+			})
+			return
+		}
+		broadcast.AdminName = principal.Name
+	} else {
+		broadcast.SenderAddress = c.GetHeader("X-Wallet-Address")
+		if err := solana.ValidateAddress(broadcast.SenderAddress); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "X-Wallet-Address header must be a valid wallet address", "code": "unauthorized"})
+			return
+		}
+	}
 	
-	if err := h.wsService.BroadcastToRoom(roomID, message); err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":   err,
-			"room_id": roomID,
-		}).Error("Failed to broadcast message")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to broadcast message"})
+	if err := h.wsService.Broadcast(c.Request.Context(), roomID, broadcast); err != nil {
+		status, code := broadcastErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			h.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"room_id": roomID,
+			}).Error("Failed to broadcast message")
+			c.JSON(status, gin.H{"error": "Failed to broadcast message", "code": code})
+			return
+		}
+		c.JSON(status, gin.H{"error": err.Error(), "code": code})
 		return
 	}
 	
 	c.JSON(http.StatusOK, gin.H{"message": "Message broadcasted successfully"})
 }
 
+// broadcastErrorStatus maps a broadcast error to its HTTP status and error code
+func broadcastErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, room.ErrRoomNotFound):
+		return http.StatusNotFound, "room_not_found"
+	case errors.Is(err, room.ErrRoomClosed):
+		return http.StatusConflict, "room_closed"
+	case errors.Is(err, room.ErrNotMember):
+		return http.StatusForbidden, "not_member"
+	case errors.Is(err, room.ErrInsufficientPermission):
+		return http.StatusForbidden, "insufficient_permission"
+	case errors.Is(err, room.ErrBroadcastTypeNotAllowed):
+		return http.StatusUnprocessableEntity, "broadcast_type_not_allowed"
+	case errors.Is(err, room.ErrInvalidBroadcast):
+		return http.StatusUnprocessableEntity, "invalid_broadcast"
+	default:
+		return http.StatusInternalServerError, "internal_error"
+	}
+}
+
 // RegisterRoutes registers WebSocket routes
 func (h *RoomWebSocketHandler) RegisterRoutes(router *gin.RouterGroup) {
 	ws := router.Group("/ws")
 	{
 		ws.GET("/rooms/:roomId", h.HandleRoomConnection)
 		ws.GET("/rooms/:roomId/connections", h.GetRoomConnections)
-		ws.POST("/rooms/:roomId/broadcast", h.BroadcastMessage)
+		ws.POST("/rooms/:roomId/broadcast", h.adminGuard.Optional(), h.BroadcastMessage)
 	}
 }
\ No newline at end of file
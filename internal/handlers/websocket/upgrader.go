@@ -0,0 +1,70 @@
+package websocket
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+)
+
+const (
+	defaultBufferSize       = 1024
+	defaultMaxMessageSize   = 64 << 10
+	defaultCompressionLevel = 1
+)
+
+// connUpgrader upgrades room and market stream connections with the configured buffers, message size
+// limit and permessage-deflate compression
+type connUpgrader struct {
+	upgrader         websocket.Upgrader
+	maxMessageSize   int64
+	compressionLevel int
+}
+
+func newConnUpgrader(cfg *config.WebSocketConfig) *connUpgrader {
+	readBufferSize := cfg.ReadBufferSize
+	if readBufferSize <= 0 {
+		readBufferSize = defaultBufferSize
+	}
+	writeBufferSize := cfg.WriteBufferSize
+	if writeBufferSize <= 0 {
+		writeBufferSize = defaultBufferSize
+	}
+	maxMessageSize := cfg.MaxMessageSize
+	if maxMessageSize <= 0 {
+		maxMessageSize = defaultMaxMessageSize
+	}
+	compressionLevel := cfg.CompressionLevel
+	if compressionLevel < 1 || compressionLevel > 9 {
+		compressionLevel = defaultCompressionLevel
+	}
+
+	return &connUpgrader{
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:    readBufferSize,
+			WriteBufferSize:   writeBufferSize,
+			EnableCompression: cfg.EnableCompression, // only used with clients offering permessage-deflate
+			CheckOrigin: func(r *http.Request) bool {
+				// In production, implement proper origin checking
+				return true
+			},
+		},
+		maxMessageSize:   maxMessageSize,
+		compressionLevel: compressionLevel,
+	}
+}
+
+// upgrade takes over the request's connection; client messages over the size limit close it
+func (u *connUpgrader) upgrade(c *gin.Context) (*websocket.Conn, error) {
+	conn, err := u.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetReadLimit(u.maxMessageSize)
+	if u.upgrader.EnableCompression {
+		// Fails only for levels out of range, which are replaced by the default above
+		_ = conn.SetCompressionLevel(u.compressionLevel)
+	}
+	return conn, nil
+}
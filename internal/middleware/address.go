@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+// SolanaAddressParams rejects requests whose named path parameters are not valid Solana addresses.
+// Parameters a route does not declare are ignored, so it can be installed on the whole engine.
+func SolanaAddressParams(params ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, param := range params {
+			value, ok := c.Params.Get(param)
+			if !ok {
+				continue
+			}
+			if err := solana.ValidateAddress(value); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+					"field": param,
+				})
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/admin"
+)
+
+const (
+	// AdminKeyHeader carries an admin API key; a bearer token is accepted too
+	AdminKeyHeader = "X-Admin-Key"
+
+	// Headers of a request signed by an admin wallet, see admin.SignedMessage
+	AdminWalletHeader    = "X-Admin-Wallet"
+	AdminSignatureHeader = "X-Admin-Signature"
+	AdminTimestampHeader = "X-Admin-Timestamp"
+
+	adminPrincipalKey = "admin_principal"
+	maxAuditFieldLen  = 500
+)
+
+// AdminGuard gates admin routes by role and audits every admin action
+type AdminGuard struct {
+	accessService admin.AccessService
+	logger        *logrus.Logger
+}
+
+// NewAdminGuard creates a new admin guard
+func NewAdminGuard(accessService admin.AccessService, logger *logrus.Logger) *AdminGuard {
+	return &AdminGuard{
+		accessService: accessService,
+		logger:        logger,
+	}
+}
+
+// Require returns middleware admitting admins with at least the given role. Requests other than GET and HEAD
+// are recorded in the audit log with their response status, including those refused for lack of role.
+func (g *AdminGuard) Require(role models.AdminRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := g.authenticate(c)
+		if err != nil {
+			status, code := http.StatusUnauthorized, "unauthorized"
+			if errors.Is(err, admin.ErrAdminDisabled) {
+				status, code = http.StatusServiceUnavailable, "admin_disabled"
+			}
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error(), "code": code})
+			return
+		}
+
+		if !principal.Role.Allows(role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "admin role " + string(role) + " required",
+				"code":  "admin_forbidden",
+			})
+		} else {
+			c.Set(adminPrincipalKey, principal)
+			c.Next()
+		}
+
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			g.audit(c, principal)
+		}
+	}
+}
+
+// Optional returns middleware for routes open to non-admins too: requests without admin credentials pass
+// through, those with credentials must authenticate and are then handled and audited as by Require, with
+// whatever role the admin has.
+func (g *AdminGuard) Optional() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(AdminWalletHeader) == "" && c.GetHeader(AdminKeyHeader) == "" && c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+
+		principal, err := g.authenticate(c)
+		if err != nil {
+			status, code := http.StatusUnauthorized, "unauthorized"
+			if errors.Is(err, admin.ErrAdminDisabled) {
+				status, code = http.StatusServiceUnavailable, "admin_disabled"
+			}
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error(), "code": code})
+			return
+		}
+
+		c.Set(adminPrincipalKey, principal)
+		c.Next()
+
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			g.audit(c, principal)
+		}
+	}
+}
+
+// AdminPrincipal returns the admin a guarded request was admitted for
+func AdminPrincipal(c *gin.Context) (*admin.Principal, bool) {
+	value, ok := c.Get(adminPrincipalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*admin.Principal)
+	return principal, ok
+}
+
+func (g *AdminGuard) authenticate(c *gin.Context) (*admin.Principal, error) {
+	if wallet := c.GetHeader(AdminWalletHeader); wallet != "" {
+		return g.accessService.AuthenticateWallet(&admin.WalletCredentials{
+			Address:   wallet,
+			Signature: c.GetHeader(AdminSignatureHeader),
+			Timestamp: c.GetHeader(AdminTimestampHeader),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+		})
+	}
+
+	key := c.GetHeader(AdminKeyHeader)
+	if key == "" {
+		key = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	}
+	return g.accessService.AuthenticateKey(key)
+}
+
+// audit records the action; the request is already answered, so failures are only logged
+func (g *AdminGuard) audit(c *gin.Context, principal *admin.Principal) {
+	entry := &models.AdminAuditLog{
+		Actor:     principal.Name,
+		ActorType: principal.Type,
+		Role:      principal.Role,
+		Method:    c.Request.Method,
+		Route:     c.FullPath(),
+		Path:      truncate(c.Request.URL.Path, maxAuditFieldLen),
+		Query:     truncate(c.Request.URL.RawQuery, maxAuditFieldLen),
+		Status:    c.Writer.Status(),
+		ClientIP:  c.ClientIP(),
+		CreatedAt: time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := g.accessService.RecordAction(ctx, entry); err != nil {
+		g.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"actor":  entry.Actor,
+			"method": entry.Method,
+			"path":   entry.Path,
+		}).Error("Failed to audit admin action")
+	}
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
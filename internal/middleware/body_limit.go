@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+)
+
+const (
+	defaultMaxBodyBytes          = 1 << 20
+	defaultMaxBatchBodyBytes     = 256 << 10
+	defaultMaxBroadcastBodyBytes = 16 << 10
+)
+
+// BodyLimitClass is a kind of route sharing one request body limit
+type BodyLimitClass string
+
+const (
+	BodyLimitBatch     BodyLimitClass = "batch"
+	BodyLimitBroadcast BodyLimitClass = "broadcast"
+)
+
+// BodyLimit caps request bodies at the limit of the matched route's class, or the default limit. Bodies
+// declaring a larger Content-Length are refused up front; others fail when read past the limit, which
+// handlers answer with a 413 as well. routes maps gin route paths, e.g. /api/v1/rooms/:roomId/members/batch,
+// to their class.
+func BodyLimit(cfg *config.BodyLimitsConfig, routes map[string]BodyLimitClass) gin.HandlerFunc {
+	limits := map[BodyLimitClass]int64{
+		BodyLimitBatch:     cfg.Batch,
+		BodyLimitBroadcast: cfg.Broadcast,
+	}
+	defaults := map[BodyLimitClass]int64{
+		BodyLimitBatch:     defaultMaxBatchBodyBytes,
+		BodyLimitBroadcast: defaultMaxBroadcastBodyBytes,
+	}
+	for class, limit := range limits {
+		if limit <= 0 {
+			limits[class] = defaults[class]
+		}
+	}
+	defaultLimit := cfg.Default
+	if defaultLimit <= 0 {
+		defaultLimit = defaultMaxBodyBytes
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		limit := defaultLimit
+		if class, ok := routes[c.FullPath()]; ok {
+			limit = limits[class]
+		}
+		if c.Request.ContentLength > limit {
+			RespondBodyTooLarge(c, limit)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// RespondBodyTooLarge aborts the request with a 413 naming the limit
+func RespondBodyTooLarge(c *gin.Context, limit int64) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error": "Request body is too large",
+		"code":  "payload_too_large",
+		"limit": limit,
+	})
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+)
+
+const (
+	defaultCompressionLevel     = 5
+	defaultCompressionMinLength = 1 << 10
+)
+
+// Compression gzips responses for clients that accept it. Responses are held back until MinLength bytes
+// were written, so small ones are sent as they are; WebSocket upgrades and event streams are never compressed.
+func Compression(cfg *config.CompressionConfig) gin.HandlerFunc {
+	if cfg.Disabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	level := cfg.Level
+	if level < gzip.BestSpeed || level > gzip.BestCompression {
+		level = defaultCompressionLevel
+	}
+	minLength := cfg.MinLength
+	if minLength <= 0 {
+		minLength = defaultCompressionMinLength
+	}
+
+	pool := &sync.Pool{
+		New: func() interface{} {
+			// The level is checked above, so this cannot fail
+			gz, _ := gzip.NewWriterLevel(nil, level)
+			return gz
+		},
+	}
+
+	return func(c *gin.Context) {
+		if !acceptsGzip(c.Request) || c.Request.Method == http.MethodHead ||
+			c.IsWebsocket() || strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			c.Next()
+			return
+		}
+
+		writer := &gzipWriter{ResponseWriter: c.Writer, pool: pool, minLength: minLength}
+		c.Writer = writer
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		c.Next()
+		writer.close()
+	}
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(encoding, ";")
+		if strings.TrimSpace(name) != "gzip" {
+			continue
+		}
+		// gzip;q=0 refuses it
+		key, value, _ := strings.Cut(strings.TrimSpace(params), "=")
+		if strings.TrimSpace(key) != "q" {
+			return true
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return err == nil && q > 0
+	}
+	return false
+}
+
+// gzipWriter buffers the start of a response until it is long enough to be worth compressing
+type gzipWriter struct {
+	gin.ResponseWriter
+	pool      *sync.Pool
+	minLength int
+
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer // set once the response is being compressed
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, data...)
+		if len(w.buf) < w.minLength {
+			return len(data), nil
+		}
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what was written so far, compressed unless the response says it is encoded already
+func (w *gzipWriter) Flush() {
+	if !w.decided {
+		if err := w.start(true); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap lets http.ResponseController reach the connection, e.g. to clear the write deadline of a stream
+func (w *gzipWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// start decides whether to compress and writes out the buffered start of the response
+func (w *gzipWriter) start(compress bool) error {
+	w.decided = true
+	header := w.Header()
+	status := w.Status()
+	if compress && header.Get("Content-Encoding") == "" &&
+		status != http.StatusNoContent && status != http.StatusNotModified {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.gz = w.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.gz != nil {
+		_, err := w.gz.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// close writes out a response left below the minimum length or finishes the compressed stream
+func (w *gzipWriter) close() {
+	if !w.decided {
+		w.start(false)
+		return
+	}
+	if w.gz != nil {
+		w.gz.Close()
+		w.gz.Reset(nil)
+		w.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
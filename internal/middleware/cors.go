@@ -7,14 +7,12 @@ import (
 // CORS middleware for handling Cross-Origin Resource Sharing
 func CORS() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
-		origin := c.Request.Header.Get("Origin")
-		
 		// Allow specific origins or all origins for development
 		// In production, specify exact origins
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Authorization, Content-Type, X-Creator-Address, X-Wallet-Address, X-Sharer-Address")
-		c.Header("Access-Control-Expose-Headers", "Content-Length")
+		c.Header("Access-Control-Allow-Headers", "Origin, Authorization, Content-Type, X-Creator-Address, X-Wallet-Address, X-Sharer-Address, X-Admin-Key, X-Admin-Wallet, X-Admin-Signature, X-Admin-Timestamp, X-Session-ID, Idempotency-Key")
+		c.Header("Access-Control-Expose-Headers", "Content-Length, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Class, X-AI-Quota-Daily-Limit, X-AI-Quota-Daily-Remaining, X-AI-Quota-Monthly-Limit, X-AI-Quota-Monthly-Remaining")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Max-Age", "43200")
 		
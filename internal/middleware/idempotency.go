@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key of a retryable request
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	idempotencyKeyPrefix = "idempotency:"
+	idempotencyTTL       = 24 * time.Hour   // how long completed responses are replayed
+	idempotencyLockTTL   = 30 * time.Second // how long an in-flight request holds its key
+	maxIdempotencyKeyLen = 255
+)
+
+// idempotencyRecord is the stored state of a keyed request
+type idempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"` // hash of the request body
+	Pending     bool   `json:"pending"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Idempotency replays the original response of requests retried with the same Idempotency-Key
+type Idempotency struct {
+	client *redis.Client
+	logger *logrus.Logger
+}
+
+// NewIdempotency creates a Redis-backed idempotency middleware; with a nil client keys are ignored
+func NewIdempotency(client *redis.Client, logger *logrus.Logger) *Idempotency {
+	return &Idempotency{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Middleware returns the idempotency middleware. Keys are scoped to the request method and path;
+// reusing a key with a different body is rejected. Server errors release the key so the request can be retried.
+func (i *Idempotency) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || i.client == nil {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				RespondBodyTooLarge(c, maxBytesErr.Limit)
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+		fingerprint := hex.EncodeToString(sum[:])
+
+		ctx := c.Request.Context()
+		redisKey := idempotencyKeyPrefix + c.Request.Method + ":" + c.Request.URL.Path + ":" + key
+
+		acquired, err := i.acquire(ctx, redisKey, fingerprint)
+		if err != nil {
+			// Redis being unavailable should not block writes
+			i.logger.WithError(err).Warn("Idempotency check failed, processing request without it")
+			c.Next()
+			return
+		}
+		if !acquired {
+			i.replay(c, redisKey, fingerprint)
+			return
+		}
+
+		writer := &idempotentWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			if err := i.client.Del(context.Background(), redisKey).Err(); err != nil {
+				i.logger.WithError(err).Warn("Failed to release idempotency key")
+			}
+			return
+		}
+		record := &idempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+		if err := i.client.SetJSON(context.Background(), redisKey, record, idempotencyTTL); err != nil {
+			i.logger.WithError(err).Warn("Failed to store idempotent response")
+		}
+	}
+}
+
+// acquire claims the key for this request; false if it was already claimed
+func (i *Idempotency) acquire(ctx context.Context, redisKey, fingerprint string) (bool, error) {
+	pending := &idempotencyRecord{Fingerprint: fingerprint, Pending: true}
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return false, err
+	}
+	return i.client.SetNX(ctx, redisKey, data, idempotencyLockTTL).Result()
+}
+
+// replay answers a retried request from the stored record
+func (i *Idempotency) replay(c *gin.Context, redisKey, fingerprint string) {
+	var record idempotencyRecord
+	if err := i.client.GetJSON(c.Request.Context(), redisKey, &record); err != nil {
+		if errors.Is(err, redis.Nil) {
+			// The first request finished with a server error between our claim and read; let the client retry
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key just failed, retry it"})
+			return
+		}
+		i.logger.WithError(err).Error("Failed to read idempotent response")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to read idempotent response"})
+		return
+	}
+
+	switch {
+	case record.Fingerprint != fingerprint:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request body"})
+	case record.Pending:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still in progress"})
+	default:
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(record.Status, record.ContentType, record.Body)
+		c.Abort()
+	}
+}
+
+// idempotentWriter keeps a copy of the response body so it can be replayed
+type idempotentWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotentWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotentWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
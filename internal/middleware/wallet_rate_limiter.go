@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+// RateClass is a separately budgeted kind of request
+type RateClass string
+
+const (
+	RateClassRead  RateClass = "read"
+	RateClassWrite RateClass = "write"
+	RateClassAI    RateClass = "ai"
+)
+
+const (
+	walletRateKeyPrefix = "ratelimit:wallet:"
+	walletRateWindow    = time.Minute
+
+	defaultWalletReadLimit  = 300
+	defaultWalletWriteLimit = 60
+	defaultWalletAILimit    = 10
+)
+
+// walletHeaders carry the acting wallet, in order of precedence
+var walletHeaders = []string{"X-Wallet-Address", "X-Creator-Address", "X-Sharer-Address"}
+
+// WalletRateLimiter limits requests per wallet across all the IPs it calls from, so wallets sharing a NAT
+// do not share a budget. Counts are kept in Redis per one-minute window and shared by all instances.
+type WalletRateLimiter struct {
+	client *redis.Client
+	limits map[RateClass]int
+	logger *logrus.Logger
+}
+
+// NewWalletRateLimiter creates a Redis-backed wallet rate limiter; with a nil client wallets are not limited
+func NewWalletRateLimiter(client *redis.Client, cfg *config.WalletRateLimitConfig, logger *logrus.Logger) *WalletRateLimiter {
+	limits := map[RateClass]int{
+		RateClassRead:  cfg.Read,
+		RateClassWrite: cfg.Write,
+		RateClassAI:    cfg.AI,
+	}
+	defaults := map[RateClass]int{
+		RateClassRead:  defaultWalletReadLimit,
+		RateClassWrite: defaultWalletWriteLimit,
+		RateClassAI:    defaultWalletAILimit,
+	}
+	for class, limit := range limits {
+		if limit <= 0 {
+			limits[class] = defaults[class]
+		}
+	}
+
+	return &WalletRateLimiter{
+		client: client,
+		limits: limits,
+		logger: logger,
+	}
+}
+
+// Middleware returns the middleware charging a wallet's read budget for GET and HEAD requests and its write
+// budget for the others. Requests naming no valid wallet are left to the IP limiter.
+func (l *WalletRateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		class := RateClassWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			class = RateClassRead
+		}
+		l.limit(c, class)
+	}
+}
+
+// AI returns route middleware charging the wallet's AI budget, on top of the read or write budget
+func (l *WalletRateLimiter) AI() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		l.limit(c, RateClassAI)
+	}
+}
+
+func (l *WalletRateLimiter) limit(c *gin.Context, class RateClass) {
+	wallet := requestWallet(c)
+	if wallet == "" || l.client == nil {
+		c.Next()
+		return
+	}
+
+	limit := l.limits[class]
+	count, resetAt, err := l.increment(c.Request.Context(), class, wallet)
+	if err != nil {
+		// Redis being unavailable should not block requests; the IP limiter still applies
+		l.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": wallet,
+			"class":  class,
+		}).Warn("Failed to count wallet request")
+		c.Next()
+		return
+	}
+
+	remaining := limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("X-RateLimit-Class", string(class))
+
+	if int(count) > limit {
+		retryAfter := int(time.Until(resetAt).Seconds()) + 1
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":       fmt.Sprintf("wallet %s rate limit exceeded", class),
+			"code":        "rate_limited",
+			"retry_after": retryAfter,
+		})
+		return
+	}
+	c.Next()
+}
+
+// increment counts a request in the wallet's current window and returns the count and when the window ends
+func (l *WalletRateLimiter) increment(ctx context.Context, class RateClass, wallet string) (int64, time.Time, error) {
+	window := time.Now().Truncate(walletRateWindow)
+	key := fmt.Sprintf("%s%s:%s:%d", walletRateKeyPrefix, class, wallet, window.Unix())
+
+	pipe := l.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, 2*walletRateWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, time.Time{}, err
+	}
+	return incr.Val(), window.Add(walletRateWindow), nil
+}
+
+// requestWallet returns the wallet a request acts for, or "" when it names no valid one
+func requestWallet(c *gin.Context) string {
+	for _, header := range walletHeaders {
+		if wallet := c.GetHeader(header); wallet != "" {
+			if solana.ValidateAddress(wallet) != nil {
+				return ""
+			}
+			return wallet
+		}
+	}
+	return ""
+}
@@ -0,0 +1,156 @@
+package admin
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+var (
+	ErrAdminDisabled    = errors.New("no admins are configured")
+	ErrUnauthenticated  = errors.New("invalid admin credentials")
+	ErrSignatureExpired = errors.New("admin request signature expired")
+)
+
+const defaultSignatureMaxAge = 5 * time.Minute
+
+// Principal is an authenticated admin
+type Principal struct {
+	Name string                // API key name or wallet address
+	Type models.AdminActorType // how it authenticated
+	Role models.AdminRole
+}
+
+// WalletCredentials are the headers of a request signed by an admin wallet
+type WalletCredentials struct {
+	Address   string
+	Signature string // base58 ed25519 signature of SignedMessage
+	Timestamp string // unix seconds
+	Method    string
+	Path      string
+}
+
+// SignedMessage is what an admin wallet signs to authenticate a request
+func SignedMessage(method, path, timestamp string) string {
+	return fmt.Sprintf("solana-wallet-service admin\n%s %s\n%s", method, path, timestamp)
+}
+
+// AccessService authenticates admins seeded from config and keeps the audit log of their actions
+type AccessService interface {
+	Enabled() bool
+	AuthenticateKey(key string) (*Principal, error)
+	AuthenticateWallet(creds *WalletCredentials) (*Principal, error)
+	RecordAction(ctx context.Context, entry *models.AdminAuditLog) error
+	ListAuditLogs(ctx context.Context, actor string, limit, offset int) ([]*models.AdminAuditLog, error)
+}
+
+type apiKey struct {
+	name string
+	hash [sha256.Size]byte // keys are compared by hash, so comparison time does not depend on their length
+	role models.AdminRole
+}
+
+type accessService struct {
+	keys            []apiKey
+	wallets         map[string]models.AdminRole
+	signatureMaxAge time.Duration
+	auditRepo       repositories.AdminAuditRepository
+	logger          *logrus.Logger
+}
+
+// NewAccessService creates a new admin access service instance; invalid entries in the config are
+// logged and skipped
+func NewAccessService(cfg *config.AdminConfig, auditRepo repositories.AdminAuditRepository, logger *logrus.Logger) AccessService {
+	s := &accessService{
+		wallets:         make(map[string]models.AdminRole),
+		signatureMaxAge: cfg.SignatureMaxAge,
+		auditRepo:       auditRepo,
+		logger:          logger,
+	}
+	if s.signatureMaxAge <= 0 {
+		s.signatureMaxAge = defaultSignatureMaxAge
+	}
+
+	for _, key := range cfg.APIKeys {
+		role := models.AdminRole(key.Role)
+		if key.Name == "" || key.Key == "" || !role.IsValid() {
+			logger.WithField("name", key.Name).Warn("Skipping admin API key without name, key or valid role")
+			continue
+		}
+		s.keys = append(s.keys, apiKey{name: key.Name, hash: sha256.Sum256([]byte(key.Key)), role: role})
+	}
+	for _, wallet := range cfg.Wallets {
+		role := models.AdminRole(wallet.Role)
+		if err := solana.ValidateAddress(wallet.Address); err != nil || !role.IsValid() {
+			logger.WithField("wallet", wallet.Address).Warn("Skipping admin wallet without valid address or role")
+			continue
+		}
+		s.wallets[wallet.Address] = role
+	}
+	return s
+}
+
+func (s *accessService) Enabled() bool {
+	return len(s.keys) > 0 || len(s.wallets) > 0
+}
+
+func (s *accessService) AuthenticateKey(key string) (*Principal, error) {
+	if !s.Enabled() {
+		return nil, ErrAdminDisabled
+	}
+	hash := sha256.Sum256([]byte(key))
+	for _, k := range s.keys {
+		if subtle.ConstantTimeCompare(hash[:], k.hash[:]) == 1 {
+			return &Principal{Name: k.name, Type: models.AdminActorAPIKey, Role: k.role}, nil
+		}
+	}
+	return nil, ErrUnauthenticated
+}
+
+// AuthenticateWallet checks that a configured admin wallet signed the request's method, path and timestamp
+// recently enough
+func (s *accessService) AuthenticateWallet(creds *WalletCredentials) (*Principal, error) {
+	if !s.Enabled() {
+		return nil, ErrAdminDisabled
+	}
+	role, ok := s.wallets[creds.Address]
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	seconds, err := strconv.ParseInt(creds.Timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+	age := time.Since(time.Unix(seconds, 0))
+	if age > s.signatureMaxAge || age < -s.signatureMaxAge {
+		return nil, ErrSignatureExpired
+	}
+
+	message := SignedMessage(creds.Method, creds.Path, creds.Timestamp)
+	if !solana.VerifySignature(creds.Address, []byte(message), creds.Signature) {
+		return nil, ErrUnauthenticated
+	}
+	return &Principal{Name: creds.Address, Type: models.AdminActorWallet, Role: role}, nil
+}
+
+func (s *accessService) RecordAction(ctx context.Context, entry *models.AdminAuditLog) error {
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record admin action: %w", err)
+	}
+	return nil
+}
+
+func (s *accessService) ListAuditLogs(ctx context.Context, actor string, limit, offset int) ([]*models.AdminAuditLog, error) {
+	return s.auditRepo.List(ctx, actor, limit, offset)
+}
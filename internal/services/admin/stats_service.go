@@ -0,0 +1,275 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
+)
+
+// Background job runs are kept in a Redis hash per job, so every instance reports the runs of all of them
+const (
+	jobKeyPrefix = "admin:jobs:"
+	jobKeyTTL    = 7 * 24 * time.Hour // jobs that stopped running drop out of the stats
+)
+
+// StatsService reports live counts for the admin dashboard and records background job runs for it
+type StatsService interface {
+	GetStats(ctx context.Context) (*Stats, error)
+	RecordJobRun(ctx context.Context, job string, interval time.Duration, err error)
+}
+
+// Stats is a snapshot of the service's live state
+type Stats struct {
+	ActiveRooms         int64                        `json:"active_rooms"`
+	WebSocket           WebSocketStats               `json:"websocket"`
+	ActiveSubscriptions int                          `json:"active_subscriptions"` // wallets subscribed by this instance
+	QuickNode           *blockchain.ConnectionStatus `json:"quicknode"`            // this instance's log subscription connection
+	TokensTracked       int64                        `json:"tokens_tracked"`
+	RoomBoundTokens     int                          `json:"room_bound_tokens"`
+	Jobs                []*JobStatus                 `json:"jobs"`
+	ExternalAPIs        []apistats.Stats             `json:"external_apis"` // calls made by this instance within the window
+	APIWindowSeconds    int                          `json:"api_window_seconds"`
+	ProviderUsage       []usage.ProviderUsage        `json:"provider_usage"` // metered providers this month, across instances
+	GeneratedAt         time.Time                    `json:"generated_at"`
+}
+
+// WebSocketStats are the open room connections
+type WebSocketStats struct {
+	Instance int `json:"instance"`
+	Total    int `json:"total"` // across all instances
+}
+
+// JobStatus is the latest run of a background job; LagSeconds is how far the next successful run is
+// overdue, 0 while the job is on schedule or before it first succeeded
+type JobStatus struct {
+	Job             string     `json:"job"`
+	IntervalSeconds float64    `json:"interval_seconds"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt   *time.Time `json:"last_success_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	LagSeconds      float64    `json:"lag_seconds"`
+}
+
+type jobRun struct {
+	interval    time.Duration
+	lastRun     time.Time
+	lastSuccess time.Time
+	lastError   string
+}
+
+type statsService struct {
+	roomRepo            repositories.RoomRepository
+	tokenRepo           repositories.TokenRepository
+	wsService           room.WebSocketService
+	subscriptionManager room.SubscriptionManager
+	quickNode           blockchain.QuickNodeService
+	usage               *usage.Tracker
+	apiStats            *apistats.Registry
+	client              *redis.Client
+	logger              *logrus.Logger
+
+	// Job runs when running without Redis
+	mu   sync.Mutex
+	jobs map[string]*jobRun
+}
+
+// NewStatsService creates a new admin stats service instance; with a nil client job runs are only known
+// to the local instance
+func NewStatsService(
+	roomRepo repositories.RoomRepository,
+	tokenRepo repositories.TokenRepository,
+	wsService room.WebSocketService,
+	subscriptionManager room.SubscriptionManager,
+	quickNode blockchain.QuickNodeService,
+	usageTracker *usage.Tracker,
+	apiStats *apistats.Registry,
+	client *redis.Client,
+	logger *logrus.Logger,
+) StatsService {
+	return &statsService{
+		roomRepo:            roomRepo,
+		tokenRepo:           tokenRepo,
+		wsService:           wsService,
+		subscriptionManager: subscriptionManager,
+		quickNode:           quickNode,
+		usage:               usageTracker,
+		apiStats:            apiStats,
+		client:              client,
+		logger:              logger,
+		jobs:                make(map[string]*jobRun),
+	}
+}
+
+func (s *statsService) GetStats(ctx context.Context) (*Stats, error) {
+	activeRooms, err := s.roomRepo.CountByStatus(ctx, models.RoomStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count active rooms: %w", err)
+	}
+	tokens, err := s.tokenRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tokens: %w", err)
+	}
+	boundTokens, err := s.roomRepo.GetBoundTokenAddresses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room-bound tokens: %w", err)
+	}
+	jobs, err := s.jobStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job runs: %w", err)
+	}
+	providerUsage, err := s.usage.Snapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider usage: %w", err)
+	}
+
+	local, total := s.wsService.ConnectionCounts(ctx)
+	return &Stats{
+		ActiveRooms:         activeRooms,
+		WebSocket:           WebSocketStats{Instance: local, Total: total},
+		ActiveSubscriptions: len(s.subscriptionManager.GetActiveSubscriptions()),
+		QuickNode:           s.quickNode.Status(),
+		TokensTracked:       tokens,
+		RoomBoundTokens:     len(boundTokens),
+		Jobs:                jobs,
+		ExternalAPIs:        s.apiStats.Snapshot(),
+		APIWindowSeconds:    int(apistats.Window.Seconds()),
+		ProviderUsage:       providerUsage,
+		GeneratedAt:         time.Now(),
+	}, nil
+}
+
+// RecordJobRun records the outcome of a background job run; interval is how often the job is scheduled
+func (s *statsService) RecordJobRun(ctx context.Context, job string, interval time.Duration, err error) {
+	now := time.Now()
+	if s.client == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		run, ok := s.jobs[job]
+		if !ok {
+			run = &jobRun{}
+			s.jobs[job] = run
+		}
+		run.interval = interval
+		run.lastRun = now
+		run.lastError = ""
+		if err != nil {
+			run.lastError = err.Error()
+		} else {
+			run.lastSuccess = now
+		}
+		return
+	}
+
+	fields := map[string]interface{}{
+		"interval_ms": interval.Milliseconds(),
+		"last_run":    now.UnixMilli(),
+		"last_error":  "",
+	}
+	if err != nil {
+		fields["last_error"] = err.Error()
+	} else {
+		fields["last_success"] = now.UnixMilli()
+	}
+
+	key := jobKeyPrefix + job
+	pipe := s.client.Pipeline()
+	pipe.HSet(ctx, key, fields)
+	pipe.Expire(ctx, key, jobKeyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"job":   job,
+		}).Warn("Failed to record job run")
+	}
+}
+
+// jobStatuses returns the recorded runs of every job, by name
+func (s *statsService) jobStatuses(ctx context.Context) ([]*JobStatus, error) {
+	runs, err := s.jobRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	statuses := make([]*JobStatus, 0, len(runs))
+	for job, run := range runs {
+		status := &JobStatus{
+			Job:             job,
+			IntervalSeconds: run.interval.Seconds(),
+			LastError:       run.lastError,
+		}
+		if !run.lastRun.IsZero() {
+			lastRun := run.lastRun
+			status.LastRunAt = &lastRun
+		}
+		if !run.lastSuccess.IsZero() {
+			lastSuccess := run.lastSuccess
+			status.LastSuccessAt = &lastSuccess
+			if lag := now.Sub(lastSuccess) - run.interval; lag > 0 {
+				status.LagSeconds = lag.Seconds()
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Job < statuses[j].Job
+	})
+	return statuses, nil
+}
+
+func (s *statsService) jobRuns(ctx context.Context) (map[string]*jobRun, error) {
+	runs := make(map[string]*jobRun)
+	if s.client == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for job, run := range s.jobs {
+			copied := *run
+			runs[job] = &copied
+		}
+		return runs, nil
+	}
+
+	iter := s.client.Scan(ctx, 0, jobKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		fields, err := s.client.HGetAll(ctx, iter.Val()).Result()
+		if err != nil {
+			return nil, err
+		}
+		runs[strings.TrimPrefix(iter.Val(), jobKeyPrefix)] = &jobRun{
+			interval:    time.Duration(parseInt(fields["interval_ms"])) * time.Millisecond,
+			lastRun:     parseMillis(fields["last_run"]),
+			lastSuccess: parseMillis(fields["last_success"]),
+			lastError:   fields["last_error"],
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+func parseInt(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
+
+func parseMillis(value string) time.Time {
+	ms := parseInt(value)
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
@@ -3,30 +3,53 @@ package ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/emiyaio/solana-wallet-service/internal/config"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
 	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
 )
 
 // LangChainService provides AI-powered analysis using OpenAI
 type LangChainService interface {
-	AnalyzeToken(ctx context.Context, tokenIdentifier string) (*TokenAnalysisResponse, error)
-	GetChatCompletion(ctx context.Context, userPrompt string) (*ChatResponse, error)
+	AnalyzeToken(ctx context.Context, tokenIdentifier string, prefs *Preferences) (*TokenAnalysisResponse, error)
+	GetChatCompletion(ctx context.Context, userPrompt string, prefs *Preferences) (*ChatResponse, error)
+	ResolvePreferences(ctx context.Context, requestedLanguage, walletAddress string) (*Preferences, error)
+	ListModels() []ModelInfo
+	TestPrompt(ctx context.Context, systemPrompt, input string, opts *ModelOptions) (*ChatResponse, error)
+	SummarizeMarket(ctx context.Context, tokenIdentifier string) (string, error)
+	AnswerRoomQuestion(ctx context.Context, question string, roomCtx *RoomContext, prefs *Preferences) (*ChatResponse, error)
+	ExplainTrade(ctx context.Context, trade *TradeContext) (*ChatResponse, error)
 }
 
+// Preferences controls how AI responses are presented to a user
+type Preferences struct {
+	Language string
+	Timezone string
+	Model    *ModelOptions // nil uses the default model; honoured by AnalyzeToken and GetChatCompletion
+}
+
+var (
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+	ErrTokenNotFound       = token.ErrTokenNotFound // neither stored nor known to the market data provider
+)
+
 type langChainService struct {
 	config            *config.OpenAIConfig
 	tokenRepo         repositories.TokenRepository
+	settingsRepo      repositories.UserSettingsRepository
 	marketService     token.MarketService
-	solanaTracker     token.SolanaTrackerService
+	marketData        token.MarketDataProvider
 	openAIClient      OpenAIClient
+	prompts           PromptService
 	logger            *logrus.Logger
 }
 
@@ -42,12 +65,17 @@ type TokenAnalysisResponse struct {
 	Name         string `json:"name"`
 	Analysis     string `json:"analysis"`
 	Confidence   float64 `json:"confidence"`
+	Language     string `json:"language"`
 	Timestamp    string `json:"timestamp"`
+	Model        string `json:"model"`
+	Usage        Usage  `json:"usage"`
 }
 
 type ChatResponse struct {
 	Content   string `json:"content"`
+	Model     string `json:"model"`
 	Usage     Usage  `json:"usage"`
+	Language  string `json:"language"`
 	Timestamp string `json:"timestamp"`
 }
 
@@ -99,6 +127,7 @@ type AggregatedTokenData struct {
 	TopHolders     []TokenTopHolder     `json:"top_holders"`
 	TxStats        *TokenTxStats        `json:"transaction_stats"`
 	TrendingRank   *TokenTrendingRank   `json:"trending_rank"`
+	Flag           *TokenFlagInfo       `json:"flag,omitempty"`
 }
 
 type TokenBasicInfo struct {
@@ -146,34 +175,88 @@ type TokenTxStats struct {
 	SellVolume       float64 `json:"sell_volume"`
 }
 
+// TokenFlagInfo is an active scam/honeypot flag on the token
+type TokenFlagInfo struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+	Source string `json:"source"` // admin or heuristic
+}
+
 type TokenTrendingRank struct {
 	Rank     int    `json:"rank"`
 	Category string `json:"category"`
 	Score    float64 `json:"score"`
 }
 
+// RoomContext is the trading room state a room assistant answer is grounded in
+type RoomContext struct {
+	RoomID       string               `json:"room_id"`
+	TokenAddress string               `json:"token_address,omitempty"`
+	Token        *AggregatedTokenData `json:"token,omitempty"` // filled from TokenAddress when answering
+	RecentTrades []RoomTrade          `json:"recent_trades"`
+	TopShares    []RoomShare          `json:"top_shares"`
+}
+
+// RoomTrade is a trade event recorded by a room member
+type RoomTrade struct {
+	WalletAddress string  `json:"wallet_address"`
+	TokenAddress  string  `json:"token_address"`
+	Side          string  `json:"side"`
+	Amount        float64 `json:"amount"`
+	ValueUSD      float64 `json:"value_usd"`
+	Time          string  `json:"time"`
+}
+
+// RoomShare is a shared info posted in the room
+type RoomShare struct {
+	Type          string `json:"type"`
+	SharerAddress string `json:"sharer_address"`
+	Title         string `json:"title"`
+	Content       string `json:"content"`
+	Reactions     int    `json:"reactions"`
+}
+
+// TradeContext is a single room trade to be given a one-line rationale
+type TradeContext struct {
+	RoomID        string               `json:"-"` // selects the room's prompt override
+	WalletAddress string               `json:"wallet_address"`
+	WalletLabels  []string             `json:"wallet_labels,omitempty"`
+	TokenAddress  string               `json:"token_address"`
+	Side          string               `json:"side"`
+	Amount        float64              `json:"amount"`
+	ValueUSD      float64              `json:"value_usd"`
+	PriorTrades   []RoomTrade          `json:"prior_trades,omitempty"` // the wallet's earlier trades of the token in the room
+	Token         *AggregatedTokenData `json:"token,omitempty"`        // filled from TokenAddress when explaining
+}
+
 // NewLangChainService creates a new AI service instance
 func NewLangChainService(
 	config *config.OpenAIConfig,
 	tokenRepo repositories.TokenRepository,
+	settingsRepo repositories.UserSettingsRepository,
 	marketService token.MarketService,
-	solanaTracker token.SolanaTrackerService,
+	marketData token.MarketDataProvider,
+	prompts PromptService,
+	usageTracker *usage.Tracker,
+	apiStats *apistats.Registry,
 	logger *logrus.Logger,
 ) LangChainService {
-	openAIClient := NewOpenAIClient(config.APIKey, config.BaseURL)
+	openAIClient := NewOpenAIClient(config.APIKey, config.BaseURL, usageTracker, apiStats)
 	
 	return &langChainService{
 		config:        config,
 		tokenRepo:     tokenRepo,
+		settingsRepo:  settingsRepo,
 		marketService: marketService,
-		solanaTracker: solanaTracker,
+		marketData:    marketData,
 		openAIClient:  openAIClient,
+		prompts:       prompts,
 		logger:        logger,
 	}
 }
 
 // AnalyzeToken performs AI-powered token analysis
-func (s *langChainService) AnalyzeToken(ctx context.Context, tokenIdentifier string) (*TokenAnalysisResponse, error) {
+func (s *langChainService) AnalyzeToken(ctx context.Context, tokenIdentifier string, prefs *Preferences) (*TokenAnalysisResponse, error) {
 	// Get aggregated token data using the tool function
 	tokenData, err := s.getTokenAnalysisData(ctx, tokenIdentifier)
 	if err != nil {
@@ -181,17 +264,7 @@ func (s *langChainService) AnalyzeToken(ctx context.Context, tokenIdentifier str
 	}
 	
 	// Prepare the analysis prompt
-	systemPrompt := `You are a professional cryptocurrency market analyst with deep knowledge of DeFi and Solana ecosystem. 
-	Analyze the provided token data and give a comprehensive but concise analysis covering:
-	1. Current market position and performance
-	2. Price trends and momentum
-	3. Trading volume and liquidity analysis
-	4. Holder distribution insights
-	5. Risk assessment and key considerations
-	6. Short-term outlook (next 1-7 days)
-	
-	Keep your analysis factual, balanced, and professional. Highlight both opportunities and risks.
-	Provide actionable insights for traders and investors.`
+	systemPrompt := s.prompts.Resolve(ctx, PromptTokenAnalysis, "") + prefs.instructions()
 	
 	// Convert token data to JSON for the prompt
 	dataJSON, err := json.MarshalIndent(tokenData, "", "  ")
@@ -211,6 +284,9 @@ func (s *langChainService) AnalyzeToken(ctx context.Context, tokenIdentifier str
 		Temperature: 0.3, // Lower temperature for more consistent analysis
 		MaxTokens:   1500,
 	}
+	if err := applyModel(s.config, prefs.Model, request); err != nil {
+		return nil, err
+	}
 	
 	// Call OpenAI API
 	response, err := s.openAIClient.CreateChatCompletion(ctx, request)
@@ -231,13 +307,17 @@ func (s *langChainService) AnalyzeToken(ctx context.Context, tokenIdentifier str
 		Name:         tokenData.BasicInfo.Name,
 		Analysis:     analysis,
 		Confidence:   confidence,
+		Language:     prefs.Language,
 		Timestamp:    fmt.Sprintf("%d", getCurrentUnixTimestamp()),
+		Model:        request.Model,
+		Usage:        response.Usage,
 	}
 	
 	s.logger.WithFields(logrus.Fields{
 		"token_address": tokenData.BasicInfo.Address,
 		"symbol":        tokenData.BasicInfo.Symbol,
 		"confidence":    confidence,
+		"language":      prefs.Language,
 		"tokens_used":   response.Usage.TotalTokens,
 	}).Info("AI token analysis completed")
 	
@@ -245,11 +325,8 @@ func (s *langChainService) AnalyzeToken(ctx context.Context, tokenIdentifier str
 }
 
 // GetChatCompletion provides general AI chat functionality
-func (s *langChainService) GetChatCompletion(ctx context.Context, userPrompt string) (*ChatResponse, error) {
-	systemPrompt := `You are a knowledgeable cryptocurrency and DeFi expert assistant. 
-	Provide helpful, accurate, and educational responses about blockchain technology, 
-	cryptocurrency trading, DeFi protocols, and market analysis. 
-	Be concise but informative, and always emphasize the importance of DYOR (Do Your Own Research).`
+func (s *langChainService) GetChatCompletion(ctx context.Context, userPrompt string, prefs *Preferences) (*ChatResponse, error) {
+	systemPrompt := s.prompts.Resolve(ctx, PromptChat, "") + prefs.instructions()
 	
 	request := &ChatCompletionRequest{
 		Model: s.config.Model,
@@ -260,6 +337,9 @@ func (s *langChainService) GetChatCompletion(ctx context.Context, userPrompt str
 		Temperature: 0.7,
 		MaxTokens:   800,
 	}
+	if err := applyModel(s.config, prefs.Model, request); err != nil {
+		return nil, err
+	}
 	
 	response, err := s.openAIClient.CreateChatCompletion(ctx, request)
 	if err != nil {
@@ -272,7 +352,9 @@ func (s *langChainService) GetChatCompletion(ctx context.Context, userPrompt str
 	
 	result := &ChatResponse{
 		Content:   response.Choices[0].Message.Content,
+		Model:     request.Model,
 		Usage:     response.Usage,
+		Language:  prefs.Language,
 		Timestamp: fmt.Sprintf("%d", getCurrentUnixTimestamp()),
 	}
 	
@@ -284,6 +366,234 @@ func (s *langChainService) GetChatCompletion(ctx context.Context, userPrompt str
 	return result, nil
 }
 
+// SummarizeMarket produces a short market summary of a token for automated reports
+func (s *langChainService) SummarizeMarket(ctx context.Context, tokenIdentifier string) (string, error) {
+	tokenData, err := s.getTokenAnalysisData(ctx, tokenIdentifier)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token data: %w", err)
+	}
+	
+	systemPrompt := s.prompts.Resolve(ctx, PromptMarketSummary, "")
+	
+	dataJSON, err := json.Marshal(tokenData)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token data: %w", err)
+	}
+	
+	request := &ChatCompletionRequest{
+		Model: s.config.Model,
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: string(dataJSON)},
+		},
+		Temperature: 0.3,
+		MaxTokens:   300,
+	}
+	
+	response, err := s.openAIClient.CreateChatCompletion(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("failed to get market summary: %w", err)
+	}
+	
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("no response from AI model")
+	}
+	
+	s.logger.WithFields(logrus.Fields{
+		"token_address": tokenData.BasicInfo.Address,
+		"tokens_used":   response.Usage.TotalTokens,
+	}).Info("AI market summary completed")
+	
+	return response.Choices[0].Message.Content, nil
+}
+
+// AnswerRoomQuestion answers a room member's question using the room's token data, trades and shares
+func (s *langChainService) AnswerRoomQuestion(ctx context.Context, question string, roomCtx *RoomContext, prefs *Preferences) (*ChatResponse, error) {
+	if roomCtx.TokenAddress != "" && roomCtx.Token == nil {
+		tokenData, err := s.getTokenAnalysisData(ctx, roomCtx.TokenAddress)
+		if err != nil {
+			// Trades and shares still give the answer some grounding
+			s.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"room_id": roomCtx.RoomID,
+				"token":   roomCtx.TokenAddress,
+			}).Warn("Failed to get token data for room question")
+		} else {
+			roomCtx.Token = tokenData
+		}
+	}
+	
+	systemPrompt := s.prompts.Resolve(ctx, PromptRoomQuestion, roomCtx.RoomID) + prefs.instructions()
+	
+	contextJSON, err := json.Marshal(roomCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal room context: %w", err)
+	}
+	
+	request := &ChatCompletionRequest{
+		Model: s.config.Model,
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: fmt.Sprintf("Room context:\n%s\n\nQuestion: %s", string(contextJSON), question)},
+		},
+		Temperature: 0.4,
+		MaxTokens:   400,
+	}
+	
+	response, err := s.openAIClient.CreateChatCompletion(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to answer room question: %w", err)
+	}
+	
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("no response from AI model")
+	}
+	
+	s.logger.WithFields(logrus.Fields{
+		"room_id":     roomCtx.RoomID,
+		"tokens_used": response.Usage.TotalTokens,
+	}).Info("AI room question answered")
+	
+	return &ChatResponse{
+		Content:   response.Choices[0].Message.Content,
+		Model:     request.Model,
+		Usage:     response.Usage,
+		Language:  prefs.Language,
+		Timestamp: fmt.Sprintf("%d", getCurrentUnixTimestamp()),
+	}, nil
+}
+
+// ExplainTrade writes a one-line rationale of a trade from the token's market data and the wallet's prior trades
+func (s *langChainService) ExplainTrade(ctx context.Context, trade *TradeContext) (*ChatResponse, error) {
+	if trade.Token == nil {
+		tokenData, err := s.getTokenAnalysisData(ctx, trade.TokenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token data: %w", err)
+		}
+		trade.Token = tokenData
+	}
+	
+	systemPrompt := s.prompts.Resolve(ctx, PromptTradeRationale, trade.RoomID)
+	
+	dataJSON, err := json.Marshal(trade)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal trade: %w", err)
+	}
+	
+	request := &ChatCompletionRequest{
+		Model: s.config.Model,
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: string(dataJSON)},
+		},
+		Temperature: 0.3,
+		MaxTokens:   40,
+	}
+	
+	response, err := s.openAIClient.CreateChatCompletion(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain trade: %w", err)
+	}
+	
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("no response from AI model")
+	}
+	
+	// Models sometimes add quotes or a second line despite the instructions
+	line := strings.TrimSpace(response.Choices[0].Message.Content)
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	
+	return &ChatResponse{
+		Content:   strings.Trim(line, `"' `),
+		Model:     request.Model,
+		Usage:     response.Usage,
+		Language:  models.DefaultLanguage,
+		Timestamp: fmt.Sprintf("%d", getCurrentUnixTimestamp()),
+	}, nil
+}
+
+// ResolvePreferences builds the response preferences: an explicit language first, then the wallet's saved settings
+func (s *langChainService) ResolvePreferences(ctx context.Context, requestedLanguage, walletAddress string) (*Preferences, error) {
+	if requestedLanguage != "" && !models.IsSupportedLanguage(requestedLanguage) {
+		return nil, ErrUnsupportedLanguage
+	}
+	
+	prefs := &Preferences{Language: models.DefaultLanguage}
+	
+	if walletAddress != "" && s.settingsRepo != nil {
+		settings, err := s.settingsRepo.GetByWallet(ctx, walletAddress)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"wallet": walletAddress,
+			}).Warn("Failed to load user settings, using default preferences")
+		} else if settings != nil {
+			if models.IsSupportedLanguage(settings.Language) {
+				prefs.Language = settings.Language
+			}
+			prefs.Timezone = settings.Timezone
+		}
+	}
+	
+	if requestedLanguage != "" {
+		prefs.Language = requestedLanguage
+	}
+	
+	return prefs, nil
+}
+
+// TestPrompt runs a system prompt against a sample input, so admins can try a prompt before saving it
+func (s *langChainService) TestPrompt(ctx context.Context, systemPrompt, input string, opts *ModelOptions) (*ChatResponse, error) {
+	request := &ChatCompletionRequest{
+		Model: s.config.Model,
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: input},
+		},
+		Temperature: 0.3,
+		MaxTokens:   800,
+	}
+	if err := applyModel(s.config, opts, request); err != nil {
+		return nil, err
+	}
+	
+	response, err := s.openAIClient.CreateChatCompletion(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test prompt: %w", err)
+	}
+	
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("no response from AI model")
+	}
+	
+	return &ChatResponse{
+		Content:   response.Choices[0].Message.Content,
+		Model:     request.Model,
+		Usage:     response.Usage,
+		Language:  models.DefaultLanguage,
+		Timestamp: fmt.Sprintf("%d", getCurrentUnixTimestamp()),
+	}, nil
+}
+
+// ListModels returns the models callers may choose and the bounds of their overrides
+func (s *langChainService) ListModels() []ModelInfo {
+	return availableModels(s.config)
+}
+
+// instructions returns the system prompt suffix that applies the user's preferences
+func (p *Preferences) instructions() string {
+	var sb strings.Builder
+	if name, ok := models.SupportedLanguages[p.Language]; ok && p.Language != models.DefaultLanguage {
+		sb.WriteString(fmt.Sprintf("\n\nRespond in %s. Keep token symbols, wallet addresses and numbers unchanged.", name))
+	}
+	if p.Timezone != "" && p.Timezone != "UTC" {
+		sb.WriteString(fmt.Sprintf("\n\nThe user's timezone is %s; express any dates and times in that timezone.", p.Timezone))
+	}
+	return sb.String()
+}
+
 // getTokenAnalysisData aggregates token data from multiple sources (similar to Java TokenDatabaseTool)
 func (s *langChainService) getTokenAnalysisData(ctx context.Context, tokenIdentifier string) (*AggregatedTokenData, error) {
 	// Try to find token by symbol first, then by address
@@ -291,35 +601,41 @@ func (s *langChainService) getTokenAnalysisData(ctx context.Context, tokenIdenti
 	var token *models.Token
 	var err error
 	
-	// Check if it's a valid Solana address (base58, 32-44 characters)
-	if len(tokenIdentifier) >= 32 && len(tokenIdentifier) <= 44 {
+	// Addresses are looked up directly, anything else is treated as a symbol
+	if solana.IsValidAddress(tokenIdentifier) {
 		tokenAddress = tokenIdentifier
 		token, err = s.tokenRepo.GetByMintAddress(ctx, tokenIdentifier)
 	} else {
 		// Search by symbol
-		tokens, err := s.tokenRepo.List(ctx, 1000, 0) // Get many tokens to search
-		if err == nil {
-			for _, t := range tokens {
-				if strings.EqualFold(t.Symbol, tokenIdentifier) {
-					token = t
-					tokenAddress = t.MintAddress
-					break
-				}
+		var tokens []*models.Token
+		tokens, err = s.tokenRepo.List(ctx, 1000, 0) // Get many tokens to search
+		for _, t := range tokens {
+			if strings.EqualFold(t.Symbol, tokenIdentifier) {
+				token = t
+				tokenAddress = t.MintAddress
+				break
 			}
 		}
 	}
+	if err != nil {
+		// The market data provider is still asked below
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"token": tokenIdentifier,
+		}).Warn("Failed to look up token in database")
+	}
 	
-	// If token not found in database, try to get from SolanaTracker
+	// If token not found in database, try to get from the market data provider
 	if token == nil {
-		tokenInfoResp, err := s.solanaTracker.GetTokenInfo(tokenAddress)
+		tokenInfoResp, err := s.marketData.GetTokenInfo(ctx, tokenAddress)
 		if err != nil {
-			return nil, fmt.Errorf("token not found in database or SolanaTracker: %w", err)
+			return nil, fmt.Errorf("%w in database or market data provider: %v", ErrTokenNotFound, err)
 		}
 		
 		tokenInfo := tokenInfoResp.Data
 		tokenAddress = tokenInfo.Address
 		
-		// Create basic info from SolanaTracker data
+		// Create basic info from provider data
 		basicInfo := &TokenBasicInfo{
 			Address:     tokenInfo.Address,
 			Symbol:      tokenInfo.Symbol,
@@ -363,8 +679,9 @@ func (s *langChainService) getTokenAnalysisData(ctx context.Context, tokenIdenti
 			BasicInfo:    basicInfo,
 			MarketData:   marketData,
 			TopHolders:   topHolders,
-			TxStats:      nil, // Not available from SolanaTracker
+			TxStats:      nil, // Not available from the market data provider
 			TrendingRank: nil, // Would need to check trending data
+			Flag:         s.getTokenFlag(ctx, tokenInfo.Address),
 		}, nil
 	}
 	
@@ -373,11 +690,11 @@ func (s *langChainService) getTokenAnalysisData(ctx context.Context, tokenIdenti
 		Address:     token.MintAddress,
 		Symbol:      token.Symbol,
 		Name:        token.Name,
-		LogoURI:     *token.LogoURI,
-		Description: *token.Description,
-		Website:     *token.Website,
-		Twitter:     *token.Twitter,
-		Telegram:    *token.Telegram,
+		LogoURI:     models.StringValue(token.LogoURI),
+		Description: models.StringValue(token.Description),
+		Website:     models.StringValue(token.Website),
+		Twitter:     models.StringValue(token.Twitter),
+		Telegram:    models.StringValue(token.Telegram),
 		CreatedAt:   token.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 	
@@ -432,9 +749,30 @@ func (s *langChainService) getTokenAnalysisData(ctx context.Context, tokenIdenti
 		TopHolders:   topHolders,
 		TxStats:      txStats,
 		TrendingRank: nil, // Would need to implement trending rank lookup
+		Flag:         s.getTokenFlag(ctx, token.MintAddress),
 	}, nil
 }
 
+// getTokenFlag returns the token's active flag; lookup failures are logged and treated as unflagged
+func (s *langChainService) getTokenFlag(ctx context.Context, mintAddress string) *TokenFlagInfo {
+	flag, err := s.tokenRepo.GetFlag(ctx, mintAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":         err,
+			"token_address": mintAddress,
+		}).Warn("Failed to get token flag for AI analysis")
+		return nil
+	}
+	if flag == nil || !flag.Active {
+		return nil
+	}
+	return &TokenFlagInfo{
+		Type:   string(flag.Type),
+		Reason: flag.Reason,
+		Source: string(flag.Source),
+	}
+}
+
 // calculateConfidence calculates analysis confidence based on data availability
 func (s *langChainService) calculateConfidence(data *AggregatedTokenData) float64 {
 	confidence := 0.0
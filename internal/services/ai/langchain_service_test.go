@@ -0,0 +1,132 @@
+package ai
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+const testMint = "So11111111111111111111111111111111111111112"
+
+// stubTokenRepo serves one token; methods the aggregation does not call panic through the nil interface
+type stubTokenRepo struct {
+	repositories.TokenRepository
+	token *models.Token
+}
+
+func (r *stubTokenRepo) GetByMintAddress(ctx context.Context, mintAddress string) (*models.Token, error) {
+	if r.token != nil && r.token.MintAddress == mintAddress {
+		return r.token, nil
+	}
+	return nil, nil
+}
+
+func (r *stubTokenRepo) List(ctx context.Context, limit, offset int) ([]*models.Token, error) {
+	if r.token == nil {
+		return nil, nil
+	}
+	return []*models.Token{r.token}, nil
+}
+
+func (r *stubTokenRepo) GetFlag(ctx context.Context, mintAddress string) (*models.TokenFlag, error) {
+	return nil, nil
+}
+
+// stubMarketService has no market data, holders or transaction stats for any token
+type stubMarketService struct {
+	token.MarketService
+}
+
+func (s *stubMarketService) GetLatestMarketData(ctx context.Context, tokenID uuid.UUID) (*models.TokenMarketData, error) {
+	return nil, nil
+}
+
+func (s *stubMarketService) GetTopHolders(ctx context.Context, tokenID uuid.UUID, limit int) ([]*models.TokenTopHolders, error) {
+	return nil, nil
+}
+
+func (s *stubMarketService) GetTransactionStats(ctx context.Context, tokenID uuid.UUID, timeframe string) (*models.TokenTransactionStats, error) {
+	return nil, nil
+}
+
+// stubMarketData answers every token with an empty provider response
+type stubMarketData struct {
+	token.MarketDataProvider
+}
+
+func (p *stubMarketData) GetTokenInfo(ctx context.Context, mintAddress string) (*token.TokenInfoResponse, error) {
+	return &token.TokenInfoResponse{}, nil
+}
+
+func newTestLangChainService(tok *models.Token) *langChainService {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return &langChainService{
+		tokenRepo:     &stubTokenRepo{token: tok},
+		marketService: &stubMarketService{},
+		marketData:    &stubMarketData{},
+		logger:        logger,
+	}
+}
+
+func TestGetTokenAnalysisDataMissingMetadata(t *testing.T) {
+	// Only the required columns are set; logo, description and social links are NULL
+	tok := &models.Token{
+		ID:          uuid.New(),
+		MintAddress: testMint,
+		Symbol:      "WSOL",
+	}
+
+	tests := []struct {
+		name       string
+		identifier string
+	}{
+		{name: "by address", identifier: testMint},
+		{name: "by symbol", identifier: "wsol"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestLangChainService(tok)
+			data, err := s.getTokenAnalysisData(context.Background(), tt.identifier)
+			if err != nil {
+				t.Fatalf("getTokenAnalysisData: %v", err)
+			}
+			if data.BasicInfo == nil {
+				t.Fatal("basic info missing")
+			}
+			if data.BasicInfo.LogoURI != "" || data.BasicInfo.Description != "" || data.BasicInfo.Website != "" ||
+				data.BasicInfo.Twitter != "" || data.BasicInfo.Telegram != "" {
+				t.Errorf("nil metadata should be empty, got %+v", data.BasicInfo)
+			}
+			if data.MarketData != nil || data.TxStats != nil || data.Flag != nil {
+				t.Errorf("missing market data, stats and flag should stay nil, got %+v", data)
+			}
+			// Confidence must cope with the missing sections as well
+			if confidence := s.calculateConfidence(data); confidence <= 0 {
+				t.Errorf("confidence = %v, want > 0 with basic info", confidence)
+			}
+		})
+	}
+}
+
+func TestGetTokenAnalysisDataProviderFallback(t *testing.T) {
+	// Unknown to the database, so the empty provider response is used
+	s := newTestLangChainService(nil)
+	data, err := s.getTokenAnalysisData(context.Background(), testMint)
+	if err != nil {
+		t.Fatalf("getTokenAnalysisData: %v", err)
+	}
+	if data.BasicInfo == nil || data.MarketData == nil {
+		t.Fatalf("provider data missing: %+v", data)
+	}
+	if len(data.TopHolders) != 0 || data.TxStats != nil {
+		t.Errorf("empty provider response should have no holders or stats, got %+v", data)
+	}
+	s.calculateConfidence(data)
+}
@@ -0,0 +1,103 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
+)
+
+const maxMetadataVerdictReason = 200
+
+type metadataClassifier struct {
+	config       *config.OpenAIConfig
+	openAIClient OpenAIClient
+	logger       *logrus.Logger
+}
+
+// NewMetadataClassifier creates an AI scam classifier of token metadata for the metadata screen
+func NewMetadataClassifier(config *config.OpenAIConfig, usageTracker *usage.Tracker, apiStats *apistats.Registry, logger *logrus.Logger) token.MetadataClassifier {
+	return &metadataClassifier{
+		config:       config,
+		openAIClient: NewOpenAIClient(config.APIKey, config.BaseURL, usageTracker, apiStats),
+		logger:       logger,
+	}
+}
+
+// ClassifyMetadata asks the model whether the token's metadata looks deceptive
+func (c *metadataClassifier) ClassifyMetadata(ctx context.Context, tokenInfo *models.Token) (*token.MetadataVerdict, error) {
+	if c.config.APIKey == "" {
+		return nil, nil
+	}
+
+	systemPrompt := `You screen newly launched Solana tokens for scams.
+	Judge only the metadata given: flag impersonation of established projects, promises of guaranteed returns,
+	fake airdrops or claims, urgency to connect a wallet, and links that do not belong to the token.
+	Reply with a JSON object and nothing else: {"suspicious": true|false, "reason": "<one short sentence>"}.`
+
+	dataJSON, err := json.Marshal(map[string]string{
+		"symbol":      tokenInfo.Symbol,
+		"name":        tokenInfo.Name,
+		"description": models.StringValue(tokenInfo.Description),
+		"website":     models.StringValue(tokenInfo.Website),
+		"twitter":     models.StringValue(tokenInfo.Twitter),
+		"telegram":    models.StringValue(tokenInfo.Telegram),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	request := &ChatCompletionRequest{
+		Model: c.config.Model,
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: string(dataJSON)},
+		},
+		Temperature: 0,
+		MaxTokens:   80,
+	}
+
+	response, err := c.openAIClient.CreateChatCompletion(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to classify metadata: %w", err)
+	}
+
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("no response from AI model")
+	}
+
+	var reply struct {
+		Suspicious bool   `json:"suspicious"`
+		Reason     string `json:"reason"`
+	}
+	content := strings.TrimSpace(response.Choices[0].Message.Content)
+	if err := json.Unmarshal([]byte(content), &reply); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata verdict %q: %w", content, err)
+	}
+
+	verdict := &token.MetadataVerdict{
+		Suspicious: reply.Suspicious,
+		Reason:     strings.TrimSpace(reply.Reason),
+	}
+	if reason := []rune(verdict.Reason); len(reason) > maxMetadataVerdictReason {
+		verdict.Reason = string(reason[:maxMetadataVerdictReason])
+	}
+	if verdict.Suspicious && verdict.Reason == "" {
+		verdict.Reason = "metadata judged deceptive"
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"mint_address": tokenInfo.MintAddress,
+		"suspicious":   verdict.Suspicious,
+		"tokens_used":  response.Usage.TotalTokens,
+	}).Debug("Token metadata classified")
+
+	return verdict, nil
+}
@@ -0,0 +1,130 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+var (
+	ErrUnknownModel       = errors.New("unknown AI model")
+	ErrInvalidModelParams = errors.New("invalid AI model parameters")
+)
+
+const (
+	defaultModelName      = "default"
+	defaultModelMaxTokens = 2000
+	defaultMaxTemperature = 1.0
+)
+
+// ModelOptions chooses a configured model and overrides its sampling within the model's bounds
+type ModelOptions struct {
+	Model       string   // configured model name; empty uses the default model
+	Temperature *float64 // nil keeps the endpoint's temperature
+	MaxTokens   int      // 0 keeps the endpoint's max tokens
+}
+
+// ModelInfo is a model callers may choose and the bounds of their overrides
+type ModelInfo struct {
+	Name           string  `json:"name"`
+	Model          string  `json:"model"`
+	MaxTokens      int     `json:"max_tokens"`
+	MaxTemperature float64 `json:"max_temperature"`
+	Default        bool    `json:"default"`
+}
+
+// availableModels lists the configured models; the default model is listed as "default" unless configured by name
+func availableModels(cfg *config.OpenAIConfig) []ModelInfo {
+	infos := make([]ModelInfo, 0, len(cfg.Models)+1)
+	hasDefault := false
+	for _, m := range cfg.Models {
+		if m.Name == "" || m.Model == "" {
+			continue
+		}
+		info := modelInfo(&m)
+		info.Default = m.Model == cfg.Model
+		hasDefault = hasDefault || info.Default
+		infos = append(infos, info)
+	}
+	if !hasDefault {
+		infos = append([]ModelInfo{{
+			Name:           defaultModelName,
+			Model:          cfg.Model,
+			MaxTokens:      defaultModelMaxTokens,
+			MaxTemperature: defaultMaxTemperature,
+			Default:        true,
+		}}, infos...)
+	}
+	return infos
+}
+
+func modelInfo(m *config.AIModelConfig) ModelInfo {
+	info := ModelInfo{
+		Name:           m.Name,
+		Model:          m.Model,
+		MaxTokens:      m.MaxTokens,
+		MaxTemperature: m.MaxTemperature,
+	}
+	if info.MaxTokens <= 0 {
+		info.MaxTokens = defaultModelMaxTokens
+	}
+	if info.MaxTemperature <= 0 {
+		info.MaxTemperature = defaultMaxTemperature
+	}
+	return info
+}
+
+// applyModel sets the request's model and sampling from the caller's options. Without options the default
+// model keeps the endpoint's parameters; max tokens are capped to the chosen model's bound either way.
+func applyModel(cfg *config.OpenAIConfig, opts *ModelOptions, request *ChatCompletionRequest) error {
+	name := ""
+	if opts != nil {
+		name = opts.Model
+	}
+
+	var chosen *ModelInfo
+	for _, info := range availableModels(cfg) {
+		if (name == "" && info.Default) || (name != "" && info.Name == name) {
+			info := info
+			chosen = &info
+			break
+		}
+	}
+	if chosen == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownModel, name)
+	}
+
+	request.Model = chosen.Model
+	if request.MaxTokens > chosen.MaxTokens {
+		request.MaxTokens = chosen.MaxTokens
+	}
+	if opts == nil {
+		return nil
+	}
+
+	if opts.Temperature != nil {
+		if *opts.Temperature < 0 || *opts.Temperature > chosen.MaxTemperature {
+			return fmt.Errorf("%w: temperature must be between 0 and %g for %s", ErrInvalidModelParams, chosen.MaxTemperature, chosen.Name)
+		}
+		request.Temperature = *opts.Temperature
+	}
+	if opts.MaxTokens != 0 {
+		if opts.MaxTokens < 0 || opts.MaxTokens > chosen.MaxTokens {
+			return fmt.Errorf("%w: max_tokens must be between 1 and %d for %s", ErrInvalidModelParams, chosen.MaxTokens, chosen.Name)
+		}
+		request.MaxTokens = opts.MaxTokens
+	}
+	return nil
+}
+
+// usageCost prices usage by the configured model with the given OpenAI model ID
+func usageCost(cfg *config.OpenAIConfig, modelID string, usage *models.AIUsage) float64 {
+	for _, m := range cfg.Models {
+		if m.Model == modelID {
+			return (float64(usage.PromptTokens)*m.PromptPrice + float64(usage.CompletionTokens)*m.CompletionPrice) / 1e6
+		}
+	}
+	return 0
+}
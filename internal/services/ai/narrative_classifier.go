@@ -0,0 +1,106 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
+)
+
+const maxTokenNarratives = 2
+
+type narrativeClassifier struct {
+	config       *config.OpenAIConfig
+	openAIClient OpenAIClient
+	logger       *logrus.Logger
+}
+
+// NewNarrativeClassifier creates an AI narrative classifier for the token market service
+func NewNarrativeClassifier(config *config.OpenAIConfig, usageTracker *usage.Tracker, apiStats *apistats.Registry, logger *logrus.Logger) token.NarrativeClassifier {
+	return &narrativeClassifier{
+		config:       config,
+		openAIClient: NewOpenAIClient(config.APIKey, config.BaseURL, usageTracker, apiStats),
+		logger:       logger,
+	}
+}
+
+// ClassifyNarratives asks the model for up to two narratives; unknown narratives in the reply are dropped
+func (c *narrativeClassifier) ClassifyNarratives(ctx context.Context, tokenInfo *models.Token) ([]models.TokenNarrative, error) {
+	if c.config.APIKey == "" {
+		return nil, nil
+	}
+
+	allowed := make([]string, len(models.TokenNarratives))
+	for i, narrative := range models.TokenNarratives {
+		allowed[i] = string(narrative)
+	}
+
+	systemPrompt := fmt.Sprintf(`You categorize Solana tokens by market narrative.
+	Choose at most %d narratives from: %s.
+	Use "other" only if none of the others fit. Reply with a JSON array of narrative strings and nothing else, e.g. ["meme"].`,
+		maxTokenNarratives, strings.Join(allowed, ", "))
+
+	dataJSON, err := json.Marshal(map[string]string{
+		"symbol":      tokenInfo.Symbol,
+		"name":        tokenInfo.Name,
+		"description": models.StringValue(tokenInfo.Description),
+		"website":     models.StringValue(tokenInfo.Website),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	request := &ChatCompletionRequest{
+		Model: c.config.Model,
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: string(dataJSON)},
+		},
+		Temperature: 0,
+		MaxTokens:   30,
+	}
+
+	response, err := c.openAIClient.CreateChatCompletion(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to classify narratives: %w", err)
+	}
+
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("no response from AI model")
+	}
+
+	var reply []string
+	content := strings.TrimSpace(response.Choices[0].Message.Content)
+	if err := json.Unmarshal([]byte(content), &reply); err != nil {
+		return nil, fmt.Errorf("failed to parse narratives %q: %w", content, err)
+	}
+
+	var narratives []models.TokenNarrative
+	seen := make(map[models.TokenNarrative]bool)
+	for _, value := range reply {
+		narrative := models.TokenNarrative(strings.ToLower(strings.TrimSpace(value)))
+		if !narrative.IsValid() || seen[narrative] {
+			continue
+		}
+		seen[narrative] = true
+		narratives = append(narratives, narrative)
+		if len(narratives) == maxTokenNarratives {
+			break
+		}
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"mint_address": tokenInfo.MintAddress,
+		"narratives":   narratives,
+		"tokens_used":  response.Usage.TotalTokens,
+	}).Debug("Token narratives classified")
+
+	return narratives, nil
+}
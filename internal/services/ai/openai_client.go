@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
 )
 
 // openAIClient implements the OpenAIClient interface
@@ -14,10 +17,12 @@ type openAIClient struct {
 	apiKey     string
 	baseURL    string
 	httpClient *http.Client
+	usage      *usage.Tracker
 }
 
-// NewOpenAIClient creates a new OpenAI client
-func NewOpenAIClient(apiKey, baseURL string) OpenAIClient {
+// NewOpenAIClient creates a new OpenAI client; its requests and tokens are counted by the usage tracker and
+// its calls by the API stats registry
+func NewOpenAIClient(apiKey, baseURL string, usageTracker *usage.Tracker, apiStats *apistats.Registry) OpenAIClient {
 	if baseURL == "" {
 		baseURL = "https://api.openai.com/v1"
 	}
@@ -25,7 +30,8 @@ func NewOpenAIClient(apiKey, baseURL string) OpenAIClient {
 	return &openAIClient{
 		apiKey:     apiKey,
 		baseURL:    baseURL,
-		httpClient: &http.Client{Timeout: 60 * time.Second},
+		httpClient: &http.Client{Timeout: 60 * time.Second, Transport: usageTracker.Transport(usage.ProviderOpenAI, apiStats.Transport("openai", nil))},
+		usage:      usageTracker,
 	}
 }
 
@@ -66,6 +72,7 @@ func (c *openAIClient) CreateChatCompletion(ctx context.Context, request *ChatCo
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	c.usage.Add(ctx, usage.ProviderOpenAI, int64(response.Usage.TotalTokens))
 	
 	return &response, nil
 }
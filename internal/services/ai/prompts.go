@@ -0,0 +1,278 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+var (
+	ErrUnknownPrompt         = errors.New("unknown prompt")
+	ErrInvalidPrompt         = errors.New("invalid prompt")
+	ErrPromptVersionNotFound = errors.New("prompt version not found")
+)
+
+const maxPromptLength = 8000
+
+// PromptKey names the use case of an AI system prompt
+type PromptKey string
+
+const (
+	PromptTokenAnalysis  PromptKey = "token_analysis"
+	PromptChat           PromptKey = "chat"
+	PromptMarketSummary  PromptKey = "market_summary"
+	PromptRoomQuestion   PromptKey = "room_question"   // may be overridden per room
+	PromptTradeRationale PromptKey = "trade_rationale" // may be overridden per room
+)
+
+// builtinPrompt is the prompt a use case runs with until an admin saves one
+type builtinPrompt struct {
+	description string
+	content     string
+	perRoom     bool
+}
+
+var builtinPrompts = map[PromptKey]builtinPrompt{
+	PromptTokenAnalysis: {
+		description: "Token analysis, GET /ai/analyze",
+		content: `You are a professional cryptocurrency market analyst with deep knowledge of DeFi and Solana ecosystem. 
+	Analyze the provided token data and give a comprehensive but concise analysis covering:
+	1. Current market position and performance
+	2. Price trends and momentum
+	3. Trading volume and liquidity analysis
+	4. Holder distribution insights
+	5. Risk assessment and key considerations
+	6. Short-term outlook (next 1-7 days)
+	
+	Keep your analysis factual, balanced, and professional. Highlight both opportunities and risks.
+	Provide actionable insights for traders and investors.
+	If the data contains a flag, the token has been marked as a scam or honeypot: open the analysis with a clear warning stating the flag and its reason, and do not present the token as an opportunity.`,
+	},
+	PromptChat: {
+		description: "General crypto chat, POST /ai/chat",
+		content: `You are a knowledgeable cryptocurrency and DeFi expert assistant. 
+	Provide helpful, accurate, and educational responses about blockchain technology, 
+	cryptocurrency trading, DeFi protocols, and market analysis. 
+	Be concise but informative, and always emphasize the importance of DYOR (Do Your Own Research).`,
+	},
+	PromptMarketSummary: {
+		description: "Market section of room digests",
+		content: `You are a cryptocurrency market analyst writing the market section of a daily trading room digest.
+	Summarize the token's last 24 hours in at most 3 short sentences: price move, volume and liquidity, and notable holder or trading activity.
+	Be factual and neutral; do not give financial advice.
+	If the data contains a flag, start with a warning that the token is flagged and why.`,
+	},
+	PromptRoomQuestion: {
+		description: "Room assistant answers, POST /rooms/:roomId/ai/ask",
+		content: `You are the assistant of a Solana trading room. Members ask you about the room's token and activity.
+	Answer using the room context provided: the bound token's market data, the members' recent trades and the most-liked shares.
+	Refer to wallets by their shortened address. If the context does not contain what is needed, say so instead of guessing.
+	Keep answers under 150 words, factual and neutral, and do not give financial advice.
+	If the token data contains a flag, mention that the token is flagged and why.`,
+		perRoom: true,
+	},
+	PromptTradeRationale: {
+		description: "One-line rationales of room trades",
+		content: `You annotate trades in a Solana trading room with a one-line rationale.
+	Given the trade, the wallet's earlier trades of the token and the token's market data, state the most likely reason for the trade
+	in a single line of at most 15 words, e.g. "Adding on 15% dip with rising smart money inflow" or "Taking profit after 2x in 24h".
+	Use only what the data supports. Reply with the line only, without quotes.`,
+		perRoom: true,
+	},
+}
+
+// PromptInfo is a use case's built-in prompt, its active global version and the rooms overriding it
+type PromptInfo struct {
+	Key           PromptKey              `json:"key"`
+	Description   string                 `json:"description"`
+	PerRoom       bool                   `json:"per_room"`
+	Builtin       string                 `json:"builtin"`
+	Active        *models.PromptTemplate `json:"active,omitempty"` // nil while the built-in prompt is used
+	RoomOverrides []string               `json:"room_overrides"`
+}
+
+// SavePromptRequest is a new version of a prompt
+type SavePromptRequest struct {
+	Key       PromptKey
+	RoomID    string // empty for the global prompt
+	Content   string
+	Note      string
+	CreatedBy string
+}
+
+// PromptService keeps the versioned system prompts of the AI use cases. A use case runs with the active version
+// of its room, then its active global version, then its built-in prompt.
+type PromptService interface {
+	Resolve(ctx context.Context, key PromptKey, roomID string) string
+	ListPrompts(ctx context.Context) ([]*PromptInfo, error)
+	ListVersions(ctx context.Context, key PromptKey, roomID string) ([]*models.PromptTemplate, error)
+	SaveVersion(ctx context.Context, req *SavePromptRequest) (*models.PromptTemplate, error)
+	ActivateVersion(ctx context.Context, key PromptKey, roomID string, version int) (*models.PromptTemplate, error)
+	Reset(ctx context.Context, key PromptKey, roomID string) error
+}
+
+type promptService struct {
+	promptRepo repositories.PromptTemplateRepository
+	logger     *logrus.Logger
+}
+
+// NewPromptService creates a new prompt service instance
+func NewPromptService(promptRepo repositories.PromptTemplateRepository, logger *logrus.Logger) PromptService {
+	return &promptService{
+		promptRepo: promptRepo,
+		logger:     logger,
+	}
+}
+
+// Resolve returns the prompt a use case runs with; lookup failures fall back to the built-in prompt,
+// so a database outage does not stop AI answers
+func (s *promptService) Resolve(ctx context.Context, key PromptKey, roomID string) string {
+	builtin := builtinPrompts[key]
+	scopes := []string{""}
+	if roomID != "" && builtin.perRoom {
+		scopes = []string{roomID, ""}
+	}
+
+	for _, scope := range scopes {
+		template, err := s.promptRepo.GetActive(ctx, string(key), scope)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"prompt":  key,
+				"room_id": scope,
+			}).Warn("Failed to get prompt template, using built-in prompt")
+			return builtin.content
+		}
+		if template != nil {
+			return template.Content
+		}
+	}
+	return builtin.content
+}
+
+func (s *promptService) ListPrompts(ctx context.Context) ([]*PromptInfo, error) {
+	active, err := s.promptRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active prompts: %w", err)
+	}
+
+	keys := []PromptKey{PromptTokenAnalysis, PromptChat, PromptMarketSummary, PromptRoomQuestion, PromptTradeRationale}
+	infos := make([]*PromptInfo, 0, len(keys))
+	byKey := make(map[PromptKey]*PromptInfo, len(keys))
+	for _, key := range keys {
+		builtin := builtinPrompts[key]
+		info := &PromptInfo{
+			Key:           key,
+			Description:   builtin.description,
+			PerRoom:       builtin.perRoom,
+			Builtin:       builtin.content,
+			RoomOverrides: []string{},
+		}
+		infos = append(infos, info)
+		byKey[key] = info
+	}
+	for _, template := range active {
+		info, ok := byKey[PromptKey(template.Key)]
+		if !ok {
+			continue
+		}
+		if template.RoomID == "" {
+			info.Active = template
+		} else {
+			info.RoomOverrides = append(info.RoomOverrides, template.RoomID)
+		}
+	}
+	return infos, nil
+}
+
+func (s *promptService) ListVersions(ctx context.Context, key PromptKey, roomID string) ([]*models.PromptTemplate, error) {
+	if err := ValidatePromptScope(key, roomID); err != nil {
+		return nil, err
+	}
+	return s.promptRepo.ListVersions(ctx, string(key), roomID)
+}
+
+// SaveVersion stores the content as the prompt's next version and makes it active
+func (s *promptService) SaveVersion(ctx context.Context, req *SavePromptRequest) (*models.PromptTemplate, error) {
+	if err := ValidatePromptScope(req.Key, req.RoomID); err != nil {
+		return nil, err
+	}
+	content := strings.TrimSpace(req.Content)
+	if err := ValidatePromptContent(content); err != nil {
+		return nil, err
+	}
+
+	template := &models.PromptTemplate{
+		Key:       string(req.Key),
+		RoomID:    req.RoomID,
+		Content:   content,
+		Note:      req.Note,
+		CreatedBy: req.CreatedBy,
+	}
+	if err := s.promptRepo.CreateVersion(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to save prompt: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"prompt":  req.Key,
+		"room_id": req.RoomID,
+		"version": template.Version,
+		"by":      req.CreatedBy,
+	}).Info("Prompt version saved")
+	return template, nil
+}
+
+// ActivateVersion makes an earlier or later version the active one, e.g. to roll back an edit
+func (s *promptService) ActivateVersion(ctx context.Context, key PromptKey, roomID string, version int) (*models.PromptTemplate, error) {
+	if err := ValidatePromptScope(key, roomID); err != nil {
+		return nil, err
+	}
+	template, err := s.promptRepo.Activate(ctx, string(key), roomID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate prompt version: %w", err)
+	}
+	if template == nil {
+		return nil, ErrPromptVersionNotFound
+	}
+	return template, nil
+}
+
+// Reset deactivates the prompt's versions, so the use case falls back to the global or built-in prompt
+func (s *promptService) Reset(ctx context.Context, key PromptKey, roomID string) error {
+	if err := ValidatePromptScope(key, roomID); err != nil {
+		return err
+	}
+	if err := s.promptRepo.Deactivate(ctx, string(key), roomID); err != nil {
+		return fmt.Errorf("failed to reset prompt: %w", err)
+	}
+	return nil
+}
+
+// ValidatePromptContent checks a system prompt an admin saves or tests
+func ValidatePromptContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("%w: content is required", ErrInvalidPrompt)
+	}
+	if utf8.RuneCountInString(content) > maxPromptLength {
+		return fmt.Errorf("%w: content exceeds %d characters", ErrInvalidPrompt, maxPromptLength)
+	}
+	return nil
+}
+
+// ValidatePromptScope checks that the prompt exists and, for a room, that it may be overridden per room
+func ValidatePromptScope(key PromptKey, roomID string) error {
+	builtin, ok := builtinPrompts[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPrompt, key)
+	}
+	if roomID != "" && !builtin.perRoom {
+		return fmt.Errorf("%w: %s cannot be overridden per room", ErrInvalidPrompt, key)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+var ErrQuotaExceeded = errors.New("AI token quota exceeded")
+
+const (
+	defaultDailyQuota   = 50000
+	defaultMonthlyQuota = 1000000
+)
+
+// Quota is a wallet's AI token budget in the current UTC day and month; limits and remaining are -1 when uncapped
+type Quota struct {
+	DailyLimit       int64     `json:"daily_limit"`
+	DailyUsed        int64     `json:"daily_used"`
+	DailyRemaining   int64     `json:"daily_remaining"`
+	DailyResetAt     time.Time `json:"daily_reset_at"`
+	MonthlyLimit     int64     `json:"monthly_limit"`
+	MonthlyUsed      int64     `json:"monthly_used"`
+	MonthlyRemaining int64     `json:"monthly_remaining"`
+	MonthlyResetAt   time.Time `json:"monthly_reset_at"`
+}
+
+// Exceeded reports whether either budget is spent
+func (q *Quota) Exceeded() bool {
+	return q.DailyRemaining == 0 || q.MonthlyRemaining == 0
+}
+
+// ResetAt is when a spent quota frees up again
+func (q *Quota) ResetAt() time.Time {
+	if q.MonthlyRemaining == 0 {
+		return q.MonthlyResetAt
+	}
+	return q.DailyResetAt
+}
+
+// UsageSummary is a wallet's AI usage: its quota, this month's totals per feature and its latest requests
+type UsageSummary struct {
+	WalletAddress string                 `json:"wallet_address"`
+	Quota         *Quota                 `json:"quota"`
+	Month         []*models.AIUsageTotal `json:"month"`
+	Recent        []*models.AIUsage      `json:"recent"`
+}
+
+// UsageService accounts the OpenAI tokens used on behalf of wallets and enforces their daily and monthly quotas.
+// A check and the usage it admits are not atomic, so concurrent requests may overshoot a quota by one answer each.
+type UsageService interface {
+	CheckQuota(ctx context.Context, walletAddress string) (*Quota, error) // ErrQuotaExceeded, with the quota, when spent
+	RecordUsage(ctx context.Context, usage *models.AIUsage) (*Quota, error)
+	GetUsage(ctx context.Context, walletAddress string, limit, offset int) (*UsageSummary, error)
+}
+
+type usageService struct {
+	config       *config.OpenAIConfig
+	usageRepo    repositories.AIUsageRepository
+	dailyLimit   int64
+	monthlyLimit int64
+	logger       *logrus.Logger
+}
+
+// NewUsageService creates a new AI usage service instance
+func NewUsageService(cfg *config.OpenAIConfig, usageRepo repositories.AIUsageRepository, logger *logrus.Logger) UsageService {
+	return &usageService{
+		config:       cfg,
+		usageRepo:    usageRepo,
+		dailyLimit:   quotaLimit(cfg.Quota.Daily, defaultDailyQuota),
+		monthlyLimit: quotaLimit(cfg.Quota.Monthly, defaultMonthlyQuota),
+		logger:       logger,
+	}
+}
+
+func (s *usageService) CheckQuota(ctx context.Context, walletAddress string) (*Quota, error) {
+	quota, err := s.quota(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	if quota.Exceeded() {
+		return quota, ErrQuotaExceeded
+	}
+	return quota, nil
+}
+
+// RecordUsage prices and stores the usage and returns the wallet's quota after it
+func (s *usageService) RecordUsage(ctx context.Context, usage *models.AIUsage) (*Quota, error) {
+	if usage.Model == "" {
+		usage.Model = s.config.Model
+	}
+	usage.CostUSD = usageCost(s.config, usage.Model, usage)
+	if err := s.usageRepo.Create(ctx, usage); err != nil {
+		return nil, fmt.Errorf("failed to record AI usage: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"wallet":      usage.WalletAddress,
+		"feature":     usage.Feature,
+		"tokens_used": usage.TotalTokens,
+		"cost_usd":    usage.CostUSD,
+	}).Debug("AI usage recorded")
+
+	return s.quota(ctx, usage.WalletAddress)
+}
+
+func (s *usageService) GetUsage(ctx context.Context, walletAddress string, limit, offset int) (*UsageSummary, error) {
+	quota, err := s.quota(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	_, monthStart := usagePeriods(time.Now())
+	month, err := s.usageRepo.GetTotals(ctx, walletAddress, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI usage totals: %w", err)
+	}
+	recent, err := s.usageRepo.List(ctx, walletAddress, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list AI usage: %w", err)
+	}
+
+	return &UsageSummary{
+		WalletAddress: walletAddress,
+		Quota:         quota,
+		Month:         month,
+		Recent:        recent,
+	}, nil
+}
+
+func (s *usageService) quota(ctx context.Context, walletAddress string) (*Quota, error) {
+	dayStart, monthStart := usagePeriods(time.Now())
+	dailyUsed, err := s.usageRepo.SumTokens(ctx, walletAddress, dayStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum daily AI usage: %w", err)
+	}
+	monthlyUsed, err := s.usageRepo.SumTokens(ctx, walletAddress, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum monthly AI usage: %w", err)
+	}
+
+	return &Quota{
+		DailyLimit:       s.dailyLimit,
+		DailyUsed:        dailyUsed,
+		DailyRemaining:   remaining(s.dailyLimit, dailyUsed),
+		DailyResetAt:     dayStart.AddDate(0, 0, 1),
+		MonthlyLimit:     s.monthlyLimit,
+		MonthlyUsed:      monthlyUsed,
+		MonthlyRemaining: remaining(s.monthlyLimit, monthlyUsed),
+		MonthlyResetAt:   monthStart.AddDate(0, 1, 0),
+	}, nil
+}
+
+// usagePeriods returns the start of the UTC day and month quotas are counted in
+func usagePeriods(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return dayStart, monthStart
+}
+
+func quotaLimit(configured, fallback int64) int64 {
+	switch {
+	case configured < 0:
+		return -1
+	case configured == 0:
+		return fallback
+	default:
+		return configured
+	}
+}
+
+func remaining(limit, used int64) int64 {
+	if limit < 0 {
+		return -1
+	}
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
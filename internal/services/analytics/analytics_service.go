@@ -0,0 +1,252 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+var (
+	ErrUnknownQuery = errors.New("unknown analytics query")
+	ErrInvalidParam = errors.New("invalid analytics query parameter")
+)
+
+// QueryParam describes one parameter of a curated query; a param has either Allowed values or a Min/Max range
+type QueryParam struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Default     string   `json:"default"`
+	Allowed     []string `json:"allowed,omitempty"`
+	Min         int      `json:"min,omitempty"`
+	Max         int      `json:"max,omitempty"`
+}
+
+// Query is a curated analytical query that dashboards can run by name
+type Query struct {
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Params       []QueryParam `json:"params"`
+	CacheSeconds int          `json:"cache_seconds"`
+
+	run func(ctx context.Context, repo repositories.AnalyticsRepository, params map[string]string) (interface{}, error)
+}
+
+// QueryResult is the output of a query together with the params it was run with
+type QueryResult struct {
+	Query       string            `json:"query"`
+	Params      map[string]string `json:"params"` // every declared param, defaults included
+	Rows        interface{}       `json:"rows"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
+
+var limitParam = QueryParam{Name: "limit", Description: "Number of rows", Default: "20", Min: 1, Max: 100}
+
+// queries is the fixed catalogue of queries; params are validated before run is called
+var queries = []*Query{
+	{
+		Name:         "top_gainers",
+		Description:  "Tokens bound to active rooms ranked by price change",
+		CacheSeconds: 120,
+		Params: []QueryParam{
+			{Name: "timeframe", Description: "Price change window", Default: "24h", Allowed: []string{"1h", "24h", "7d"}},
+			limitParam,
+		},
+		run: func(ctx context.Context, repo repositories.AnalyticsRepository, params map[string]string) (interface{}, error) {
+			return repo.TopGainers(ctx, params["timeframe"], intParam(params, "limit"))
+		},
+	},
+	{
+		Name:         "most_copied_traders",
+		Description:  "Wallets whose buys were repeated by other wallets shortly afterwards",
+		CacheSeconds: 900,
+		Params: []QueryParam{
+			{Name: "days", Description: "Lookback in days", Default: "7", Min: 1, Max: 30},
+			{Name: "window_hours", Description: "How soon after a buy a matching buy counts as a copy", Default: "1", Min: 1, Max: 24},
+			limitParam,
+		},
+		run: func(ctx context.Context, repo repositories.AnalyticsRepository, params map[string]string) (interface{}, error) {
+			since := time.Now().UTC().AddDate(0, 0, -intParam(params, "days"))
+			window := time.Duration(intParam(params, "window_hours")) * time.Hour
+			return repo.MostCopiedTraders(ctx, since, window, intParam(params, "limit"))
+		},
+	},
+	{
+		Name:         "busiest_rooms",
+		Description:  "Public active rooms ranked by trade events and shares",
+		CacheSeconds: 300,
+		Params: []QueryParam{
+			{Name: "hours", Description: "Lookback in hours", Default: "24", Min: 1, Max: 168},
+			limitParam,
+		},
+		run: func(ctx context.Context, repo repositories.AnalyticsRepository, params map[string]string) (interface{}, error) {
+			since := time.Now().UTC().Add(-time.Duration(intParam(params, "hours")) * time.Hour)
+			return repo.BusiestRooms(ctx, since, intParam(params, "limit"))
+		},
+	},
+}
+
+// AnalyticsService runs the curated read-only analytics queries
+type AnalyticsService interface {
+	ListQueries() []*Query
+	RunQuery(ctx context.Context, name string, params map[string]string) (*QueryResult, error)
+
+	// DEX market share
+	RollupDexStats(ctx context.Context) (int, error)
+	GetDexShare(ctx context.Context, timeframe string) (*DexShare, error)
+
+	// Homepage market summary
+	GetMarketOverview(ctx context.Context) (*MarketOverview, error)
+}
+
+type analyticsService struct {
+	analyticsRepo        repositories.AnalyticsRepository
+	transactionProcessor blockchain.TransactionProcessor
+	prices               blockchain.PriceAggregator
+	cache                *redis.Client // optional
+	logger               *logrus.Logger
+}
+
+// NewAnalyticsService creates a new analytics service instance; cache may be nil
+func NewAnalyticsService(
+	analyticsRepo repositories.AnalyticsRepository,
+	transactionProcessor blockchain.TransactionProcessor,
+	prices blockchain.PriceAggregator,
+	cache *redis.Client,
+	logger *logrus.Logger,
+) AnalyticsService {
+	return &analyticsService{
+		analyticsRepo:        analyticsRepo,
+		transactionProcessor: transactionProcessor,
+		prices:               prices,
+		cache:                cache,
+		logger:               logger,
+	}
+}
+
+func (s *analyticsService) ListQueries() []*Query {
+	return queries
+}
+
+// RunQuery runs a query by name; params not declared by the query are ignored
+func (s *analyticsService) RunQuery(ctx context.Context, name string, params map[string]string) (*QueryResult, error) {
+	query := findQuery(name)
+	if query == nil {
+		return nil, ErrUnknownQuery
+	}
+
+	resolved, err := resolveParams(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := queryCacheKey(query.Name, resolved)
+	if s.cache != nil {
+		var cached QueryResult
+		err := s.cache.GetJSON(ctx, cacheKey, &cached)
+		if err == nil {
+			return &cached, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			s.logger.WithFields(logrus.Fields{
+				"error": err,
+				"key":   cacheKey,
+			}).Warn("Failed to read analytics cache")
+		}
+	}
+
+	rows, err := query.run(ctx, s.analyticsRepo, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", query.Name, err)
+	}
+
+	result := &QueryResult{
+		Query:       query.Name,
+		Params:      resolved,
+		Rows:        rows,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	if s.cache != nil {
+		if err := s.cache.SetJSON(ctx, cacheKey, result, time.Duration(query.CacheSeconds)*time.Second); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err,
+				"key":   cacheKey,
+			}).Warn("Failed to write analytics cache")
+		}
+	}
+
+	return result, nil
+}
+
+func findQuery(name string) *Query {
+	for _, query := range queries {
+		if query.Name == name {
+			return query
+		}
+	}
+	return nil
+}
+
+// resolveParams fills in defaults and validates every declared param of the query
+func resolveParams(query *Query, params map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(query.Params))
+	for _, param := range query.Params {
+		value, ok := params[param.Name]
+		if !ok || value == "" {
+			value = param.Default
+		}
+
+		if len(param.Allowed) > 0 {
+			if !containsString(param.Allowed, value) {
+				return nil, fmt.Errorf("%w: %s must be one of %s", ErrInvalidParam, param.Name, strings.Join(param.Allowed, ", "))
+			}
+		} else {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < param.Min || n > param.Max {
+				return nil, fmt.Errorf("%w: %s must be an integer between %d and %d", ErrInvalidParam, param.Name, param.Min, param.Max)
+			}
+			value = strconv.Itoa(n)
+		}
+		resolved[param.Name] = value
+	}
+	return resolved, nil
+}
+
+// queryCacheKey is stable for equal params regardless of map order
+func queryCacheKey(name string, params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for param := range params {
+		names = append(names, param)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, param := range names {
+		parts[i] = param + "=" + params[param]
+	}
+	return fmt.Sprintf("analytics:%s:%s", name, strings.Join(parts, "&"))
+}
+
+// intParam reads a param that resolveParams has already validated as an integer
+func intParam(params map[string]string, name string) int {
+	n, _ := strconv.Atoi(params[name])
+	return n
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
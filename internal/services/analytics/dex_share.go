@@ -0,0 +1,124 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// Recent days are rolled up again on every run, as trades are still being backfilled into them
+const dexRollupDays = 2
+
+// dexShareTimeframes maps a dex-share timeframe to the number of UTC days it covers, today included
+var dexShareTimeframes = map[string]int{
+	"1d":  1,
+	"7d":  7,
+	"30d": 30,
+	"90d": 90,
+}
+
+// PlatformShare is a DEX platform's part of detected trades over a timeframe
+type PlatformShare struct {
+	Platform    string  `json:"platform"`
+	Trades      int     `json:"trades"`
+	BuyCount    int     `json:"buy_count"`
+	SellCount   int     `json:"sell_count"`
+	VolumeUSD   float64 `json:"volume_usd"`
+	TradeShare  float64 `json:"trade_share"`  // percent of all trades
+	VolumeShare float64 `json:"volume_share"` // percent of all volume
+	PeakWallets int     `json:"peak_wallets"` // most distinct wallets on a single day
+}
+
+// DexShare is the platform market share of detected trades, with the daily stats it was computed from
+type DexShare struct {
+	Timeframe   string                 `json:"timeframe"`
+	From        time.Time              `json:"from"`
+	Trades      int                    `json:"trades"`
+	VolumeUSD   float64                `json:"volume_usd"`
+	Platforms   []*PlatformShare       `json:"platforms"` // by trades, known platforms without trades last
+	Daily       []*models.DexDailyStat `json:"daily"`     // oldest first
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+// RollupDexStats recomputes the daily per-platform stats of the last days from smart money transactions
+func (s *analyticsService) RollupDexStats(ctx context.Context) (int, error) {
+	from := utcDay(time.Now()).AddDate(0, 0, -(dexRollupDays - 1))
+	stats, err := s.analyticsRepo.AggregateDexDailyStats(ctx, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to aggregate DEX stats: %w", err)
+	}
+	if err := s.analyticsRepo.SaveDexDailyStats(ctx, stats); err != nil {
+		return 0, fmt.Errorf("failed to save DEX stats: %w", err)
+	}
+	return len(stats), nil
+}
+
+// GetDexShare sums the daily stats of the timeframe into each platform's share of trades and volume
+func (s *analyticsService) GetDexShare(ctx context.Context, timeframe string) (*DexShare, error) {
+	days, ok := dexShareTimeframes[timeframe]
+	if !ok {
+		return nil, fmt.Errorf("%w: timeframe must be one of 1d, 7d, 30d, 90d", ErrInvalidParam)
+	}
+
+	from := utcDay(time.Now()).AddDate(0, 0, -(days - 1))
+	daily, err := s.analyticsRepo.GetDexDailyStats(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	share := &DexShare{
+		Timeframe:   timeframe,
+		From:        from,
+		Daily:       daily,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	byPlatform := make(map[string]*PlatformShare)
+	for _, platform := range s.transactionProcessor.Platforms() {
+		byPlatform[platform] = &PlatformShare{Platform: platform}
+	}
+	for _, stat := range daily {
+		platform, ok := byPlatform[stat.Platform]
+		if !ok {
+			platform = &PlatformShare{Platform: stat.Platform}
+			byPlatform[stat.Platform] = platform
+		}
+		platform.Trades += stat.Trades
+		platform.BuyCount += stat.BuyCount
+		platform.SellCount += stat.SellCount
+		platform.VolumeUSD += stat.VolumeUSD
+		if stat.Wallets > platform.PeakWallets {
+			platform.PeakWallets = stat.Wallets
+		}
+		share.Trades += stat.Trades
+		share.VolumeUSD += stat.VolumeUSD
+	}
+
+	share.Platforms = make([]*PlatformShare, 0, len(byPlatform))
+	for _, platform := range byPlatform {
+		if share.Trades > 0 {
+			platform.TradeShare = float64(platform.Trades) / float64(share.Trades) * 100
+		}
+		if share.VolumeUSD > 0 {
+			platform.VolumeShare = platform.VolumeUSD / share.VolumeUSD * 100
+		}
+		share.Platforms = append(share.Platforms, platform)
+	}
+	sort.Slice(share.Platforms, func(i, j int) bool {
+		a, b := share.Platforms[i], share.Platforms[j]
+		if a.Trades != b.Trades {
+			return a.Trades > b.Trades
+		}
+		return a.Platform < b.Platform
+	})
+
+	return share, nil
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
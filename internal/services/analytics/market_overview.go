@@ -0,0 +1,80 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+const (
+	marketOverviewCacheKey = "market_overview"
+	marketOverviewCacheTTL = 60 * time.Second
+	marketOverviewWindow   = 24 * time.Hour // tokens without market data in the window are not tracked anymore
+	marketOverviewRooms    = 5
+
+	wrappedSOLMint = "So11111111111111111111111111111111111111112"
+)
+
+// MarketOverview is the homepage summary of the tracked tokens, the busiest rooms and the SOL price
+type MarketOverview struct {
+	TrackedTokens     int                `json:"tracked_tokens"`
+	TotalVolume24h    float64            `json:"total_volume_24h"`
+	Gainers           int                `json:"gainers"`
+	Losers            int                `json:"losers"`
+	AvgPriceChange24h float64            `json:"avg_price_change_24h"`
+	SOLPriceUSD       *float64           `json:"sol_price_usd"` // nil when no price is known
+	MostActiveRooms   []*models.BusyRoom `json:"most_active_rooms"`
+	GeneratedAt       time.Time          `json:"generated_at"`
+}
+
+// GetMarketOverview aggregates the latest market data of the tokens updated in the last day; it is cached for a minute
+func (s *analyticsService) GetMarketOverview(ctx context.Context) (*MarketOverview, error) {
+	if s.cache != nil {
+		var cached MarketOverview
+		err := s.cache.GetJSON(ctx, marketOverviewCacheKey, &cached)
+		if err == nil {
+			return &cached, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			s.logger.WithError(err).Warn("Failed to read market overview cache")
+		}
+	}
+
+	since := time.Now().UTC().Add(-marketOverviewWindow)
+	stats, err := s.analyticsRepo.MarketStats(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate market stats: %w", err)
+	}
+	rooms, err := s.analyticsRepo.BusiestRooms(ctx, since, marketOverviewRooms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get most active rooms: %w", err)
+	}
+
+	overview := &MarketOverview{
+		TrackedTokens:     stats.Tokens,
+		TotalVolume24h:    stats.TotalVolume24h,
+		Gainers:           stats.Gainers,
+		Losers:            stats.Losers,
+		AvgPriceChange24h: stats.AvgPriceChange24h,
+		MostActiveRooms:   rooms,
+		GeneratedAt:       time.Now().UTC(),
+	}
+	if price, ok := s.prices.PriceUSD(ctx, wrappedSOLMint); ok {
+		overview.SOLPriceUSD = &price
+	}
+
+	if s.cache != nil {
+		if err := s.cache.SetJSON(ctx, marketOverviewCacheKey, overview, marketOverviewCacheTTL); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err,
+				"key":   marketOverviewCacheKey,
+			}).Warn("Failed to write market overview cache")
+		}
+	}
+	return overview, nil
+}
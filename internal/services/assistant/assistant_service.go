@@ -0,0 +1,252 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+const (
+	maxQuestionLength = 500
+	contextTrades     = 20
+	contextShareScan  = 50 // recent shares ranked by reactions
+	contextShares     = 5
+	shareContentLimit = 400
+	postTitleLength   = 80
+)
+
+var (
+	ErrQuestionTooLong = fmt.Errorf("question exceeds %d characters", maxQuestionLength)
+	ErrEmptyQuestion   = errors.New("question is required")
+)
+
+// RoomAssistantService answers member questions about a trading room with AI
+type RoomAssistantService interface {
+	Ask(ctx context.Context, req *AskRequest) (*AskResult, error)
+}
+
+// AskRequest is a member question to the room assistant
+type AskRequest struct {
+	RoomID        string `json:"-"`
+	WalletAddress string `json:"wallet_address" binding:"required,solana_address"`
+	Question      string `json:"question" binding:"required"`
+	Language      string `json:"language,omitempty"`
+	PostToRoom    bool   `json:"post_to_room"` // share the answer with the room
+}
+
+// AskResult is the assistant's answer, with the room post if it was shared
+type AskResult struct {
+	Answer     string             `json:"answer"`
+	Language   string             `json:"language"`
+	TokensUsed int                `json:"tokens_used"`
+	SharedInfo *models.SharedInfo `json:"shared_info,omitempty"`
+	Model      string             `json:"model"`
+	Usage      ai.Usage           `json:"-"` // prompt and completion split, for usage accounting
+}
+
+type roomAssistantService struct {
+	roomRepo  repositories.RoomRepository
+	aiService ai.LangChainService
+	throttle  room.Throttle
+	wsService room.WebSocketService
+	logger    *logrus.Logger
+}
+
+// NewRoomAssistantService creates a new room assistant service instance
+func NewRoomAssistantService(
+	roomRepo repositories.RoomRepository,
+	aiService ai.LangChainService,
+	throttle room.Throttle,
+	wsService room.WebSocketService,
+	logger *logrus.Logger,
+) RoomAssistantService {
+	return &roomAssistantService{
+		roomRepo:  roomRepo,
+		aiService: aiService,
+		throttle:  throttle,
+		wsService: wsService,
+		logger:    logger,
+	}
+}
+
+// Ask answers a member's question from the room context and optionally posts the answer to the room
+func (s *roomAssistantService) Ask(ctx context.Context, req *AskRequest) (*AskResult, error) {
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		return nil, ErrEmptyQuestion
+	}
+	if len([]rune(question)) > maxQuestionLength {
+		return nil, ErrQuestionTooLong
+	}
+
+	tradeRoom, err := s.roomRepo.GetByRoomID(ctx, req.RoomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+	if tradeRoom == nil {
+		return nil, room.ErrRoomNotFound
+	}
+	if tradeRoom.Status != models.RoomStatusActive {
+		return nil, room.ErrRoomClosed
+	}
+
+	member, err := s.roomRepo.GetMemberByAddress(ctx, tradeRoom.ID, req.WalletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room member: %w", err)
+	}
+	if member == nil {
+		return nil, room.ErrNotMember
+	}
+
+	prefs, err := s.aiService.ResolvePreferences(ctx, req.Language, req.WalletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// AI calls are costly, so questions have their own room limit
+	if err := s.throttle.Allow(ctx, room.ThrottleActionAIAsk, tradeRoom.RoomID, req.WalletAddress); err != nil {
+		return nil, err
+	}
+
+	roomCtx, err := s.buildContext(ctx, tradeRoom)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.aiService.AnswerRoomQuestion(ctx, question, roomCtx, prefs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to answer question: %w", err)
+	}
+
+	result := &AskResult{
+		Answer:     response.Content,
+		Language:   response.Language,
+		TokensUsed: response.Usage.TotalTokens,
+		Model:      response.Model,
+		Usage:      response.Usage,
+	}
+
+	if req.PostToRoom {
+		info, err := s.postAnswer(ctx, tradeRoom, req.WalletAddress, question, response.Content)
+		if err != nil {
+			return nil, err
+		}
+		result.SharedInfo = info
+	}
+
+	return result, nil
+}
+
+// buildContext collects the room's bound token, latest trades and most-liked recent shares
+func (s *roomAssistantService) buildContext(ctx context.Context, tradeRoom *models.TradeRoom) (*ai.RoomContext, error) {
+	roomCtx := &ai.RoomContext{
+		RoomID:       tradeRoom.RoomID,
+		RecentTrades: []ai.RoomTrade{},
+		TopShares:    []ai.RoomShare{},
+	}
+	if tradeRoom.TokenAddress != nil && *tradeRoom.TokenAddress != "" {
+		roomCtx.TokenAddress = *tradeRoom.TokenAddress
+	} else if tradeRoom.Token != nil {
+		roomCtx.TokenAddress = tradeRoom.Token.MintAddress
+	}
+
+	events, err := s.roomRepo.GetTradeEvents(ctx, tradeRoom.ID, contextTrades, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade events: %w", err)
+	}
+	for _, event := range events {
+		value := event.ServerValueUSD
+		if value == 0 {
+			value = event.ValueUSD
+		}
+		roomCtx.RecentTrades = append(roomCtx.RecentTrades, ai.RoomTrade{
+			WalletAddress: event.WalletAddress,
+			TokenAddress:  event.TokenAddress,
+			Side:          string(event.EventType),
+			Amount:        event.Amount,
+			ValueUSD:      value,
+			Time:          event.BlockTime.UTC().Format(time.RFC3339),
+		})
+	}
+
+	infos, err := s.roomRepo.GetSharedInfos(ctx, tradeRoom.ID, contextShareScan, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared infos: %w", err)
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].LikeCount > infos[j].LikeCount
+	})
+	if len(infos) > contextShares {
+		infos = infos[:contextShares]
+	}
+	for _, info := range infos {
+		roomCtx.TopShares = append(roomCtx.TopShares, ai.RoomShare{
+			Type:          string(info.Type),
+			SharerAddress: info.SharerAddress,
+			Title:         info.Title,
+			Content:       truncate(info.Content, shareContentLimit),
+			Reactions:     info.LikeCount,
+		})
+	}
+
+	return roomCtx, nil
+}
+
+// postAnswer shares the question and answer in the room as an analysis by the assistant
+func (s *roomAssistantService) postAnswer(ctx context.Context, tradeRoom *models.TradeRoom, askedBy, question, answer string) (*models.SharedInfo, error) {
+	metadata, err := json.Marshal(map[string]string{
+		"question": question,
+		"asked_by": askedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode answer metadata: %w", err)
+	}
+
+	info := &models.SharedInfo{
+		RoomID:        tradeRoom.ID,
+		SharerAddress: models.AssistantSharerAddress,
+		Type:          models.SharedInfoTypeAnalysis,
+		Title:         truncate(question, postTitleLength),
+		Content:       answer,
+		Metadata:      string(metadata),
+	}
+	if err := s.roomRepo.CreateSharedInfo(ctx, info); err != nil {
+		return nil, fmt.Errorf("failed to post answer: %w", err)
+	}
+
+	if err := s.roomRepo.UpdateLastActivity(ctx, tradeRoom.ID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": tradeRoom.RoomID,
+		}).Warn("Failed to update room activity")
+	}
+
+	// Rooms without open connections have nothing to notify
+	_ = s.wsService.NotifySharedInfo(tradeRoom.RoomID, info)
+
+	s.logger.WithFields(logrus.Fields{
+		"room_id":  tradeRoom.RoomID,
+		"asked_by": askedBy,
+		"info_id":  info.ID,
+	}).Info("Room assistant answer posted")
+
+	return info, nil
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-3]) + "..."
+}
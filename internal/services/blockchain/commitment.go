@@ -0,0 +1,98 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+)
+
+// Commitment is a Solana commitment level, from fastest to most certain
+type Commitment string
+
+const (
+	CommitmentProcessed Commitment = "processed"
+	CommitmentConfirmed Commitment = "confirmed"
+	CommitmentFinalized Commitment = "finalized"
+)
+
+func (c Commitment) IsValid() bool {
+	switch c {
+	case CommitmentProcessed, CommitmentConfirmed, CommitmentFinalized:
+		return true
+	}
+	return false
+}
+
+// Commitments are the resolved commitment levels per use, see config.CommitmentConfig
+type Commitments struct {
+	Subscription Commitment
+	Read         Commitment
+	Persistence  Commitment
+}
+
+// ResolveCommitments applies defaults to the configured commitment levels. getTransaction and
+// getSignaturesForAddress do not accept processed, so reads and persistence are raised to at least confirmed.
+func ResolveCommitments(cfg config.CommitmentConfig) Commitments {
+	resolve := func(value string, fallback Commitment) Commitment {
+		commitment := Commitment(value)
+		if !commitment.IsValid() {
+			return fallback
+		}
+		return commitment
+	}
+	atLeastConfirmed := func(commitment Commitment) Commitment {
+		if commitment == CommitmentProcessed {
+			return CommitmentConfirmed
+		}
+		return commitment
+	}
+
+	return Commitments{
+		Subscription: resolve(cfg.Subscription, CommitmentProcessed),
+		Read:         atLeastConfirmed(resolve(cfg.Read, CommitmentConfirmed)),
+		Persistence:  atLeastConfirmed(resolve(cfg.Persistence, CommitmentFinalized)),
+	}
+}
+
+// SignatureStatus is a transaction's current standing as reported by getSignatureStatuses
+type SignatureStatus struct {
+	Slot               int64       `json:"slot"`
+	Confirmations      *int64      `json:"confirmations"` // nil once finalized
+	Err                interface{} `json:"err"`
+	ConfirmationStatus Commitment  `json:"confirmationStatus"`
+}
+
+// Finalized reports whether the transaction can no longer be rolled back
+func (s *SignatureStatus) Finalized() bool {
+	return s.ConfirmationStatus == CommitmentFinalized
+}
+
+// Up to this many signatures fit one getSignatureStatuses call
+const maxSignatureStatuses = 256
+
+// GetSignatureStatuses looks up the statuses of the given signatures, searching the full transaction history.
+// Entries are nil for signatures the cluster does not know, e.g. transactions dropped by a fork.
+func (tp *transactionProcessor) GetSignatureStatuses(signatures []string) ([]*SignatureStatus, error) {
+	statuses := make([]*SignatureStatus, 0, len(signatures))
+	for start := 0; start < len(signatures); start += maxSignatureStatuses {
+		end := start + maxSignatureStatuses
+		if end > len(signatures) {
+			end = len(signatures)
+		}
+
+		var page struct {
+			Value []*SignatureStatus `json:"value"`
+		}
+		if err := tp.callRPC("getSignatureStatuses", []interface{}{
+			signatures[start:end],
+			map[string]interface{}{"searchTransactionHistory": true},
+		}, &page); err != nil {
+			return nil, fmt.Errorf("failed to get signature statuses: %w", err)
+		}
+		if len(page.Value) != end-start {
+			return nil, fmt.Errorf("expected %d signature statuses, got %d", end-start, len(page.Value))
+		}
+		statuses = append(statuses, page.Value...)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,63 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+)
+
+// Every signature pays the base fee; anything above it is the priority fee set through compute budget instructions
+const baseFeeLamportsPerSignature = 5000
+
+// Stored market data is only the latest price, so it is a fair reference for a trade's execution price
+// only while the trade is this recent
+const slippageReferenceWindow = 2 * time.Minute
+
+// priorityFee returns the part of a transaction's fee paid above the base fee
+func priorityFee(tx *SolanaTransactionResponse) int64 {
+	signatures := int64(tx.Transaction.Message.Header.NumRequiredSignatures)
+	if signatures <= 0 {
+		signatures = 1
+	}
+	fee := tx.Meta.Fee - signatures*baseFeeLamportsPerSignature
+	if fee < 0 {
+		return 0
+	}
+	return fee
+}
+
+// executionSlippage compares a swap's execution price with the traded token's market price, in percent;
+// positive values mean the wallet paid more on a buy or received less on a sell. It is nil when the swap
+// has no quote side, the trade is too old for the latest market price to be a reference, or there is no price.
+func (tp *transactionProcessor) executionSlippage(ctx context.Context, action *AnalyzedWalletAction) *float64 {
+	if !action.Success || action.ValueUSD <= 0 || time.Since(action.BlockTime) > slippageReferenceWindow {
+		return nil
+	}
+
+	var traded, quote *TokenAmount
+	for _, side := range []*TokenAmount{action.InputToken, action.OutputToken} {
+		if side == nil {
+			continue
+		}
+		if IsQuoteAsset(side.Mint) {
+			quote = side
+		} else {
+			traded = side
+		}
+	}
+	if traded == nil || quote == nil || traded.Amount <= 0 {
+		return nil
+	}
+
+	marketPrice, ok := tp.prices.PriceUSD(ctx, traded.Mint)
+	if !ok || marketPrice <= 0 {
+		return nil
+	}
+
+	executionPrice := action.ValueUSD / traded.Amount
+	slippage := (executionPrice - marketPrice) / marketPrice * 100
+	if traded == action.InputToken {
+		// Selling the token: a lower execution price is the worse fill
+		slippage = -slippage
+	}
+	return &slippage
+}
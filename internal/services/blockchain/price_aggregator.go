@@ -0,0 +1,171 @@
+package blockchain
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+const (
+	wrappedSOLMint = "So11111111111111111111111111111111111111112"
+	usdcMint       = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	usdtMint       = "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"
+
+	feedPriceMaxAge = 5 * time.Minute // price feed records older than this fall back to market data or the peg
+
+	defaultMaxDeviationPercent = 5.0
+	defaultMaxDEXPriceAge      = 15 * time.Minute
+)
+
+// quoteAsset is a mint that traded tokens are bought with and sold for
+type quoteAsset struct {
+	Symbol string
+	Stable bool // pegged to USD, so its amount is its USD value
+}
+
+var quoteAssets = map[string]quoteAsset{
+	wrappedSOLMint: {Symbol: "SOL"},
+	usdcMint:       {Symbol: "USDC", Stable: true},
+	usdtMint:       {Symbol: "USDT", Stable: true},
+}
+
+// IsQuoteAsset reports whether a mint is a quote asset (wSOL, USDC or USDT) rather than a traded token
+func IsQuoteAsset(mint string) bool {
+	_, ok := quoteAssets[mint]
+	return ok
+}
+
+// PriceAggregator resolves USD prices of mints. Quote assets and mints with a Pyth reference feed use the
+// price feed while it is fresh; otherwise stablecoins are pegged at $1 and other mints use the latest stored market data.
+type PriceAggregator interface {
+	PriceUSD(ctx context.Context, mint string) (float64, bool)
+	ValueUSD(ctx context.Context, token *TokenAmount) (float64, bool)
+	// ReferencePrice returns the fresh price feed record of a quote asset or reference feed mint
+	ReferencePrice(ctx context.Context, mint string) (*models.AssetPrice, bool)
+	// CheckMarketData attaches the reference price to the mint's market data and flags a stale or divergent DEX price
+	CheckMarketData(ctx context.Context, mint string, data *models.TokenMarketData)
+}
+
+type priceAggregator struct {
+	tokenRepo           repositories.TokenRepository
+	referenceMints      map[string]bool
+	maxDeviationPercent float64
+	maxDEXPriceAge      time.Duration
+	logger              *logrus.Logger
+}
+
+// NewPriceAggregator creates a new price aggregator
+func NewPriceAggregator(tokenRepo repositories.TokenRepository, cfg *config.PythConfig, logger *logrus.Logger) PriceAggregator {
+	p := &priceAggregator{
+		tokenRepo:           tokenRepo,
+		referenceMints:      make(map[string]bool),
+		maxDeviationPercent: cfg.MaxDeviationPercent,
+		maxDEXPriceAge:      cfg.MaxDEXPriceAge,
+		logger:              logger,
+	}
+	for mint := range quoteAssets {
+		p.referenceMints[mint] = true
+	}
+	for _, feed := range cfg.Feeds {
+		if feed.Mint != "" {
+			p.referenceMints[feed.Mint] = true
+		}
+	}
+	if p.maxDeviationPercent <= 0 {
+		p.maxDeviationPercent = defaultMaxDeviationPercent
+	}
+	if p.maxDEXPriceAge <= 0 {
+		p.maxDEXPriceAge = defaultMaxDEXPriceAge
+	}
+	return p
+}
+
+// PriceUSD returns false if the mint has no known price
+func (p *priceAggregator) PriceUSD(ctx context.Context, mint string) (float64, bool) {
+	if reference, ok := p.ReferencePrice(ctx, mint); ok {
+		return reference.PriceUSD, true
+	}
+	if quote, ok := quoteAssets[mint]; ok && quote.Stable {
+		return 1, true
+	}
+
+	token, err := p.tokenRepo.GetByMintAddress(ctx, mint)
+	if err != nil {
+		p.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": mint,
+		}).Warn("Failed to get token for pricing")
+		return 0, false
+	}
+	if token == nil {
+		return 0, false
+	}
+
+	marketData, err := p.tokenRepo.GetLatestMarketData(ctx, token.ID)
+	if err != nil || marketData == nil || marketData.PriceUSD == 0 {
+		return 0, false
+	}
+	return marketData.PriceUSD, true
+}
+
+// ReferencePrice returns false for mints without a feed and while the feed has no recent price
+func (p *priceAggregator) ReferencePrice(ctx context.Context, mint string) (*models.AssetPrice, bool) {
+	if !p.referenceMints[mint] {
+		return nil, false
+	}
+	price, err := p.tokenRepo.GetLatestAssetPrice(ctx, mint)
+	if err != nil {
+		p.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": mint,
+		}).Warn("Failed to get feed price")
+		return nil, false
+	}
+	if price == nil || price.PriceUSD <= 0 || time.Since(price.FetchedAt) > feedPriceMaxAge {
+		return nil, false
+	}
+	return price, true
+}
+
+// CheckMarketData flags the DEX price as divergent when it is further from the reference than the confidence
+// interval plus the allowed deviation, and as stale when it was not updated within the allowed age
+func (p *priceAggregator) CheckMarketData(ctx context.Context, mint string, data *models.TokenMarketData) {
+	if data == nil {
+		return
+	}
+	reference, ok := p.ReferencePrice(ctx, mint)
+	if !ok {
+		return
+	}
+
+	check := &models.ReferencePrice{
+		Source:      reference.Source,
+		PriceUSD:    reference.PriceUSD,
+		Confidence:  reference.Confidence,
+		PublishedAt: reference.FetchedAt,
+	}
+	if data.PriceUSD > 0 {
+		check.DeviationPercent = (data.PriceUSD - reference.PriceUSD) / reference.PriceUSD * 100
+		allowed := p.maxDeviationPercent + reference.Confidence/reference.PriceUSD*100
+		check.DEXPriceDivergent = math.Abs(check.DeviationPercent) > allowed
+	}
+	check.DEXPriceStale = !data.LastUpdated.IsZero() && time.Since(data.LastUpdated) > p.maxDEXPriceAge
+	data.Reference = check
+}
+
+// ValueUSD values a token amount at its current price
+func (p *priceAggregator) ValueUSD(ctx context.Context, token *TokenAmount) (float64, bool) {
+	if token == nil {
+		return 0, false
+	}
+	price, ok := p.PriceUSD(ctx, token.Mint)
+	if !ok {
+		return 0, false
+	}
+	return token.Amount * price, true
+}
@@ -0,0 +1,203 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Reconnect policy and outage alerting defaults
+const (
+	defaultMaxReconnectAttempts = 10
+	defaultMaxReconnectBackoff  = 30 * time.Second
+	defaultOutageAlertAfter     = 5 * time.Minute
+	outageCheckInterval         = 15 * time.Second
+	outageAlertTimeout          = 10 * time.Second
+)
+
+// Outage alert events
+const (
+	outageEventDown     = "quicknode_down"
+	outageEventGaveUp   = "quicknode_gave_up"
+	outageEventResolved = "quicknode_resolved"
+)
+
+// ConnectionStatus is the state of the QuickNode WebSocket for monitoring
+type ConnectionStatus struct {
+	Connected         bool       `json:"connected"`
+	DisconnectedSince *time.Time `json:"disconnected_since,omitempty"`
+	LastActivity      *time.Time `json:"last_activity,omitempty"`
+	ReconnectAttempts int        `json:"reconnect_attempts"`
+	GaveUp            bool       `json:"gave_up"`  // reconnecting stopped until a reconnect is forced
+	Outages           int        `json:"outages"`  // connections lost since the service started
+	Alerting          bool       `json:"alerting"` // an outage alert was raised and not resolved yet
+	Subscriptions     int        `json:"subscriptions"`
+}
+
+// outageAlert is the JSON posted to the alert webhook
+type outageAlert struct {
+	Service                string    `json:"service"`
+	Event                  string    `json:"event"`
+	Severity               string    `json:"severity"`
+	DisconnectedForSeconds float64   `json:"disconnected_for_seconds"`
+	ReconnectAttempts      int       `json:"reconnect_attempts"`
+	Subscriptions          int       `json:"subscriptions"` // wallets waiting for their logs subscription
+	At                     time.Time `json:"at"`
+}
+
+// Status reports the connection state, the reconnect progress and whether an outage alert is open
+func (q *quickNodeService) Status() *ConnectionStatus {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	status := &ConnectionStatus{
+		Connected:         q.isConnected,
+		ReconnectAttempts: q.reconnectAttempts,
+		GaveUp:            q.gaveUp,
+		Outages:           q.outages,
+		Alerting:          q.outageAlerted,
+		Subscriptions:     len(q.walletNotificationConsumers),
+	}
+	if !q.disconnectedSince.IsZero() {
+		disconnectedSince := q.disconnectedSince
+		status.DisconnectedSince = &disconnectedSince
+	}
+	if !q.lastSeen.IsZero() {
+		lastSeen := q.lastSeen
+		status.LastActivity = &lastSeen
+	}
+	return status
+}
+
+// Reconnect closes the current connection, if any, and reconnects starting from the first backoff step
+func (q *quickNodeService) Reconnect() {
+	q.mu.Lock()
+	q.reconnectAttempts = 0
+	q.gaveUp = false
+	q.startMonitors()
+	conn := q.conn
+	if !q.isConnected {
+		if q.disconnectedSince.IsZero() {
+			q.disconnectedSince = time.Now()
+		}
+		conn = nil
+	}
+	q.mu.Unlock()
+
+	q.logger.Info("Reconnecting to QuickNode with a fresh attempt budget")
+	if conn != nil {
+		// The read pump fails on the closed connection and triggers the reconnect
+		conn.Close()
+		return
+	}
+	q.triggerReconnect()
+}
+
+// startMonitors starts the reconnect and outage monitors once; the caller holds the lock
+func (q *quickNodeService) startMonitors() {
+	if q.monitoring {
+		return
+	}
+	q.monitoring = true
+	go q.connectionMonitor()
+	go q.outageMonitor()
+}
+
+// outageMonitor raises a critical alert once the connection has been down longer than the alert threshold
+func (q *quickNodeService) outageMonitor() {
+	alertAfter := q.config.Reconnect.AlertAfter
+	if alertAfter <= 0 {
+		alertAfter = defaultOutageAlertAfter
+	}
+	ticker := time.NewTicker(outageCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stopChan:
+			return
+		case <-ticker.C:
+			q.mu.Lock()
+			down := !q.isConnected && !q.disconnectedSince.IsZero() && !q.outageAlerted
+			disconnectedFor := time.Since(q.disconnectedSince)
+			if down && disconnectedFor > alertAfter {
+				q.outageAlerted = true
+				q.mu.Unlock()
+				q.sendOutageAlert(outageEventDown, disconnectedFor)
+				continue
+			}
+			q.mu.Unlock()
+		}
+	}
+}
+
+// sendOutageAlert logs the alert and posts it to the alert webhook when one is configured
+func (q *quickNodeService) sendOutageAlert(event string, disconnectedFor time.Duration) {
+	status := q.Status()
+	alert := &outageAlert{
+		Service:                "quicknode",
+		Event:                  event,
+		Severity:               "critical",
+		DisconnectedForSeconds: disconnectedFor.Seconds(),
+		ReconnectAttempts:      status.ReconnectAttempts,
+		Subscriptions:          status.Subscriptions,
+		At:                     time.Now().UTC(),
+	}
+	if event == outageEventResolved {
+		alert.Severity = "info"
+	}
+
+	entry := q.logger.WithFields(logrus.Fields{
+		"alert":            event,
+		"severity":         alert.Severity,
+		"disconnected_for": disconnectedFor.Round(time.Second),
+		"attempts":         alert.ReconnectAttempts,
+	})
+	if event == outageEventResolved {
+		entry.Info("QuickNode connection restored")
+	} else {
+		entry.Error("QuickNode connection is down")
+	}
+
+	if q.config.Reconnect.AlertWebhookURL == "" {
+		return
+	}
+	if err := q.postOutageAlert(alert); err != nil {
+		q.logger.WithFields(logrus.Fields{
+			"alert": event,
+			"error": err,
+		}).Error("Failed to deliver QuickNode outage alert")
+	}
+}
+
+func (q *quickNodeService) postOutageAlert(alert *outageAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), outageAlertTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, q.config.Reconnect.AlertWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "solana-wallet-service/1.0")
+
+	resp, err := q.alertClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
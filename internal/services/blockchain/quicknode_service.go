@@ -3,6 +3,7 @@ package blockchain
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"sync"
 	"time"
@@ -20,8 +21,18 @@ type QuickNodeService interface {
 	UnsubscribeWalletLogs(walletAddress string) error
 	IsConnected() bool
 	GetActiveSubscriptions() map[string]string
+	LastActivity() time.Time     // when the connection last delivered a message or pong
+	OnReconnect(listener func()) // called after a reconnect once subscriptions were sent again
+	Status() *ConnectionStatus
+	Reconnect() // drops the connection and reconnects with a fresh attempt budget, also after giving up
 }
 
+// Liveness defaults: the connection is recycled when nothing, not even a pong, arrived within the timeout
+const (
+	defaultLivenessTimeout = 2 * time.Minute
+	defaultPingInterval    = 54 * time.Second
+)
+
 // LogConsumer defines callback for processing wallet logs
 type LogConsumer func(notification *LogsNotification) error
 
@@ -33,6 +44,19 @@ type quickNodeService struct {
 	isConnected                 bool
 	reconnectAttempts           int
 	maxReconnectAttempts        int
+	reconnectForever            bool
+	maxReconnectBackoff         time.Duration
+	livenessTimeout             time.Duration
+	lastSeen                    time.Time // last message or pong on the current connection
+	reconnectListeners          []func()
+	
+	// Outage tracking
+	disconnectedSince           time.Time // zero while connected
+	gaveUp                      bool
+	outages                     int
+	outageAlerted               bool
+	alertClient                 *http.Client
+	monitoring                  bool
 	
 	// Subscription management
 	pendingSubscriptions        map[string]*SubscriptionRequest  // requestId -> request
@@ -88,16 +112,33 @@ type LogsNotification struct {
 
 // NewQuickNodeService creates a new QuickNode service instance
 func NewQuickNodeService(config *config.QuickNodeConfig, logger *logrus.Logger) QuickNodeService {
+	livenessTimeout := config.LivenessTimeout
+	if livenessTimeout <= 0 {
+		livenessTimeout = defaultLivenessTimeout
+	}
+	maxReconnectAttempts := config.Reconnect.MaxAttempts
+	if maxReconnectAttempts <= 0 {
+		maxReconnectAttempts = defaultMaxReconnectAttempts
+	}
+	maxReconnectBackoff := config.Reconnect.MaxBackoff
+	if maxReconnectBackoff <= 0 {
+		maxReconnectBackoff = defaultMaxReconnectBackoff
+	}
+	
 	return &quickNodeService{
 		config:                      config,
 		logger:                      logger,
-		maxReconnectAttempts:        10,
+		maxReconnectAttempts:        maxReconnectAttempts,
+		reconnectForever:            config.Reconnect.Forever,
+		maxReconnectBackoff:         maxReconnectBackoff,
+		livenessTimeout:             livenessTimeout,
+		alertClient:                 &http.Client{Timeout: outageAlertTimeout},
 		pendingSubscriptions:        make(map[string]*SubscriptionRequest),
 		activeSubscriptionsByQnId:   make(map[string]string),
 		activeQnIdByWallet:          make(map[string]string),
 		walletNotificationConsumers: make(map[string]LogConsumer),
 		stopChan:                    make(chan bool),
-		reconnectChan:               make(chan bool),
+		reconnectChan:               make(chan bool, 1),
 	}
 }
 
@@ -125,19 +166,40 @@ func (q *quickNodeService) Connect() error {
 		HandshakeTimeout: 30 * time.Second,
 	}
 	
+	// The monitors outlive single connections, so a failed first connect can still be retried
+	q.startMonitors()
+	
 	conn, _, err := dialer.Dial(u.String(), headers)
 	if err != nil {
+		if q.disconnectedSince.IsZero() {
+			q.disconnectedSince = time.Now()
+		}
 		return fmt.Errorf("failed to connect to QuickNode: %w", err)
 	}
 	
+	if q.outageAlerted {
+		go q.sendOutageAlert(outageEventResolved, time.Since(q.disconnectedSince))
+	}
 	q.conn = conn
 	q.isConnected = true
 	q.reconnectAttempts = 0
+	q.disconnectedSince = time.Time{}
+	q.gaveUp = false
+	q.outageAlerted = false
+	q.lastSeen = time.Now()
+	conn.SetPongHandler(func(string) error {
+		q.markSeen()
+		return nil
+	})
+	
+	// Subscription IDs belong to the previous connection; consumers stay so subscriptions can be sent again
+	q.pendingSubscriptions = make(map[string]*SubscriptionRequest)
+	q.activeSubscriptionsByQnId = make(map[string]string)
+	q.activeQnIdByWallet = make(map[string]string)
 	
 	// Start message handling goroutines
 	go q.readPump()
 	go q.writePump()
-	go q.connectionMonitor()
 	
 	q.logger.Info("Connected to QuickNode WebSocket")
 	return nil
@@ -191,7 +253,7 @@ func (q *quickNodeService) SubscribeWalletLogs(walletAddress string, consumer Lo
 				"mentions": []string{walletAddress},
 			},
 			map[string]interface{}{
-				"commitment": "confirmed",
+				"commitment": ResolveCommitments(q.config.Commitment).Subscription,
 			},
 		},
 	}
@@ -278,10 +340,34 @@ func (q *quickNodeService) GetActiveSubscriptions() map[string]string {
 	return result
 }
 
+// LastActivity returns when the current connection last delivered a message or answered a ping
+func (q *quickNodeService) LastActivity() time.Time {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.lastSeen
+}
+
+// OnReconnect registers a listener for restored connections, e.g. to verify subscriptions
+func (q *quickNodeService) OnReconnect(listener func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.reconnectListeners = append(q.reconnectListeners, listener)
+}
+
+func (q *quickNodeService) markSeen() {
+	q.mu.Lock()
+	q.lastSeen = time.Now()
+	q.mu.Unlock()
+}
+
 // readPump handles incoming WebSocket messages
 func (q *quickNodeService) readPump() {
 	defer func() {
 		q.mu.Lock()
+		if q.isConnected {
+			q.disconnectedSince = time.Now()
+			q.outages++
+		}
 		q.isConnected = false
 		q.mu.Unlock()
 		q.triggerReconnect()
@@ -300,14 +386,20 @@ func (q *quickNodeService) readPump() {
 				return
 			}
 			
+			q.markSeen()
 			q.handleMessage(message)
 		}
 	}
 }
 
-// writePump handles outgoing WebSocket messages
+// writePump handles outgoing WebSocket messages and closes connections that went silent; the read pump then
+// fails and triggers the reconnect
 func (q *quickNodeService) writePump() {
-	ticker := time.NewTicker(54 * time.Second)
+	pingInterval := defaultPingInterval
+	if q.livenessTimeout/2 < pingInterval {
+		pingInterval = q.livenessTimeout / 2
+	}
+	ticker := time.NewTicker(pingInterval)
 	defer ticker.Stop()
 	
 	for {
@@ -325,7 +417,16 @@ func (q *quickNodeService) writePump() {
 					return
 				}
 			}
+			idle := time.Since(q.lastSeen)
+			conn := q.conn
 			q.mu.Unlock()
+			
+			// A half-open connection still accepts pings, but neither pongs nor notifications come back
+			if idle > q.livenessTimeout && conn != nil {
+				q.logger.WithField("idle", idle.Round(time.Second)).Warn("QuickNode connection went silent, reconnecting")
+				conn.Close()
+				return
+			}
 		}
 	}
 }
@@ -447,23 +548,35 @@ func (q *quickNodeService) attemptReconnect() {
 		return
 	}
 	
-	if q.reconnectAttempts >= q.maxReconnectAttempts {
-		q.logger.Error("Max reconnect attempts reached, giving up")
+	if q.gaveUp {
 		q.mu.Unlock()
 		return
 	}
+	if !q.reconnectForever && q.reconnectAttempts >= q.maxReconnectAttempts {
+		q.gaveUp = true
+		q.outageAlerted = true
+		disconnectedFor := time.Since(q.disconnectedSince)
+		q.mu.Unlock()
+		q.logger.WithField("attempts", q.maxReconnectAttempts).Error("Max reconnect attempts reached, giving up")
+		q.sendOutageAlert(outageEventGaveUp, disconnectedFor)
+		return
+	}
 	
 	q.reconnectAttempts++
+	attempt := q.reconnectAttempts
 	q.mu.Unlock()
 	
 	// Exponential backoff
-	backoff := time.Duration(q.reconnectAttempts) * time.Second
-	if backoff > 30*time.Second {
-		backoff = 30 * time.Second
+	backoff := time.Second
+	for i := 1; i < attempt && backoff < q.maxReconnectBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > q.maxReconnectBackoff {
+		backoff = q.maxReconnectBackoff
 	}
 	
 	q.logger.WithFields(logrus.Fields{
-		"attempt": q.reconnectAttempts,
+		"attempt": attempt,
 		"backoff": backoff,
 	}).Info("Attempting to reconnect to QuickNode")
 	
@@ -477,6 +590,13 @@ func (q *quickNodeService) attemptReconnect() {
 	
 	// Restore previous subscriptions
 	q.restoreSubscriptions()
+	
+	q.mu.RLock()
+	listeners := q.reconnectListeners
+	q.mu.RUnlock()
+	for _, listener := range listeners {
+		go listener()
+	}
 }
 
 // restoreSubscriptions restores all active subscriptions after reconnection
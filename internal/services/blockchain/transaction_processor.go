@@ -3,28 +3,52 @@ package blockchain
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/emiyaio/solana-wallet-service/internal/config"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/label"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
+)
+
+// ErrTransactionNotFound is returned for transactions that have not reached the requested commitment, or do not exist
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// Notifications at processed commitment may arrive before the transaction can be fetched at the read commitment
+const (
+	notificationFetchAttempts = 5
+	notificationFetchDelay    = time.Second
 )
 
 // TransactionProcessor processes and analyzes Solana transactions
 type TransactionProcessor interface {
-	ProcessLogNotification(notification *LogsNotification) (*AnalyzedWalletAction, error)
-	GetTransactionDetails(signature string) (*SolanaTransactionResponse, error)
-	AnalyzeTransaction(tx *SolanaTransactionResponse) (*AnalyzedWalletAction, error)
+	ProcessLogNotification(ctx context.Context, notification *LogsNotification) (*AnalyzedWalletAction, error)
+	GetTransactionDetails(signature string) (*SolanaTransactionResponse, error) // at the read commitment
+	GetTransactionDetailsAt(signature string, commitment Commitment) (*SolanaTransactionResponse, error)
+	GetSignatureStatuses(signatures []string) ([]*SignatureStatus, error)
+	Commitments() Commitments
+	GetSignaturesForAddress(address string, limit int) ([]SignatureInfo, error)
+	FindCreationSignature(address string, maxPages int) (*SignatureInfo, error)
+	GetWalletBalances(address string) (*WalletBalances, error)
+	AnalyzeTransaction(ctx context.Context, tx *SolanaTransactionResponse) (*AnalyzedWalletAction, error)
 	IsRelevantTransaction(logs []string) bool
+	Platforms() []string // names of the known DEX platforms, sorted
 }
 
 type transactionProcessor struct {
 	config      *config.QuickNodeConfig
 	httpClient  *http.Client
 	tokenRepo   repositories.TokenRepository
+	labelRepo   repositories.WalletLabelRepository
+	prices      PriceAggregator
+	commitments Commitments
 	logger      *logrus.Logger
 	
 	// Known DEX program IDs
@@ -37,6 +61,7 @@ type SolanaTransactionResponse struct {
 	Meta            TransactionMeta          `json:"meta"`
 	Slot            int64                    `json:"slot"`
 	Transaction     TransactionInfo          `json:"transaction"`
+	Commitment      Commitment               `json:"-"` // level the transaction was fetched at
 }
 
 type TransactionMeta struct {
@@ -102,6 +127,19 @@ type AnalyzedWalletAction struct {
 	LogMessages      []string               `json:"log_messages"`
 	Success          bool                   `json:"success"`
 	Fee              int64                  `json:"fee"`
+	PriorityFee      int64                  `json:"priority_fee"`               // lamports paid above the base fee
+	SlippagePercent  *float64               `json:"slippage_percent,omitempty"` // execution price against market price, nil when unknown
+	ValueUSD         float64                `json:"value_usd"` // swap value, taken from the quote side when there is one
+	Labels           []string               `json:"labels,omitempty"` // known entity labels of the wallet
+	Provisional      bool                   `json:"provisional"`      // fetched before finalization; the trade may still be rolled back
+}
+
+// SignatureInfo represents a single entry returned by getSignaturesForAddress
+type SignatureInfo struct {
+	Signature string      `json:"signature"`
+	Slot      int64       `json:"slot"`
+	BlockTime int64       `json:"blockTime"`
+	Err       interface{} `json:"err"`
 }
 
 type TokenAmount struct {
@@ -115,6 +153,10 @@ type TokenAmount struct {
 func NewTransactionProcessor(
 	config *config.QuickNodeConfig,
 	tokenRepo repositories.TokenRepository,
+	labelRepo repositories.WalletLabelRepository,
+	prices PriceAggregator,
+	usageTracker *usage.Tracker,
+	apiStats *apistats.Registry,
 	logger *logrus.Logger,
 ) TransactionProcessor {
 	// Initialize DEX program mappings
@@ -133,15 +175,18 @@ func NewTransactionProcessor(
 	
 	return &transactionProcessor{
 		config:      config,
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		httpClient:  &http.Client{Timeout: 30 * time.Second, Transport: usageTracker.Transport(usage.ProviderQuickNode, apiStats.Transport("quicknode", nil))},
 		tokenRepo:   tokenRepo,
+		labelRepo:   labelRepo,
+		prices:      prices,
+		commitments: ResolveCommitments(config.Commitment),
 		logger:      logger,
 		dexPrograms: dexPrograms,
 	}
 }
 
 // ProcessLogNotification processes a log notification from QuickNode
-func (tp *transactionProcessor) ProcessLogNotification(notification *LogsNotification) (*AnalyzedWalletAction, error) {
+func (tp *transactionProcessor) ProcessLogNotification(ctx context.Context, notification *LogsNotification) (*AnalyzedWalletAction, error) {
 	// Pre-filter: check if logs contain relevant DEX activity
 	if !tp.IsRelevantTransaction(notification.Params.Result.Value.Logs) {
 		return nil, nil // Not a relevant transaction
@@ -149,14 +194,18 @@ func (tp *transactionProcessor) ProcessLogNotification(notification *LogsNotific
 	
 	signature := notification.Params.Result.Value.Signature
 	
-	// Get full transaction details
+	// Get full transaction details, waiting for the read commitment if the notification came earlier
 	txDetails, err := tp.GetTransactionDetails(signature)
+	for attempt := 1; errors.Is(err, ErrTransactionNotFound) && attempt < notificationFetchAttempts; attempt++ {
+		time.Sleep(notificationFetchDelay)
+		txDetails, err = tp.GetTransactionDetails(signature)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction details: %w", err)
 	}
 	
 	// Analyze transaction
-	action, err := tp.AnalyzeTransaction(txDetails)
+	action, err := tp.AnalyzeTransaction(ctx, txDetails)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze transaction: %w", err)
 	}
@@ -170,8 +219,18 @@ func (tp *transactionProcessor) ProcessLogNotification(notification *LogsNotific
 	return action, nil
 }
 
+// Commitments returns the commitment levels in use
+func (tp *transactionProcessor) Commitments() Commitments {
+	return tp.commitments
+}
+
 // GetTransactionDetails fetches full transaction details from QuickNode RPC
 func (tp *transactionProcessor) GetTransactionDetails(signature string) (*SolanaTransactionResponse, error) {
+	return tp.GetTransactionDetailsAt(signature, tp.commitments.Read)
+}
+
+// GetTransactionDetailsAt fetches transaction details only once the transaction reached the given commitment
+func (tp *transactionProcessor) GetTransactionDetailsAt(signature string, commitment Commitment) (*SolanaTransactionResponse, error) {
 	requestBody := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      1,
@@ -180,7 +239,7 @@ func (tp *transactionProcessor) GetTransactionDetails(signature string) (*Solana
 			signature,
 			map[string]interface{}{
 				"encoding":                       "json",
-				"commitment":                     "confirmed",
+				"commitment":                     commitment,
 				"maxSupportedTransactionVersion": 0,
 			},
 		},
@@ -219,14 +278,99 @@ func (tp *transactionProcessor) GetTransactionDetails(signature string) (*Solana
 	}
 	
 	if rpcResponse.Result == nil {
-		return nil, fmt.Errorf("transaction not found")
+		return nil, ErrTransactionNotFound
+	}
+	rpcResponse.Result.Commitment = commitment
+	
+	return rpcResponse.Result, nil
+}
+
+// GetSignaturesForAddress fetches the most recent transaction signatures for an address
+func (tp *transactionProcessor) GetSignaturesForAddress(address string, limit int) ([]SignatureInfo, error) {
+	return tp.getSignatures(address, limit, "")
+}
+
+// FindCreationSignature walks an address's history backwards and returns its oldest signature.
+// It gives up after maxPages pages of 1000 signatures, which bounds the cost for busy accounts.
+func (tp *transactionProcessor) FindCreationSignature(address string, maxPages int) (*SignatureInfo, error) {
+	var oldest *SignatureInfo
+	before := ""
+	for page := 0; page < maxPages; page++ {
+		signatures, err := tp.getSignatures(address, 1000, before)
+		if err != nil {
+			return nil, err
+		}
+		if len(signatures) == 0 {
+			return oldest, nil
+		}
+		
+		oldest = &signatures[len(signatures)-1]
+		if len(signatures) < 1000 {
+			return oldest, nil
+		}
+		before = oldest.Signature
+	}
+	
+	return nil, fmt.Errorf("creation signature for %s not found within %d pages", address, maxPages)
+}
+
+// getSignatures fetches a page of signatures, optionally starting before a given signature
+func (tp *transactionProcessor) getSignatures(address string, limit int, before string) ([]SignatureInfo, error) {
+	options := map[string]interface{}{
+		"limit":      limit,
+		"commitment": tp.commitments.Read,
+	}
+	if before != "" {
+		options["before"] = before
+	}
+	
+	requestBody := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getSignaturesForAddress",
+		"params": []interface{}{
+			address,
+			options,
+		},
+	}
+	
+	reqBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	
+	req, err := http.NewRequest("POST", tp.config.HTTPUrl, strings.NewReader(string(reqBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tp.config.APIKey)
+	
+	resp, err := tp.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	var rpcResponse struct {
+		Result []SignatureInfo `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+	
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", rpcResponse.Error.Message)
 	}
 	
 	return rpcResponse.Result, nil
 }
 
 // AnalyzeTransaction analyzes a Solana transaction and extracts wallet actions
-func (tp *transactionProcessor) AnalyzeTransaction(tx *SolanaTransactionResponse) (*AnalyzedWalletAction, error) {
+func (tp *transactionProcessor) AnalyzeTransaction(ctx context.Context, tx *SolanaTransactionResponse) (*AnalyzedWalletAction, error) {
 	// Determine platform from program IDs
 	platform := tp.identifyPlatform(tx)
 	
@@ -238,10 +382,12 @@ func (tp *transactionProcessor) AnalyzeTransaction(tx *SolanaTransactionResponse
 	
 	// Analyze token balance changes
 	inputToken, outputToken, transactionType := tp.analyzeTokenBalanceChanges(
+		ctx,
 		tx.Meta.PreTokenBalances,
 		tx.Meta.PostTokenBalances,
 		walletAddress,
 	)
+	inputToken, outputToken, transactionType = nativeSOLSide(tx, inputToken, outputToken, transactionType)
 	
 	// Check transaction success
 	success := tx.Meta.Err == nil
@@ -258,6 +404,23 @@ func (tp *transactionProcessor) AnalyzeTransaction(tx *SolanaTransactionResponse
 		LogMessages:     tx.Meta.LogMessages,
 		Success:         success,
 		Fee:             tx.Meta.Fee,
+		PriorityFee:     priorityFee(tx),
+		ValueUSD:        tp.swapValueUSD(ctx, inputToken, outputToken),
+		Provisional:     tx.Commitment != CommitmentFinalized,
+	}
+	action.SlippagePercent = tp.executionSlippage(ctx, action)
+	
+	// Attach known entity labels of the acting wallet
+	if walletAddress != "" {
+		labels, err := label.LookupLabels(ctx, tp.labelRepo, []string{walletAddress})
+		if err != nil {
+			tp.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"wallet": walletAddress,
+			}).Warn("Failed to look up wallet labels")
+		} else {
+			action.Labels = labels[walletAddress]
+		}
 	}
 	
 	return action, nil
@@ -289,6 +452,19 @@ func (tp *transactionProcessor) IsRelevantTransaction(logs []string) bool {
 	return false
 }
 
+func (tp *transactionProcessor) Platforms() []string {
+	seen := make(map[string]bool, len(tp.dexPrograms))
+	platforms := make([]string, 0, len(tp.dexPrograms))
+	for _, platform := range tp.dexPrograms {
+		if !seen[platform] {
+			seen[platform] = true
+			platforms = append(platforms, platform)
+		}
+	}
+	sort.Strings(platforms)
+	return platforms
+}
+
 // identifyPlatform identifies the DEX platform from transaction
 func (tp *transactionProcessor) identifyPlatform(tx *SolanaTransactionResponse) string {
 	// Check instructions for known program IDs
@@ -319,6 +495,7 @@ func (tp *transactionProcessor) identifyPlatform(tx *SolanaTransactionResponse)
 
 // analyzeTokenBalanceChanges analyzes pre/post token balances to determine swap details
 func (tp *transactionProcessor) analyzeTokenBalanceChanges(
+	ctx context.Context,
 	preBalances, postBalances []TokenBalance,
 	walletAddress string,
 ) (*TokenAmount, *TokenAmount, string) {
@@ -381,39 +558,86 @@ func (tp *transactionProcessor) analyzeTokenBalanceChanges(
 		}
 	}
 	
-	// Determine transaction type
+	// Determine transaction type; paying a quote asset for a token is a buy, the reverse a sell
 	transactionType := "swap"
 	if inputToken != nil && outputToken != nil {
-		// Check if SOL is involved
-		solMint := "So11111111111111111111111111111111111111112" // Wrapped SOL
-		if inputToken.Mint == solMint {
+		inputIsQuote := IsQuoteAsset(inputToken.Mint)
+		outputIsQuote := IsQuoteAsset(outputToken.Mint)
+		if inputIsQuote && !outputIsQuote {
 			transactionType = "buy"
-		} else if outputToken.Mint == solMint {
+		} else if outputIsQuote && !inputIsQuote {
 			transactionType = "sell"
 		}
 	}
 	
 	// Enrich with token symbols
-	tp.enrichTokenSymbols(inputToken, outputToken)
+	tp.enrichTokenSymbols(ctx, inputToken, outputToken)
+	
+	return inputToken, outputToken, transactionType
+}
+
+// nativeSOLSide fills in the missing side of a token trade settled in native SOL, which moves the signer's
+// lamports rather than a token balance. The side is reported as wrapped SOL so it is priced like wSOL.
+func nativeSOLSide(tx *SolanaTransactionResponse, inputToken, outputToken *TokenAmount, transactionType string) (*TokenAmount, *TokenAmount, string) {
+	if (inputToken == nil) == (outputToken == nil) || len(tx.Meta.PreBalances) == 0 || len(tx.Meta.PostBalances) == 0 {
+		return inputToken, outputToken, transactionType
+	}
+	
+	// The signer pays the fee, which is not part of the trade
+	lamports := tx.Meta.PostBalances[0] - tx.Meta.PreBalances[0] + tx.Meta.Fee
+	sol := &TokenAmount{
+		Mint:     wrappedSOLMint,
+		Decimals: 9,
+		Symbol:   quoteAssets[wrappedSOLMint].Symbol,
+	}
 	
+	if outputToken != nil && !IsQuoteAsset(outputToken.Mint) && lamports < 0 {
+		sol.Amount = float64(-lamports) / 1e9
+		return sol, outputToken, "buy"
+	}
+	if inputToken != nil && !IsQuoteAsset(inputToken.Mint) && lamports > 0 {
+		sol.Amount = float64(lamports) / 1e9
+		return inputToken, sol, "sell"
+	}
 	return inputToken, outputToken, transactionType
 }
 
 // enrichTokenSymbols adds symbol information to tokens
-func (tp *transactionProcessor) enrichTokenSymbols(tokens ...*TokenAmount) {
+func (tp *transactionProcessor) enrichTokenSymbols(ctx context.Context, tokens ...*TokenAmount) {
 	for _, token := range tokens {
 		if token == nil {
 			continue
 		}
 		
 		// Try to get token info from database
-		if tokenInfo, err := tp.tokenRepo.GetByMintAddress(context.Background(), token.Mint); err == nil && tokenInfo != nil {
+		if tokenInfo, err := tp.tokenRepo.GetByMintAddress(ctx, token.Mint); err == nil && tokenInfo != nil {
 			token.Symbol = tokenInfo.Symbol
 		} else {
-			// Special case for SOL
-			if token.Mint == "So11111111111111111111111111111111111111112" {
-				token.Symbol = "SOL"
+			// Quote assets are known without a token record
+			if quote, ok := quoteAssets[token.Mint]; ok {
+				token.Symbol = quote.Symbol
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+// swapValueUSD values a swap by its quote side, falling back to whichever side has a known price
+func (tp *transactionProcessor) swapValueUSD(ctx context.Context, inputToken, outputToken *TokenAmount) float64 {
+	sides := []*TokenAmount{inputToken, outputToken}
+	
+	for _, side := range sides {
+		if side != nil && IsQuoteAsset(side.Mint) {
+			if value, ok := tp.prices.ValueUSD(ctx, side); ok {
+				return value
+			}
+		}
+	}
+	for _, side := range sides {
+		if side != nil && !IsQuoteAsset(side.Mint) {
+			if value, ok := tp.prices.ValueUSD(ctx, side); ok {
+				return value
+			}
+		}
+	}
+	return 0
+}
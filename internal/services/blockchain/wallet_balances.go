@@ -0,0 +1,129 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SPL token program IDs whose accounts make up a wallet's token holdings
+var tokenProgramIDs = []string{
+	"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+	"TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb",
+}
+
+const lamportsPerSOL = 1e9
+
+// WalletBalances holds the native and SPL token balances of a wallet
+type WalletBalances struct {
+	WalletAddress string        `json:"wallet_address"`
+	SOLBalance    float64       `json:"sol_balance"`
+	Tokens        []TokenAmount `json:"tokens"`
+}
+
+// GetWalletBalances fetches the SOL balance and all non-empty token accounts of a wallet
+func (tp *transactionProcessor) GetWalletBalances(address string) (*WalletBalances, error) {
+	var balance struct {
+		Value int64 `json:"value"`
+	}
+	if err := tp.callRPC("getBalance", []interface{}{
+		address,
+		map[string]interface{}{"commitment": tp.commitments.Read},
+	}, &balance); err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	balances := &WalletBalances{
+		WalletAddress: address,
+		SOLBalance:    float64(balance.Value) / lamportsPerSOL,
+	}
+
+	for _, programID := range tokenProgramIDs {
+		var accounts struct {
+			Value []struct {
+				Account struct {
+					Data struct {
+						Parsed struct {
+							Info struct {
+								Mint        string `json:"mint"`
+								TokenAmount struct {
+									UIAmount float64 `json:"uiAmount"`
+									Decimals int     `json:"decimals"`
+								} `json:"tokenAmount"`
+							} `json:"info"`
+						} `json:"parsed"`
+					} `json:"data"`
+				} `json:"account"`
+			} `json:"value"`
+		}
+		if err := tp.callRPC("getTokenAccountsByOwner", []interface{}{
+			address,
+			map[string]interface{}{"programId": programID},
+			map[string]interface{}{"encoding": "jsonParsed", "commitment": tp.commitments.Read},
+		}, &accounts); err != nil {
+			return nil, fmt.Errorf("failed to get token accounts: %w", err)
+		}
+
+		for _, account := range accounts.Value {
+			info := account.Account.Data.Parsed.Info
+			if info.TokenAmount.UIAmount == 0 {
+				continue
+			}
+			balances.Tokens = append(balances.Tokens, TokenAmount{
+				Mint:     info.Mint,
+				Amount:   info.TokenAmount.UIAmount,
+				Decimals: info.TokenAmount.Decimals,
+			})
+		}
+	}
+
+	return balances, nil
+}
+
+// callRPC performs a JSON-RPC request against the QuickNode HTTP endpoint and decodes its result
+func (tp *transactionProcessor) callRPC(method string, params []interface{}, result interface{}) error {
+	requestBody := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	}
+
+	reqBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", tp.config.HTTPUrl, strings.NewReader(string(reqBytes)))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tp.config.APIKey)
+
+	resp, err := tp.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResponse struct {
+		Result json.RawMessage `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResponse); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if rpcResponse.Error != nil {
+		return fmt.Errorf("RPC error: %s", rpcResponse.Error.Message)
+	}
+
+	if err := json.Unmarshal(rpcResponse.Result, result); err != nil {
+		return fmt.Errorf("failed to decode result: %w", err)
+	}
+
+	return nil
+}
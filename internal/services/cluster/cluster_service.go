@@ -0,0 +1,436 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/label"
+)
+
+const (
+	// Maximum signature pages (1000 each) scanned when searching for a wallet's funding transaction
+	fundingMaxSignaturePages = 5
+	// How long a scan stays fresh before the heuristics are rerun
+	scanTTL = 24 * time.Hour
+	// Funders of more wallets than this are treated as distributors (faucets, payout wallets), not as one entity
+	maxFundedWallets = 100
+	// Trades by two wallets count as synchronized when they are this close together
+	syncTradeWindow = 60 * time.Second
+	// How far back synchronized trades are searched
+	syncLookback = 30 * 24 * time.Hour
+	// Synchronized trades must span at least this many tokens to rule out coincidence
+	syncMinTokens    = 2
+	syncTradersLimit = 50
+	// Links at or above this confidence put both wallets in the same cluster
+	clusterMinConfidence = 0.5
+	// Wallets scanned per ScanActiveWallets run, which bounds the RPC cost
+	scanBatchSize = 50
+)
+
+// Confidence of each heuristic; synchronized trades grow with the number of shared tokens
+const (
+	fundedByConfidence     = 0.5
+	commonFunderConfidence = 0.7
+	syncBaseConfidence     = 0.3
+	syncTokenConfidence    = 0.1
+	syncMaxConfidence      = 0.9
+)
+
+// ClusterService links wallets likely controlled by the same entity and groups them into clusters
+type ClusterService interface {
+	ScanWallet(ctx context.Context, walletAddress string) error
+	ScanActiveWallets(ctx context.Context) (int, error)
+	GetRelatedWallets(ctx context.Context, walletAddress string, refresh bool) (*RelatedWalletsResult, error)
+}
+
+type clusterService struct {
+	clusterRepo          repositories.ClusterRepository
+	transactionRepo      repositories.TransactionRepository
+	labelRepo            repositories.WalletLabelRepository
+	transactionProcessor blockchain.TransactionProcessor
+	logger               *logrus.Logger
+}
+
+// NewClusterService creates a new wallet cluster service instance
+func NewClusterService(
+	clusterRepo repositories.ClusterRepository,
+	transactionRepo repositories.TransactionRepository,
+	labelRepo repositories.WalletLabelRepository,
+	transactionProcessor blockchain.TransactionProcessor,
+	logger *logrus.Logger,
+) ClusterService {
+	return &clusterService{
+		clusterRepo:          clusterRepo,
+		transactionRepo:      transactionRepo,
+		labelRepo:            labelRepo,
+		transactionProcessor: transactionProcessor,
+		logger:               logger,
+	}
+}
+
+// RelatedWalletsResult lists the wallets linked to a wallet, directly or through its cluster
+type RelatedWalletsResult struct {
+	WalletAddress string           `json:"wallet_address"`
+	FunderAddress string           `json:"funder_address,omitempty"`
+	ClusterID     *uuid.UUID       `json:"cluster_id,omitempty"`
+	ClusterSize   int              `json:"cluster_size"`
+	Related       []*RelatedWallet `json:"related"`
+	ScannedAt     time.Time        `json:"scanned_at"`
+}
+
+type RelatedWallet struct {
+	WalletAddress string                    `json:"wallet_address"`
+	Reasons       []models.WalletLinkReason `json:"reasons"`    // empty for wallets only related through the cluster
+	Confidence    float64                   `json:"confidence"` // highest confidence of the direct links
+	Evidence      []string                  `json:"evidence,omitempty"`
+	Labels        []string                  `json:"labels,omitempty"`
+}
+
+// ScanWallet runs the funding and synchronized-trade heuristics for a wallet and updates its cluster
+func (s *clusterService) ScanWallet(ctx context.Context, walletAddress string) error {
+	funding, err := s.clusterRepo.GetFunding(ctx, walletAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get wallet funding: %w", err)
+	}
+	if funding == nil {
+		funding = &models.WalletFunding{WalletAddress: walletAddress}
+	}
+
+	// Funding never changes, so it is only looked up until it has been found
+	if funding.FunderAddress == "" {
+		if err := s.resolveFunding(funding); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"wallet": walletAddress,
+			}).Warn("Failed to resolve wallet funding")
+		}
+	}
+
+	links, err := s.fundingLinks(ctx, funding)
+	if err != nil {
+		return err
+	}
+	syncLinks, err := s.synchronizedTradeLinks(ctx, walletAddress)
+	if err != nil {
+		return err
+	}
+	links = append(links, syncLinks...)
+
+	links, err = s.dropInstitutionalWallets(ctx, links)
+	if err != nil {
+		return err
+	}
+
+	clustered := []string{walletAddress}
+	for _, link := range links {
+		if link.Confidence >= clusterMinConfidence {
+			clustered = append(clustered, link.RelatedAddress)
+		}
+		if err := s.clusterRepo.SaveLink(ctx, link); err != nil {
+			return fmt.Errorf("failed to save wallet link: %w", err)
+		}
+	}
+
+	if len(clustered) > 1 {
+		clusterID, err := s.clusterRepo.MergeCluster(ctx, clustered)
+		if err != nil {
+			return fmt.Errorf("failed to merge wallet cluster: %w", err)
+		}
+		s.logger.WithFields(logrus.Fields{
+			"wallet":     walletAddress,
+			"cluster_id": clusterID,
+			"linked":     len(clustered) - 1,
+		}).Info("Wallet cluster updated")
+	}
+
+	funding.ScannedAt = time.Now()
+	if err := s.clusterRepo.SaveFunding(ctx, funding); err != nil {
+		return fmt.Errorf("failed to save wallet funding: %w", err)
+	}
+	return nil
+}
+
+// resolveFunding records the fee payer of the wallet's first transaction as its funder, if that transaction credited the wallet
+func (s *clusterService) resolveFunding(funding *models.WalletFunding) error {
+	first, err := s.transactionProcessor.FindCreationSignature(funding.WalletAddress, fundingMaxSignaturePages)
+	if err != nil {
+		return fmt.Errorf("failed to find first signature: %w", err)
+	}
+	if first == nil {
+		return nil
+	}
+
+	details, err := s.transactionProcessor.GetTransactionDetails(first.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to get funding transaction: %w", err)
+	}
+
+	keys := details.Transaction.Message.AccountKeys
+	if len(keys) == 0 || keys[0] == funding.WalletAddress {
+		return nil
+	}
+	for i, key := range keys {
+		if key != funding.WalletAddress {
+			continue
+		}
+		if i < len(details.Meta.PreBalances) && i < len(details.Meta.PostBalances) &&
+			details.Meta.PostBalances[i] > details.Meta.PreBalances[i] {
+			fundedAt := time.Unix(details.BlockTime, 0)
+			funding.FunderAddress = keys[0]
+			funding.FundingSignature = first.Signature
+			funding.FundedAt = &fundedAt
+		}
+		break
+	}
+	return nil
+}
+
+// fundingLinks links the wallet to its funder and to the other wallets the funder created
+func (s *clusterService) fundingLinks(ctx context.Context, funding *models.WalletFunding) ([]*models.WalletLink, error) {
+	if funding.FunderAddress == "" {
+		return nil, nil
+	}
+
+	links := []*models.WalletLink{{
+		WalletAddress:  funding.WalletAddress,
+		RelatedAddress: funding.FunderAddress,
+		Reason:         models.WalletLinkFundedBy,
+		Confidence:     fundedByConfidence,
+		Evidence:       fmt.Sprintf("Funded by %s in %s", funding.FunderAddress, funding.FundingSignature),
+	}}
+
+	siblings, err := s.clusterRepo.GetFundedBy(ctx, funding.FunderAddress, maxFundedWallets+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallets with the same funder: %w", err)
+	}
+	if len(siblings) > maxFundedWallets {
+		return links, nil
+	}
+
+	for _, sibling := range siblings {
+		if sibling.WalletAddress == funding.WalletAddress {
+			continue
+		}
+		links = append(links, &models.WalletLink{
+			WalletAddress:  funding.WalletAddress,
+			RelatedAddress: sibling.WalletAddress,
+			Reason:         models.WalletLinkCommonFunder,
+			Confidence:     commonFunderConfidence,
+			Evidence:       fmt.Sprintf("Both funded by %s", funding.FunderAddress),
+		})
+	}
+	return links, nil
+}
+
+// synchronizedTradeLinks links the wallet to wallets that repeatedly traded the same tokens alongside it
+func (s *clusterService) synchronizedTradeLinks(ctx context.Context, walletAddress string) ([]*models.WalletLink, error) {
+	traders, err := s.clusterRepo.FindSynchronizedTraders(ctx, walletAddress, time.Now().Add(-syncLookback), syncTradeWindow, syncMinTokens, syncTradersLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find synchronized traders: %w", err)
+	}
+
+	links := make([]*models.WalletLink, 0, len(traders))
+	for _, trader := range traders {
+		links = append(links, &models.WalletLink{
+			WalletAddress:  walletAddress,
+			RelatedAddress: trader.WalletAddress,
+			Reason:         models.WalletLinkSynchronizedTrades,
+			Confidence:     math.Min(syncBaseConfidence+syncTokenConfidence*float64(trader.SharedTokens), syncMaxConfidence),
+			Evidence: fmt.Sprintf("%d trades across %d tokens within %s of each other",
+				trader.SharedTrades, trader.SharedTokens, syncTradeWindow),
+		})
+	}
+	return links, nil
+}
+
+// dropInstitutionalWallets removes links to exchanges and market makers, which fund and trade alongside everyone
+func (s *clusterService) dropInstitutionalWallets(ctx context.Context, links []*models.WalletLink) ([]*models.WalletLink, error) {
+	if len(links) == 0 {
+		return links, nil
+	}
+
+	wallets := make([]string, 0, len(links))
+	for _, link := range links {
+		wallets = append(wallets, link.RelatedAddress)
+	}
+	labels, err := label.LookupLabels(ctx, s.labelRepo, wallets)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := links[:0]
+	for _, link := range links {
+		if isInstitutional(labels[link.RelatedAddress]) {
+			continue
+		}
+		// Siblings of an exchange-funded wallet are only related through the exchange
+		if link.Reason == models.WalletLinkCommonFunder && hasInstitutionalFunder(labels, links) {
+			continue
+		}
+		kept = append(kept, link)
+	}
+	return kept, nil
+}
+
+func hasInstitutionalFunder(labels map[string][]string, links []*models.WalletLink) bool {
+	for _, link := range links {
+		if link.Reason == models.WalletLinkFundedBy {
+			return isInstitutional(labels[link.RelatedAddress])
+		}
+	}
+	return false
+}
+
+func isInstitutional(labels []string) bool {
+	for _, l := range labels {
+		switch models.WalletLabelType(l) {
+		case models.WalletLabelExchange, models.WalletLabelMarketMaker:
+			return true
+		}
+	}
+	return false
+}
+
+// ScanActiveWallets scans wallets with recent smart money trades whose scan is missing or stale
+func (s *clusterService) ScanActiveWallets(ctx context.Context) (int, error) {
+	transactions, err := s.transactionRepo.GetRecentTransactions(ctx, 24, 1000)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get recent transactions: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var wallets []string
+	for _, tx := range transactions {
+		if !seen[tx.WalletAddress] {
+			seen[tx.WalletAddress] = true
+			wallets = append(wallets, tx.WalletAddress)
+		}
+	}
+
+	scannedAt, err := s.clusterRepo.GetScannedAt(ctx, wallets)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get wallet scan times: %w", err)
+	}
+
+	scanned := 0
+	for _, wallet := range wallets {
+		if scanned >= scanBatchSize {
+			break
+		}
+		if ctx.Err() != nil {
+			return scanned, ctx.Err()
+		}
+		if at, ok := scannedAt[wallet]; ok && time.Since(at) < scanTTL {
+			continue
+		}
+		if err := s.ScanWallet(ctx, wallet); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"wallet": wallet,
+			}).Warn("Failed to scan wallet for related wallets")
+			continue
+		}
+		scanned++
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"active":  len(wallets),
+		"scanned": scanned,
+	}).Info("Wallet cluster scan completed")
+
+	return scanned, nil
+}
+
+// GetRelatedWallets scans the wallet first if it has never been scanned, the scan is stale or refresh is set
+func (s *clusterService) GetRelatedWallets(ctx context.Context, walletAddress string, refresh bool) (*RelatedWalletsResult, error) {
+	funding, err := s.clusterRepo.GetFunding(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet funding: %w", err)
+	}
+
+	if funding == nil || refresh || time.Since(funding.ScannedAt) > scanTTL {
+		if err := s.ScanWallet(ctx, walletAddress); err != nil {
+			if funding == nil {
+				return nil, err
+			}
+			s.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"wallet": walletAddress,
+			}).Warn("Failed to rescan wallet, serving stored links")
+		}
+		if funding, err = s.clusterRepo.GetFunding(ctx, walletAddress); err != nil {
+			return nil, fmt.Errorf("failed to get wallet funding: %w", err)
+		}
+	}
+
+	links, err := s.clusterRepo.GetLinks(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet links: %w", err)
+	}
+	clusterID, members, err := s.clusterRepo.GetClusterMembers(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet cluster: %w", err)
+	}
+
+	related := make(map[string]*RelatedWallet)
+	for _, link := range links {
+		other := link.RelatedAddress
+		if other == walletAddress {
+			other = link.WalletAddress
+		}
+		entry, ok := related[other]
+		if !ok {
+			entry = &RelatedWallet{WalletAddress: other}
+			related[other] = entry
+		}
+		entry.Reasons = append(entry.Reasons, link.Reason)
+		entry.Evidence = append(entry.Evidence, link.Evidence)
+		entry.Confidence = math.Max(entry.Confidence, link.Confidence)
+	}
+	for _, member := range members {
+		if _, ok := related[member]; !ok && member != walletAddress {
+			related[member] = &RelatedWallet{WalletAddress: member, Reasons: []models.WalletLinkReason{}}
+		}
+	}
+
+	result := &RelatedWalletsResult{
+		WalletAddress: walletAddress,
+		ClusterID:     clusterID,
+		ClusterSize:   len(members),
+		Related:       make([]*RelatedWallet, 0, len(related)),
+	}
+	if funding != nil {
+		result.FunderAddress = funding.FunderAddress
+		result.ScannedAt = funding.ScannedAt
+	}
+
+	wallets := make([]string, 0, len(related))
+	for wallet, entry := range related {
+		wallets = append(wallets, wallet)
+		result.Related = append(result.Related, entry)
+	}
+	sort.Slice(result.Related, func(i, j int) bool {
+		return result.Related[i].Confidence > result.Related[j].Confidence
+	})
+
+	labels, err := label.LookupLabels(ctx, s.labelRepo, wallets)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": walletAddress,
+		}).Warn("Failed to look up labels of related wallets")
+	}
+	for _, entry := range result.Related {
+		entry.Labels = labels[entry.WalletAddress]
+	}
+
+	return result, nil
+}
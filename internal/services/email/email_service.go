@@ -0,0 +1,336 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+var (
+	ErrEmailDisabled           = errors.New("email is not configured")
+	ErrInvalidEmail            = errors.New("invalid email address")
+	ErrVerificationNotFound    = errors.New("no pending email verification")
+	ErrInvalidVerificationCode = errors.New("invalid or expired verification code")
+	ErrTooManyAttempts         = errors.New("too many verification attempts")
+	ErrVerificationCooldown    = errors.New("a verification code was sent recently")
+
+	// ErrNotSubscribed is returned when the wallet has no verified email or opted out of the category
+	ErrNotSubscribed = errors.New("wallet is not subscribed to this email")
+)
+
+const (
+	defaultVerificationTTL = 30 * time.Minute
+	verificationCooldown   = time.Minute
+	maxVerificationTries   = 5
+	verificationCodeDigits = 6
+)
+
+// EmailService renders and sends templated emails and binds verified addresses to wallets
+type EmailService interface {
+	Enabled() bool
+	StartVerification(ctx context.Context, walletAddress, address string) (*models.EmailVerification, error)
+	ConfirmVerification(ctx context.Context, walletAddress, code string) (*models.UserSettings, error)
+	Unbind(ctx context.Context, walletAddress string) (*models.UserSettings, error)
+	SendNotification(ctx context.Context, settings *models.UserSettings, n *models.Notification) error
+	SendDigest(ctx context.Context, digest *Digest, walletAddresses []string) (int, error)
+}
+
+type emailService struct {
+	sender          Sender
+	settingsRepo    repositories.UserSettingsRepository
+	verificationTTL time.Duration
+	logger          *logrus.Logger
+}
+
+// NewEmailService creates a new email service instance; without a sender nothing is sent and binding is refused
+func NewEmailService(
+	sender Sender,
+	settingsRepo repositories.UserSettingsRepository,
+	cfg *config.EmailConfig,
+	logger *logrus.Logger,
+) EmailService {
+	s := &emailService{
+		sender:          sender,
+		settingsRepo:    settingsRepo,
+		verificationTTL: cfg.VerificationTTL,
+		logger:          logger,
+	}
+	if s.verificationTTL <= 0 {
+		s.verificationTTL = defaultVerificationTTL
+	}
+	return s
+}
+
+// Digest is a generated room digest to mail to the room's members
+type Digest struct {
+	RoomName string
+	Digest   *models.RoomDigest
+	Trades   []models.DigestTrade
+	Shares   []models.DigestShare
+	Text     string // plain text form, as posted to the room
+}
+
+func (s *emailService) Enabled() bool {
+	return s.sender != nil
+}
+
+// StartVerification mails a code to the address; the address is bound to the wallet once the code is confirmed
+func (s *emailService) StartVerification(ctx context.Context, walletAddress, address string) (*models.EmailVerification, error) {
+	if !s.Enabled() {
+		return nil, ErrEmailDisabled
+	}
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != strings.TrimSpace(address) {
+		return nil, ErrInvalidEmail
+	}
+
+	pending, err := s.settingsRepo.GetEmailVerification(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email verification: %w", err)
+	}
+	if pending != nil && time.Since(pending.CreatedAt) < verificationCooldown {
+		return nil, ErrVerificationCooldown
+	}
+
+	code, err := verificationCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	now := time.Now()
+	verification := &models.EmailVerification{
+		WalletAddress: walletAddress,
+		Email:         parsed.Address,
+		CodeHash:      hashCode(code),
+		ExpiresAt:     now.Add(s.verificationTTL),
+		CreatedAt:     now,
+	}
+
+	subject := "Your verification code"
+	html, err := render(templateVerification, &verificationData{
+		layoutData: layoutData{Subject: subject, WalletAddress: walletAddress},
+		Code:       code,
+		ExpiresIn:  s.verificationTTL.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render verification email: %w", err)
+	}
+
+	// Store first, so a code that was mailed can always be confirmed
+	if err := s.settingsRepo.SaveEmailVerification(ctx, verification); err != nil {
+		return nil, fmt.Errorf("failed to save email verification: %w", err)
+	}
+	err = s.sender.Send(ctx, &Message{
+		To:      verification.Email,
+		Subject: subject,
+		HTML:    html,
+		Text:    fmt.Sprintf("Your verification code for wallet %s is %s. It expires in %s.", walletAddress, code, s.verificationTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithField("wallet", walletAddress).Info("Email verification sent")
+	return verification, nil
+}
+
+// ConfirmVerification binds the pending address to the wallet if the code matches
+func (s *emailService) ConfirmVerification(ctx context.Context, walletAddress, code string) (*models.UserSettings, error) {
+	verification, err := s.settingsRepo.GetEmailVerification(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email verification: %w", err)
+	}
+	if verification == nil {
+		return nil, ErrVerificationNotFound
+	}
+	if verification.Attempts >= maxVerificationTries {
+		return nil, ErrTooManyAttempts
+	}
+	if time.Now().After(verification.ExpiresAt) {
+		return nil, ErrInvalidVerificationCode
+	}
+	if subtle.ConstantTimeCompare([]byte(hashCode(strings.TrimSpace(code))), []byte(verification.CodeHash)) != 1 {
+		if err := s.settingsRepo.IncrementEmailVerificationAttempts(ctx, verification.ID); err != nil {
+			return nil, fmt.Errorf("failed to count verification attempt: %w", err)
+		}
+		return nil, ErrInvalidVerificationCode
+	}
+
+	settings, err := s.getSettings(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	settings.NotificationEmail = verification.Email
+	if err := s.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save user settings: %w", err)
+	}
+	if err := s.settingsRepo.DeleteEmailVerification(ctx, walletAddress); err != nil {
+		s.logger.WithError(err).WithField("wallet", walletAddress).Warn("Failed to delete confirmed email verification")
+	}
+
+	s.logger.WithField("wallet", walletAddress).Info("Email bound to wallet")
+	return settings, nil
+}
+
+// Unbind removes the wallet's email address and any pending verification
+func (s *emailService) Unbind(ctx context.Context, walletAddress string) (*models.UserSettings, error) {
+	if err := s.settingsRepo.DeleteEmailVerification(ctx, walletAddress); err != nil {
+		return nil, fmt.Errorf("failed to delete email verification: %w", err)
+	}
+	settings, err := s.getSettings(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	if settings.NotificationEmail == "" {
+		return settings, nil
+	}
+	settings.NotificationEmail = ""
+	if err := s.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save user settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SendNotification mails a notification with the template of its type
+func (s *emailService) SendNotification(ctx context.Context, settings *models.UserSettings, n *models.Notification) error {
+	if !s.Enabled() {
+		return ErrEmailDisabled
+	}
+	category := models.EmailCategory(n.Type)
+	if settings.NotificationEmail == "" || settings.EmailOptedOut(category) {
+		return ErrNotSubscribed
+	}
+
+	data := &notificationData{
+		layoutData: layoutData{
+			Subject:       n.Title,
+			WalletAddress: n.WalletAddress,
+			Category:      category,
+		},
+		Notification: n,
+	}
+	name := templateNotification
+	switch n.Type {
+	case models.NotificationTypePriceAlert:
+		var alert models.LimitWatchNotification
+		if err := json.Unmarshal([]byte(n.Data), &alert); err == nil && alert.Watch != nil {
+			data.Alert = &alert
+		}
+		name = templatePriceAlert
+	case models.NotificationTypeRoomInvite:
+		var invite RoomInvite
+		if err := json.Unmarshal([]byte(n.Data), &invite); err == nil && invite.RoomID != "" {
+			data.Invite = &invite
+		}
+		name = templateRoomInvite
+	}
+
+	html, err := render(name, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s email: %w", n.Type, err)
+	}
+	text := n.Title
+	if n.Body != "" {
+		text += "\n\n" + n.Body
+	}
+	return s.sender.Send(ctx, &Message{
+		To:      settings.NotificationEmail,
+		Subject: n.Title,
+		HTML:    html,
+		Text:    text,
+	})
+}
+
+// SendDigest mails a room digest to the given wallets that have a verified email and did not opt out of digests,
+// and returns how many were sent
+func (s *emailService) SendDigest(ctx context.Context, digest *Digest, walletAddresses []string) (int, error) {
+	if !s.Enabled() {
+		return 0, nil
+	}
+	settings, err := s.settingsRepo.GetByWallets(ctx, walletAddresses)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	date := digest.Digest.DigestDate.Format("2006-01-02")
+	subject := fmt.Sprintf("%s daily digest %s", digest.RoomName, date)
+	sent := 0
+	for _, recipient := range settings {
+		if recipient.NotificationEmail == "" || recipient.EmailOptedOut(models.EmailCategoryDigest) {
+			continue
+		}
+
+		html, err := render(templateDigest, &digestData{
+			layoutData: layoutData{
+				Subject:       subject,
+				WalletAddress: recipient.WalletAddress,
+				Category:      models.EmailCategoryDigest,
+			},
+			RoomName: digest.RoomName,
+			Date:     date,
+			Digest:   digest.Digest,
+			Trades:   digest.Trades,
+			Shares:   digest.Shares,
+		})
+		if err != nil {
+			return sent, fmt.Errorf("failed to render digest email: %w", err)
+		}
+		err = s.sender.Send(ctx, &Message{
+			To:      recipient.NotificationEmail,
+			Subject: subject,
+			HTML:    html,
+			Text:    digest.Text,
+		})
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"wallet": recipient.WalletAddress,
+			}).Warn("Failed to send digest email")
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (s *emailService) getSettings(ctx context.Context, walletAddress string) (*models.UserSettings, error) {
+	settings, err := s.settingsRepo.GetByWallet(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+	if settings == nil {
+		settings = models.NewDefaultUserSettings(walletAddress)
+	}
+	return settings, nil
+}
+
+// verificationCode returns a random numeric code
+func verificationCode() (string, error) {
+	var sb strings.Builder
+	for i := 0; i < verificationCodeDigits; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(digit.String())
+	}
+	return sb.String(), nil
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
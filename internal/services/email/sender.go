@@ -0,0 +1,134 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+)
+
+const (
+	ProviderSMTP = "smtp"
+	ProviderSES  = "ses" // Amazon SES through its SMTP interface
+
+	defaultSMTPPort = 587
+)
+
+// Message is a rendered email to one recipient
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string // plain text alternative of the HTML
+}
+
+// Sender hands messages to a mail service
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+type smtpSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSender creates the sender for the configured provider; it returns nil when email is not configured
+func NewSender(cfg *config.EmailConfig) (Sender, error) {
+	if cfg.From == "" {
+		return nil, nil
+	}
+
+	host := cfg.Host
+	switch cfg.Provider {
+	case "", ProviderSMTP:
+	case ProviderSES:
+		if host == "" {
+			if cfg.SESRegion == "" {
+				return nil, fmt.Errorf("email provider ses requires ses_region or host")
+			}
+			host = fmt.Sprintf("email-smtp.%s.amazonaws.com", cfg.SESRegion)
+		}
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", cfg.Provider)
+	}
+	if host == "" {
+		return nil, fmt.Errorf("email provider %s requires a host", ProviderSMTP)
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	sender := &smtpSender{
+		addr: fmt.Sprintf("%s:%d", host, port),
+		from: cfg.From,
+	}
+	if cfg.Username != "" {
+		sender.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+	return sender, nil
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	body, err := s.encode(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, body); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// encode builds a multipart/alternative message with the plain text part first
+func (s *smtpSender) encode(msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+	parts := multipart.NewWriter(&buf)
+
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", s.from)
+	header("To", msg.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", strings.ReplaceAll(msg.Subject, "\n", " ")))
+	header("Date", time.Now().UTC().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,77 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"shortAddress": shortAddress,
+}).ParseFS(templateFS, "templates/*.html"))
+
+// Template names, one file each under templates/
+const (
+	templateVerification = "verification.html"
+	templateDigest       = "digest.html"
+	templatePriceAlert   = "price_alert.html"
+	templateRoomInvite   = "room_invite.html"
+	templateNotification = "notification.html"
+)
+
+// layoutData is what the shared header and footer read; every template's data embeds it
+type layoutData struct {
+	Subject       string
+	WalletAddress string
+	Category      models.EmailCategory // empty for transactional mail such as verification codes
+}
+
+type verificationData struct {
+	layoutData
+	Code      string
+	ExpiresIn string
+}
+
+type digestData struct {
+	layoutData
+	RoomName string
+	Date     string
+	Digest   *models.RoomDigest
+	Trades   []models.DigestTrade
+	Shares   []models.DigestShare
+}
+
+type notificationData struct {
+	layoutData
+	Notification *models.Notification
+	Alert        *models.LimitWatchNotification // price alerts only
+	Invite       *RoomInvite                    // room invites only
+}
+
+// RoomInvite is the data of a room invite notification
+type RoomInvite struct {
+	RoomID    string `json:"room_id"`
+	RoomName  string `json:"room_name,omitempty"`
+	InvitedBy string `json:"invited_by,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+func render(name string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func shortAddress(address string) string {
+	if len(address) <= 10 {
+		return address
+	}
+	return address[:4] + "..." + address[len(address)-4:]
+}
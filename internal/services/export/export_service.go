@@ -0,0 +1,351 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+var (
+	ErrExportNotFound         = errors.New("export not found")
+	ErrInvalidFormat          = errors.New("unsupported export format")
+	ErrInsufficientPermission = errors.New("insufficient permission")
+)
+
+const (
+	exportBatchSize       = 500
+	defaultAsyncThreshold = 5000
+	defaultExportTTL      = 24 * time.Hour
+)
+
+// ExportService generates downloadable exports of stored data
+type ExportService interface {
+	RequestRoomExport(ctx context.Context, roomID, requester string, format models.ExportFormat) (*RoomExport, error)
+	WriteRoomExport(ctx context.Context, tradeRoom *models.TradeRoom, format models.ExportFormat, w io.Writer) (int64, error)
+	WriteWalletTransactions(ctx context.Context, walletAddress string, from, to time.Time, w io.Writer) (int64, error)
+	GetExport(ctx context.Context, id uuid.UUID, requester string) (*models.DataExport, error)
+	CleanupExpiredExports(ctx context.Context) (int, error)
+}
+
+// RoomExport is the outcome of a room export request. Rooms up to the async threshold
+// are streamed by the caller; larger ones are generated in the background and Job is set.
+type RoomExport struct {
+	Room *models.TradeRoom
+	Rows int64
+	Job  *models.DataExport
+}
+
+type exportService struct {
+	exportRepo      repositories.ExportRepository
+	roomRepo        repositories.RoomRepository
+	transactionRepo repositories.TransactionRepository
+	tokenRepo       repositories.TokenRepository
+	config          *config.ExportConfig
+	logger          *logrus.Logger
+}
+
+// NewExportService creates a new export service instance
+func NewExportService(
+	exportRepo repositories.ExportRepository,
+	roomRepo repositories.RoomRepository,
+	transactionRepo repositories.TransactionRepository,
+	tokenRepo repositories.TokenRepository,
+	config *config.ExportConfig,
+	logger *logrus.Logger,
+) ExportService {
+	return &exportService{
+		exportRepo:      exportRepo,
+		roomRepo:        roomRepo,
+		transactionRepo: transactionRepo,
+		tokenRepo:       tokenRepo,
+		config:          config,
+		logger:          logger,
+	}
+}
+
+// RequestRoomExport checks that requester created the room and decides how the export is delivered
+func (s *exportService) RequestRoomExport(ctx context.Context, roomID, requester string, format models.ExportFormat) (*RoomExport, error) {
+	if !format.IsValid() {
+		return nil, ErrInvalidFormat
+	}
+
+	tradeRoom, err := s.roomRepo.GetByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+	if tradeRoom == nil {
+		return nil, room.ErrRoomNotFound
+	}
+	if tradeRoom.CreatorAddress != requester {
+		return nil, ErrInsufficientPermission
+	}
+
+	eventCount, err := s.roomRepo.CountTradeEvents(ctx, tradeRoom.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count trade events: %w", err)
+	}
+	infoCount, err := s.roomRepo.CountSharedInfos(ctx, tradeRoom.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count shared infos: %w", err)
+	}
+
+	result := &RoomExport{Room: tradeRoom, Rows: eventCount + infoCount}
+	if result.Rows <= s.asyncThreshold() {
+		return result, nil
+	}
+
+	job := &models.DataExport{
+		Kind:        models.ExportKindRoom,
+		SubjectID:   tradeRoom.RoomID,
+		RequestedBy: requester,
+		Format:      format,
+		Status:      models.ExportStatusPending,
+		ExpiresAt:   time.Now().Add(s.ttl()),
+	}
+	if err := s.exportRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create export: %w", err)
+	}
+	result.Job = job
+
+	go s.generate(job, func(ctx context.Context, w io.Writer) (int64, error) {
+		return s.WriteRoomExport(ctx, tradeRoom, format, w)
+	})
+
+	return result, nil
+}
+
+// WriteRoomExport writes the room's trade events followed by its shared infos, oldest first
+func (s *exportService) WriteRoomExport(ctx context.Context, tradeRoom *models.TradeRoom, format models.ExportFormat, w io.Writer) (int64, error) {
+	out, err := newRoomWriter(format, tradeRoom, w)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := int64(0)
+	for offset := 0; ; offset += exportBatchSize {
+		events, err := s.roomRepo.GetTradeEventsBatch(ctx, tradeRoom.ID, exportBatchSize, offset)
+		if err != nil {
+			return rows, fmt.Errorf("failed to get trade events: %w", err)
+		}
+		for _, event := range events {
+			if err := out.writeTradeEvent(event); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if err := out.flush(); err != nil {
+			return rows, err
+		}
+		if len(events) < exportBatchSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += exportBatchSize {
+		infos, err := s.roomRepo.GetSharedInfosBatch(ctx, tradeRoom.ID, exportBatchSize, offset)
+		if err != nil {
+			return rows, fmt.Errorf("failed to get shared infos: %w", err)
+		}
+		for _, info := range infos {
+			if err := out.writeSharedInfo(info); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if err := out.flush(); err != nil {
+			return rows, err
+		}
+		if len(infos) < exportBatchSize {
+			break
+		}
+	}
+
+	return rows, out.close()
+}
+
+// WriteWalletTransactions writes the wallet's stored transactions with block time in [from, to) as CSV, oldest first
+func (s *exportService) WriteWalletTransactions(ctx context.Context, walletAddress string, from, to time.Time, w io.Writer) (int64, error) {
+	out, err := newTransactionCSVWriter(w)
+	if err != nil {
+		return 0, err
+	}
+
+	symbols := make(map[string]string)
+	rows := int64(0)
+	for offset := 0; ; offset += exportBatchSize {
+		transactions, err := s.transactionRepo.GetByWalletBetween(ctx, walletAddress, from, to, exportBatchSize, offset)
+		if err != nil {
+			return rows, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		for _, tx := range transactions {
+			if err := out.write(tx, s.tokenSymbol(ctx, symbols, tx.TokenAddress)); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if err := out.flush(); err != nil {
+			return rows, err
+		}
+		if len(transactions) < exportBatchSize {
+			break
+		}
+	}
+
+	return rows, nil
+}
+
+// tokenSymbol resolves a mint's symbol once per export; unknown tokens get an empty symbol
+func (s *exportService) tokenSymbol(ctx context.Context, symbols map[string]string, mintAddress string) string {
+	if symbol, ok := symbols[mintAddress]; ok {
+		return symbol
+	}
+
+	symbol := ""
+	token, err := s.tokenRepo.GetByMintAddress(ctx, mintAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"token": mintAddress,
+		}).Warn("Failed to get token for export")
+	} else if token != nil {
+		symbol = token.Symbol
+	}
+	symbols[mintAddress] = symbol
+	return symbol
+}
+
+// GetExport returns an unexpired export requested by requester
+func (s *exportService) GetExport(ctx context.Context, id uuid.UUID, requester string) (*models.DataExport, error) {
+	job, err := s.exportRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export: %w", err)
+	}
+	if job == nil || time.Now().After(job.ExpiresAt) {
+		return nil, ErrExportNotFound
+	}
+	if job.RequestedBy != requester {
+		return nil, ErrInsufficientPermission
+	}
+	return job, nil
+}
+
+// CleanupExpiredExports deletes expired exports and their files
+func (s *exportService) CleanupExpiredExports(ctx context.Context) (int, error) {
+	expired, err := s.exportRepo.GetExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired exports: %w", err)
+	}
+
+	removed := 0
+	for _, job := range expired {
+		if job.FilePath != "" {
+			if err := os.Remove(job.FilePath); err != nil && !os.IsNotExist(err) {
+				s.logger.WithFields(logrus.Fields{
+					"error":     err,
+					"export_id": job.ID,
+				}).Warn("Failed to remove export file")
+				continue
+			}
+		}
+		if err := s.exportRepo.Delete(ctx, job.ID); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err,
+				"export_id": job.ID,
+			}).Warn("Failed to delete export")
+			continue
+		}
+		removed++
+	}
+
+	if removed > 0 {
+		s.logger.WithField("removed", removed).Info("Expired exports cleaned up")
+	}
+	return removed, nil
+}
+
+// generate writes a background export to disk and records the outcome on the job
+func (s *exportService) generate(job *models.DataExport, write func(ctx context.Context, w io.Writer) (int64, error)) {
+	ctx := context.Background()
+
+	path, rows, err := s.writeFile(ctx, job, write)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err,
+			"export_id": job.ID,
+			"subject":   job.SubjectID,
+		}).Error("Failed to generate export")
+		job.Status = models.ExportStatusFailed
+		job.Error = err.Error()
+	} else {
+		now := time.Now()
+		job.Status = models.ExportStatusCompleted
+		job.FilePath = path
+		job.RowCount = rows
+		job.CompletedAt = &now
+		if info, statErr := os.Stat(path); statErr == nil {
+			job.FileSize = info.Size()
+		}
+	}
+
+	if err := s.exportRepo.Update(ctx, job); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err,
+			"export_id": job.ID,
+		}).Error("Failed to update export")
+	}
+}
+
+func (s *exportService) writeFile(ctx context.Context, job *models.DataExport, write func(ctx context.Context, w io.Writer) (int64, error)) (string, int64, error) {
+	dir := s.dir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", 0, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s.%s", job.ID, job.Format))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	rows, err := write(ctx, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", 0, err
+	}
+	return path, rows, nil
+}
+
+func (s *exportService) asyncThreshold() int64 {
+	if s.config.AsyncThreshold > 0 {
+		return s.config.AsyncThreshold
+	}
+	return defaultAsyncThreshold
+}
+
+func (s *exportService) ttl() time.Duration {
+	if s.config.TTL > 0 {
+		return s.config.TTL
+	}
+	return defaultExportTTL
+}
+
+func (s *exportService) dir() string {
+	if s.config.Dir != "" {
+		return s.config.Dir
+	}
+	return filepath.Join(os.TempDir(), "solana-wallet-exports")
+}
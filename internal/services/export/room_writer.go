@@ -0,0 +1,252 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// roomWriter encodes room records; trade events must be written before shared infos
+type roomWriter interface {
+	writeTradeEvent(event *models.TradeEvent) error
+	writeSharedInfo(info *models.SharedInfo) error
+	flush() error
+	close() error
+}
+
+func newRoomWriter(format models.ExportFormat, tradeRoom *models.TradeRoom, w io.Writer) (roomWriter, error) {
+	switch format {
+	case models.ExportFormatCSV:
+		return newCSVRoomWriter(w)
+	case models.ExportFormatJSON:
+		return &jsonRoomWriter{w: bufio.NewWriter(w), room: tradeRoom}, nil
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// roomCSVHeader lists the columns of a room CSV export; each row is either a trade event or a shared info
+var roomCSVHeader = []string{
+	"record_type", "id", "created_at", "wallet_address",
+	"token_address", "event_type", "amount", "price", "value_usd", "server_value_usd", "tx_signature", "block_time",
+	"info_type", "title", "content", "like_count", "view_count", "is_sticky",
+}
+
+type csvRoomWriter struct {
+	w *csv.Writer
+}
+
+func newCSVRoomWriter(w io.Writer) (*csvRoomWriter, error) {
+	out := &csvRoomWriter{w: csv.NewWriter(w)}
+	if err := out.w.Write(roomCSVHeader); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (cw *csvRoomWriter) writeTradeEvent(event *models.TradeEvent) error {
+	return cw.w.Write([]string{
+		"trade_event",
+		event.ID.String(),
+		event.CreatedAt.UTC().Format(time.RFC3339),
+		event.WalletAddress,
+		event.TokenAddress,
+		string(event.EventType),
+		formatFloat(event.Amount),
+		formatFloat(event.Price),
+		formatFloat(event.ValueUSD),
+		formatFloat(event.ServerValueUSD),
+		event.TxSignature,
+		event.BlockTime.UTC().Format(time.RFC3339),
+		"", "", "", "", "", "",
+	})
+}
+
+func (cw *csvRoomWriter) writeSharedInfo(info *models.SharedInfo) error {
+	return cw.w.Write([]string{
+		"shared_info",
+		info.ID.String(),
+		info.CreatedAt.UTC().Format(time.RFC3339),
+		info.SharerAddress,
+		"", "", "", "", "", "", "", "",
+		string(info.Type),
+		info.Title,
+		info.Content,
+		strconv.Itoa(info.LikeCount),
+		strconv.Itoa(info.ViewCount),
+		strconv.FormatBool(info.IsSticky),
+	})
+}
+
+func (cw *csvRoomWriter) flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvRoomWriter) close() error {
+	return cw.flush()
+}
+
+// Exported records omit the nested room that the models carry
+type exportedRoom struct {
+	RoomID         string    `json:"room_id"`
+	CreatorAddress string    `json:"creator_address"`
+	TokenAddress   *string   `json:"token_address"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExportedAt     time.Time `json:"exported_at"`
+}
+
+type exportedTradeEvent struct {
+	ID             string    `json:"id"`
+	WalletAddress  string    `json:"wallet_address"`
+	TokenAddress   string    `json:"token_address"`
+	EventType      string    `json:"event_type"`
+	Amount         float64   `json:"amount"`
+	Price          float64   `json:"price"`
+	ValueUSD       float64   `json:"value_usd"`
+	ServerValueUSD float64   `json:"server_value_usd"`
+	TxSignature    string    `json:"tx_signature"`
+	BlockTime      time.Time `json:"block_time"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type exportedSharedInfo struct {
+	ID            string    `json:"id"`
+	SharerAddress string    `json:"sharer_address"`
+	Type          string    `json:"type"`
+	Title         string    `json:"title"`
+	Content       string    `json:"content"`
+	Metadata      string    `json:"metadata,omitempty"`
+	IsSticky      bool      `json:"is_sticky"`
+	LikeCount     int       `json:"like_count"`
+	ViewCount     int       `json:"view_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// jsonRoomWriter streams {"room": ..., "trade_events": [...], "shared_infos": [...]}
+type jsonRoomWriter struct {
+	w       *bufio.Writer
+	room    *models.TradeRoom
+	section string
+	count   int
+}
+
+func (jw *jsonRoomWriter) writeTradeEvent(event *models.TradeEvent) error {
+	if err := jw.enter("trade_events"); err != nil {
+		return err
+	}
+	return jw.writeItem(exportedTradeEvent{
+		ID:             event.ID.String(),
+		WalletAddress:  event.WalletAddress,
+		TokenAddress:   event.TokenAddress,
+		EventType:      string(event.EventType),
+		Amount:         event.Amount,
+		Price:          event.Price,
+		ValueUSD:       event.ValueUSD,
+		ServerValueUSD: event.ServerValueUSD,
+		TxSignature:    event.TxSignature,
+		BlockTime:      event.BlockTime,
+		CreatedAt:      event.CreatedAt,
+	})
+}
+
+func (jw *jsonRoomWriter) writeSharedInfo(info *models.SharedInfo) error {
+	if err := jw.enter("shared_infos"); err != nil {
+		return err
+	}
+	return jw.writeItem(exportedSharedInfo{
+		ID:            info.ID.String(),
+		SharerAddress: info.SharerAddress,
+		Type:          string(info.Type),
+		Title:         info.Title,
+		Content:       info.Content,
+		Metadata:      info.Metadata,
+		IsSticky:      info.IsSticky,
+		LikeCount:     info.LikeCount,
+		ViewCount:     info.ViewCount,
+		CreatedAt:     info.CreatedAt,
+	})
+}
+
+func (jw *jsonRoomWriter) flush() error {
+	return jw.w.Flush()
+}
+
+func (jw *jsonRoomWriter) close() error {
+	if err := jw.enter("trade_events"); err != nil {
+		return err
+	}
+	if err := jw.enter("shared_infos"); err != nil {
+		return err
+	}
+	if _, err := jw.w.WriteString("]}\n"); err != nil {
+		return err
+	}
+	return jw.w.Flush()
+}
+
+// enter opens the given array, writing the room header and closing earlier sections as needed
+func (jw *jsonRoomWriter) enter(section string) error {
+	if jw.section == section || (jw.section == "shared_infos" && section == "trade_events") {
+		return nil
+	}
+
+	if jw.section == "" {
+		header, err := json.Marshal(exportedRoom{
+			RoomID:         jw.room.RoomID,
+			CreatorAddress: jw.room.CreatorAddress,
+			TokenAddress:   jw.room.TokenAddress,
+			Status:         string(jw.room.Status),
+			CreatedAt:      jw.room.CreatedAt,
+			ExportedAt:     time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := jw.w.WriteString(`{"room":`); err != nil {
+			return err
+		}
+		if _, err := jw.w.Write(header); err != nil {
+			return err
+		}
+		if _, err := jw.w.WriteString(`,"trade_events":[`); err != nil {
+			return err
+		}
+		jw.section = "trade_events"
+		jw.count = 0
+	}
+
+	if jw.section == "trade_events" && section == "shared_infos" {
+		if _, err := jw.w.WriteString(`],"shared_infos":[`); err != nil {
+			return err
+		}
+		jw.section = "shared_infos"
+		jw.count = 0
+	}
+	return nil
+}
+
+func (jw *jsonRoomWriter) writeItem(item interface{}) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if jw.count > 0 {
+		if err := jw.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	jw.count++
+	_, err = jw.w.Write(data)
+	return err
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+const lamportsPerSOL = 1_000_000_000
+
+// transactionCSVHeader lists the columns of a wallet transaction export
+var transactionCSVHeader = []string{
+	"timestamp", "signature", "type", "token_address", "token_symbol",
+	"amount", "price_usd", "value_usd", "fee_sol", "platform", "status",
+}
+
+type transactionCSVWriter struct {
+	w *csv.Writer
+}
+
+func newTransactionCSVWriter(w io.Writer) (*transactionCSVWriter, error) {
+	out := &transactionCSVWriter{w: csv.NewWriter(w)}
+	if err := out.w.Write(transactionCSVHeader); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (tw *transactionCSVWriter) write(tx *models.SmartMoneyTransaction, symbol string) error {
+	return tw.w.Write([]string{
+		tx.BlockTime.UTC().Format(time.RFC3339),
+		tx.Signature,
+		string(tx.TransactionType),
+		tx.TokenAddress,
+		symbol,
+		formatFloat(tx.Amount),
+		formatFloat(tx.Price),
+		formatFloat(transactionValue(tx)),
+		strconv.FormatFloat(float64(tx.FeeLamports)/lamportsPerSOL, 'f', 9, 64),
+		tx.InstructionType,
+		string(tx.Status),
+	})
+}
+
+func (tw *transactionCSVWriter) flush() error {
+	tw.w.Flush()
+	return tw.w.Error()
+}
+
+// transactionValue is the stored USD value at trade time, derived from the price when it was not recorded
+func transactionValue(tx *models.SmartMoneyTransaction) float64 {
+	if tx.ValueUSD > 0 {
+		return tx.ValueUSD
+	}
+	return tx.Amount * tx.Price
+}
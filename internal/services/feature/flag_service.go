@@ -0,0 +1,290 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+var (
+	ErrUnknownFlag      = errors.New("unknown feature flag")
+	ErrInvalidFlagScope = errors.New("invalid feature flag scope")
+	ErrFeatureDisabled  = errors.New("feature is disabled")
+)
+
+// Flag names a gated feature
+type Flag string
+
+const (
+	FlagAIRationale        Flag = "ai_rationale"       // may be set per room
+	FlagCopyTradeSignals   Flag = "copy_trade_signals" // may be set per room
+	FlagBirdeyeMarketData  Flag = "birdeye_market_data"
+	FlagGeckoTerminalPools Flag = "geckoterminal_pools"
+)
+
+// builtinFlag is a flag's description and the default it has unless the environment's config sets another
+type builtinFlag struct {
+	description string
+	perRoom     bool
+	enabled     bool
+}
+
+var builtinFlags = map[Flag]builtinFlag{
+	FlagAIRationale: {
+		description: "AI rationales of trades in rooms that enable them",
+		perRoom:     true,
+		enabled:     true,
+	},
+	FlagCopyTradeSignals: {
+		description: "Sharing trade signals in rooms for members to copy",
+		perRoom:     true,
+		enabled:     true,
+	},
+	FlagBirdeyeMarketData: {
+		description: "Birdeye as market data provider or fallback, when it has an API key",
+		enabled:     true,
+	},
+	FlagGeckoTerminalPools: {
+		description: "GeckoTerminal pools of a token and the pool liquidity fallback",
+		enabled:     true,
+	},
+}
+
+// Flags in listing order
+var flagKeys = []Flag{FlagAIRationale, FlagCopyTradeSignals, FlagBirdeyeMarketData, FlagGeckoTerminalPools}
+
+const (
+	featureFlagsCacheKey = "feature_flags"
+	defaultFlagCacheTTL  = 30 * time.Second
+)
+
+// FlagInfo is a flag's default in this environment, the value an admin set globally and the rooms overriding it
+type FlagInfo struct {
+	Key           Flag                  `json:"key"`
+	Description   string                `json:"description"`
+	PerRoom       bool                  `json:"per_room"`
+	Default       bool                  `json:"default"`
+	Enabled       bool                  `json:"enabled"`          // globally; rooms may override it
+	Global        *models.FeatureFlag   `json:"global,omitempty"` // nil while the default applies
+	RoomOverrides []*models.FeatureFlag `json:"room_overrides"`
+}
+
+// SetFlagRequest turns a flag on or off, for one room or globally
+type SetFlagRequest struct {
+	Flag      Flag
+	RoomID    string // empty for the global flag
+	Enabled   bool
+	UpdatedBy string
+}
+
+// FlagService gates risky features at runtime. A feature is enabled as set for its room, then as set globally,
+// then as the environment's config defaults it, then as built in.
+type FlagService interface {
+	Enabled(ctx context.Context, flag Flag, roomID string) bool
+	ListFlags(ctx context.Context) ([]*FlagInfo, error)
+	SetFlag(ctx context.Context, req *SetFlagRequest) (*models.FeatureFlag, error)
+	ClearFlag(ctx context.Context, flag Flag, roomID string) error // back to the global flag or the default
+}
+
+type flagService struct {
+	flagRepo repositories.FeatureFlagRepository
+	cache    *redis.Client // optional
+	defaults map[Flag]bool
+	cacheTTL time.Duration
+	logger   *logrus.Logger
+}
+
+// NewFlagService creates a new feature flag service instance; without a cache every check reads the database
+func NewFlagService(flagRepo repositories.FeatureFlagRepository, cache *redis.Client, cfg *config.FeatureFlagsConfig, logger *logrus.Logger) FlagService {
+	defaults := make(map[Flag]bool, len(builtinFlags))
+	for flag, builtin := range builtinFlags {
+		defaults[flag] = builtin.enabled
+	}
+	for key, enabled := range cfg.Defaults {
+		flag := Flag(key)
+		if _, ok := builtinFlags[flag]; !ok {
+			logger.WithField("flag", key).Warn("Ignoring default of unknown feature flag")
+			continue
+		}
+		defaults[flag] = enabled
+	}
+
+	cacheTTL := cfg.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = defaultFlagCacheTTL
+	}
+
+	return &flagService{
+		flagRepo: flagRepo,
+		cache:    cache,
+		defaults: defaults,
+		cacheTTL: cacheTTL,
+		logger:   logger,
+	}
+}
+
+// Enabled reports whether the feature is on for the room, or globally for an empty roomID; lookup failures
+// fall back to the default, so a database outage does not flip features
+func (s *flagService) Enabled(ctx context.Context, flag Flag, roomID string) bool {
+	builtin, ok := builtinFlags[flag]
+	if !ok {
+		return false
+	}
+
+	flags, err := s.flags(ctx)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"flag":  flag,
+		}).Warn("Failed to get feature flags, using default")
+		return s.defaults[flag]
+	}
+
+	var global, room *models.FeatureFlag
+	for _, set := range flags {
+		if set.Key != string(flag) {
+			continue
+		}
+		if set.RoomID == "" {
+			global = set
+		} else if builtin.perRoom && roomID != "" && set.RoomID == roomID {
+			room = set
+		}
+	}
+	if room != nil {
+		return room.Enabled
+	}
+	if global != nil {
+		return global.Enabled
+	}
+	return s.defaults[flag]
+}
+
+// flags returns every flag an admin set, from the cache when it holds them
+func (s *flagService) flags(ctx context.Context) ([]*models.FeatureFlag, error) {
+	if s.cache != nil {
+		var cached []*models.FeatureFlag
+		err := s.cache.GetJSON(ctx, featureFlagsCacheKey, &cached)
+		if err == nil {
+			return cached, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			s.logger.WithError(err).Warn("Failed to read feature flag cache")
+		}
+	}
+
+	flags, err := s.flagRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.cache != nil {
+		if err := s.cache.SetJSON(ctx, featureFlagsCacheKey, flags, s.cacheTTL); err != nil {
+			s.logger.WithError(err).Warn("Failed to write feature flag cache")
+		}
+	}
+	return flags, nil
+}
+
+// invalidate drops the cached flags, so every instance reads a change on its next check
+func (s *flagService) invalidate(ctx context.Context) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Del(ctx, featureFlagsCacheKey).Err(); err != nil {
+		s.logger.WithError(err).Warn("Failed to invalidate feature flag cache")
+	}
+}
+
+// ListFlags lists every flag with its default and what admins set, read from the database
+func (s *flagService) ListFlags(ctx context.Context) ([]*FlagInfo, error) {
+	flags, err := s.flagRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+
+	infos := make([]*FlagInfo, 0, len(flagKeys))
+	byKey := make(map[Flag]*FlagInfo, len(flagKeys))
+	for _, flag := range flagKeys {
+		builtin := builtinFlags[flag]
+		info := &FlagInfo{
+			Key:           flag,
+			Description:   builtin.description,
+			PerRoom:       builtin.perRoom,
+			Default:       s.defaults[flag],
+			Enabled:       s.defaults[flag],
+			RoomOverrides: []*models.FeatureFlag{},
+		}
+		infos = append(infos, info)
+		byKey[flag] = info
+	}
+	for _, set := range flags {
+		info, ok := byKey[Flag(set.Key)]
+		if !ok {
+			continue
+		}
+		if set.RoomID == "" {
+			info.Global = set
+			info.Enabled = set.Enabled
+		} else {
+			info.RoomOverrides = append(info.RoomOverrides, set)
+		}
+	}
+	return infos, nil
+}
+
+// SetFlag turns the flag on or off for the room, or globally; it takes effect on every instance right away
+func (s *flagService) SetFlag(ctx context.Context, req *SetFlagRequest) (*models.FeatureFlag, error) {
+	if err := ValidateFlagScope(req.Flag, req.RoomID); err != nil {
+		return nil, err
+	}
+
+	flag := &models.FeatureFlag{
+		Key:       string(req.Flag),
+		RoomID:    req.RoomID,
+		Enabled:   req.Enabled,
+		UpdatedBy: req.UpdatedBy,
+	}
+	if err := s.flagRepo.Set(ctx, flag); err != nil {
+		return nil, fmt.Errorf("failed to set feature flag: %w", err)
+	}
+	s.invalidate(ctx)
+
+	s.logger.WithFields(logrus.Fields{
+		"flag":    req.Flag,
+		"room_id": req.RoomID,
+		"enabled": req.Enabled,
+		"by":      req.UpdatedBy,
+	}).Info("Feature flag set")
+	return flag, nil
+}
+
+// ClearFlag removes what an admin set for the room, or globally, so the global flag or the default applies again
+func (s *flagService) ClearFlag(ctx context.Context, flag Flag, roomID string) error {
+	if err := ValidateFlagScope(flag, roomID); err != nil {
+		return err
+	}
+	if _, err := s.flagRepo.Delete(ctx, string(flag), roomID); err != nil {
+		return fmt.Errorf("failed to clear feature flag: %w", err)
+	}
+	s.invalidate(ctx)
+	return nil
+}
+
+// ValidateFlagScope checks that the flag exists and, for a room, that it may be set per room
+func ValidateFlagScope(flag Flag, roomID string) error {
+	builtin, ok := builtinFlags[flag]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+	}
+	if roomID != "" && !builtin.perRoom {
+		return fmt.Errorf("%w: %s cannot be set per room", ErrInvalidFlagScope, flag)
+	}
+	return nil
+}
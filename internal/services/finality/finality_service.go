@@ -0,0 +1,161 @@
+package finality
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+const (
+	finalityBatchSize = 200
+	// A transaction the cluster still does not know this long after it was recorded is taken as dropped;
+	// its blockhash has expired by then, so it can no longer land
+	droppedTransactionTimeout = 5 * time.Minute
+)
+
+// FinalityService settles trades recorded before finalized commitment, marking them final or reverted
+type FinalityService interface {
+	CheckFinality(ctx context.Context) (int, error)
+}
+
+type finalityService struct {
+	roomRepo             repositories.RoomRepository
+	transactionRepo      repositories.TransactionRepository
+	transactionProcessor blockchain.TransactionProcessor
+	wsService            room.WebSocketService
+	logger               *logrus.Logger
+}
+
+// NewFinalityService creates a new finality service instance
+func NewFinalityService(
+	roomRepo repositories.RoomRepository,
+	transactionRepo repositories.TransactionRepository,
+	transactionProcessor blockchain.TransactionProcessor,
+	wsService room.WebSocketService,
+	logger *logrus.Logger,
+) FinalityService {
+	return &finalityService{
+		roomRepo:             roomRepo,
+		transactionRepo:      transactionRepo,
+		transactionProcessor: transactionProcessor,
+		wsService:            wsService,
+		logger:               logger,
+	}
+}
+
+// verdict is the outcome of a finality check; settled is false while the transaction may still finalize
+type verdict struct {
+	settled  bool
+	reverted bool
+}
+
+// CheckFinality looks up the on-chain status of provisional room trade events and unfinalized stored
+// transactions, and returns how many were settled. Rooms are told about each settled trade event.
+func (s *finalityService) CheckFinality(ctx context.Context) (int, error) {
+	events, err := s.roomRepo.GetProvisionalTradeEvents(ctx, finalityBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	transactions, err := s.transactionRepo.GetUnfinalized(ctx, finalityBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 && len(transactions) == 0 {
+		return 0, nil
+	}
+
+	signatures := make([]string, 0, len(events)+len(transactions))
+	for _, event := range events {
+		signatures = append(signatures, event.TxSignature)
+	}
+	for _, tx := range transactions {
+		signatures = append(signatures, tx.Signature)
+	}
+
+	statuses, err := s.transactionProcessor.GetSignatureStatuses(signatures)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for i, event := range events {
+		v := judge(statuses[i], event.CreatedAt)
+		if !v.settled {
+			continue
+		}
+		if err := s.roomRepo.SetTradeEventFinality(ctx, event.ID, v.reverted); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":    err,
+				"event_id": event.ID,
+			}).Error("Failed to update trade event finality")
+			continue
+		}
+		settled++
+		s.notifyRoom(event, v.reverted)
+	}
+
+	for i, tx := range transactions {
+		v := judge(statuses[len(events)+i], tx.CreatedAt)
+		if !v.settled {
+			continue
+		}
+		if err := s.transactionRepo.SetFinality(ctx, tx.ID, v.reverted); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err,
+				"signature": tx.Signature,
+			}).Error("Failed to update transaction finality")
+			continue
+		}
+		settled++
+		if v.reverted {
+			s.logger.WithField("signature", tx.Signature).Warn("Stored transaction was reverted before finality")
+		}
+	}
+
+	if settled > 0 {
+		s.logger.WithField("count", settled).Info("Settled provisional trades")
+	}
+	return settled, nil
+}
+
+// judge decides a trade's finality from its signature status and when it was recorded
+func judge(status *blockchain.SignatureStatus, recordedAt time.Time) verdict {
+	if status == nil {
+		if time.Since(recordedAt) > droppedTransactionTimeout {
+			return verdict{settled: true, reverted: true}
+		}
+		return verdict{}
+	}
+	if status.Err != nil {
+		return verdict{settled: true, reverted: true}
+	}
+	if status.Finalized() {
+		return verdict{settled: true}
+	}
+	return verdict{}
+}
+
+func (s *finalityService) notifyRoom(event *models.TradeEvent, reverted bool) {
+	message := &room.Message{
+		Type: room.MessageTypeTradeFinality,
+		Data: map[string]interface{}{
+			"event_id":       event.ID,
+			"wallet_address": event.WalletAddress,
+			"tx_signature":   event.TxSignature,
+			"provisional":    false,
+			"reverted":       reverted,
+		},
+	}
+	if err := s.wsService.BroadcastToRoom(event.Room.RoomID, message); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"room_id":  event.Room.RoomID,
+			"event_id": event.ID,
+		}).Warn("Failed to broadcast trade finality")
+	}
+}
@@ -0,0 +1,177 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+var (
+	ErrLabelNotFound    = errors.New("wallet label not found")
+	ErrInvalidLabelType = errors.New("invalid wallet label type")
+	ErrLabelExists      = errors.New("wallet already has this label")
+)
+
+// LabelService defines the interface for wallet label management and lookup
+type LabelService interface {
+	CreateLabel(ctx context.Context, req *CreateLabelRequest) (*models.WalletLabel, error)
+	UpdateLabel(ctx context.Context, id uuid.UUID, req *UpdateLabelRequest) (*models.WalletLabel, error)
+	DeleteLabel(ctx context.Context, id uuid.UUID) error
+	ListLabels(ctx context.Context, labelType models.WalletLabelType, limit, offset int) ([]*models.WalletLabel, error)
+	GetWalletLabels(ctx context.Context, walletAddress string) ([]*models.WalletLabel, error)
+	LookupLabels(ctx context.Context, walletAddresses []string) (map[string][]string, error)
+}
+
+type labelService struct {
+	labelRepo repositories.WalletLabelRepository
+	logger    *logrus.Logger
+}
+
+// NewLabelService creates a new label service instance
+func NewLabelService(labelRepo repositories.WalletLabelRepository, logger *logrus.Logger) LabelService {
+	return &labelService{
+		labelRepo: labelRepo,
+		logger:    logger,
+	}
+}
+
+// Request structures
+type CreateLabelRequest struct {
+	WalletAddress string                 `json:"wallet_address" binding:"required,solana_address"`
+	Label         models.WalletLabelType `json:"label" binding:"required"`
+	EntityName    string                 `json:"entity_name"`
+	Source        string                 `json:"source"`
+	Notes         string                 `json:"notes"`
+}
+
+type UpdateLabelRequest struct {
+	Label      *models.WalletLabelType `json:"label"`
+	EntityName *string                 `json:"entity_name"`
+	Source     *string                 `json:"source"`
+	Notes      *string                 `json:"notes"`
+}
+
+func (s *labelService) CreateLabel(ctx context.Context, req *CreateLabelRequest) (*models.WalletLabel, error) {
+	if !req.Label.IsValid() {
+		return nil, ErrInvalidLabelType
+	}
+
+	label := &models.WalletLabel{
+		WalletAddress: req.WalletAddress,
+		Label:         req.Label,
+		EntityName:    req.EntityName,
+		Source:        req.Source,
+		Notes:         req.Notes,
+	}
+
+	if err := s.labelRepo.Create(ctx, label); err != nil {
+		if errors.Is(err, repositories.ErrLabelExists) {
+			return nil, ErrLabelExists
+		}
+		return nil, fmt.Errorf("failed to create wallet label: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"wallet": label.WalletAddress,
+		"label":  label.Label,
+	}).Info("Wallet label created")
+
+	return label, nil
+}
+
+func (s *labelService) UpdateLabel(ctx context.Context, id uuid.UUID, req *UpdateLabelRequest) (*models.WalletLabel, error) {
+	label, err := s.labelRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet label: %w", err)
+	}
+	if label == nil {
+		return nil, ErrLabelNotFound
+	}
+
+	if req.Label != nil {
+		if !req.Label.IsValid() {
+			return nil, ErrInvalidLabelType
+		}
+		label.Label = *req.Label
+	}
+	if req.EntityName != nil {
+		label.EntityName = *req.EntityName
+	}
+	if req.Source != nil {
+		label.Source = *req.Source
+	}
+	if req.Notes != nil {
+		label.Notes = *req.Notes
+	}
+
+	if err := s.labelRepo.Update(ctx, label); err != nil {
+		if errors.Is(err, repositories.ErrLabelExists) {
+			return nil, ErrLabelExists
+		}
+		return nil, fmt.Errorf("failed to update wallet label: %w", err)
+	}
+
+	return label, nil
+}
+
+func (s *labelService) DeleteLabel(ctx context.Context, id uuid.UUID) error {
+	label, err := s.labelRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get wallet label: %w", err)
+	}
+	if label == nil {
+		return ErrLabelNotFound
+	}
+
+	if err := s.labelRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete wallet label: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"wallet": label.WalletAddress,
+		"label":  label.Label,
+	}).Info("Wallet label deleted")
+
+	return nil
+}
+
+func (s *labelService) ListLabels(ctx context.Context, labelType models.WalletLabelType, limit, offset int) ([]*models.WalletLabel, error) {
+	if labelType != "" && !labelType.IsValid() {
+		return nil, ErrInvalidLabelType
+	}
+	return s.labelRepo.List(ctx, labelType, limit, offset)
+}
+
+func (s *labelService) GetWalletLabels(ctx context.Context, walletAddress string) ([]*models.WalletLabel, error) {
+	return s.labelRepo.GetByWallet(ctx, walletAddress)
+}
+
+// LookupLabels returns the label types attached to each of the given wallets
+func (s *labelService) LookupLabels(ctx context.Context, walletAddresses []string) (map[string][]string, error) {
+	return LookupLabels(ctx, s.labelRepo, walletAddresses)
+}
+
+// LookupLabels resolves labels for a batch of wallets in a single query.
+// Wallets without labels are omitted from the result.
+func LookupLabels(ctx context.Context, labelRepo repositories.WalletLabelRepository, walletAddresses []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if labelRepo == nil || len(walletAddresses) == 0 {
+		return result, nil
+	}
+
+	labels, err := labelRepo.GetByWallets(ctx, walletAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet labels: %w", err)
+	}
+
+	for _, label := range labels {
+		result[label.WalletAddress] = append(result[label.WalletAddress], string(label.Label))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,252 @@
+package limitwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/notification"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+var (
+	ErrWatchNotFound      = errors.New("limit watch not found")
+	ErrInvalidTargetPrice = errors.New("target price must be positive")
+	ErrInvalidDirection   = errors.New("direction must be above or below")
+	ErrInvalidSwapSide    = errors.New("swap side must be buy or sell")
+	ErrInvalidExpiry      = errors.New("invalid expiry")
+	ErrTooManyWatches     = errors.New("too many active limit watches")
+	ErrPriceUnknown       = errors.New("token price is unknown; direction is required")
+	ErrAlreadyCrossed     = errors.New("price has already crossed the target")
+)
+
+const (
+	maxActiveWatches = 50
+	maxWatchExpiry   = 30 * 24 * time.Hour
+)
+
+// LimitWatchService manages price limit watches and evaluates them against streamed prices
+type LimitWatchService interface {
+	CreateWatch(ctx context.Context, req *CreateWatchRequest) (*models.LimitWatch, error)
+	ListWatches(ctx context.Context, walletAddress string, status models.LimitWatchStatus, limit, offset int) ([]*models.LimitWatch, error)
+	CancelWatch(ctx context.Context, walletAddress string, id uuid.UUID) error
+	OnPrice(ctx context.Context, mintAddress string, priceUSD float64) // matches token.PriceListener
+	ExpireWatches(ctx context.Context) (int64, error)
+}
+
+type limitWatchService struct {
+	limitWatchRepo  repositories.LimitWatchRepository
+	priceAggregator blockchain.PriceAggregator
+	notifications   notification.NotificationService
+	logger          *logrus.Logger
+}
+
+// NewLimitWatchService creates a new limit watch service instance
+func NewLimitWatchService(
+	limitWatchRepo repositories.LimitWatchRepository,
+	priceAggregator blockchain.PriceAggregator,
+	notifications notification.NotificationService,
+	logger *logrus.Logger,
+) LimitWatchService {
+	return &limitWatchService{
+		limitWatchRepo:  limitWatchRepo,
+		priceAggregator: priceAggregator,
+		notifications:   notifications,
+		logger:          logger,
+	}
+}
+
+// CreateWatchRequest registers a price target for a token
+type CreateWatchRequest struct {
+	WalletAddress  string  `json:"-"`
+	MintAddress    string  `json:"mint_address" binding:"required,solana_address"`
+	TargetPriceUSD float64 `json:"target_price_usd" binding:"required"`
+	Direction      string  `json:"direction"` // above or below; derived from the current price when empty
+	SwapSide       string  `json:"swap_side"` // buy or sell adds a Jupiter swap link to the notification
+	Note           string  `json:"note"`
+	ExpiresInHours int     `json:"expires_in_hours"` // 0 never expires; at most 30 days
+}
+
+// CreateWatch validates and stores a new active watch
+func (s *limitWatchService) CreateWatch(ctx context.Context, req *CreateWatchRequest) (*models.LimitWatch, error) {
+	if req.TargetPriceUSD <= 0 {
+		return nil, ErrInvalidTargetPrice
+	}
+	if req.SwapSide != "" && req.SwapSide != "buy" && req.SwapSide != "sell" {
+		return nil, ErrInvalidSwapSide
+	}
+	if req.ExpiresInHours < 0 || time.Duration(req.ExpiresInHours)*time.Hour > maxWatchExpiry {
+		return nil, ErrInvalidExpiry
+	}
+
+	active, err := s.limitWatchRepo.CountActive(ctx, req.WalletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count limit watches: %w", err)
+	}
+	if active >= maxActiveWatches {
+		return nil, ErrTooManyWatches
+	}
+
+	watch := &models.LimitWatch{
+		WalletAddress:  req.WalletAddress,
+		MintAddress:    req.MintAddress,
+		TargetPriceUSD: req.TargetPriceUSD,
+		Direction:      models.LimitWatchDirection(req.Direction),
+		SwapSide:       req.SwapSide,
+		Note:           req.Note,
+		Status:         models.LimitWatchActive,
+	}
+	if price, ok := s.priceAggregator.PriceUSD(ctx, req.MintAddress); ok {
+		watch.ReferencePriceUSD = price
+	}
+
+	switch watch.Direction {
+	case "":
+		// Without a direction the target is compared to the current price
+		if watch.ReferencePriceUSD <= 0 {
+			return nil, ErrPriceUnknown
+		}
+		watch.Direction = models.LimitWatchAbove
+		if req.TargetPriceUSD < watch.ReferencePriceUSD {
+			watch.Direction = models.LimitWatchBelow
+		}
+	case models.LimitWatchAbove, models.LimitWatchBelow:
+	default:
+		return nil, ErrInvalidDirection
+	}
+	if watch.Crossed(watch.ReferencePriceUSD) {
+		return nil, ErrAlreadyCrossed
+	}
+
+	if req.ExpiresInHours > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		watch.ExpiresAt = &expiresAt
+	}
+
+	if err := s.limitWatchRepo.Create(ctx, watch); err != nil {
+		return nil, fmt.Errorf("failed to create limit watch: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"watch_id":  watch.ID,
+		"wallet":    watch.WalletAddress,
+		"mint":      watch.MintAddress,
+		"target":    watch.TargetPriceUSD,
+		"direction": watch.Direction,
+	}).Info("Limit watch created")
+
+	return watch, nil
+}
+
+// ListWatches returns a wallet's watches, newest first; an empty status matches all
+func (s *limitWatchService) ListWatches(ctx context.Context, walletAddress string, status models.LimitWatchStatus, limit, offset int) ([]*models.LimitWatch, error) {
+	watches, err := s.limitWatchRepo.ListByWallet(ctx, walletAddress, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit watches: %w", err)
+	}
+	return watches, nil
+}
+
+// CancelWatch cancels an active watch owned by the wallet
+func (s *limitWatchService) CancelWatch(ctx context.Context, walletAddress string, id uuid.UUID) error {
+	watch, err := s.limitWatchRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get limit watch: %w", err)
+	}
+	if watch == nil || watch.WalletAddress != walletAddress {
+		return ErrWatchNotFound
+	}
+
+	cancelled, err := s.limitWatchRepo.Cancel(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to cancel limit watch: %w", err)
+	}
+	if !cancelled {
+		return ErrWatchNotFound
+	}
+	return nil
+}
+
+// OnPrice triggers the active watches of the mint that the price has crossed and notifies their wallets
+func (s *limitWatchService) OnPrice(ctx context.Context, mintAddress string, priceUSD float64) {
+	watches, err := s.limitWatchRepo.GetActiveByMint(ctx, mintAddress, time.Now())
+	if err != nil {
+		s.logger.WithError(err).WithField("mint", mintAddress).Error("Failed to get limit watches")
+		return
+	}
+
+	for _, watch := range watches {
+		if !watch.Crossed(priceUSD) {
+			continue
+		}
+
+		// The conditional update makes sure a watch fires once even when prices arrive concurrently
+		now := time.Now()
+		triggered, err := s.limitWatchRepo.MarkTriggered(ctx, watch.ID, priceUSD, now)
+		if err != nil {
+			s.logger.WithError(err).WithField("watch_id", watch.ID).Error("Failed to mark limit watch triggered")
+			continue
+		}
+		if !triggered {
+			continue
+		}
+		watch.Status = models.LimitWatchTriggered
+		watch.TriggeredPriceUSD = priceUSD
+		watch.TriggeredAt = &now
+
+		payload := &models.LimitWatchNotification{
+			Watch:    watch,
+			PriceUSD: priceUSD,
+		}
+		if watch.SwapSide != "" {
+			payload.SwapURL = token.SwapDeepLink(watch.MintAddress, watch.SwapSide)
+		}
+		_, err = s.notifications.Notify(ctx, &notification.NotifyRequest{
+			WalletAddress: watch.WalletAddress,
+			Type:          models.NotificationTypePriceAlert,
+			Title:         fmt.Sprintf("Price %s $%g", watch.Direction, watch.TargetPriceUSD),
+			Body:          priceAlertBody(payload),
+			Data:          payload,
+		})
+		if err != nil {
+			s.logger.WithError(err).WithField("watch_id", watch.ID).Error("Failed to notify triggered limit watch")
+		}
+
+		s.logger.WithFields(logrus.Fields{
+			"watch_id": watch.ID,
+			"wallet":   watch.WalletAddress,
+			"mint":     mintAddress,
+			"price":    priceUSD,
+		}).Info("Limit watch triggered")
+	}
+}
+
+// priceAlertBody describes a triggered watch for chat and mail channels
+func priceAlertBody(payload *models.LimitWatchNotification) string {
+	body := fmt.Sprintf("%s traded at $%g.", payload.Watch.MintAddress, payload.PriceUSD)
+	if payload.Watch.Note != "" {
+		body += "\n" + payload.Watch.Note
+	}
+	if payload.SwapURL != "" {
+		body += "\n" + payload.SwapURL
+	}
+	return body
+}
+
+// ExpireWatches marks active watches past their expiry as expired
+func (s *limitWatchService) ExpireWatches(ctx context.Context) (int64, error) {
+	expired, err := s.limitWatchRepo.ExpireBefore(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire limit watches: %w", err)
+	}
+	if expired > 0 {
+		s.logger.WithField("count", expired).Info("Expired limit watches")
+	}
+	return expired, nil
+}
@@ -0,0 +1,280 @@
+package liquidity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/feature"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+// ErrRoomHasNoToken is returned for rooms that are not bound to a token
+var ErrRoomHasNoToken = errors.New("room is not bound to a token")
+
+const (
+	defaultDropPercent = 30.0
+	defaultWindow      = time.Hour
+	defaultRetention   = 7 * 24 * time.Hour
+)
+
+// LiquidityService samples pool liquidity of room-bound tokens and alerts rooms when it is pulled
+type LiquidityService interface {
+	CheckLiquidity(ctx context.Context) (int, error)
+	GetRoomLiquidity(ctx context.Context, roomID string, since time.Time) (*RoomLiquidity, error)
+	GetRoomAlerts(ctx context.Context, roomID string, limit, offset int) ([]*models.LiquidityAlert, error)
+}
+
+type liquidityService struct {
+	liquidityRepo repositories.LiquidityRepository
+	roomRepo      repositories.RoomRepository
+	marketData    token.MarketDataProvider
+	pools         token.PoolsProvider
+	wsService     room.WebSocketService
+	dropPercent   float64
+	window        time.Duration
+	retention     time.Duration
+	logger        *logrus.Logger
+}
+
+// NewLiquidityService creates a new liquidity monitoring service instance
+func NewLiquidityService(
+	liquidityRepo repositories.LiquidityRepository,
+	roomRepo repositories.RoomRepository,
+	marketData token.MarketDataProvider,
+	pools token.PoolsProvider,
+	wsService room.WebSocketService,
+	cfg *config.LiquidityConfig,
+	logger *logrus.Logger,
+) LiquidityService {
+	s := &liquidityService{
+		liquidityRepo: liquidityRepo,
+		roomRepo:      roomRepo,
+		marketData:    marketData,
+		pools:         pools,
+		wsService:     wsService,
+		dropPercent:   cfg.DropPercent,
+		window:        cfg.Window,
+		retention:     cfg.Retention,
+		logger:        logger,
+	}
+	if s.dropPercent <= 0 || s.dropPercent > 100 {
+		s.dropPercent = defaultDropPercent
+	}
+	if s.window <= 0 {
+		s.window = defaultWindow
+	}
+	if s.retention < s.window {
+		s.retention = defaultRetention
+	}
+	return s
+}
+
+// RoomLiquidity is the liquidity history of a room's token with its recent alerts
+type RoomLiquidity struct {
+	TokenAddress string                      `json:"token_address"`
+	Snapshots    []*models.LiquiditySnapshot `json:"snapshots"`
+	Alerts       []*models.LiquidityAlert    `json:"alerts"`
+}
+
+// Number of alerts returned alongside a room's liquidity history
+const roomLiquidityAlerts = 10
+
+// CheckLiquidity snapshots the liquidity of every token bound to an active room and returns how many alerts were raised
+func (s *liquidityService) CheckLiquidity(ctx context.Context) (int, error) {
+	mints, err := s.roomRepo.GetBoundTokenAddresses(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get room-bound tokens: %w", err)
+	}
+
+	alerts := 0
+	for _, mint := range mints {
+		alert, err := s.checkToken(ctx, mint)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": mint,
+			}).Warn("Failed to check token liquidity")
+			continue
+		}
+		if alert != nil {
+			alerts++
+		}
+	}
+
+	if deleted, err := s.liquidityRepo.DeleteSnapshotsBefore(ctx, time.Now().Add(-s.retention)); err != nil {
+		s.logger.WithError(err).Warn("Failed to delete old liquidity snapshots")
+	} else if deleted > 0 {
+		s.logger.WithField("deleted", deleted).Debug("Deleted old liquidity snapshots")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"tokens": len(mints),
+		"alerts": alerts,
+	}).Info("Liquidity check completed")
+
+	return alerts, nil
+}
+
+// checkToken records the token's current liquidity and raises an alert if it dropped too far below the window peak
+func (s *liquidityService) checkToken(ctx context.Context, mintAddress string) (*models.LiquidityAlert, error) {
+	liquidityUSD, err := s.currentLiquidity(ctx, mintAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	peak, err := s.liquidityRepo.GetPeakSnapshot(ctx, mintAddress, now.Add(-s.window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peak liquidity: %w", err)
+	}
+
+	snapshot := &models.LiquiditySnapshot{
+		MintAddress:  mintAddress,
+		LiquidityUSD: liquidityUSD,
+	}
+	if err := s.liquidityRepo.CreateSnapshot(ctx, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to save liquidity snapshot: %w", err)
+	}
+
+	if peak == nil || peak.LiquidityUSD <= 0 {
+		return nil, nil
+	}
+	dropPercent := (peak.LiquidityUSD - snapshot.LiquidityUSD) / peak.LiquidityUSD * 100
+	if dropPercent < s.dropPercent {
+		return nil, nil
+	}
+
+	// One alert per window, so a pull is not re-announced on every check while liquidity stays low
+	latest, err := s.liquidityRepo.GetLatestAlert(ctx, mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest liquidity alert: %w", err)
+	}
+	if latest != nil && latest.CreatedAt.After(now.Add(-s.window)) {
+		return nil, nil
+	}
+
+	alert := &models.LiquidityAlert{
+		MintAddress:      mintAddress,
+		PeakLiquidityUSD: peak.LiquidityUSD,
+		PeakAt:           peak.CreatedAt,
+		LiquidityUSD:     snapshot.LiquidityUSD,
+		DropPercent:      dropPercent,
+		WindowMinutes:    int(s.window.Minutes()),
+	}
+	if err := s.liquidityRepo.CreateAlert(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to save liquidity alert: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"peak":         alert.PeakLiquidityUSD,
+		"liquidity":    alert.LiquidityUSD,
+		"drop_percent": alert.DropPercent,
+	}).Warn("Liquidity pull detected")
+
+	s.notifyRooms(ctx, alert)
+	return alert, nil
+}
+
+// currentLiquidity reads the token's liquidity from the market data provider, summing the pools of the pools
+// provider when the market data provider fails or reports none
+func (s *liquidityService) currentLiquidity(ctx context.Context, mintAddress string) (float64, error) {
+	info, err := s.marketData.GetTokenInfo(ctx, mintAddress)
+	if err == nil && info.Data.Liquidity > 0 {
+		return info.Data.Liquidity, nil
+	}
+
+	pools, poolsErr := s.pools.GetPools(ctx, mintAddress)
+	if poolsErr != nil {
+		if err != nil {
+			return 0, fmt.Errorf("%w; pools: %v", err, poolsErr)
+		}
+		// The provider's zero stands when the pools cannot be checked
+		if errors.Is(poolsErr, feature.ErrFeatureDisabled) {
+			return 0, nil
+		}
+		s.logger.WithFields(logrus.Fields{
+			"error":        poolsErr,
+			"mint_address": mintAddress,
+		}).Warn("Failed to get token pools")
+		return 0, nil
+	}
+	return token.TotalLiquidityUSD(pools), nil
+}
+
+// notifyRooms broadcasts the alert to every active room bound to the token
+func (s *liquidityService) notifyRooms(ctx context.Context, alert *models.LiquidityAlert) {
+	rooms, err := s.roomRepo.GetActiveByToken(ctx, alert.MintAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": alert.MintAddress,
+		}).Error("Failed to get rooms for liquidity alert")
+		return
+	}
+
+	for _, tradeRoom := range rooms {
+		if err := s.wsService.NotifyLiquidityAlert(tradeRoom.RoomID, alert); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"room_id": tradeRoom.RoomID,
+			}).Warn("Failed to broadcast liquidity alert")
+		}
+	}
+}
+
+func (s *liquidityService) GetRoomLiquidity(ctx context.Context, roomID string, since time.Time) (*RoomLiquidity, error) {
+	mintAddress, err := s.roomToken(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.liquidityRepo.GetSnapshots(ctx, mintAddress, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get liquidity snapshots: %w", err)
+	}
+	alerts, err := s.liquidityRepo.GetAlerts(ctx, mintAddress, roomLiquidityAlerts, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get liquidity alerts: %w", err)
+	}
+
+	return &RoomLiquidity{
+		TokenAddress: mintAddress,
+		Snapshots:    snapshots,
+		Alerts:       alerts,
+	}, nil
+}
+
+func (s *liquidityService) GetRoomAlerts(ctx context.Context, roomID string, limit, offset int) ([]*models.LiquidityAlert, error) {
+	mintAddress, err := s.roomToken(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return s.liquidityRepo.GetAlerts(ctx, mintAddress, limit, offset)
+}
+
+// roomToken resolves the mint address a room is bound to
+func (s *liquidityService) roomToken(ctx context.Context, roomID string) (string, error) {
+	tradeRoom, err := s.roomRepo.GetByRoomID(ctx, roomID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get room: %w", err)
+	}
+	if tradeRoom == nil {
+		return "", room.ErrRoomNotFound
+	}
+
+	if tradeRoom.TokenAddress != nil && *tradeRoom.TokenAddress != "" {
+		return *tradeRoom.TokenAddress, nil
+	}
+	if tradeRoom.Token != nil {
+		return tradeRoom.Token.MintAddress, nil
+	}
+	return "", ErrRoomHasNoToken
+}
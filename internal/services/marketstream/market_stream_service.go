@@ -0,0 +1,217 @@
+package marketstream
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+const (
+	defaultPriceMoveThresholdPercent = 10.0
+	defaultPriceMoveWindow           = time.Hour
+	defaultTopRank                   = 100
+)
+
+// TrendingChange is a token that entered, moved within or left the streamed top of a trending ranking
+type TrendingChange struct {
+	MintAddress  string  `json:"mint_address"`
+	Symbol       string  `json:"symbol"`
+	Rank         int     `json:"rank"`          // 0 when the token left the top
+	PreviousRank int     `json:"previous_rank"` // 0 when the token entered the top
+	PriceUSD     float64 `json:"price_usd,omitempty"`
+}
+
+// TrendingUpdate is the data of a trending_update message: the changes of one trending sync, best rank first
+type TrendingUpdate struct {
+	Timeframe string            `json:"timeframe"`
+	Changes   []*TrendingChange `json:"changes"`
+}
+
+// PriceMove is the data of a price_move message
+type PriceMove struct {
+	MintAddress      string    `json:"mint_address"`
+	Symbol           string    `json:"symbol,omitempty"`
+	PriceUSD         float64   `json:"price_usd"`
+	PreviousPriceUSD float64   `json:"previous_price_usd"`
+	ChangePercent    float64   `json:"change_percent"`
+	Since            time.Time `json:"since"` // when the previous price was seen
+}
+
+// MarketStreamService turns trending syncs, token discoveries and price updates into market stream events
+type MarketStreamService interface {
+	OnTrendingSync(ctx context.Context, timeframe string, previous, current []*models.TokenTrendingRanking)
+	OnPrice(ctx context.Context, mintAddress string, priceUSD float64)
+	Start() // streams discoveries until Stop
+	Stop()
+}
+
+type marketStreamService struct {
+	tokenRepo        repositories.TokenRepository
+	discoveryService token.DiscoveryService
+	wsService        room.WebSocketService
+	thresholdPercent float64
+	window           time.Duration
+	topRank          int
+	logger           *logrus.Logger
+
+	referencesMu sync.Mutex
+	references   map[string]priceReference // mint -> price moves are measured from
+
+	unsubscribe func()
+}
+
+type priceReference struct {
+	PriceUSD float64
+	SeenAt   time.Time
+}
+
+// NewMarketStreamService creates a new market stream service instance
+func NewMarketStreamService(
+	tokenRepo repositories.TokenRepository,
+	discoveryService token.DiscoveryService,
+	wsService room.WebSocketService,
+	cfg *config.MarketStreamConfig,
+	logger *logrus.Logger,
+) MarketStreamService {
+	s := &marketStreamService{
+		tokenRepo:        tokenRepo,
+		discoveryService: discoveryService,
+		wsService:        wsService,
+		thresholdPercent: cfg.PriceMoveThresholdPercent,
+		window:           cfg.PriceMoveWindow,
+		topRank:          cfg.TopRank,
+		logger:           logger,
+		references:       make(map[string]priceReference),
+	}
+	if s.thresholdPercent <= 0 {
+		s.thresholdPercent = defaultPriceMoveThresholdPercent
+	}
+	if s.window <= 0 {
+		s.window = defaultPriceMoveWindow
+	}
+	if s.topRank <= 0 {
+		s.topRank = defaultTopRank
+	}
+	return s
+}
+
+// OnTrendingSync streams the tokens that entered, moved within or left the top of the rebuilt ranking
+func (s *marketStreamService) OnTrendingSync(ctx context.Context, timeframe string, previous, current []*models.TokenTrendingRanking) {
+	before := make(map[string]*models.TokenTrendingRanking, len(previous))
+	for _, ranking := range previous {
+		if ranking.Rank <= s.topRank {
+			before[ranking.Token.MintAddress] = ranking
+		}
+	}
+
+	changes := []*TrendingChange{}
+	for _, ranking := range current {
+		if ranking.Rank > s.topRank {
+			continue
+		}
+		earlier, ok := before[ranking.Token.MintAddress]
+		delete(before, ranking.Token.MintAddress)
+		if ok && earlier.Rank == ranking.Rank {
+			continue
+		}
+
+		change := &TrendingChange{
+			MintAddress: ranking.Token.MintAddress,
+			Symbol:      ranking.Token.Symbol,
+			Rank:        ranking.Rank,
+			PriceUSD:    ranking.PriceUSD,
+		}
+		if ok {
+			change.PreviousRank = earlier.Rank
+		}
+		changes = append(changes, change)
+	}
+	// Whatever is left of the previous top dropped out of it
+	for _, earlier := range previous {
+		if _, left := before[earlier.Token.MintAddress]; left {
+			changes = append(changes, &TrendingChange{
+				MintAddress:  earlier.Token.MintAddress,
+				Symbol:       earlier.Token.Symbol,
+				PreviousRank: earlier.Rank,
+			})
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	sent := s.wsService.PublishMarket(room.MarketTopicTrending, &room.Message{
+		Type: room.MessageTypeTrendingUpdate,
+		Data: &TrendingUpdate{Timeframe: timeframe, Changes: changes},
+	})
+	s.logger.WithFields(logrus.Fields{
+		"timeframe": timeframe,
+		"changes":   len(changes),
+		"sent":      sent,
+	}).Debug("Trending changes streamed")
+}
+
+// OnPrice streams a price move once the price is the configured percentage away from the reference price.
+// The reference is the price last streamed, or the first price seen in the current window.
+func (s *marketStreamService) OnPrice(ctx context.Context, mintAddress string, priceUSD float64) {
+	now := time.Now()
+
+	s.referencesMu.Lock()
+	reference, ok := s.references[mintAddress]
+	if !ok || reference.PriceUSD <= 0 || now.Sub(reference.SeenAt) > s.window {
+		s.references[mintAddress] = priceReference{PriceUSD: priceUSD, SeenAt: now}
+		s.referencesMu.Unlock()
+		return
+	}
+	changePercent := (priceUSD - reference.PriceUSD) / reference.PriceUSD * 100
+	if math.Abs(changePercent) < s.thresholdPercent {
+		s.referencesMu.Unlock()
+		return
+	}
+	s.references[mintAddress] = priceReference{PriceUSD: priceUSD, SeenAt: now}
+	s.referencesMu.Unlock()
+
+	move := &PriceMove{
+		MintAddress:      mintAddress,
+		PriceUSD:         priceUSD,
+		PreviousPriceUSD: reference.PriceUSD,
+		ChangePercent:    changePercent,
+		Since:            reference.SeenAt,
+	}
+	if tokenInfo, err := s.tokenRepo.GetByMintAddress(ctx, mintAddress); err == nil && tokenInfo != nil {
+		move.Symbol = tokenInfo.Symbol
+	}
+
+	s.wsService.PublishMarket(room.MarketTopicPriceMoves, &room.Message{
+		Type: room.MessageTypePriceMove,
+		Data: move,
+	})
+}
+
+func (s *marketStreamService) Start() {
+	discoveries, unsubscribe := s.discoveryService.Subscribe()
+	s.unsubscribe = unsubscribe
+
+	go func() {
+		for discovery := range discoveries {
+			s.wsService.PublishMarket(room.MarketTopicNewTokens, &room.Message{
+				Type: room.MessageTypeNewToken,
+				Data: discovery,
+			})
+		}
+	}()
+}
+
+func (s *marketStreamService) Stop() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+}
@@ -0,0 +1,176 @@
+package momentum
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+const (
+	defaultMinRankGain           = 10
+	defaultMaxPriceChangePercent = 5.0
+	defaultTopRank               = 50
+	defaultCooldown              = 6 * time.Hour
+)
+
+// MomentumService watches trending rank moves for divergence from price moves and alerts on early momentum
+type MomentumService interface {
+	OnTrendingSync(ctx context.Context, timeframe string, previous, current []*models.TokenTrendingRanking)
+	ListAlerts(ctx context.Context, limit, offset int) ([]*models.MomentumAlert, error)
+}
+
+type momentumService struct {
+	tokenRepo             repositories.TokenRepository
+	roomRepo              repositories.RoomRepository
+	wsService             room.WebSocketService
+	minRankGain           int
+	maxPriceChangePercent float64
+	topRank               int
+	cooldown              time.Duration
+	logger                *logrus.Logger
+}
+
+// NewMomentumService creates a new momentum alert service instance
+func NewMomentumService(
+	tokenRepo repositories.TokenRepository,
+	roomRepo repositories.RoomRepository,
+	wsService room.WebSocketService,
+	cfg *config.MomentumConfig,
+	logger *logrus.Logger,
+) MomentumService {
+	s := &momentumService{
+		tokenRepo:             tokenRepo,
+		roomRepo:              roomRepo,
+		wsService:             wsService,
+		minRankGain:           cfg.MinRankGain,
+		maxPriceChangePercent: cfg.MaxPriceChangePercent,
+		topRank:               cfg.TopRank,
+		cooldown:              cfg.Cooldown,
+		logger:                logger,
+	}
+	if s.minRankGain <= 0 {
+		s.minRankGain = defaultMinRankGain
+	}
+	if s.maxPriceChangePercent <= 0 {
+		s.maxPriceChangePercent = defaultMaxPriceChangePercent
+	}
+	if s.topRank <= 0 {
+		s.topRank = defaultTopRank
+	}
+	if s.cooldown <= 0 {
+		s.cooldown = defaultCooldown
+	}
+	return s
+}
+
+// OnTrendingSync compares a rebuilt trending ranking with the one it replaced and alerts on tokens that
+// climbed at least the configured number of places while their price stayed within the configured band.
+// Tokens new to the ranking are skipped, as there is no earlier price to compare against.
+func (s *momentumService) OnTrendingSync(ctx context.Context, timeframe string, previous, current []*models.TokenTrendingRanking) {
+	before := make(map[string]*models.TokenTrendingRanking, len(previous))
+	for _, ranking := range previous {
+		before[ranking.Token.MintAddress] = ranking
+	}
+
+	var candidates []*models.MomentumAlert
+	for _, ranking := range current {
+		if ranking.Rank > s.topRank {
+			continue
+		}
+		earlier, ok := before[ranking.Token.MintAddress]
+		if !ok || earlier.PriceUSD <= 0 || ranking.PriceUSD <= 0 {
+			continue
+		}
+		if earlier.Rank-ranking.Rank < s.minRankGain {
+			continue
+		}
+		priceChange := (ranking.PriceUSD - earlier.PriceUSD) / earlier.PriceUSD * 100
+		if math.Abs(priceChange) > s.maxPriceChangePercent {
+			continue
+		}
+
+		candidates = append(candidates, &models.MomentumAlert{
+			TokenID:            ranking.TokenID,
+			MintAddress:        ranking.Token.MintAddress,
+			Symbol:             ranking.Token.Symbol,
+			Timeframe:          timeframe,
+			PreviousRank:       earlier.Rank,
+			Rank:               ranking.Rank,
+			PriceUSD:           ranking.PriceUSD,
+			PriceChangePercent: priceChange,
+		})
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	// One alert per token per cooldown, so a steady climb is not re-announced on every sync
+	mintAddresses := make([]string, len(candidates))
+	for i, alert := range candidates {
+		mintAddresses[i] = alert.MintAddress
+	}
+	recent, err := s.tokenRepo.GetLatestMomentumAlerts(ctx, mintAddresses, time.Now().Add(-s.cooldown))
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get recent momentum alerts")
+		return
+	}
+	cooling := make(map[string]bool, len(recent))
+	for _, alert := range recent {
+		cooling[alert.MintAddress] = true
+	}
+
+	for _, alert := range candidates {
+		if cooling[alert.MintAddress] {
+			continue
+		}
+		if err := s.tokenRepo.CreateMomentumAlert(ctx, alert); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": alert.MintAddress,
+			}).Error("Failed to save momentum alert")
+			continue
+		}
+
+		s.logger.WithFields(logrus.Fields{
+			"mint_address":  alert.MintAddress,
+			"previous_rank": alert.PreviousRank,
+			"rank":          alert.Rank,
+			"price_change":  alert.PriceChangePercent,
+		}).Info("Early momentum detected")
+
+		s.notify(ctx, alert)
+	}
+}
+
+// notify pushes the alert to the token's active rooms and to wallets that opted in
+func (s *momentumService) notify(ctx context.Context, alert *models.MomentumAlert) {
+	rooms, err := s.roomRepo.GetActiveByToken(ctx, alert.MintAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": alert.MintAddress,
+		}).Error("Failed to get rooms for momentum alert")
+	}
+
+	roomIDs := make([]string, len(rooms))
+	for i, tradeRoom := range rooms {
+		roomIDs[i] = tradeRoom.RoomID
+	}
+	delivered := s.wsService.NotifyMomentumAlert(roomIDs, alert)
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": alert.MintAddress,
+		"rooms":        len(roomIDs),
+		"connections":  delivered,
+	}).Debug("Momentum alert delivered")
+}
+
+func (s *momentumService) ListAlerts(ctx context.Context, limit, offset int) ([]*models.MomentumAlert, error) {
+	return s.tokenRepo.ListMomentumAlerts(ctx, limit, offset)
+}
@@ -0,0 +1,142 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/email"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// errNoRecipient is returned by a channel when the wallet has not set up where it should deliver
+var errNoRecipient = errors.New("no recipient configured")
+
+const defaultTelegramBaseURL = "https://api.telegram.org"
+
+// channel delivers a stored notification to its wallet
+type channel interface {
+	Send(ctx context.Context, settings *models.UserSettings, n *models.Notification) error
+}
+
+// newChannels returns the channels the configuration enables; the WebSocket is always enabled
+func newChannels(
+	cfg *config.NotificationConfig,
+	wsService room.WebSocketService,
+	emailService email.EmailService,
+	httpClient *http.Client,
+) map[models.NotificationChannel]channel {
+	channels := map[models.NotificationChannel]channel{
+		models.NotificationChannelWebSocket: &webSocketChannel{wsService: wsService},
+		models.NotificationChannelWebhook:   &webhookChannel{httpClient: httpClient},
+	}
+	if cfg.TelegramBotToken != "" {
+		channels[models.NotificationChannelTelegram] = &telegramChannel{
+			botToken:   cfg.TelegramBotToken,
+			httpClient: httpClient,
+		}
+	}
+	if emailService.Enabled() {
+		channels[models.NotificationChannelEmail] = &emailChannel{emailService: emailService}
+	}
+	return channels
+}
+
+// webSocketChannel pushes the notification to the wallet's open connections on every instance
+type webSocketChannel struct {
+	wsService room.WebSocketService
+}
+
+func (c *webSocketChannel) Send(ctx context.Context, settings *models.UserSettings, n *models.Notification) error {
+	c.wsService.NotifyWallet(n.WalletAddress, &room.Message{
+		Type:      room.MessageTypeNotification,
+		Data:      n,
+		Timestamp: n.CreatedAt,
+	})
+	return nil
+}
+
+// telegramChannel sends the notification as a message to the wallet's Telegram chat through the bot API
+type telegramChannel struct {
+	botToken   string
+	httpClient *http.Client
+}
+
+func (c *telegramChannel) Send(ctx context.Context, settings *models.UserSettings, n *models.Notification) error {
+	if settings.TelegramChatID == "" {
+		return errNoRecipient
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"chat_id":                  settings.TelegramChatID,
+		"text":                     messageText(n),
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, c.httpClient, defaultTelegramBaseURL+"/bot"+c.botToken+"/sendMessage", body)
+}
+
+// webhookChannel posts the notification as JSON to the wallet's webhook URL
+type webhookChannel struct {
+	httpClient *http.Client
+}
+
+func (c *webhookChannel) Send(ctx context.Context, settings *models.UserSettings, n *models.Notification) error {
+	if settings.WebhookURL == "" {
+		return errNoRecipient
+	}
+
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, c.httpClient, settings.WebhookURL, body)
+}
+
+// emailChannel mails the notification to the wallet's verified address with the template of its type
+type emailChannel struct {
+	emailService email.EmailService
+}
+
+func (c *emailChannel) Send(ctx context.Context, settings *models.UserSettings, n *models.Notification) error {
+	err := c.emailService.SendNotification(ctx, settings, n)
+	if errors.Is(err, email.ErrNotSubscribed) {
+		return errNoRecipient
+	}
+	return err
+}
+
+func postJSON(ctx context.Context, httpClient *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "solana-wallet-service/1.0")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// messageText is the plain text form of a notification used by chat channels
+func messageText(n *models.Notification) string {
+	if n.Body == "" {
+		return n.Title
+	}
+	return n.Title + "\n\n" + n.Body
+}
@@ -0,0 +1,305 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/email"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrInvalidNotification  = errors.New("invalid notification")
+)
+
+const (
+	defaultRetention             = 90 * 24 * time.Hour
+	defaultReadRetention         = 30 * 24 * time.Hour
+	defaultWhaleAlertMinValueUSD = 50000.0
+	defaultWebhookTimeout        = 10 * time.Second
+
+	// Followers alerted about a single whale trade
+	maxWhaleAlertFollowers = 1000
+
+	// How long delivery of one notification on all its external channels may take
+	deliveryTimeout = 30 * time.Second
+)
+
+// NotificationService stores per-wallet notifications and routes them to the channels the wallet chose
+type NotificationService interface {
+	Notify(ctx context.Context, req *NotifyRequest) (*models.Notification, error)
+	ListNotifications(ctx context.Context, walletAddress string, unreadOnly bool, limit, offset int) ([]*models.Notification, error)
+	CountUnread(ctx context.Context, walletAddress string) (int64, error)
+	MarkRead(ctx context.Context, walletAddress string, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, walletAddress string) (int64, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+	OnTrade(ctx context.Context, action *blockchain.AnalyzedWalletAction)                               // matches room.TradeListener
+	OnWatchedTrade(ctx context.Context, watcherAddress string, action *blockchain.AnalyzedWalletAction) // matches room.WatchListener
+}
+
+type notificationService struct {
+	notificationRepo      repositories.NotificationRepository
+	settingsRepo          repositories.UserSettingsRepository
+	traderRepo            repositories.TraderRepository
+	channels              map[models.NotificationChannel]channel
+	retention             time.Duration
+	readRetention         time.Duration
+	whaleAlertMinValueUSD float64
+	logger                *logrus.Logger
+}
+
+// NewNotificationService creates a new notification service instance
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	settingsRepo repositories.UserSettingsRepository,
+	traderRepo repositories.TraderRepository,
+	wsService room.WebSocketService,
+	emailService email.EmailService,
+	cfg *config.NotificationConfig,
+	logger *logrus.Logger,
+) NotificationService {
+	webhookTimeout := cfg.WebhookTimeout
+	if webhookTimeout <= 0 {
+		webhookTimeout = defaultWebhookTimeout
+	}
+
+	s := &notificationService{
+		notificationRepo:      notificationRepo,
+		settingsRepo:          settingsRepo,
+		traderRepo:            traderRepo,
+		channels:              newChannels(cfg, wsService, emailService, &http.Client{Timeout: webhookTimeout}),
+		retention:             cfg.Retention,
+		readRetention:         cfg.ReadRetention,
+		whaleAlertMinValueUSD: cfg.WhaleAlertMinValueUSD,
+		logger:                logger,
+	}
+	if s.retention <= 0 {
+		s.retention = defaultRetention
+	}
+	if s.readRetention <= 0 {
+		s.readRetention = defaultReadRetention
+	}
+	if s.whaleAlertMinValueUSD <= 0 {
+		s.whaleAlertMinValueUSD = defaultWhaleAlertMinValueUSD
+	}
+	return s
+}
+
+// NotifyRequest is a notification to one wallet; Data is stored as JSON
+type NotifyRequest struct {
+	WalletAddress string
+	Type          models.NotificationType
+	Title         string
+	Body          string
+	Data          interface{}
+}
+
+// Notify stores the notification and delivers it on the wallet's channels for its type in the background
+func (s *notificationService) Notify(ctx context.Context, req *NotifyRequest) (*models.Notification, error) {
+	if req.WalletAddress == "" || req.Title == "" || !req.Type.IsValid() {
+		return nil, ErrInvalidNotification
+	}
+
+	data := []byte("{}")
+	if req.Data != nil {
+		var err error
+		if data, err = json.Marshal(req.Data); err != nil {
+			return nil, fmt.Errorf("failed to encode notification data: %w", err)
+		}
+	}
+
+	notification := &models.Notification{
+		WalletAddress: req.WalletAddress,
+		Type:          req.Type,
+		Title:         req.Title,
+		Body:          req.Body,
+		Data:          string(data),
+		CreatedAt:     time.Now(),
+	}
+	if err := s.notificationRepo.Create(ctx, notification); err != nil {
+		return nil, fmt.Errorf("failed to store notification: %w", err)
+	}
+
+	go s.deliver(notification)
+	return notification, nil
+}
+
+// deliver sends the notification on each channel its wallet routes the type to, and records the ones that took it
+func (s *notificationService) deliver(notification *models.Notification) {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	settings, err := s.settingsRepo.GetByWallet(ctx, notification.WalletAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": notification.WalletAddress,
+		}).Warn("Failed to load user settings, delivering notification with default routing")
+	}
+	if settings == nil {
+		settings = models.NewDefaultUserSettings(notification.WalletAddress)
+	}
+
+	delivered := make([]models.NotificationChannel, 0)
+	for _, name := range settings.Routes()[notification.Type] {
+		ch, ok := s.channels[name]
+		if !ok {
+			s.logger.WithField("channel", name).Debug("Notification channel not configured, skipping")
+			continue
+		}
+		if err := ch.Send(ctx, settings, notification); err != nil {
+			if !errors.Is(err, errNoRecipient) {
+				s.logger.WithFields(logrus.Fields{
+					"error":           err,
+					"channel":         name,
+					"notification_id": notification.ID,
+				}).Warn("Failed to deliver notification")
+			}
+			continue
+		}
+		delivered = append(delivered, name)
+	}
+
+	notification.SetChannels(delivered)
+	if err := s.notificationRepo.UpdateChannels(ctx, notification.ID, notification.Channels); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err,
+			"notification_id": notification.ID,
+		}).Warn("Failed to record notification channels")
+	}
+}
+
+func (s *notificationService) ListNotifications(ctx context.Context, walletAddress string, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	return s.notificationRepo.ListByWallet(ctx, walletAddress, unreadOnly, limit, offset)
+}
+
+func (s *notificationService) CountUnread(ctx context.Context, walletAddress string) (int64, error) {
+	return s.notificationRepo.CountUnread(ctx, walletAddress)
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, walletAddress string, id uuid.UUID) error {
+	found, err := s.notificationRepo.MarkRead(ctx, walletAddress, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, walletAddress string) (int64, error) {
+	return s.notificationRepo.MarkAllRead(ctx, walletAddress, time.Now())
+}
+
+// PurgeExpired deletes notifications past their retention; read notifications are kept for a shorter time
+func (s *notificationService) PurgeExpired(ctx context.Context) (int64, error) {
+	now := time.Now()
+	deleted, err := s.notificationRepo.DeleteExpired(ctx, now.Add(-s.retention), now.Add(-s.readRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge notifications: %w", err)
+	}
+	if deleted > 0 {
+		s.logger.WithField("count", deleted).Info("Purged expired notifications")
+	}
+	return deleted, nil
+}
+
+// OnTrade alerts the followers of a wallet about its large trades
+func (s *notificationService) OnTrade(ctx context.Context, action *blockchain.AnalyzedWalletAction) {
+	if !action.Success || action.ValueUSD < s.whaleAlertMinValueUSD {
+		return
+	}
+
+	// Trades are seen on the subscription goroutine, which should not wait for followers to be alerted
+	go func() {
+		ctx := context.Background()
+		followers, err := s.traderRepo.GetFollowers(ctx, action.WalletAddress, maxWhaleAlertFollowers, 0)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"wallet": action.WalletAddress,
+			}).Error("Failed to get followers for whale alert")
+			return
+		}
+
+		title := fmt.Sprintf("Whale %s of $%.0f by %s", action.TransactionType, action.ValueUSD, shortAddress(action.WalletAddress))
+		body := tradeSummary(action)
+		for _, follower := range followers {
+			_, err := s.Notify(ctx, &NotifyRequest{
+				WalletAddress: follower.FollowerAddress,
+				Type:          models.NotificationTypeWhaleAlert,
+				Title:         title,
+				Body:          body,
+				Data:          action,
+			})
+			if err != nil {
+				s.logger.WithFields(logrus.Fields{
+					"error":    err,
+					"follower": follower.FollowerAddress,
+				}).Warn("Failed to notify follower of whale trade")
+			}
+		}
+	}()
+}
+
+// OnWatchedTrade notifies a wallet of a trade by a wallet it watches
+func (s *notificationService) OnWatchedTrade(ctx context.Context, watcherAddress string, action *blockchain.AnalyzedWalletAction) {
+	if !action.Success {
+		return
+	}
+
+	// Like whale alerts, the subscription goroutine does not wait for the notification to be stored
+	go func() {
+		_, err := s.Notify(context.Background(), &NotifyRequest{
+			WalletAddress: watcherAddress,
+			Type:          models.NotificationTypeWalletWatch,
+			Title:         fmt.Sprintf("%s made a %s", shortAddress(action.WalletAddress), action.TransactionType),
+			Body:          tradeSummary(action),
+			Data:          action,
+		})
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"watcher": watcherAddress,
+				"wallet":  action.WalletAddress,
+			}).Warn("Failed to notify watcher of wallet trade")
+		}
+	}()
+}
+
+// tradeSummary describes the tokens a trade swapped
+func tradeSummary(action *blockchain.AnalyzedWalletAction) string {
+	if action.InputToken == nil || action.OutputToken == nil {
+		return fmt.Sprintf("%s on %s: %s", action.TransactionType, action.Platform, action.Signature)
+	}
+	return fmt.Sprintf("Swapped %.4g %s for %.4g %s on %s",
+		action.InputToken.Amount, tokenName(action.InputToken),
+		action.OutputToken.Amount, tokenName(action.OutputToken),
+		action.Platform)
+}
+
+func tokenName(token *blockchain.TokenAmount) string {
+	if token.Symbol != "" {
+		return token.Symbol
+	}
+	return shortAddress(token.Mint)
+}
+
+func shortAddress(address string) string {
+	if len(address) <= 10 {
+		return address
+	}
+	return address[:4] + "..." + address[len(address)-4:]
+}
@@ -0,0 +1,181 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+)
+
+const wrappedSOLMint = "So11111111111111111111111111111111111111112"
+
+// PortfolioService defines the interface for wallet portfolio valuation and history
+type PortfolioService interface {
+	SnapshotWallet(ctx context.Context, walletAddress string) (*models.PortfolioSnapshot, error)
+	SnapshotTrackedWallets(ctx context.Context) (int, error)
+	GetPerformance(ctx context.Context, walletAddress string, days int) (*PerformanceResult, error)
+}
+
+type portfolioService struct {
+	portfolioRepo        repositories.PortfolioRepository
+	prices               blockchain.PriceAggregator
+	transactionProcessor blockchain.TransactionProcessor
+	logger               *logrus.Logger
+}
+
+// NewPortfolioService creates a new portfolio service instance
+func NewPortfolioService(
+	portfolioRepo repositories.PortfolioRepository,
+	prices blockchain.PriceAggregator,
+	transactionProcessor blockchain.TransactionProcessor,
+	logger *logrus.Logger,
+) PortfolioService {
+	return &portfolioService{
+		portfolioRepo:        portfolioRepo,
+		prices:               prices,
+		transactionProcessor: transactionProcessor,
+		logger:               logger,
+	}
+}
+
+// PerformanceResult is a wallet's value-over-time series with drawdown statistics
+type PerformanceResult struct {
+	WalletAddress      string             `json:"wallet_address"`
+	Days               int                `json:"days"`
+	Series             []PerformancePoint `json:"series"`
+	StartValueUSD      float64            `json:"start_value_usd"`
+	CurrentValueUSD    float64            `json:"current_value_usd"`
+	PeakValueUSD       float64            `json:"peak_value_usd"`
+	ReturnPercent      float64            `json:"return_percent"`
+	MaxDrawdownPercent float64            `json:"max_drawdown_percent"` // largest peak-to-trough decline
+	CurrentDrawdown    float64            `json:"current_drawdown_percent"`
+}
+
+type PerformancePoint struct {
+	Date     time.Time `json:"date"`
+	ValueUSD float64   `json:"value_usd"`
+}
+
+// SnapshotWallet values a wallet's current holdings and stores today's snapshot
+func (s *portfolioService) SnapshotWallet(ctx context.Context, walletAddress string) (*models.PortfolioSnapshot, error) {
+	balances, err := s.transactionProcessor.GetWalletBalances(walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet balances: %w", err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	snapshot, err := s.portfolioRepo.GetSnapshot(ctx, walletAddress, today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing snapshot: %w", err)
+	}
+	if snapshot == nil {
+		snapshot = &models.PortfolioSnapshot{
+			WalletAddress: walletAddress,
+			SnapshotDate:  today,
+		}
+	}
+
+	snapshot.SOLBalance = balances.SOLBalance
+	snapshot.SOLValueUSD = 0
+	if solPrice, ok := s.prices.PriceUSD(ctx, wrappedSOLMint); ok {
+		snapshot.SOLValueUSD = balances.SOLBalance * solPrice
+	}
+
+	snapshot.TokenValueUSD = 0
+	snapshot.TokenCount = len(balances.Tokens)
+	snapshot.UnpricedCount = 0
+	for _, holding := range balances.Tokens {
+		price, ok := s.prices.PriceUSD(ctx, holding.Mint)
+		if !ok {
+			snapshot.UnpricedCount++
+			continue
+		}
+		snapshot.TokenValueUSD += holding.Amount * price
+	}
+	snapshot.TotalValueUSD = snapshot.SOLValueUSD + snapshot.TokenValueUSD
+
+	if err := s.portfolioRepo.SaveSnapshot(ctx, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// SnapshotTrackedWallets snapshots every wallet that has a follower or a room membership
+func (s *portfolioService) SnapshotTrackedWallets(ctx context.Context) (int, error) {
+	wallets, err := s.portfolioRepo.GetTrackedWallets(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tracked wallets: %w", err)
+	}
+
+	snapshotted := 0
+	for _, wallet := range wallets {
+		if ctx.Err() != nil {
+			return snapshotted, ctx.Err()
+		}
+		if _, err := s.SnapshotWallet(ctx, wallet); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"wallet": wallet,
+			}).Warn("Failed to snapshot wallet portfolio")
+			continue
+		}
+		snapshotted++
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"tracked":     len(wallets),
+		"snapshotted": snapshotted,
+	}).Info("Portfolio snapshots completed")
+
+	return snapshotted, nil
+}
+
+// GetPerformance returns the stored value series of a wallet over the last days
+func (s *portfolioService) GetPerformance(ctx context.Context, walletAddress string, days int) (*PerformanceResult, error) {
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -days)
+	snapshots, err := s.portfolioRepo.GetSnapshots(ctx, walletAddress, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshots: %w", err)
+	}
+
+	result := &PerformanceResult{
+		WalletAddress: walletAddress,
+		Days:          days,
+		Series:        make([]PerformancePoint, 0, len(snapshots)),
+	}
+	if len(snapshots) == 0 {
+		return result, nil
+	}
+
+	for _, snapshot := range snapshots {
+		value := snapshot.TotalValueUSD
+		result.Series = append(result.Series, PerformancePoint{
+			Date:     snapshot.SnapshotDate,
+			ValueUSD: value,
+		})
+
+		if value > result.PeakValueUSD {
+			result.PeakValueUSD = value
+		}
+		if result.PeakValueUSD > 0 {
+			drawdown := (result.PeakValueUSD - value) / result.PeakValueUSD * 100
+			if drawdown > result.MaxDrawdownPercent {
+				result.MaxDrawdownPercent = drawdown
+			}
+			result.CurrentDrawdown = drawdown
+		}
+	}
+
+	result.StartValueUSD = snapshots[0].TotalValueUSD
+	result.CurrentValueUSD = snapshots[len(snapshots)-1].TotalValueUSD
+	if result.StartValueUSD > 0 {
+		result.ReturnPercent = (result.CurrentValueUSD - result.StartValueUSD) / result.StartValueUSD * 100
+	}
+
+	return result, nil
+}
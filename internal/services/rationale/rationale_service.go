@@ -0,0 +1,200 @@
+package rationale
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/feature"
+)
+
+const (
+	defaultDailyTokenBudget = 20000
+	defaultTimeout          = 8 * time.Second
+	priorTradesScan         = 50
+	maxPriorTrades          = 5
+)
+
+// RationaleService generates one-line AI rationales of room trades.
+// Rationales are only generated for rooms that enable them, while the ai_rationale feature flag is on for the
+// room and while the room's daily token budget lasts; an empty string means no rationale, and failures never
+// block the trade itself.
+type RationaleService interface {
+	ForTradeEvent(ctx context.Context, tradeRoom *models.TradeRoom, event *models.TradeEvent) string
+	ForDetectedTrade(ctx context.Context, tradeRoom *models.TradeRoom, action *blockchain.AnalyzedWalletAction) string
+}
+
+type rationaleService struct {
+	roomRepo  repositories.RoomRepository
+	aiService ai.LangChainService
+	flags     feature.FlagService
+	config    *config.RationaleConfig
+	logger    *logrus.Logger
+}
+
+// NewRationaleService creates a new trade rationale service instance
+func NewRationaleService(
+	roomRepo repositories.RoomRepository,
+	aiService ai.LangChainService,
+	flags feature.FlagService,
+	config *config.RationaleConfig,
+	logger *logrus.Logger,
+) RationaleService {
+	return &rationaleService{
+		roomRepo:  roomRepo,
+		aiService: aiService,
+		flags:     flags,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// ForTradeEvent explains a trade event recorded by a member; the caller stores it on the event
+func (s *rationaleService) ForTradeEvent(ctx context.Context, tradeRoom *models.TradeRoom, event *models.TradeEvent) string {
+	value := event.ServerValueUSD
+	if value == 0 {
+		value = event.ValueUSD
+	}
+	trade := &ai.TradeContext{
+		WalletAddress: event.WalletAddress,
+		TokenAddress:  event.TokenAddress,
+		Side:          string(event.EventType),
+		Amount:        event.Amount,
+		ValueUSD:      value,
+	}
+	return s.generate(ctx, tradeRoom, trade, event.TxSignature, models.TradeRationaleSourceRecorded)
+}
+
+// ForDetectedTrade explains an on-chain buy or sell of a subscribed member wallet; other swaps get no rationale
+func (s *rationaleService) ForDetectedTrade(ctx context.Context, tradeRoom *models.TradeRoom, action *blockchain.AnalyzedWalletAction) string {
+	var token *blockchain.TokenAmount
+	switch action.TransactionType {
+	case string(models.TradeEventTypeBuy):
+		token = action.OutputToken
+	case string(models.TradeEventTypeSell):
+		token = action.InputToken
+	}
+	if token == nil {
+		return ""
+	}
+
+	trade := &ai.TradeContext{
+		WalletAddress: action.WalletAddress,
+		WalletLabels:  action.Labels,
+		TokenAddress:  token.Mint,
+		Side:          action.TransactionType,
+		Amount:        token.Amount,
+		ValueUSD:      action.ValueUSD,
+	}
+	return s.generate(ctx, tradeRoom, trade, action.Signature, models.TradeRationaleSourceDetected)
+}
+
+func (s *rationaleService) generate(ctx context.Context, tradeRoom *models.TradeRoom, trade *ai.TradeContext, signature string, source models.TradeRationaleSource) string {
+	if !tradeRoom.AIRationale || trade.TokenAddress == "" {
+		return ""
+	}
+	if !s.flags.Enabled(ctx, feature.FlagAIRationale, tradeRoom.RoomID) {
+		return ""
+	}
+
+	logger := s.logger.WithFields(logrus.Fields{
+		"room_id": tradeRoom.RoomID,
+		"wallet":  trade.WalletAddress,
+		"token":   trade.TokenAddress,
+		"source":  source,
+	})
+
+	budget, timeout := s.settings()
+	spent, err := s.roomRepo.SumRationaleTokens(ctx, tradeRoom.ID, time.Now().UTC().Truncate(24*time.Hour))
+	if err != nil {
+		logger.WithError(err).Warn("Failed to get rationale token usage")
+		return ""
+	}
+	if spent >= budget {
+		logger.WithField("spent", spent).Debug("Room rationale token budget spent, skipping rationale")
+		return ""
+	}
+
+	trade.RoomID = tradeRoom.RoomID
+	trade.PriorTrades = s.priorTrades(ctx, tradeRoom, trade)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	response, err := s.aiService.ExplainTrade(ctx, trade)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to generate trade rationale")
+		return ""
+	}
+	if response.Content == "" {
+		return ""
+	}
+
+	record := &models.TradeRationale{
+		RoomID:        tradeRoom.ID,
+		WalletAddress: trade.WalletAddress,
+		TokenAddress:  trade.TokenAddress,
+		TxSignature:   signature,
+		Source:        source,
+		Rationale:     response.Content,
+		TokensUsed:    response.Usage.TotalTokens,
+	}
+	if err := s.roomRepo.CreateTradeRationale(ctx, record); err != nil {
+		// Unrecorded usage does not count against the budget, so the line is dropped as well
+		logger.WithError(err).Warn("Failed to store trade rationale")
+		return ""
+	}
+
+	return response.Content
+}
+
+// priorTrades returns the wallet's latest trades of the token in the room
+func (s *rationaleService) priorTrades(ctx context.Context, tradeRoom *models.TradeRoom, trade *ai.TradeContext) []ai.RoomTrade {
+	events, err := s.roomRepo.GetTradeEventsByWallet(ctx, trade.WalletAddress, priorTradesScan, 0)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": trade.WalletAddress,
+		}).Warn("Failed to get prior trades for rationale")
+		return nil
+	}
+
+	var prior []ai.RoomTrade
+	for _, event := range events {
+		if event.RoomID != tradeRoom.ID || event.TokenAddress != trade.TokenAddress {
+			continue
+		}
+		value := event.ServerValueUSD
+		if value == 0 {
+			value = event.ValueUSD
+		}
+		prior = append(prior, ai.RoomTrade{
+			WalletAddress: event.WalletAddress,
+			TokenAddress:  event.TokenAddress,
+			Side:          string(event.EventType),
+			Amount:        event.Amount,
+			ValueUSD:      value,
+			Time:          event.BlockTime.UTC().Format(time.RFC3339),
+		})
+		if len(prior) == maxPriorTrades {
+			break
+		}
+	}
+	return prior
+}
+
+func (s *rationaleService) settings() (int, time.Duration) {
+	budget, timeout := defaultDailyTokenBudget, defaultTimeout
+	if s.config != nil && s.config.DailyTokenBudget > 0 {
+		budget = s.config.DailyTokenBudget
+	}
+	if s.config != nil && s.config.Timeout > 0 {
+		timeout = s.config.Timeout
+	}
+	return budget, timeout
+}
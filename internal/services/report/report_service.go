@@ -0,0 +1,385 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
+	"github.com/emiyaio/solana-wallet-service/internal/services/email"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+const (
+	digestTopTrades = 5
+	digestTopShares = 5
+	roomPageSize    = 100
+)
+
+// ReportService generates and serves scheduled room reports
+type ReportService interface {
+	GenerateDailyDigests(ctx context.Context) (int, error)
+	GenerateRoomDigest(ctx context.Context, roomID string, date time.Time) (*models.RoomDigest, error)
+	GetDigests(ctx context.Context, roomID string, limit, offset int) ([]*models.RoomDigest, error)
+
+	// Creator analytics
+	RollupRoomStats(ctx context.Context) (int, error)
+	GetRoomAnalytics(ctx context.Context, roomID, creatorAddress string, days int) (*RoomAnalytics, error)
+}
+
+type reportService struct {
+	reportRepo   repositories.ReportRepository
+	roomRepo     repositories.RoomRepository
+	aiService    ai.LangChainService
+	wsService    room.WebSocketService
+	emailService email.EmailService
+	logger       *logrus.Logger
+}
+
+// NewReportService creates a new report service instance
+func NewReportService(
+	reportRepo repositories.ReportRepository,
+	roomRepo repositories.RoomRepository,
+	aiService ai.LangChainService,
+	wsService room.WebSocketService,
+	emailService email.EmailService,
+	logger *logrus.Logger,
+) ReportService {
+	return &reportService{
+		reportRepo:   reportRepo,
+		roomRepo:     roomRepo,
+		aiService:    aiService,
+		wsService:    wsService,
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// GenerateDailyDigests builds yesterday's digest (UTC) for every active room that does not have one yet
+func (s *reportService) GenerateDailyDigests(ctx context.Context) (int, error) {
+	date := time.Now().UTC().Truncate(24 * time.Hour).AddDate(0, 0, -1)
+	generated := 0
+
+	for offset := 0; ; offset += roomPageSize {
+		rooms, err := s.roomRepo.List(ctx, models.RoomStatusActive, roomPageSize, offset)
+		if err != nil {
+			return generated, fmt.Errorf("failed to list active rooms: %w", err)
+		}
+
+		for _, tradeRoom := range rooms {
+			digest, err := s.generateDigest(ctx, tradeRoom, date)
+			if err != nil {
+				s.logger.WithFields(logrus.Fields{
+					"error":   err,
+					"room_id": tradeRoom.RoomID,
+				}).Error("Failed to generate room digest")
+				continue
+			}
+			if digest != nil {
+				generated++
+			}
+		}
+
+		if len(rooms) < roomPageSize {
+			break
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"date":      date.Format("2006-01-02"),
+		"generated": generated,
+	}).Info("Daily room digests generated")
+
+	return generated, nil
+}
+
+// GenerateRoomDigest builds the digest of a room for the UTC day containing date
+func (s *reportService) GenerateRoomDigest(ctx context.Context, roomID string, date time.Time) (*models.RoomDigest, error) {
+	tradeRoom, err := s.getRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return s.generateDigest(ctx, tradeRoom, date.UTC().Truncate(24*time.Hour))
+}
+
+func (s *reportService) GetDigests(ctx context.Context, roomID string, limit, offset int) ([]*models.RoomDigest, error) {
+	tradeRoom, err := s.getRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return s.reportRepo.ListDigests(ctx, tradeRoom.ID, limit, offset)
+}
+
+func (s *reportService) getRoom(ctx context.Context, roomID string) (*models.TradeRoom, error) {
+	tradeRoom, err := s.roomRepo.GetByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+	if tradeRoom == nil {
+		return nil, room.ErrRoomNotFound
+	}
+	return tradeRoom, nil
+}
+
+// generateDigest stores and posts the digest of one room day. Existing digests are returned as is;
+// rooms without any activity that day get no digest and nil is returned.
+func (s *reportService) generateDigest(ctx context.Context, tradeRoom *models.TradeRoom, date time.Time) (*models.RoomDigest, error) {
+	existing, err := s.reportRepo.GetDigest(ctx, tradeRoom.ID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest: %w", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	from, to := date, date.Add(24*time.Hour)
+
+	members, err := s.roomRepo.GetMembers(ctx, tradeRoom.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room members: %w", err)
+	}
+	events, err := s.roomRepo.GetTradeEventsBetween(ctx, tradeRoom.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade events: %w", err)
+	}
+	infos, err := s.roomRepo.GetSharedInfosBetween(ctx, tradeRoom.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared infos: %w", err)
+	}
+
+	previous, err := s.reportRepo.GetLatestDigest(ctx, tradeRoom.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous digest: %w", err)
+	}
+	memberChange := 0
+	if previous != nil {
+		memberChange = len(members) - previous.MemberCount
+	}
+
+	trades, volume := topTrades(events)
+	shares := topShares(infos)
+	if len(events) == 0 && len(shares) == 0 && memberChange == 0 {
+		return nil, nil
+	}
+
+	digest := &models.RoomDigest{
+		RoomID:            tradeRoom.ID,
+		DigestDate:        date,
+		MemberCount:       len(members),
+		MemberCountChange: memberChange,
+		TradeCount:        len(events),
+		TradeVolumeUSD:    volume,
+	}
+	if tradeRoom.TokenAddress != nil {
+		digest.TokenAddress = *tradeRoom.TokenAddress
+	}
+
+	tradesJSON, err := json.Marshal(trades)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode top trades: %w", err)
+	}
+	sharesJSON, err := json.Marshal(shares)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode top shares: %w", err)
+	}
+	digest.TopTrades = string(tradesJSON)
+	digest.TopShares = string(sharesJSON)
+
+	// Rooms watching a basket get a market summary per bound token
+	if s.aiService != nil {
+		var summaries []string
+		for _, mint := range tradeRoom.BoundTokenAddresses() {
+			summary, err := s.aiService.SummarizeMarket(ctx, mint)
+			if err != nil {
+				s.logger.WithFields(logrus.Fields{
+					"error":   err,
+					"room_id": tradeRoom.RoomID,
+					"token":   mint,
+				}).Warn("Failed to get market summary for digest")
+				continue
+			}
+			summaries = append(summaries, summary)
+		}
+		digest.MarketSummary = strings.Join(summaries, "\n\n")
+	}
+
+	info := &models.SharedInfo{
+		RoomID:        tradeRoom.ID,
+		SharerAddress: models.DigestSharerAddress,
+		Type:          models.SharedInfoTypeDigest,
+		Title:         fmt.Sprintf("Daily digest %s", date.Format("2006-01-02")),
+		Content:       formatDigest(digest, trades, shares),
+		Metadata:      fmt.Sprintf(`{"digest_date":%q}`, date.Format("2006-01-02")),
+		IsSticky:      true,
+	}
+	if err := s.roomRepo.CreateSharedInfo(ctx, info); err != nil {
+		return nil, fmt.Errorf("failed to post digest: %w", err)
+	}
+	digest.SharedInfoID = &info.ID
+
+	if err := s.reportRepo.SaveDigest(ctx, digest); err != nil {
+		return nil, fmt.Errorf("failed to save digest: %w", err)
+	}
+
+	// Only the latest digest stays pinned
+	if previous != nil && previous.SharedInfoID != nil {
+		s.unpin(ctx, *previous.SharedInfoID)
+	}
+
+	// Rooms without open connections have nothing to notify
+	_ = s.wsService.NotifySharedInfo(tradeRoom.RoomID, info)
+
+	// Members who bound an email get the digest mailed, unless they opted out
+	go s.mailDigest(tradeRoom, &email.Digest{
+		RoomName: "Room " + tradeRoom.RoomID,
+		Digest:   digest,
+		Trades:   trades,
+		Shares:   shares,
+		Text:     info.Content,
+	}, members)
+
+	s.logger.WithFields(logrus.Fields{
+		"room_id":     tradeRoom.RoomID,
+		"date":        date.Format("2006-01-02"),
+		"trade_count": digest.TradeCount,
+	}).Info("Room digest generated")
+
+	return digest, nil
+}
+
+func (s *reportService) mailDigest(tradeRoom *models.TradeRoom, digest *email.Digest, members []*models.RoomMember) {
+	wallets := make([]string, 0, len(members))
+	for _, member := range members {
+		wallets = append(wallets, member.WalletAddress)
+	}
+
+	sent, err := s.emailService.SendDigest(context.Background(), digest, wallets)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": tradeRoom.RoomID,
+		}).Warn("Failed to mail room digest")
+	}
+	if sent > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"room_id": tradeRoom.RoomID,
+			"sent":    sent,
+		}).Info("Room digest mailed")
+	}
+}
+
+func (s *reportService) unpin(ctx context.Context, infoID uuid.UUID) {
+	info, err := s.roomRepo.GetSharedInfoByID(ctx, infoID)
+	if err != nil || info == nil || !info.IsSticky {
+		return
+	}
+	info.IsSticky = false
+	if err := s.roomRepo.UpdateSharedInfo(ctx, info); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"info_id": infoID,
+		}).Warn("Failed to unpin previous digest")
+	}
+}
+
+// tradeValue prefers the server-side valuation over the client-supplied one
+func tradeValue(event *models.TradeEvent) float64 {
+	if event.ServerValueUSD > 0 {
+		return event.ServerValueUSD
+	}
+	return event.ValueUSD
+}
+
+// topTrades returns the largest trades by USD value and the total traded volume
+func topTrades(events []*models.TradeEvent) ([]models.DigestTrade, float64) {
+	trades := make([]models.DigestTrade, 0, len(events))
+	volume := 0.0
+	for _, event := range events {
+		value := tradeValue(event)
+		volume += value
+		trades = append(trades, models.DigestTrade{
+			WalletAddress: event.WalletAddress,
+			TokenAddress:  event.TokenAddress,
+			EventType:     event.EventType,
+			ValueUSD:      value,
+			Timestamp:     event.BlockTime,
+		})
+	}
+
+	sort.Slice(trades, func(i, j int) bool {
+		return trades[i].ValueUSD > trades[j].ValueUSD
+	})
+	if len(trades) > digestTopTrades {
+		trades = trades[:digestTopTrades]
+	}
+	return trades, volume
+}
+
+// topShares returns the member shares with the most reactions, ignoring earlier digests
+func topShares(infos []*models.SharedInfo) []models.DigestShare {
+	shares := make([]models.DigestShare, 0, len(infos))
+	for _, info := range infos {
+		if info.Type == models.SharedInfoTypeDigest {
+			continue
+		}
+		shares = append(shares, models.DigestShare{
+			ID:            info.ID,
+			SharerAddress: info.SharerAddress,
+			Type:          info.Type,
+			Title:         info.Title,
+			LikeCount:     info.LikeCount,
+		})
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].LikeCount > shares[j].LikeCount
+	})
+	if len(shares) > digestTopShares {
+		shares = shares[:digestTopShares]
+	}
+	return shares
+}
+
+// formatDigest renders the digest as the plain-text body of the room post
+func formatDigest(digest *models.RoomDigest, trades []models.DigestTrade, shares []models.DigestShare) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Members: %d (%+d)\n", digest.MemberCount, digest.MemberCountChange)
+	fmt.Fprintf(&sb, "Trades: %d, volume $%.2f\n", digest.TradeCount, digest.TradeVolumeUSD)
+
+	if len(trades) > 0 {
+		sb.WriteString("\nTop trades:\n")
+		for i, trade := range trades {
+			fmt.Fprintf(&sb, "%d. %s %s $%.2f of %s\n", i+1, shortAddress(trade.WalletAddress), trade.EventType, trade.ValueUSD, shortAddress(trade.TokenAddress))
+		}
+	}
+
+	if len(shares) > 0 {
+		sb.WriteString("\nMost liked:\n")
+		for i, share := range shares {
+			fmt.Fprintf(&sb, "%d. %s (%d reactions)\n", i+1, share.Title, share.LikeCount)
+		}
+	}
+
+	if digest.MarketSummary != "" {
+		sb.WriteString("\nMarket:\n")
+		sb.WriteString(digest.MarketSummary)
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func shortAddress(address string) string {
+	if len(address) <= 10 {
+		return address
+	}
+	return address[:4] + "..." + address[len(address)-4:]
+}
@@ -0,0 +1,129 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+const (
+	roomStatsRollupDays  = 2 // recent days are rolled up again on every run, as their events are still coming in
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 90
+	analyticsTopMembers  = 10
+)
+
+// RoomAnalyticsDay is a day of room activity with the member count at the end of the day
+type RoomAnalyticsDay struct {
+	*models.RoomDailyStat
+	Members int `json:"members"`
+}
+
+// RoomAnalytics is a creator's view of a room's growth and activity over the last days
+type RoomAnalytics struct {
+	RoomID         string                       `json:"room_id"`
+	Days           int                          `json:"days"`
+	From           time.Time                    `json:"from"`
+	Members        int                          `json:"members"`
+	MemberGrowth   int                          `json:"member_growth"` // joins minus leaves over the period
+	Messages       int                          `json:"messages"`
+	Shares         int                          `json:"shares"`
+	Trades         int                          `json:"trades"`
+	TradeVolumeUSD float64                      `json:"trade_volume_usd"`
+	Daily          []*RoomAnalyticsDay          `json:"daily"` // oldest first, days without activity included
+	TopMembers     []*models.RoomMemberActivity `json:"top_members"`
+	Retention      *models.RoomRetention        `json:"retention"` // of the wallets that joined over the period
+	GeneratedAt    time.Time                    `json:"generated_at"`
+}
+
+// RollupRoomStats recomputes the daily stats of every room for the last days from the room event log
+func (s *reportService) RollupRoomStats(ctx context.Context) (int, error) {
+	from := utcDay(time.Now()).AddDate(0, 0, -(roomStatsRollupDays - 1))
+	stats, err := s.reportRepo.AggregateRoomDailyStats(ctx, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to aggregate room stats: %w", err)
+	}
+	if err := s.reportRepo.SaveRoomDailyStats(ctx, stats); err != nil {
+		return 0, fmt.Errorf("failed to save room stats: %w", err)
+	}
+	return len(stats), nil
+}
+
+// GetRoomAnalytics summarizes the last days of a room for its creator. Daily figures come from the
+// rollup and trail live activity by up to the rollup interval.
+func (s *reportService) GetRoomAnalytics(ctx context.Context, roomID, creatorAddress string, days int) (*RoomAnalytics, error) {
+	tradeRoom, err := s.getRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if tradeRoom.CreatorAddress != creatorAddress {
+		return nil, room.ErrInsufficientPermission
+	}
+	if days <= 0 || days > maxAnalyticsDays {
+		days = defaultAnalyticsDays
+	}
+
+	today := utcDay(time.Now())
+	from := today.AddDate(0, 0, -(days - 1))
+	stats, err := s.reportRepo.GetRoomDailyStats(ctx, tradeRoom.ID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room stats: %w", err)
+	}
+	topMembers, err := s.reportRepo.GetMostActiveMembers(ctx, tradeRoom.ID, from, analyticsTopMembers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get most active members: %w", err)
+	}
+	retention, err := s.reportRepo.GetRetention(ctx, tradeRoom.ID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get retention: %w", err)
+	}
+	if retention.Joined > 0 {
+		retention.RetentionRate = float64(retention.Retained) / float64(retention.Joined) * 100
+		retention.ActivationRate = float64(retention.Active) / float64(retention.Joined) * 100
+	}
+
+	analytics := &RoomAnalytics{
+		RoomID:      tradeRoom.RoomID,
+		Days:        days,
+		From:        from,
+		Members:     tradeRoom.CurrentMembers,
+		TopMembers:  topMembers,
+		Retention:   retention,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	byDay := make(map[time.Time]*models.RoomDailyStat, len(stats))
+	for _, stat := range stats {
+		byDay[utcDay(stat.Day)] = stat
+	}
+	for day := from; !day.After(today); day = day.AddDate(0, 0, 1) {
+		stat, ok := byDay[day]
+		if !ok {
+			stat = &models.RoomDailyStat{RoomID: tradeRoom.ID, Day: day}
+		}
+		analytics.Daily = append(analytics.Daily, &RoomAnalyticsDay{RoomDailyStat: stat})
+		analytics.MemberGrowth += stat.Joins - stat.Leaves
+		analytics.Messages += stat.Messages
+		analytics.Shares += stat.Shares
+		analytics.Trades += stat.Trades
+		analytics.TradeVolumeUSD += stat.TradeVolumeUSD
+	}
+
+	// Member counts are walked back from the current count, undoing each later day's joins and leaves
+	members := tradeRoom.CurrentMembers
+	for i := len(analytics.Daily) - 1; i >= 0; i-- {
+		day := analytics.Daily[i]
+		day.Members = members
+		members -= day.Joins - day.Leaves
+	}
+
+	return analytics, nil
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,120 @@
+package room
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+var (
+	ErrBroadcastTypeNotAllowed = errors.New("message type cannot be broadcast")
+	ErrInvalidBroadcast        = errors.New("invalid broadcast data")
+)
+
+// MessageTypeAnnouncement is a message the room's creator, a moderator or an admin pushes to every member
+const MessageTypeAnnouncement MessageType = "announcement"
+
+const (
+	maxAnnouncementTitle = 100
+	maxAnnouncementText  = 1000
+)
+
+// broadcastTypes are the message types that may be broadcast through the API, each with the check of its data
+var broadcastTypes = map[MessageType]func(data json.RawMessage) (interface{}, error){
+	MessageTypeAnnouncement: decodeAnnouncement,
+}
+
+// AnnouncementData is the data of an announcement message
+type AnnouncementData struct {
+	Title string `json:"title,omitempty"`
+	Text  string `json:"text"`
+}
+
+// BroadcastRequest is a message pushed to a room through the API; an admin sends it as AdminName,
+// anyone else as SenderAddress, who must be the room's creator or a moderator
+type BroadcastRequest struct {
+	Type          MessageType
+	Data          json.RawMessage
+	SenderAddress string
+	AdminName     string
+}
+
+// Broadcast checks the sender and the message, broadcasts it to the room and records it in the room's event log
+func (ws *webSocketService) Broadcast(ctx context.Context, roomID string, req *BroadcastRequest) error {
+	decode, ok := broadcastTypes[req.Type]
+	if !ok {
+		return ErrBroadcastTypeNotAllowed
+	}
+	data, err := decode(req.Data)
+	if err != nil {
+		return err
+	}
+
+	room, err := ws.roomRepo.GetByRoomID(ctx, roomID)
+	if err != nil {
+		return fmt.Errorf("failed to get room: %w", err)
+	}
+	if room == nil {
+		return ErrRoomNotFound
+	}
+	if room.Status != models.RoomStatusActive {
+		return ErrRoomClosed
+	}
+
+	if req.AdminName == "" {
+		member, err := ws.roomRepo.GetMemberByAddress(ctx, room.ID, req.SenderAddress)
+		if err != nil {
+			return fmt.Errorf("failed to get member: %w", err)
+		}
+		if member == nil {
+			return ErrNotMember
+		}
+		if member.Role != models.MemberRoleCreator && member.Role != models.MemberRoleModerator {
+			return ErrInsufficientPermission
+		}
+	}
+
+	message := &Message{
+		Type: req.Type,
+		Data: data,
+		From: req.SenderAddress,
+	}
+	if err := ws.BroadcastToRoom(roomID, message); err != nil {
+		return err
+	}
+
+	appendRoomEvent(ctx, ws.roomRepo, ws.logger, room.ID, models.RoomEventBroadcast, req.SenderAddress, &models.RoomEventBroadcastPayload{
+		MessageType: string(req.Type),
+		Data:        data,
+		Admin:       req.AdminName,
+	})
+	return nil
+}
+
+func decodeAnnouncement(raw json.RawMessage) (interface{}, error) {
+	var data AnnouncementData
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBroadcast, err)
+	}
+
+	data.Title = strings.TrimSpace(data.Title)
+	data.Text = strings.TrimSpace(data.Text)
+	if data.Text == "" {
+		return nil, fmt.Errorf("%w: text is required", ErrInvalidBroadcast)
+	}
+	if utf8.RuneCountInString(data.Title) > maxAnnouncementTitle {
+		return nil, fmt.Errorf("%w: title exceeds %d characters", ErrInvalidBroadcast, maxAnnouncementTitle)
+	}
+	if utf8.RuneCountInString(data.Text) > maxAnnouncementText {
+		return nil, fmt.Errorf("%w: text exceeds %d characters", ErrInvalidBroadcast, maxAnnouncementText)
+	}
+	return &data, nil
+}
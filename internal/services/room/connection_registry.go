@@ -0,0 +1,247 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+const (
+	connectionRegistryPrefix = "ws:room:"    // hash per room: wallet -> owning instance
+	clusterChannel           = "ws:cluster"  // messages for every instance
+	clusterInstancePrefix    = "ws:cluster:" // messages for one instance
+)
+
+// Entries not refreshed within the TTL belong to instances that stopped without cleaning up
+const connectionEntryTTL = 90 * time.Second
+
+// ConnectionInfo records which instance holds a wallet's connection to a room
+type ConnectionInfo struct {
+	RoomID        string    `json:"room_id"`
+	WalletAddress string    `json:"wallet_address"`
+	InstanceID    string    `json:"instance_id"`
+	LastSeen      time.Time `json:"last_seen"`
+}
+
+// ConnectionRegistry shares room connection ownership between service instances and carries messages
+// between them, so connections held by another replica can still be reached
+type ConnectionRegistry interface {
+	InstanceID() string
+	Enabled() bool // false when running as a single instance without Redis
+
+	Register(ctx context.Context, roomID, walletAddress string) error
+	Unregister(ctx context.Context, roomID, walletAddress string) error // only removes entries owned by this instance
+	Refresh(ctx context.Context, connections []ConnectionInfo) error
+	RoomConnections(ctx context.Context, roomID string) ([]*ConnectionInfo, error)
+	Owner(ctx context.Context, roomID, walletAddress string) (*ConnectionInfo, error) // nil when not connected anywhere
+	CountConnections(ctx context.Context) (int, error)                                // across all rooms and instances
+
+	// Publish sends a payload to one instance, or to all instances when instanceID is empty
+	Publish(ctx context.Context, instanceID string, payload []byte) error
+	// Listen delivers payloads published to this instance or to all instances until ctx is done
+	Listen(ctx context.Context, handle func(payload []byte))
+}
+
+type redisConnectionRegistry struct {
+	client     *redis.Client
+	instanceID string
+	logger     *logrus.Logger
+}
+
+// NewConnectionRegistry creates a Redis-backed connection registry; with a nil client connections are only
+// known to the local instance
+func NewConnectionRegistry(client *redis.Client, logger *logrus.Logger) ConnectionRegistry {
+	return &redisConnectionRegistry{
+		client:     client,
+		instanceID: uuid.New().String(),
+		logger:     logger,
+	}
+}
+
+// unregisterScript deletes a connection entry only if the given instance still owns it; the wallet may
+// have reconnected through another instance in the meantime
+const unregisterScript = `
+local entry = redis.call('HGET', KEYS[1], ARGV[1])
+if not entry then
+	return 0
+end
+if cjson.decode(entry).instance_id ~= ARGV[2] then
+	return 0
+end
+return redis.call('HDEL', KEYS[1], ARGV[1])
+`
+
+func (r *redisConnectionRegistry) InstanceID() string {
+	return r.instanceID
+}
+
+func (r *redisConnectionRegistry) Enabled() bool {
+	return r.client != nil
+}
+
+func (r *redisConnectionRegistry) Register(ctx context.Context, roomID, walletAddress string) error {
+	return r.Refresh(ctx, []ConnectionInfo{{RoomID: roomID, WalletAddress: walletAddress}})
+}
+
+func (r *redisConnectionRegistry) Unregister(ctx context.Context, roomID, walletAddress string) error {
+	if r.client == nil {
+		return nil
+	}
+	return r.client.Eval(ctx, unregisterScript, []string{connectionRegistryPrefix + roomID}, walletAddress, r.instanceID).Err()
+}
+
+// Refresh claims the given connections for this instance and extends their TTL
+func (r *redisConnectionRegistry) Refresh(ctx context.Context, connections []ConnectionInfo) error {
+	if r.client == nil || len(connections) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	pipe := r.client.Pipeline()
+	for _, conn := range connections {
+		entry, err := json.Marshal(&ConnectionInfo{
+			RoomID:        conn.RoomID,
+			WalletAddress: conn.WalletAddress,
+			InstanceID:    r.instanceID,
+			LastSeen:      now,
+		})
+		if err != nil {
+			return err
+		}
+		key := connectionRegistryPrefix + conn.RoomID
+		pipe.HSet(ctx, key, conn.WalletAddress, entry)
+		pipe.Expire(ctx, key, connectionEntryTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RoomConnections lists the live connections of a room across all instances
+func (r *redisConnectionRegistry) RoomConnections(ctx context.Context, roomID string) ([]*ConnectionInfo, error) {
+	if r.client == nil {
+		return nil, nil
+	}
+
+	key := connectionRegistryPrefix + roomID
+	entries, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var connections []*ConnectionInfo
+	var stale []string
+	for walletAddress, entry := range entries {
+		conn, ok := r.decode(entry)
+		if !ok {
+			stale = append(stale, walletAddress)
+			continue
+		}
+		connections = append(connections, conn)
+	}
+	if len(stale) > 0 {
+		if err := r.client.HDel(ctx, key, stale...).Err(); err != nil {
+			r.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"room_id": roomID,
+			}).Warn("Failed to remove stale room connections")
+		}
+	}
+	return connections, nil
+}
+
+func (r *redisConnectionRegistry) Owner(ctx context.Context, roomID, walletAddress string) (*ConnectionInfo, error) {
+	if r.client == nil {
+		return nil, nil
+	}
+
+	entry, err := r.client.HGet(ctx, connectionRegistryPrefix+roomID, walletAddress).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	conn, ok := r.decode(entry)
+	if !ok {
+		return nil, nil
+	}
+	return conn, nil
+}
+
+// CountConnections sums the registered connections of every room; entries of stopped instances are counted
+// until they expire
+func (r *redisConnectionRegistry) CountConnections(ctx context.Context) (int, error) {
+	if r.client == nil {
+		return 0, nil
+	}
+
+	total := 0
+	iter := r.client.Scan(ctx, 0, connectionRegistryPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		count, err := r.client.HLen(ctx, iter.Val()).Result()
+		if err != nil {
+			return 0, err
+		}
+		total += int(count)
+	}
+	if err := iter.Err(); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// decode parses a registry entry, reporting false for unreadable or expired entries
+func (r *redisConnectionRegistry) decode(entry string) (*ConnectionInfo, bool) {
+	var conn ConnectionInfo
+	if err := json.Unmarshal([]byte(entry), &conn); err != nil {
+		return nil, false
+	}
+	if time.Since(conn.LastSeen) > connectionEntryTTL {
+		return nil, false
+	}
+	return &conn, true
+}
+
+func (r *redisConnectionRegistry) Publish(ctx context.Context, instanceID string, payload []byte) error {
+	if r.client == nil {
+		return nil
+	}
+
+	channel := clusterChannel
+	if instanceID != "" {
+		channel = clusterInstancePrefix + instanceID
+	}
+	if err := r.client.Publish(ctx, channel, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", channel, err)
+	}
+	return nil
+}
+
+func (r *redisConnectionRegistry) Listen(ctx context.Context, handle func(payload []byte)) {
+	if r.client == nil {
+		return
+	}
+
+	// The subscription reconnects on its own; messages published while it is down are lost
+	pubsub := r.client.Subscribe(ctx, clusterChannel, clusterInstancePrefix+r.instanceID)
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			handle([]byte(msg.Payload))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
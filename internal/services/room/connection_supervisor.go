@@ -0,0 +1,93 @@
+package room
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/emiyaio/solana-wallet-service/pkg/clock"
+)
+
+// Connection liveness: every local connection is pinged each period, and one that has not answered for
+// pongWait is closed by the next sweep, so a connection gets at least two pings to answer
+const (
+	pingPeriod = 30 * time.Second
+	pongWait   = 90 * time.Second
+	writeWait  = 10 * time.Second
+)
+
+// connectionSupervisor runs the WebSocket service's single heartbeat: a sweep each ping period that pings
+// connections and closes those that went quiet. Start and Stop are safe to call in any order and more than once.
+type connectionSupervisor struct {
+	clock    clock.Clock
+	interval time.Duration
+	sweep    func()
+
+	mu     sync.Mutex
+	ticker clock.Ticker
+	stop   chan struct{} // nil while stopped
+	done   chan struct{}
+}
+
+func newConnectionSupervisor(clk clock.Clock, interval time.Duration, sweep func()) *connectionSupervisor {
+	return &connectionSupervisor{clock: clk, interval: interval, sweep: sweep}
+}
+
+// Start begins sweeping and reports whether it did; starting a running supervisor does nothing
+func (s *connectionSupervisor) Start() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return false
+	}
+
+	s.ticker = s.clock.NewTicker(s.interval)
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run(s.ticker, s.stop, s.done)
+	return true
+}
+
+// Stop ends sweeping, waiting for a sweep in progress, and reports whether it was running; a stopped
+// supervisor can be started again
+func (s *connectionSupervisor) Stop() bool {
+	s.mu.Lock()
+	if s.stop == nil {
+		s.mu.Unlock()
+		return false
+	}
+	s.ticker.Stop()
+	close(s.stop)
+	done := s.done
+	s.stop = nil
+	s.mu.Unlock()
+
+	<-done
+	return true
+}
+
+func (s *connectionSupervisor) run(ticker clock.Ticker, stop, done chan struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-ticker.C():
+			s.sweep()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// watchPongs gives a connection pongWait to answer each ping, calling touch with every pong
+func watchPongs(conn *websocket.Conn, touch func()) {
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		touch()
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+}
+
+// ping pings a connection; it may run alongside the connection's writer, and a failure is left to the sweep
+func ping(conn *websocket.Conn) {
+	conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+}
@@ -0,0 +1,147 @@
+package room
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/pkg/clock"
+)
+
+// ErrDraining is returned for connections offered to an instance that is draining
+var ErrDraining = errors.New("instance is draining")
+
+// defaultDrainGrace is how long clients get to reconnect elsewhere before a draining instance closes their connections
+const defaultDrainGrace = 30 * time.Second
+
+// DrainStatus reports the drain of this instance, started before it is replaced in a deploy
+type DrainStatus struct {
+	Draining    bool       `json:"draining"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	Deadline    *time.Time `json:"deadline,omitempty"` // connections still open then are closed
+	Connections int        `json:"connections"`        // room and market stream connections open on this instance
+	Drained     bool       `json:"drained"`            // draining with no connection left
+}
+
+type drainState struct {
+	startedAt time.Time
+	deadline  time.Time
+	timer     clock.Timer
+}
+
+// Drain stops this instance from accepting WebSocket connections and sends every open connection a reconnect
+// message, spread over the first half of grace so the clients do not all reconnect at once; connections still
+// open after grace are closed. Draining an instance already draining returns its status.
+func (ws *webSocketService) Drain(grace time.Duration) DrainStatus {
+	if grace <= 0 {
+		grace = defaultDrainGrace
+	}
+
+	ws.mu.Lock()
+	if ws.drain != nil {
+		ws.mu.Unlock()
+		return ws.DrainStatus()
+	}
+	now := ws.clock.Now()
+	ws.drain = &drainState{
+		startedAt: now,
+		deadline:  now.Add(grace),
+		timer:     ws.clock.AfterFunc(grace, ws.closeDrained),
+	}
+	// Send is only closed holding mu, so the connections are messaged under it
+	for _, client := range ws.clients {
+		ws.sendReconnect(client.Send, grace)
+	}
+	for _, client := range ws.marketClients {
+		ws.sendReconnect(client.Send, grace)
+	}
+	connections := len(ws.clients) + len(ws.marketClients)
+	ws.mu.Unlock()
+
+	ws.logger.WithFields(logrus.Fields{
+		"connections": connections,
+		"grace":       grace,
+	}).Warn("Draining WebSocket connections")
+	return ws.DrainStatus()
+}
+
+// sendReconnect queues the reconnect message; a full channel is left to the deadline
+func (ws *webSocketService) sendReconnect(send chan *Message, grace time.Duration) {
+	message := &Message{
+		Type: MessageTypeReconnect,
+		Data: map[string]interface{}{
+			"reason":          "draining",
+			"reconnect_in_ms": rand.Int63n(int64(grace/2)/int64(time.Millisecond) + 1),
+		},
+		Timestamp: ws.clock.Now(),
+	}
+	select {
+	case send <- message:
+	default:
+	}
+}
+
+// CancelDrain accepts connections again, e.g. after an aborted deploy; connections closed meanwhile stay closed
+func (ws *webSocketService) CancelDrain() DrainStatus {
+	ws.mu.Lock()
+	if ws.drain != nil {
+		ws.drain.timer.Stop()
+		ws.drain = nil
+		ws.logger.Info("WebSocket drain cancelled")
+	}
+	ws.mu.Unlock()
+	return ws.DrainStatus()
+}
+
+// DrainStatus reports whether this instance is draining and how many connections it still holds
+func (ws *webSocketService) DrainStatus() DrainStatus {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	status := DrainStatus{Connections: len(ws.clients) + len(ws.marketClients)}
+	if ws.drain != nil {
+		startedAt, deadline := ws.drain.startedAt, ws.drain.deadline
+		status.Draining = true
+		status.StartedAt = &startedAt
+		status.Deadline = &deadline
+		status.Drained = status.Connections == 0
+	}
+	return status
+}
+
+// draining reports whether new connections are refused
+func (ws *webSocketService) draining() bool {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	return ws.drain != nil
+}
+
+// closeDrained closes the connections left when the drain deadline passes
+func (ws *webSocketService) closeDrained() {
+	if !ws.draining() {
+		return
+	}
+
+	ws.mu.RLock()
+	clients := make([]*Client, 0, len(ws.clients))
+	for _, client := range ws.clients {
+		clients = append(clients, client)
+	}
+	marketClients := make([]*marketClient, 0, len(ws.marketClients))
+	for _, client := range ws.marketClients {
+		marketClients = append(marketClients, client)
+	}
+	ws.mu.RUnlock()
+
+	for _, client := range clients {
+		ws.disconnectLocal(client.RoomID, client.WalletAddress)
+	}
+	for _, client := range marketClients {
+		ws.disconnectMarket(client)
+	}
+
+	if len(clients)+len(marketClients) > 0 {
+		ws.logger.WithField("connections", len(clients)+len(marketClients)).Warn("Closed WebSocket connections left after the drain deadline")
+	}
+}
@@ -0,0 +1,82 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+const (
+	defaultReplayLimit = 500
+	maxReplayLimit     = 1000
+)
+
+// EventReplay is a page of a room's event log; clients resume from NextSequence until HasMore is false
+type EventReplay struct {
+	RoomID       string              `json:"room_id"`
+	Events       []*models.RoomEvent `json:"events"`
+	NextSequence int64               `json:"next_sequence"` // pass as since to continue
+	HasMore      bool                `json:"has_more"`
+}
+
+// appendRoomEvent adds an event to the room's log; the change it records stands even if logging fails
+func appendRoomEvent(ctx context.Context, roomRepo repositories.RoomRepository, logger *logrus.Logger, roomID uuid.UUID, eventType models.RoomEventType, walletAddress string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.WithFields(logrus.Fields{"error": err, "room_id": roomID, "type": eventType}).Warn("Failed to encode room event")
+		return
+	}
+
+	event := &models.RoomEvent{
+		RoomID:        roomID,
+		Type:          eventType,
+		WalletAddress: walletAddress,
+		Payload:       string(data),
+	}
+	if err := roomRepo.AppendEvent(ctx, event); err != nil {
+		logger.WithFields(logrus.Fields{"error": err, "room_id": roomID, "type": eventType}).Warn("Failed to append room event")
+	}
+}
+
+// ReplayEvents returns the room's events after the since sequence, oldest first. Closed and expired rooms can be replayed.
+func (s *roomService) ReplayEvents(ctx context.Context, roomID string, since int64, limit int) (*EventReplay, error) {
+	if limit <= 0 || limit > maxReplayLimit {
+		limit = defaultReplayLimit
+	}
+
+	room, err := s.roomRepo.GetByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, ErrRoomNotFound
+	}
+
+	// One extra event tells whether another page follows
+	events, err := s.roomRepo.GetEventsAfter(ctx, room.ID, since, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := &EventReplay{
+		RoomID:       roomID,
+		Events:       events,
+		NextSequence: since,
+	}
+	if len(events) > limit {
+		replay.Events = events[:limit]
+		replay.HasMore = true
+	}
+	if len(replay.Events) > 0 {
+		replay.NextSequence = replay.Events[len(replay.Events)-1].Sequence
+	}
+	return replay, nil
+}
+
+func (s *roomService) appendEvent(ctx context.Context, roomID uuid.UUID, eventType models.RoomEventType, walletAddress string, payload interface{}) {
+	appendRoomEvent(ctx, s.roomRepo, s.logger, roomID, eventType, walletAddress, payload)
+}
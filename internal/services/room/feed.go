@@ -0,0 +1,122 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+const (
+	defaultFeedLimit       = 50
+	maxFeedLimit           = 200
+	defaultFeedRetention   = 7 * 24 * time.Hour
+	defaultFeedMaxMessages = 1000
+)
+
+// FeedMessage is a room broadcast as clients received it live
+type FeedMessage struct {
+	Sequence  int64           `json:"sequence"`
+	Type      MessageType     `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	Timestamp time.Time       `json:"timestamp"`
+	From      string          `json:"from,omitempty"`
+}
+
+// RoomFeed is a page of a room's feed, oldest first; clients page back by passing NextBefore as before
+type RoomFeed struct {
+	RoomID     string         `json:"room_id"`
+	Messages   []*FeedMessage `json:"messages"`
+	NextBefore int64          `json:"next_before,omitempty"`
+	HasMore    bool           `json:"has_more"`
+}
+
+// GetFeed returns the room's latest broadcasts before the given sequence, or the latest ones when before is 0.
+// Closed and expired rooms keep their feed until it is pruned.
+func (s *roomService) GetFeed(ctx context.Context, roomID string, before int64, limit int) (*RoomFeed, error) {
+	if limit <= 0 || limit > maxFeedLimit {
+		limit = defaultFeedLimit
+	}
+
+	room, err := s.roomRepo.GetByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, ErrRoomNotFound
+	}
+
+	// One extra message tells whether older ones remain
+	stored, err := s.roomRepo.GetFeedBefore(ctx, roomID, before, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &RoomFeed{
+		RoomID:   roomID,
+		Messages: make([]*FeedMessage, 0, len(stored)),
+	}
+	if len(stored) > limit {
+		stored = stored[:limit]
+		feed.HasMore = true
+	}
+	for i := len(stored) - 1; i >= 0; i-- {
+		message := stored[i]
+		feed.Messages = append(feed.Messages, &FeedMessage{
+			Sequence:  message.Sequence,
+			Type:      MessageType(message.Type),
+			Data:      json.RawMessage(message.Data),
+			Timestamp: message.SentAt,
+			From:      message.FromWallet,
+		})
+	}
+	if feed.HasMore {
+		feed.NextBefore = feed.Messages[0].Sequence
+	}
+	return feed, nil
+}
+
+// PruneFeed drops feed messages past the configured retention, or past each room's message cap
+func (s *roomService) PruneFeed(ctx context.Context) (int64, error) {
+	retention := s.config.FeedRetention
+	if retention <= 0 {
+		retention = defaultFeedRetention
+	}
+	maxMessages := s.config.FeedMaxMessages
+	if maxMessages <= 0 {
+		maxMessages = defaultFeedMaxMessages
+	}
+
+	pruned, err := s.roomRepo.PruneFeed(ctx, time.Now().Add(-retention), maxMessages)
+	if err != nil {
+		return pruned, err
+	}
+	if pruned > 0 {
+		s.logger.WithField("count", pruned).Info("Pruned room feed messages")
+	}
+	return pruned, nil
+}
+
+// recordFeed keeps a room broadcast for the feed; the broadcast stands even if recording fails
+func (ws *webSocketService) recordFeed(roomID string, message *Message) {
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		ws.logger.WithFields(logrus.Fields{"error": err, "room_id": roomID, "type": message.Type}).Warn("Failed to encode room feed message")
+		return
+	}
+
+	feedMessage := &models.RoomFeedMessage{
+		RoomID:     roomID,
+		Type:       string(message.Type),
+		FromWallet: message.From,
+		Data:       string(data),
+		SentAt:     message.Timestamp,
+	}
+	ctx, cancel := ws.opContext()
+	defer cancel()
+	if err := ws.roomRepo.AppendFeedMessage(ctx, feedMessage); err != nil {
+		ws.logger.WithFields(logrus.Fields{"error": err, "room_id": roomID, "type": message.Type}).Warn("Failed to record room feed message")
+	}
+}
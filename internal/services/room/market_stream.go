@@ -0,0 +1,296 @@
+package room
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrInvalidMarketTopic = errors.New("invalid market topic")
+
+// MarketTopic is a market event stream clients of the market WebSocket subscribe to
+type MarketTopic string
+
+const (
+	MarketTopicTrending   MarketTopic = "trending"    // trending rank changes
+	MarketTopicNewTokens  MarketTopic = "new_tokens"  // newly listed tokens
+	MarketTopicPriceMoves MarketTopic = "price_moves" // large price moves
+)
+
+// MarketTopics lists the market topics; a connection naming none subscribes to all of them
+var MarketTopics = []MarketTopic{MarketTopicTrending, MarketTopicNewTokens, MarketTopicPriceMoves}
+
+func (t MarketTopic) IsValid() bool {
+	for _, topic := range MarketTopics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMarketTopics parses a comma-separated topic list; an empty list is every topic
+func ParseMarketTopics(value string) ([]MarketTopic, error) {
+	if strings.TrimSpace(value) == "" {
+		return MarketTopics, nil
+	}
+	var topics []MarketTopic
+	for _, part := range strings.Split(value, ",") {
+		topic := MarketTopic(strings.ToLower(strings.TrimSpace(part)))
+		if !topic.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidMarketTopic, part)
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
+
+// Market stream messages
+const (
+	MessageTypeSubscribe      MessageType = "subscribe"   // client to server, data: {"topics": [...]}
+	MessageTypeUnsubscribe    MessageType = "unsubscribe" // client to server, data: {"topics": [...]}
+	MessageTypeSubscribed     MessageType = "subscribed"  // the connection's topics, after connecting and after each change
+	MessageTypeTrendingUpdate MessageType = "trending_update"
+	MessageTypeNewToken       MessageType = "new_token"
+	MessageTypePriceMove      MessageType = "price_move"
+)
+
+// marketClient is a connection to the market stream; unlike room clients it is bound to no room or wallet
+type marketClient struct {
+	ID       string
+	Conn     *websocket.Conn
+	Send     chan *Message
+	LastPing time.Time
+	topics   map[MarketTopic]bool
+	mu       sync.Mutex
+}
+
+func (c *marketClient) subscribed(topic MarketTopic) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.topics[topic]
+}
+
+// setTopics subscribes or unsubscribes the topics and returns the connection's topics after the change
+func (c *marketClient) setTopics(topics []MarketTopic, subscribe bool) []MarketTopic {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, topic := range topics {
+		if subscribe {
+			c.topics[topic] = true
+		} else {
+			delete(c.topics, topic)
+		}
+	}
+	current := []MarketTopic{}
+	for _, topic := range MarketTopics {
+		if c.topics[topic] {
+			current = append(current, topic)
+		}
+	}
+	return current
+}
+
+// HandleMarketConnection serves a market stream connection subscribed to the given topics
+func (ws *webSocketService) HandleMarketConnection(conn *websocket.Conn, topics []MarketTopic) error {
+	if ws.draining() {
+		return ErrDraining
+	}
+	for _, topic := range topics {
+		if !topic.IsValid() {
+			return fmt.Errorf("%w: %q", ErrInvalidMarketTopic, topic)
+		}
+	}
+
+	client := &marketClient{
+		ID:       uuid.New().String(),
+		Conn:     conn,
+		Send:     make(chan *Message, 256),
+		LastPing: ws.clock.Now(),
+		topics:   make(map[MarketTopic]bool),
+	}
+	current := client.setTopics(topics, true)
+	client.Send <- &Message{
+		Type:      MessageTypeSubscribed,
+		Data:      map[string]interface{}{"topics": current},
+		Timestamp: time.Now(),
+	}
+
+	ws.mu.Lock()
+	ws.marketClients[client.ID] = client
+	ws.mu.Unlock()
+
+	go ws.marketWritePump(client)
+	go ws.marketReadPump(client)
+
+	ws.logger.WithFields(logrus.Fields{
+		"client_id": client.ID,
+		"topics":    current,
+	}).Info("Market stream client connected")
+	return nil
+}
+
+// PublishMarket sends the message to every market stream connection subscribed to the topic, on every instance.
+// It returns the number of connections on this instance the message was queued on.
+func (ws *webSocketService) PublishMarket(topic MarketTopic, message *Message) int {
+	if message.Timestamp.IsZero() {
+		message.Timestamp = time.Now()
+	}
+	ws.publish("", &clusterEnvelope{
+		Kind:  clusterKindMarket,
+		Topic: topic,
+	}, message)
+	return ws.publishMarketLocal(topic, message)
+}
+
+func (ws *webSocketService) publishMarketLocal(topic MarketTopic, message *Message) int {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	sent := 0
+	for _, client := range ws.marketClients {
+		if !client.subscribed(topic) {
+			continue
+		}
+		select {
+		case client.Send <- message:
+			sent++
+		default:
+			// A full channel is left to the connection supervisor to clean up; market events are not replayed
+		}
+	}
+	return sent
+}
+
+// disconnectMarket closes a market stream connection held by this instance
+func (ws *webSocketService) disconnectMarket(client *marketClient) {
+	ws.mu.Lock()
+	if _, exists := ws.marketClients[client.ID]; !exists {
+		ws.mu.Unlock()
+		return
+	}
+	close(client.Send)
+	client.Conn.Close()
+	delete(ws.marketClients, client.ID)
+	ws.mu.Unlock()
+
+	ws.logger.WithField("client_id", client.ID).Info("Market stream client disconnected")
+}
+
+// removeInactiveMarketClients closes market stream connections that stopped answering pings
+func (ws *webSocketService) removeInactiveMarketClients() {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	threshold := ws.clock.Now().Add(-pongWait)
+	for id, client := range ws.marketClients {
+		client.mu.Lock()
+		inactive := client.LastPing.Before(threshold)
+		client.mu.Unlock()
+		if !inactive {
+			continue
+		}
+
+		close(client.Send)
+		client.Conn.Close()
+		delete(ws.marketClients, id)
+		ws.logger.WithField("client_id", id).Info("Disconnected inactive market stream client")
+	}
+}
+
+func (ws *webSocketService) marketReadPump(client *marketClient) {
+	defer ws.disconnectMarket(client)
+
+	watchPongs(client.Conn, func() {
+		client.mu.Lock()
+		client.LastPing = ws.clock.Now()
+		client.mu.Unlock()
+	})
+
+	for {
+		var message Message
+		if err := client.Conn.ReadJSON(&message); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				ws.logger.WithFields(logrus.Fields{
+					"error":     err,
+					"client_id": client.ID,
+				}).Error("Market stream read error")
+			}
+			return
+		}
+		ws.handleMarketMessage(client, &message)
+	}
+}
+
+func (ws *webSocketService) marketWritePump(client *marketClient) {
+	defer client.Conn.Close()
+
+	for message := range client.Send {
+		client.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := client.Conn.WriteJSON(message); err != nil {
+			ws.logger.WithFields(logrus.Fields{
+				"error":     err,
+				"client_id": client.ID,
+			}).Error("Market stream write error")
+			return
+		}
+	}
+	client.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+	client.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+}
+
+// handleMarketMessage answers pings and topic subscription changes
+func (ws *webSocketService) handleMarketMessage(client *marketClient, message *Message) {
+	var reply *Message
+	switch message.Type {
+	case MessageTypePing:
+		reply = &Message{Type: MessageTypePong}
+
+	case MessageTypeSubscribe, MessageTypeUnsubscribe:
+		topics, err := decodeMarketTopics(message.Data)
+		if err != nil {
+			reply = &Message{Type: MessageTypeError, Data: map[string]interface{}{"error": err.Error()}}
+			break
+		}
+		current := client.setTopics(topics, message.Type == MessageTypeSubscribe)
+		reply = &Message{Type: MessageTypeSubscribed, Data: map[string]interface{}{"topics": current}}
+
+	default:
+		reply = &Message{Type: MessageTypeError, Data: map[string]interface{}{"error": fmt.Sprintf("unknown message type %q", message.Type)}}
+	}
+
+	reply.Timestamp = time.Now()
+	select {
+	case client.Send <- reply:
+	default:
+		ws.disconnectMarket(client)
+	}
+}
+
+// decodeMarketTopics reads the topics of a subscribe or unsubscribe message
+func decodeMarketTopics(data interface{}) ([]MarketTopic, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var body struct {
+		Topics []MarketTopic `json:"topics"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil || len(body.Topics) == 0 {
+		return nil, fmt.Errorf("%w: data must be {\"topics\": [...]}", ErrInvalidMarketTopic)
+	}
+	for _, topic := range body.Topics {
+		if !topic.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidMarketTopic, topic)
+		}
+	}
+	return body.Topics, nil
+}
@@ -0,0 +1,166 @@
+package room
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+const defaultInactivityWarning = 24 * time.Hour
+
+// MemberPruner removes members that have been inactive longer than their room's policy allows
+type MemberPruner interface {
+	PruneInactiveMembers(ctx context.Context) (*PruneResult, error)
+}
+
+type memberPruner struct {
+	roomRepo            repositories.RoomRepository
+	wsService           WebSocketService
+	subscriptionManager SubscriptionManager
+	warning             time.Duration
+	logger              *logrus.Logger
+}
+
+// NewMemberPruner creates a new inactive member pruner
+func NewMemberPruner(
+	roomRepo repositories.RoomRepository,
+	wsService WebSocketService,
+	subscriptionManager SubscriptionManager,
+	cfg *config.RoomConfig,
+	logger *logrus.Logger,
+) MemberPruner {
+	p := &memberPruner{
+		roomRepo:            roomRepo,
+		wsService:           wsService,
+		subscriptionManager: subscriptionManager,
+		warning:             cfg.InactivityWarning,
+		logger:              logger,
+	}
+	if p.warning <= 0 {
+		p.warning = defaultInactivityWarning
+	}
+	return p
+}
+
+// PruneResult counts the members warned and removed in one pruning run
+type PruneResult struct {
+	Rooms   int `json:"rooms"`
+	Warned  int `json:"warned"`
+	Removed int `json:"removed"`
+}
+
+// InactivityNotice is sent to a member that is about to be, or has been, removed for inactivity
+type InactivityNotice struct {
+	RoomID       string    `json:"room_id"`
+	InactiveDays int       `json:"inactive_days"` // the room's policy
+	LastActiveAt time.Time `json:"last_active_at"`
+	RemoveAt     time.Time `json:"remove_at,omitempty"` // set on warnings
+}
+
+// PruneInactiveMembers warns members of rooms with an inactivity policy once they near the limit,
+// and removes those still inactive past the limit a full warning period after being warned.
+// Creators and moderators are exempt.
+func (p *memberPruner) PruneInactiveMembers(ctx context.Context) (*PruneResult, error) {
+	rooms, err := p.roomRepo.GetPruningRooms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rooms with an inactivity policy: %w", err)
+	}
+
+	result := &PruneResult{}
+	for _, room := range rooms {
+		warned, removed, err := p.pruneRoom(ctx, room)
+		if err != nil {
+			p.logger.WithFields(logrus.Fields{"error": err, "room_id": room.RoomID}).Error("Failed to prune inactive members")
+			continue
+		}
+		result.Rooms++
+		result.Warned += warned
+		result.Removed += removed
+	}
+
+	if result.Warned > 0 || result.Removed > 0 {
+		p.logger.WithFields(logrus.Fields{
+			"rooms":   result.Rooms,
+			"warned":  result.Warned,
+			"removed": result.Removed,
+		}).Info("Pruned inactive room members")
+	}
+	return result, nil
+}
+
+func (p *memberPruner) pruneRoom(ctx context.Context, room *models.TradeRoom) (int, int, error) {
+	now := time.Now()
+	limit := time.Duration(room.PruneInactiveDays) * 24 * time.Hour
+
+	// Short policies get a proportionally shorter warning so members are not warned right after joining
+	warning := p.warning
+	if warning > limit/2 {
+		warning = limit / 2
+	}
+
+	members, err := p.roomRepo.GetInactiveMembers(ctx, room.ID, now.Add(-(limit - warning)))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	warned, removed := 0, 0
+	for _, member := range members {
+		if member.IsPruneExempt() {
+			continue
+		}
+
+		notice := &InactivityNotice{
+			RoomID:       room.RoomID,
+			InactiveDays: room.PruneInactiveDays,
+			LastActiveAt: member.LastActiveTime(),
+		}
+
+		// Members are always warned first, including those already past the limit when the policy was enabled
+		if member.InactivityWarnedAt == nil {
+			if err := p.roomRepo.MarkMemberWarned(ctx, member.ID, now); err != nil {
+				return warned, removed, err
+			}
+			notice.RemoveAt = member.LastActiveTime().Add(limit)
+			if earliest := now.Add(warning); notice.RemoveAt.Before(earliest) {
+				notice.RemoveAt = earliest
+			}
+			p.wsService.SendToClient(room.RoomID, member.WalletAddress, &Message{
+				Type: MessageTypeInactivityWarning,
+				Data: notice,
+			})
+			warned++
+			continue
+		}
+
+		if now.Sub(member.LastActiveTime()) < limit || now.Sub(*member.InactivityWarnedAt) < warning {
+			continue
+		}
+
+		if err := p.roomRepo.RemoveMember(ctx, room.ID, member.WalletAddress); err != nil {
+			return warned, removed, err
+		}
+		appendRoomEvent(ctx, p.roomRepo, p.logger, room.ID, models.RoomEventLeft, "", &models.RoomEventLeftPayload{WalletAddress: member.WalletAddress, Reason: "pruned"})
+		p.wsService.SendToClient(room.RoomID, member.WalletAddress, &Message{
+			Type: MessageTypeMemberPruned,
+			Data: notice,
+		})
+		p.wsService.NotifyMemberLeft(room.RoomID, member.WalletAddress)
+		p.wsService.DisconnectClient(room.RoomID, member.WalletAddress)
+		if err := p.subscriptionManager.HandleUserLeftRoom(member.WalletAddress, room.RoomID); err != nil {
+			p.logger.WithFields(logrus.Fields{"error": err, "wallet": member.WalletAddress}).Warn("Failed to update subscriptions of pruned member")
+		}
+		removed++
+
+		p.logger.WithFields(logrus.Fields{
+			"room_id":        room.RoomID,
+			"wallet":         member.WalletAddress,
+			"last_active_at": member.LastActiveTime(),
+		}).Info("Removed inactive room member")
+	}
+	return warned, removed, nil
+}
@@ -0,0 +1,59 @@
+package room
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// MembershipListener follows the members of rooms as the room service adds and removes them; rooms are
+// identified by their public room ID. The SubscriptionManager is one.
+type MembershipListener interface {
+	HandleUserJoinedRoom(walletAddress, roomID string, targetTokens []string) error
+	HandleUserLeftRoom(walletAddress, roomID string) error
+	HandleRoomClosed(roomID string) error
+}
+
+// OnMembershipChange registers a listener for members joining and leaving rooms and for rooms closing
+func (s *roomService) OnMembershipChange(listener MembershipListener) {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	s.membershipListeners = append(s.membershipListeners, listener)
+}
+
+func (s *roomService) listeners() []MembershipListener {
+	s.listenersMu.RLock()
+	defer s.listenersMu.RUnlock()
+	return s.membershipListeners
+}
+
+// notifyJoined tells the listeners about a new member; like the notifications below, the change stands when a
+// listener fails
+func (s *roomService) notifyJoined(room *models.TradeRoom, walletAddress string) {
+	for _, listener := range s.listeners() {
+		if err := listener.HandleUserJoinedRoom(walletAddress, room.RoomID, room.BoundTokenAddresses()); err != nil {
+			s.logger.WithFields(logrus.Fields{"error": err, "room_id": room.RoomID, "wallet": walletAddress}).Warn("Failed to handle member joining room")
+		}
+	}
+}
+
+func (s *roomService) notifyLeft(room *models.TradeRoom, walletAddress string) {
+	for _, listener := range s.listeners() {
+		if err := listener.HandleUserLeftRoom(walletAddress, room.RoomID); err != nil {
+			s.logger.WithFields(logrus.Fields{"error": err, "room_id": room.RoomID, "wallet": walletAddress}).Warn("Failed to handle member leaving room")
+		}
+	}
+}
+
+// RoomClosedElsewhere runs the close listeners for a room another process closed; the cluster channel brings
+// the close to every instance
+func (s *roomService) RoomClosedElsewhere(roomID string) {
+	s.notifyClosed(&models.TradeRoom{RoomID: roomID})
+}
+
+func (s *roomService) notifyClosed(room *models.TradeRoom) {
+	for _, listener := range s.listeners() {
+		if err := listener.HandleRoomClosed(room.RoomID); err != nil {
+			s.logger.WithFields(logrus.Fields{"error": err, "room_id": room.RoomID}).Warn("Failed to handle room closing")
+		}
+	}
+}
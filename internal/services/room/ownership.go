@@ -0,0 +1,169 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+// ErrNoPendingTransfer is returned when a wallet accepts or cancels a transfer that is not offered to it
+var ErrNoPendingTransfer = errors.New("no pending ownership transfer for this wallet")
+
+// defaultTransferTTL is how long a transfer waits to be accepted when none is configured
+const defaultTransferTTL = 24 * time.Hour
+
+// Ownership transfer statuses recorded in room events
+const (
+	transferRequested = "requested"
+	transferAccepted  = "accepted"
+	transferCancelled = "cancelled"
+)
+
+// RequestTransfer offers the room to another member, replacing any earlier offer; creator only. Nothing
+// changes hands until the member accepts.
+func (s *roomService) RequestTransfer(ctx context.Context, roomID, creatorAddress, targetAddress string) (*models.TradeRoom, error) {
+	room, err := s.creatorRoom(ctx, roomID, creatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if targetAddress == creatorAddress {
+		return nil, ErrInsufficientPermission
+	}
+
+	member, err := s.roomRepo.GetMemberByAddress(ctx, room.ID, targetAddress)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrNotMember
+	}
+
+	ttl := s.config.TransferTTL
+	if ttl <= 0 {
+		ttl = defaultTransferTTL
+	}
+	expiresAt := s.clock.Now().Add(ttl)
+	offered, err := s.roomRepo.OfferTransfer(ctx, room.ID, creatorAddress, targetAddress, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !offered {
+		return nil, ErrInsufficientPermission
+	}
+	room.PendingOwnerAddress = &targetAddress
+	room.TransferExpiresAt = &expiresAt
+
+	s.appendEvent(ctx, room.ID, models.RoomEventTransfer, creatorAddress, &models.RoomEventTransferPayload{
+		From:   creatorAddress,
+		To:     targetAddress,
+		Status: transferRequested,
+	})
+	return room, nil
+}
+
+// AcceptTransfer makes the wallet offered the room its creator. The previous creator stays on as a
+// moderator and may leave from then on.
+func (s *roomService) AcceptTransfer(ctx context.Context, roomID, walletAddress string) (*models.TradeRoom, error) {
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.Status != models.RoomStatusActive {
+		return nil, ErrRoomClosed
+	}
+	if !s.transferPendingFor(room, walletAddress) {
+		return nil, ErrNoPendingTransfer
+	}
+
+	// The offer lapses if the member left since
+	member, err := s.roomRepo.GetMemberByAddress(ctx, room.ID, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrNotMember
+	}
+
+	// The checks above read the room outside the transaction; the owner only changes if the offer is still
+	// pending when it is accepted
+	previousOwner := room.CreatorAddress
+	err = s.unitOfWork.Do(ctx, func(repos *repositories.Repositories) error {
+		accepted, err := repos.Room.AcceptTransfer(ctx, room.ID, walletAddress, s.clock.Now())
+		if err != nil {
+			return fmt.Errorf("failed to update room owner: %w", err)
+		}
+		if !accepted {
+			return ErrNoPendingTransfer
+		}
+		if err := repos.Room.UpdateMemberRole(ctx, room.ID, walletAddress, models.MemberRoleCreator); err != nil {
+			return fmt.Errorf("failed to promote new owner: %w", err)
+		}
+		if err := repos.Room.UpdateMemberRole(ctx, room.ID, previousOwner, models.MemberRoleModerator); err != nil {
+			return fmt.Errorf("failed to demote previous owner: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, ErrNoPendingTransfer) {
+		return nil, err
+	}
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{"error": err, "room_id": roomID, "wallet": walletAddress}).Error("Failed to transfer room ownership")
+		return nil, err
+	}
+	room.CreatorAddress = walletAddress
+	room.PendingOwnerAddress = nil
+	room.TransferExpiresAt = nil
+
+	s.appendEvent(ctx, room.ID, models.RoomEventTransfer, walletAddress, &models.RoomEventTransferPayload{
+		From:   previousOwner,
+		To:     walletAddress,
+		Status: transferAccepted,
+	})
+	// Rebind the new owner's subscriptions to the room so they follow it as its creator
+	s.notifyJoined(room, walletAddress)
+
+	s.logger.WithFields(logrus.Fields{"room_id": roomID, "from": previousOwner, "to": walletAddress}).Info("Room ownership transferred")
+	return room, nil
+}
+
+// CancelTransfer withdraws a pending transfer; the creator may cancel it and the member offered it may decline
+func (s *roomService) CancelTransfer(ctx context.Context, roomID, walletAddress string) error {
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if room.PendingOwnerAddress == nil {
+		return ErrNoPendingTransfer
+	}
+	if walletAddress != room.CreatorAddress && walletAddress != *room.PendingOwnerAddress {
+		return ErrInsufficientPermission
+	}
+
+	// A transfer accepted or replaced since it was read is no longer there to cancel
+	target := *room.PendingOwnerAddress
+	cancelled, err := s.roomRepo.CancelTransfer(ctx, room.ID, target)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrNoPendingTransfer
+	}
+
+	s.appendEvent(ctx, room.ID, models.RoomEventTransfer, walletAddress, &models.RoomEventTransferPayload{
+		From:   room.CreatorAddress,
+		To:     target,
+		Status: transferCancelled,
+	})
+	return nil
+}
+
+// transferPendingFor reports whether the room is offered to the wallet and the offer has not expired
+func (s *roomService) transferPendingFor(room *models.TradeRoom, walletAddress string) bool {
+	return room.PendingOwnerAddress != nil && *room.PendingOwnerAddress == walletAddress &&
+		room.TransferExpiresAt != nil && s.clock.Now().Before(*room.TransferExpiresAt)
+}
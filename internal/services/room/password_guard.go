@@ -0,0 +1,259 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+// ErrPasswordAttempts is matched by every PasswordAttemptError
+var ErrPasswordAttempts = errors.New("too many wrong room passwords")
+
+const (
+	defaultPasswordFreeAttempts    = 3
+	defaultPasswordBaseDelay       = 2 * time.Second
+	defaultPasswordMaxDelay        = time.Minute
+	defaultPasswordLockoutAfter    = 10
+	defaultPasswordWindow          = 15 * time.Minute
+	defaultPasswordLockoutDuration = 30 * time.Minute
+)
+
+// PasswordAttemptError reports a password guess refused or answered with a delay or lockout
+type PasswordAttemptError struct {
+	Attempts   int // wrong guesses counted, or 0 when the guess was refused before being checked
+	RetryAfter time.Duration
+	LockedOut  bool
+}
+
+func (e *PasswordAttemptError) Error() string {
+	if e.LockedOut {
+		return fmt.Sprintf("too many wrong passwords, locked out of the room for %s", e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("too many wrong passwords, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+func (e *PasswordAttemptError) Is(target error) bool {
+	return target == ErrPasswordAttempts
+}
+
+// RetryAfterSeconds rounds RetryAfter up to whole seconds
+func (e *PasswordAttemptError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// Details is the structured form of the refusal returned to clients
+func (e *PasswordAttemptError) Details() map[string]interface{} {
+	details := map[string]interface{}{
+		"locked_out":          e.LockedOut,
+		"retry_after_seconds": e.RetryAfterSeconds(),
+	}
+	if e.Attempts > 0 {
+		details["attempts"] = e.Attempts
+	}
+	return details
+}
+
+// PasswordGuard slows down and locks out repeated wrong room password guesses. Guesses are counted per
+// wallet and per client IP, so neither rotating wallets nor rotating IPs escapes the limit. A guess is
+// counted before the password is checked, so parallel guesses cannot outrun it.
+type PasswordGuard interface {
+	// Reserve counts a guess and returns the count, with a *PasswordAttemptError instead if the wallet or IP
+	// must wait or is locked out of the room
+	Reserve(ctx context.Context, roomID, walletAddress, clientIP string) (int, error)
+	// Fail settles a reserved guess that was wrong, returning a *PasswordAttemptError once retries are delayed
+	Fail(ctx context.Context, roomID, walletAddress, clientIP string, attempts int) error
+	// Succeed forgets the wrong guesses of the wallet and IP, refunding the reserved one
+	Succeed(ctx context.Context, roomID, walletAddress, clientIP string)
+}
+
+type redisPasswordGuard struct {
+	client *redis.Client
+	config *config.PasswordAttemptConfig
+	logger *logrus.Logger
+}
+
+// NewPasswordGuard creates a Redis-backed password guard; with a nil client guesses are not limited
+func NewPasswordGuard(client *redis.Client, config *config.PasswordAttemptConfig, logger *logrus.Logger) PasswordGuard {
+	return &redisPasswordGuard{
+		client: client,
+		config: config,
+		logger: logger,
+	}
+}
+
+// passwordSubjects returns the wallet and IP a guess is counted against, as key suffixes
+func passwordSubjects(roomID, walletAddress, clientIP string) []string {
+	subjects := []string{fmt.Sprintf("%s:wallet:%s", roomID, walletAddress)}
+	if clientIP != "" {
+		subjects = append(subjects, fmt.Sprintf("%s:ip:%s", roomID, clientIP))
+	}
+	return subjects
+}
+
+// reserveScript counts a guess against every subject unless one of them is locked out, waiting, or has as
+// many guesses counted as the lockout allows. KEYS holds the lock, wait and fails key of each subject; ARGV
+// the window, free attempts, lockout threshold, base and max delay and lockout duration, in milliseconds
+// where they are durations. It returns {0, attempts} when the guess may go ahead, setting the delay the next
+// guess waits once past the free attempts, {1, ms} when locked out and {2, ms} when the guess must wait.
+const reserveScript = `
+local window = tonumber(ARGV[1])
+local free = tonumber(ARGV[2])
+local lockoutAfter = tonumber(ARGV[3])
+local lockout, wait = 0, 0
+for i = 1, #KEYS, 3 do
+	lockout = math.max(lockout, redis.call('PTTL', KEYS[i]))
+	wait = math.max(wait, redis.call('PTTL', KEYS[i + 1]))
+end
+if lockout > 0 then
+	return {1, lockout}
+end
+if wait > 0 then
+	return {2, wait}
+end
+local attempts = 0
+for i = 1, #KEYS, 3 do
+	attempts = math.max(attempts, redis.call('INCR', KEYS[i + 2]))
+	redis.call('PEXPIRE', KEYS[i + 2], window)
+end
+if attempts > lockoutAfter then
+	for i = 1, #KEYS, 3 do
+		redis.call('DECR', KEYS[i + 2])
+	end
+	return {1, tonumber(ARGV[6])}
+end
+if attempts > free then
+	local delay = tonumber(ARGV[4])
+	for i = free + 2, attempts do
+		delay = math.min(delay * 2, tonumber(ARGV[5]))
+	end
+	for i = 1, #KEYS, 3 do
+		redis.call('SET', KEYS[i + 1], 1, 'PX', delay)
+	end
+end
+return {0, attempts}
+`
+
+// Reserve fails open when Redis is unavailable, like the room throttle. Counts last until a window passes
+// without a wrong guess and go by the higher of the wallet's and the IP's.
+func (g *redisPasswordGuard) Reserve(ctx context.Context, roomID, walletAddress, clientIP string) (int, error) {
+	if g.client == nil {
+		return 0, nil
+	}
+	settings := g.settings()
+
+	var keys []string
+	for _, subject := range passwordSubjects(roomID, walletAddress, clientIP) {
+		keys = append(keys, "roompass:lock:"+subject, "roompass:wait:"+subject, "roompass:fails:"+subject)
+	}
+	result, err := g.client.Eval(ctx, reserveScript, keys,
+		settings.Window.Milliseconds(), settings.FreeAttempts, settings.LockoutAfter,
+		settings.BaseDelay.Milliseconds(), settings.MaxDelay.Milliseconds(), settings.LockoutDuration.Milliseconds()).Slice()
+	if err != nil || len(result) != 2 {
+		g.warn(err, roomID, walletAddress, clientIP)
+		return 0, nil
+	}
+	status, _ := result[0].(int64)
+	value, _ := result[1].(int64)
+
+	switch status {
+	case 1:
+		return 0, &PasswordAttemptError{RetryAfter: time.Duration(value) * time.Millisecond, LockedOut: true}
+	case 2:
+		return 0, &PasswordAttemptError{RetryAfter: time.Duration(value) * time.Millisecond}
+	}
+	return int(value), nil
+}
+
+// Fail locks the wallet and the IP out once the guess reached the lockout threshold; a lockout starts the
+// counts afresh. Below it, guesses past the free ones wait the delay Reserve set.
+func (g *redisPasswordGuard) Fail(ctx context.Context, roomID, walletAddress, clientIP string, attempts int) error {
+	if g.client == nil || attempts == 0 {
+		return nil
+	}
+	settings := g.settings()
+
+	if attempts >= settings.LockoutAfter {
+		pipe := g.client.TxPipeline()
+		for _, subject := range passwordSubjects(roomID, walletAddress, clientIP) {
+			pipe.Set(ctx, "roompass:lock:"+subject, 1, settings.LockoutDuration)
+			pipe.Del(ctx, "roompass:fails:"+subject, "roompass:wait:"+subject)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			g.warn(err, roomID, walletAddress, clientIP)
+			return nil
+		}
+		g.logger.WithFields(logrus.Fields{
+			"room_id":   roomID,
+			"wallet":    walletAddress,
+			"client_ip": clientIP,
+			"attempts":  attempts,
+			"duration":  settings.LockoutDuration,
+		}).Warn("Locked out of room after wrong passwords")
+		return &PasswordAttemptError{Attempts: attempts, RetryAfter: settings.LockoutDuration, LockedOut: true}
+	}
+	if attempts <= settings.FreeAttempts {
+		return nil
+	}
+
+	// The delay doubles with each guess past the free ones
+	delay := settings.BaseDelay
+	for i := settings.FreeAttempts + 1; i < attempts && delay < settings.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > settings.MaxDelay {
+		delay = settings.MaxDelay
+	}
+	return &PasswordAttemptError{Attempts: attempts, RetryAfter: delay}
+}
+
+func (g *redisPasswordGuard) Succeed(ctx context.Context, roomID, walletAddress, clientIP string) {
+	if g.client == nil {
+		return
+	}
+
+	var keys []string
+	for _, subject := range passwordSubjects(roomID, walletAddress, clientIP) {
+		keys = append(keys, "roompass:fails:"+subject, "roompass:wait:"+subject)
+	}
+	if err := g.client.Del(ctx, keys...).Err(); err != nil {
+		g.warn(err, roomID, walletAddress, clientIP)
+	}
+}
+
+func (g *redisPasswordGuard) settings() config.PasswordAttemptConfig {
+	settings := *g.config
+	if settings.FreeAttempts <= 0 {
+		settings.FreeAttempts = defaultPasswordFreeAttempts
+	}
+	if settings.BaseDelay <= 0 {
+		settings.BaseDelay = defaultPasswordBaseDelay
+	}
+	if settings.MaxDelay <= 0 {
+		settings.MaxDelay = defaultPasswordMaxDelay
+	}
+	if settings.LockoutAfter <= 0 {
+		settings.LockoutAfter = defaultPasswordLockoutAfter
+	}
+	if settings.Window <= 0 {
+		settings.Window = defaultPasswordWindow
+	}
+	if settings.LockoutDuration <= 0 {
+		settings.LockoutDuration = defaultPasswordLockoutDuration
+	}
+	return settings
+}
+
+func (g *redisPasswordGuard) warn(err error, roomID, walletAddress, clientIP string) {
+	g.logger.WithFields(logrus.Fields{
+		"error":     err,
+		"room_id":   roomID,
+		"wallet":    walletAddress,
+		"client_ip": clientIP,
+	}).Warn("Room password guard unavailable, allowing guess")
+}
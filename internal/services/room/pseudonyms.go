@@ -0,0 +1,159 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+var (
+	ErrInvalidAlias = errors.New("alias must be 3 to 24 letters, digits, spaces, dashes or underscores")
+	ErrAliasTaken   = errors.New("alias is taken in this room")
+)
+
+var aliasPattern = regexp.MustCompile(`^[A-Za-z0-9 _-]{3,24}$`)
+
+// SetMemberAlias sets the alias the member shows in the room in place of their address, or clears it when
+// alias is empty. Aliases are unique within a room and may not look like an address, so no member can pass
+// as another wallet.
+func (s *roomService) SetMemberAlias(ctx context.Context, roomID, walletAddress, alias string) error {
+	alias = strings.TrimSpace(alias)
+	if alias != "" && (!aliasPattern.MatchString(alias) || solana.IsValidAddress(alias)) {
+		return ErrInvalidAlias
+	}
+
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.roomRepo.GetMemberByAddress(ctx, room.ID, walletAddress)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrNotMember
+	}
+
+	if alias == "" {
+		return s.roomRepo.UpdateMemberAlias(ctx, room.ID, walletAddress, nil)
+	}
+	holder, err := s.roomRepo.GetMemberByAlias(ctx, room.ID, alias)
+	if err != nil {
+		return err
+	}
+	if holder != nil && holder.WalletAddress != walletAddress {
+		return ErrAliasTaken
+	}
+	return s.roomRepo.UpdateMemberAlias(ctx, room.ID, walletAddress, &alias)
+}
+
+// ListRoomMembers lists the room's members as seen by the viewer: aliased members show their alias in place
+// of their address to everyone but the creator and themselves
+func (s *roomService) ListRoomMembers(ctx context.Context, roomID, viewerAddress string) ([]*models.RoomMember, error) {
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.GetRoomMembers(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if viewerAddress != "" && viewerAddress == room.CreatorAddress {
+		return members, nil
+	}
+	for _, member := range members {
+		if member.Alias != nil && member.WalletAddress != viewerAddress {
+			member.WalletAddress = *member.Alias
+		}
+	}
+	return members, nil
+}
+
+// senderView is the message as sent, delivered to the room's creator in place of the aliased broadcast
+type senderView struct {
+	creator string
+	message *Message
+}
+
+// pseudonymize builds the payload a room sees of a message from an aliased member, with the sender's address
+// replaced by the alias, and the view of the creator, who sees the real address. Messages without an aliased
+// sender are returned as is with a nil view.
+func (ws *webSocketService) pseudonymize(roomID string, message *Message) (*Message, *senderView, error) {
+	sender := messageSender(message)
+	if sender == "" {
+		return message, nil, nil
+	}
+
+	ctx, cancel := ws.opContext()
+	defer cancel()
+	alias, err := ws.roomRepo.GetMemberAlias(ctx, roomID, sender)
+	if err != nil {
+		// The message is withheld rather than risk revealing the address
+		return nil, nil, fmt.Errorf("failed to look up member alias: %w", err)
+	}
+	if alias == nil || alias.Alias == "" {
+		return message, nil, nil
+	}
+
+	masked := *message
+	if masked.From == sender {
+		masked.From = alias.Alias
+	}
+	masked.Data = aliasData(message.Data, sender, alias.Alias)
+	return &masked, &senderView{creator: alias.CreatorAddress, message: message}, nil
+}
+
+// messageSender returns the wallet a room message is from or about, or "" if none
+func messageSender(message *Message) string {
+	if message.From != "" {
+		return message.From
+	}
+	switch data := message.Data.(type) {
+	case *models.RoomMember:
+		return data.WalletAddress
+	case map[string]interface{}:
+		if wallet, ok := data["wallet_address"].(string); ok {
+			return wallet
+		}
+	}
+	return ""
+}
+
+// aliasData copies message data with the sender's address replaced by the alias
+func aliasData(data interface{}, sender, alias string) interface{} {
+	switch data := data.(type) {
+	case *models.RoomMember:
+		member := *data
+		member.WalletAddress = alias
+		return &member
+	case *models.SharedInfo:
+		info := *data
+		if info.SharerAddress == sender {
+			info.SharerAddress = alias
+		}
+		return &info
+	case *models.TradeEvent:
+		event := *data
+		if event.WalletAddress == sender {
+			event.WalletAddress = alias
+		}
+		return &event
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(data))
+		for key, value := range data {
+			if value == sender {
+				value = alias
+			}
+			copied[key] = value
+		}
+		return copied
+	}
+	return data
+}
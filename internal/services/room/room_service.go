@@ -6,12 +6,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/feature"
+	"github.com/emiyaio/solana-wallet-service/internal/services/rationale"
+	"github.com/emiyaio/solana-wallet-service/internal/services/trader"
+	"github.com/emiyaio/solana-wallet-service/pkg/clock"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
 )
 
 var (
@@ -23,6 +32,18 @@ var (
 	ErrAlreadyMember      = errors.New("already a member of this room")
 	ErrNotMember          = errors.New("not a member of this room")
 	ErrInsufficientPermission = errors.New("insufficient permission")
+	ErrInvalidInfoType    = errors.New("invalid shared info type")
+	ErrInvalidPayload     = errors.New("invalid shared info metadata")
+	ErrSharedInfoNotFound = errors.New("shared info not found")
+	ErrInvalidReaction    = errors.New("invalid reaction type")
+	ErrTokenFlagged       = errors.New("token is flagged")
+	ErrInvalidRole        = errors.New("role must be moderator or member")
+	ErrInvalidPrunePolicy = errors.New("prune_inactive_days must be between 0 and 90")
+	ErrInvalidBatchAction = errors.New("batch action must be add or remove")
+	ErrBatchTooLarge      = errors.New("batch exceeds 100 wallet addresses")
+	ErrBasketFull         = errors.New("room basket holds at most 20 tokens")
+	ErrTokenNotInBasket   = errors.New("token is not in the room basket")
+	ErrMaxMembersTooLow   = errors.New("max members cannot be less than current members")
 )
 
 // RoomService defines the interface for room management
@@ -34,65 +55,158 @@ type RoomService interface {
 	ListRooms(ctx context.Context, status models.RoomStatus, limit, offset int) ([]*models.TradeRoom, error)
 	GetUserRooms(ctx context.Context, creatorAddress string, limit, offset int) ([]*models.TradeRoom, error)
 	UpdateRoom(ctx context.Context, roomID string, req *UpdateRoomRequest) (*models.TradeRoom, error)
+	SetRoomToken(ctx context.Context, req *SetRoomTokenRequest) (*models.TradeRoom, error)
+	AddBasketTokens(ctx context.Context, req *BasketTokensRequest) (*models.TradeRoom, error)
+	RemoveBasketToken(ctx context.Context, roomID, creatorAddress, mintAddress string) (*models.TradeRoom, error)
 	CloseRoom(ctx context.Context, roomID, creatorAddress string) error
 	DeleteRoom(ctx context.Context, roomID, creatorAddress string) error
 	
 	// Member operations
-	JoinRoom(ctx context.Context, roomID, walletAddress, password string) (*models.RoomMember, error)
+	JoinRoom(ctx context.Context, roomID, walletAddress, password, clientIP string) (*models.RoomMember, error) // clientIP is counted with the wallet against password guessing
 	LeaveRoom(ctx context.Context, roomID, walletAddress string) error
 	GetRoomMembers(ctx context.Context, roomID string) ([]*models.RoomMember, error)
+	ListRoomMembers(ctx context.Context, roomID, viewerAddress string) ([]*models.RoomMember, error) // with aliases applied for the viewer
 	UpdateMemberStatus(ctx context.Context, roomID, walletAddress string, isOnline bool) error
 	KickMember(ctx context.Context, roomID, creatorAddress, targetAddress string) error
+	SetMemberRole(ctx context.Context, roomID, creatorAddress, targetAddress string, role models.MemberRole) error
+	SetTradePrivacy(ctx context.Context, roomID, walletAddress string, hideTradeSizes bool) error // members only, for themselves
+	SetMemberAlias(ctx context.Context, roomID, walletAddress, alias string) error                // members only, for themselves; empty clears it
+	BatchMembers(ctx context.Context, req *BatchMembersRequest) (*BatchMembersResult, error)
+	
+	// Ownership operations
+	RequestTransfer(ctx context.Context, roomID, creatorAddress, targetAddress string) (*models.TradeRoom, error)
+	AcceptTransfer(ctx context.Context, roomID, walletAddress string) (*models.TradeRoom, error)
+	CancelTransfer(ctx context.Context, roomID, walletAddress string) error // by the creator or the member offered the room
 	
 	// Content operations
 	ShareInfo(ctx context.Context, req *ShareInfoRequest) (*models.SharedInfo, error)
 	GetSharedInfos(ctx context.Context, roomID string, limit, offset int) ([]*models.SharedInfo, error)
+	FilterSharedInfos(ctx context.Context, roomID string, infoType models.SharedInfoType, token string, limit, offset int) ([]*models.SharedInfo, error)
+	GetSignals(ctx context.Context, token string, side models.SignalSide, limit, offset int) ([]*Signal, error)
 	UpdateSharedInfo(ctx context.Context, infoID uuid.UUID, req *UpdateSharedInfoRequest) (*models.SharedInfo, error)
 	DeleteSharedInfo(ctx context.Context, infoID uuid.UUID, sharerAddress string) error
-	LikeSharedInfo(ctx context.Context, infoID uuid.UUID) error
 	ViewSharedInfo(ctx context.Context, infoID uuid.UUID) error
 	
+	// Reaction operations
+	React(ctx context.Context, infoID uuid.UUID, walletAddress string, reactionType models.ReactionType) (*ReactionSummary, error)
+	Unreact(ctx context.Context, infoID uuid.UUID, walletAddress string, reactionType models.ReactionType) (*ReactionSummary, error)
+	GetReactionSummary(ctx context.Context, infoID uuid.UUID, walletAddress string) (*ReactionSummary, error)
+	GetReactions(ctx context.Context, infoID uuid.UUID, reactionType models.ReactionType, limit, offset int) ([]*models.Reaction, error)
+	
 	// Trade event operations
 	RecordTradeEvent(ctx context.Context, req *TradeEventRequest) (*models.TradeEvent, error)
 	GetTradeEvents(ctx context.Context, roomID string, limit, offset int) ([]*models.TradeEvent, error)
 	
+	// Event log operations
+	ReplayEvents(ctx context.Context, roomID string, since int64, limit int) (*EventReplay, error)
+	GetFeed(ctx context.Context, roomID string, before int64, limit int) (*RoomFeed, error)
+	
 	// Maintenance operations
 	CleanupExpiredRooms(ctx context.Context) error
+	PruneFeed(ctx context.Context) (int64, error)
 	UpdateRoomActivity(ctx context.Context, roomID string) error
+	
+	// OnMembershipChange registers a listener, e.g. the subscription manager, for joins, leaves and closed rooms
+	OnMembershipChange(listener MembershipListener)
+	// RoomClosedElsewhere tells the listeners about a room closed by another process, e.g. the admin CLI
+	RoomClosedElsewhere(roomID string)
 }
 
+// defaultTradeValueTolerance is used when no tolerance is configured
+const defaultTradeValueTolerance = 0.05
+
+// wrappedSOLMint is the mint native SOL settlements are priced as
+const wrappedSOLMint = "So11111111111111111111111111111111111111112"
+
+// maxPruneInactiveDays bounds the inactivity policy a creator may set
+const maxPruneInactiveDays = 90
+
+// maxBasketTokens bounds the watch basket a room keeps besides its own token
+const maxBasketTokens = 20
+
+// maxBatchMembers bounds the wallets of one batch member request
+const maxBatchMembers = 100
+
+// Batch member actions
+const (
+	BatchActionAdd    = "add"
+	BatchActionRemove = "remove"
+)
+
 type roomService struct {
-	roomRepo repositories.RoomRepository
-	logger   *logrus.Logger
+	roomRepo      repositories.RoomRepository
+	tokenRepo     repositories.TokenRepository
+	unitOfWork    repositories.UnitOfWork
+	prices        blockchain.PriceAggregator
+	signalTracker trader.SignalTracker
+	rationale     rationale.RationaleService
+	flags         feature.FlagService
+	throttle      Throttle
+	passwordGuard PasswordGuard
+	config        *config.RoomConfig
+	clock         clock.Clock
+	logger        *logrus.Logger
+	
+	listenersMu         sync.RWMutex
+	membershipListeners []MembershipListener
 }
 
 // NewRoomService creates a new room service instance
-func NewRoomService(roomRepo repositories.RoomRepository, logger *logrus.Logger) RoomService {
+func NewRoomService(roomRepo repositories.RoomRepository, tokenRepo repositories.TokenRepository, unitOfWork repositories.UnitOfWork, prices blockchain.PriceAggregator, signalTracker trader.SignalTracker, rationaleService rationale.RationaleService, flags feature.FlagService, throttle Throttle, passwordGuard PasswordGuard, config *config.RoomConfig, clk clock.Clock, logger *logrus.Logger) RoomService {
 	return &roomService{
-		roomRepo: roomRepo,
-		logger:   logger,
+		roomRepo:      roomRepo,
+		tokenRepo:     tokenRepo,
+		unitOfWork:    unitOfWork,
+		prices:        prices,
+		signalTracker: signalTracker,
+		rationale:     rationaleService,
+		flags:         flags,
+		throttle:      throttle,
+		passwordGuard: passwordGuard,
+		config:        config,
+		clock:         clk,
+		logger:        logger,
 	}
 }
 
 // Request/Response structs
 type CreateRoomRequest struct {
-	CreatorAddress string    `json:"creator_address" validate:"required"`
+	CreatorAddress string    `json:"creator_address" validate:"required,solana_address"`
 	TokenID        *uuid.UUID `json:"token_id,omitempty"`
-	TokenAddress   *string   `json:"token_address,omitempty"`
+	TokenAddress   *string   `json:"token_address,omitempty" validate:"omitempty,solana_address"`
 	Password       *string   `json:"password,omitempty"`
-	RecycleHours   int       `json:"recycle_hours" validate:"min=1,max=168"` // max 7 days
-	MaxMembers     int       `json:"max_members" validate:"min=2,max=1000"`
+	RecycleHours   int       `json:"recycle_hours" validate:"omitempty,min=1,max=168"` // max 7 days; 0 uses the default
+	MaxMembers     int       `json:"max_members" validate:"omitempty,min=2,max=1000"`
+	AIRationale    bool      `json:"ai_rationale,omitempty"` // generate AI rationales for member trades
+	PruneInactiveDays int    `json:"prune_inactive_days,omitempty"` // remove members inactive this many days; 0 disables
 }
 
+
 type UpdateRoomRequest struct {
-	Password     *string `json:"password,omitempty"`
-	RecycleHours *int    `json:"recycle_hours,omitempty" validate:"omitempty,min=1,max=168"`
-	MaxMembers   *int    `json:"max_members,omitempty" validate:"omitempty,min=2,max=1000"`
+	Password          *string `json:"password,omitempty"`
+	RecycleHours      *int    `json:"recycle_hours,omitempty" validate:"omitempty,min=1,max=168"`
+	MaxMembers        *int    `json:"max_members,omitempty" validate:"omitempty,min=2,max=1000"`
+	AIRationale       *bool   `json:"ai_rationale,omitempty"`
+	PruneInactiveDays *int    `json:"prune_inactive_days,omitempty"`
+}
+
+// SetRoomTokenRequest changes the token a room is bound to; an empty token address clears it. Creator only.
+type SetRoomTokenRequest struct {
+	RoomID         string  `json:"-"`
+	CreatorAddress string  `json:"-"`
+	TokenAddress   *string `json:"token_address" validate:"omitempty,solana_address"`
+}
+
+// BasketTokensRequest adds tokens to a room's watch basket. Creator only.
+type BasketTokensRequest struct {
+	RoomID         string   `json:"-"`
+	CreatorAddress string   `json:"-"`
+	TokenAddresses []string `json:"token_addresses" binding:"required"`
 }
 
 type ShareInfoRequest struct {
-	RoomID        string                 `json:"room_id" validate:"required"`
-	SharerAddress string                 `json:"sharer_address" validate:"required"`
+	RoomID        string                 `json:"-"` // from the path
+	SharerAddress string                 `json:"sharer_address" validate:"required,solana_address"`
 	Type          models.SharedInfoType  `json:"type" validate:"required"`
 	Title         string                 `json:"title" validate:"required,max=255"`
 	Content       string                 `json:"content" validate:"required"`
@@ -100,6 +214,42 @@ type ShareInfoRequest struct {
 	IsSticky      bool                   `json:"is_sticky"`
 }
 
+// BatchMembersRequest adds or removes up to 100 members at once; creator only
+type BatchMembersRequest struct {
+	RoomID          string   `json:"-"`
+	CreatorAddress  string   `json:"-"`
+	Action          string   `json:"action" binding:"required"` // add or remove
+	WalletAddresses []string `json:"wallet_addresses" binding:"required"`
+}
+
+// BatchMembersResult lists the members a batch request changed and the wallets it skipped
+type BatchMembersResult struct {
+	Action  string               `json:"action"`
+	Added   []*models.RoomMember `json:"added,omitempty"`
+	Removed []string             `json:"removed,omitempty"`
+	Skipped []BatchMemberSkip    `json:"skipped,omitempty"`
+}
+
+// BatchMemberSkip is a wallet of a batch request that was left unchanged
+type BatchMemberSkip struct {
+	WalletAddress string `json:"wallet_address"`
+	Reason        string `json:"reason"` // invalid_address, duplicate, already_member, not_member or creator
+}
+
+// Signal is a signal share with its decoded payload
+type Signal struct {
+	*models.SharedInfo
+	Payload *models.SignalPayload `json:"payload"`
+}
+
+// ReactionSummary counts the reactions on a shared info
+type ReactionSummary struct {
+	SharedInfoID uuid.UUID              `json:"shared_info_id"`
+	Total        int                    `json:"total"`
+	Counts       []models.ReactionCount `json:"counts"`         // every reaction type, in display order
+	Mine         []models.ReactionType  `json:"mine,omitempty"` // reactions of the requesting wallet
+}
+
 type UpdateSharedInfoRequest struct {
 	Title    *string                `json:"title,omitempty" validate:"omitempty,max=255"`
 	Content  *string                `json:"content,omitempty"`
@@ -108,13 +258,14 @@ type UpdateSharedInfoRequest struct {
 }
 
 type TradeEventRequest struct {
-	RoomID        string                 `json:"room_id" validate:"required"`
-	WalletAddress string                 `json:"wallet_address" validate:"required"`
-	TokenAddress  string                 `json:"token_address" validate:"required"`
+	RoomID        string                 `json:"-"` // from the path
+	WalletAddress string                 `json:"wallet_address" validate:"required,solana_address"`
+	TokenAddress  string                 `json:"token_address" validate:"required,solana_address"`
 	EventType     models.TradeEventType  `json:"event_type" validate:"required"`
 	Amount        float64                `json:"amount" validate:"required,min=0"`
 	Price         float64                `json:"price" validate:"required,min=0"`
 	ValueUSD      float64                `json:"value_usd" validate:"required,min=0"`
+	SOLAmount     float64                `json:"sol_amount,omitempty" validate:"omitempty,min=0"` // native SOL paid or received, valued server-side at the SOL price
 	TxSignature   string                 `json:"tx_signature" validate:"required"`
 	BlockTime     time.Time              `json:"block_time" validate:"required"`
 }
@@ -128,6 +279,13 @@ func (s *roomService) CreateRoom(ctx context.Context, req *CreateRoomRequest) (*
 	if req.MaxMembers == 0 {
 		req.MaxMembers = 100
 	}
+	if req.PruneInactiveDays < 0 || req.PruneInactiveDays > maxPruneInactiveDays {
+		return nil, ErrInvalidPrunePolicy
+	}
+	
+	if err := s.checkTokenFlag(ctx, req.TokenID, req.TokenAddress); err != nil {
+		return nil, err
+	}
 	
 	// Hash password if provided
 	var hashedPassword *string
@@ -137,38 +295,76 @@ func (s *roomService) CreateRoom(ctx context.Context, req *CreateRoomRequest) (*
 	}
 	
 	room := &models.TradeRoom{
-		CreatorAddress: req.CreatorAddress,
-		TokenID:        req.TokenID,
-		TokenAddress:   req.TokenAddress,
-		Password:       hashedPassword,
-		RecycleHours:   req.RecycleHours,
-		MaxMembers:     req.MaxMembers,
-		Status:         models.RoomStatusActive,
-		CurrentMembers: 1,
-	}
-	
-	if err := s.roomRepo.Create(ctx, room); err != nil {
-		s.logger.WithFields(logrus.Fields{"error": err}).Error("Failed to create room")
-		return nil, err
+		CreatorAddress:    req.CreatorAddress,
+		TokenID:           req.TokenID,
+		TokenAddress:      req.TokenAddress,
+		Password:          hashedPassword,
+		RecycleHours:      req.RecycleHours,
+		MaxMembers:        req.MaxMembers,
+		Status:            models.RoomStatusActive,
+		AIRationale:       req.AIRationale,
+		PruneInactiveDays: req.PruneInactiveDays,
 	}
 	
-	// Add creator as member
+	// The room and its creator's membership are written together, so a failed member insert leaves no
+	// memberless room behind
 	member := &models.RoomMember{
-		RoomID:        room.ID,
 		WalletAddress: req.CreatorAddress,
 		Role:          models.MemberRoleCreator,
 		IsOnline:      true,
 	}
-	
-	if err := s.roomRepo.AddMember(ctx, member); err != nil {
-		s.logger.WithFields(logrus.Fields{"error": err, "room_id": room.RoomID}).Error("Failed to add creator as member")
+	err := s.unitOfWork.Do(ctx, func(repos *repositories.Repositories) error {
+		if err := repos.Room.Create(ctx, room); err != nil {
+			return fmt.Errorf("failed to create room: %w", err)
+		}
+		member.RoomID = room.ID
+		if _, err := repos.Room.AddMember(ctx, member); err != nil {
+			return fmt.Errorf("failed to add creator as member: %w", err)
+		}
+		room.CurrentMembers = 1 // the creator's seat, counted by AddMember
+		return nil
+	})
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{"error": err, "creator": req.CreatorAddress}).Error("Failed to create room")
 		return nil, err
 	}
 	
+	s.appendEvent(ctx, room.ID, models.RoomEventCreated, req.CreatorAddress, room)
+	s.appendEvent(ctx, room.ID, models.RoomEventJoined, req.CreatorAddress, member)
+	s.notifyJoined(room, req.CreatorAddress)
+	
 	s.logger.WithFields(logrus.Fields{"room_id": room.RoomID, "creator": req.CreatorAddress}).Info("Room created successfully")
 	return room, nil
 }
 
+// checkTokenFlag rejects rooms bound to a token flagged as a scam or honeypot
+func (s *roomService) checkTokenFlag(ctx context.Context, tokenID *uuid.UUID, tokenAddress *string) error {
+	var mintAddresses []string
+	if tokenAddress != nil && *tokenAddress != "" {
+		mintAddresses = append(mintAddresses, *tokenAddress)
+	}
+	if tokenID != nil {
+		token, err := s.tokenRepo.GetByID(ctx, *tokenID)
+		if err != nil {
+			return fmt.Errorf("failed to get room token: %w", err)
+		}
+		if token != nil {
+			mintAddresses = append(mintAddresses, token.MintAddress)
+		}
+	}
+	
+	for _, mintAddress := range mintAddresses {
+		flag, err := s.tokenRepo.GetFlag(ctx, mintAddress)
+		if err != nil {
+			return fmt.Errorf("failed to get token flag: %w", err)
+		}
+		if flag != nil && flag.Active {
+			return fmt.Errorf("%w as %s: %s", ErrTokenFlagged, flag.Type, flag.Reason)
+		}
+	}
+	return nil
+}
+
 func (s *roomService) GetRoom(ctx context.Context, roomID string) (*models.TradeRoom, error) {
 	room, err := s.roomRepo.GetByRoomID(ctx, roomID)
 	if err != nil {
@@ -179,10 +375,13 @@ func (s *roomService) GetRoom(ctx context.Context, roomID string) (*models.Trade
 	}
 	
 	// Check if room is expired
-	if room.Status == models.RoomStatusActive && time.Now().After(room.ExpiresAt) {
+	if room.Status == models.RoomStatusActive && s.clock.Now().After(room.ExpiresAt) {
 		room.Status = models.RoomStatusExpired
 		if updateErr := s.roomRepo.Update(ctx, room); updateErr != nil {
 			s.logger.WithFields(logrus.Fields{"error": updateErr, "room_id": roomID}).Error("Failed to update expired room status")
+		} else {
+			s.appendEvent(ctx, room.ID, models.RoomEventClosed, "", &models.RoomEventClosedPayload{Status: room.Status})
+			s.notifyClosed(room)
 		}
 		return nil, ErrRoomExpired
 	}
@@ -224,12 +423,28 @@ func (s *roomService) UpdateRoom(ctx context.Context, roomID string, req *Update
 	
 	if req.RecycleHours != nil {
 		room.RecycleHours = *req.RecycleHours
-		room.ExpiresAt = time.Now().Add(time.Duration(*req.RecycleHours) * time.Hour)
+		room.ExpiresAt = s.clock.Now().Add(time.Duration(*req.RecycleHours) * time.Hour)
+	}
+	
+	if req.AIRationale != nil {
+		room.AIRationale = *req.AIRationale
 	}
 	
+	if req.PruneInactiveDays != nil {
+		if *req.PruneInactiveDays < 0 || *req.PruneInactiveDays > maxPruneInactiveDays {
+			return nil, ErrInvalidPrunePolicy
+		}
+		room.PruneInactiveDays = *req.PruneInactiveDays
+	}
+	
+	// The new size is checked against the member count in the same statement, so joins cannot slip past it
 	if req.MaxMembers != nil {
-		if *req.MaxMembers < room.CurrentMembers {
-			return nil, fmt.Errorf("max members cannot be less than current members (%d)", room.CurrentMembers)
+		resized, err := s.roomRepo.UpdateMaxMembers(ctx, room.ID, *req.MaxMembers)
+		if err != nil {
+			return nil, err
+		}
+		if !resized {
+			return nil, ErrMaxMembersTooLow
 		}
 		room.MaxMembers = *req.MaxMembers
 	}
@@ -241,6 +456,136 @@ func (s *roomService) UpdateRoom(ctx context.Context, roomID string, req *Update
 	return room, nil
 }
 
+// SetRoomToken rebinds the room to another token, or clears its token, and logs the change in the room history
+func (s *roomService) SetRoomToken(ctx context.Context, req *SetRoomTokenRequest) (*models.TradeRoom, error) {
+	room, err := s.creatorRoom(ctx, req.RoomID, req.CreatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	
+	var tokenAddress *string
+	if req.TokenAddress != nil && *req.TokenAddress != "" {
+		tokenAddress = req.TokenAddress
+	}
+	if err := s.checkTokenFlag(ctx, nil, tokenAddress); err != nil {
+		return nil, err
+	}
+	
+	previous := room.TokenAddress
+	if previous == nil && room.Token != nil {
+		previous = &room.Token.MintAddress
+	}
+	
+	// Rooms keep the token ID when the token is already tracked
+	room.TokenAddress = tokenAddress
+	room.TokenID = nil
+	room.Token = nil
+	if tokenAddress != nil {
+		token, err := s.tokenRepo.GetByMintAddress(ctx, *tokenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get room token: %w", err)
+		}
+		if token != nil {
+			room.TokenID = &token.ID
+			room.Token = token
+		}
+	}
+	
+	if err := s.roomRepo.UpdateToken(ctx, room.ID, room.TokenAddress, room.TokenID); err != nil {
+		return nil, err
+	}
+	
+	s.appendEvent(ctx, room.ID, models.RoomEventToken, req.CreatorAddress, &models.RoomEventTokenPayload{
+		PreviousTokenAddress: previous,
+		TokenAddress:         tokenAddress,
+	})
+	s.logger.WithFields(logrus.Fields{
+		"room_id":        room.RoomID,
+		"previous_token": previous,
+		"token":          tokenAddress,
+	}).Info("Room token changed")
+	
+	return room, nil
+}
+
+// AddBasketTokens adds tokens to the room's watch basket; tokens the room already watches are skipped
+func (s *roomService) AddBasketTokens(ctx context.Context, req *BasketTokensRequest) (*models.TradeRoom, error) {
+	room, err := s.creatorRoom(ctx, req.RoomID, req.CreatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	
+	bound := make(map[string]bool)
+	for _, mint := range room.BoundTokenAddresses() {
+		bound[mint] = true
+	}
+	var added []string
+	for _, mint := range req.TokenAddresses {
+		if !solana.IsValidAddress(mint) {
+			return nil, fmt.Errorf("%w: %s", solana.ErrInvalidAddress, mint)
+		}
+		if !bound[mint] {
+			bound[mint] = true
+			added = append(added, mint)
+		}
+	}
+	if len(added) == 0 {
+		return room, nil
+	}
+	if len(room.Basket)+len(added) > maxBasketTokens {
+		return nil, ErrBasketFull
+	}
+	
+	tokens := make([]*models.RoomToken, 0, len(added))
+	for _, mint := range added {
+		mint := mint
+		if err := s.checkTokenFlag(ctx, nil, &mint); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, &models.RoomToken{RoomID: room.ID, MintAddress: mint, AddedBy: req.CreatorAddress})
+	}
+	if err := s.roomRepo.AddBasketTokens(ctx, tokens); err != nil {
+		return nil, fmt.Errorf("failed to add basket tokens: %w", err)
+	}
+	
+	s.appendEvent(ctx, room.ID, models.RoomEventBasket, req.CreatorAddress, &models.RoomEventBasketPayload{Added: added})
+	return s.GetRoom(ctx, req.RoomID)
+}
+
+// RemoveBasketToken removes a token from the room's watch basket; the room's own token is changed with SetRoomToken
+func (s *roomService) RemoveBasketToken(ctx context.Context, roomID, creatorAddress, mintAddress string) (*models.TradeRoom, error) {
+	room, err := s.creatorRoom(ctx, roomID, creatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	
+	removed, err := s.roomRepo.RemoveBasketToken(ctx, room.ID, mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove basket token: %w", err)
+	}
+	if !removed {
+		return nil, ErrTokenNotInBasket
+	}
+	
+	s.appendEvent(ctx, room.ID, models.RoomEventBasket, creatorAddress, &models.RoomEventBasketPayload{Removed: []string{mintAddress}})
+	return s.GetRoom(ctx, roomID)
+}
+
+// creatorRoom gets an active room the wallet created
+func (s *roomService) creatorRoom(ctx context.Context, roomID, creatorAddress string) (*models.TradeRoom, error) {
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.CreatorAddress != creatorAddress {
+		return nil, ErrInsufficientPermission
+	}
+	if room.Status != models.RoomStatusActive {
+		return nil, ErrRoomClosed
+	}
+	return room, nil
+}
+
 func (s *roomService) CloseRoom(ctx context.Context, roomID, creatorAddress string) error {
 	room, err := s.GetRoom(ctx, roomID)
 	if err != nil {
@@ -252,7 +597,13 @@ func (s *roomService) CloseRoom(ctx context.Context, roomID, creatorAddress stri
 	}
 	
 	room.Status = models.RoomStatusClosed
-	return s.roomRepo.Update(ctx, room)
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return err
+	}
+	
+	s.appendEvent(ctx, room.ID, models.RoomEventClosed, creatorAddress, &models.RoomEventClosedPayload{Status: room.Status})
+	s.notifyClosed(room)
+	return nil
 }
 
 func (s *roomService) DeleteRoom(ctx context.Context, roomID, creatorAddress string) error {
@@ -265,11 +616,19 @@ func (s *roomService) DeleteRoom(ctx context.Context, roomID, creatorAddress str
 		return ErrInsufficientPermission
 	}
 	
-	return s.roomRepo.Delete(ctx, room.ID)
+	if err := s.roomRepo.Delete(ctx, room.ID); err != nil {
+		return err
+	}
+	s.notifyClosed(room)
+	return nil
 }
 
 // Member operations
-func (s *roomService) JoinRoom(ctx context.Context, roomID, walletAddress, password string) (*models.RoomMember, error) {
+func (s *roomService) JoinRoom(ctx context.Context, roomID, walletAddress, password, clientIP string) (*models.RoomMember, error) {
+	if err := solana.ValidateAddress(walletAddress); err != nil {
+		return nil, err
+	}
+	
 	room, err := s.GetRoom(ctx, roomID)
 	if err != nil {
 		return nil, err
@@ -279,19 +638,22 @@ func (s *roomService) JoinRoom(ctx context.Context, roomID, walletAddress, passw
 		return nil, ErrRoomClosed
 	}
 	
+	// Fast path only; AddMember enforces the capacity atomically
 	if room.CurrentMembers >= room.MaxMembers {
 		return nil, ErrRoomFull
 	}
 	
-	// Check password
+	// Check password, refusing guesses while the wallet or IP is delayed or locked out
 	if room.Password != nil {
-		if password == "" {
-			return nil, ErrInvalidPassword
+		attempts, err := s.passwordGuard.Reserve(ctx, room.RoomID, walletAddress, clientIP)
+		if err != nil {
+			return nil, err
 		}
 		hashedPassword := fmt.Sprintf("%x", md5.Sum([]byte(password)))
-		if hashedPassword != *room.Password {
-			return nil, ErrInvalidPassword
+		if password == "" || hashedPassword != *room.Password {
+			return nil, s.passwordFailed(ctx, room, walletAddress, clientIP, attempts)
 		}
+		s.passwordGuard.Succeed(ctx, room.RoomID, walletAddress, clientIP)
 	}
 	
 	// Check if already a member
@@ -310,9 +672,20 @@ func (s *roomService) JoinRoom(ctx context.Context, roomID, walletAddress, passw
 		IsOnline:      true,
 	}
 	
-	if err := s.roomRepo.AddMember(ctx, member); err != nil {
+	// The check above is only a fast path; concurrent joins are settled by the insert
+	added, err := s.roomRepo.AddMember(ctx, member)
+	if errors.Is(err, repositories.ErrRoomAtCapacity) {
+		return nil, ErrRoomFull
+	}
+	if err != nil {
 		return nil, err
 	}
+	if !added {
+		return nil, ErrAlreadyMember
+	}
+	
+	s.appendEvent(ctx, room.ID, models.RoomEventJoined, walletAddress, member)
+	s.notifyJoined(room, walletAddress)
 	
 	// Update room activity
 	s.roomRepo.UpdateLastActivity(ctx, room.ID)
@@ -321,6 +694,32 @@ func (s *roomService) JoinRoom(ctx context.Context, roomID, walletAddress, passw
 	return member, nil
 }
 
+// passwordFailed settles and audits a wrong password, returning the error for the guess
+func (s *roomService) passwordFailed(ctx context.Context, room *models.TradeRoom, walletAddress, clientIP string, attempts int) error {
+	guardErr := s.passwordGuard.Fail(ctx, room.RoomID, walletAddress, clientIP, attempts)
+	
+	var attemptErr *PasswordAttemptError
+	failure := &models.RoomPasswordFailure{
+		RoomID:        room.ID,
+		WalletAddress: walletAddress,
+		ClientIP:      clientIP,
+		Attempts:      attempts,
+		LockedOut:     errors.As(guardErr, &attemptErr) && attemptErr.LockedOut,
+	}
+	if err := s.roomRepo.RecordPasswordFailure(ctx, failure); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": room.RoomID,
+			"wallet":  walletAddress,
+		}).Error("Failed to audit wrong room password")
+	}
+	
+	if guardErr != nil {
+		return guardErr
+	}
+	return ErrInvalidPassword
+}
+
 func (s *roomService) LeaveRoom(ctx context.Context, roomID, walletAddress string) error {
 	room, err := s.GetRoom(ctx, roomID)
 	if err != nil {
@@ -345,6 +744,9 @@ func (s *roomService) LeaveRoom(ctx context.Context, roomID, walletAddress strin
 		return err
 	}
 	
+	s.appendEvent(ctx, room.ID, models.RoomEventLeft, walletAddress, &models.RoomEventLeftPayload{WalletAddress: walletAddress, Reason: "left"})
+	s.notifyLeft(room, walletAddress)
+	
 	s.logger.WithFields(logrus.Fields{"room_id": roomID, "wallet": walletAddress}).Info("User left room")
 	return nil
 }
@@ -355,7 +757,28 @@ func (s *roomService) GetRoomMembers(ctx context.Context, roomID string) ([]*mod
 		return nil, err
 	}
 	
-	return s.roomRepo.GetMembers(ctx, room.ID)
+	members, err := s.roomRepo.GetMembers(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Attach each member's signal accuracy; members are still listed if it is unavailable
+	now := s.clock.Now()
+	addresses := make([]string, 0, len(members))
+	for _, member := range members {
+		member.ActivityScore = activityScore(member, now)
+		addresses = append(addresses, member.WalletAddress)
+	}
+	accuracies, err := s.signalTracker.GetAccuracies(ctx, addresses)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{"error": err, "room_id": roomID}).Warn("Failed to get member signal accuracy")
+		return members, nil
+	}
+	for _, member := range members {
+		member.SignalAccuracy = accuracies[member.WalletAddress]
+	}
+	
+	return members, nil
 }
 
 func (s *roomService) UpdateMemberStatus(ctx context.Context, roomID, walletAddress string, isOnline bool) error {
@@ -385,11 +808,183 @@ func (s *roomService) KickMember(ctx context.Context, roomID, creatorAddress, ta
 		return ErrInsufficientPermission
 	}
 	
-	return s.roomRepo.RemoveMember(ctx, room.ID, targetAddress)
+	if err := s.roomRepo.RemoveMember(ctx, room.ID, targetAddress); err != nil {
+		return err
+	}
+	
+	s.appendEvent(ctx, room.ID, models.RoomEventLeft, creatorAddress, &models.RoomEventLeftPayload{WalletAddress: targetAddress, Reason: "kicked"})
+	s.notifyLeft(room, targetAddress)
+	return nil
+}
+
+// SetMemberRole promotes a member to moderator or demotes a moderator; creator only
+func (s *roomService) SetMemberRole(ctx context.Context, roomID, creatorAddress, targetAddress string, role models.MemberRole) error {
+	if role != models.MemberRoleModerator && role != models.MemberRoleMember {
+		return ErrInvalidRole
+	}
+	
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	
+	if room.CreatorAddress != creatorAddress || targetAddress == creatorAddress {
+		return ErrInsufficientPermission
+	}
+	
+	member, err := s.roomRepo.GetMemberByAddress(ctx, room.ID, targetAddress)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrNotMember
+	}
+	
+	return s.roomRepo.UpdateMemberRole(ctx, room.ID, targetAddress, role)
+}
+
+// SetTradePrivacy chooses whether the member's detected trades are broadcast to the room with exact amounts
+// or only a size bucket
+func (s *roomService) SetTradePrivacy(ctx context.Context, roomID, walletAddress string, hideTradeSizes bool) error {
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	
+	member, err := s.roomRepo.GetMemberByAddress(ctx, room.ID, walletAddress)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrNotMember
+	}
+	
+	return s.roomRepo.UpdateMemberPrivacy(ctx, room.ID, walletAddress, hideTradeSizes)
+}
+
+// BatchMembers adds or removes the wallets in one transaction. Adding is all or nothing:
+// if the room cannot fit every new member, none are added.
+func (s *roomService) BatchMembers(ctx context.Context, req *BatchMembersRequest) (*BatchMembersResult, error) {
+	if req.Action != BatchActionAdd && req.Action != BatchActionRemove {
+		return nil, ErrInvalidBatchAction
+	}
+	if len(req.WalletAddresses) > maxBatchMembers {
+		return nil, ErrBatchTooLarge
+	}
+	
+	room, err := s.GetRoom(ctx, req.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.CreatorAddress != req.CreatorAddress {
+		return nil, ErrInsufficientPermission
+	}
+	
+	result := &BatchMembersResult{Action: req.Action}
+	
+	// Drop malformed and repeated addresses before touching the database
+	seen := make(map[string]bool, len(req.WalletAddresses))
+	addresses := make([]string, 0, len(req.WalletAddresses))
+	for _, address := range req.WalletAddresses {
+		switch {
+		case !solana.IsValidAddress(address):
+			result.Skipped = append(result.Skipped, BatchMemberSkip{WalletAddress: address, Reason: "invalid_address"})
+		case seen[address]:
+			result.Skipped = append(result.Skipped, BatchMemberSkip{WalletAddress: address, Reason: "duplicate"})
+		default:
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return result, nil
+	}
+	
+	if req.Action == BatchActionAdd {
+		if room.Status != models.RoomStatusActive {
+			return nil, ErrRoomClosed
+		}
+		added, fits, err := s.roomRepo.AddMembers(ctx, room.ID, addresses)
+		if err != nil {
+			return nil, err
+		}
+		if !fits {
+			return nil, ErrRoomFull
+		}
+		result.Added = added
+		changed := make(map[string]bool, len(added))
+		for _, member := range added {
+			changed[member.WalletAddress] = true
+			s.appendEvent(ctx, room.ID, models.RoomEventJoined, req.CreatorAddress, member)
+			s.notifyJoined(room, member.WalletAddress)
+		}
+		for _, address := range addresses {
+			if !changed[address] {
+				result.Skipped = append(result.Skipped, BatchMemberSkip{WalletAddress: address, Reason: "already_member"})
+			}
+		}
+	} else {
+		removed, err := s.roomRepo.RemoveMembers(ctx, room.ID, addresses)
+		if err != nil {
+			return nil, err
+		}
+		result.Removed = removed
+		changed := make(map[string]bool, len(removed))
+		for _, address := range removed {
+			changed[address] = true
+			s.appendEvent(ctx, room.ID, models.RoomEventLeft, req.CreatorAddress, &models.RoomEventLeftPayload{WalletAddress: address, Reason: "removed"})
+			s.notifyLeft(room, address)
+		}
+		for _, address := range addresses {
+			if changed[address] {
+				continue
+			}
+			reason := "not_member"
+			if address == room.CreatorAddress {
+				reason = "creator"
+			}
+			result.Skipped = append(result.Skipped, BatchMemberSkip{WalletAddress: address, Reason: reason})
+		}
+	}
+	
+	s.logger.WithFields(logrus.Fields{
+		"room_id": req.RoomID,
+		"action":  req.Action,
+		"added":   len(result.Added),
+		"removed": len(result.Removed),
+		"skipped": len(result.Skipped),
+	}).Info("Batch member update")
+	
+	return result, nil
+}
+
+// activityScore weighs a member's messages, shares and trade events, decaying with the time since their last activity
+func activityScore(member *models.RoomMember, now time.Time) float64 {
+	points := float64(member.MessageCount) + 3*float64(member.ShareCount) + 2*float64(member.TradeCount)
+	if points == 0 {
+		return 0
+	}
+	idleDays := now.Sub(member.LastActiveTime()).Hours() / 24
+	if idleDays < 0 {
+		idleDays = 0
+	}
+	// Halves after a week of inactivity
+	return math.Round(points/(1+idleDays/7)*100) / 100
+}
+
+// recordActivity counts an action towards the member's activity; the action stands even if counting fails
+func (s *roomService) recordActivity(ctx context.Context, roomID uuid.UUID, walletAddress string, activity models.MemberActivity) {
+	if err := s.roomRepo.RecordMemberActivity(ctx, roomID, walletAddress, activity); err != nil {
+		s.logger.WithFields(logrus.Fields{"error": err, "room_id": roomID, "wallet": walletAddress}).Warn("Failed to record member activity")
+	}
 }
 
 // Content operations
 func (s *roomService) ShareInfo(ctx context.Context, req *ShareInfoRequest) (*models.SharedInfo, error) {
+	if !req.Type.IsUserShareable() {
+		return nil, ErrInvalidInfoType
+	}
+	
 	room, err := s.GetRoom(ctx, req.RoomID)
 	if err != nil {
 		return nil, err
@@ -404,6 +999,20 @@ func (s *roomService) ShareInfo(ctx context.Context, req *ShareInfoRequest) (*mo
 		return nil, ErrNotMember
 	}
 	
+	// Signals for members to copy can be switched off for the room or everywhere
+	if req.Type == models.SharedInfoTypeSignal && !s.flags.Enabled(ctx, feature.FlagCopyTradeSignals, room.RoomID) {
+		return nil, fmt.Errorf("%w: %s", feature.ErrFeatureDisabled, feature.FlagCopyTradeSignals)
+	}
+	
+	// Discussions are chat and limited separately from structured shares
+	action := ThrottleActionShare
+	if req.Type == models.SharedInfoTypeDiscussion {
+		action = ThrottleActionChat
+	}
+	if err := s.throttle.Allow(ctx, action, room.RoomID, req.SharerAddress); err != nil {
+		return nil, err
+	}
+	
 	// Convert metadata to JSON string
 	var metadataStr string
 	if req.Metadata != nil {
@@ -411,6 +1020,11 @@ func (s *roomService) ShareInfo(ctx context.Context, req *ShareInfoRequest) (*mo
 		metadataStr = string(metadataBytes)
 	}
 	
+	// Signals and alerts carry a typed payload
+	if err := models.ValidateSharedInfoMetadata(req.Type, metadataStr); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	
 	info := &models.SharedInfo{
 		RoomID:        room.ID,
 		SharerAddress: req.SharerAddress,
@@ -425,8 +1039,22 @@ func (s *roomService) ShareInfo(ctx context.Context, req *ShareInfoRequest) (*mo
 		return nil, err
 	}
 	
-	// Update room activity
+	s.appendEvent(ctx, room.ID, models.RoomEventShare, req.SharerAddress, info)
+	
+	// Track signal performance for sharer accuracy; the share stands even if tracking fails
+	if info.Type == models.SharedInfoTypeSignal {
+		if err := s.signalTracker.TrackSignal(ctx, info); err != nil {
+			s.logger.WithFields(logrus.Fields{"error": err, "shared_info_id": info.ID}).Warn("Failed to track signal")
+		}
+	}
+	
+	// Update room and member activity
 	s.roomRepo.UpdateLastActivity(ctx, room.ID)
+	if info.Type == models.SharedInfoTypeDiscussion {
+		s.recordActivity(ctx, room.ID, req.SharerAddress, models.MemberActivityMessage)
+	} else {
+		s.recordActivity(ctx, room.ID, req.SharerAddress, models.MemberActivityShare)
+	}
 	
 	return info, nil
 }
@@ -440,13 +1068,50 @@ func (s *roomService) GetSharedInfos(ctx context.Context, roomID string, limit,
 	return s.roomRepo.GetSharedInfos(ctx, room.ID, limit, offset)
 }
 
+func (s *roomService) FilterSharedInfos(ctx context.Context, roomID string, infoType models.SharedInfoType, token string, limit, offset int) ([]*models.SharedInfo, error) {
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.roomRepo.FindSharedInfos(ctx, repositories.SharedInfoFilter{
+		RoomID: &room.ID,
+		Type:   infoType,
+		Token:  token,
+	}, limit, offset)
+}
+
+// GetSignals lists signals for a token shared in active public rooms, newest first
+func (s *roomService) GetSignals(ctx context.Context, token string, side models.SignalSide, limit, offset int) ([]*Signal, error) {
+	infos, err := s.roomRepo.FindSharedInfos(ctx, repositories.SharedInfoFilter{
+		Type:       models.SharedInfoTypeSignal,
+		Token:      token,
+		Side:       side,
+		PublicOnly: true,
+	}, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	
+	signals := make([]*Signal, 0, len(infos))
+	for _, info := range infos {
+		payload, err := info.SignalPayload()
+		if err != nil {
+			// Signals shared before payloads were validated may not decode
+			continue
+		}
+		signals = append(signals, &Signal{SharedInfo: info, Payload: payload})
+	}
+	return signals, nil
+}
+
 func (s *roomService) UpdateSharedInfo(ctx context.Context, infoID uuid.UUID, req *UpdateSharedInfoRequest) (*models.SharedInfo, error) {
 	info, err := s.roomRepo.GetSharedInfoByID(ctx, infoID)
 	if err != nil {
 		return nil, err
 	}
 	if info == nil {
-		return nil, errors.New("shared info not found")
+		return nil, ErrSharedInfoNotFound
 	}
 	
 	// Update fields
@@ -458,6 +1123,9 @@ func (s *roomService) UpdateSharedInfo(ctx context.Context, infoID uuid.UUID, re
 	}
 	if req.Metadata != nil {
 		metadataBytes, _ := json.Marshal(req.Metadata)
+		if err := models.ValidateSharedInfoMetadata(info.Type, string(metadataBytes)); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
 		info.Metadata = string(metadataBytes)
 	}
 	if req.IsSticky != nil {
@@ -477,7 +1145,7 @@ func (s *roomService) DeleteSharedInfo(ctx context.Context, infoID uuid.UUID, sh
 		return err
 	}
 	if info == nil {
-		return errors.New("shared info not found")
+		return ErrSharedInfoNotFound
 	}
 	
 	// Check permission
@@ -495,14 +1163,111 @@ func (s *roomService) DeleteSharedInfo(ctx context.Context, infoID uuid.UUID, sh
 	return s.roomRepo.DeleteSharedInfo(ctx, infoID)
 }
 
-func (s *roomService) LikeSharedInfo(ctx context.Context, infoID uuid.UUID) error {
-	return s.roomRepo.IncrementLikeCount(ctx, infoID)
-}
-
 func (s *roomService) ViewSharedInfo(ctx context.Context, infoID uuid.UUID) error {
 	return s.roomRepo.IncrementViewCount(ctx, infoID)
 }
 
+// Reaction operations
+
+// React adds a room member's reaction to shared info; reacting twice with the same type has no effect
+func (s *roomService) React(ctx context.Context, infoID uuid.UUID, walletAddress string, reactionType models.ReactionType) (*ReactionSummary, error) {
+	if !reactionType.IsValid() {
+		return nil, ErrInvalidReaction
+	}
+	if err := s.checkReactor(ctx, infoID, walletAddress); err != nil {
+		return nil, err
+	}
+	
+	if _, err := s.roomRepo.AddReaction(ctx, &models.Reaction{
+		SharedInfoID:  infoID,
+		WalletAddress: walletAddress,
+		Type:          reactionType,
+	}); err != nil {
+		return nil, err
+	}
+	
+	return s.GetReactionSummary(ctx, infoID, walletAddress)
+}
+
+// Unreact removes a wallet's reaction from shared info
+func (s *roomService) Unreact(ctx context.Context, infoID uuid.UUID, walletAddress string, reactionType models.ReactionType) (*ReactionSummary, error) {
+	if !reactionType.IsValid() {
+		return nil, ErrInvalidReaction
+	}
+	
+	info, err := s.roomRepo.GetSharedInfoByID(ctx, infoID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, ErrSharedInfoNotFound
+	}
+	
+	if _, err := s.roomRepo.RemoveReaction(ctx, infoID, walletAddress, reactionType); err != nil {
+		return nil, err
+	}
+	
+	return s.GetReactionSummary(ctx, infoID, walletAddress)
+}
+
+// checkReactor verifies that the shared info exists and walletAddress is a member of its room
+func (s *roomService) checkReactor(ctx context.Context, infoID uuid.UUID, walletAddress string) error {
+	info, err := s.roomRepo.GetSharedInfoByID(ctx, infoID)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return ErrSharedInfoNotFound
+	}
+	
+	member, err := s.roomRepo.GetMemberByAddress(ctx, info.RoomID, walletAddress)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrNotMember
+	}
+	return nil
+}
+
+// GetReactionSummary counts the reactions on shared info; walletAddress, if set, also lists that wallet's reactions
+func (s *roomService) GetReactionSummary(ctx context.Context, infoID uuid.UUID, walletAddress string) (*ReactionSummary, error) {
+	counts, err := s.roomRepo.CountReactions(ctx, infoID)
+	if err != nil {
+		return nil, err
+	}
+	
+	byType := make(map[models.ReactionType]int, len(counts))
+	for _, count := range counts {
+		byType[count.Type] = count.Count
+	}
+	
+	summary := &ReactionSummary{
+		SharedInfoID: infoID,
+		Counts:       make([]models.ReactionCount, 0, len(models.ReactionTypes)),
+	}
+	for _, reactionType := range models.ReactionTypes {
+		summary.Counts = append(summary.Counts, models.ReactionCount{Type: reactionType, Count: byType[reactionType]})
+		summary.Total += byType[reactionType]
+	}
+	
+	if walletAddress != "" {
+		if summary.Mine, err = s.roomRepo.GetWalletReactions(ctx, infoID, walletAddress); err != nil {
+			return nil, err
+		}
+	}
+	
+	return summary, nil
+}
+
+// GetReactions lists who reacted to shared info, newest first; an empty type lists every reaction
+func (s *roomService) GetReactions(ctx context.Context, infoID uuid.UUID, reactionType models.ReactionType, limit, offset int) ([]*models.Reaction, error) {
+	if reactionType != "" && !reactionType.IsValid() {
+		return nil, ErrInvalidReaction
+	}
+	return s.roomRepo.GetReactions(ctx, infoID, reactionType, limit, offset)
+}
+
 // Trade event operations
 func (s *roomService) RecordTradeEvent(ctx context.Context, req *TradeEventRequest) (*models.TradeEvent, error) {
 	room, err := s.GetRoom(ctx, req.RoomID)
@@ -519,6 +1284,10 @@ func (s *roomService) RecordTradeEvent(ctx context.Context, req *TradeEventReque
 		return nil, ErrNotMember
 	}
 	
+	if err := s.throttle.Allow(ctx, ThrottleActionTradeEvent, room.RoomID, req.WalletAddress); err != nil {
+		return nil, err
+	}
+	
 	event := &models.TradeEvent{
 		RoomID:        room.ID,
 		WalletAddress: req.WalletAddress,
@@ -527,20 +1296,93 @@ func (s *roomService) RecordTradeEvent(ctx context.Context, req *TradeEventReque
 		Amount:        req.Amount,
 		Price:         req.Price,
 		ValueUSD:      req.ValueUSD,
+		SOLAmount:     req.SOLAmount,
 		TxSignature:   req.TxSignature,
+		Provisional:   req.TxSignature != "", // settled by the finality check
 		BlockTime:     req.BlockTime,
 	}
 	
+	s.valueTradeEvent(ctx, event)
+	
+	// Rooms that enable rationales get a one-line AI explanation attached to the event
+	event.Rationale = s.rationale.ForTradeEvent(ctx, room, event)
+	
 	if err := s.roomRepo.CreateTradeEvent(ctx, event); err != nil {
 		return nil, err
 	}
 	
-	// Update room activity
+	s.appendEvent(ctx, room.ID, models.RoomEventTrade, req.WalletAddress, event)
+	
+	// Update room and member activity
 	s.roomRepo.UpdateLastActivity(ctx, room.ID)
+	s.recordActivity(ctx, room.ID, req.WalletAddress, models.MemberActivityTrade)
 	
 	return event, nil
 }
 
+// valueTradeEvent prices the event server-side and flags client figures that deviate too far.
+// Trades settled in native SOL are valued at the SOL price; others at the token's market data.
+func (s *roomService) valueTradeEvent(ctx context.Context, event *models.TradeEvent) {
+	if !s.valueSOLSettlement(ctx, event) && !s.valueAtMarketPrice(ctx, event) {
+		return
+	}
+	if event.ServerValueUSD == 0 {
+		return
+	}
+	
+	event.ValueDeviation = math.Abs(event.ValueUSD-event.ServerValueUSD) / event.ServerValueUSD
+	
+	tolerance := defaultTradeValueTolerance
+	if s.config != nil && s.config.TradeValueTolerance > 0 {
+		tolerance = s.config.TradeValueTolerance
+	}
+	if event.ValueDeviation > tolerance {
+		event.ValueFlagged = true
+		s.logger.WithFields(logrus.Fields{
+			"room_id":          event.RoomID,
+			"wallet":           event.WalletAddress,
+			"token_address":    event.TokenAddress,
+			"client_value_usd": event.ValueUSD,
+			"server_value_usd": event.ServerValueUSD,
+			"deviation":        event.ValueDeviation,
+		}).Warn("Trade event value deviates from market price")
+	}
+}
+
+// valueSOLSettlement values the event by the native SOL it settled in
+func (s *roomService) valueSOLSettlement(ctx context.Context, event *models.TradeEvent) bool {
+	if event.SOLAmount <= 0 || s.prices == nil {
+		return false
+	}
+	solPrice, ok := s.prices.PriceUSD(ctx, wrappedSOLMint)
+	if !ok {
+		return false
+	}
+	
+	event.ServerValueUSD = event.SOLAmount * solPrice
+	if event.Amount > 0 {
+		event.ServerPrice = event.ServerValueUSD / event.Amount
+	}
+	return true
+}
+
+// valueAtMarketPrice values the event at the token's latest market data
+func (s *roomService) valueAtMarketPrice(ctx context.Context, event *models.TradeEvent) bool {
+	token, err := s.tokenRepo.GetByMintAddress(ctx, event.TokenAddress)
+	if err != nil || token == nil {
+		return false
+	}
+	
+	marketData, err := s.tokenRepo.GetLatestMarketData(ctx, token.ID)
+	if err != nil || marketData == nil || marketData.PriceUSD == 0 {
+		return false
+	}
+	
+	event.ServerPrice = marketData.PriceUSD
+	event.ServerValueUSD = event.Amount * marketData.PriceUSD
+	return true
+}
+
 func (s *roomService) GetTradeEvents(ctx context.Context, roomID string, limit, offset int) ([]*models.TradeEvent, error) {
 	room, err := s.GetRoom(ctx, roomID)
 	if err != nil {
@@ -552,7 +1394,7 @@ func (s *roomService) GetTradeEvents(ctx context.Context, roomID string, limit,
 
 // Maintenance operations
 func (s *roomService) CleanupExpiredRooms(ctx context.Context) error {
-	expiredRooms, err := s.roomRepo.GetExpiredRooms(ctx)
+	expiredRooms, err := s.roomRepo.GetExpiredRooms(ctx, s.clock.Now())
 	if err != nil {
 		return err
 	}
@@ -563,6 +1405,8 @@ func (s *roomService) CleanupExpiredRooms(ctx context.Context) error {
 			s.logger.WithFields(logrus.Fields{"error": err, "room_id": room.RoomID}).Error("Failed to update expired room")
 			continue
 		}
+		s.appendEvent(ctx, room.ID, models.RoomEventClosed, "", &models.RoomEventClosedPayload{Status: room.Status})
+		s.notifyClosed(room)
 		s.logger.WithFields(logrus.Fields{"room_id": room.RoomID}).Info("Room expired")
 	}
 	
@@ -8,37 +8,105 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
 	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/rationale"
 )
 
 // SubscriptionManager manages wallet subscriptions for room members
 type SubscriptionManager interface {
-	HandleUserJoinedRoom(walletAddress, roomID string, targetTokenAddress *string) error
+	HandleUserJoinedRoom(walletAddress, roomID string, targetTokens []string) error
 	HandleUserLeftRoom(walletAddress, roomID string) error
 	HandleRoomClosed(roomID string) error
+	HandleRoomTokensChanged(roomID string, targetTokens []string) // updates the target tokens of the room's subscriptions
 	OnWebSocketReconnected() error
-	GetActiveSubscriptions() map[string][]string // wallet -> roomIDs
+	ReconcileWallets(ctx context.Context) (int, error)
+	RestoreSubscriptions(ctx context.Context) (int, error) // adopts persisted intents no live instance holds, run on startup
+	ResubscribeMembers(ctx context.Context) (int, error)   // subscribes the room members and watches stored in the database, run on startup
+	CleanupSubscriptions(ctx context.Context) (int, error) // drops subscriptions without an active room member and heartbeats the rest
+	GetActiveSubscriptions() map[string][]string // wallet -> roomIDs, with watch:{watcher} for personal watches
+	OnTrade(listener TradeListener)
+	Stop() // cancels the notification handling and verification still running
+	
+	// Personal watches follow a wallet's trades outside of rooms, limited per watching wallet
+	WatchWallet(ctx context.Context, watcherAddress, walletAddress string) (*models.WalletWatch, error)
+	UnwatchWallet(ctx context.Context, watcherAddress, walletAddress string) error
+	ListWatches(ctx context.Context, watcherAddress string) ([]*models.WalletWatch, error)
+	OnWatchedTrade(listener WatchListener)
 }
 
+// TradeListener is called with each detected trade of a subscribed wallet; it runs on the subscription goroutine
+// and should return quickly
+type TradeListener func(ctx context.Context, action *blockchain.AnalyzedWalletAction)
+
+// WatchListener is called with each trade of a watched wallet, once per watching wallet; like TradeListener it
+// runs on the subscription goroutine
+type WatchListener func(ctx context.Context, watcherAddress string, action *blockchain.AnalyzedWalletAction)
+
+// Reconciliation bounds: signatures fetched per wallet and run, how recent a signature may be before the
+// live notification is given up on, and how long processed signatures are remembered
+const (
+	reconcileSignatureLimit     = 50
+	reconcileGracePeriod        = time.Minute
+	processedSignatureRetention = 6 * time.Hour
+)
+
+// How long after a reconnect subscriptions are expected to be confirmed before they are verified
+const subscriptionConfirmWait = 10 * time.Second
+
+// Intents not heartbeated for this long belong to an instance that stopped; the janitor heartbeats well within it
+const subscriptionIntentStaleAfter = 5 * time.Minute
+
+// Page size for walking the active rooms and wallet watches when resubscribing from the database
+const resubscribePageSize = 100
+
+// defaultSubscriptionOpTimeout bounds the handling of one notification, including room lookups and rationales
+const defaultSubscriptionOpTimeout = 30 * time.Second
+
 type subscriptionManager struct {
 	quickNodeService        blockchain.QuickNodeService
 	transactionProcessor    blockchain.TransactionProcessor
 	roomRepo                repositories.RoomRepository
+	watchRepo               repositories.WalletWatchRepository
 	wsService               WebSocketService
+	rationale               rationale.RationaleService
+	store                   SubscriptionStore
 	logger                  *logrus.Logger
+	ctx                     context.Context // cancelled by Stop
+	cancel                  context.CancelFunc
+	opTimeout               time.Duration
+	maxWatches              int
 	
 	// Subscription state management
 	walletRoomSubscriptions map[string]map[string]*RoomSubscriptionContext // wallet -> roomID -> context
 	walletNotificationConsumers map[string]blockchain.LogConsumer          // wallet -> consumer
+	walletReconciledUntil   map[string]time.Time                          // wallet -> block time up to which signatures were reconciled
+	processedSignatures     map[string]map[string]time.Time                // wallet -> signature -> when it was processed
+	tradeListeners          []TradeListener
+	watchListeners          []WatchListener
 	mu                      sync.RWMutex
 }
 
 // RoomSubscriptionContext holds context for room-specific subscriptions
 type RoomSubscriptionContext struct {
-	RoomID             string
-	TargetTokenAddress *string
-	JoinedAt           string
+	RoomID       string
+	TargetTokens []string // the room's bound tokens; trades touching none of them are not sent to the room
+	JoinedAt     string
+}
+
+// watches reports whether a trade concerns the room: rooms without tokens see every trade
+func (c *RoomSubscriptionContext) watches(action *blockchain.AnalyzedWalletAction) bool {
+	if len(c.TargetTokens) == 0 {
+		return true
+	}
+	for _, mint := range c.TargetTokens {
+		if (action.InputToken != nil && action.InputToken.Mint == mint) || (action.OutputToken != nil && action.OutputToken.Mint == mint) {
+			return true
+		}
+	}
+	return false
 }
 
 // NewSubscriptionManager creates a new subscription manager
@@ -46,22 +114,74 @@ func NewSubscriptionManager(
 	quickNodeService blockchain.QuickNodeService,
 	transactionProcessor blockchain.TransactionProcessor,
 	roomRepo repositories.RoomRepository,
+	watchRepo repositories.WalletWatchRepository,
 	wsService WebSocketService,
+	rationaleService rationale.RationaleService,
+	store SubscriptionStore,
+	roomConfig *config.RoomConfig,
+	timeouts *config.TimeoutsConfig,
 	logger *logrus.Logger,
 ) SubscriptionManager {
+	opTimeout := timeouts.Subscription
+	if opTimeout <= 0 {
+		opTimeout = defaultSubscriptionOpTimeout
+	}
+	maxWatches := roomConfig.MaxWalletWatches
+	if maxWatches <= 0 {
+		maxWatches = defaultMaxWalletWatches
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	
 	return &subscriptionManager{
 		quickNodeService:            quickNodeService,
 		transactionProcessor:        transactionProcessor,
 		roomRepo:                    roomRepo,
+		watchRepo:                   watchRepo,
 		wsService:                   wsService,
+		rationale:                   rationaleService,
+		store:                       store,
 		logger:                      logger,
+		ctx:                         ctx,
+		cancel:                      cancel,
+		opTimeout:                   opTimeout,
+		maxWatches:                  maxWatches,
 		walletRoomSubscriptions:     make(map[string]map[string]*RoomSubscriptionContext),
 		walletNotificationConsumers: make(map[string]blockchain.LogConsumer),
+		walletReconciledUntil:       make(map[string]time.Time),
+		processedSignatures:         make(map[string]map[string]time.Time),
 	}
 }
 
+// Stop cancels the notification handling and verification still running
+func (sm *subscriptionManager) Stop() {
+	sm.cancel()
+}
+
+// opContext bounds the handling of a notification; it is cancelled by Stop
+func (sm *subscriptionManager) opContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(sm.ctx, sm.opTimeout)
+}
+
 // HandleUserJoinedRoom handles user joining a room
-func (sm *subscriptionManager) HandleUserJoinedRoom(walletAddress, roomID string, targetTokenAddress *string) error {
+func (sm *subscriptionManager) HandleUserJoinedRoom(walletAddress, roomID string, targetTokens []string) error {
+	subscriptions, err := sm.subscribe(walletAddress, roomID, targetTokens)
+	if err != nil {
+		return err
+	}
+	
+	sm.logger.WithFields(logrus.Fields{
+		"wallet":              walletAddress,
+		"room_id":             roomID,
+		"target_tokens":       targetTokens,
+		"total_rooms":         subscriptions,
+	}).Info("User joined room, subscription updated")
+	
+	return nil
+}
+
+// subscribe follows the wallet's logs for a room, or for a personal watch keyed by watchKey, and returns how
+// many rooms and watches the wallet is followed for
+func (sm *subscriptionManager) subscribe(walletAddress, roomID string, targetTokens []string) (int, error) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	
@@ -72,9 +192,9 @@ func (sm *subscriptionManager) HandleUserJoinedRoom(walletAddress, roomID string
 	
 	// Add room context
 	context := &RoomSubscriptionContext{
-		RoomID:             roomID,
-		TargetTokenAddress: targetTokenAddress,
-		JoinedAt:           fmt.Sprintf("%d", getCurrentTimestamp()),
+		RoomID:       roomID,
+		TargetTokens: targetTokens,
+		JoinedAt:     fmt.Sprintf("%d", getCurrentTimestamp()),
 	}
 	
 	sm.walletRoomSubscriptions[walletAddress][roomID] = context
@@ -83,60 +203,71 @@ func (sm *subscriptionManager) HandleUserJoinedRoom(walletAddress, roomID string
 	consumer := sm.createConsumerForWallet(walletAddress)
 	sm.walletNotificationConsumers[walletAddress] = consumer
 	
+	// Reconciliation only covers trades made while the wallet is subscribed
+	if _, exists := sm.walletReconciledUntil[walletAddress]; !exists {
+		sm.walletReconciledUntil[walletAddress] = time.Now()
+	}
+	
 	// Subscribe to wallet logs if not already subscribed
 	if err := sm.quickNodeService.SubscribeWalletLogs(walletAddress, consumer); err != nil {
 		// Clean up on failure
 		delete(sm.walletRoomSubscriptions[walletAddress], roomID)
 		if len(sm.walletRoomSubscriptions[walletAddress]) == 0 {
-			delete(sm.walletRoomSubscriptions, walletAddress)
-			delete(sm.walletNotificationConsumers, walletAddress)
+			sm.forgetWallet(walletAddress)
 		}
-		return fmt.Errorf("failed to subscribe to wallet logs: %w", err)
+		return 0, fmt.Errorf("failed to subscribe to wallet logs: %w", err)
 	}
+	sm.saveIntents(sm.intentsOf(walletAddress, roomID))
 	
-	sm.logger.WithFields(logrus.Fields{
-		"wallet":              walletAddress,
-		"room_id":             roomID,
-		"target_token":        targetTokenAddress,
-		"total_rooms":         len(sm.walletRoomSubscriptions[walletAddress]),
-	}).Info("User joined room, subscription updated")
-	
-	return nil
+	return len(sm.walletRoomSubscriptions[walletAddress]), nil
 }
 
 // HandleUserLeftRoom handles user leaving a room
 func (sm *subscriptionManager) HandleUserLeftRoom(walletAddress, roomID string) error {
+	remaining, err := sm.unsubscribe(walletAddress, roomID)
+	if err != nil {
+		return err
+	}
+	
+	if remaining == 0 {
+		sm.logger.WithField("wallet", walletAddress).Info("User left all rooms, unsubscribed from wallet logs")
+	} else {
+		sm.logger.WithFields(logrus.Fields{
+			"wallet":        walletAddress,
+			"room_id":       roomID,
+			"remaining_rooms": remaining,
+		}).Info("User left room, subscription maintained for other rooms")
+	}
+	
+	return nil
+}
+
+// unsubscribe stops following the wallet's logs for a room or personal watch, and for good once no room or
+// watch is left; it returns how many remain
+func (sm *subscriptionManager) unsubscribe(walletAddress, roomID string) (int, error) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	
-	// Remove room context
-	if roomContexts, exists := sm.walletRoomSubscriptions[walletAddress]; exists {
-		delete(roomContexts, roomID)
-		
-		// If no more rooms for this wallet, unsubscribe completely
-		if len(roomContexts) == 0 {
-			delete(sm.walletRoomSubscriptions, walletAddress)
-			delete(sm.walletNotificationConsumers, walletAddress)
-			
-			if err := sm.quickNodeService.UnsubscribeWalletLogs(walletAddress); err != nil {
-				sm.logger.WithFields(logrus.Fields{
-					"wallet": walletAddress,
-					"error":  err,
-				}).Error("Failed to unsubscribe wallet logs")
-				return fmt.Errorf("failed to unsubscribe wallet logs: %w", err)
-			}
-			
-			sm.logger.WithField("wallet", walletAddress).Info("User left all rooms, unsubscribed from wallet logs")
-		} else {
-			sm.logger.WithFields(logrus.Fields{
-				"wallet":        walletAddress,
-				"room_id":       roomID,
-				"remaining_rooms": len(roomContexts),
-			}).Info("User left room, subscription maintained for other rooms")
-		}
+	roomContexts, exists := sm.walletRoomSubscriptions[walletAddress]
+	if !exists {
+		return 0, nil
+	}
+	delete(roomContexts, roomID)
+	sm.deleteIntent(walletAddress, roomID)
+	if len(roomContexts) > 0 {
+		return len(roomContexts), nil
 	}
 	
-	return nil
+	// No room or watch is left for this wallet, unsubscribe completely
+	sm.forgetWallet(walletAddress)
+	if err := sm.quickNodeService.UnsubscribeWalletLogs(walletAddress); err != nil {
+		sm.logger.WithFields(logrus.Fields{
+			"wallet": walletAddress,
+			"error":  err,
+		}).Error("Failed to unsubscribe wallet logs")
+		return 0, fmt.Errorf("failed to unsubscribe wallet logs: %w", err)
+	}
+	return 0, nil
 }
 
 // HandleRoomClosed handles room closure
@@ -150,12 +281,12 @@ func (sm *subscriptionManager) HandleRoomClosed(roomID string) error {
 	for walletAddress, roomContexts := range sm.walletRoomSubscriptions {
 		if _, exists := roomContexts[roomID]; exists {
 			delete(roomContexts, roomID)
+			sm.deleteIntent(walletAddress, roomID)
 			walletsToUpdate = append(walletsToUpdate, walletAddress)
 			
 			// If no more rooms for this wallet, clean up
 			if len(roomContexts) == 0 {
-				delete(sm.walletRoomSubscriptions, walletAddress)
-				delete(sm.walletNotificationConsumers, walletAddress)
+				sm.forgetWallet(walletAddress)
 			}
 		}
 	}
@@ -180,29 +311,538 @@ func (sm *subscriptionManager) HandleRoomClosed(roomID string) error {
 	return nil
 }
 
-// OnWebSocketReconnected handles WebSocket reconnection
+// HandleRoomTokensChanged points the room's wallet subscriptions at the room's current tokens
+func (sm *subscriptionManager) HandleRoomTokensChanged(roomID string, targetTokens []string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	
+	var updated []*SubscriptionIntent
+	for walletAddress, rooms := range sm.walletRoomSubscriptions {
+		if context, exists := rooms[roomID]; exists {
+			context.TargetTokens = targetTokens
+			updated = append(updated, sm.intentsOf(walletAddress, roomID)...)
+		}
+	}
+	sm.saveIntents(updated)
+	
+	sm.logger.WithFields(logrus.Fields{
+		"room_id":       roomID,
+		"target_tokens": targetTokens,
+		"subscriptions": len(updated),
+	}).Info("Room tokens changed, subscriptions updated")
+}
+
+// RestoreSubscriptions subscribes the wallets of persisted intents that no live instance holds anymore, e.g.
+// after a crash, provided the wallet is still a member of the active room or still watched; intents of former
+// members and watches are deleted
+func (sm *subscriptionManager) RestoreSubscriptions(ctx context.Context) (int, error) {
+	if !sm.store.Enabled() {
+		return 0, nil
+	}
+	
+	intents, err := sm.store.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list subscription intents: %w", err)
+	}
+	
+	rooms := make(map[string]*models.TradeRoom)
+	restored, dropped := 0, 0
+	for _, intent := range intents {
+		if sm.isSubscribed(intent.WalletAddress, intent.RoomID) {
+			continue
+		}
+		if time.Since(intent.HeartbeatAt) < subscriptionIntentStaleAfter {
+			continue // held by another instance
+		}
+		
+		room, member, err := sm.activeMembership(ctx, rooms, intent.WalletAddress, intent.RoomID)
+		if err != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"wallet":  intent.WalletAddress,
+				"room_id": intent.RoomID,
+				"error":   err,
+			}).Warn("Failed to check membership of subscription intent")
+			continue
+		}
+		if !member {
+			sm.deleteIntent(intent.WalletAddress, intent.RoomID)
+			dropped++
+			continue
+		}
+		
+		var targetTokens []string
+		if room != nil {
+			targetTokens = room.BoundTokenAddresses()
+		}
+		if _, err := sm.subscribe(intent.WalletAddress, intent.RoomID, targetTokens); err != nil {
+			// The intent stays for the next run
+			sm.logger.WithFields(logrus.Fields{
+				"wallet":  intent.WalletAddress,
+				"room_id": intent.RoomID,
+				"error":   err,
+			}).Warn("Failed to restore wallet subscription")
+			continue
+		}
+		restored++
+	}
+	
+	if restored > 0 || dropped > 0 {
+		sm.logger.WithFields(logrus.Fields{
+			"restored": restored,
+			"dropped":  dropped,
+		}).Info("Restored orphaned wallet subscriptions")
+	}
+	return restored, nil
+}
+
+// ResubscribeMembers subscribes the members of active rooms and the wallets of personal watches as stored in
+// the database, for subscriptions whose intents were lost, e.g. with Redis flushed or after a long downtime.
+// Subscriptions a live instance holds an intent for are left to it. It returns how many were subscribed.
+func (sm *subscriptionManager) ResubscribeMembers(ctx context.Context) (int, error) {
+	held, err := sm.liveIntents(ctx)
+	if err != nil {
+		return 0, err
+	}
+	
+	resubscribed := 0
+	resubscribe := func(walletAddress, roomID string, targetTokens []string) {
+		if held[subscriptionIntentKey(walletAddress, roomID)] || sm.isSubscribed(walletAddress, roomID) {
+			return
+		}
+		if _, err := sm.subscribe(walletAddress, roomID, targetTokens); err != nil {
+			// Not retried before the next start, or the member joining again
+			sm.logger.WithFields(logrus.Fields{
+				"wallet":  walletAddress,
+				"room_id": roomID,
+				"error":   err,
+			}).Warn("Failed to resubscribe wallet")
+			return
+		}
+		resubscribed++
+	}
+	
+	for offset := 0; ; offset += resubscribePageSize {
+		rooms, err := sm.roomRepo.List(ctx, models.RoomStatusActive, resubscribePageSize, offset)
+		if err != nil {
+			return resubscribed, fmt.Errorf("failed to list active rooms: %w", err)
+		}
+		for _, room := range rooms {
+			members, err := sm.roomRepo.GetMembers(ctx, room.ID)
+			if err != nil {
+				return resubscribed, fmt.Errorf("failed to get room members: %w", err)
+			}
+			targetTokens := room.BoundTokenAddresses()
+			for _, member := range members {
+				resubscribe(member.WalletAddress, room.RoomID, targetTokens)
+			}
+		}
+		if len(rooms) < resubscribePageSize {
+			break
+		}
+	}
+	
+	for offset := 0; ; offset += resubscribePageSize {
+		watches, err := sm.watchRepo.List(ctx, resubscribePageSize, offset)
+		if err != nil {
+			return resubscribed, fmt.Errorf("failed to list wallet watches: %w", err)
+		}
+		for _, watch := range watches {
+			resubscribe(watch.WatchedAddress, watchKey(watch.WalletAddress), nil)
+		}
+		if len(watches) < resubscribePageSize {
+			break
+		}
+	}
+	
+	if resubscribed > 0 {
+		sm.logger.WithField("resubscribed", resubscribed).Info("Resubscribed wallets from the database")
+	}
+	return resubscribed, nil
+}
+
+// liveIntents returns the keys of the persisted intents heartbeated recently, by this or another instance
+func (sm *subscriptionManager) liveIntents(ctx context.Context) (map[string]bool, error) {
+	live := make(map[string]bool)
+	if !sm.store.Enabled() {
+		return live, nil
+	}
+	
+	intents, err := sm.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscription intents: %w", err)
+	}
+	for _, intent := range intents {
+		if time.Since(intent.HeartbeatAt) < subscriptionIntentStaleAfter {
+			live[subscriptionIntentKey(intent.WalletAddress, intent.RoomID)] = true
+		}
+	}
+	return live, nil
+}
+
+// CleanupSubscriptions unsubscribes the rooms of wallets that are no longer members of them, or whose room is no
+// longer active, and watches that were removed elsewhere, heartbeats the intents of the remaining subscriptions and adopts orphaned intents. It returns
+// how many room subscriptions were dropped.
+func (sm *subscriptionManager) CleanupSubscriptions(ctx context.Context) (int, error) {
+	rooms := make(map[string]*models.TradeRoom)
+	removed := 0
+	for walletAddress, roomIDs := range sm.GetActiveSubscriptions() {
+		for _, roomID := range roomIDs {
+			if err := ctx.Err(); err != nil {
+				return removed, err
+			}
+			
+			_, member, err := sm.activeMembership(ctx, rooms, walletAddress, roomID)
+			if err != nil {
+				sm.logger.WithFields(logrus.Fields{
+					"wallet":  walletAddress,
+					"room_id": roomID,
+					"error":   err,
+				}).Warn("Failed to check membership of wallet subscription")
+				continue
+			}
+			if member {
+				continue
+			}
+			
+			// Unsubscribing is retried on the next run if it fails
+			if _, err := sm.unsubscribe(walletAddress, roomID); err != nil {
+				continue
+			}
+			removed++
+		}
+	}
+	
+	sm.mu.RLock()
+	var intents []*SubscriptionIntent
+	for walletAddress, roomContexts := range sm.walletRoomSubscriptions {
+		for roomID := range roomContexts {
+			intents = append(intents, sm.intentsOf(walletAddress, roomID)...)
+		}
+	}
+	sm.mu.RUnlock()
+	if err := sm.store.Save(ctx, intents); err != nil {
+		return removed, fmt.Errorf("failed to heartbeat subscription intents: %w", err)
+	}
+	
+	if removed > 0 {
+		sm.logger.WithField("removed", removed).Info("Removed orphaned wallet subscriptions")
+	}
+	
+	if _, err := sm.RestoreSubscriptions(ctx); err != nil {
+		return removed, err
+	}
+	return removed, nil
+}
+
+// activeMembership reports whether the wallet is a member of the room and the room is active; rooms are
+// looked up by public room ID and cached in rooms for the rest of the run. A personal watch counts as a
+// membership, without a room, for as long as the watch exists.
+func (sm *subscriptionManager) activeMembership(ctx context.Context, rooms map[string]*models.TradeRoom, walletAddress, roomID string) (*models.TradeRoom, bool, error) {
+	if watcherAddress, ok := watcherOf(roomID); ok {
+		watch, err := sm.watchRepo.Get(ctx, watcherAddress, walletAddress)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get wallet watch: %w", err)
+		}
+		return nil, watch != nil, nil
+	}
+	
+	room, cached := rooms[roomID]
+	if !cached {
+		var err error
+		room, err = sm.roomRepo.GetByRoomID(ctx, roomID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get room: %w", err)
+		}
+		rooms[roomID] = room
+	}
+	if room == nil || room.Status != models.RoomStatusActive {
+		return room, false, nil
+	}
+	
+	member, err := sm.roomRepo.GetMemberByAddress(ctx, room.ID, walletAddress)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get member: %w", err)
+	}
+	return room, member != nil, nil
+}
+
+func (sm *subscriptionManager) isSubscribed(walletAddress, roomID string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	
+	_, subscribed := sm.walletRoomSubscriptions[walletAddress][roomID]
+	return subscribed
+}
+
+// intentsOf returns the intent of a wallet's room subscription, or none if it is not subscribed; callers hold mu
+func (sm *subscriptionManager) intentsOf(walletAddress, roomID string) []*SubscriptionIntent {
+	context, exists := sm.walletRoomSubscriptions[walletAddress][roomID]
+	if !exists {
+		return nil
+	}
+	return []*SubscriptionIntent{{
+		WalletAddress: walletAddress,
+		RoomID:        roomID,
+		TargetTokens:  context.TargetTokens,
+	}}
+}
+
+// saveIntents persists subscription intents; the subscriptions stand even if persisting fails
+func (sm *subscriptionManager) saveIntents(intents []*SubscriptionIntent) {
+	ctx, cancel := sm.opContext()
+	defer cancel()
+	if err := sm.store.Save(ctx, intents); err != nil {
+		sm.logger.WithError(err).Warn("Failed to persist subscription intents")
+	}
+}
+
+func (sm *subscriptionManager) deleteIntent(walletAddress, roomID string) {
+	ctx, cancel := sm.opContext()
+	defer cancel()
+	if err := sm.store.Delete(ctx, walletAddress, roomID); err != nil {
+		sm.logger.WithFields(logrus.Fields{
+			"wallet":  walletAddress,
+			"room_id": roomID,
+			"error":   err,
+		}).Warn("Failed to delete subscription intent")
+	}
+}
+
+// OnWebSocketReconnected verifies the wallet subscriptions once the QuickNode connection is back. Wallets whose
+// subscription was not confirmed in time are subscribed again, and each wallet's latest signature is checked as a
+// sanity check: wallets with a trade the live subscription never delivered are reconciled right away.
 func (sm *subscriptionManager) OnWebSocketReconnected() error {
+	// Give the subscriptions sent on reconnect time to be confirmed
+	select {
+	case <-time.After(subscriptionConfirmWait):
+	case <-sm.ctx.Done():
+		return sm.ctx.Err()
+	}
+	
 	sm.mu.RLock()
-	consumersToRestore := make(map[string]blockchain.LogConsumer)
+	consumers := make(map[string]blockchain.LogConsumer, len(sm.walletNotificationConsumers))
 	for wallet, consumer := range sm.walletNotificationConsumers {
-		consumersToRestore[wallet] = consumer
+		consumers[wallet] = consumer
+	}
+	watermarks := make(map[string]time.Time, len(sm.walletReconciledUntil))
+	for walletAddress, reconciledUntil := range sm.walletReconciledUntil {
+		watermarks[walletAddress] = reconciledUntil
 	}
 	sm.mu.RUnlock()
 	
-	// Restore all subscriptions
-	for walletAddress, consumer := range consumersToRestore {
-		if err := sm.quickNodeService.SubscribeWalletLogs(walletAddress, consumer); err != nil {
+	confirmed := sm.quickNodeService.GetActiveSubscriptions()
+	resubscribed, stale, backfilled := 0, 0, 0
+	for walletAddress, consumer := range consumers {
+		if _, ok := confirmed[walletAddress]; !ok {
+			if err := sm.quickNodeService.SubscribeWalletLogs(walletAddress, consumer); err != nil {
+				sm.logger.WithFields(logrus.Fields{
+					"wallet": walletAddress,
+					"error":  err,
+				}).Error("Failed to resubscribe wallet after reconnection")
+			} else {
+				resubscribed++
+			}
+		}
+		
+		signatures, err := sm.transactionProcessor.GetSignaturesForAddress(walletAddress, 1)
+		if err != nil {
 			sm.logger.WithFields(logrus.Fields{
 				"wallet": walletAddress,
 				"error":  err,
-			}).Error("Failed to restore wallet subscription after reconnection")
+			}).Warn("Failed to check latest wallet signature after reconnection")
+			continue
 		}
+		if len(signatures) == 0 || sm.isProcessed(walletAddress, signatures[0].Signature) {
+			continue
+		}
+		if !time.Unix(signatures[0].BlockTime, 0).After(watermarks[walletAddress]) {
+			continue
+		}
+		
+		// The latest trade came in after the watermark but was never processed, so notifications were lost
+		stale++
+		ctx, cancel := sm.opContext()
+		count, err := sm.reconcileWallet(ctx, walletAddress, watermarks[walletAddress])
+		cancel()
+		if err != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"wallet": walletAddress,
+				"error":  err,
+			}).Warn("Failed to reconcile wallet after reconnection")
+			continue
+		}
+		backfilled += count
 	}
 	
-	sm.logger.WithField("restored_subscriptions", len(consumersToRestore)).Info("Restored wallet subscriptions after WebSocket reconnection")
+	sm.logger.WithFields(logrus.Fields{
+		"wallets":      len(consumers),
+		"resubscribed": resubscribed,
+		"stale":        stale,
+		"backfilled":   backfilled,
+	}).Info("Verified wallet subscriptions after WebSocket reconnection")
 	return nil
 }
 
+// ReconcileWallets fetches the recent signatures of every subscribed wallet and runs those the live log
+// subscription never delivered, e.g. during a reconnect, through the processor; it returns how many trades
+// were backfilled into rooms
+func (sm *subscriptionManager) ReconcileWallets(ctx context.Context) (int, error) {
+	sm.mu.RLock()
+	watermarks := make(map[string]time.Time, len(sm.walletReconciledUntil))
+	for walletAddress, reconciledUntil := range sm.walletReconciledUntil {
+		watermarks[walletAddress] = reconciledUntil
+	}
+	sm.mu.RUnlock()
+	
+	backfilled := 0
+	for walletAddress, reconciledUntil := range watermarks {
+		if err := ctx.Err(); err != nil {
+			return backfilled, err
+		}
+		
+		count, err := sm.reconcileWallet(ctx, walletAddress, reconciledUntil)
+		if err != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"wallet": walletAddress,
+				"error":  err,
+			}).Warn("Failed to reconcile wallet transactions")
+			continue
+		}
+		backfilled += count
+	}
+	
+	sm.pruneProcessedSignatures()
+	
+	if backfilled > 0 {
+		sm.logger.WithFields(logrus.Fields{
+			"wallets":    len(watermarks),
+			"backfilled": backfilled,
+		}).Info("Backfilled missed wallet transactions")
+	}
+	return backfilled, nil
+}
+
+// reconcileWallet processes the wallet's unprocessed signatures between its watermark and the grace period,
+// then moves the watermark up
+func (sm *subscriptionManager) reconcileWallet(ctx context.Context, walletAddress string, reconciledUntil time.Time) (int, error) {
+	signatures, err := sm.transactionProcessor.GetSignaturesForAddress(walletAddress, reconcileSignatureLimit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get signatures: %w", err)
+	}
+	
+	// Signatures newer than the grace period may still arrive through the live subscription
+	cutoff := time.Now().Add(-reconcileGracePeriod)
+	if !cutoff.After(reconciledUntil) {
+		return 0, nil
+	}
+	if len(signatures) == reconcileSignatureLimit && time.Unix(signatures[len(signatures)-1].BlockTime, 0).After(reconciledUntil) {
+		sm.logger.WithField("wallet", walletAddress).Warn("More signatures since last reconciliation than fetched, older ones are skipped")
+	}
+	
+	backfilled := 0
+	reconciledTo := cutoff
+	for i := len(signatures) - 1; i >= 0; i-- { // oldest first, so rooms see trades in order
+		sig := signatures[i]
+		blockTime := time.Unix(sig.BlockTime, 0)
+		if sig.BlockTime == 0 || blockTime.Before(reconciledUntil) || blockTime.After(cutoff) {
+			continue
+		}
+		if sm.isProcessed(walletAddress, sig.Signature) {
+			continue
+		}
+		
+		details, err := sm.transactionProcessor.GetTransactionDetails(sig.Signature)
+		if err != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"wallet":    walletAddress,
+				"signature": sig.Signature,
+				"error":     err,
+			}).Warn("Failed to fetch transaction during reconciliation")
+			// Keep the watermark at the failed signature so the next run retries it
+			if blockTime.Before(reconciledTo) {
+				reconciledTo = blockTime
+			}
+			continue
+		}
+		sm.markProcessed(walletAddress, sig.Signature)
+		
+		if !sm.transactionProcessor.IsRelevantTransaction(details.Meta.LogMessages) {
+			continue
+		}
+		action, err := sm.transactionProcessor.AnalyzeTransaction(ctx, details)
+		if err != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"wallet":    walletAddress,
+				"signature": sig.Signature,
+				"error":     err,
+			}).Warn("Failed to analyze transaction during reconciliation")
+			continue
+		}
+		
+		sm.logger.WithFields(logrus.Fields{
+			"wallet":    walletAddress,
+			"signature": sig.Signature,
+		}).Info("Backfilling missed wallet transaction")
+		sm.deliverAction(ctx, walletAddress, action)
+		backfilled++
+	}
+	
+	sm.mu.Lock()
+	if _, subscribed := sm.walletReconciledUntil[walletAddress]; subscribed {
+		sm.walletReconciledUntil[walletAddress] = reconciledTo
+	}
+	sm.mu.Unlock()
+	
+	return backfilled, nil
+}
+
+// forgetWallet drops all state of a wallet that is no longer subscribed; callers hold mu
+func (sm *subscriptionManager) forgetWallet(walletAddress string) {
+	delete(sm.walletRoomSubscriptions, walletAddress)
+	delete(sm.walletNotificationConsumers, walletAddress)
+	delete(sm.walletReconciledUntil, walletAddress)
+	delete(sm.processedSignatures, walletAddress)
+}
+
+func (sm *subscriptionManager) isProcessed(walletAddress, signature string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	
+	_, processed := sm.processedSignatures[walletAddress][signature]
+	return processed
+}
+
+func (sm *subscriptionManager) markProcessed(walletAddress, signature string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	
+	if _, subscribed := sm.walletReconciledUntil[walletAddress]; !subscribed {
+		return
+	}
+	if _, exists := sm.processedSignatures[walletAddress]; !exists {
+		sm.processedSignatures[walletAddress] = make(map[string]time.Time)
+	}
+	sm.processedSignatures[walletAddress][signature] = time.Now()
+}
+
+// pruneProcessedSignatures forgets signatures processed long enough ago to be behind every watermark
+func (sm *subscriptionManager) pruneProcessedSignatures() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	
+	cutoff := time.Now().Add(-processedSignatureRetention)
+	for walletAddress, signatures := range sm.processedSignatures {
+		for signature, processedAt := range signatures {
+			if processedAt.Before(cutoff) {
+				delete(signatures, signature)
+			}
+		}
+		if len(signatures) == 0 {
+			delete(sm.processedSignatures, walletAddress)
+		}
+	}
+}
+
 // GetActiveSubscriptions returns active subscriptions
 func (sm *subscriptionManager) GetActiveSubscriptions() map[string][]string {
 	sm.mu.RLock()
@@ -223,8 +863,17 @@ func (sm *subscriptionManager) GetActiveSubscriptions() map[string][]string {
 // createConsumerForWallet creates a log consumer for a specific wallet
 func (sm *subscriptionManager) createConsumerForWallet(walletAddress string) blockchain.LogConsumer {
 	return func(notification *blockchain.LogsNotification) error {
+		// Processed notifications are shown as a pending trade until the transaction can be read
+		if sm.transactionProcessor.Commitments().Subscription == blockchain.CommitmentProcessed &&
+			sm.transactionProcessor.IsRelevantTransaction(notification.Params.Result.Value.Logs) {
+			sm.deliverPending(walletAddress, notification)
+		}
+		
+		ctx, cancel := sm.opContext()
+		defer cancel()
+		
 		// Process the log notification
-		action, err := sm.transactionProcessor.ProcessLogNotification(notification)
+		action, err := sm.transactionProcessor.ProcessLogNotification(ctx, notification)
 		if err != nil {
 			sm.logger.WithFields(logrus.Fields{
 				"wallet": walletAddress,
@@ -233,102 +882,198 @@ func (sm *subscriptionManager) createConsumerForWallet(walletAddress string) blo
 			return err
 		}
 		
+		sm.markProcessed(walletAddress, notification.Params.Result.Value.Signature)
+		
 		// If no relevant action was found, skip
 		if action == nil {
 			return nil
 		}
 		
-		// Get current room contexts for this wallet
-		sm.mu.RLock()
-		roomContexts, exists := sm.walletRoomSubscriptions[walletAddress]
-		if !exists {
-			sm.mu.RUnlock()
-			return nil
+		sm.deliverAction(ctx, walletAddress, action)
+		return nil
+	}
+}
+
+// deliverPending broadcasts an early hint of a wallet trade that is not yet confirmed
+func (sm *subscriptionManager) deliverPending(walletAddress string, notification *blockchain.LogsNotification) {
+	sm.mu.RLock()
+	roomIDsToNotify := make([]string, 0, len(sm.walletRoomSubscriptions[walletAddress]))
+	for roomID := range sm.walletRoomSubscriptions[walletAddress] {
+		if _, watch := watcherOf(roomID); watch {
+			continue // watchers are only notified of confirmed trades
 		}
-		
-		// Create a copy to avoid holding the lock too long
-		roomIDsToNotify := make([]string, 0, len(roomContexts))
-		for roomID := range roomContexts {
-			roomIDsToNotify = append(roomIDsToNotify, roomID)
+		roomIDsToNotify = append(roomIDsToNotify, roomID)
+	}
+	sm.mu.RUnlock()
+	
+	for _, roomID := range roomIDsToNotify {
+		message := &Message{
+			Type: MessageTypeTradePending,
+			Data: map[string]interface{}{
+				"wallet_address": walletAddress,
+				"signature":      notification.Params.Result.Value.Signature,
+				"slot":           notification.Params.Result.Value.Slot,
+			},
+			From: walletAddress,
 		}
+		if err := sm.wsService.BroadcastToRoom(roomID, message); err != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"room_id": roomID,
+				"wallet":  walletAddress,
+				"error":   err,
+			}).Debug("Failed to broadcast pending trade to room")
+		}
+	}
+}
+
+// OnTrade registers a listener for detected wallet trades
+func (sm *subscriptionManager) OnTrade(listener TradeListener) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.tradeListeners = append(sm.tradeListeners, listener)
+}
+
+// deliverAction broadcasts a wallet's trade to every room the wallet is still a member of, and hands it to the
+// watch listeners once per watcher
+func (sm *subscriptionManager) deliverAction(ctx context.Context, walletAddress string, action *blockchain.AnalyzedWalletAction) {
+	sm.mu.RLock()
+	listeners := sm.tradeListeners
+	sm.mu.RUnlock()
+	for _, listener := range listeners {
+		listener(ctx, action)
+	}
+	
+	// Get current room contexts for this wallet
+	sm.mu.RLock()
+	roomContexts, exists := sm.walletRoomSubscriptions[walletAddress]
+	if !exists {
 		sm.mu.RUnlock()
+		return
+	}
+	
+	// Create a copy to avoid holding the lock too long; rooms bound to tokens only get trades of those tokens
+	roomIDsToNotify := make([]string, 0, len(roomContexts))
+	var watchers []string
+	for roomID, roomContext := range roomContexts {
+		if watcherAddress, ok := watcherOf(roomID); ok {
+			watchers = append(watchers, watcherAddress)
+			continue
+		}
+		if roomContext.watches(action) {
+			roomIDsToNotify = append(roomIDsToNotify, roomID)
+		}
+	}
+	watchListeners := sm.watchListeners
+	sm.mu.RUnlock()
+	
+	for _, watcherAddress := range watchers {
+		for _, listener := range watchListeners {
+			listener(ctx, watcherAddress, action)
+		}
+	}
+	
+	// Notify all rooms where this wallet is a member
+	for _, roomID := range roomIDsToNotify {
+		// Check if the room still exists and wallet is still a member
+		member, err := sm.validateRoomMembership(ctx, walletAddress, roomID)
+		if err != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"wallet":  walletAddress,
+				"room_id": roomID,
+				"error":   err,
+			}).Warn("Wallet no longer member of room, skipping notification")
+			continue
+		}
 		
-		// Notify all rooms where this wallet is a member
-		for _, roomID := range roomIDsToNotify {
-			// Check if the room still exists and wallet is still a member
-			if err := sm.validateRoomMembership(walletAddress, roomID); err != nil {
-				sm.logger.WithFields(logrus.Fields{
-					"wallet":  walletAddress,
-					"room_id": roomID,
-					"error":   err,
-				}).Warn("Wallet no longer member of room, skipping notification")
-				continue
-			}
-			
-			// Create trade event message for WebSocket
-			tradeEventMessage := &Message{
-				Type: MessageTypeTradeEvent,
-				Data: map[string]interface{}{
-					"wallet_address":    action.WalletAddress,
-					"platform":          action.Platform,
-					"transaction_type":  action.TransactionType,
-					"input_token":       action.InputToken,
-					"output_token":      action.OutputToken,
-					"signature":         action.Signature,
-					"block_time":        action.BlockTime,
-					"success":           action.Success,
-					"fee":               action.Fee,
-				},
-				From: action.WalletAddress,
-			}
-			
-			// Broadcast to room via WebSocket
-			if err := sm.wsService.BroadcastToRoom(roomID, tradeEventMessage); err != nil {
-				sm.logger.WithFields(logrus.Fields{
-					"room_id": roomID,
-					"wallet":  walletAddress,
-					"error":   err,
-				}).Error("Failed to broadcast trade event to room")
-			} else {
-				sm.logger.WithFields(logrus.Fields{
-					"room_id":          roomID,
-					"wallet":           walletAddress,
-					"transaction_type": action.TransactionType,
-					"platform":         action.Platform,
-				}).Info("Broadcasted trade event to room")
-			}
+		// Create trade event message for WebSocket
+		tradeEventData := map[string]interface{}{
+			"wallet_address":    action.WalletAddress,
+			"platform":          action.Platform,
+			"transaction_type":  action.TransactionType,
+			"input_token":       action.InputToken,
+			"output_token":      action.OutputToken,
+			"signature":         action.Signature,
+			"block_time":        action.BlockTime,
+			"success":           action.Success,
+			"fee":               action.Fee,
+			"value_usd":         action.ValueUSD,
+			"provisional":       action.Provisional,
+		}
+		if member.HideTradeSizes {
+			// Rationales are left out too, as they may quote the amounts
+			hideTradeSize(tradeEventData, action)
+		} else if line := sm.rationaleFor(ctx, roomID, action); line != "" {
+			tradeEventData["rationale"] = line
+		}
+		tradeEventMessage := &Message{
+			Type: MessageTypeTradeEvent,
+			Data: tradeEventData,
+			From: action.WalletAddress,
 		}
 		
-		return nil
+		// Broadcast to room via WebSocket
+		if err := sm.wsService.BroadcastToRoom(roomID, tradeEventMessage); err != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"room_id": roomID,
+				"wallet":  walletAddress,
+				"error":   err,
+			}).Error("Failed to broadcast trade event to room")
+		} else {
+			sm.logger.WithFields(logrus.Fields{
+				"room_id":          roomID,
+				"wallet":           walletAddress,
+				"transaction_type": action.TransactionType,
+				"platform":         action.Platform,
+			}).Info("Broadcasted trade event to room")
+		}
+	}
+}
+
+// rationaleFor generates the AI rationale of a detected trade if the room enables rationales
+func (sm *subscriptionManager) rationaleFor(ctx context.Context, roomID string, action *blockchain.AnalyzedWalletAction) string {
+	if !action.Success {
+		return ""
+	}
+	
+	var room *models.TradeRoom
+	var err error
+	if roomUUID, parseErr := uuid.Parse(roomID); parseErr == nil {
+		room, err = sm.roomRepo.GetByID(ctx, roomUUID)
+	} else {
+		room, err = sm.roomRepo.GetByRoomID(ctx, roomID)
 	}
+	if err != nil || room == nil {
+		return ""
+	}
+	return sm.rationale.ForDetectedTrade(ctx, room, action)
 }
 
-// validateRoomMembership validates that a wallet is still a member of a room
-func (sm *subscriptionManager) validateRoomMembership(walletAddress, roomID string) error {
+// validateRoomMembership validates that a wallet is still a member of a room and returns the membership
+func (sm *subscriptionManager) validateRoomMembership(ctx context.Context, walletAddress, roomID string) (*models.RoomMember, error) {
 	// Parse room ID to UUID
 	roomUUID, err := uuid.Parse(roomID)
 	if err != nil {
 		// Try to get room by room_id string field
-		room, err := sm.roomRepo.GetByRoomID(context.Background(), roomID)
+		room, err := sm.roomRepo.GetByRoomID(ctx, roomID)
 		if err != nil {
-			return fmt.Errorf("failed to get room: %w", err)
+			return nil, fmt.Errorf("failed to get room: %w", err)
 		}
 		if room == nil {
-			return fmt.Errorf("room not found")
+			return nil, fmt.Errorf("room not found")
 		}
 		roomUUID = room.ID
 	}
 	
 	// Check if member exists
-	member, err := sm.roomRepo.GetMemberByAddress(context.Background(), roomUUID, walletAddress)
+	member, err := sm.roomRepo.GetMemberByAddress(ctx, roomUUID, walletAddress)
 	if err != nil {
-		return fmt.Errorf("failed to get member: %w", err)
+		return nil, fmt.Errorf("failed to get member: %w", err)
 	}
 	if member == nil {
-		return fmt.Errorf("wallet is not a member of room")
+		return nil, fmt.Errorf("wallet is not a member of room")
 	}
 	
-	return nil
+	return member, nil
 }
 
 // getCurrentTimestamp returns current timestamp as int64
@@ -0,0 +1,117 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+const subscriptionIntentPrefix = "subs:intent:" // string per wallet and room: the subscription intent
+
+// Intents are heartbeated by the instance holding them; the TTL only bounds how long the intents of a
+// stopped deployment are kept for the next start to restore
+const subscriptionIntentTTL = 24 * time.Hour
+
+// SubscriptionIntent records that a wallet's logs should be followed for a room, and which instance follows them
+type SubscriptionIntent struct {
+	WalletAddress string    `json:"wallet_address"`
+	RoomID        string    `json:"room_id"`
+	TargetTokens  []string  `json:"target_tokens,omitempty"`
+	InstanceID    string    `json:"instance_id"`
+	HeartbeatAt   time.Time `json:"heartbeat_at"`
+}
+
+// SubscriptionStore persists wallet subscription intents, so subscriptions outlive the process that made them
+type SubscriptionStore interface {
+	Enabled() bool // false without Redis; subscriptions then only live in memory
+
+	Save(ctx context.Context, intents []*SubscriptionIntent) error // claims the intents for this instance and refreshes their TTL
+	Delete(ctx context.Context, walletAddress, roomID string) error
+	List(ctx context.Context) ([]*SubscriptionIntent, error)
+}
+
+type redisSubscriptionStore struct {
+	client     *redis.Client
+	instanceID string
+	logger     *logrus.Logger
+}
+
+// NewSubscriptionStore creates a Redis-backed subscription intent store; with a nil client nothing is persisted
+func NewSubscriptionStore(client *redis.Client, instanceID string, logger *logrus.Logger) SubscriptionStore {
+	return &redisSubscriptionStore{
+		client:     client,
+		instanceID: instanceID,
+		logger:     logger,
+	}
+}
+
+func (s *redisSubscriptionStore) Enabled() bool {
+	return s.client != nil
+}
+
+func (s *redisSubscriptionStore) Save(ctx context.Context, intents []*SubscriptionIntent) error {
+	if s.client == nil || len(intents) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	pipe := s.client.Pipeline()
+	for _, intent := range intents {
+		intent.InstanceID = s.instanceID
+		intent.HeartbeatAt = now
+		entry, err := json.Marshal(intent)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, subscriptionIntentKey(intent.WalletAddress, intent.RoomID), entry, subscriptionIntentTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (s *redisSubscriptionStore) Delete(ctx context.Context, walletAddress, roomID string) error {
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Del(ctx, subscriptionIntentKey(walletAddress, roomID)).Err()
+}
+
+// List returns the intents of every instance; unreadable entries are dropped
+func (s *redisSubscriptionStore) List(ctx context.Context) ([]*SubscriptionIntent, error) {
+	if s.client == nil {
+		return nil, nil
+	}
+
+	var intents []*SubscriptionIntent
+	iter := s.client.Scan(ctx, 0, subscriptionIntentPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		entry, err := s.client.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue // expired or deleted since the scan
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var intent SubscriptionIntent
+		if err := json.Unmarshal(entry, &intent); err != nil {
+			s.logger.WithField("key", key).Warn("Dropping unreadable subscription intent")
+			s.client.Del(ctx, key)
+			continue
+		}
+		intents = append(intents, &intent)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return intents, nil
+}
+
+func subscriptionIntentKey(walletAddress, roomID string) string {
+	return subscriptionIntentPrefix + walletAddress + ":" + roomID
+}
@@ -0,0 +1,232 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+// ErrThrottled is matched by every ThrottleError
+var ErrThrottled = errors.New("too many room actions")
+
+// ThrottleAction is a room action with its own rate limit
+type ThrottleAction string
+
+const (
+	ThrottleActionShare      ThrottleAction = "share"
+	ThrottleActionChat       ThrottleAction = "chat"
+	ThrottleActionTradeEvent ThrottleAction = "trade_event"
+	ThrottleActionAIAsk      ThrottleAction = "ai_ask"
+)
+
+var defaultThrottleLimits = map[ThrottleAction]config.ThrottleLimit{
+	ThrottleActionShare:      {Limit: 10, Window: time.Minute},
+	ThrottleActionChat:       {Limit: 30, Window: time.Minute},
+	ThrottleActionTradeEvent: {Limit: 60, Window: time.Minute},
+	ThrottleActionAIAsk:      {Limit: 5, Window: 10 * time.Minute},
+}
+
+const (
+	defaultMuteAfter    = 3
+	defaultMuteWindow   = 10 * time.Minute
+	defaultMuteDuration = 15 * time.Minute
+)
+
+// ThrottleError reports a rejected room action and when it may be retried
+type ThrottleError struct {
+	Action     ThrottleAction
+	Limit      int
+	Window     time.Duration
+	RetryAfter time.Duration
+	Muted      bool // the wallet is muted in the room for repeatedly exceeding limits
+}
+
+func (e *ThrottleError) Error() string {
+	if e.Muted {
+		return fmt.Sprintf("muted in room for %s after repeatedly exceeding limits", e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%s limit of %d per %s exceeded, retry in %s", e.Action, e.Limit, e.Window, e.RetryAfter.Round(time.Second))
+}
+
+func (e *ThrottleError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+// RetryAfterSeconds rounds RetryAfter up to whole seconds
+func (e *ThrottleError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// Details is the structured form of the rejection returned to clients
+func (e *ThrottleError) Details() map[string]interface{} {
+	details := map[string]interface{}{
+		"action":              e.Action,
+		"muted":               e.Muted,
+		"retry_after_seconds": e.RetryAfterSeconds(),
+	}
+	if !e.Muted {
+		details["limit"] = e.Limit
+		details["window_seconds"] = int(e.Window.Seconds())
+	}
+	return details
+}
+
+// Throttle limits how often a wallet may act in a room
+type Throttle interface {
+	// Allow records an action, or returns a *ThrottleError if the wallet is over its limit or muted
+	Allow(ctx context.Context, action ThrottleAction, roomID, walletAddress string) error
+}
+
+type redisThrottle struct {
+	client *redis.Client
+	config *config.ThrottleConfig
+	logger *logrus.Logger
+}
+
+// NewThrottle creates a Redis-backed throttle; with a nil client every action is allowed
+func NewThrottle(client *redis.Client, config *config.ThrottleConfig, logger *logrus.Logger) Throttle {
+	return &redisThrottle{
+		client: client,
+		config: config,
+		logger: logger,
+	}
+}
+
+// slidingWindowScript records an action in a sorted set of timestamps unless the window is full.
+// It returns 0 when allowed, otherwise the milliseconds until the oldest action leaves the window.
+const slidingWindowScript = `
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	return math.max(tonumber(oldest[2]) + window - now, 1)
+end
+redis.call('ZADD', KEYS[1], now, ARGV[4])
+redis.call('PEXPIRE', KEYS[1], window)
+return 0
+`
+
+// Allow fails open when Redis is unavailable so that throttling never blocks the room
+func (t *redisThrottle) Allow(ctx context.Context, action ThrottleAction, roomID, walletAddress string) error {
+	if t.client == nil {
+		return nil
+	}
+
+	muteKey := fmt.Sprintf("throttle:mute:%s:%s", roomID, walletAddress)
+	if ttl, err := t.client.PTTL(ctx, muteKey).Result(); err != nil {
+		t.warn(err, action, roomID, walletAddress)
+		return nil
+	} else if ttl > 0 {
+		return &ThrottleError{Action: action, RetryAfter: ttl, Muted: true}
+	}
+
+	limit := t.limit(action)
+	now := time.Now().UnixMilli()
+	key := fmt.Sprintf("throttle:%s:%s:%s", action, roomID, walletAddress)
+	retryMs, err := t.client.Eval(ctx, slidingWindowScript, []string{key},
+		now, limit.Window.Milliseconds(), limit.Limit, uuid.NewString()).Int64()
+	if err != nil {
+		t.warn(err, action, roomID, walletAddress)
+		return nil
+	}
+	if retryMs == 0 {
+		return nil
+	}
+
+	throttleErr := &ThrottleError{
+		Action:     action,
+		Limit:      limit.Limit,
+		Window:     limit.Window,
+		RetryAfter: time.Duration(retryMs) * time.Millisecond,
+	}
+	if muted := t.strike(ctx, roomID, walletAddress, muteKey); muted > 0 {
+		throttleErr.Muted = true
+		throttleErr.RetryAfter = muted
+		t.logger.WithFields(logrus.Fields{
+			"room_id":  roomID,
+			"wallet":   walletAddress,
+			"action":   action,
+			"duration": muted,
+		}).Warn("Wallet muted in room for exceeding limits")
+	}
+	return throttleErr
+}
+
+// strike counts a throttled attempt and mutes the wallet once it has too many, returning the mute duration
+func (t *redisThrottle) strike(ctx context.Context, roomID, walletAddress, muteKey string) time.Duration {
+	muteAfter, muteWindow, muteDuration := t.muteSettings()
+
+	strikeKey := fmt.Sprintf("throttle:strikes:%s:%s", roomID, walletAddress)
+	strikes, err := t.client.Incr(ctx, strikeKey).Result()
+	if err != nil {
+		t.warn(err, "", roomID, walletAddress)
+		return 0
+	}
+	if strikes == 1 {
+		t.client.PExpire(ctx, strikeKey, muteWindow)
+	}
+	if strikes < int64(muteAfter) {
+		return 0
+	}
+
+	if err := t.client.Set(ctx, muteKey, 1, muteDuration).Err(); err != nil {
+		t.warn(err, "", roomID, walletAddress)
+		return 0
+	}
+	t.client.Del(ctx, strikeKey)
+	return muteDuration
+}
+
+func (t *redisThrottle) limit(action ThrottleAction) config.ThrottleLimit {
+	var configured config.ThrottleLimit
+	switch action {
+	case ThrottleActionShare:
+		configured = t.config.Share
+	case ThrottleActionChat:
+		configured = t.config.Chat
+	case ThrottleActionTradeEvent:
+		configured = t.config.TradeEvent
+	case ThrottleActionAIAsk:
+		configured = t.config.AIAsk
+	}
+
+	limit := defaultThrottleLimits[action]
+	if configured.Limit > 0 {
+		limit.Limit = configured.Limit
+	}
+	if configured.Window > 0 {
+		limit.Window = configured.Window
+	}
+	return limit
+}
+
+func (t *redisThrottle) muteSettings() (int, time.Duration, time.Duration) {
+	muteAfter, muteWindow, muteDuration := defaultMuteAfter, defaultMuteWindow, defaultMuteDuration
+	if t.config.MuteAfter > 0 {
+		muteAfter = t.config.MuteAfter
+	}
+	if t.config.MuteWindow > 0 {
+		muteWindow = t.config.MuteWindow
+	}
+	if t.config.MuteDuration > 0 {
+		muteDuration = t.config.MuteDuration
+	}
+	return muteAfter, muteWindow, muteDuration
+}
+
+func (t *redisThrottle) warn(err error, action ThrottleAction, roomID, walletAddress string) {
+	t.logger.WithFields(logrus.Fields{
+		"error":   err,
+		"action":  action,
+		"room_id": roomID,
+		"wallet":  walletAddress,
+	}).Warn("Room throttle unavailable, allowing action")
+}
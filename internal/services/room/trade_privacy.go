@@ -0,0 +1,51 @@
+package room
+
+import (
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+)
+
+// Trade size buckets broadcast for members who hide their trade sizes, by USD value
+const (
+	tradeSizeSmall  = "small"
+	tradeSizeMedium = "medium"
+	tradeSizeLarge  = "large"
+
+	mediumTradeMinUSD = 1000.0
+	largeTradeMinUSD  = 10000.0
+)
+
+// tradeSizeBucket returns the size bucket of a trade value, or "" if the trade could not be valued
+func tradeSizeBucket(valueUSD float64) string {
+	switch {
+	case valueUSD <= 0:
+		return ""
+	case valueUSD < mediumTradeMinUSD:
+		return tradeSizeSmall
+	case valueUSD < largeTradeMinUSD:
+		return tradeSizeMedium
+	default:
+		return tradeSizeLarge
+	}
+}
+
+// hideTradeSize replaces the amounts and value of a trade event with its size bucket, keeping which tokens
+// were swapped
+func hideTradeSize(data map[string]interface{}, action *blockchain.AnalyzedWalletAction) {
+	delete(data, "value_usd")
+	data["input_token"] = tokenWithoutAmount(action.InputToken)
+	data["output_token"] = tokenWithoutAmount(action.OutputToken)
+	if bucket := tradeSizeBucket(action.ValueUSD); bucket != "" {
+		data["size_bucket"] = bucket
+	}
+}
+
+func tokenWithoutAmount(token *blockchain.TokenAmount) map[string]interface{} {
+	if token == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"mint":     token.Mint,
+		"decimals": token.Decimals,
+		"symbol":   token.Symbol,
+	}
+}
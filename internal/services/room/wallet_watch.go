@@ -0,0 +1,141 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+var (
+	ErrWatchNotFound  = errors.New("wallet watch not found")
+	ErrTooManyWatches = errors.New("too many watched wallets")
+	ErrWatchSelf      = errors.New("a wallet cannot watch itself")
+)
+
+// defaultMaxWalletWatches is how many wallets a user may watch when no limit is configured
+const defaultMaxWalletWatches = 20
+
+// A personal watch is kept among the wallet's room subscriptions under this prefix and the watcher's address,
+// so it is persisted, heartbeated, restored and reconciled like a room subscription
+const watchKeyPrefix = "watch:"
+
+func watchKey(watcherAddress string) string {
+	return watchKeyPrefix + watcherAddress
+}
+
+// watcherOf returns the watching wallet of a personal watch key, or false for a room ID
+func watcherOf(roomID string) (string, bool) {
+	if !strings.HasPrefix(roomID, watchKeyPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(roomID, watchKeyPrefix), true
+}
+
+// WatchWallet subscribes the watcher to every trade of the wallet, delivered to the watch listeners. Watching
+// a wallet already watched returns the existing watch.
+func (sm *subscriptionManager) WatchWallet(ctx context.Context, watcherAddress, walletAddress string) (*models.WalletWatch, error) {
+	if err := solana.ValidateAddress(watcherAddress); err != nil {
+		return nil, err
+	}
+	if err := solana.ValidateAddress(walletAddress); err != nil {
+		return nil, err
+	}
+	if watcherAddress == walletAddress {
+		return nil, ErrWatchSelf
+	}
+
+	existing, err := sm.watchRepo.Get(ctx, watcherAddress, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet watch: %w", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	count, err := sm.watchRepo.CountByWallet(ctx, watcherAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count wallet watches: %w", err)
+	}
+	if count >= int64(sm.maxWatches) {
+		return nil, fmt.Errorf("%w: at most %d per wallet", ErrTooManyWatches, sm.maxWatches)
+	}
+
+	watch := &models.WalletWatch{
+		WalletAddress:  watcherAddress,
+		WatchedAddress: walletAddress,
+		CreatedAt:      time.Now(),
+	}
+	created, err := sm.watchRepo.Create(ctx, watch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet watch: %w", err)
+	}
+	if !created {
+		// A concurrent request created it first and subscribed it
+		return sm.watchRepo.Get(ctx, watcherAddress, walletAddress)
+	}
+
+	subscriptions, err := sm.subscribe(walletAddress, watchKey(watcherAddress), nil)
+	if err != nil {
+		// Without a subscription or intent the watch would never deliver, so it is not kept
+		if _, deleteErr := sm.watchRepo.Delete(ctx, watcherAddress, walletAddress); deleteErr != nil {
+			sm.logger.WithFields(logrus.Fields{
+				"watcher": watcherAddress,
+				"wallet":  walletAddress,
+				"error":   deleteErr,
+			}).Warn("Failed to remove unsubscribed wallet watch")
+		}
+		return nil, err
+	}
+
+	sm.logger.WithFields(logrus.Fields{
+		"watcher":       watcherAddress,
+		"wallet":        walletAddress,
+		"subscriptions": subscriptions,
+	}).Info("Wallet watched")
+	return watch, nil
+}
+
+// UnwatchWallet removes the watch and the wallet's subscription unless rooms or other watchers still need it.
+// A subscription held by another instance is dropped by that instance's janitor.
+func (sm *subscriptionManager) UnwatchWallet(ctx context.Context, watcherAddress, walletAddress string) error {
+	deleted, err := sm.watchRepo.Delete(ctx, watcherAddress, walletAddress)
+	if err != nil {
+		return fmt.Errorf("failed to delete wallet watch: %w", err)
+	}
+	if !deleted {
+		return ErrWatchNotFound
+	}
+
+	// The watch is gone either way; an unsubscription that failed is retried by the janitor
+	if _, err := sm.unsubscribe(walletAddress, watchKey(watcherAddress)); err != nil {
+		sm.logger.WithFields(logrus.Fields{
+			"watcher": watcherAddress,
+			"wallet":  walletAddress,
+			"error":   err,
+		}).Warn("Failed to unsubscribe unwatched wallet")
+	}
+
+	sm.logger.WithFields(logrus.Fields{
+		"watcher": watcherAddress,
+		"wallet":  walletAddress,
+	}).Info("Wallet unwatched")
+	return nil
+}
+
+// ListWatches lists the wallets the watcher watches, newest first
+func (sm *subscriptionManager) ListWatches(ctx context.Context, watcherAddress string) ([]*models.WalletWatch, error) {
+	return sm.watchRepo.ListByWallet(ctx, watcherAddress)
+}
+
+// OnWatchedTrade registers a listener for trades of watched wallets
+func (sm *subscriptionManager) OnWatchedTrade(listener WatchListener) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.watchListeners = append(sm.watchListeners, listener)
+}
@@ -0,0 +1,233 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// clusterKind says what a receiving instance should do with a cluster envelope
+type clusterKind string
+
+const (
+	clusterKindRoom       clusterKind = "room"       // broadcast to the room's local connections
+	clusterKindClient     clusterKind = "client"     // send to one local connection
+	clusterKindDisconnect clusterKind = "disconnect" // close one local connection
+	clusterKindSettings   clusterKind = "settings"   // refresh a wallet's preferences
+	clusterKindWallet     clusterKind = "wallet"     // send to every local connection of a wallet
+	clusterKindMomentum   clusterKind = "momentum"
+	clusterKindMarket     clusterKind = "market"      // send to the local market stream connections of a topic
+	clusterKindRoomClosed clusterKind = "room_closed" // run the room service's close listeners
+)
+
+// clusterEnvelope carries a WebSocket operation to the instances holding the affected connections
+type clusterEnvelope struct {
+	Origin        string               `json:"origin"`
+	Kind          clusterKind          `json:"kind"`
+	RoomID        string               `json:"room_id,omitempty"`
+	RoomIDs       []string             `json:"room_ids,omitempty"`
+	WalletAddress string               `json:"wallet_address,omitempty"`
+	ExceptWallet  string               `json:"except_wallet,omitempty"`
+	Message       *clusterMessage      `json:"message,omitempty"`
+	Unmasked      *clusterMessage      `json:"unmasked,omitempty"` // the message as sent, for Creator when Message hides an aliased sender
+	Creator       string               `json:"creator,omitempty"`
+	Settings      *models.UserSettings `json:"settings,omitempty"`
+	Topic         MarketTopic          `json:"topic,omitempty"`
+}
+
+// clusterMessage is a Message with its data kept as JSON. Data types that notification preferences look at
+// are named, so the receiver can restore them; anything else is forwarded to clients as is.
+type clusterMessage struct {
+	Type      MessageType     `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	DataType  string          `json:"data_type,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+	From      string          `json:"from,omitempty"`
+}
+
+const (
+	clusterDataTradeEvent     = "trade_event"
+	clusterDataLiquidityAlert = "liquidity_alert"
+	clusterDataMomentumAlert  = "momentum_alert"
+)
+
+func newClusterMessage(message *Message) (*clusterMessage, error) {
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &clusterMessage{
+		Type:      message.Type,
+		Data:      data,
+		Timestamp: message.Timestamp,
+		From:      message.From,
+	}
+	switch message.Data.(type) {
+	case *models.TradeEvent:
+		cm.DataType = clusterDataTradeEvent
+	case *models.LiquidityAlert:
+		cm.DataType = clusterDataLiquidityAlert
+	case *models.MomentumAlert:
+		cm.DataType = clusterDataMomentumAlert
+	}
+	return cm, nil
+}
+
+func (cm *clusterMessage) message() (*Message, error) {
+	message := &Message{
+		Type:      cm.Type,
+		Data:      cm.Data,
+		Timestamp: cm.Timestamp,
+		From:      cm.From,
+	}
+
+	var typed interface{}
+	switch cm.DataType {
+	case clusterDataTradeEvent:
+		typed = &models.TradeEvent{}
+	case clusterDataLiquidityAlert:
+		typed = &models.LiquidityAlert{}
+	case clusterDataMomentumAlert:
+		typed = &models.MomentumAlert{}
+	default:
+		return message, nil
+	}
+	if err := json.Unmarshal(cm.Data, typed); err != nil {
+		return nil, err
+	}
+	message.Data = typed
+	return message, nil
+}
+
+// publish hands an envelope to one instance, or to all others when instanceID is empty
+func (ws *webSocketService) publish(instanceID string, envelope *clusterEnvelope, message *Message) {
+	if !ws.registry.Enabled() {
+		return
+	}
+
+	envelope.Origin = ws.registry.InstanceID()
+	if message != nil {
+		cm, err := newClusterMessage(message)
+		if err != nil {
+			ws.logger.WithError(err).Error("Failed to encode WebSocket message for the cluster")
+			return
+		}
+		envelope.Message = cm
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		ws.logger.WithError(err).Error("Failed to encode cluster envelope")
+		return
+	}
+	ctx, cancel := ws.opContext()
+	defer cancel()
+	if err := ws.registry.Publish(ctx, instanceID, payload); err != nil {
+		ws.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"kind":    envelope.Kind,
+			"room_id": envelope.RoomID,
+		}).Error("Failed to publish WebSocket message to the cluster")
+	}
+}
+
+// AnnounceRoomClosed tells every instance about a room closed by a process that serves no connections, such as
+// the admin CLI, so the instances let go of the room's wallet subscriptions. Unlike publish it reports the
+// failure, since the caller has no other way to reach the instances.
+func (ws *webSocketService) AnnounceRoomClosed(ctx context.Context, roomID string) error {
+	if !ws.registry.Enabled() {
+		return nil
+	}
+
+	payload, err := json.Marshal(&clusterEnvelope{
+		Origin: ws.registry.InstanceID(),
+		Kind:   clusterKindRoomClosed,
+		RoomID: roomID,
+	})
+	if err != nil {
+		return err
+	}
+	return ws.registry.Publish(ctx, "", payload)
+}
+
+// handleClusterEnvelope applies an operation published by another instance to the local connections
+func (ws *webSocketService) handleClusterEnvelope(payload []byte) {
+	var envelope clusterEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		ws.logger.WithError(err).Warn("Failed to decode cluster envelope")
+		return
+	}
+	if envelope.Origin == ws.registry.InstanceID() {
+		return
+	}
+
+	var message *Message
+	if envelope.Message != nil {
+		var err error
+		if message, err = envelope.Message.message(); err != nil {
+			ws.logger.WithFields(logrus.Fields{
+				"error": err,
+				"kind":  envelope.Kind,
+			}).Warn("Failed to decode cluster message")
+			return
+		}
+	}
+
+	switch envelope.Kind {
+	case clusterKindRoom:
+		var view *senderView
+		if envelope.Unmasked != nil {
+			unmasked, err := envelope.Unmasked.message()
+			if err != nil {
+				ws.logger.WithError(err).Warn("Failed to decode unmasked cluster message")
+				return
+			}
+			view = &senderView{creator: envelope.Creator, message: unmasked}
+		}
+		ws.broadcastLocal(envelope.RoomID, envelope.ExceptWallet, message, view)
+	case clusterKindClient:
+		ws.sendLocal(envelope.RoomID, envelope.WalletAddress, message)
+	case clusterKindDisconnect:
+		ws.disconnectLocal(envelope.RoomID, envelope.WalletAddress)
+	case clusterKindSettings:
+		if envelope.Settings != nil {
+			ws.applySettingsLocal(envelope.Settings)
+		}
+	case clusterKindWallet:
+		ws.notifyWalletLocal(envelope.WalletAddress, message)
+	case clusterKindMomentum:
+		ws.notifyMomentumLocal(envelope.RoomIDs, message)
+	case clusterKindMarket:
+		ws.publishMarketLocal(envelope.Topic, message)
+	case clusterKindRoomClosed:
+		if ws.roomService != nil {
+			ws.roomService.RoomClosedElsewhere(envelope.RoomID)
+		}
+	default:
+		ws.logger.WithField("kind", envelope.Kind).Warn("Unknown cluster envelope kind")
+	}
+}
+
+// refreshRegistry keeps this instance's connection entries alive in the registry
+func (ws *webSocketService) refreshRegistry() {
+	if !ws.registry.Enabled() {
+		return
+	}
+
+	ws.mu.RLock()
+	connections := make([]ConnectionInfo, 0, len(ws.clients))
+	for _, client := range ws.clients {
+		connections = append(connections, ConnectionInfo{RoomID: client.RoomID, WalletAddress: client.WalletAddress})
+	}
+	ws.mu.RUnlock()
+
+	ctx, cancel := ws.opContext()
+	defer cancel()
+	if err := ws.registry.Refresh(ctx, connections); err != nil {
+		ws.logger.WithError(err).Error("Failed to refresh WebSocket connection registry")
+	}
+}
@@ -3,6 +3,7 @@ package room
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -10,14 +11,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/pkg/clock"
 )
 
 // WebSocketService manages WebSocket connections for trading rooms
 type WebSocketService interface {
 	// Connection management
-	HandleConnection(conn *websocket.Conn, roomID, walletAddress string) error
+	HandleConnection(ctx context.Context, conn *websocket.Conn, roomID, walletAddress string) error // ctx bounds the checks made before the connection is taken over
 	DisconnectClient(roomID, walletAddress string)
 	GetRoomConnections(roomID string) []*Client
 	
@@ -25,6 +28,7 @@ type WebSocketService interface {
 	BroadcastToRoom(roomID string, message *Message) error
 	BroadcastToRoomExcept(roomID, excludeWallet string, message *Message) error
 	SendToClient(roomID, walletAddress string, message *Message) error
+	Broadcast(ctx context.Context, roomID string, req *BroadcastRequest) error // checked and recorded in the room's event log
 	
 	// Room events
 	NotifyMemberJoined(roomID string, member *models.RoomMember) error
@@ -32,24 +36,57 @@ type WebSocketService interface {
 	NotifySharedInfo(roomID string, info *models.SharedInfo) error
 	NotifyTradeEvent(roomID string, event *models.TradeEvent) error
 	NotifyRoomUpdate(roomID string, room *models.TradeRoom) error
+	NotifyLiquidityAlert(roomID string, alert *models.LiquidityAlert) error
+	NotifyTokenGraduated(ctx context.Context, token *models.Token) // to every active room bound to the token
+	AnnounceRoomClosed(ctx context.Context, roomID string) error   // to every instance, for rooms closed outside the server
+	
+	// Wallet events, pushed to every room connection of the wallet
+	NotifyWallet(walletAddress string, message *Message) int
+	NotifyMomentumAlert(roomIDs []string, alert *models.MomentumAlert) int // also reaches wallets that opted in
+	
+	// Market stream, not bound to a room
+	HandleMarketConnection(conn *websocket.Conn, topics []MarketTopic) error
+	PublishMarket(topic MarketTopic, message *Message) int
+	
+	// User preferences
+	ApplyUserSettings(settings *models.UserSettings)
+	WarmRooms(ctx context.Context) (int, error) // preloads the settings of active room members for their first connection, run on startup
 	
 	// Health monitoring
 	StartHeartbeat()
 	StopHeartbeat()
 	CleanupInactiveConnections()
+	ConnectionCounts(ctx context.Context) (local, total int) // total spans all instances
+	
+	// Deploys; a draining instance refuses new connections and moves its clients to other instances
+	Drain(grace time.Duration) DrainStatus
+	CancelDrain() DrainStatus
+	DrainStatus() DrainStatus
 }
 
 type webSocketService struct {
-	rooms       map[string]*Room          // roomID -> Room
-	clients     map[string]*Client        // connectionID -> Client
-	roomRepo    repositories.RoomRepository
-	roomService RoomService
-	logger      *logrus.Logger
-	mu          sync.RWMutex
-	heartbeat   *time.Ticker
-	stopChan    chan bool
+	rooms         map[string]*Room         // roomID -> Room
+	clients       map[string]*Client       // connectionID -> Client
+	marketClients map[string]*marketClient // connectionID -> market stream client
+	roomRepo      repositories.RoomRepository
+	roomService   RoomService
+	settingsRepo  repositories.UserSettingsRepository
+	registry      ConnectionRegistry
+	warmSettings  map[string]*models.UserSettings // walletAddress -> settings preloaded by WarmRooms, nil if none are saved
+	drain         *drainState                     // set while draining
+	logger        *logrus.Logger
+	mu            sync.RWMutex
+	supervisor    *connectionSupervisor
+	stopListen    context.CancelFunc
+	ctx           context.Context // cancelled when the service stops
+	cancel        context.CancelFunc
+	opTimeout     time.Duration
+	clock         clock.Clock // drives pings, heartbeats and drains; connection deadlines use the wall clock
 }
 
+// defaultWebSocketOpTimeout bounds a store call made for a connection or message
+const defaultWebSocketOpTimeout = 10 * time.Second
+
 // Room represents a WebSocket room with multiple clients
 type Room struct {
 	ID          string             `json:"id"`
@@ -59,12 +96,15 @@ type Room struct {
 
 // Client represents a WebSocket client connection
 type Client struct {
-	ID            string          `json:"id"`
-	Conn          *websocket.Conn `json:"-"`
-	RoomID        string          `json:"room_id"`
-	WalletAddress string          `json:"wallet_address"`
-	LastPing      time.Time       `json:"last_ping"`
-	Send          chan *Message   `json:"-"`
+	ID            string               `json:"id"`
+	Conn          *websocket.Conn      `json:"-"`
+	RoomID        string               `json:"room_id"`
+	WalletAddress string               `json:"wallet_address"`
+	LastPing      time.Time            `json:"last_ping"`
+	InstanceID    string               `json:"instance_id,omitempty"` // set for connections held by another instance, which have no Conn or Send
+	Send          chan *Message        `json:"-"`
+	settings      *models.UserSettings // notification preferences, nil delivers everything
+	hiddenTokens  map[string]bool
 	mu            sync.Mutex
 }
 
@@ -79,13 +119,23 @@ const (
 	MessageTypePing      MessageType = "ping"
 	
 	// Server to client messages
-	MessageTypeMemberJoined  MessageType = "member_joined"
-	MessageTypeMemberLeft    MessageType = "member_left"
-	MessageTypeSharedInfo    MessageType = "shared_info"
-	MessageTypeTradeEvent    MessageType = "trade_event"
-	MessageTypeRoomUpdate    MessageType = "room_update"
-	MessageTypePong          MessageType = "pong"
-	MessageTypeError         MessageType = "error"
+	MessageTypeMemberJoined      MessageType = "member_joined"
+	MessageTypeMemberLeft        MessageType = "member_left"
+	MessageTypeSharedInfo        MessageType = "shared_info"
+	MessageTypeTradeEvent        MessageType = "trade_event"
+	MessageTypeTradePending      MessageType = "trade_pending"  // a member trade seen at processed commitment, not yet confirmed
+	MessageTypeTradeFinality     MessageType = "trade_finality" // a provisional trade was finalized or reverted
+	MessageTypeRoomUpdate        MessageType = "room_update"
+	MessageTypeLiquidityAlert    MessageType = "liquidity_alert"
+	MessageTypeNotification      MessageType = "notification" // a notification center entry of the connected wallet
+	MessageTypeMomentumAlert     MessageType = "momentum_alert"
+	MessageTypeTokenGraduated    MessageType = "token_graduated"
+	MessageTypeUnlockWarning     MessageType = "unlock_warning"     // a large vesting unlock of the room's token is near
+	MessageTypeInactivityWarning MessageType = "inactivity_warning" // sent to the member only
+	MessageTypeMemberPruned      MessageType = "member_pruned"      // sent to the member only
+	MessageTypeReconnect         MessageType = "reconnect"          // the instance is draining; reconnect after reconnect_in_ms
+	MessageTypePong              MessageType = "pong"
+	MessageTypeError             MessageType = "error"
 )
 
 // Message represents a WebSocket message
@@ -96,27 +146,51 @@ type Message struct {
 	From      string          `json:"from,omitempty"`
 }
 
-// NewWebSocketService creates a new WebSocket service instance
-func NewWebSocketService(roomRepo repositories.RoomRepository, roomService RoomService, logger *logrus.Logger) WebSocketService {
-	return &webSocketService{
-		rooms:       make(map[string]*Room),
-		clients:     make(map[string]*Client),
-		roomRepo:    roomRepo,
-		roomService: roomService,
-		logger:      logger,
-		stopChan:    make(chan bool),
+// NewWebSocketService creates a new WebSocket service instance; connections held by other instances are
+// reached through the registry
+func NewWebSocketService(roomRepo repositories.RoomRepository, roomService RoomService, settingsRepo repositories.UserSettingsRepository, registry ConnectionRegistry, timeouts *config.TimeoutsConfig, clk clock.Clock, logger *logrus.Logger) WebSocketService {
+	opTimeout := timeouts.WebSocket
+	if opTimeout <= 0 {
+		opTimeout = defaultWebSocketOpTimeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	
+	ws := &webSocketService{
+		rooms:         make(map[string]*Room),
+		clients:       make(map[string]*Client),
+		marketClients: make(map[string]*marketClient),
+		roomRepo:      roomRepo,
+		roomService:   roomService,
+		settingsRepo:  settingsRepo,
+		registry:      registry,
+		warmSettings:  make(map[string]*models.UserSettings),
+		logger:        logger,
+		ctx:           ctx,
+		cancel:        cancel,
+		opTimeout:     opTimeout,
+		clock:         clk,
 	}
+	ws.supervisor = newConnectionSupervisor(clk, pingPeriod, ws.superviseConnections)
+	return ws
+}
+
+// opContext bounds a store call made outside the connecting request; it is cancelled when the service stops
+func (ws *webSocketService) opContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ws.ctx, ws.opTimeout)
 }
 
 // HandleConnection handles a new WebSocket connection
-func (ws *webSocketService) HandleConnection(conn *websocket.Conn, roomID, walletAddress string) error {
+func (ws *webSocketService) HandleConnection(ctx context.Context, conn *websocket.Conn, roomID, walletAddress string) error {
+	if ws.draining() {
+		return ErrDraining
+	}
+	
 	// Verify room exists and user is a member
-	room, err := ws.roomService.GetRoom(context.Background(), roomID)
-	if err != nil {
+	if _, err := ws.roomService.GetRoom(ctx, roomID); err != nil {
 		return fmt.Errorf("failed to get room: %w", err)
 	}
 	
-	members, err := ws.roomService.GetRoomMembers(context.Background(), roomID)
+	members, err := ws.roomService.GetRoomMembers(ctx, roomID)
 	if err != nil {
 		return fmt.Errorf("failed to get room members: %w", err)
 	}
@@ -141,9 +215,10 @@ func (ws *webSocketService) HandleConnection(conn *websocket.Conn, roomID, walle
 		Conn:          conn,
 		RoomID:        roomID,
 		WalletAddress: walletAddress,
-		LastPing:      time.Now(),
+		LastPing:      ws.clock.Now(),
 		Send:          make(chan *Message, 256),
 	}
+	ws.loadClientSettings(ctx, client)
 	
 	// Add client to room
 	ws.mu.Lock()
@@ -157,8 +232,17 @@ func (ws *webSocketService) HandleConnection(conn *websocket.Conn, roomID, walle
 	ws.clients[clientID] = client
 	ws.mu.Unlock()
 	
+	// Claim the connection so other instances route to this one
+	if err := ws.registry.Register(ctx, roomID, walletAddress); err != nil {
+		ws.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": roomID,
+			"wallet":  walletAddress,
+		}).Error("Failed to register WebSocket connection")
+	}
+	
 	// Update member status to online
-	if err := ws.roomService.UpdateMemberStatus(context.Background(), roomID, walletAddress, true); err != nil {
+	if err := ws.roomService.UpdateMemberStatus(ctx, roomID, walletAddress, true); err != nil {
 		ws.logger.WithFields(logrus.Fields{
 			"error":    err,
 			"room_id":  roomID,
@@ -185,123 +269,252 @@ func (ws *webSocketService) HandleConnection(conn *websocket.Conn, roomID, walle
 	return nil
 }
 
-// DisconnectClient disconnects a client from WebSocket
+// DisconnectClient disconnects a client from WebSocket, on whichever instance holds the connection
 func (ws *webSocketService) DisconnectClient(roomID, walletAddress string) {
+	if ws.disconnectLocal(roomID, walletAddress) {
+		return
+	}
+	if owner := ws.remoteOwner(roomID, walletAddress); owner != "" {
+		ws.publish(owner, &clusterEnvelope{
+			Kind:          clusterKindDisconnect,
+			RoomID:        roomID,
+			WalletAddress: walletAddress,
+		}, nil)
+	}
+}
+
+// disconnectLocal disconnects a client held by this instance and reports whether there was one
+func (ws *webSocketService) disconnectLocal(roomID, walletAddress string) bool {
 	ws.mu.Lock()
-	defer ws.mu.Unlock()
+	room, exists := ws.rooms[roomID]
+	if !exists {
+		ws.mu.Unlock()
+		return false
+	}
+	client, exists := room.Clients[walletAddress]
+	if !exists {
+		ws.mu.Unlock()
+		return false
+	}
 	
-	if room, exists := ws.rooms[roomID]; exists {
-		if client, exists := room.Clients[walletAddress]; exists {
-			close(client.Send)
-			client.Conn.Close()
-			delete(room.Clients, walletAddress)
-			delete(ws.clients, client.ID)
-			
-			// Remove empty rooms
-			if len(room.Clients) == 0 {
-				delete(ws.rooms, roomID)
-			}
-			
-			// Update member status to offline
-			if err := ws.roomService.UpdateMemberStatus(context.Background(), roomID, walletAddress, false); err != nil {
-				ws.logger.WithFields(logrus.Fields{
-					"error":   err,
-					"room_id": roomID,
-					"wallet":  walletAddress,
-				}).Error("Failed to update member status to offline")
-			}
-			
-			// Notify other members that user left
-			ws.NotifyMemberLeft(roomID, walletAddress)
-			
-			ws.logger.WithFields(logrus.Fields{
-				"room_id": roomID,
-				"wallet":  walletAddress,
-			}).Info("WebSocket client disconnected")
-		}
+	close(client.Send)
+	client.Conn.Close()
+	delete(room.Clients, walletAddress)
+	delete(ws.clients, client.ID)
+	
+	// Remove empty rooms
+	if len(room.Clients) == 0 {
+		delete(ws.rooms, roomID)
 	}
+	ws.mu.Unlock()
+	
+	ctx, cancel := ws.opContext()
+	defer cancel()
+	if err := ws.registry.Unregister(ctx, roomID, walletAddress); err != nil {
+		ws.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": roomID,
+			"wallet":  walletAddress,
+		}).Error("Failed to unregister WebSocket connection")
+	}
+	
+	// Update member status to offline
+	if err := ws.roomService.UpdateMemberStatus(ctx, roomID, walletAddress, false); err != nil {
+		ws.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": roomID,
+			"wallet":  walletAddress,
+		}).Error("Failed to update member status to offline")
+	}
+	
+	// Notify other members that user left
+	ws.NotifyMemberLeft(roomID, walletAddress)
+	
+	ws.logger.WithFields(logrus.Fields{
+		"room_id": roomID,
+		"wallet":  walletAddress,
+	}).Info("WebSocket client disconnected")
+	return true
 }
 
-// GetRoomConnections returns all active connections in a room
-func (ws *webSocketService) GetRoomConnections(roomID string) []*Client {
+// remoteOwner returns the instance holding the wallet's connection to the room, or "" if no other instance does
+func (ws *webSocketService) remoteOwner(roomID, walletAddress string) string {
+	ctx, cancel := ws.opContext()
+	defer cancel()
+	owner, err := ws.registry.Owner(ctx, roomID, walletAddress)
+	if err != nil {
+		ws.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": roomID,
+			"wallet":  walletAddress,
+		}).Error("Failed to look up WebSocket connection owner")
+		return ""
+	}
+	if owner == nil || owner.InstanceID == ws.registry.InstanceID() {
+		return ""
+	}
+	return owner.InstanceID
+}
+
+// ConnectionCounts returns the connections held by this instance and by all instances; without a shared
+// registry, or if it cannot be read, both are the local count
+func (ws *webSocketService) ConnectionCounts(ctx context.Context) (int, int) {
 	ws.mu.RLock()
-	defer ws.mu.RUnlock()
+	local := len(ws.clients)
+	ws.mu.RUnlock()
 	
+	if !ws.registry.Enabled() {
+		return local, local
+	}
+	total, err := ws.registry.CountConnections(ctx)
+	if err != nil {
+		ws.logger.WithError(err).Warn("Failed to count connections of other instances")
+		return local, local
+	}
+	return local, total
+}
+
+// GetRoomConnections returns all active connections in a room, including those held by other instances
+func (ws *webSocketService) GetRoomConnections(roomID string) []*Client {
+	ws.mu.RLock()
 	var clients []*Client
+	local := make(map[string]bool)
 	if room, exists := ws.rooms[roomID]; exists {
 		for _, client := range room.Clients {
 			clients = append(clients, client)
+			local[client.WalletAddress] = true
+		}
+	}
+	ws.mu.RUnlock()
+	
+	ctx, cancel := ws.opContext()
+	defer cancel()
+	remote, err := ws.registry.RoomConnections(ctx, roomID)
+	if err != nil {
+		ws.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"room_id": roomID,
+		}).Error("Failed to list room connections of other instances")
+		return clients
+	}
+	for _, conn := range remote {
+		if local[conn.WalletAddress] || conn.InstanceID == ws.registry.InstanceID() {
+			continue
 		}
+		clients = append(clients, &Client{
+			RoomID:        roomID,
+			WalletAddress: conn.WalletAddress,
+			LastPing:      conn.LastSeen,
+			InstanceID:    conn.InstanceID,
+		})
 	}
 	return clients
 }
 
 // BroadcastToRoom broadcasts a message to all clients in a room
 func (ws *webSocketService) BroadcastToRoom(roomID string, message *Message) error {
-	ws.mu.RLock()
-	room, exists := ws.rooms[roomID]
-	ws.mu.RUnlock()
+	return ws.BroadcastToRoomExcept(roomID, "", message)
+}
+
+// BroadcastToRoomExcept broadcasts a message to all clients in a room except one; messages from an aliased
+// member show the alias to everyone but the creator
+func (ws *webSocketService) BroadcastToRoomExcept(roomID, excludeWallet string, message *Message) error {
+	message.Timestamp = time.Now()
 	
-	if !exists {
-		return fmt.Errorf("room %s not found", roomID)
+	masked, view, err := ws.pseudonymize(roomID, message)
+	if err != nil {
+		return err
 	}
 	
-	room.mu.RLock()
-	defer room.mu.RUnlock()
-	
-	message.Timestamp = time.Now()
-	
-	for _, client := range room.Clients {
-		select {
-		case client.Send <- message:
-		default:
-			// Client channel is full, disconnect client
-			ws.DisconnectClient(roomID, client.WalletAddress)
+	delivered := ws.broadcastLocal(roomID, excludeWallet, masked, view)
+	ws.recordFeed(roomID, masked)
+	if ws.registry.Enabled() {
+		// Other instances may hold connections to the room
+		envelope := &clusterEnvelope{
+			Kind:         clusterKindRoom,
+			RoomID:       roomID,
+			ExceptWallet: excludeWallet,
 		}
+		if view != nil {
+			envelope.Creator = view.creator
+			envelope.Unmasked, err = newClusterMessage(view.message)
+			if err != nil {
+				return fmt.Errorf("failed to encode message for the creator: %w", err)
+			}
+		}
+		ws.publish("", envelope, masked)
+		return nil
 	}
 	
+	if !delivered {
+		return fmt.Errorf("room %s not found", roomID)
+	}
 	return nil
 }
 
-// BroadcastToRoomExcept broadcasts a message to all clients in a room except one
-func (ws *webSocketService) BroadcastToRoomExcept(roomID, excludeWallet string, message *Message) error {
+// broadcastLocal queues a message on this instance's connections to a room and reports whether it holds any;
+// with a view, the creator is sent the view's message instead
+func (ws *webSocketService) broadcastLocal(roomID, excludeWallet string, message *Message, view *senderView) bool {
 	ws.mu.RLock()
 	room, exists := ws.rooms[roomID]
 	ws.mu.RUnlock()
 	
 	if !exists {
-		return fmt.Errorf("room %s not found", roomID)
+		return false
 	}
 	
 	room.mu.RLock()
 	defer room.mu.RUnlock()
 	
-	message.Timestamp = time.Now()
-	
 	for walletAddress, client := range room.Clients {
-		if walletAddress == excludeWallet {
+		outgoing := message
+		if view != nil && walletAddress == view.creator {
+			outgoing = view.message
+		}
+		if walletAddress == excludeWallet || !client.wantsMessage(outgoing) {
 			continue
 		}
 		
 		select {
-		case client.Send <- message:
+		case client.Send <- outgoing:
 		default:
 			// Client channel is full, disconnect client
-			ws.DisconnectClient(roomID, client.WalletAddress)
+			ws.disconnectLocal(roomID, client.WalletAddress)
 		}
 	}
 	
-	return nil
+	return true
 }
 
-// SendToClient sends a message to a specific client
+// SendToClient sends a message to a specific client, on whichever instance holds the connection
 func (ws *webSocketService) SendToClient(roomID, walletAddress string, message *Message) error {
+	message.Timestamp = time.Now()
+	
+	found, err := ws.sendLocal(roomID, walletAddress, message)
+	if found {
+		return err
+	}
+	
+	owner := ws.remoteOwner(roomID, walletAddress)
+	if owner == "" {
+		return fmt.Errorf("client %s not found in room %s", walletAddress, roomID)
+	}
+	ws.publish(owner, &clusterEnvelope{
+		Kind:          clusterKindClient,
+		RoomID:        roomID,
+		WalletAddress: walletAddress,
+	}, message)
+	return nil
+}
+
+// sendLocal queues a message on this instance's connection of the wallet, reporting whether it holds one
+func (ws *webSocketService) sendLocal(roomID, walletAddress string, message *Message) (bool, error) {
 	ws.mu.RLock()
 	room, exists := ws.rooms[roomID]
 	ws.mu.RUnlock()
 	
 	if !exists {
-		return fmt.Errorf("room %s not found", roomID)
+		return false, nil
 	}
 	
 	room.mu.RLock()
@@ -309,18 +522,16 @@ func (ws *webSocketService) SendToClient(roomID, walletAddress string, message *
 	room.mu.RUnlock()
 	
 	if !exists {
-		return fmt.Errorf("client %s not found in room %s", walletAddress, roomID)
+		return false, nil
 	}
 	
-	message.Timestamp = time.Now()
-	
 	select {
 	case client.Send <- message:
-		return nil
+		return true, nil
 	default:
 		// Client channel is full, disconnect client
-		ws.DisconnectClient(roomID, walletAddress)
-		return fmt.Errorf("client %s channel is full", walletAddress)
+		ws.disconnectLocal(roomID, walletAddress)
+		return true, fmt.Errorf("client %s channel is full", walletAddress)
 	}
 }
 
@@ -369,20 +580,308 @@ func (ws *webSocketService) NotifyRoomUpdate(roomID string, room *models.TradeRo
 	return ws.BroadcastToRoom(roomID, message)
 }
 
+func (ws *webSocketService) NotifyLiquidityAlert(roomID string, alert *models.LiquidityAlert) error {
+	message := &Message{
+		Type: MessageTypeLiquidityAlert,
+		Data: alert,
+	}
+	return ws.BroadcastToRoom(roomID, message)
+}
+
+func (ws *webSocketService) NotifyTokenGraduated(ctx context.Context, token *models.Token) {
+	rooms, err := ws.roomRepo.GetActiveByToken(ctx, token.MintAddress)
+	if err != nil {
+		ws.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": token.MintAddress,
+		}).Error("Failed to get rooms for token graduation")
+		return
+	}
+	
+	for _, tradeRoom := range rooms {
+		message := &Message{
+			Type: MessageTypeTokenGraduated,
+			Data: map[string]interface{}{
+				"token_address": token.MintAddress,
+				"symbol":        token.Symbol,
+				"graduated_to":  token.GraduatedTo,
+				"graduated_at":  token.GraduatedAt,
+			},
+		}
+		if err := ws.BroadcastToRoom(tradeRoom.RoomID, message); err != nil {
+			ws.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"room_id": tradeRoom.RoomID,
+			}).Warn("Failed to broadcast token graduation")
+		}
+	}
+}
+
+// NotifyWallet returns the number of connections on this instance the message was queued on; other instances
+// deliver it to their own connections of the wallet
+func (ws *webSocketService) NotifyWallet(walletAddress string, message *Message) int {
+	if message.Timestamp.IsZero() {
+		message.Timestamp = time.Now()
+	}
+	ws.publish("", &clusterEnvelope{
+		Kind:          clusterKindWallet,
+		WalletAddress: walletAddress,
+	}, message)
+	return ws.notifyWalletLocal(walletAddress, message)
+}
+
+func (ws *webSocketService) notifyWalletLocal(walletAddress string, message *Message) int {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	
+	sent := 0
+	for _, client := range ws.clients {
+		if client.WalletAddress != walletAddress {
+			continue
+		}
+		select {
+		case client.Send <- message:
+			sent++
+		default:
+			// A full channel is left to the connection supervisor to clean up; the notification stays visible through the API
+		}
+	}
+	return sent
+}
+
+// NotifyMomentumAlert pushes the alert to every connection in the given rooms, and to one connection of each
+// other wallet that opted in to momentum alerts. It returns the number of connections on this instance the alert
+// was queued on; other instances deliver it to their own connections.
+func (ws *webSocketService) NotifyMomentumAlert(roomIDs []string, alert *models.MomentumAlert) int {
+	message := &Message{
+		Type:      MessageTypeMomentumAlert,
+		Data:      alert,
+		Timestamp: time.Now(),
+	}
+	ws.publish("", &clusterEnvelope{
+		Kind:    clusterKindMomentum,
+		RoomIDs: roomIDs,
+	}, message)
+	for _, roomID := range roomIDs {
+		ws.recordFeed(roomID, message)
+	}
+	return ws.notifyMomentumLocal(roomIDs, message)
+}
+
+func (ws *webSocketService) notifyMomentumLocal(roomIDs []string, message *Message) int {
+	inRoom := make(map[string]bool, len(roomIDs))
+	for _, roomID := range roomIDs {
+		inRoom[roomID] = true
+	}
+	
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	
+	sent := 0
+	reached := make(map[string]bool)
+	queue := func(client *Client) {
+		select {
+		case client.Send <- message:
+			sent++
+			reached[client.WalletAddress] = true
+		default:
+			// A full channel is left to the connection supervisor to clean up; the alert stays visible through the API
+		}
+	}
+	
+	// Room connections first, so subscribers in a bound room are not sent a second copy
+	for _, client := range ws.clients {
+		if inRoom[client.RoomID] && client.wantsMessage(message) {
+			queue(client)
+		}
+	}
+	for _, client := range ws.clients {
+		if !reached[client.WalletAddress] && client.wantsMomentumAlerts() && client.wantsMessage(message) {
+			queue(client)
+		}
+	}
+	return sent
+}
+
+// ApplyUserSettings refreshes the preferences of the wallet's open connections on every instance
+func (ws *webSocketService) ApplyUserSettings(settings *models.UserSettings) {
+	ws.publish("", &clusterEnvelope{
+		Kind:     clusterKindSettings,
+		Settings: settings,
+	}, nil)
+	ws.applySettingsLocal(settings)
+}
+
+func (ws *webSocketService) applySettingsLocal(settings *models.UserSettings) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	
+	for _, client := range ws.clients {
+		if client.WalletAddress == settings.WalletAddress {
+			client.setSettings(settings)
+		}
+	}
+	if _, warmed := ws.warmSettings[settings.WalletAddress]; warmed {
+		ws.warmSettings[settings.WalletAddress] = settings
+	}
+}
+
+// Page size for walking the active rooms when warming up
+const warmRoomsPageSize = 100
+
+// WarmRooms loads the members of every active room and preloads their settings, so the first connections after
+// a start do not each read them; settings updates reach the preloaded copies like open connections. It returns
+// how many members' settings were preloaded.
+func (ws *webSocketService) WarmRooms(ctx context.Context) (int, error) {
+	if ws.settingsRepo == nil {
+		return 0, nil
+	}
+	
+	wallets := make(map[string]bool)
+	for offset := 0; ; offset += warmRoomsPageSize {
+		rooms, err := ws.roomRepo.List(ctx, models.RoomStatusActive, warmRoomsPageSize, offset)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list active rooms: %w", err)
+		}
+		for _, room := range rooms {
+			members, err := ws.roomRepo.GetMembers(ctx, room.ID)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get room members: %w", err)
+			}
+			for _, member := range members {
+				wallets[member.WalletAddress] = true
+			}
+		}
+		if len(rooms) < warmRoomsPageSize {
+			break
+		}
+	}
+	if len(wallets) == 0 {
+		return 0, nil
+	}
+	
+	addresses := make([]string, 0, len(wallets))
+	for walletAddress := range wallets {
+		addresses = append(addresses, walletAddress)
+	}
+	saved, err := ws.settingsRepo.GetByWallets(ctx, addresses)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user settings: %w", err)
+	}
+	
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, walletAddress := range addresses {
+		ws.warmSettings[walletAddress] = nil
+	}
+	for _, settings := range saved {
+		ws.warmSettings[settings.WalletAddress] = settings
+	}
+	return len(addresses), nil
+}
+
+// takeWarmSettings returns and forgets the wallet's preloaded settings; later connections read them again
+func (ws *webSocketService) takeWarmSettings(walletAddress string) (*models.UserSettings, bool) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	
+	settings, warmed := ws.warmSettings[walletAddress]
+	if warmed {
+		delete(ws.warmSettings, walletAddress)
+	}
+	return settings, warmed
+}
+
+// loadClientSettings attaches the wallet's saved preferences to a new client
+func (ws *webSocketService) loadClientSettings(ctx context.Context, client *Client) {
+	if ws.settingsRepo == nil {
+		return
+	}
+	
+	if settings, warmed := ws.takeWarmSettings(client.WalletAddress); warmed {
+		if settings != nil {
+			client.setSettings(settings)
+		}
+		return
+	}
+	
+	settings, err := ws.settingsRepo.GetByWallet(ctx, client.WalletAddress)
+	if err != nil {
+		ws.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"wallet": client.WalletAddress,
+		}).Warn("Failed to load user settings, delivering all notifications")
+		return
+	}
+	if settings != nil {
+		client.setSettings(settings)
+	}
+}
+
+func (c *Client) setSettings(settings *models.UserSettings) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settings = settings
+	c.hiddenTokens = settings.HiddenTokenSet()
+}
+
+// wantsMessage applies the client's notification preferences to a room broadcast
+func (c *Client) wantsMessage(message *Message) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	if c.settings == nil {
+		return true
+	}
+	
+	switch message.Type {
+	case MessageTypeMemberJoined, MessageTypeMemberLeft:
+		return c.settings.NotifyMemberActivity
+	case MessageTypeSharedInfo:
+		return c.settings.NotifySharedInfo
+	case MessageTypeTradeEvent:
+		if !c.settings.NotifyTradeEvents {
+			return false
+		}
+		if event, ok := message.Data.(*models.TradeEvent); ok {
+			if c.hiddenTokens[event.TokenAddress] {
+				return false
+			}
+			value := event.ServerValueUSD
+			if value == 0 {
+				value = event.ValueUSD
+			}
+			return value >= c.settings.AlertMinValueUSD
+		}
+	case MessageTypeLiquidityAlert:
+		if alert, ok := message.Data.(*models.LiquidityAlert); ok {
+			return !c.hiddenTokens[alert.MintAddress]
+		}
+	case MessageTypeMomentumAlert:
+		if alert, ok := message.Data.(*models.MomentumAlert); ok {
+			return !c.hiddenTokens[alert.MintAddress]
+		}
+	}
+	return true
+}
+
+// wantsMomentumAlerts reports whether the wallet opted in to momentum alerts of tokens outside its rooms
+func (c *Client) wantsMomentumAlerts() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.settings != nil && c.settings.NotifyMomentumAlerts
+}
+
 // readPump handles reading messages from WebSocket connection
 func (ws *webSocketService) readPump(client *Client) {
 	defer func() {
-		ws.DisconnectClient(client.RoomID, client.WalletAddress)
+		ws.disconnectLocal(client.RoomID, client.WalletAddress)
 	}()
 	
-	// Set read deadline and pong handler
-	client.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	client.Conn.SetPongHandler(func(string) error {
+	watchPongs(client.Conn, func() {
 		client.mu.Lock()
-		client.LastPing = time.Now()
+		client.LastPing = ws.clock.Now()
 		client.mu.Unlock()
-		client.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		return nil
 	})
 	
 	for {
@@ -404,39 +903,23 @@ func (ws *webSocketService) readPump(client *Client) {
 	}
 }
 
-// writePump handles writing messages to WebSocket connection
+// writePump handles writing messages to WebSocket connection; pings are sent by the connection supervisor
 func (ws *webSocketService) writePump(client *Client) {
-	ticker := time.NewTicker(54 * time.Second)
-	defer func() {
-		ticker.Stop()
-		client.Conn.Close()
-	}()
+	defer client.Conn.Close()
 	
-	for {
-		select {
-		case message, ok := <-client.Send:
-			client.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if !ok {
-				client.Conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-			
-			if err := client.Conn.WriteJSON(message); err != nil {
-				ws.logger.WithFields(logrus.Fields{
-					"error":  err,
-					"client": client.WalletAddress,
-					"room":   client.RoomID,
-				}).Error("WebSocket write error")
-				return
-			}
-			
-		case <-ticker.C:
-			client.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := client.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
-			}
+	for message := range client.Send {
+		client.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := client.Conn.WriteJSON(message); err != nil {
+			ws.logger.WithFields(logrus.Fields{
+				"error":  err,
+				"client": client.WalletAddress,
+				"room":   client.RoomID,
+			}).Error("WebSocket write error")
+			return
 		}
 	}
+	client.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+	client.Conn.WriteMessage(websocket.CloseMessage, []byte{})
 }
 
 // handleMessage processes incoming WebSocket messages
@@ -485,8 +968,15 @@ func (ws *webSocketService) handleShareInfoMessage(client *Client, data map[stri
 	req.SharerAddress = client.WalletAddress
 	
 	// Create shared info through service
-	info, err := ws.roomService.ShareInfo(context.Background(), &req)
+	ctx, cancel := ws.opContext()
+	defer cancel()
+	info, err := ws.roomService.ShareInfo(ctx, &req)
 	if err != nil {
+		var throttleErr *ThrottleError
+		if errors.As(err, &throttleErr) {
+			ws.sendThrottleMessage(client, throttleErr)
+			return
+		}
 		ws.sendErrorMessage(client, fmt.Sprintf("Failed to share info: %v", err))
 		return
 	}
@@ -509,39 +999,108 @@ func (ws *webSocketService) sendErrorMessage(client *Client, errorMsg string) {
 	case client.Send <- message:
 	default:
 		// Channel is full, disconnect client
-		ws.DisconnectClient(client.RoomID, client.WalletAddress)
+		ws.disconnectLocal(client.RoomID, client.WalletAddress)
+	}
+}
+
+// sendThrottleMessage tells a client its action was throttled, with the limit details
+func (ws *webSocketService) sendThrottleMessage(client *Client, throttleErr *ThrottleError) {
+	data := throttleErr.Details()
+	data["error"] = throttleErr.Error()
+	
+	message := &Message{
+		Type:      MessageTypeError,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+	
+	select {
+	case client.Send <- message:
+	default:
+		// Channel is full, disconnect client
+		ws.disconnectLocal(client.RoomID, client.WalletAddress)
 	}
 }
 
-// StartHeartbeat starts the heartbeat monitoring
+// StartHeartbeat starts the connection supervisor and, when clustered, listening to other instances; it does
+// nothing if already started
 func (ws *webSocketService) StartHeartbeat() {
-	ws.heartbeat = time.NewTicker(30 * time.Second)
-	go func() {
-		for {
-			select {
-			case <-ws.heartbeat.C:
-				ws.CleanupInactiveConnections()
-			case <-ws.stopChan:
-				return
-			}
-		}
-	}()
+	if !ws.supervisor.Start() {
+		return
+	}
+	
+	listenCtx, stopListen := context.WithCancel(ws.ctx)
+	ws.stopListen = stopListen
+	go ws.registry.Listen(listenCtx, ws.handleClusterEnvelope)
 }
 
-// StopHeartbeat stops the heartbeat monitoring
+// StopHeartbeat stops the connection supervisor, releases this instance's connections in the registry and
+// cancels the operations still running for connections; stopping again does nothing
 func (ws *webSocketService) StopHeartbeat() {
-	if ws.heartbeat != nil {
-		ws.heartbeat.Stop()
+	if !ws.supervisor.Stop() {
+		return
+	}
+	ws.stopListen()
+	
+	ws.mu.RLock()
+	clients := make([]*Client, 0, len(ws.clients))
+	for _, client := range ws.clients {
+		clients = append(clients, client)
 	}
-	close(ws.stopChan)
+	ws.mu.RUnlock()
+	ws.unregister(clients)
+	ws.cancel()
+}
+
+// unregister releases connections this instance no longer holds
+func (ws *webSocketService) unregister(clients []*Client) {
+	for _, client := range clients {
+		ctx, cancel := ws.opContext()
+		err := ws.registry.Unregister(ctx, client.RoomID, client.WalletAddress)
+		cancel()
+		if err != nil {
+			ws.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"room_id": client.RoomID,
+				"wallet":  client.WalletAddress,
+			}).Error("Failed to unregister WebSocket connection")
+		}
+	}
+}
+
+// superviseConnections is the supervisor's sweep: it pings every local connection, closes those that have
+// not answered within pongWait and refreshes this instance's registrations
+func (ws *webSocketService) superviseConnections() {
+	ws.mu.RLock()
+	conns := make([]*websocket.Conn, 0, len(ws.clients)+len(ws.marketClients))
+	for _, client := range ws.clients {
+		conns = append(conns, client.Conn)
+	}
+	for _, client := range ws.marketClients {
+		conns = append(conns, client.Conn)
+	}
+	ws.mu.RUnlock()
+	
+	for _, conn := range conns {
+		ping(conn)
+	}
+	ws.CleanupInactiveConnections()
+	ws.refreshRegistry()
 }
 
 // CleanupInactiveConnections removes inactive connections
 func (ws *webSocketService) CleanupInactiveConnections() {
+	ws.unregister(ws.removeInactiveConnections())
+	ws.removeInactiveMarketClients()
+}
+
+// removeInactiveConnections closes and returns connections that stopped answering pings
+func (ws *webSocketService) removeInactiveConnections() []*Client {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	
-	threshold := time.Now().Add(-90 * time.Second)
+	var removed []*Client
+	threshold := ws.clock.Now().Add(-pongWait)
 	
 	for roomID, room := range ws.rooms {
 		room.mu.Lock()
@@ -553,6 +1112,7 @@ func (ws *webSocketService) CleanupInactiveConnections() {
 				client.Conn.Close()
 				delete(room.Clients, walletAddress)
 				delete(ws.clients, client.ID)
+				removed = append(removed, client)
 				
 				ws.logger.WithFields(logrus.Fields{
 					"room_id": roomID,
@@ -568,4 +1128,6 @@ func (ws *webSocketService) CleanupInactiveConnections() {
 		}
 		room.mu.Unlock()
 	}
+	
+	return removed
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package room
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/pkg/clock"
+)
+
+// benchRoomRepo keeps no aliases and discards feed messages; other methods panic through the nil interface
+type benchRoomRepo struct {
+	repositories.RoomRepository
+}
+
+func (r *benchRoomRepo) GetMemberAlias(ctx context.Context, roomID, walletAddress string) (*repositories.MemberAlias, error) {
+	return nil, nil
+}
+
+func (r *benchRoomRepo) AppendFeedMessage(ctx context.Context, message *models.RoomFeedMessage) error {
+	return nil
+}
+
+// benchQueueSize is the queue of each benchmark client; queues are emptied before they fill up, which
+// would disconnect the client
+const benchQueueSize = 256
+
+// newBenchRoom returns a service without a shared registry holding a room of the given number of clients
+func newBenchRoom(clients int) (*webSocketService, *Room) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	ws := NewWebSocketService(&benchRoomRepo{}, nil, nil, NewConnectionRegistry(nil, logger), &config.TimeoutsConfig{}, clock.Real, logger).(*webSocketService)
+
+	room := &Room{ID: "bench", Clients: make(map[string]*Client, clients)}
+	for i := 0; i < clients; i++ {
+		client := &Client{
+			ID:            uuid.New().String(),
+			RoomID:        room.ID,
+			WalletAddress: fmt.Sprintf("wallet-%d", i),
+			Send:          make(chan *Message, benchQueueSize),
+		}
+		room.Clients[client.WalletAddress] = client
+		ws.clients[client.ID] = client
+	}
+	ws.rooms[room.ID] = room
+	return ws, room
+}
+
+// drainRoom empties the queues of the room's clients
+func drainRoom(room *Room) {
+	for _, client := range room.Clients {
+		for len(client.Send) > 0 {
+			<-client.Send
+		}
+	}
+}
+
+func BenchmarkBroadcastToRoom(b *testing.B) {
+	for _, clients := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("clients=%d", clients), func(b *testing.B) {
+			ws, room := newBenchRoom(clients)
+			data := map[string]interface{}{"status": "active"}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if i%benchQueueSize == benchQueueSize-1 {
+					b.StopTimer()
+					drainRoom(room)
+					b.StartTimer()
+				}
+				if err := ws.BroadcastToRoom(room.ID, &Message{Type: MessageTypeRoomUpdate, Data: data}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -4,10 +4,36 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/emiyaio/solana-wallet-service/internal/config"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/admin"
 	"github.com/emiyaio/solana-wallet-service/internal/services/ai"
+	"github.com/emiyaio/solana-wallet-service/internal/services/analytics"
+	"github.com/emiyaio/solana-wallet-service/internal/services/assistant"
 	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/cluster"
+	"github.com/emiyaio/solana-wallet-service/internal/services/email"
+	"github.com/emiyaio/solana-wallet-service/internal/services/export"
+	"github.com/emiyaio/solana-wallet-service/internal/services/feature"
+	"github.com/emiyaio/solana-wallet-service/internal/services/finality"
+	"github.com/emiyaio/solana-wallet-service/internal/services/label"
+	"github.com/emiyaio/solana-wallet-service/internal/services/limitwatch"
+	"github.com/emiyaio/solana-wallet-service/internal/services/liquidity"
+	"github.com/emiyaio/solana-wallet-service/internal/services/marketstream"
+	"github.com/emiyaio/solana-wallet-service/internal/services/momentum"
+	"github.com/emiyaio/solana-wallet-service/internal/services/notification"
+	"github.com/emiyaio/solana-wallet-service/internal/services/portfolio"
+	"github.com/emiyaio/solana-wallet-service/internal/services/rationale"
+	"github.com/emiyaio/solana-wallet-service/internal/services/report"
 	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/internal/services/social"
 	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+	"github.com/emiyaio/solana-wallet-service/internal/services/trader"
+	"github.com/emiyaio/solana-wallet-service/internal/services/unlock"
+	"github.com/emiyaio/solana-wallet-service/internal/services/user"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/clock"
+	"github.com/emiyaio/solana-wallet-service/pkg/ratelimit"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
 )
 
 // Services holds all service instances
@@ -16,36 +42,142 @@ type Services struct {
 	Room                room.RoomService
 	WebSocket           room.WebSocketService
 	SubscriptionManager room.SubscriptionManager
+	MemberPruner        room.MemberPruner
 	
 	// Token services
 	TokenMarket     token.MarketService
+	MarketEvents    token.MarketEventBus
+	TokenSync       token.SyncScheduler
 	SolanaTracker   token.SolanaTrackerService
 	TokenAnalysis   token.AnalysisService
+	TokenBacktest   token.BacktestService
+	TokenProvenance token.ProvenanceService
+	TokenChart      token.ChartService
+	TokenFlag       token.FlagService
+	Sellability     token.SellabilityService
+	TokenScreener   token.ScreenerService
+	TokenDiscovery  token.DiscoveryService
+	PriceFeed       token.PriceFeedService
 	
 	// Blockchain services
 	QuickNode           blockchain.QuickNodeService
 	TransactionProcessor blockchain.TransactionProcessor
 	
+	// Trader services
+	Trader        trader.TraderService
+	SignalTracker trader.SignalTracker
+	
+	// Wallet label services
+	Label label.LabelService
+	
+	// Wallet cluster services
+	Cluster cluster.ClusterService
+	
+	// Portfolio services
+	Portfolio portfolio.PortfolioService
+	
+	// User services
+	UserSettings user.SettingsService
+	UserPurge    user.PurgeService
+	
 	// AI services
-	LangChain ai.LangChainService
+	LangChain     ai.LangChainService
+	AIUsage       ai.UsageService
+	Prompt        ai.PromptService
+	RoomAssistant assistant.RoomAssistantService
+	
+	// Report services
+	Report report.ReportService
+	
+	// Export services
+	Export export.ExportService
+	
+	// Analytics services
+	Analytics analytics.AnalyticsService
+	
+	// Liquidity services
+	Liquidity liquidity.LiquidityService
+	
+	// Social services
+	Social social.SocialService
+	
+	// Limit watch services
+	LimitWatch limitwatch.LimitWatchService
+	
+	// Momentum alert services
+	Momentum momentum.MomentumService
+	
+	// Market WebSocket stream services
+	MarketStream marketstream.MarketStreamService
+	
+	// Trade finality services
+	Finality finality.FinalityService
+	
+	// Token unlock schedule services
+	Unlock unlock.UnlockService
+	
+	// Notification center services
+	Notification notification.NotificationService
+	Email        email.EmailService
+	
+	// Admin services
+	AdminStats  admin.StatsService
+	AdminAccess admin.AccessService
+	
+	// Feature flag services
+	FeatureFlags feature.FlagService
 }
 
-// NewServices creates and returns all service instances
-func NewServices(repos *repositories.Repositories, cfg *config.Config, logger *logrus.Logger) *Services {
-	// External services
-	solanaTrackerService := token.NewSolanaTrackerService(&cfg.ExternalAPIs.SolanaTracker, logger)
+// NewServices creates and returns all service instances; redisClient may be nil, which disables caching, room throttling
+// and sharing WebSocket connections between instances
+func NewServices(repos *repositories.Repositories, redisClient *redis.Client, cfg *config.Config, logger *logrus.Logger) *Services {
+	// Provider usage is counted in Redis and paced against the monthly quotas
+	usageTracker := usage.NewTracker(redisClient, &cfg.ExternalAPIs.Quotas, logger)
 	
-	// Token services
+	// Calls to every external API are counted for the admin stats
+	apiStats := apistats.NewRegistry()
+	
+	// Feature flags gate risky features at runtime, globally or per room
+	flagsService := feature.NewFlagService(repos.FeatureFlag, redisClient, &cfg.FeatureFlags, logger)
+	
+	// External services; the API clients share one set of rate limiters, and the newer providers are behind
+	// feature flags
+	apiLimits := ratelimit.NewRegistry(cfg.ExternalAPIs.RateLimits)
+	solanaTrackerService := token.NewSolanaTrackerService(&cfg.ExternalAPIs.SolanaTracker, apiLimits, usageTracker, apiStats, logger)
+	birdeyeService := token.NewFlaggedMarketDataProvider(token.NewBirdeyeService(&cfg.ExternalAPIs.Birdeye, apiLimits, usageTracker, apiStats, logger), flagsService, feature.FlagBirdeyeMarketData)
+	marketDataProvider := token.NewMarketDataProvider(&cfg.ExternalAPIs, solanaTrackerService, birdeyeService, logger)
+	poolsProvider := token.NewFlaggedPoolsProvider(token.NewGeckoTerminalService(&cfg.ExternalAPIs.GeckoTerminal, apiLimits, apiStats, logger), flagsService, feature.FlagGeckoTerminalPools)
+	
+	// Prices from the Pyth reference feeds and stored market data
+	priceAggregator := blockchain.NewPriceAggregator(repos.Token, &cfg.ExternalAPIs.Pyth, logger)
+	
+	// Token services; market data changes are published on the event bus for the alert and stream consumers
+	marketEvents := token.NewMarketEventBus(redisClient, logger)
+	flagService := token.NewFlagService(repos.Token, logger)
 	marketService := token.NewMarketService(
 		repos.Token,
+		repos.WalletLabel,
 		solanaTrackerService,
+		marketDataProvider,
+		poolsProvider,
+		ai.NewNarrativeClassifier(&cfg.ExternalAPIs.OpenAI, usageTracker, apiStats, logger),
+		token.NewMetadataScreenService(repos.Token, flagService, ai.NewMetadataClassifier(&cfg.ExternalAPIs.OpenAI, usageTracker, apiStats, logger), logger),
+		priceAggregator,
+		marketEvents,
+		redisClient,
+		&cfg.SyncScheduler,
 		logger,
 	)
+	syncScheduler := token.NewSyncScheduler(repos.Token, repos.Room, repos.LimitWatch, marketService, &cfg.SyncScheduler, clock.Real, logger)
 	
 	// Blockchain services
 	transactionProcessor := blockchain.NewTransactionProcessor(
 		&cfg.ExternalAPIs.QuickNode,
 		repos.Token,
+		repos.WalletLabel,
+		priceAggregator,
+		usageTracker,
+		apiStats,
 		logger,
 	)
 	quickNodeService := blockchain.NewQuickNodeService(
@@ -53,34 +185,260 @@ func NewServices(repos *repositories.Repositories, cfg *config.Config, logger *l
 		logger,
 	)
 	
+	// Token analysis services
+	provenanceService := token.NewProvenanceService(
+		repos.Token,
+		repos.WalletLabel,
+		transactionProcessor,
+		solanaTrackerService,
+		logger,
+	)
+	jupiterService := token.NewJupiterService(&cfg.ExternalAPIs.Jupiter, apiStats, logger)
+	sellabilityService := token.NewSellabilityService(jupiterService, redisClient, logger)
+	priceFeedService := token.NewPriceFeedService(repos.Token, jupiterService, &cfg.ExternalAPIs.Pyth, apiStats, logger)
+	analysisService := token.NewAnalysisService(
+		repos.Token,
+		repos.Transaction,
+		repos.WalletLabel,
+		repos.Cluster,
+		repos.Social,
+		marketService,
+		provenanceService,
+		flagService,
+		sellabilityService,
+		&cfg.Scoring,
+		logger,
+	)
+	
+	backtestService := token.NewBacktestService(repos.Token, analysisService, logger)
+	screenerService := token.NewScreenerService(repos.Token, marketService, logger)
+	discoveryService := token.NewDiscoveryService(repos.Token, marketService, solanaTrackerService, logger)
+	
+	chartService := token.NewChartService(
+		repos.Token,
+		marketDataProvider,
+		redisClient,
+		clock.Real,
+		logger,
+	)
+	
+	// Trader services
+	signalTracker := trader.NewSignalTracker(repos.Signal, repos.Token, logger)
+	traderService := trader.NewTraderService(
+		repos.Trader,
+		repos.Transaction,
+		transactionProcessor,
+		signalTracker,
+		logger,
+	)
+	
+	// Wallet label services
+	labelService := label.NewLabelService(repos.WalletLabel, logger)
+	
+	// Wallet cluster services
+	clusterService := cluster.NewClusterService(
+		repos.Cluster,
+		repos.Transaction,
+		repos.WalletLabel,
+		transactionProcessor,
+		logger,
+	)
+	
+	// Portfolio services
+	portfolioService := portfolio.NewPortfolioService(
+		repos.Portfolio,
+		priceAggregator,
+		transactionProcessor,
+		logger,
+	)
+	
+	// User services
+	settingsService := user.NewSettingsService(repos.UserSettings, logger)
+	
+	// AI services
+	promptService := ai.NewPromptService(repos.Prompt, logger)
+	langChainService := ai.NewLangChainService(
+		&cfg.ExternalAPIs.OpenAI,
+		repos.Token,
+		repos.UserSettings,
+		marketService,
+		marketDataProvider,
+		promptService,
+		usageTracker,
+		apiStats,
+		logger,
+	)
+	aiUsageService := ai.NewUsageService(&cfg.ExternalAPIs.OpenAI, repos.AIUsage, logger)
+	rationaleService := rationale.NewRationaleService(repos.Room, langChainService, flagsService, &cfg.Room.Rationale, logger)
+	
 	// Room services
-	roomService := room.NewRoomService(repos.Room, logger)
-	wsService := room.NewWebSocketService(repos.Room, roomService, logger)
+	roomThrottle := room.NewThrottle(redisClient, &cfg.Room.Throttle, logger)
+	roomPasswordGuard := room.NewPasswordGuard(redisClient, &cfg.Room.PasswordAttempts, logger)
+	roomService := room.NewRoomService(repos.Room, repos.Token, repos.UnitOfWork, priceAggregator, signalTracker, rationaleService, flagsService, roomThrottle, roomPasswordGuard, &cfg.Room, clock.Real, logger)
+	connectionRegistry := room.NewConnectionRegistry(redisClient, logger)
+	wsService := room.NewWebSocketService(repos.Room, roomService, repos.UserSettings, connectionRegistry, &cfg.Timeouts, clock.Real, logger)
+	subscriptionStore := room.NewSubscriptionStore(redisClient, connectionRegistry.InstanceID(), logger)
 	subscriptionManager := room.NewSubscriptionManager(
 		quickNodeService,
 		transactionProcessor,
 		repos.Room,
+		repos.WalletWatch,
+		wsService,
+		rationaleService,
+		subscriptionStore,
+		&cfg.Room,
+		&cfg.Timeouts,
+		logger,
+	)
+	roomService.OnMembershipChange(subscriptionManager)
+	purgeService := user.NewPurgeService(repos.Purge, redisClient, subscriptionManager, logger)
+	quickNodeService.OnReconnect(func() {
+		if err := subscriptionManager.OnWebSocketReconnected(); err != nil {
+			logger.WithError(err).Error("Failed to verify subscriptions after reconnection")
+		}
+	})
+	memberPruner := room.NewMemberPruner(repos.Room, wsService, subscriptionManager, &cfg.Room, logger)
+	roomAssistantService := assistant.NewRoomAssistantService(
+		repos.Room,
+		langChainService,
+		roomThrottle,
 		wsService,
 		logger,
 	)
 	
-	// AI services
-	langChainService := ai.NewLangChainService(
-		&cfg.ExternalAPIs.OpenAI,
+	// Email services; nothing is mailed without a configured sender address
+	emailSender, err := email.NewSender(&cfg.Email)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid email configuration, email disabled")
+	}
+	emailService := email.NewEmailService(emailSender, repos.UserSettings, &cfg.Email, logger)
+	
+	// Report services; digests are also mailed to members who bound an email
+	reportService := report.NewReportService(
+		repos.Report,
+		repos.Room,
+		langChainService,
+		wsService,
+		emailService,
+		logger,
+	)
+	
+	// Export services
+	exportService := export.NewExportService(
+		repos.Export,
+		repos.Room,
+		repos.Transaction,
 		repos.Token,
-		marketService,
-		solanaTrackerService,
+		&cfg.Export,
+		logger,
+	)
+	
+	// Analytics services
+	analyticsService := analytics.NewAnalyticsService(repos.Analytics, transactionProcessor, priceAggregator, redisClient, logger)
+	
+	// Liquidity services
+	liquidityService := liquidity.NewLiquidityService(
+		repos.Liquidity,
+		repos.Room,
+		marketDataProvider,
+		poolsProvider,
+		wsService,
+		&cfg.Room.Liquidity,
+		logger,
+	)
+	
+	// Social services
+	socialProvider, err := social.NewProvider(&cfg.ExternalAPIs.Social, apiStats, logger)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid social provider configuration, social ingestion disabled")
+	}
+	socialService := social.NewSocialService(
+		&cfg.ExternalAPIs.Social,
+		socialProvider,
+		repos.Social,
+		repos.Token,
+		repos.Room,
 		logger,
 	)
 	
+	// Notification center services; followers are alerted about large trades of the wallets they follow
+	notificationService := notification.NewNotificationService(repos.Notification, repos.UserSettings, repos.Trader, wsService, emailService, &cfg.Notification, logger)
+	subscriptionManager.OnTrade(notificationService.OnTrade)
+	subscriptionManager.OnWatchedTrade(notificationService.OnWatchedTrade)
+	
+	// Limit watch services; watches are evaluated on every market data event and notify their wallet
+	limitWatchService := limitwatch.NewLimitWatchService(repos.LimitWatch, priceAggregator, notificationService, logger)
+	marketEvents.Subscribe("limit_watch", token.OnPrice(limitWatchService.OnPrice))
+	
+	// Momentum alert services; trending rank moves are checked after every trending sync
+	momentumService := momentum.NewMomentumService(repos.Token, repos.Room, wsService, &cfg.Momentum, logger)
+	marketService.OnTrendingSync(momentumService.OnTrendingSync)
+	
+	// Market stream services; trending changes, discoveries and large price moves are streamed to /ws/market
+	marketStreamService := marketstream.NewMarketStreamService(repos.Token, discoveryService, wsService, &cfg.MarketStream, logger)
+	marketService.OnTrendingSync(marketStreamService.OnTrendingSync)
+	marketEvents.Subscribe("market_stream", token.OnPrice(marketStreamService.OnPrice))
+	
+	// Rooms bound to a Pump.fun token are told when it graduates from its bonding curve
+	marketService.OnGraduation(wsService.NotifyTokenGraduated)
+	
+	// Trade finality services; trades recorded before finalized commitment are settled on a schedule
+	finalityService := finality.NewFinalityService(repos.Room, repos.Transaction, transactionProcessor, wsService, logger)
+	
+	// Token unlock schedule services; rooms are warned of large unlocks of their token on a schedule
+	unlockService := unlock.NewUnlockService(repos.Token, repos.Room, wsService, logger)
+	
+	// Admin dashboard services; background jobs record their runs for the sync lag
+	adminStatsService := admin.NewStatsService(repos.Room, repos.Token, wsService, subscriptionManager, quickNodeService, usageTracker, apiStats, redisClient, logger)
+	
+	// Admin access services; admin API keys and wallets are seeded from config
+	adminAccessService := admin.NewAccessService(&cfg.Admin, repos.AdminAudit, logger)
+	
 	return &Services{
 		Room:                 roomService,
 		WebSocket:            wsService,
 		SubscriptionManager:  subscriptionManager,
+		MemberPruner:         memberPruner,
 		TokenMarket:          marketService,
+		MarketEvents:         marketEvents,
+		TokenSync:            syncScheduler,
 		SolanaTracker:        solanaTrackerService,
+		TokenAnalysis:        analysisService,
+		TokenBacktest:        backtestService,
+		TokenProvenance:      provenanceService,
+		TokenChart:           chartService,
+		TokenFlag:            flagService,
+		Sellability:          sellabilityService,
+		TokenScreener:        screenerService,
+		TokenDiscovery:       discoveryService,
+		PriceFeed:            priceFeedService,
 		QuickNode:            quickNodeService,
 		TransactionProcessor: transactionProcessor,
+		Trader:               traderService,
+		SignalTracker:        signalTracker,
+		Label:                labelService,
+		Cluster:              clusterService,
+		Portfolio:            portfolioService,
+		UserSettings:         settingsService,
+		UserPurge:            purgeService,
 		LangChain:            langChainService,
+		AIUsage:              aiUsageService,
+		Prompt:               promptService,
+		RoomAssistant:        roomAssistantService,
+		Report:               reportService,
+		Export:               exportService,
+		Analytics:            analyticsService,
+		Liquidity:            liquidityService,
+		Social:               socialService,
+		LimitWatch:           limitWatchService,
+		Momentum:             momentumService,
+		MarketStream:         marketStreamService,
+		Finality:             finalityService,
+		Unlock:               unlockService,
+		Notification:         notificationService,
+		Email:                emailService,
+		AdminStats:           adminStatsService,
+		AdminAccess:          adminAccessService,
+		FeatureFlags:         flagsService,
 	}
 }
\ No newline at end of file
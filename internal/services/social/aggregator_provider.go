@@ -0,0 +1,96 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// aggregatorProvider reads pre-aggregated mention counts from a social data provider.
+// The provider is expected to serve GET {base_url}/tokens/{mint}/mentions?from=&to= (RFC 3339).
+type aggregatorProvider struct {
+	config     *config.SocialConfig
+	httpClient *http.Client
+	logger     *logrus.Logger
+}
+
+func newAggregatorProvider(config *config.SocialConfig, httpClient *http.Client, logger *logrus.Logger) Provider {
+	return &aggregatorProvider{
+		config:     config,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+type aggregatorMentionsResponse struct {
+	Mentions  int     `json:"mentions"`
+	Positive  int     `json:"positive"`
+	Negative  int     `json:"negative"`
+	Sentiment float64 `json:"sentiment"` // -1 to 1
+}
+
+func (p *aggregatorProvider) Name() string {
+	return ProviderAggregator
+}
+
+func (p *aggregatorProvider) FetchMentions(ctx context.Context, token *models.Token, from, to time.Time) (*Mentions, error) {
+	query := url.Values{}
+	query.Set("from", from.UTC().Format(time.RFC3339))
+	query.Set("to", to.UTC().Format(time.RFC3339))
+	if token.Symbol != "" {
+		query.Set("symbol", token.Symbol)
+	}
+
+	endpoint := fmt.Sprintf("%s/tokens/%s/mentions?%s", strings.TrimRight(p.config.BaseURL, "/"), url.PathEscape(token.MintAddress), query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if p.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+	req.Header.Set("User-Agent", "solana-wallet-service/1.0")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return &Mentions{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("social aggregator returned status %d", resp.StatusCode)
+	}
+
+	var body aggregatorMentionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &Mentions{
+		Count:    body.Mentions,
+		Positive: body.Positive,
+		Negative: body.Negative,
+		Score:    clampScore(body.Sentiment),
+	}, nil
+}
+
+func clampScore(score float64) float64 {
+	if score > 1 {
+		return 1
+	}
+	if score < -1 {
+		return -1
+	}
+	return score
+}
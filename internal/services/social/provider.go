@@ -0,0 +1,57 @@
+package social
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+)
+
+const (
+	ProviderTwitter    = "twitter"
+	ProviderAggregator = "aggregator"
+
+	defaultProviderTimeout = 15 * time.Second
+)
+
+// Mentions is what a provider reports for a token over a period
+type Mentions struct {
+	Count    int
+	Positive int     // positive posts among those classified
+	Negative int     // negative posts among those classified
+	Score    float64 // -1 to 1
+}
+
+// Provider collects social media mentions of a token
+type Provider interface {
+	Name() string
+	FetchMentions(ctx context.Context, token *models.Token, from, to time.Time) (*Mentions, error)
+}
+
+// NewProvider creates the configured provider; it returns nil if social ingestion is disabled
+func NewProvider(config *config.SocialConfig, apiStats *apistats.Registry, logger *logrus.Logger) (Provider, error) {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultProviderTimeout
+	}
+	httpClient := &http.Client{Timeout: timeout, Transport: apiStats.Transport("social", nil)}
+
+	switch config.Provider {
+	case "":
+		return nil, nil
+	case ProviderTwitter:
+		return newTwitterProvider(config, httpClient, logger), nil
+	case ProviderAggregator:
+		if config.BaseURL == "" {
+			return nil, fmt.Errorf("social aggregator provider requires a base URL")
+		}
+		return newAggregatorProvider(config, httpClient, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown social provider %q", config.Provider)
+	}
+}
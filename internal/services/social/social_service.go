@@ -0,0 +1,212 @@
+package social
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+const (
+	// Trending tokens tracked per ingestion run, on top of the tokens bound to rooms
+	trackedTrendingLimit = 50
+	defaultRetention     = 30 * 24 * time.Hour
+	maxSocialHours       = 7 * 24
+)
+
+// SocialService ingests hourly social mentions of tracked tokens and serves them
+type SocialService interface {
+	IngestHour(ctx context.Context) (int, error)
+	GetTokenSocial(ctx context.Context, mintAddress string, hours int) (*TokenSocialResult, error)
+}
+
+type socialService struct {
+	config     *config.SocialConfig
+	provider   Provider
+	socialRepo repositories.SocialRepository
+	tokenRepo  repositories.TokenRepository
+	roomRepo   repositories.RoomRepository
+	logger     *logrus.Logger
+}
+
+// NewSocialService creates a new social service instance; a nil provider disables ingestion
+func NewSocialService(
+	config *config.SocialConfig,
+	provider Provider,
+	socialRepo repositories.SocialRepository,
+	tokenRepo repositories.TokenRepository,
+	roomRepo repositories.RoomRepository,
+	logger *logrus.Logger,
+) SocialService {
+	return &socialService{
+		config:     config,
+		provider:   provider,
+		socialRepo: socialRepo,
+		tokenRepo:  tokenRepo,
+		roomRepo:   roomRepo,
+		logger:     logger,
+	}
+}
+
+// TokenSocialResult holds a token's hourly social metrics and their summary
+type TokenSocialResult struct {
+	MintAddress string                      `json:"mint_address"`
+	Hours       int                         `json:"hours"`
+	Summary     *models.TokenSocialSummary  `json:"summary"`
+	Metrics     []*models.TokenSocialMetric `json:"metrics"`
+}
+
+// IngestHour collects mentions for the last complete UTC hour of every tracked token not yet ingested for it
+func (s *socialService) IngestHour(ctx context.Context) (int, error) {
+	if s.provider == nil {
+		return 0, nil
+	}
+
+	to := time.Now().UTC().Truncate(time.Hour)
+	from := to.Add(-time.Hour)
+
+	tokens, err := s.trackedTokens(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ingestedMints, err := s.socialRepo.GetIngestedMints(ctx, from, s.provider.Name())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ingested mints: %w", err)
+	}
+	ingested := make(map[string]bool, len(ingestedMints))
+	for _, mint := range ingestedMints {
+		ingested[mint] = true
+	}
+
+	count := 0
+	for _, token := range tokens {
+		if ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+		if ingested[token.MintAddress] {
+			continue
+		}
+
+		mentions, err := s.provider.FetchMentions(ctx, token, from, to)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": token.MintAddress,
+				"provider":     s.provider.Name(),
+			}).Warn("Failed to fetch social mentions")
+			continue
+		}
+
+		metric := &models.TokenSocialMetric{
+			MintAddress:      token.MintAddress,
+			Hour:             from,
+			Provider:         s.provider.Name(),
+			Mentions:         mentions.Count,
+			PositiveMentions: mentions.Positive,
+			NegativeMentions: mentions.Negative,
+			SentimentScore:   mentions.Score,
+		}
+		if err := s.socialRepo.SaveMetric(ctx, metric); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": token.MintAddress,
+			}).Warn("Failed to save social metric")
+			continue
+		}
+		count++
+	}
+
+	retention := s.config.Retention
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+	deleted, err := s.socialRepo.DeleteMetricsBefore(ctx, to.Add(-retention))
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to delete expired social metrics")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"hour":     from,
+		"tracked":  len(tokens),
+		"ingested": count,
+		"deleted":  deleted,
+		"provider": s.provider.Name(),
+	}).Info("Social ingestion completed")
+
+	return count, nil
+}
+
+// trackedTokens returns the tokens bound to active rooms and the current 24h trending tokens
+func (s *socialService) trackedTokens(ctx context.Context) ([]*models.Token, error) {
+	seen := make(map[string]bool)
+	var tokens []*models.Token
+
+	boundMints, err := s.roomRepo.GetBoundTokenAddresses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room token addresses: %w", err)
+	}
+	for _, mint := range boundMints {
+		if seen[mint] {
+			continue
+		}
+		seen[mint] = true
+
+		token, err := s.tokenRepo.GetByMintAddress(ctx, mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token: %w", err)
+		}
+		if token == nil {
+			// Unknown tokens are still searched by mint address
+			token = &models.Token{MintAddress: mint}
+		}
+		tokens = append(tokens, token)
+	}
+
+	rankings, err := s.tokenRepo.GetTrendingTokens(ctx, "trending", "24h", "", trackedTrendingLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending tokens: %w", err)
+	}
+	for _, ranking := range rankings {
+		if ranking.Token.MintAddress == "" || seen[ranking.Token.MintAddress] {
+			continue
+		}
+		seen[ranking.Token.MintAddress] = true
+		token := ranking.Token
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, nil
+}
+
+// GetTokenSocial returns the token's hourly metrics and summary over the last hours (24 by default, up to a week)
+func (s *socialService) GetTokenSocial(ctx context.Context, mintAddress string, hours int) (*TokenSocialResult, error) {
+	if hours <= 0 {
+		hours = 24
+	}
+	if hours > maxSocialHours {
+		hours = maxSocialHours
+	}
+	since := time.Now().UTC().Truncate(time.Hour).Add(-time.Duration(hours) * time.Hour)
+
+	summary, err := s.socialRepo.GetSummary(ctx, mintAddress, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get social summary: %w", err)
+	}
+
+	metrics, err := s.socialRepo.GetMetrics(ctx, mintAddress, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get social metrics: %w", err)
+	}
+
+	return &TokenSocialResult{
+		MintAddress: mintAddress,
+		Hours:       hours,
+		Summary:     summary,
+		Metrics:     metrics,
+	}, nil
+}
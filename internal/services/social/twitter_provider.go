@@ -0,0 +1,153 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+const (
+	defaultTwitterBaseURL = "https://api.twitter.com/2"
+	twitterSampleSize     = 100 // posts fetched per token and hour for sentiment
+)
+
+// Words that mark a post as positive or negative; crypto slang is included since posts rarely use formal language
+var (
+	positiveWords = map[string]bool{
+		"bullish": true, "moon": true, "mooning": true, "pump": true, "pumping": true, "buy": true, "buying": true,
+		"long": true, "gem": true, "ath": true, "breakout": true, "send": true, "sending": true, "lfg": true,
+		"undervalued": true, "accumulate": true, "accumulating": true, "strong": true, "rocket": true, "win": true,
+	}
+	negativeWords = map[string]bool{
+		"bearish": true, "dump": true, "dumping": true, "sell": true, "selling": true, "short": true, "rug": true,
+		"rugged": true, "scam": true, "honeypot": true, "dead": true, "rekt": true, "exit": true, "crash": true,
+		"overvalued": true, "avoid": true, "weak": true, "fud": true, "down": true, "loss": true,
+	}
+)
+
+type twitterProvider struct {
+	config     *config.SocialConfig
+	httpClient *http.Client
+	logger     *logrus.Logger
+}
+
+func newTwitterProvider(config *config.SocialConfig, httpClient *http.Client, logger *logrus.Logger) Provider {
+	return &twitterProvider{
+		config:     config,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+type twitterCountsResponse struct {
+	Meta struct {
+		TotalTweetCount int `json:"total_tweet_count"`
+	} `json:"meta"`
+}
+
+type twitterSearchResponse struct {
+	Data []struct {
+		Text string `json:"text"`
+	} `json:"data"`
+}
+
+func (p *twitterProvider) Name() string {
+	return ProviderTwitter
+}
+
+// FetchMentions counts posts with the token's cashtag or mint address, and scores a sample of them for sentiment
+func (p *twitterProvider) FetchMentions(ctx context.Context, token *models.Token, from, to time.Time) (*Mentions, error) {
+	query := url.Values{}
+	query.Set("query", searchQuery(token))
+	query.Set("start_time", from.UTC().Format(time.RFC3339))
+	query.Set("end_time", to.UTC().Format(time.RFC3339))
+
+	var counts twitterCountsResponse
+	if err := p.get(ctx, "/tweets/counts/recent", query, &counts); err != nil {
+		return nil, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	mentions := &Mentions{Count: counts.Meta.TotalTweetCount}
+	if mentions.Count == 0 {
+		return mentions, nil
+	}
+
+	query.Set("max_results", fmt.Sprintf("%d", twitterSampleSize))
+	var search twitterSearchResponse
+	if err := p.get(ctx, "/tweets/search/recent", query, &search); err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	for _, post := range search.Data {
+		switch score := scoreText(post.Text); {
+		case score > 0:
+			mentions.Positive++
+		case score < 0:
+			mentions.Negative++
+		}
+	}
+	if len(search.Data) > 0 {
+		mentions.Score = float64(mentions.Positive-mentions.Negative) / float64(len(search.Data))
+	}
+	return mentions, nil
+}
+
+func (p *twitterProvider) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	baseURL := p.config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultTwitterBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	req.Header.Set("User-Agent", "solana-wallet-service/1.0")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("X API returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// searchQuery matches original posts with the token's cashtag or mint address
+func searchQuery(token *models.Token) string {
+	terms := []string{token.MintAddress}
+	if symbol := strings.TrimSpace(token.Symbol); symbol != "" && !strings.ContainsAny(symbol, " \"") {
+		terms = append([]string{"$" + symbol}, terms...)
+	}
+	return fmt.Sprintf("(%s) -is:retweet", strings.Join(terms, " OR "))
+}
+
+// scoreText returns the number of positive minus negative words in the text
+func scoreText(text string) int {
+	score := 0
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z')
+	}) {
+		if positiveWords[word] {
+			score++
+		} else if negativeWords[word] {
+			score--
+		}
+	}
+	return score
+}
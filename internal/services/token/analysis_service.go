@@ -2,16 +2,19 @@ package token
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/label"
 )
 
 // AnalysisService defines the interface for AI-powered token analysis
@@ -35,27 +38,54 @@ type AnalysisService interface {
 	
 	// Batch analysis
 	BatchAnalyzeTokens(ctx context.Context, tokenIDs []uuid.UUID) ([]*TokenAnalysisResult, error)
+	
+	// Scoring configuration
+	GetScoringWeights() *ScoringWeights
+	UpdateScoring(cfg *config.ScoringConfig)
 }
 
 type analysisService struct {
 	tokenRepo       repositories.TokenRepository
 	transactionRepo repositories.TransactionRepository
+	labelRepo       repositories.WalletLabelRepository
+	clusterRepo     repositories.ClusterRepository
+	socialRepo      repositories.SocialRepository
 	marketService   MarketService
+	provenance      ProvenanceService
+	flags           FlagService
+	sellability     SellabilityService
 	logger          *logrus.Logger
+	
+	scoringMu sync.RWMutex
+	scoring   *ScoringWeights // replaced, never modified, on reload
 }
 
 // NewAnalysisService creates a new analysis service instance
 func NewAnalysisService(
 	tokenRepo repositories.TokenRepository,
 	transactionRepo repositories.TransactionRepository,
+	labelRepo repositories.WalletLabelRepository,
+	clusterRepo repositories.ClusterRepository,
+	socialRepo repositories.SocialRepository,
 	marketService MarketService,
+	provenance ProvenanceService,
+	flags FlagService,
+	sellability SellabilityService,
+	scoring *config.ScoringConfig,
 	logger *logrus.Logger,
 ) AnalysisService {
 	return &analysisService{
 		tokenRepo:       tokenRepo,
 		transactionRepo: transactionRepo,
+		labelRepo:       labelRepo,
+		clusterRepo:     clusterRepo,
+		socialRepo:      socialRepo,
 		marketService:   marketService,
+		provenance:      provenance,
+		flags:           flags,
+		sellability:     sellability,
 		logger:          logger,
+		scoring:         NewScoringWeights(scoring),
 	}
 }
 
@@ -68,6 +98,7 @@ type TokenAnalysisResult struct {
 	Recommendation string                 `json:"recommendation"`  // buy, hold, sell
 	Confidence     float64                `json:"confidence"`      // 0-1
 	Analysis       map[string]interface{} `json:"analysis"`
+	Weights        *ScoringWeights        `json:"weights"`
 	Timestamp      time.Time              `json:"timestamp"`
 }
 
@@ -83,14 +114,16 @@ type TrendAnalysisResult struct {
 }
 
 type SentimentAnalysisResult struct {
-	TokenID         uuid.UUID `json:"token_id"`
-	SentimentScore  float64   `json:"sentiment_score"`  // -1 to 1
-	SentimentLabel  string    `json:"sentiment_label"`  // bearish, neutral, bullish
-	BuyPressure     float64   `json:"buy_pressure"`     // 0-1
-	SellPressure    float64   `json:"sell_pressure"`    // 0-1
-	MarketMood      string    `json:"market_mood"`      // fear, greed, neutral
-	SocialMentions  int       `json:"social_mentions"`
-	Timestamp       time.Time `json:"timestamp"`
+	TokenID         uuid.UUID       `json:"token_id"`
+	SentimentScore  float64         `json:"sentiment_score"`  // -1 to 1
+	SentimentLabel  string          `json:"sentiment_label"`  // bearish, neutral, bullish
+	BuyPressure     float64         `json:"buy_pressure"`     // 0-1
+	SellPressure    float64         `json:"sell_pressure"`    // 0-1
+	MarketMood      string          `json:"market_mood"`      // fear, greed, neutral
+	SocialMentions  int             `json:"social_mentions"`  // last 24h
+	SocialSentiment float64         `json:"social_sentiment"` // -1 to 1, mention-weighted over the last 24h
+	Weights         *ScoringWeights `json:"weights"`
+	Timestamp       time.Time       `json:"timestamp"`
 }
 
 type TransactionPatternResult struct {
@@ -109,21 +142,29 @@ type SmartMoneyAnalysisResult struct {
 	SmartMoneyFlow       float64   `json:"smart_money_flow"`       // net flow in USD
 	SmartMoneySignal     string    `json:"smart_money_signal"`     // bullish, bearish, neutral
 	TopTraderActions     []string  `json:"top_trader_actions"`     // recent actions
-	InsiderActivity      float64   `json:"insider_activity"`       // 0-1
+	InsiderActivity      float64   `json:"insider_activity"`       // 0-1, includes wallets clustered with insiders
+	InsiderWallets       []string  `json:"insider_wallets,omitempty"`
+	ClusteredActivity    float64   `json:"clustered_activity"`     // 0-1, volume of wallets trading alongside wallets of their own cluster
 	InstitutionalSignal  string    `json:"institutional_signal"`   // buying, selling, neutral
+	WalletLabels         map[string][]string `json:"wallet_labels,omitempty"` // labels of the wallets involved
 	Timestamp            time.Time `json:"timestamp"`
 }
 
 type RiskAssessmentResult struct {
-	TokenID        uuid.UUID `json:"token_id"`
-	RiskScore      float64   `json:"risk_score"`      // 0-100 (higher = riskier)
-	RiskLevel      string    `json:"risk_level"`      // low, medium, high
-	LiquidityRisk  float64   `json:"liquidity_risk"`  // 0-1
-	VolatilityRisk float64   `json:"volatility_risk"` // 0-1
-	MarketRisk     float64   `json:"market_risk"`     // 0-1
-	TechnicalRisk  float64   `json:"technical_risk"`  // 0-1
-	Warnings       []string  `json:"warnings"`
-	Timestamp      time.Time `json:"timestamp"`
+	TokenID        uuid.UUID          `json:"token_id"`
+	RiskScore      float64            `json:"risk_score"`      // 0-100 (higher = riskier)
+	RiskLevel      string             `json:"risk_level"`      // low, medium, high
+	LiquidityRisk  float64            `json:"liquidity_risk"`  // 0-1
+	VolatilityRisk float64            `json:"volatility_risk"` // 0-1
+	MarketRisk     float64            `json:"market_risk"`     // 0-1
+	TechnicalRisk  float64            `json:"technical_risk"`  // 0-1
+	ProvenanceRisk float64            `json:"provenance_risk"` // 0-1, deployer history and token age
+	Flag           *models.TokenFlag  `json:"flag,omitempty"`  // an active flag forces the highest risk
+	Sellability    *SellabilityResult `json:"sellability,omitempty"` // a token that cannot be sold forces the highest risk
+	Unlocks        []*models.TokenUnlockSchedule `json:"unlocks,omitempty"` // vesting unlocks within the risk window, soonest first
+	Warnings       []string           `json:"warnings"`
+	Weights        *ScoringWeights    `json:"weights"`
+	Timestamp      time.Time          `json:"timestamp"`
 }
 
 type VolatilityMetrics struct {
@@ -139,15 +180,16 @@ type VolatilityMetrics struct {
 }
 
 type TokenRecommendation struct {
-	TokenID      uuid.UUID `json:"token_id"`
-	Action       string    `json:"action"`       // buy, sell, hold
-	Confidence   float64   `json:"confidence"`   // 0-1
-	TargetPrice  float64   `json:"target_price"`
-	StopLoss     float64   `json:"stop_loss"`
-	TimeHorizon  string    `json:"time_horizon"` // short, medium, long
-	Reasoning    string    `json:"reasoning"`
-	RiskReward   float64   `json:"risk_reward"`
-	Timestamp    time.Time `json:"timestamp"`
+	TokenID     uuid.UUID       `json:"token_id"`
+	Action      string          `json:"action"`     // buy, sell, hold
+	Confidence  float64         `json:"confidence"` // 0-1
+	TargetPrice float64         `json:"target_price"`
+	StopLoss    float64         `json:"stop_loss"`
+	TimeHorizon string          `json:"time_horizon"` // short, medium, long
+	Reasoning   string          `json:"reasoning"`
+	RiskReward  float64         `json:"risk_reward"`
+	Weights     *ScoringWeights `json:"weights"`
+	Timestamp   time.Time       `json:"timestamp"`
 }
 
 type TokenComparisonResult struct {
@@ -172,6 +214,9 @@ func (s *analysisService) AnalyzeTokenMarketData(ctx context.Context, tokenID uu
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token: %w", err)
 	}
+	if token == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, tokenID)
+	}
 	
 	// Get latest market data
 	marketData, err := s.marketService.GetLatestMarketData(ctx, tokenID)
@@ -184,15 +229,11 @@ func (s *analysisService) AnalyzeTokenMarketData(ctx context.Context, tokenID uu
 	}
 	
 	// Calculate analysis scores
-	priceScore := s.calculatePriceScore(marketData)
-	volumeScore := s.calculateVolumeScore(marketData)
-	momentumScore := s.calculateMomentumScore(marketData)
-	
-	// Overall score (weighted average)
-	overallScore := (priceScore*0.3 + volumeScore*0.3 + momentumScore*0.4)
+	weights := s.GetScoringWeights()
+	priceScore, volumeScore, momentumScore, overallScore := marketScores(marketData, weights)
 	
 	// Generate recommendation
-	recommendation := s.generateRecommendation(overallScore, marketData)
+	recommendation := recommendAction(overallScore, weights)
 	confidence := s.calculateConfidence(marketData)
 	
 	analysis := map[string]interface{}{
@@ -213,6 +254,7 @@ func (s *analysisService) AnalyzeTokenMarketData(ctx context.Context, tokenID uu
 		Recommendation: recommendation,
 		Confidence:     confidence,
 		Analysis:       analysis,
+		Weights:        weights,
 		Timestamp:      time.Now(),
 	}
 	
@@ -315,8 +357,15 @@ func (s *analysisService) AnalyzeMarketSentiment(ctx context.Context, tokenID uu
 	}
 	
 	// Calculate sentiment based on price changes and volume
-	sentimentScore := s.calculateSentimentScore(marketData, stats)
-	sentimentLabel := s.getSentimentLabel(sentimentScore)
+	weights := s.GetScoringWeights()
+	sentimentScore := s.calculateSentimentScore(marketData, stats, weights)
+	
+	// Blend in social sentiment when the token was mentioned
+	social := s.getSocialSummary(ctx, tokenID)
+	if social.Mentions > 0 {
+		sentimentScore = sentimentScore*(1-weights.SocialWeight) + social.SentimentScore*weights.SocialWeight
+	}
+	sentimentLabel := s.getSentimentLabel(sentimentScore, weights)
 	
 	// Calculate buy/sell pressure
 	buyPressure := 0.5
@@ -338,7 +387,9 @@ func (s *analysisService) AnalyzeMarketSentiment(ctx context.Context, tokenID uu
 		BuyPressure:     buyPressure,
 		SellPressure:    sellPressure,
 		MarketMood:      marketMood,
-		SocialMentions:  0, // Would integrate with social media APIs
+		SocialMentions:  social.Mentions,
+		SocialSentiment: social.SentimentScore,
+		Weights:         weights,
 		Timestamp:       time.Now(),
 	}, nil
 }
@@ -363,14 +414,26 @@ func (s *analysisService) AssessTokenRisk(ctx context.Context, tokenID uuid.UUID
 	technicalRisk := s.calculateTechnicalRisk(marketData)
 	
 	// Overall risk score (weighted average)
-	riskScore := (liquidityRisk*0.25 + volatilityRisk*0.35 + marketRisk*0.2 + technicalRisk*0.2) * 100
+	weights := s.GetScoringWeights()
+	riskScore := (liquidityRisk*weights.Risk.Liquidity + volatilityRisk*weights.Risk.Volatility +
+		marketRisk*weights.Risk.Market + technicalRisk*weights.Risk.Technical) * 100
+	
+	// Blend in provenance when the token's origin can be resolved
+	provenanceRisk, provenanceWarnings := s.assessProvenanceRisk(ctx, tokenID)
+	if provenanceRisk >= 0 {
+		riskScore = riskScore*(1-weights.ProvenanceWeight) + provenanceRisk*100*weights.ProvenanceWeight
+	}
+	
+	// Supply about to be unlocked adds sell pressure the market data does not show yet
+	unlocks, unlockRisk, unlockWarnings := s.assessUnlockRisk(ctx, tokenID)
+	riskScore = math.Min(riskScore+unlockRisk, 100)
 	
 	// Risk level classification
 	var riskLevel string
 	switch {
-	case riskScore < 30:
+	case riskScore < weights.LowRisk:
 		riskLevel = "low"
-	case riskScore < 70:
+	case riskScore < weights.HighRisk:
 		riskLevel = "medium"
 	default:
 		riskLevel = "high"
@@ -387,6 +450,32 @@ func (s *analysisService) AssessTokenRisk(ctx context.Context, tokenID uuid.UUID
 	if marketData.MarketCapRank > 500 {
 		warnings = append(warnings, "Low market cap token")
 	}
+	warnings = append(warnings, provenanceWarnings...)
+	warnings = append(warnings, unlockWarnings...)
+	if warning := s.checkHolderOutflow(ctx, tokenID); warning != "" {
+		warnings = append(warnings, warning)
+	}
+	
+	// Honeypot and flag checks are keyed by mint address
+	var sellability *SellabilityResult
+	var flag *models.TokenFlag
+	if token, err := s.tokenRepo.GetByID(ctx, tokenID); err == nil && token != nil {
+		sellability = s.checkSellability(ctx, token.MintAddress)
+		flag = s.checkFlag(ctx, token.MintAddress, provenanceRisk, sellability)
+		warnings = append(warnings, s.checkMetadataScreen(ctx, token.MintAddress)...)
+	}
+	
+	// A token that cannot be sold or is flagged is high risk whatever its market data says
+	if sellability != nil && sellability.CannotSell() {
+		riskScore = 100
+		riskLevel = "high"
+		warnings = append([]string{"Token cannot be sold: " + sellability.Reason}, warnings...)
+	}
+	if flag != nil {
+		riskScore = 100
+		riskLevel = "high"
+		warnings = append([]string{fmt.Sprintf("Token is flagged as %s: %s", flag.Type, flag.Reason)}, warnings...)
+	}
 	
 	return &RiskAssessmentResult{
 		TokenID:        tokenID,
@@ -396,7 +485,12 @@ func (s *analysisService) AssessTokenRisk(ctx context.Context, tokenID uuid.UUID
 		VolatilityRisk: volatilityRisk,
 		MarketRisk:     marketRisk,
 		TechnicalRisk:  technicalRisk,
+		ProvenanceRisk: math.Max(provenanceRisk, 0),
+		Flag:           flag,
+		Sellability:    sellability,
+		Unlocks:        unlocks,
 		Warnings:       warnings,
+		Weights:        weights,
 		Timestamp:      time.Now(),
 	}, nil
 }
@@ -461,11 +555,13 @@ func (s *analysisService) GenerateTokenRecommendation(ctx context.Context, token
 	var timeHorizon string
 	var reasoning strings.Builder
 	
-	if analysis.OverallScore >= 70 && riskAssessment.RiskScore < 50 {
+	// Both analyses are scored with the same weights unless the config reloaded in between; the risk weights are reported
+	weights := riskAssessment.Weights
+	if analysis.OverallScore >= weights.BuyScore && riskAssessment.RiskScore < weights.BuyMaxRisk {
 		action = "buy"
 		timeHorizon = "medium"
 		reasoning.WriteString("Strong fundamentals with manageable risk. ")
-	} else if analysis.OverallScore <= 30 || riskAssessment.RiskScore > 80 {
+	} else if analysis.OverallScore <= weights.SellScore || riskAssessment.RiskScore > weights.SellMinRisk {
 		action = "sell"
 		timeHorizon = "short"
 		reasoning.WriteString("Weak performance with high risk. ")
@@ -494,6 +590,22 @@ func (s *analysisService) GenerateTokenRecommendation(ctx context.Context, token
 	// Risk-reward ratio
 	riskReward := math.Abs(targetPrice-currentPrice) / math.Abs(currentPrice-stopLoss)
 	
+	// Keep the recommendation for backtesting; failing to record it does not fail the request
+	record := &models.TokenRecommendationRecord{
+		TokenID:      tokenID,
+		Action:       action,
+		OverallScore: analysis.OverallScore,
+		RiskScore:    riskAssessment.RiskScore,
+		Confidence:   analysis.Confidence,
+		PriceUSD:     currentPrice,
+	}
+	if err := s.tokenRepo.CreateRecommendationRecord(ctx, record); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"token_id": tokenID,
+		}).Warn("Failed to record token recommendation")
+	}
+	
 	return &TokenRecommendation{
 		TokenID:      tokenID,
 		Action:       action,
@@ -503,6 +615,7 @@ func (s *analysisService) GenerateTokenRecommendation(ctx context.Context, token
 		TimeHorizon:  timeHorizon,
 		Reasoning:    reasoning.String(),
 		RiskReward:   riskReward,
+		Weights:      weights,
 		Timestamp:    time.Now(),
 	}, nil
 }
@@ -530,38 +643,25 @@ func (s *analysisService) BatchAnalyzeTokens(ctx context.Context, tokenIDs []uui
 	return results, nil
 }
 
-// Helper functions
-func (s *analysisService) calculatePriceScore(data *models.TokenMarketData) float64 {
-	// Score based on price changes (higher positive change = higher score)
-	score := 50 + (data.PriceChange24h * 2) // Base 50, adjust by 24h change
-	return math.Max(0, math.Min(100, score))
+// GetScoringWeights returns the weight set currently used for scoring
+func (s *analysisService) GetScoringWeights() *ScoringWeights {
+	s.scoringMu.RLock()
+	defer s.scoringMu.RUnlock()
+	return s.scoring
 }
 
-func (s *analysisService) calculateVolumeScore(data *models.TokenMarketData) float64 {
-	// Score based on volume change
-	score := 50 + (data.VolumeChange24h / 2)
-	return math.Max(0, math.Min(100, score))
-}
-
-func (s *analysisService) calculateMomentumScore(data *models.TokenMarketData) float64 {
-	// Weighted momentum score
-	momentum1h := data.PriceChange1h * 0.2
-	momentum24h := data.PriceChange24h * 0.5
-	momentum7d := data.PriceChange7d * 0.3
+// UpdateScoring swaps in a new weight set; analyses already running keep the set they started with
+func (s *analysisService) UpdateScoring(cfg *config.ScoringConfig) {
+	weights := NewScoringWeights(cfg)
 	
-	score := 50 + momentum1h + momentum24h + momentum7d
-	return math.Max(0, math.Min(100, score))
-}
-
-func (s *analysisService) generateRecommendation(score float64, data *models.TokenMarketData) string {
-	if score >= 70 {
-		return "buy"
-	} else if score <= 30 {
-		return "sell"
-	}
-	return "hold"
+	s.scoringMu.Lock()
+	s.scoring = weights
+	s.scoringMu.Unlock()
+	
+	s.logger.WithField("weights", weights).Info("Token scoring weights updated")
 }
 
+// Helper functions
 func (s *analysisService) calculateConfidence(data *models.TokenMarketData) float64 {
 	// Confidence based on volume and market cap
 	if data.Volume24h > 1000000 && data.MarketCap > 10000000 {
@@ -572,16 +672,17 @@ func (s *analysisService) calculateConfidence(data *models.TokenMarketData) floa
 	return 0.4
 }
 
-func (s *analysisService) calculateSentimentScore(data *models.TokenMarketData, stats *models.TokenTransactionStats) float64 {
+func (s *analysisService) calculateSentimentScore(data *models.TokenMarketData, stats *models.TokenTransactionStats, weights *ScoringWeights) float64 {
 	// Sentiment based on price performance
-	sentiment := (data.PriceChange1h*0.2 + data.PriceChange24h*0.5 + data.PriceChange7d*0.3) / 100
+	sentiment := (data.PriceChange1h*weights.Momentum.Change1h + data.PriceChange24h*weights.Momentum.Change24h +
+		data.PriceChange7d*weights.Momentum.Change7d) / 100
 	return math.Max(-1, math.Min(1, sentiment))
 }
 
-func (s *analysisService) getSentimentLabel(score float64) string {
-	if score > 0.3 {
+func (s *analysisService) getSentimentLabel(score float64, weights *ScoringWeights) string {
+	if score > weights.BullishSentiment {
 		return "bullish"
-	} else if score < -0.3 {
+	} else if score < -weights.BullishSentiment {
 		return "bearish"
 	}
 	return "neutral"
@@ -620,6 +721,175 @@ func (s *analysisService) calculateMarketRisk(data *models.TokenMarketData) floa
 	return 0.3
 }
 
+// assessProvenanceRisk returns -1 when the token's provenance cannot be resolved
+func (s *analysisService) assessProvenanceRisk(ctx context.Context, tokenID uuid.UUID) (float64, []string) {
+	if s.provenance == nil {
+		return -1, nil
+	}
+	
+	token, err := s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil || token == nil {
+		return -1, nil
+	}
+	
+	result, err := s.provenance.GetProvenance(ctx, token.MintAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": token.MintAddress,
+		}).Warn("Failed to get provenance for risk assessment")
+		return -1, nil
+	}
+	
+	return ProvenanceRisk(result)
+}
+
+// Unlocks within the window raise the risk score; unlocks of at least the warning share of supply are named
+const (
+	unlockRiskWindow          = 30 * 24 * time.Hour
+	unlockRiskPerPercent      = 2.0
+	unlockRiskMax             = 20.0
+	largeUnlockWarningPercent = 1.0
+)
+
+// assessUnlockRisk returns the unlocks within the risk window and the risk points they add: each percent of
+// supply unlocking adds unlockRiskPerPercent points, up to unlockRiskMax
+func (s *analysisService) assessUnlockRisk(ctx context.Context, tokenID uuid.UUID) ([]*models.TokenUnlockSchedule, float64, []string) {
+	now := time.Now()
+	unlocks, err := s.tokenRepo.GetTokenUnlocks(ctx, tokenID, now)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"token_id": tokenID,
+		}).Warn("Failed to get unlocks for risk assessment")
+		return nil, 0, nil
+	}
+	
+	var upcoming []*models.TokenUnlockSchedule
+	var percent float64
+	var warnings []string
+	for _, unlock := range unlocks {
+		if unlock.UnlockAt.After(now.Add(unlockRiskWindow)) {
+			break
+		}
+		upcoming = append(upcoming, unlock)
+		percent += unlock.PercentOfSupply
+		if unlock.PercentOfSupply >= largeUnlockWarningPercent {
+			warnings = append(warnings, fmt.Sprintf("%.2f%% of supply unlocks for %s on %s",
+				unlock.PercentOfSupply, unlock.Category, unlock.UnlockAt.UTC().Format("2006-01-02")))
+		}
+	}
+	return upcoming, math.Min(percent*unlockRiskPerPercent, unlockRiskMax), warnings
+}
+
+// checkHolderOutflow warns when the top holders sold down a notable share of supply over the outflow window
+func (s *analysisService) checkHolderOutflow(ctx context.Context, tokenID uuid.UUID) string {
+	changes, err := s.marketService.GetHolderChanges(ctx, tokenID, time.Now().Add(-holderOutflowWindow), false)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"token_id": tokenID,
+		}).Warn("Failed to get holder changes for risk assessment")
+		return ""
+	}
+	if changes.From == nil || changes.NetTopHolderChangePercent > -holderOutflowWarningPercent {
+		return ""
+	}
+	return fmt.Sprintf("Top holders reduced their share of supply by %.2f%% since %s",
+		-changes.NetTopHolderChangePercent, changes.From.Format(time.RFC3339))
+}
+
+// getSocialSummary returns the token's social mentions over the last 24h; it is empty when none were ingested
+func (s *analysisService) getSocialSummary(ctx context.Context, tokenID uuid.UUID) *models.TokenSocialSummary {
+	if s.socialRepo == nil {
+		return &models.TokenSocialSummary{}
+	}
+
+	token, err := s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil || token == nil {
+		return &models.TokenSocialSummary{}
+	}
+
+	summary, err := s.socialRepo.GetSummary(ctx, token.MintAddress, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": token.MintAddress,
+		}).Warn("Failed to get social summary for sentiment analysis")
+		return &models.TokenSocialSummary{}
+	}
+
+	return summary
+}
+
+// checkSellability returns nil when the check cannot be run
+func (s *analysisService) checkSellability(ctx context.Context, mintAddress string) *SellabilityResult {
+	if s.sellability == nil {
+		return nil
+	}
+	
+	result, err := s.sellability.CheckSellability(ctx, mintAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": mintAddress,
+		}).Warn("Failed to check sellability for risk assessment")
+		return nil
+	}
+	return result
+}
+
+// checkMetadataScreen returns the findings of the token's metadata screen as warnings
+func (s *analysisService) checkMetadataScreen(ctx context.Context, mintAddress string) []string {
+	screen, err := s.tokenRepo.GetMetadataScreen(ctx, mintAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": mintAddress,
+		}).Warn("Failed to get token metadata screen for risk assessment")
+		return nil
+	}
+	if screen == nil {
+		return nil
+	}
+	return metadataWarnings(screen)
+}
+
+// checkFlag flags tokens that fail the sellability check or come from known scammer deployers,
+// then returns the token's active flag, if any
+func (s *analysisService) checkFlag(ctx context.Context, mintAddress string, provenanceRisk float64, sellability *SellabilityResult) *models.TokenFlag {
+	if s.flags == nil {
+		return nil
+	}
+	
+	var flagType models.TokenFlagType
+	var reason string
+	switch {
+	case sellability != nil && sellability.CannotSell():
+		flagType, reason = models.TokenFlagHoneypot, sellability.Reason
+	case provenanceRisk >= 1: // provenance risk only reaches 1 when the deployer is labelled a known scammer
+		flagType, reason = models.TokenFlagScam, "Deployer is a known scammer"
+	}
+	if flagType != "" {
+		if err := s.flags.FlagFromHeuristic(ctx, mintAddress, flagType, reason); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": mintAddress,
+			}).Warn("Failed to flag token from risk heuristics")
+		}
+	}
+	
+	flag, err := s.flags.GetActiveFlag(ctx, mintAddress)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": mintAddress,
+		}).Warn("Failed to get token flag for risk assessment")
+		return nil
+	}
+	return flag
+}
+
 func (s *analysisService) calculateTechnicalRisk(data *models.TokenMarketData) float64 {
 	// Risk based on price volatility
 	volatility := (math.Abs(data.PriceChange1h) + math.Abs(data.PriceChange24h) + math.Abs(data.PriceChange7d)) / 3
@@ -642,18 +912,198 @@ func (s *analysisService) AnalyzeTransactionPatterns(ctx context.Context, tokenI
 }
 
 func (s *analysisService) AnalyzeSmartMoneyActivity(ctx context.Context, tokenID uuid.UUID) (*SmartMoneyAnalysisResult, error) {
-	// TODO: Implement smart money analysis
+	token, err := s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if token == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, tokenID)
+	}
+	
+	transactions, err := s.transactionRepo.GetByToken(ctx, token.MintAddress, 200, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token transactions: %w", err)
+	}
+	
+	// Aggregate net flow per wallet over the last 24 hours
+	since := time.Now().Add(-24 * time.Hour)
+	walletFlow := make(map[string]float64)
+	walletVolume := make(map[string]float64)
+	var netFlow, totalVolume float64
+	for _, tx := range transactions {
+		if tx.Status != models.TransactionStatusSuccess || tx.BlockTime.Before(since) {
+			continue
+		}
+		switch tx.TransactionType {
+		case models.TransactionTypeBuy:
+			walletFlow[tx.WalletAddress] += tx.ValueUSD
+			netFlow += tx.ValueUSD
+		case models.TransactionTypeSell:
+			walletFlow[tx.WalletAddress] -= tx.ValueUSD
+			netFlow -= tx.ValueUSD
+		default:
+			continue
+		}
+		walletVolume[tx.WalletAddress] += tx.ValueUSD
+		totalVolume += tx.ValueUSD
+	}
+	
+	wallets := make([]string, 0, len(walletVolume))
+	for wallet := range walletVolume {
+		wallets = append(wallets, wallet)
+	}
+	sort.Slice(wallets, func(i, j int) bool {
+		return walletVolume[wallets[i]] > walletVolume[wallets[j]]
+	})
+	
+	walletLabels, err := label.LookupLabels(ctx, s.labelRepo, wallets)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"token_id": tokenID,
+		}).Warn("Failed to look up wallet labels for smart money analysis")
+		walletLabels = map[string][]string{}
+	}
+	
+	// Split volume by entity type
+	insiders, coordinated := s.clusterInsights(ctx, token.MintAddress, wallets, walletLabels)
+	var insiderVolume, clusteredVolume, institutionalFlow float64
+	insiderWallets := make([]string, 0, len(insiders))
+	for _, wallet := range wallets {
+		if insiders[wallet] {
+			insiderVolume += walletVolume[wallet]
+			insiderWallets = append(insiderWallets, wallet)
+		}
+		if coordinated[wallet] {
+			clusteredVolume += walletVolume[wallet]
+		}
+	}
+	for wallet, labels := range walletLabels {
+		for _, l := range labels {
+			switch models.WalletLabelType(l) {
+			case models.WalletLabelInsider, models.WalletLabelDeployer:
+				// Counted with the insider wallets above
+			case models.WalletLabelExchange, models.WalletLabelMarketMaker:
+				institutionalFlow += walletFlow[wallet]
+			default:
+				continue
+			}
+			break
+		}
+	}
+	
+	var topActions []string
+	for i, wallet := range wallets {
+		if i >= 5 {
+			break
+		}
+		action := "bought"
+		if walletFlow[wallet] < 0 {
+			action = "sold"
+		}
+		entry := fmt.Sprintf("%s %s $%.2f", wallet, action, math.Abs(walletFlow[wallet]))
+		if labels := walletLabels[wallet]; len(labels) > 0 {
+			entry = fmt.Sprintf("%s [%s]", entry, strings.Join(labels, ", "))
+		}
+		topActions = append(topActions, entry)
+	}
+	
+	insiderActivity, clusteredActivity := 0.0, 0.0
+	if totalVolume > 0 {
+		insiderActivity = insiderVolume / totalVolume
+		clusteredActivity = clusteredVolume / totalVolume
+	}
+	
 	return &SmartMoneyAnalysisResult{
-		TokenID:              tokenID,
-		SmartMoneyFlow:       0,
-		SmartMoneySignal:     "neutral",
-		TopTraderActions:     []string{"holding"},
-		InsiderActivity:      0.1,
-		InstitutionalSignal:  "neutral",
-		Timestamp:            time.Now(),
+		TokenID:             tokenID,
+		SmartMoneyFlow:      netFlow,
+		SmartMoneySignal:    flowSignal(netFlow, totalVolume, "bullish", "bearish"),
+		TopTraderActions:    topActions,
+		InsiderActivity:     insiderActivity,
+		InsiderWallets:      insiderWallets,
+		ClusteredActivity:   clusteredActivity,
+		InstitutionalSignal: flowSignal(institutionalFlow, totalVolume, "buying", "selling"),
+		WalletLabels:        walletLabels,
+		Timestamp:           time.Now(),
 	}, nil
 }
 
+// clusterInsights widens the insider set to wallets clustered with labelled insiders or the token's deployer,
+// and finds wallets trading the token alongside other wallets of their own cluster
+func (s *analysisService) clusterInsights(ctx context.Context, mintAddress string, wallets []string, walletLabels map[string][]string) (map[string]bool, map[string]bool) {
+	insiders := make(map[string]bool)
+	coordinated := make(map[string]bool)
+	for wallet, labels := range walletLabels {
+		for _, l := range labels {
+			if t := models.WalletLabelType(l); t == models.WalletLabelInsider || t == models.WalletLabelDeployer {
+				insiders[wallet] = true
+			}
+		}
+	}
+	
+	lookup := wallets
+	deployer := ""
+	if provenance, err := s.tokenRepo.GetProvenance(ctx, mintAddress); err == nil && provenance != nil {
+		deployer = provenance.DeployerAddress
+		lookup = append(append([]string{}, wallets...), deployer)
+	}
+	
+	clusterIDs, err := s.clusterRepo.GetClusterIDs(ctx, lookup)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": mintAddress,
+		}).Warn("Failed to look up wallet clusters for smart money analysis")
+		return insiders, coordinated
+	}
+	
+	insiderClusters := make(map[uuid.UUID]bool)
+	if id, ok := clusterIDs[deployer]; ok && deployer != "" {
+		insiderClusters[id] = true
+	}
+	clusterTraders := make(map[uuid.UUID]int)
+	for _, wallet := range wallets {
+		if wallet == deployer {
+			insiders[wallet] = true
+		}
+		if id, ok := clusterIDs[wallet]; ok {
+			clusterTraders[id]++
+			if insiders[wallet] {
+				insiderClusters[id] = true
+			}
+		}
+	}
+	
+	for _, wallet := range wallets {
+		id, ok := clusterIDs[wallet]
+		if !ok {
+			continue
+		}
+		if insiderClusters[id] {
+			insiders[wallet] = true
+		}
+		if clusterTraders[id] > 1 {
+			coordinated[wallet] = true
+		}
+	}
+	
+	return insiders, coordinated
+}
+
+// flowSignal classifies a net flow as positive, negative or neutral relative to total volume
+func flowSignal(flow, volume float64, positive, negative string) string {
+	if volume == 0 {
+		return "neutral"
+	}
+	ratio := flow / volume
+	if ratio > 0.1 {
+		return positive
+	} else if ratio < -0.1 {
+		return negative
+	}
+	return "neutral"
+}
+
 func (s *analysisService) CompareTokens(ctx context.Context, tokenIDs []uuid.UUID) (*TokenComparisonResult, error) {
 	// TODO: Implement token comparison
 	return &TokenComparisonResult{
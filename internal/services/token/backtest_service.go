@@ -0,0 +1,340 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+var (
+	ErrInvalidBacktestPeriod    = errors.New("invalid backtest period")
+	ErrInvalidBacktestSource    = errors.New("invalid backtest source")
+	ErrInsufficientBacktestData = errors.New("not enough stored data for the backtest period")
+)
+
+const (
+	BacktestSourceReplay   = "replay"   // recommendations recomputed from stored candles
+	BacktestSourceRecorded = "recorded" // recommendations the engine actually issued
+
+	defaultBacktestPeriod = 30 * 24 * time.Hour
+	maxBacktestPeriod     = 365 * 24 * time.Hour
+	maxBacktestSteps      = 5000
+	// History before the period needed to compute 7d price changes of its first candles
+	backtestLookback = 7 * 24 * time.Hour
+)
+
+// backtestIntervals maps a replay interval to the candle resolution stepped through
+var backtestIntervals = map[string]time.Duration{
+	"1h": time.Hour,
+	"4h": 4 * time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// BacktestService evaluates how following the recommendation engine would have performed
+type BacktestService interface {
+	Backtest(ctx context.Context, req *BacktestRequest) (*BacktestResult, error)
+}
+
+type backtestService struct {
+	tokenRepo       repositories.TokenRepository
+	analysisService AnalysisService
+	logger          *logrus.Logger
+}
+
+// NewBacktestService creates a new backtest service instance
+func NewBacktestService(
+	tokenRepo repositories.TokenRepository,
+	analysisService AnalysisService,
+	logger *logrus.Logger,
+) BacktestService {
+	return &backtestService{
+		tokenRepo:       tokenRepo,
+		analysisService: analysisService,
+		logger:          logger,
+	}
+}
+
+// BacktestRequest selects the token, period and recommendation source of a backtest
+type BacktestRequest struct {
+	Token        string    `json:"token" binding:"required"` // mint address or token ID
+	From         time.Time `json:"from"`                     // defaults to 30 days before To
+	To           time.Time `json:"to"`                       // defaults to now
+	Source       string    `json:"source"`                   // replay (default) or recorded
+	Interval     string    `json:"interval"`                 // replay step: 1h (default), 4h or 1d
+	IncludeSteps bool      `json:"include_steps"`
+}
+
+// BacktestResult holds the hypothetical performance of following every call over the period.
+// A buy call opens a position, a sell call closes it and a hold call keeps the current one.
+type BacktestResult struct {
+	TokenID            uuid.UUID       `json:"token_id"`
+	MintAddress        string          `json:"mint_address"`
+	Source             string          `json:"source"`
+	Interval           string          `json:"interval,omitempty"`
+	From               time.Time       `json:"from"`
+	To                 time.Time       `json:"to"`
+	Calls              int             `json:"calls"`
+	Buys               int             `json:"buys"`
+	Sells              int             `json:"sells"`
+	Holds              int             `json:"holds"`
+	Trades             int             `json:"trades"`               // position changes
+	HitRate            float64         `json:"hit_rate"`             // 0-1, buy and sell calls followed by a move in their direction
+	ReturnPercent      float64         `json:"return_percent"`       // following the calls
+	BenchmarkPercent   float64         `json:"benchmark_percent"`    // buying at the start and holding to the end
+	MaxDrawdownPercent float64         `json:"max_drawdown_percent"` // largest peak-to-trough decline while following the calls
+	Weights            *ScoringWeights `json:"weights,omitempty"`    // weights the replayed calls were scored with
+	Notes              []string        `json:"notes,omitempty"`
+	Steps              []BacktestStep  `json:"steps,omitempty"`
+}
+
+// BacktestStep is one call of the backtest and the equity after acting on it
+type BacktestStep struct {
+	Time     time.Time `json:"time"`
+	Price    float64   `json:"price"`
+	Action   string    `json:"action"`
+	Score    float64   `json:"score"`
+	Position bool      `json:"position"` // holding the token after the call
+	Equity   float64   `json:"equity"`   // starts at 1
+}
+
+// Backtest replays the engine's calls over the period and simulates following them
+func (s *backtestService) Backtest(ctx context.Context, req *BacktestRequest) (*BacktestResult, error) {
+	to := req.To
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	from := req.From
+	if from.IsZero() {
+		from = to.Add(-defaultBacktestPeriod)
+	}
+	if !from.Before(to) || to.Sub(from) > maxBacktestPeriod {
+		return nil, ErrInvalidBacktestPeriod
+	}
+
+	token, err := s.resolveToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BacktestResult{
+		TokenID:     token.ID,
+		MintAddress: token.MintAddress,
+		From:        from,
+		To:          to,
+	}
+
+	var steps []BacktestStep
+	switch req.Source {
+	case "", BacktestSourceReplay:
+		result.Source = BacktestSourceReplay
+		result.Interval = req.Interval
+		if result.Interval == "" {
+			result.Interval = "1h"
+		}
+		step, ok := backtestIntervals[result.Interval]
+		if !ok {
+			return nil, ErrInvalidInterval
+		}
+		if to.Sub(from)/step > maxBacktestSteps {
+			return nil, ErrInvalidBacktestPeriod
+		}
+		result.Weights = s.analysisService.GetScoringWeights()
+		steps, err = s.replaySteps(ctx, token.ID, result.Interval, from, to, result.Weights)
+		result.Notes = append(result.Notes, "Replayed calls use the overall score cutoffs only; risk scores depend on data that is not stored historically")
+	case BacktestSourceRecorded:
+		result.Source = BacktestSourceRecorded
+		steps, err = s.recordedSteps(ctx, token.ID, from, to)
+	default:
+		return nil, ErrInvalidBacktestSource
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) < 2 {
+		return nil, ErrInsufficientBacktestData
+	}
+
+	simulate(result, steps)
+	if req.IncludeSteps {
+		result.Steps = steps
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"token_id": token.ID,
+		"source":   result.Source,
+		"calls":    result.Calls,
+		"return":   result.ReturnPercent,
+	}).Info("Recommendation backtest completed")
+
+	return result, nil
+}
+
+func (s *backtestService) resolveToken(ctx context.Context, identifier string) (*models.Token, error) {
+	var token *models.Token
+	var err error
+	if tokenID, parseErr := uuid.Parse(identifier); parseErr == nil {
+		token, err = s.tokenRepo.GetByID(ctx, tokenID)
+	} else {
+		token, err = s.tokenRepo.GetByMintAddress(ctx, identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if token == nil {
+		return nil, ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// replaySteps recomputes a call for every stored candle of the period from the candles before it
+func (s *backtestService) replaySteps(ctx context.Context, tokenID uuid.UUID, resolution string, from, to time.Time, weights *ScoringWeights) ([]BacktestStep, error) {
+	candles, err := s.tokenRepo.GetCandles(ctx, tokenID, resolution, from.Add(-backtestLookback), to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candles: %w", err)
+	}
+
+	// Prefix sums of volume give the 24h volume windows in constant time
+	volumes := make([]float64, len(candles)+1)
+	for i, candle := range candles {
+		volumes[i+1] = volumes[i] + candle.Volume
+	}
+
+	var steps []BacktestStep
+	for i, candle := range candles {
+		if candle.OpenTime.Before(from) {
+			continue
+		}
+
+		data := &models.TokenMarketData{
+			PriceUSD:        candle.Close,
+			PriceChange1h:   priceChange(candles, i, time.Hour),
+			PriceChange24h:  priceChange(candles, i, 24*time.Hour),
+			PriceChange7d:   priceChange(candles, i, 7*24*time.Hour),
+			VolumeChange24h: volumeChange(candles, volumes, i, 24*time.Hour),
+		}
+		_, _, _, overall := marketScores(data, weights)
+		steps = append(steps, BacktestStep{
+			Time:   candle.OpenTime,
+			Price:  candle.Close,
+			Action: recommendAction(overall, weights),
+			Score:  overall,
+		})
+	}
+	return steps, nil
+}
+
+// recordedSteps turns the recommendations issued during the period into steps
+func (s *backtestService) recordedSteps(ctx context.Context, tokenID uuid.UUID, from, to time.Time) ([]BacktestStep, error) {
+	records, err := s.tokenRepo.GetRecommendationRecords(ctx, tokenID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommendation records: %w", err)
+	}
+
+	steps := make([]BacktestStep, 0, len(records))
+	for _, record := range records {
+		if record.PriceUSD <= 0 {
+			continue
+		}
+		steps = append(steps, BacktestStep{
+			Time:   record.CreatedAt,
+			Price:  record.PriceUSD,
+			Action: record.Action,
+			Score:  record.OverallScore,
+		})
+	}
+	return steps, nil
+}
+
+// candleIndexAt returns the index of the last candle opened at or before t, or -1
+func candleIndexAt(candles []*models.TokenCandle, t time.Time) int {
+	return sort.Search(len(candles), func(i int) bool {
+		return candles[i].OpenTime.After(t)
+	}) - 1
+}
+
+// priceChange returns the percent change of the close of candle i over window; 0 if there is no candle that far back
+func priceChange(candles []*models.TokenCandle, i int, window time.Duration) float64 {
+	j := candleIndexAt(candles, candles[i].OpenTime.Add(-window))
+	if j < 0 || j == i || candles[j].Close <= 0 {
+		return 0
+	}
+	return (candles[i].Close - candles[j].Close) / candles[j].Close * 100
+}
+
+// volumeChange returns the percent change of the volume of the window ending at candle i over the window before it
+func volumeChange(candles []*models.TokenCandle, volumes []float64, i int, window time.Duration) float64 {
+	end := candles[i].OpenTime
+	j := candleIndexAt(candles, end.Add(-window))
+	k := candleIndexAt(candles, end.Add(-2*window))
+	if j < 0 || k < 0 {
+		return 0
+	}
+	current := volumes[i+1] - volumes[j+1]
+	previous := volumes[j+1] - volumes[k+1]
+	if previous <= 0 {
+		return 0
+	}
+	return (current - previous) / previous * 100
+}
+
+// simulate follows the calls of steps, starting without a position, and fills in the result's statistics
+func simulate(result *BacktestResult, steps []BacktestStep) {
+	equity, peak := 1.0, 1.0
+	position := false
+	hits, directional := 0, 0
+
+	for i := range steps {
+		if i > 0 && position {
+			equity *= steps[i].Price / steps[i-1].Price
+		}
+		if equity > peak {
+			peak = equity
+		}
+		if drawdown := (peak - equity) / peak * 100; drawdown > result.MaxDrawdownPercent {
+			result.MaxDrawdownPercent = drawdown
+		}
+
+		switch steps[i].Action {
+		case "buy":
+			result.Buys++
+			if !position {
+				result.Trades++
+			}
+			position = true
+		case "sell":
+			result.Sells++
+			if position {
+				result.Trades++
+			}
+			position = false
+		default:
+			result.Holds++
+		}
+
+		// A call is judged by the price move up to the next call
+		if i+1 < len(steps) && steps[i].Action != "hold" {
+			directional++
+			move := steps[i+1].Price - steps[i].Price
+			if steps[i].Action == "buy" && move > 0 || steps[i].Action == "sell" && move < 0 {
+				hits++
+			}
+		}
+
+		steps[i].Position = position
+		steps[i].Equity = equity
+	}
+
+	result.Calls = len(steps)
+	result.ReturnPercent = (equity - 1) * 100
+	result.BenchmarkPercent = (steps[len(steps)-1].Price/steps[0].Price - 1) * 100
+	if directional > 0 {
+		result.HitRate = float64(hits) / float64(directional)
+	}
+}
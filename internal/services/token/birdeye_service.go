@@ -0,0 +1,276 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/ratelimit"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
+)
+
+const (
+	defaultBirdeyeBaseURL = "https://public-api.birdeye.so"
+	defaultBirdeyeTimeout = 10 * time.Second
+	birdeyeTopHolders     = 20 // holders fetched with a token's overview
+)
+
+// Birdeye endpoint classes, each of which may be given its own rate on top of the provider's
+const (
+	birdeyeOverview = "token_overview"
+	birdeyeOHLCV    = "ohlcv"
+	birdeyeHolders  = "holders"
+)
+
+type birdeyeService struct {
+	config     *config.BirdeyeConfig
+	httpClient *http.Client
+	limits     *ratelimit.Registry
+	logger     *logrus.Logger
+}
+
+// NewBirdeyeService creates a market data provider backed by the Birdeye API
+func NewBirdeyeService(config *config.BirdeyeConfig, limits *ratelimit.Registry, usageTracker *usage.Tracker, apiStats *apistats.Registry, logger *logrus.Logger) MarketDataProvider {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultBirdeyeTimeout
+	}
+
+	return &birdeyeService{
+		config:     config,
+		httpClient: &http.Client{Timeout: timeout, Transport: usageTracker.Transport(usage.ProviderBirdeye, apiStats.Transport("birdeye", nil))},
+		limits:     limits,
+		logger:     logger,
+	}
+}
+
+type birdeyeOverviewResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    struct {
+		Address    string `json:"address"`
+		Symbol     string `json:"symbol"`
+		Name       string `json:"name"`
+		LogoURI    string `json:"logoURI"`
+		Extensions struct {
+			Description string `json:"description"`
+			Website     string `json:"website"`
+			Twitter     string `json:"twitter"`
+			Telegram    string `json:"telegram"`
+		} `json:"extensions"`
+		Price                 float64 `json:"price"`
+		PriceChange1hPercent  float64 `json:"priceChange1hPercent"`
+		PriceChange24hPercent float64 `json:"priceChange24hPercent"`
+		Volume24hUSD          float64 `json:"v24hUSD"`
+		Volume24hChange       float64 `json:"v24hChangePercent"`
+		MarketCap             float64 `json:"marketCap"`
+		Liquidity             float64 `json:"liquidity"`
+		Supply                float64 `json:"supply"` // in whole tokens
+		CirculatingSupply     float64 `json:"circulatingSupply"`
+		Holder                int     `json:"holder"`
+		LastTradeUnixTime     int64   `json:"lastTradeUnixTime"`
+	} `json:"data"`
+}
+
+type birdeyeHoldersResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    struct {
+		Items []struct {
+			Owner    string  `json:"owner"`
+			Amount   string  `json:"amount"` // raw amount, not adjusted for decimals
+			UIAmount float64 `json:"ui_amount"`
+		} `json:"items"`
+	} `json:"data"`
+}
+
+type birdeyeOHLCVResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    struct {
+		Items []struct {
+			Open     float64 `json:"o"`
+			High     float64 `json:"h"`
+			Low      float64 `json:"l"`
+			Close    float64 `json:"c"`
+			Volume   float64 `json:"v"`
+			UnixTime int64   `json:"unixTime"`
+		} `json:"items"`
+	} `json:"data"`
+}
+
+// GetTokenInfo fetches a token's overview and top holders. Holders are left out, not failed, when only their
+// request fails.
+func (s *birdeyeService) GetTokenInfo(ctx context.Context, mintAddress string) (*TokenInfoResponse, error) {
+	var overview birdeyeOverviewResponse
+	query := url.Values{"address": {mintAddress}}
+	if err := s.get(ctx, birdeyeOverview, "/defi/token_overview", query, &overview); err != nil {
+		return nil, fmt.Errorf("failed to get token overview: %w", err)
+	}
+	if !overview.Success {
+		return nil, fmt.Errorf("failed to get token overview: %s", overview.Message)
+	}
+
+	data := overview.Data
+	info := TokenInfo{
+		Address:           mintAddress,
+		Symbol:            data.Symbol,
+		Name:              data.Name,
+		LogoURI:           data.LogoURI,
+		Description:       data.Extensions.Description,
+		Website:           data.Extensions.Website,
+		Twitter:           data.Extensions.Twitter,
+		Telegram:          data.Extensions.Telegram,
+		Price:             data.Price,
+		PriceChange1h:     data.PriceChange1hPercent,
+		PriceChange24h:    data.PriceChange24hPercent,
+		Volume24h:         data.Volume24hUSD,
+		VolumeChange24h:   data.Volume24hChange,
+		MarketCap:         data.MarketCap,
+		Liquidity:         data.Liquidity,
+		CirculatingSupply: data.CirculatingSupply,
+		TotalSupply:       data.Supply,
+		HolderCount:       data.Holder,
+	}
+	if data.LastTradeUnixTime > 0 {
+		info.LastUpdated = time.Unix(data.LastTradeUnixTime, 0).UTC().Format(time.RFC3339)
+	}
+
+	holders, err := s.getTopHolders(ctx, mintAddress, data.Supply)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": mintAddress,
+		}).Warn("Failed to get top holders from Birdeye")
+	}
+	info.TopHolders = holders
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"symbol":       info.Symbol,
+	}).Info("Fetched token info from Birdeye")
+
+	return &TokenInfoResponse{Data: info}, nil
+}
+
+// getTopHolders fetches the largest holders; supply, in whole tokens, gives their share
+func (s *birdeyeService) getTopHolders(ctx context.Context, mintAddress string, supply float64) ([]TokenTopHolder, error) {
+	var response birdeyeHoldersResponse
+	query := url.Values{
+		"address": {mintAddress},
+		"offset":  {"0"},
+		"limit":   {strconv.Itoa(birdeyeTopHolders)},
+	}
+	if err := s.get(ctx, birdeyeHolders, "/defi/v3/token/holder", query, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("Birdeye error: %s", response.Message)
+	}
+
+	holders := make([]TokenTopHolder, 0, len(response.Data.Items))
+	for i, item := range response.Data.Items {
+		balance, err := strconv.ParseFloat(item.Amount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holder amount %q: %w", item.Amount, err)
+		}
+		holder := TokenTopHolder{
+			Address: item.Owner,
+			Balance: balance,
+			Rank:    i + 1,
+		}
+		if supply > 0 {
+			holder.Percentage = item.UIAmount / supply * 100
+		}
+		holders = append(holders, holder)
+	}
+	return holders, nil
+}
+
+// GetChart fetches OHLCV candles of a token between from and to; candleType is e.g. 1m, 5m, 1h
+func (s *birdeyeService) GetChart(ctx context.Context, mintAddress, candleType string, from, to time.Time) (*ChartResponse, error) {
+	var response birdeyeOHLCVResponse
+	query := url.Values{
+		"address":   {mintAddress},
+		"type":      {birdeyeCandleType(candleType)},
+		"time_from": {strconv.FormatInt(from.Unix(), 10)},
+		"time_to":   {strconv.FormatInt(to.Unix(), 10)},
+	}
+	if err := s.get(ctx, birdeyeOHLCV, "/defi/ohlcv", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to get chart: %w", err)
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("failed to get chart: %s", response.Message)
+	}
+
+	candles := make([]ChartCandle, 0, len(response.Data.Items))
+	for _, item := range response.Data.Items {
+		candles = append(candles, ChartCandle{
+			Open:   item.Open,
+			Close:  item.Close,
+			Low:    item.Low,
+			High:   item.High,
+			Volume: item.Volume,
+			Time:   item.UnixTime,
+		})
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"type":         candleType,
+		"count":        len(candles),
+	}).Debug("Fetched chart from Birdeye")
+
+	return &ChartResponse{Candles: candles}, nil
+}
+
+// birdeyeCandleType converts a candle type such as 1h or 1d to Birdeye's, which writes hours, days and weeks
+// in upper case
+func birdeyeCandleType(candleType string) string {
+	if strings.HasSuffix(candleType, "m") {
+		return candleType
+	}
+	return strings.ToUpper(candleType)
+}
+
+// get calls a Birdeye endpoint of the given class and decodes its response
+func (s *birdeyeService) get(ctx context.Context, class, path string, query url.Values, response interface{}) error {
+	if err := s.limits.Wait(ctx, usage.ProviderBirdeye, class); err != nil {
+		return err
+	}
+
+	baseURL := s.config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBirdeyeBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-KEY", s.config.APIKey)
+	req.Header.Set("x-chain", "solana")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "solana-wallet-service/1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Birdeye returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package token
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// SolanaTracker lists a Pump.fun bonding curve as a pool of this market; other markets of the same token are
+// the AMM pools its liquidity migrated to
+const pumpFunMarket = "pumpfun"
+
+// A bonding curve is complete, and the token graduates, once all of it has been sold
+const bondingCurveComplete = 100.0
+
+// bondingCurveState reads a token's Pump.fun launch stage from its pools; stage is empty for tokens without a
+// Pump.fun bonding curve
+func bondingCurveState(pools []TokenPool) (stage models.BondingCurveStage, progress float64, graduatedTo string) {
+	var curve *TokenPool
+	for i := range pools {
+		if strings.EqualFold(pools[i].Market, pumpFunMarket) {
+			curve = &pools[i]
+			break
+		}
+	}
+	if curve == nil {
+		return "", 0, ""
+	}
+
+	progress = math.Min(math.Max(curve.CurvePercentage, 0), bondingCurveComplete)
+	for _, pool := range pools {
+		if !strings.EqualFold(pool.Market, pumpFunMarket) && pool.Market != "" {
+			graduatedTo = pool.Market
+			break
+		}
+	}
+	if graduatedTo == "" && progress < bondingCurveComplete {
+		return models.BondingCurveStageBonding, progress, ""
+	}
+	return models.BondingCurveStageGraduated, bondingCurveComplete, graduatedTo
+}
+
+// updateBondingCurve stores the token's launch stage and tells graduation listeners when a token that was
+// seen on its bonding curve has graduated. Tokens first seen already graduated are stored without an alert.
+func (s *marketService) updateBondingCurve(ctx context.Context, token *models.Token, pools []TokenPool) {
+	stage, progress, graduatedTo := bondingCurveState(pools)
+	if stage == "" {
+		return
+	}
+	if stage == token.BondingCurveStage && progress == token.BondingCurveProgress && graduatedTo == token.GraduatedTo {
+		return
+	}
+
+	graduating := token.BondingCurveStage == models.BondingCurveStageBonding && stage == models.BondingCurveStageGraduated
+	token.BondingCurveStage = stage
+	token.BondingCurveProgress = progress
+	token.GraduatedTo = graduatedTo
+	if stage == models.BondingCurveStageGraduated && token.GraduatedAt == nil {
+		now := time.Now()
+		token.GraduatedAt = &now
+	}
+
+	if err := s.tokenRepo.Update(ctx, token); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": token.MintAddress,
+		}).Warn("Failed to update bonding curve state")
+		return
+	}
+	if !graduating {
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": token.MintAddress,
+		"symbol":       token.Symbol,
+		"graduated_to": graduatedTo,
+	}).Info("Token graduated from its bonding curve")
+
+	s.listenersMu.RLock()
+	listeners := s.graduationListeners
+	s.listenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(ctx, token)
+	}
+}
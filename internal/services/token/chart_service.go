@@ -0,0 +1,281 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/pkg/clock"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+var (
+	ErrTokenNotFound   = errors.New("token not found")
+	ErrInvalidInterval = errors.New("invalid chart interval")
+)
+
+const (
+	DefaultChartPoints = 200
+	MaxChartPoints     = 1000
+	minChartPoints     = 3
+)
+
+// chartInterval maps a chart window to the candle resolution it is built from
+type chartInterval struct {
+	window     time.Duration
+	resolution string
+	step       time.Duration
+}
+
+var chartIntervals = map[string]chartInterval{
+	"1h":  {window: time.Hour, resolution: "1m", step: time.Minute},
+	"24h": {window: 24 * time.Hour, resolution: "5m", step: 5 * time.Minute},
+	"7d":  {window: 7 * 24 * time.Hour, resolution: "1h", step: time.Hour},
+	"30d": {window: 30 * 24 * time.Hour, resolution: "4h", step: 4 * time.Hour},
+	"1y":  {window: 365 * 24 * time.Hour, resolution: "1d", step: 24 * time.Hour},
+}
+
+// ChartService serves downsampled price and volume series built from stored candles
+type ChartService interface {
+	GetChart(ctx context.Context, tokenID uuid.UUID, interval string, points int) (*TokenChart, error)
+}
+
+type chartService struct {
+	tokenRepo            repositories.TokenRepository
+	marketData           MarketDataProvider
+	cache                *redis.Client // optional
+	clock                clock.Clock
+	logger               *logrus.Logger
+}
+
+// NewChartService creates a new chart service instance; cache may be nil
+func NewChartService(
+	tokenRepo repositories.TokenRepository,
+	marketData MarketDataProvider,
+	cache *redis.Client,
+	clk clock.Clock,
+	logger *logrus.Logger,
+) ChartService {
+	return &chartService{
+		tokenRepo:            tokenRepo,
+		marketData:           marketData,
+		cache:                cache,
+		clock:                clk,
+		logger:               logger,
+	}
+}
+
+// TokenChart is a price/volume series ready for frontend charting
+type TokenChart struct {
+	TokenID     uuid.UUID    `json:"token_id"`
+	MintAddress string       `json:"mint_address"`
+	Interval    string       `json:"interval"`
+	Resolution  string       `json:"resolution"` // resolution of the underlying candles
+	Candles     int          `json:"candles"`    // number of candles the series was computed from
+	Points      []ChartPoint `json:"points"`
+	GeneratedAt time.Time    `json:"generated_at"`
+}
+
+// ChartPoint is one sample of a chart; Volume covers every candle since the previous point
+type ChartPoint struct {
+	Time   time.Time `json:"time"`
+	Price  float64   `json:"price"`
+	Volume float64   `json:"volume"`
+}
+
+// GetChart returns the token's chart over interval (1h, 24h, 7d, 30d, 1y) with at most points samples
+func (s *chartService) GetChart(ctx context.Context, tokenID uuid.UUID, interval string, points int) (*TokenChart, error) {
+	spec, ok := chartIntervals[interval]
+	if !ok {
+		return nil, ErrInvalidInterval
+	}
+	if points < minChartPoints || points > MaxChartPoints {
+		points = DefaultChartPoints
+	}
+
+	cacheKey := fmt.Sprintf("token_chart:%s:%s:%d", tokenID, interval, points)
+	if s.cache != nil {
+		var cached TokenChart
+		err := s.cache.GetJSON(ctx, cacheKey, &cached)
+		if err == nil {
+			return &cached, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			s.logger.WithFields(logrus.Fields{
+				"error": err,
+				"key":   cacheKey,
+			}).Warn("Failed to read chart cache")
+		}
+	}
+
+	token, err := s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if token == nil {
+		return nil, ErrTokenNotFound
+	}
+
+	now := s.clock.Now().UTC()
+	from := now.Add(-spec.window)
+
+	candles, err := s.tokenRepo.GetCandles(ctx, tokenID, spec.resolution, from, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candles: %w", err)
+	}
+
+	// Fetch missing recent candles; a failed refresh still serves whatever is stored
+	if len(candles) == 0 || now.Sub(candles[len(candles)-1].OpenTime) > 2*spec.step {
+		fetchFrom := from
+		if len(candles) > 0 {
+			fetchFrom = candles[len(candles)-1].OpenTime
+		}
+		if err := s.syncCandles(ctx, token, spec.resolution, fetchFrom, now); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":      err,
+				"token":      token.MintAddress,
+				"resolution": spec.resolution,
+			}).Warn("Failed to sync token candles")
+		} else if candles, err = s.tokenRepo.GetCandles(ctx, tokenID, spec.resolution, from, now); err != nil {
+			return nil, fmt.Errorf("failed to get candles: %w", err)
+		}
+	}
+
+	chart := &TokenChart{
+		TokenID:     tokenID,
+		MintAddress: token.MintAddress,
+		Interval:    interval,
+		Resolution:  spec.resolution,
+		Candles:     len(candles),
+		Points:      downsampleCandles(candles, points),
+		GeneratedAt: now,
+	}
+
+	if s.cache != nil {
+		if err := s.cache.SetJSON(ctx, cacheKey, chart, spec.step); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err,
+				"key":   cacheKey,
+			}).Warn("Failed to write chart cache")
+		}
+	}
+
+	return chart, nil
+}
+
+// syncCandles stores the token's candles between from and to as reported by the market data provider
+func (s *chartService) syncCandles(ctx context.Context, token *models.Token, resolution string, from, to time.Time) error {
+	response, err := s.marketData.GetChart(ctx, token.MintAddress, resolution, from, to)
+	if err != nil {
+		return err
+	}
+
+	candles := make([]*models.TokenCandle, 0, len(response.Candles))
+	for _, c := range response.Candles {
+		candles = append(candles, &models.TokenCandle{
+			TokenID:    token.ID,
+			Resolution: resolution,
+			OpenTime:   time.Unix(c.Time, 0).UTC(),
+			Open:       c.Open,
+			High:       c.High,
+			Low:        c.Low,
+			Close:      c.Close,
+			Volume:     c.Volume,
+		})
+	}
+
+	if err := s.tokenRepo.SaveCandles(ctx, candles); err != nil {
+		return fmt.Errorf("failed to save candles: %w", err)
+	}
+	return nil
+}
+
+// downsampleCandles picks at most threshold closing prices with LTTB and sums the
+// volume of the candles each picked point stands for
+func downsampleCandles(candles []*models.TokenCandle, threshold int) []ChartPoint {
+	xs := make([]float64, len(candles))
+	ys := make([]float64, len(candles))
+	for i, candle := range candles {
+		xs[i] = float64(candle.OpenTime.Unix())
+		ys[i] = candle.Close
+	}
+
+	selected := lttb(xs, ys, threshold)
+	points := make([]ChartPoint, 0, len(selected))
+	prev := -1
+	for _, idx := range selected {
+		volume := 0.0
+		for j := prev + 1; j <= idx; j++ {
+			volume += candles[j].Volume
+		}
+		points = append(points, ChartPoint{
+			Time:   candles[idx].OpenTime,
+			Price:  candles[idx].Close,
+			Volume: volume,
+		})
+		prev = idx
+	}
+	return points
+}
+
+// lttb returns the indices kept by the Largest-Triangle-Three-Buckets algorithm.
+// The first and last points are always kept; short series are returned whole.
+func lttb(xs, ys []float64, threshold int) []int {
+	n := len(xs)
+	if threshold >= n || threshold < minChartPoints {
+		selected := make([]int, n)
+		for i := range selected {
+			selected[i] = i
+		}
+		return selected
+	}
+
+	selected := make([]int, 0, threshold)
+	selected = append(selected, 0)
+
+	bucketSize := float64(n-2) / float64(threshold-2)
+	a := 0
+	for i := 0; i < threshold-2; i++ {
+		// Average of the next bucket is the third triangle vertex
+		avgStart := int(float64(i+1)*bucketSize) + 1
+		avgEnd := int(float64(i+2)*bucketSize) + 1
+		if avgEnd > n {
+			avgEnd = n
+		}
+		if avgStart >= avgEnd {
+			avgStart = avgEnd - 1
+		}
+		avgX, avgY := 0.0, 0.0
+		for j := avgStart; j < avgEnd; j++ {
+			avgX += xs[j]
+			avgY += ys[j]
+		}
+		count := float64(avgEnd - avgStart)
+		avgX /= count
+		avgY /= count
+
+		// Keep the point of the current bucket forming the largest triangle
+		rangeStart := int(float64(i)*bucketSize) + 1
+		rangeEnd := int(float64(i+1)*bucketSize) + 1
+		maxArea := -1.0
+		next := rangeStart
+		for j := rangeStart; j < rangeEnd; j++ {
+			area := math.Abs((xs[a]-avgX)*(ys[j]-ys[a]) - (xs[a]-xs[j])*(avgY-ys[a]))
+			if area > maxArea {
+				maxArea = area
+				next = j
+			}
+		}
+
+		selected = append(selected, next)
+		a = next
+	}
+
+	return append(selected, n-1)
+}
@@ -0,0 +1,251 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+// Pre-screen thresholds for newly listed tokens
+const (
+	discoveryHighRiskLiquidityUSD   = 5000
+	discoveryMediumRiskLiquidityUSD = 20000
+	discoveryMinHolders             = 50
+	discoveryMaxMarketCapLiquidity  = 50 // market cap to liquidity ratio above which the pool is considered thin
+)
+
+// Buffered discoveries per stream subscriber; slower subscribers miss discoveries rather than block polling
+const discoverySubscriberBuffer = 64
+
+// DiscoveryService picks up newly listed tokens from the latest-tokens feed, pre-screens their risk
+// and streams them to subscribers
+type DiscoveryService interface {
+	PollLatestTokens(ctx context.Context) (int, error)
+	ListNewTokens(ctx context.Context, riskLevel models.TokenRiskLevel, limit, offset int) ([]*models.TokenDiscovery, error)
+	Subscribe() (<-chan *models.TokenDiscovery, func())
+}
+
+type discoveryService struct {
+	tokenRepo            repositories.TokenRepository
+	marketService        MarketService
+	solanaTrackerService SolanaTrackerService
+	logger               *logrus.Logger
+
+	subscribersMu sync.RWMutex
+	subscribers   map[chan *models.TokenDiscovery]struct{}
+}
+
+// NewDiscoveryService creates a new token discovery service instance
+func NewDiscoveryService(
+	tokenRepo repositories.TokenRepository,
+	marketService MarketService,
+	solanaTrackerService SolanaTrackerService,
+	logger *logrus.Logger,
+) DiscoveryService {
+	return &discoveryService{
+		tokenRepo:            tokenRepo,
+		marketService:        marketService,
+		solanaTrackerService: solanaTrackerService,
+		logger:               logger,
+		subscribers:          make(map[chan *models.TokenDiscovery]struct{}),
+	}
+}
+
+// PollLatestTokens records the feed's mints that were not discovered before and returns how many were new
+func (s *discoveryService) PollLatestTokens(ctx context.Context) (int, error) {
+	latest, err := s.solanaTrackerService.GetLatestTokens(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest tokens from SolanaTracker: %w", err)
+	}
+
+	mintAddresses := make([]string, len(latest.Data))
+	for i, item := range latest.Data {
+		mintAddresses[i] = item.Address
+	}
+	discovered, err := s.tokenRepo.GetDiscoveredMints(ctx, mintAddresses)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get discovered mints: %w", err)
+	}
+	seen := make(map[string]bool, len(discovered))
+	for _, mint := range discovered {
+		seen[mint] = true
+	}
+
+	flags, err := s.tokenRepo.GetActiveFlags(ctx, mintAddresses)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get token flags: %w", err)
+	}
+	flagged := make(map[string]*models.TokenFlag, len(flags))
+	for _, flag := range flags {
+		flagged[flag.MintAddress] = flag
+	}
+
+	added := 0
+	for _, item := range latest.Data {
+		if seen[item.Address] {
+			continue
+		}
+		seen[item.Address] = true // the feed may repeat a mint
+
+		discovery, err := s.record(ctx, item, flagged[item.Address])
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": item.Address,
+			}).Warn("Failed to record discovered token")
+			continue
+		}
+		if discovery == nil {
+			continue // discovered concurrently by another poll
+		}
+
+		added++
+		s.publish(discovery)
+	}
+
+	if added > 0 {
+		s.logger.WithField("count", added).Info("New tokens discovered")
+	}
+	return added, nil
+}
+
+// record stores the token, its listing market data and the discovery; it returns nil if the mint was already discovered
+func (s *discoveryService) record(ctx context.Context, item LatestToken, flag *models.TokenFlag) (*models.TokenDiscovery, error) {
+	token, err := s.marketService.CreateToken(ctx, &CreateTokenRequest{
+		MintAddress: item.Address,
+		Symbol:      item.Symbol,
+		Name:        item.Name,
+		Decimals:    9, // Default for most SPL tokens
+		LogoURI:     models.NullableString(item.LogoURI),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	if item.Price > 0 {
+		marketData := &models.TokenMarketData{
+			Price:       item.Price,
+			PriceUSD:    item.Price,
+			MarketCap:   item.MarketCap,
+			HolderCount: item.HolderCount,
+			LastUpdated: time.Now(),
+		}
+		if err := s.marketService.UpdateMarketData(ctx, token.ID, marketData); err != nil {
+			return nil, fmt.Errorf("failed to save market data: %w", err)
+		}
+	}
+
+	level, reasons := prescreen(item, flag)
+	reasonsJSON, err := json.Marshal(reasons)
+	if err != nil {
+		return nil, err
+	}
+
+	discovery := &models.TokenDiscovery{
+		TokenID:      token.ID,
+		MintAddress:  item.Address,
+		PriceUSD:     item.Price,
+		MarketCapUSD: item.MarketCap,
+		LiquidityUSD: item.Liquidity,
+		HolderCount:  item.HolderCount,
+		ListedAt:     parseListedAt(item.CreatedAt),
+		RiskLevel:    level,
+		RiskReasons:  string(reasonsJSON),
+	}
+	created, err := s.tokenRepo.CreateDiscovery(ctx, discovery)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, nil
+	}
+	discovery.Token = *token
+	return discovery, nil
+}
+
+// prescreen rates a new listing from the feed's own numbers and the flag registry, without further lookups
+func prescreen(item LatestToken, flag *models.TokenFlag) (models.TokenRiskLevel, []string) {
+	level := models.TokenRiskLow
+	reasons := []string{}
+	raise := func(to models.TokenRiskLevel, reason string) {
+		if to == models.TokenRiskHigh || level == models.TokenRiskLow {
+			level = to
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if flag != nil {
+		raise(models.TokenRiskHigh, fmt.Sprintf("flagged as %s", flag.Type))
+	}
+	switch {
+	case item.Liquidity < discoveryHighRiskLiquidityUSD:
+		raise(models.TokenRiskHigh, fmt.Sprintf("liquidity below $%d", discoveryHighRiskLiquidityUSD))
+	case item.Liquidity < discoveryMediumRiskLiquidityUSD:
+		raise(models.TokenRiskMedium, fmt.Sprintf("liquidity below $%d", discoveryMediumRiskLiquidityUSD))
+	}
+	if item.HolderCount < discoveryMinHolders {
+		raise(models.TokenRiskMedium, fmt.Sprintf("fewer than %d holders", discoveryMinHolders))
+	}
+	if item.Liquidity > 0 && item.MarketCap/item.Liquidity > discoveryMaxMarketCapLiquidity {
+		raise(models.TokenRiskMedium, fmt.Sprintf("market cap over %dx liquidity", discoveryMaxMarketCapLiquidity))
+	}
+	return level, reasons
+}
+
+// parseListedAt reads the feed's creation time, given either as RFC 3339 or unix milliseconds
+func parseListedAt(raw string) *time.Time {
+	if raw == "" {
+		return nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t
+	}
+	if ms, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		t := time.UnixMilli(ms)
+		return &t
+	}
+	return nil
+}
+
+func (s *discoveryService) ListNewTokens(ctx context.Context, riskLevel models.TokenRiskLevel, limit, offset int) ([]*models.TokenDiscovery, error) {
+	return s.tokenRepo.ListDiscoveries(ctx, riskLevel, limit, offset)
+}
+
+// Subscribe streams discoveries made after the call; the returned function unsubscribes and closes the channel
+func (s *discoveryService) Subscribe() (<-chan *models.TokenDiscovery, func()) {
+	ch := make(chan *models.TokenDiscovery, discoverySubscriberBuffer)
+
+	s.subscribersMu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subscribersMu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.subscribersMu.Lock()
+			delete(s.subscribers, ch)
+			s.subscribersMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (s *discoveryService) publish(discovery *models.TokenDiscovery) {
+	s.subscribersMu.RLock()
+	defer s.subscribersMu.RUnlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- discovery:
+		default:
+			s.logger.WithField("mint_address", discovery.MintAddress).Debug("Discovery subscriber is behind, dropping token")
+		}
+	}
+}
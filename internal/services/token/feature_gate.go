@@ -0,0 +1,54 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/services/feature"
+)
+
+// NewFlaggedMarketDataProvider serves the provider while the feature flag is on and fails with
+// feature.ErrFeatureDisabled otherwise, so a fallback provider takes over without a redeploy
+func NewFlaggedMarketDataProvider(provider MarketDataProvider, flags feature.FlagService, flag feature.Flag) MarketDataProvider {
+	return &flaggedMarketDataProvider{provider: provider, flags: flags, flag: flag}
+}
+
+type flaggedMarketDataProvider struct {
+	provider MarketDataProvider
+	flags    feature.FlagService
+	flag     feature.Flag
+}
+
+func (p *flaggedMarketDataProvider) GetTokenInfo(ctx context.Context, mintAddress string) (*TokenInfoResponse, error) {
+	if !p.flags.Enabled(ctx, p.flag, "") {
+		return nil, fmt.Errorf("%w: %s", feature.ErrFeatureDisabled, p.flag)
+	}
+	return p.provider.GetTokenInfo(ctx, mintAddress)
+}
+
+func (p *flaggedMarketDataProvider) GetChart(ctx context.Context, mintAddress, candleType string, from, to time.Time) (*ChartResponse, error) {
+	if !p.flags.Enabled(ctx, p.flag, "") {
+		return nil, fmt.Errorf("%w: %s", feature.ErrFeatureDisabled, p.flag)
+	}
+	return p.provider.GetChart(ctx, mintAddress, candleType, from, to)
+}
+
+// NewFlaggedPoolsProvider serves the pools provider while the feature flag is on and fails with
+// feature.ErrFeatureDisabled otherwise
+func NewFlaggedPoolsProvider(provider PoolsProvider, flags feature.FlagService, flag feature.Flag) PoolsProvider {
+	return &flaggedPoolsProvider{provider: provider, flags: flags, flag: flag}
+}
+
+type flaggedPoolsProvider struct {
+	provider PoolsProvider
+	flags    feature.FlagService
+	flag     feature.Flag
+}
+
+func (p *flaggedPoolsProvider) GetPools(ctx context.Context, mintAddress string) ([]TokenPool, error) {
+	if !p.flags.Enabled(ctx, p.flag, "") {
+		return nil, fmt.Errorf("%w: %s", feature.ErrFeatureDisabled, p.flag)
+	}
+	return p.provider.GetPools(ctx, mintAddress)
+}
@@ -0,0 +1,200 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+var (
+	ErrInvalidFlagType = errors.New("invalid token flag type")
+	ErrFlagNotFound    = errors.New("token flag not found")
+)
+
+// Maximum number of history entries returned with a flag
+const flagHistoryLimit = 50
+
+// FlagService maintains the scam/honeypot flag registry of token mints
+type FlagService interface {
+	FlagToken(ctx context.Context, mintAddress string, req *FlagTokenRequest) (*models.TokenFlag, error)
+	ClearFlag(ctx context.Context, mintAddress string, req *ClearFlagRequest) (*models.TokenFlag, error)
+	FlagFromHeuristic(ctx context.Context, mintAddress string, flagType models.TokenFlagType, reason string) error
+	GetFlag(ctx context.Context, mintAddress string) (*TokenFlagResult, error)
+	GetActiveFlag(ctx context.Context, mintAddress string) (*models.TokenFlag, error)
+	ListFlags(ctx context.Context, activeOnly bool, limit, offset int) ([]*models.TokenFlag, error)
+}
+
+type flagService struct {
+	tokenRepo repositories.TokenRepository
+	logger    *logrus.Logger
+}
+
+// NewFlagService creates a new token flag service instance
+func NewFlagService(tokenRepo repositories.TokenRepository, logger *logrus.Logger) FlagService {
+	return &flagService{
+		tokenRepo: tokenRepo,
+		logger:    logger,
+	}
+}
+
+// Request structures
+type FlagTokenRequest struct {
+	Type      models.TokenFlagType `json:"type" binding:"required"`
+	Reason    string               `json:"reason" binding:"required"`
+	FlaggedBy string               `json:"flagged_by"`
+}
+
+type ClearFlagRequest struct {
+	Reason    string `json:"reason"`
+	ClearedBy string `json:"cleared_by"`
+}
+
+// TokenFlagResult is a token's flag together with its reason history, newest first
+type TokenFlagResult struct {
+	Flag    *models.TokenFlag        `json:"flag"`
+	History []*models.TokenFlagEvent `json:"history"`
+}
+
+func (s *flagService) FlagToken(ctx context.Context, mintAddress string, req *FlagTokenRequest) (*models.TokenFlag, error) {
+	if !req.Type.IsValid() {
+		return nil, ErrInvalidFlagType
+	}
+
+	flag, err := s.save(ctx, mintAddress, models.TokenFlagActionFlagged, req.Type, req.Reason, models.TokenFlagSourceAdmin, req.FlaggedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"type":         flag.Type,
+		"flagged_by":   req.FlaggedBy,
+	}).Info("Token flagged")
+
+	return flag, nil
+}
+
+func (s *flagService) ClearFlag(ctx context.Context, mintAddress string, req *ClearFlagRequest) (*models.TokenFlag, error) {
+	existing, err := s.tokenRepo.GetFlag(ctx, mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token flag: %w", err)
+	}
+	if existing == nil || !existing.Active {
+		return nil, ErrFlagNotFound
+	}
+
+	flag, err := s.save(ctx, mintAddress, models.TokenFlagActionCleared, existing.Type, req.Reason, models.TokenFlagSourceAdmin, req.ClearedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"type":         flag.Type,
+		"cleared_by":   req.ClearedBy,
+	}).Info("Token flag cleared")
+
+	return flag, nil
+}
+
+// FlagFromHeuristic raises an automated flag; it never overrides a flag an admin has set or cleared
+func (s *flagService) FlagFromHeuristic(ctx context.Context, mintAddress string, flagType models.TokenFlagType, reason string) error {
+	existing, err := s.tokenRepo.GetFlag(ctx, mintAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get token flag: %w", err)
+	}
+	if existing != nil && (existing.Source == models.TokenFlagSourceAdmin || existing.Active) {
+		return nil
+	}
+
+	if _, err := s.save(ctx, mintAddress, models.TokenFlagActionFlagged, flagType, reason, models.TokenFlagSourceHeuristic, ""); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"type":         flagType,
+		"reason":       reason,
+	}).Warn("Token flagged by heuristic")
+
+	return nil
+}
+
+// GetFlag returns ErrFlagNotFound if the token has never been flagged
+func (s *flagService) GetFlag(ctx context.Context, mintAddress string) (*TokenFlagResult, error) {
+	flag, err := s.tokenRepo.GetFlag(ctx, mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token flag: %w", err)
+	}
+	if flag == nil {
+		return nil, ErrFlagNotFound
+	}
+
+	history, err := s.tokenRepo.GetFlagHistory(ctx, mintAddress, flagHistoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token flag history: %w", err)
+	}
+
+	return &TokenFlagResult{
+		Flag:    flag,
+		History: history,
+	}, nil
+}
+
+// GetActiveFlag returns nil if the token is not currently flagged
+func (s *flagService) GetActiveFlag(ctx context.Context, mintAddress string) (*models.TokenFlag, error) {
+	return activeFlag(ctx, s.tokenRepo, mintAddress)
+}
+
+func (s *flagService) ListFlags(ctx context.Context, activeOnly bool, limit, offset int) ([]*models.TokenFlag, error) {
+	return s.tokenRepo.ListFlags(ctx, activeOnly, limit, offset)
+}
+
+// save records a flag change on the token's current flag and appends it to the history
+func (s *flagService) save(ctx context.Context, mintAddress string, action models.TokenFlagAction, flagType models.TokenFlagType, reason string, source models.TokenFlagSource, actor string) (*models.TokenFlag, error) {
+	flag, err := s.tokenRepo.GetFlag(ctx, mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token flag: %w", err)
+	}
+	if flag == nil {
+		flag = &models.TokenFlag{MintAddress: mintAddress}
+	}
+
+	flag.Type = flagType
+	flag.Source = source
+	flag.FlaggedBy = actor
+	flag.Active = action == models.TokenFlagActionFlagged
+	if flag.Active {
+		flag.Reason = reason
+	}
+
+	event := &models.TokenFlagEvent{
+		MintAddress: mintAddress,
+		Action:      action,
+		Type:        flagType,
+		Reason:      reason,
+		Source:      source,
+		Actor:       actor,
+	}
+
+	if err := s.tokenRepo.SaveFlag(ctx, flag, event); err != nil {
+		return nil, fmt.Errorf("failed to save token flag: %w", err)
+	}
+	return flag, nil
+}
+
+// activeFlag returns the token's flag if it is active, otherwise nil
+func activeFlag(ctx context.Context, tokenRepo repositories.TokenRepository, mintAddress string) (*models.TokenFlag, error) {
+	flag, err := tokenRepo.GetFlag(ctx, mintAddress)
+	if err != nil {
+		return nil, err
+	}
+	if flag == nil || !flag.Active {
+		return nil, nil
+	}
+	return flag, nil
+}
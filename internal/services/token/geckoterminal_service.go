@@ -0,0 +1,168 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/ratelimit"
+)
+
+const (
+	defaultGeckoTerminalBaseURL = "https://api.geckoterminal.com/api/v2"
+	defaultGeckoTerminalTimeout = 10 * time.Second
+	geckoTerminalAPIVersion     = "application/json;version=20230302"
+)
+
+// GeckoTerminal endpoint classes, each of which may be given its own rate on top of the provider's
+const geckoTerminalTokenPools = "token_pools"
+
+// PoolsProvider serves the pools a token trades in with their prices, liquidity and volume
+type PoolsProvider interface {
+	// GetPools lists the token's pools, most liquid first
+	GetPools(ctx context.Context, mintAddress string) ([]TokenPool, error)
+}
+
+type geckoTerminalService struct {
+	config     *config.GeckoTerminalConfig
+	httpClient *http.Client
+	limits     *ratelimit.Registry
+	logger     *logrus.Logger
+}
+
+// NewGeckoTerminalService creates a pools provider backed by the GeckoTerminal API
+func NewGeckoTerminalService(config *config.GeckoTerminalConfig, limits *ratelimit.Registry, apiStats *apistats.Registry, logger *logrus.Logger) PoolsProvider {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultGeckoTerminalTimeout
+	}
+
+	return &geckoTerminalService{
+		config:     config,
+		httpClient: &http.Client{Timeout: timeout, Transport: apiStats.Transport("geckoterminal", nil)},
+		limits:     limits,
+		logger:     logger,
+	}
+}
+
+// GeckoTerminal prefixes IDs with the network and sends figures as decimal strings
+type geckoTerminalPoolsResponse struct {
+	Data []struct {
+		Attributes struct {
+			Address            string `json:"address"`
+			BaseTokenPriceUSD  string `json:"base_token_price_usd"`
+			QuoteTokenPriceUSD string `json:"quote_token_price_usd"`
+			ReserveUSD         string `json:"reserve_in_usd"`
+			VolumeUSD          struct {
+				H24 string `json:"h24"`
+			} `json:"volume_usd"`
+			PoolCreatedAt string `json:"pool_created_at"`
+		} `json:"attributes"`
+		Relationships struct {
+			BaseToken  geckoTerminalRelation `json:"base_token"`
+			QuoteToken geckoTerminalRelation `json:"quote_token"`
+			Dex        geckoTerminalRelation `json:"dex"`
+		} `json:"relationships"`
+	} `json:"data"`
+}
+
+type geckoTerminalRelation struct {
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// GetPools fetches the first page of the token's pools from GeckoTerminal, which holds its top pools
+func (s *geckoTerminalService) GetPools(ctx context.Context, mintAddress string) ([]TokenPool, error) {
+	if err := s.limits.Wait(ctx, ratelimit.ProviderGeckoTerminal, geckoTerminalTokenPools); err != nil {
+		return nil, err
+	}
+
+	baseURL := s.config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultGeckoTerminalBaseURL
+	}
+	url := fmt.Sprintf("%s/networks/solana/tokens/%s/pools", baseURL, mintAddress)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", geckoTerminalAPIVersion)
+	req.Header.Set("User-Agent", "solana-wallet-service/1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GeckoTerminal returned status %d", resp.StatusCode)
+	}
+	var response geckoTerminalPoolsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	pools := make([]TokenPool, 0, len(response.Data))
+	for _, item := range response.Data {
+		attributes := item.Attributes
+		pool := TokenPool{
+			PoolID:       attributes.Address,
+			Market:       geckoTerminalID(item.Relationships.Dex.Data.ID),
+			LiquidityUSD: parseGeckoTerminalFloat(attributes.ReserveUSD),
+			Volume24hUSD: parseGeckoTerminalFloat(attributes.VolumeUSD.H24),
+		}
+		// The token may be either side of the pair
+		if geckoTerminalID(item.Relationships.QuoteToken.Data.ID) == mintAddress {
+			pool.PriceUSD = parseGeckoTerminalFloat(attributes.QuoteTokenPriceUSD)
+		} else {
+			pool.PriceUSD = parseGeckoTerminalFloat(attributes.BaseTokenPriceUSD)
+		}
+		if createdAt, err := time.Parse(time.RFC3339, attributes.PoolCreatedAt); err == nil {
+			pool.CreatedAt = createdAt.UnixMilli()
+		}
+		pools = append(pools, pool)
+	}
+	sort.SliceStable(pools, func(i, j int) bool {
+		return pools[i].LiquidityUSD > pools[j].LiquidityUSD
+	})
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"count":        len(pools),
+	}).Debug("Fetched token pools from GeckoTerminal")
+
+	return pools, nil
+}
+
+// geckoTerminalID strips the network prefix from a GeckoTerminal ID, as in solana_<address>
+func geckoTerminalID(id string) string {
+	return strings.TrimPrefix(id, "solana_")
+}
+
+// parseGeckoTerminalFloat reads a decimal string, treating a missing value as zero
+func parseGeckoTerminalFloat(value string) float64 {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// TotalLiquidityUSD sums the liquidity of the pools
+func TotalLiquidityUSD(pools []TokenPool) float64 {
+	var total float64
+	for _, pool := range pools {
+		total += pool.LiquidityUSD
+	}
+	return total
+}
@@ -0,0 +1,187 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// Share of supply, in percent, from which a holder counts as a whale
+const whaleHolderPercentage = 1.0
+
+// Risk assessment warns when the top holders' share of supply fell by at least this many percentage
+// points over the window
+const (
+	holderOutflowWarningPercent = 5.0
+	holderOutflowWindow         = 24 * time.Hour
+)
+
+// HolderChanges compares the top holders of two holder snapshots. Holders that exited only left the
+// tracked top list; they may still hold a smaller balance.
+type HolderChanges struct {
+	TokenID uuid.UUID  `json:"token_id"`
+	From    *time.Time `json:"from,omitempty"` // nil until a second snapshot exists
+	To      *time.Time `json:"to,omitempty"`   // nil until a first snapshot exists
+
+	Entered   []*HolderChange `json:"entered"`
+	Exited    []*HolderChange `json:"exited"`
+	Increased []*HolderChange `json:"increased"`
+	Decreased []*HolderChange `json:"decreased"`
+
+	// Change in the share of supply held by the top holders, in percentage points. Exchange and program
+	// accounts are left out unless IncludesExcluded is set.
+	NetTopHolderChangePercent float64 `json:"net_top_holder_change_percent"`
+	IncludesExcluded          bool    `json:"includes_excluded"`
+}
+
+// HolderChange is one holder's position in both snapshots; zero values stand for not being a top holder
+type HolderChange struct {
+	HolderAddress      string   `json:"holder_address"`
+	PreviousRank       int      `json:"previous_rank,omitempty"`
+	Rank               int      `json:"rank,omitempty"`
+	PreviousBalance    float64  `json:"previous_balance"`
+	Balance            float64  `json:"balance"`
+	PreviousPercentage float64  `json:"previous_percentage"`
+	Percentage         float64  `json:"percentage"`
+	Whale              bool     `json:"whale"`
+	Excluded           bool     `json:"excluded,omitempty"` // exchange or program account
+	Labels             []string `json:"labels,omitempty"`
+}
+
+// GetHolderChanges diffs the latest holder snapshot against the newest one taken at or before since,
+// or against the one before it when since is zero. Exchange and program accounts are listed but only count
+// towards the net change and whale status when includeExcluded is set.
+func (s *marketService) GetHolderChanges(ctx context.Context, tokenID uuid.UUID, since time.Time, includeExcluded bool) (*HolderChanges, error) {
+	changes := &HolderChanges{
+		TokenID:          tokenID,
+		Entered:          []*HolderChange{},
+		Exited:           []*HolderChange{},
+		Increased:        []*HolderChange{},
+		Decreased:        []*HolderChange{},
+		IncludesExcluded: includeExcluded,
+	}
+
+	times, err := s.tokenRepo.GetHolderSnapshotTimes(ctx, tokenID, time.Now(), 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holder snapshots: %w", err)
+	}
+	if len(times) == 0 {
+		return changes, nil
+	}
+	to := times[0]
+	changes.To = &to
+
+	var from time.Time
+	if since.IsZero() {
+		if len(times) < 2 {
+			return changes, nil
+		}
+		from = times[1]
+	} else {
+		earlier, err := s.tokenRepo.GetHolderSnapshotTimes(ctx, tokenID, since, 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get holder snapshots: %w", err)
+		}
+		if len(earlier) == 0 || earlier[0].Equal(to) {
+			return changes, nil
+		}
+		from = earlier[0]
+	}
+	changes.From = &from
+
+	previous, err := s.tokenRepo.GetHolderSnapshot(ctx, tokenID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holder snapshot: %w", err)
+	}
+	current, err := s.tokenRepo.GetHolderSnapshot(ctx, tokenID, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holder snapshot: %w", err)
+	}
+
+	addresses := make([]string, 0, len(previous)+len(current))
+	for _, holder := range previous {
+		addresses = append(addresses, holder.HolderAddress)
+	}
+	for _, holder := range current {
+		addresses = append(addresses, holder.HolderAddress)
+	}
+	labels, err := s.lookupHolderLabels(ctx, addresses)
+	if err != nil {
+		// Off-curve program accounts are still tagged and excluded
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"token_id": tokenID,
+		}).Warn("Failed to look up holder labels")
+	}
+	counted := func(address string) bool {
+		return includeExcluded || !excludedFromConcentration(labels[address])
+	}
+
+	before := make(map[string]*models.TokenHolderSnapshot, len(previous))
+	for _, holder := range previous {
+		before[holder.HolderAddress] = holder
+		if counted(holder.HolderAddress) {
+			changes.NetTopHolderChangePercent -= holder.Percentage
+		}
+	}
+
+	var all []*HolderChange
+	for _, holder := range current {
+		if counted(holder.HolderAddress) {
+			changes.NetTopHolderChangePercent += holder.Percentage
+		}
+
+		change := &HolderChange{
+			HolderAddress: holder.HolderAddress,
+			Rank:          holder.Rank,
+			Balance:       holder.Balance,
+			Percentage:    holder.Percentage,
+		}
+		earlier, ok := before[holder.HolderAddress]
+		delete(before, holder.HolderAddress)
+		switch {
+		case !ok:
+			changes.Entered = append(changes.Entered, change)
+		case holder.Balance > earlier.Balance:
+			changes.Increased = append(changes.Increased, change)
+		case holder.Balance < earlier.Balance:
+			changes.Decreased = append(changes.Decreased, change)
+		default:
+			continue
+		}
+		if ok {
+			change.PreviousRank = earlier.Rank
+			change.PreviousBalance = earlier.Balance
+			change.PreviousPercentage = earlier.Percentage
+		}
+		all = append(all, change)
+	}
+
+	// Whatever is left in before dropped out of the top holders; keep the previous ranking order
+	for _, holder := range previous {
+		if _, ok := before[holder.HolderAddress]; !ok {
+			continue
+		}
+		change := &HolderChange{
+			HolderAddress:      holder.HolderAddress,
+			PreviousRank:       holder.Rank,
+			PreviousBalance:    holder.Balance,
+			PreviousPercentage: holder.Percentage,
+		}
+		changes.Exited = append(changes.Exited, change)
+		all = append(all, change)
+	}
+
+	for _, change := range all {
+		change.Labels = labels[change.HolderAddress]
+		change.Excluded = excludedFromConcentration(change.Labels)
+		change.Whale = counted(change.HolderAddress) &&
+			(change.Percentage >= whaleHolderPercentage || change.PreviousPercentage >= whaleHolderPercentage)
+	}
+
+	return changes, nil
+}
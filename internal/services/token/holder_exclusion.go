@@ -0,0 +1,50 @@
+package token
+
+import (
+	"context"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/label"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+// Labels of holders left out of concentration metrics: exchange wallets and program-owned accounts such as
+// AMM vaults and bonding curves hold tokens on behalf of many users
+var concentrationExcludedLabels = map[string]bool{
+	string(models.WalletLabelExchange): true,
+	string(models.WalletLabelProgram):  true,
+}
+
+// lookupHolderLabels resolves the registry labels of holders and tags off-curve addresses, which only programs
+// can own, as program accounts. The tags are returned even when the registry lookup fails.
+func (s *marketService) lookupHolderLabels(ctx context.Context, addresses []string) (map[string][]string, error) {
+	labels, err := label.LookupLabels(ctx, s.labelRepo, addresses)
+	if err != nil {
+		labels = make(map[string][]string)
+	}
+	for _, address := range addresses {
+		if solana.IsPDA(address) && !hasLabel(labels[address], string(models.WalletLabelProgram)) {
+			labels[address] = append(labels[address], string(models.WalletLabelProgram))
+		}
+	}
+	return labels, err
+}
+
+// excludedFromConcentration reports whether a holder with the given labels is left out of concentration metrics
+func excludedFromConcentration(labels []string) bool {
+	for _, l := range labels {
+		if concentrationExcludedLabels[l] {
+			return true
+		}
+	}
+	return false
+}
+
+func hasLabel(labels []string, want string) bool {
+	for _, l := range labels {
+		if l == want {
+			return true
+		}
+	}
+	return false
+}
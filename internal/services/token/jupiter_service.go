@@ -0,0 +1,139 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+)
+
+// ErrNoRoute is returned when Jupiter cannot route a swap between two mints
+var ErrNoRoute = errors.New("no swap route")
+
+const (
+	defaultJupiterBaseURL = "https://lite-api.jup.ag/swap/v1"
+	defaultJupiterTimeout = 10 * time.Second
+	wrappedSOLMint        = "So11111111111111111111111111111111111111112"
+	jupiterSwapURL        = "https://jup.ag/swap"
+)
+
+// Jupiter error codes meaning the pair cannot be swapped rather than a transient failure
+var jupiterNoRouteCodes = map[string]bool{
+	"COULD_NOT_FIND_ANY_ROUTE": true,
+	"NO_ROUTES_FOUND":          true,
+	"TOKEN_NOT_TRADABLE":       true,
+}
+
+// JupiterService quotes swaps through the Jupiter aggregator
+type JupiterService interface {
+	// GetQuote quotes swapping amount base units of inputMint into outputMint; ErrNoRoute if the pair cannot be swapped
+	GetQuote(ctx context.Context, inputMint, outputMint string, amount uint64, slippageBps int) (*JupiterQuote, error)
+}
+
+type jupiterService struct {
+	config     *config.JupiterConfig
+	httpClient *http.Client
+	logger     *logrus.Logger
+}
+
+// NewJupiterService creates a new Jupiter quote client
+func NewJupiterService(config *config.JupiterConfig, apiStats *apistats.Registry, logger *logrus.Logger) JupiterService {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultJupiterTimeout
+	}
+
+	return &jupiterService{
+		config:     config,
+		httpClient: &http.Client{Timeout: timeout, Transport: apiStats.Transport("jupiter", nil)},
+		logger:     logger,
+	}
+}
+
+// JupiterQuote is a swap quote; amounts are in base units of their mint
+type JupiterQuote struct {
+	InputMint      string  `json:"inputMint"`
+	OutputMint     string  `json:"outputMint"`
+	InAmount       uint64  `json:"inAmount,string"`
+	OutAmount      uint64  `json:"outAmount,string"`
+	PriceImpactPct float64 `json:"priceImpactPct,string"` // fraction, e.g. 0.01 for 1%
+	RoutePlan      []struct {
+		SwapInfo struct {
+			Label string `json:"label"`
+		} `json:"swapInfo"`
+	} `json:"routePlan"`
+}
+
+type jupiterErrorResponse struct {
+	Error     string `json:"error"`
+	ErrorCode string `json:"errorCode"`
+}
+
+func (s *jupiterService) GetQuote(ctx context.Context, inputMint, outputMint string, amount uint64, slippageBps int) (*JupiterQuote, error) {
+	baseURL := s.config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultJupiterBaseURL
+	}
+
+	query := url.Values{}
+	query.Set("inputMint", inputMint)
+	query.Set("outputMint", outputMint)
+	query.Set("amount", strconv.FormatUint(amount, 10))
+	query.Set("slippageBps", strconv.Itoa(slippageBps))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/quote?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if s.config.APIKey != "" {
+		req.Header.Set("x-api-key", s.config.APIKey)
+	}
+	req.Header.Set("User-Agent", "solana-wallet-service/1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp jupiterErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && jupiterNoRouteCodes[errResp.ErrorCode] {
+			return nil, fmt.Errorf("%w: %s", ErrNoRoute, errResp.Error)
+		}
+		return nil, fmt.Errorf("Jupiter returned status %d: %s", resp.StatusCode, errResp.Error)
+	}
+
+	var quote JupiterQuote
+	if err := json.NewDecoder(resp.Body).Decode(&quote); err != nil {
+		return nil, fmt.Errorf("failed to decode quote: %w", err)
+	}
+	if quote.OutAmount == 0 {
+		return nil, fmt.Errorf("%w: quote returned no output", ErrNoRoute)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"input_mint":  inputMint,
+		"output_mint": outputMint,
+		"in_amount":   quote.InAmount,
+		"out_amount":  quote.OutAmount,
+	}).Debug("Fetched Jupiter quote")
+
+	return &quote, nil
+}
+
+// SwapDeepLink returns a jup.ag link that opens a SOL swap of the mint; side is buy (SOL into the mint) or sell
+func SwapDeepLink(mintAddress, side string) string {
+	if side == "sell" {
+		return fmt.Sprintf("%s/%s-%s", jupiterSwapURL, mintAddress, wrappedSOLMint)
+	}
+	return fmt.Sprintf("%s/%s-%s", jupiterSwapURL, wrappedSOLMint, mintAddress)
+}
@@ -0,0 +1,104 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+// How long a token's latest market data is served from Redis; every save writes through, so this only bounds
+// how long tokens that stopped syncing are kept
+const marketDataCacheTTL = 5 * time.Minute
+
+// cachedMarketData is the Redis entry of a token's latest market data, with the mint for the reference check
+type cachedMarketData struct {
+	MintAddress string                  `json:"mint_address"`
+	Data        *models.TokenMarketData `json:"data"`
+}
+
+func marketDataCacheKey(tokenID uuid.UUID) string {
+	return fmt.Sprintf("token_market_latest:%s", tokenID)
+}
+
+// cachedMarketDataOf returns the cached market data of the token, or nil on a miss or without a cache
+func (s *marketService) cachedMarketDataOf(ctx context.Context, tokenID uuid.UUID) *cachedMarketData {
+	if s.cache == nil {
+		return nil
+	}
+
+	var cached cachedMarketData
+	err := s.cache.GetJSON(ctx, marketDataCacheKey(tokenID), &cached)
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			s.logger.WithFields(logrus.Fields{
+				"error":    err,
+				"token_id": tokenID,
+			}).Warn("Failed to read market data cache")
+		}
+		return nil
+	}
+	if cached.Data == nil {
+		return nil
+	}
+	return &cached
+}
+
+// cacheMarketData stores the token's latest market data; the reference check is made on every read and not cached
+func (s *marketService) cacheMarketData(ctx context.Context, mintAddress string, data *models.TokenMarketData) {
+	if s.cache == nil {
+		return
+	}
+
+	entry := *data
+	entry.Reference = nil
+	err := s.cache.SetJSON(ctx, marketDataCacheKey(data.TokenID), &cachedMarketData{
+		MintAddress: mintAddress,
+		Data:        &entry,
+	}, marketDataCacheTTL)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"token_id": data.TokenID,
+		}).Warn("Failed to write market data cache")
+	}
+}
+
+// WarmMarketData loads the latest stored market data of the tokens into the cache, so the first reads after a
+// start are not served from the database; it returns how many tokens were cached
+func (s *marketService) WarmMarketData(ctx context.Context, mintAddresses []string) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+
+	warmed := 0
+	for _, mintAddress := range mintAddresses {
+		if err := ctx.Err(); err != nil {
+			return warmed, err
+		}
+
+		token, err := s.tokenRepo.GetByMintAddress(ctx, mintAddress)
+		if err != nil {
+			return warmed, fmt.Errorf("failed to get token: %w", err)
+		}
+		if token == nil {
+			continue
+		}
+		data, err := s.tokenRepo.GetLatestMarketData(ctx, token.ID)
+		if err != nil {
+			return warmed, fmt.Errorf("failed to get market data: %w", err)
+		}
+		if data == nil {
+			continue
+		}
+
+		s.cacheMarketData(ctx, mintAddress, data)
+		warmed++
+	}
+	return warmed, nil
+}
@@ -0,0 +1,94 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
+)
+
+// MarketDataProvider serves a token's metadata, market data and top holders, and its candles. Responses are
+// in SolanaTracker's shape whichever API they come from.
+type MarketDataProvider interface {
+	GetTokenInfo(ctx context.Context, mintAddress string) (*TokenInfoResponse, error)
+	GetChart(ctx context.Context, mintAddress, candleType string, from, to time.Time) (*ChartResponse, error)
+}
+
+// NewMarketDataProvider returns the configured provider, backed by the other one for tokens it fails on.
+// SolanaTracker skips a token for half an hour once it failed, so its failed tokens are served by Birdeye
+// meanwhile. Birdeye is only used with an API key.
+func NewMarketDataProvider(cfg *config.ExternalAPIsConfig, solanaTracker SolanaTrackerService, birdeye MarketDataProvider, logger *logrus.Logger) MarketDataProvider {
+	birdeyeEnabled := cfg.Birdeye.APIKey != ""
+	if cfg.MarketDataProvider == usage.ProviderBirdeye {
+		if !birdeyeEnabled {
+			logger.Warn("Birdeye is the market data provider but has no API key")
+		}
+		return &fallbackMarketDataProvider{
+			primary:      birdeye,
+			primaryName:  usage.ProviderBirdeye,
+			fallback:     solanaTracker,
+			fallbackName: usage.ProviderSolanaTracker,
+			logger:       logger,
+		}
+	}
+	if !birdeyeEnabled {
+		return solanaTracker
+	}
+	return &fallbackMarketDataProvider{
+		primary:      solanaTracker,
+		primaryName:  usage.ProviderSolanaTracker,
+		fallback:     birdeye,
+		fallbackName: usage.ProviderBirdeye,
+		logger:       logger,
+	}
+}
+
+// fallbackMarketDataProvider asks the fallback provider whenever the primary fails
+type fallbackMarketDataProvider struct {
+	primary      MarketDataProvider
+	primaryName  string
+	fallback     MarketDataProvider
+	fallbackName string
+	logger       *logrus.Logger
+}
+
+func (p *fallbackMarketDataProvider) GetTokenInfo(ctx context.Context, mintAddress string) (*TokenInfoResponse, error) {
+	response, err := p.primary.GetTokenInfo(ctx, mintAddress)
+	if err == nil || ctx.Err() != nil {
+		return response, err
+	}
+
+	p.logFallback("token_info", mintAddress, err)
+	response, fallbackErr := p.fallback.GetTokenInfo(ctx, mintAddress)
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("%w; %s fallback: %v", err, p.fallbackName, fallbackErr)
+	}
+	return response, nil
+}
+
+func (p *fallbackMarketDataProvider) GetChart(ctx context.Context, mintAddress, candleType string, from, to time.Time) (*ChartResponse, error) {
+	response, err := p.primary.GetChart(ctx, mintAddress, candleType, from, to)
+	if err == nil || ctx.Err() != nil {
+		return response, err
+	}
+
+	p.logFallback("chart", mintAddress, err)
+	response, fallbackErr := p.fallback.GetChart(ctx, mintAddress, candleType, from, to)
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("%w; %s fallback: %v", err, p.fallbackName, fallbackErr)
+	}
+	return response, nil
+}
+
+func (p *fallbackMarketDataProvider) logFallback(endpoint, mintAddress string, err error) {
+	p.logger.WithFields(logrus.Fields{
+		"error":        err,
+		"mint_address": mintAddress,
+		"endpoint":     endpoint,
+		"provider":     p.primaryName,
+		"fallback":     p.fallbackName,
+	}).Debug("Market data provider failed, asking fallback")
+}
@@ -0,0 +1,212 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+const (
+	marketEventStream      = "market:data_events"
+	marketEventStreamLen   = 10000 // approximate number of events the stream keeps
+	marketEventReadCount   = 100
+	marketEventReadBlock   = 5 * time.Second
+	marketEventRetryDelay  = time.Second
+	marketEventHandleLimit = 10 * time.Second // how long one consumer may take for one event
+)
+
+// MarketDataEvent is a change of a token's stored market data
+type MarketDataEvent struct {
+	MintAddress      string    `json:"mint_address"`
+	TokenID          uuid.UUID `json:"token_id"`
+	PriceUSD         float64   `json:"price_usd"`
+	PreviousPriceUSD float64   `json:"previous_price_usd"` // 0 for the token's first market data
+	Volume24h        float64   `json:"volume_24h"`
+	MarketCap        float64   `json:"market_cap"`
+	LastUpdated      time.Time `json:"last_updated"` // when the source last priced the token
+}
+
+// MarketEventHandler consumes market data events
+type MarketEventHandler func(ctx context.Context, event *MarketDataEvent)
+
+// OnPrice adapts a price listener to market data events; events without a price are skipped
+func OnPrice(listener PriceListener) MarketEventHandler {
+	return func(ctx context.Context, event *MarketDataEvent) {
+		if event.PriceUSD > 0 {
+			listener(ctx, event.MintAddress, event.PriceUSD)
+		}
+	}
+}
+
+// MarketEventBus carries market data changes to the alert and stream consumers. With Redis events go through
+// a stream, and each subscribed group handles an event once across all instances; without it handlers run on
+// the publishing goroutine.
+type MarketEventBus interface {
+	Publish(ctx context.Context, event *MarketDataEvent)
+	Subscribe(group string, handler MarketEventHandler) // before Start
+	Start()
+	Stop()
+}
+
+type marketEventBus struct {
+	client     *redis.Client
+	consumerID string
+	logger     *logrus.Logger
+
+	mu       sync.RWMutex
+	handlers map[string]MarketEventHandler // group -> handler
+	groups   []string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewMarketEventBus creates a market data event bus; with a nil client events stay in the process
+func NewMarketEventBus(client *redis.Client, logger *logrus.Logger) MarketEventBus {
+	return &marketEventBus{
+		client:     client,
+		consumerID: uuid.New().String(),
+		logger:     logger,
+		handlers:   make(map[string]MarketEventHandler),
+	}
+}
+
+func (b *marketEventBus) Subscribe(group string, handler MarketEventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.handlers[group]; !ok {
+		b.groups = append(b.groups, group)
+	}
+	b.handlers[group] = handler
+}
+
+func (b *marketEventBus) Publish(ctx context.Context, event *MarketDataEvent) {
+	if b.client == nil {
+		b.mu.RLock()
+		groups := b.groups
+		b.mu.RUnlock()
+		for _, group := range groups {
+			b.handle(ctx, group, event)
+		}
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		b.logger.WithError(err).Error("Failed to encode market data event")
+		return
+	}
+	err = b.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: marketEventStream,
+		MaxLen: marketEventStreamLen,
+		Approx: true,
+		Values: map[string]interface{}{"event": payload},
+	}).Err()
+	if err != nil {
+		b.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": event.MintAddress,
+		}).Warn("Failed to publish market data event")
+	}
+}
+
+// Start runs a stream consumer for each subscribed group; without Redis there is nothing to run
+func (b *marketEventBus) Start() {
+	if b.client == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+
+	b.mu.RLock()
+	groups := b.groups
+	b.mu.RUnlock()
+	for _, group := range groups {
+		// Groups start at the end of the stream; a group created earlier resumes where it stopped
+		err := b.client.XGroupCreateMkStream(ctx, marketEventStream, group, "$").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			b.logger.WithFields(logrus.Fields{
+				"error": err,
+				"group": group,
+			}).Error("Failed to create market data event group")
+			continue
+		}
+
+		b.wg.Add(1)
+		go b.consume(ctx, group)
+	}
+}
+
+func (b *marketEventBus) Stop() {
+	if b.cancel == nil {
+		return
+	}
+	b.cancel()
+	b.wg.Wait()
+}
+
+// consume reads the group's share of the stream until the bus stops; events are acknowledged once handled
+func (b *marketEventBus) consume(ctx context.Context, group string) {
+	defer b.wg.Done()
+
+	for ctx.Err() == nil {
+		streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: b.consumerID,
+			Streams:  []string{marketEventStream, ">"},
+			Count:    marketEventReadCount,
+			Block:    marketEventReadBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+			continue // nothing new within the block time, or stopping
+		}
+		if err != nil {
+			b.logger.WithFields(logrus.Fields{
+				"error": err,
+				"group": group,
+			}).Warn("Failed to read market data events")
+			time.Sleep(marketEventRetryDelay)
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				if raw, ok := message.Values["event"].(string); ok {
+					var event MarketDataEvent
+					if err := json.Unmarshal([]byte(raw), &event); err != nil {
+						b.logger.WithField("id", message.ID).Warn("Dropping unreadable market data event")
+					} else {
+						b.handle(ctx, group, &event)
+					}
+				}
+				if err := b.client.XAck(ctx, marketEventStream, group, message.ID).Err(); err != nil {
+					b.logger.WithFields(logrus.Fields{
+						"error": err,
+						"group": group,
+					}).Warn("Failed to acknowledge market data event")
+				}
+			}
+		}
+	}
+}
+
+func (b *marketEventBus) handle(ctx context.Context, group string, event *MarketDataEvent) {
+	b.mu.RLock()
+	handler := b.handlers[group]
+	b.mu.RUnlock()
+	if handler == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, marketEventHandleLimit)
+	defer cancel()
+	handler(ctx, event)
+}
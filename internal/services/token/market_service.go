@@ -3,12 +3,18 @@ package token
 import (
 	"context"
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
 	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
 )
 
 // MarketService defines the interface for token market data operations
@@ -18,24 +24,33 @@ type MarketService interface {
 	GetToken(ctx context.Context, mintAddress string) (*models.Token, error)
 	GetTokenByID(ctx context.Context, id uuid.UUID) (*models.Token, error)
 	ListTokens(ctx context.Context, limit, offset int) ([]*models.Token, error)
+	SearchTokens(ctx context.Context, filter repositories.TokenFilter, sort repositories.TokenSort, limit, offset int) ([]*models.Token, error)
 	UpdateToken(ctx context.Context, token *models.Token) error
 	
 	// Market data
 	UpdateMarketData(ctx context.Context, tokenID uuid.UUID, data *models.TokenMarketData) error
 	GetLatestMarketData(ctx context.Context, tokenID uuid.UUID) (*models.TokenMarketData, error)
 	SyncMarketDataFromExternalAPI(ctx context.Context, mintAddress string) (*models.TokenMarketData, error)
+	RefreshMetadata(ctx context.Context, mintAddress string) (*models.Token, error)
+	GetPools(ctx context.Context, mintAddress string) ([]TokenPool, error)
+	WarmMarketData(ctx context.Context, mintAddresses []string) (int, error) // primes the latest market data cache
 	
 	// Trending and rankings
 	UpdateTrendingRanking(ctx context.Context, ranking *models.TokenTrendingRanking) error
-	GetTrendingTokens(ctx context.Context, category, timeframe string, limit int) ([]*models.TokenTrendingRanking, error)
+	GetTrendingTokens(ctx context.Context, category, timeframe string, narrative models.TokenNarrative, limit int) ([]*models.TokenTrendingRanking, error)
+	SyncTrendingTokens(ctx context.Context, timeframe string) (int, error)
+	OnTrendingSync(listener TrendingListener)
+	OnGraduation(listener GraduationListener)
 	
 	// Top holders
 	UpdateTopHolders(ctx context.Context, tokenID uuid.UUID, holders []*models.TokenTopHolders) error
 	GetTopHolders(ctx context.Context, tokenID uuid.UUID, limit int) ([]*models.TokenTopHolders, error)
+	GetHolderChanges(ctx context.Context, tokenID uuid.UUID, since time.Time, includeExcluded bool) (*HolderChanges, error)
 	
 	// Transaction statistics
 	UpdateTransactionStats(ctx context.Context, stats *models.TokenTransactionStats) error
 	GetTransactionStats(ctx context.Context, tokenID uuid.UUID, timeframe string) (*models.TokenTransactionStats, error)
+	RollupTransactionStats(ctx context.Context) (int, error)
 	
 	// Batch operations
 	BatchUpdateMarketData(ctx context.Context, data []*models.TokenMarketData) error
@@ -44,29 +59,78 @@ type MarketService interface {
 
 type marketService struct {
 	tokenRepo             repositories.TokenRepository
+	labelRepo             repositories.WalletLabelRepository
 	solanaTrackerService  SolanaTrackerService
+	marketData            MarketDataProvider
+	pools                 PoolsProvider
+	classifier            NarrativeClassifier
+	metadataScreen        MetadataScreenService
+	prices                blockchain.PriceAggregator
+	events                MarketEventBus
+	cache                 *redis.Client // optional, holds each token's latest market data
+	metadataMaxAge        time.Duration
 	logger                *logrus.Logger
+	
+	listenersMu       sync.RWMutex
+	trendingListeners   []TrendingListener
+	graduationListeners []GraduationListener
 }
 
-// NewMarketService creates a new market service instance
+// Upper bound on the replaced trending ranking handed to trending listeners
+const previousTrendingLimit = 500
+
+// PriceListener is called with each updated token price, through OnPrice on the market event bus
+type PriceListener func(ctx context.Context, mintAddress string, priceUSD float64)
+
+// TrendingListener is called after each trending sync with the replaced and the new ranking of the timeframe, best first
+type TrendingListener func(ctx context.Context, timeframe string, previous, current []*models.TokenTrendingRanking)
+
+// GraduationListener is called when a Pump.fun token is seen to have left its bonding curve
+type GraduationListener func(ctx context.Context, token *models.Token)
+
+// NewMarketService creates a new market service instance; cache may be nil
 func NewMarketService(
 	tokenRepo repositories.TokenRepository,
+	labelRepo repositories.WalletLabelRepository,
 	solanaTrackerService SolanaTrackerService,
+	marketData MarketDataProvider,
+	pools PoolsProvider,
+	classifier NarrativeClassifier,
+	metadataScreen MetadataScreenService,
+	prices blockchain.PriceAggregator,
+	events MarketEventBus,
+	cache *redis.Client,
+	syncConfig *config.SyncSchedulerConfig,
 	logger *logrus.Logger,
 ) MarketService {
-	return &marketService{
+	metadataMaxAge := syncConfig.TokenMetadataMaxAge
+	if metadataMaxAge <= 0 {
+		metadataMaxAge = defaultTokenMetadataMaxAge
+	}
+	s := &marketService{
 		tokenRepo:            tokenRepo,
+		labelRepo:            labelRepo,
 		solanaTrackerService: solanaTrackerService,
+		marketData:           marketData,
+		pools:                pools,
+		classifier:           classifier,
+		metadataScreen:       metadataScreen,
+		prices:               prices,
+		events:               events,
+		cache:                cache,
+		metadataMaxAge:       metadataMaxAge,
 		logger:               logger,
 	}
+	events.Subscribe("reference_check", s.checkReferencePrice)
+	return s
 }
 
 // Request/Response structs
 type CreateTokenRequest struct {
-	MintAddress string  `json:"mint_address" validate:"required"`
+	MintAddress string  `json:"mint_address" validate:"required,solana_address"`
 	Symbol      string  `json:"symbol" validate:"required"`
 	Name        string  `json:"name" validate:"required"`
-	Decimals    int     `json:"decimals" validate:"required,min=0,max=18"`
+	Decimals    int     `json:"decimals" validate:"min=0,max=18"`
 	LogoURI     *string `json:"logo_uri,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Website     *string `json:"website,omitempty"`
@@ -95,6 +159,39 @@ type ExternalMarketDataResponse struct {
 	LastUpdated time.Time `json:"last_updated"`
 }
 
+// OnTrendingSync registers a listener for rebuilt trending rankings
+func (s *marketService) OnTrendingSync(listener TrendingListener) {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	s.trendingListeners = append(s.trendingListeners, listener)
+}
+
+// OnGraduation registers a listener for Pump.fun tokens graduating from their bonding curve
+func (s *marketService) OnGraduation(listener GraduationListener) {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	s.graduationListeners = append(s.graduationListeners, listener)
+}
+
+// checkReferencePrice warns about an updated DEX price that is stale or diverges from its Pyth reference
+func (s *marketService) checkReferencePrice(ctx context.Context, event *MarketDataEvent) {
+	marketData := &models.TokenMarketData{PriceUSD: event.PriceUSD, LastUpdated: event.LastUpdated}
+	s.prices.CheckMarketData(ctx, event.MintAddress, marketData)
+	reference := marketData.Reference
+	if reference == nil || (!reference.DEXPriceDivergent && !reference.DEXPriceStale) {
+		return
+	}
+	
+	s.logger.WithFields(logrus.Fields{
+		"mint_address":      event.MintAddress,
+		"dex_price_usd":     marketData.PriceUSD,
+		"reference_usd":     reference.PriceUSD,
+		"deviation_percent": reference.DeviationPercent,
+		"stale":             reference.DEXPriceStale,
+		"divergent":         reference.DEXPriceDivergent,
+	}).Warn("Synced DEX price fails the reference check")
+}
+
 // Token management
 func (s *marketService) CreateToken(ctx context.Context, req *CreateTokenRequest) (*models.Token, error) {
 	// Check if token already exists
@@ -132,6 +229,16 @@ func (s *marketService) CreateToken(ctx context.Context, req *CreateTokenRequest
 		"symbol":       req.Symbol,
 	}).Info("Token created successfully")
 	
+	s.tagNarratives(ctx, token)
+	
+	// A failed screen leaves the token unflagged rather than failing ingestion
+	if _, err := s.metadataScreen.Screen(ctx, token); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": token.MintAddress,
+		}).Warn("Failed to screen token metadata")
+	}
+	
 	return token, nil
 }
 
@@ -141,7 +248,14 @@ func (s *marketService) GetToken(ctx context.Context, mintAddress string) (*mode
 		return nil, err
 	}
 	if token == nil {
-		return nil, fmt.Errorf("token not found: %s", mintAddress)
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, mintAddress)
+	}
+	
+	if token.Flag, err = activeFlag(ctx, s.tokenRepo, mintAddress); err != nil {
+		return nil, fmt.Errorf("failed to get token flag: %w", err)
+	}
+	if err := s.fillNarratives(ctx, []*models.Token{token}); err != nil {
+		return nil, err
 	}
 	return token, nil
 }
@@ -151,7 +265,44 @@ func (s *marketService) GetTokenByID(ctx context.Context, id uuid.UUID) (*models
 }
 
 func (s *marketService) ListTokens(ctx context.Context, limit, offset int) ([]*models.Token, error) {
-	return s.tokenRepo.List(ctx, limit, offset)
+	tokens, err := s.tokenRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return s.decorateTokens(ctx, tokens)
+}
+
+// SearchTokens lists tokens matching the filter in the given order
+func (s *marketService) SearchTokens(ctx context.Context, filter repositories.TokenFilter, sort repositories.TokenSort, limit, offset int) ([]*models.Token, error) {
+	tokens, err := s.tokenRepo.Find(ctx, filter, sort, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return s.decorateTokens(ctx, tokens)
+}
+
+// decorateTokens fills the active flags and narratives of listed tokens
+func (s *marketService) decorateTokens(ctx context.Context, tokens []*models.Token) ([]*models.Token, error) {
+	mintAddresses := make([]string, len(tokens))
+	for i, token := range tokens {
+		mintAddresses[i] = token.MintAddress
+	}
+	flags, err := s.tokenRepo.GetActiveFlags(ctx, mintAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token flags: %w", err)
+	}
+	
+	flagsByMint := make(map[string]*models.TokenFlag, len(flags))
+	for _, flag := range flags {
+		flagsByMint[flag.MintAddress] = flag
+	}
+	for _, token := range tokens {
+		token.Flag = flagsByMint[token.MintAddress]
+	}
+	if err := s.fillNarratives(ctx, tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
 }
 
 func (s *marketService) UpdateToken(ctx context.Context, token *models.Token) error {
@@ -160,6 +311,18 @@ func (s *marketService) UpdateToken(ctx context.Context, token *models.Token) er
 
 // Market data operations
 func (s *marketService) UpdateMarketData(ctx context.Context, tokenID uuid.UUID, data *models.TokenMarketData) error {
+	token, err := s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+	if token == nil {
+		return fmt.Errorf("token %s not found", tokenID)
+	}
+	return s.saveMarketData(ctx, token.MintAddress, tokenID, data)
+}
+
+// saveMarketData stores the token's market data and publishes the change on the market event bus
+func (s *marketService) saveMarketData(ctx context.Context, mintAddress string, tokenID uuid.UUID, data *models.TokenMarketData) error {
 	data.TokenID = tokenID
 	
 	// Try to update existing data first
@@ -168,25 +331,61 @@ func (s *marketService) UpdateMarketData(ctx context.Context, tokenID uuid.UUID,
 		return fmt.Errorf("failed to get existing market data: %w", err)
 	}
 	
+	event := &MarketDataEvent{
+		MintAddress: mintAddress,
+		TokenID:     tokenID,
+		PriceUSD:    data.PriceUSD,
+		Volume24h:   data.Volume24h,
+		MarketCap:   data.MarketCap,
+		LastUpdated: data.LastUpdated,
+	}
 	if existing != nil {
 		// Update existing record
 		data.ID = existing.ID
-		return s.tokenRepo.UpdateMarketData(ctx, data)
+		event.PreviousPriceUSD = existing.PriceUSD
+		err = s.tokenRepo.UpdateMarketData(ctx, data)
+	} else {
+		// Create new record
+		err = s.tokenRepo.CreateMarketData(ctx, data)
 	}
+	if err != nil {
+		return err
+	}
+	s.cacheMarketData(ctx, mintAddress, data)
 	
-	// Create new record
-	return s.tokenRepo.CreateMarketData(ctx, data)
+	s.events.Publish(ctx, event)
+	return nil
 }
 
+// GetLatestMarketData includes the reference price check for tokens with a Pyth feed; it is served from the
+// cache when the token's data is cached
 func (s *marketService) GetLatestMarketData(ctx context.Context, tokenID uuid.UUID) (*models.TokenMarketData, error) {
-	return s.tokenRepo.GetLatestMarketData(ctx, tokenID)
+	if cached := s.cachedMarketDataOf(ctx, tokenID); cached != nil {
+		s.prices.CheckMarketData(ctx, cached.MintAddress, cached.Data)
+		return cached.Data, nil
+	}
+	
+	marketData, err := s.tokenRepo.GetLatestMarketData(ctx, tokenID)
+	if err != nil || marketData == nil {
+		return marketData, err
+	}
+	
+	token, err := s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if token != nil {
+		s.cacheMarketData(ctx, token.MintAddress, marketData)
+		s.prices.CheckMarketData(ctx, token.MintAddress, marketData)
+	}
+	return marketData, nil
 }
 
 func (s *marketService) SyncMarketDataFromExternalAPI(ctx context.Context, mintAddress string) (*models.TokenMarketData, error) {
-	// Get token info from SolanaTracker
-	tokenInfoResp, err := s.solanaTrackerService.GetTokenInfo(mintAddress)
+	// Get token info from the market data provider
+	tokenInfoResp, err := s.marketData.GetTokenInfo(ctx, mintAddress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get token info from SolanaTracker: %w", err)
+		return nil, fmt.Errorf("failed to get token info: %w", err)
 	}
 	
 	tokenInfo := tokenInfoResp.Data
@@ -204,20 +403,26 @@ func (s *marketService) SyncMarketDataFromExternalAPI(ctx context.Context, mintA
 			Symbol:      tokenInfo.Symbol,
 			Name:        tokenInfo.Name,
 			Decimals:    9, // Default for most SPL tokens
-			LogoURI:     &tokenInfo.LogoURI,
-			Description: &tokenInfo.Description,
-			Website:     &tokenInfo.Website,
-			Twitter:     &tokenInfo.Twitter,
-			Telegram:    &tokenInfo.Telegram,
+			LogoURI:     models.NullableString(tokenInfo.LogoURI),
+			Description: models.NullableString(tokenInfo.Description),
+			Website:     models.NullableString(tokenInfo.Website),
+			Twitter:     models.NullableString(tokenInfo.Twitter),
+			Telegram:    models.NullableString(tokenInfo.Telegram),
 		}
 		
 		token, err = s.CreateToken(ctx, createReq)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create token: %w", err)
 		}
+	} else {
+		// Tokens created before narratives existed are tagged on their next sync
+		s.ensureNarratives(ctx, token)
+		if s.metadataStale(token) {
+			s.refreshMetadata(ctx, token, tokenInfo)
+		}
 	}
 	
-	// Convert SolanaTracker data to internal model
+	// Convert provider data to internal model
 	var lastUpdated time.Time
 	if tokenInfo.LastUpdated != "" {
 		if parsed, err := time.Parse(time.RFC3339, tokenInfo.LastUpdated); err == nil {
@@ -232,7 +437,7 @@ func (s *marketService) SyncMarketDataFromExternalAPI(ctx context.Context, mintA
 	marketData := &models.TokenMarketData{
 		TokenID:           token.ID,
 		Price:             tokenInfo.Price,
-		PriceUSD:          tokenInfo.Price, // SolanaTracker already provides USD price
+		PriceUSD:          tokenInfo.Price, // providers report USD prices
 		Volume24h:         tokenInfo.Volume24h,
 		VolumeChange24h:   tokenInfo.VolumeChange24h,
 		MarketCap:         tokenInfo.MarketCap,
@@ -245,13 +450,18 @@ func (s *marketService) SyncMarketDataFromExternalAPI(ctx context.Context, mintA
 		MaxSupply:         tokenInfo.MaxSupply,
 		ATH:               tokenInfo.ATH,
 		ATL:               tokenInfo.ATL,
+		HolderCount:       tokenInfo.HolderCount,
 		LastUpdated:       lastUpdated,
 	}
 	
-	// Save to database
-	if err := s.UpdateMarketData(ctx, token.ID, marketData); err != nil {
+	// Save to database; the reference_check consumer of the published event warns about divergent prices
+	if err := s.saveMarketData(ctx, mintAddress, token.ID, marketData); err != nil {
 		return nil, fmt.Errorf("failed to save market data: %w", err)
 	}
+	s.prices.CheckMarketData(ctx, mintAddress, marketData)
+	
+	// Track the Pump.fun launch stage from the token's pools
+	s.updateBondingCurve(ctx, token, tokenInfo.Pools)
 	
 	// Update top holders if available
 	if len(tokenInfo.TopHolders) > 0 {
@@ -265,6 +475,7 @@ func (s *marketService) SyncMarketDataFromExternalAPI(ctx context.Context, mintA
 				Rank:          holder.Rank,
 			})
 		}
+		normalizeHolders(holders, token.Decimals, marketData.PriceUSD, marketData.TotalSupply)
 		
 		if err := s.UpdateTopHolders(ctx, token.ID, holders); err != nil {
 			s.logger.WithError(err).Warn("Failed to update top holders")
@@ -276,7 +487,7 @@ func (s *marketService) SyncMarketDataFromExternalAPI(ctx context.Context, mintA
 		"mint_address": mintAddress,
 		"symbol":       token.Symbol,
 		"price_usd":    marketData.PriceUSD,
-	}).Info("Market data synced from SolanaTracker")
+	}).Info("Market data synced")
 	
 	return marketData, nil
 }
@@ -284,7 +495,7 @@ func (s *marketService) SyncMarketDataFromExternalAPI(ctx context.Context, mintA
 // Trending and rankings
 func (s *marketService) UpdateTrendingRanking(ctx context.Context, ranking *models.TokenTrendingRanking) error {
 	// Try to update existing ranking first
-	existing, err := s.tokenRepo.GetTrendingTokens(ctx, string(ranking.Category), ranking.Timeframe, 1)
+	existing, err := s.tokenRepo.GetTrendingTokens(ctx, string(ranking.Category), ranking.Timeframe, "", 1)
 	if err != nil {
 		return fmt.Errorf("failed to check existing ranking: %w", err)
 	}
@@ -301,8 +512,95 @@ func (s *marketService) UpdateTrendingRanking(ctx context.Context, ranking *mode
 	return s.tokenRepo.CreateTrendingRanking(ctx, ranking)
 }
 
-func (s *marketService) GetTrendingTokens(ctx context.Context, category, timeframe string, limit int) ([]*models.TokenTrendingRanking, error) {
-	return s.tokenRepo.GetTrendingTokens(ctx, category, timeframe, limit)
+func (s *marketService) GetTrendingTokens(ctx context.Context, category, timeframe string, narrative models.TokenNarrative, limit int) ([]*models.TokenTrendingRanking, error) {
+	rankings, err := s.tokenRepo.GetTrendingTokens(ctx, category, timeframe, narrative, limit)
+	if err != nil {
+		return nil, err
+	}
+	
+	tokens := make([]*models.Token, len(rankings))
+	for i, ranking := range rankings {
+		tokens[i] = &ranking.Token
+	}
+	if err := s.fillNarratives(ctx, tokens); err != nil {
+		return nil, err
+	}
+	return rankings, nil
+}
+
+// SyncTrendingTokens rebuilds the trending ranking for a timeframe from SolanaTracker
+func (s *marketService) SyncTrendingTokens(ctx context.Context, timeframe string) (int, error) {
+	trending, err := s.solanaTrackerService.GetTrendingTokens(ctx, timeframe)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trending tokens from SolanaTracker: %w", err)
+	}
+	
+	// Replace the previous ranking rather than merging into it; listeners still get to compare against it
+	previous, err := s.tokenRepo.GetTrendingTokens(ctx, "trending", timeframe, "", previousTrendingLimit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get previous trending rankings: %w", err)
+	}
+	if err := s.tokenRepo.DeleteTrendingRankings(ctx, "trending", timeframe); err != nil {
+		return 0, fmt.Errorf("failed to clear trending rankings: %w", err)
+	}
+	
+	current := make([]*models.TokenTrendingRanking, 0, len(trending.Data))
+	for i, item := range trending.Data {
+		token, err := s.CreateToken(ctx, &CreateTokenRequest{
+			MintAddress: item.Address,
+			Symbol:      item.Symbol,
+			Name:        item.Name,
+			Decimals:    9, // Default for most SPL tokens
+			LogoURI:     models.NullableString(item.LogoURI),
+		})
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": item.Address,
+			}).Warn("Failed to upsert trending token")
+			continue
+		}
+		
+		ranking := &models.TokenTrendingRanking{
+			TokenID:   token.ID,
+			Rank:      i + 1,
+			Category:  "trending",
+			Timeframe: timeframe,
+			Score:     item.PriceChange24h,
+			PriceUSD:  item.Price,
+		}
+		if err := s.tokenRepo.CreateTrendingRanking(ctx, ranking); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": item.Address,
+			}).Warn("Failed to create trending ranking")
+			continue
+		}
+		ranking.Token = *token
+		current = append(current, ranking)
+	}
+	
+	s.logger.WithFields(logrus.Fields{
+		"timeframe": timeframe,
+		"indexed":   len(current),
+	}).Info("Trending tokens reindexed")
+	
+	s.listenersMu.RLock()
+	listeners := s.trendingListeners
+	s.listenersMu.RUnlock()
+	for _, listener := range listeners {
+		listener(ctx, timeframe, previous, current)
+	}
+	
+	return len(current), nil
+}
+
+// GetPools lists the pools the token trades in with their price, liquidity and volume, most liquid first
+func (s *marketService) GetPools(ctx context.Context, mintAddress string) ([]TokenPool, error) {
+	if err := solana.ValidateAddress(mintAddress); err != nil {
+		return nil, err
+	}
+	return s.pools.GetPools(ctx, mintAddress)
 }
 
 // Top holders
@@ -335,11 +633,63 @@ func (s *marketService) UpdateTopHolders(ctx context.Context, tokenID uuid.UUID,
 		}
 	}
 	
+	// Keep the sync as a snapshot so holder changes can be diffed later
+	takenAt := time.Now().UTC().Truncate(time.Microsecond) // postgres timestamp precision
+	snapshot := make([]*models.TokenHolderSnapshot, 0, len(holders))
+	for _, holder := range holders {
+		snapshot = append(snapshot, &models.TokenHolderSnapshot{
+			TokenID:       tokenID,
+			TakenAt:       takenAt,
+			HolderAddress: holder.HolderAddress,
+			Balance:       holder.Balance,
+			Percentage:    holder.Percentage,
+			Rank:          holder.Rank,
+		})
+	}
+	if err := s.tokenRepo.SaveHolderSnapshot(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to save holder snapshot: %w", err)
+	}
+	
 	return nil
 }
 
+// normalizeHolders sets the balances of holders in whole tokens and their USD value at priceUSD. Holders
+// reported without a percentage get it from totalSupply, which is given in whole tokens.
+func normalizeHolders(holders []*models.TokenTopHolders, decimals int, priceUSD, totalSupply float64) {
+	scale := math.Pow10(decimals)
+	for _, holder := range holders {
+		holder.UIBalance = holder.Balance / scale
+		holder.ValueUSD = holder.UIBalance * priceUSD
+		if holder.Percentage == 0 && totalSupply > 0 {
+			holder.Percentage = holder.UIBalance / totalSupply * 100
+		}
+	}
+}
+
 func (s *marketService) GetTopHolders(ctx context.Context, tokenID uuid.UUID, limit int) ([]*models.TokenTopHolders, error) {
-	return s.tokenRepo.GetTopHolders(ctx, tokenID, limit)
+	holders, err := s.tokenRepo.GetTopHolders(ctx, tokenID, limit)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Enrich holders with known entity labels and mark those left out of concentration metrics
+	addresses := make([]string, 0, len(holders))
+	for _, holder := range holders {
+		addresses = append(addresses, holder.HolderAddress)
+	}
+	labels, err := s.lookupHolderLabels(ctx, addresses)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"token_id": tokenID,
+		}).Warn("Failed to look up holder labels")
+	}
+	for _, holder := range holders {
+		holder.Labels = labels[holder.HolderAddress]
+		holder.Excluded = excludedFromConcentration(holder.Labels)
+	}
+	
+	return holders, nil
 }
 
 // Transaction statistics
@@ -363,6 +713,51 @@ func (s *marketService) GetTransactionStats(ctx context.Context, tokenID uuid.UU
 	return s.tokenRepo.GetTransactionStats(ctx, tokenID, timeframe)
 }
 
+// transactionStatsTimeframes are the rolling windows kept in TokenTransactionStats
+var transactionStatsTimeframes = []struct {
+	name   string
+	window time.Duration
+}{
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+}
+
+// RollupTransactionStats recomputes every token's transaction stats from smart money transactions
+// and room trade events, and returns the number of stats rows written. Tokens without trades in a
+// window have their stats for it zeroed.
+func (s *marketService) RollupTransactionStats(ctx context.Context) (int, error) {
+	written := 0
+	for _, timeframe := range transactionStatsTimeframes {
+		startedAt := time.Now()
+		stats, err := s.tokenRepo.AggregateTradeStats(ctx, startedAt.Add(-timeframe.window))
+		if err != nil {
+			return written, fmt.Errorf("failed to aggregate %s trade stats: %w", timeframe.name, err)
+		}
+		
+		for _, stat := range stats {
+			stat.Timeframe = timeframe.name
+			stat.TransactionCount = stat.BuyCount + stat.SellCount
+			stat.NetVolume = stat.BuyVolume - stat.SellVolume
+			if stat.TransactionCount > 0 {
+				stat.AverageTradeSize = (stat.BuyVolume + stat.SellVolume) / float64(stat.TransactionCount)
+			}
+		}
+		
+		if err := s.tokenRepo.SaveTransactionStats(ctx, stats); err != nil {
+			return written, fmt.Errorf("failed to save %s trade stats: %w", timeframe.name, err)
+		}
+		written += len(stats)
+		
+		if _, err := s.tokenRepo.ResetTransactionStats(ctx, timeframe.name, startedAt); err != nil {
+			return written, fmt.Errorf("failed to reset stale %s trade stats: %w", timeframe.name, err)
+		}
+	}
+	
+	s.logger.WithField("rows", written).Info("Token transaction stats rolled up")
+	return written, nil
+}
+
 // Batch operations
 func (s *marketService) BatchUpdateMarketData(ctx context.Context, data []*models.TokenMarketData) error {
 	for _, marketData := range data {
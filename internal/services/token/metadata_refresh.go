@@ -0,0 +1,100 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// defaultTokenMetadataMaxAge is how old token metadata may get before a sync re-fetches it when no age is configured
+const defaultTokenMetadataMaxAge = 7 * 24 * time.Hour
+
+// RefreshMetadata re-fetches the token's name, symbol, logo and socials regardless of their age
+func (s *marketService) RefreshMetadata(ctx context.Context, mintAddress string) (*models.Token, error) {
+	token, err := s.tokenRepo.GetByMintAddress(ctx, mintAddress)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, mintAddress)
+	}
+
+	tokenInfoResp, err := s.marketData.GetTokenInfo(ctx, mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token info: %w", err)
+	}
+	if err := s.applyMetadata(ctx, token, tokenInfoResp.Data); err != nil {
+		return nil, err
+	}
+	// Read back with the flag a new screen may have set
+	return s.GetToken(ctx, mintAddress)
+}
+
+// metadataStale reports whether the token's metadata was fetched longer ago than the configured maximum age
+func (s *marketService) metadataStale(token *models.Token) bool {
+	fetchedAt := token.CreatedAt
+	if token.MetadataRefreshedAt != nil {
+		fetchedAt = *token.MetadataRefreshedAt
+	}
+	return time.Since(fetchedAt) > s.metadataMaxAge
+}
+
+// refreshMetadata applies fetched metadata during a sync; a failure is logged and retried on the next sync
+func (s *marketService) refreshMetadata(ctx context.Context, token *models.Token, info TokenInfo) {
+	if err := s.applyMetadata(ctx, token, info); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": token.MintAddress,
+		}).Warn("Failed to refresh token metadata")
+	}
+}
+
+// applyMetadata stores fetched metadata on the token and marks it refreshed. Fields the API left empty keep
+// their stored value, so a partial response does not erase known metadata. Changed metadata is screened again.
+func (s *marketService) applyMetadata(ctx context.Context, token *models.Token, info TokenInfo) error {
+	changed := false
+	setString := func(field *string, value string) {
+		if value != "" && value != *field {
+			*field = value
+			changed = true
+		}
+	}
+	setNullable := func(field **string, value string) {
+		if value != "" && value != models.StringValue(*field) {
+			*field = models.NullableString(value)
+			changed = true
+		}
+	}
+	setString(&token.Symbol, info.Symbol)
+	setString(&token.Name, info.Name)
+	setNullable(&token.LogoURI, info.LogoURI)
+	setNullable(&token.Description, info.Description)
+	setNullable(&token.Website, info.Website)
+	setNullable(&token.Twitter, info.Twitter)
+	setNullable(&token.Telegram, info.Telegram)
+
+	now := time.Now()
+	token.MetadataRefreshedAt = &now
+	if err := s.tokenRepo.Update(ctx, token); err != nil {
+		return fmt.Errorf("failed to update token metadata: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": token.MintAddress,
+		"changed":      changed,
+	}).Debug("Token metadata refreshed")
+
+	if changed {
+		// A failed screen keeps the previous verdict rather than failing the refresh
+		if _, err := s.metadataScreen.Screen(ctx, token); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": token.MintAddress,
+			}).Warn("Failed to screen token metadata")
+		}
+	}
+	return nil
+}
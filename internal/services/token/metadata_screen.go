@@ -0,0 +1,330 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+const (
+	minCopiedDescriptionLength = 40 // shorter descriptions are too generic to tell copying from coincidence
+	copiedMetadataLookupLimit  = 5
+	metadataFlagSignals        = 2 // signals that flag a token without impersonation
+)
+
+// MetadataClassifier judges whether a token's metadata is deceptive; it returns nil when it cannot decide
+type MetadataClassifier interface {
+	ClassifyMetadata(ctx context.Context, token *models.Token) (*MetadataVerdict, error)
+}
+
+// MetadataVerdict is the AI classifier's judgement of a token's metadata
+type MetadataVerdict struct {
+	Suspicious bool
+	Reason     string
+}
+
+// majorProject is a well-known token new mints impersonate
+type majorProject struct {
+	Mint    string
+	Symbol  string
+	Names   []string
+	Domains []string // official domains, whose subdomains are official too
+	Brand   string   // a website host containing it off the official domains is a lookalike
+}
+
+var majorProjects = []majorProject{
+	{Mint: wrappedSOLMint, Symbol: "SOL", Names: []string{"solana", "wrapped sol"}, Domains: []string{"solana.com"}, Brand: "solana"},
+	{Mint: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Symbol: "USDC", Names: []string{"usd coin"}, Domains: []string{"circle.com"}, Brand: "circle"},
+	{Mint: "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB", Symbol: "USDT", Names: []string{"tether", "tether usd"}, Domains: []string{"tether.to"}, Brand: "tether"},
+	{Mint: "JUPyiwrYJFskUPiHa7hkeR8VUtAeFoSYbKedZNsDvCN", Symbol: "JUP", Names: []string{"jupiter"}, Domains: []string{"jup.ag"}, Brand: "jupiter"},
+	{Mint: "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263", Symbol: "BONK", Names: []string{"bonk"}, Domains: []string{"bonkcoin.com"}, Brand: "bonk"},
+	{Mint: "EKpQGSJtjMFqKZ9KQanSqYXRcF8fBopzLHYxdM65zcjm", Symbol: "WIF", Names: []string{"dogwifhat"}, Domains: []string{"dogwifcoin.org"}, Brand: "dogwif"},
+	{Mint: "4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R", Symbol: "RAY", Names: []string{"raydium"}, Domains: []string{"raydium.io"}, Brand: "raydium"},
+	{Mint: "HZ1JovNiVvGrGNiiYvEozEVgZ58xaU3RKwX8eACQBCt3", Symbol: "PYTH", Names: []string{"pyth", "pyth network"}, Domains: []string{"pyth.network"}, Brand: "pyth"},
+	{Mint: "jtojtomepa8beP8AuQc6eXt5FriJwfFMwQx2v2f9mCL", Symbol: "JTO", Names: []string{"jito"}, Domains: []string{"jito.network"}, Brand: "jito"},
+}
+
+// URL shorteners hide where a link leads
+var urlShorteners = map[string]bool{
+	"bit.ly": true, "tinyurl.com": true, "cutt.ly": true, "is.gd": true, "rb.gy": true,
+	"shorturl.at": true, "t.ly": true, "ow.ly": true, "goo.gl": true, "rebrand.ly": true,
+}
+
+// Words in a link typical of wallet drainer pages
+var drainerKeywords = []string{"claim", "airdrop", "connect-wallet", "connectwallet", "walletconnect", "validate", "rectify", "migrate", "whitelist"}
+
+// Hosts social links must point to
+var (
+	twitterHosts  = []string{"twitter.com", "x.com"}
+	telegramHosts = []string{"t.me", "telegram.me", "telegram.org"}
+)
+
+// MetadataScreenService screens the description, website and socials of newly ingested tokens for copied
+// metadata, impersonation of major projects and suspicious links, and flags the tokens that look like scams
+type MetadataScreenService interface {
+	Screen(ctx context.Context, token *models.Token) (*models.TokenMetadataScreen, error)
+}
+
+type metadataScreenService struct {
+	tokenRepo  repositories.TokenRepository
+	flags      FlagService
+	classifier MetadataClassifier
+	logger     *logrus.Logger
+}
+
+// NewMetadataScreenService creates a new metadata screen service instance; the classifier is optional
+func NewMetadataScreenService(
+	tokenRepo repositories.TokenRepository,
+	flags FlagService,
+	classifier MetadataClassifier,
+	logger *logrus.Logger,
+) MetadataScreenService {
+	return &metadataScreenService{
+		tokenRepo:  tokenRepo,
+		flags:      flags,
+		classifier: classifier,
+		logger:     logger,
+	}
+}
+
+// Screen runs the heuristics and, when the token has metadata to judge, the AI classifier, stores the signals
+// and raises a scam flag on impersonation or on several signals
+func (s *metadataScreenService) Screen(ctx context.Context, token *models.Token) (*models.TokenMetadataScreen, error) {
+	signals := []models.MetadataSignal{}
+	signals = append(signals, impersonationSignals(token)...)
+	signals = append(signals, suspiciousURLSignals(token)...)
+
+	copied, err := s.copiedMetadataSignals(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	signals = append(signals, copied...)
+
+	aiChecked := false
+	if s.classifier != nil && hasMetadata(token) {
+		verdict, err := s.classifier.ClassifyMetadata(ctx, token)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": token.MintAddress,
+			}).Warn("Failed to classify token metadata, using heuristics only")
+		} else if verdict != nil {
+			aiChecked = true
+			if verdict.Suspicious {
+				signals = append(signals, models.MetadataSignal{Kind: models.MetadataSignalAI, Detail: verdict.Reason})
+			}
+		}
+	}
+
+	signalsJSON, err := json.Marshal(signals)
+	if err != nil {
+		return nil, err
+	}
+	screen := &models.TokenMetadataScreen{
+		MintAddress: token.MintAddress,
+		Signals:     string(signalsJSON),
+		Suspicious:  flagsMetadata(signals),
+		AIChecked:   aiChecked,
+		ScreenedAt:  time.Now(),
+	}
+	if err := s.tokenRepo.SaveMetadataScreen(ctx, screen); err != nil {
+		return nil, fmt.Errorf("failed to save metadata screen: %w", err)
+	}
+
+	if screen.Suspicious {
+		if err := s.flags.FlagFromHeuristic(ctx, token.MintAddress, models.TokenFlagScam, metadataFlagReason(signals)); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": token.MintAddress,
+			}).Warn("Failed to flag token from metadata screen")
+		}
+	}
+	return screen, nil
+}
+
+// copiedMetadataSignals reports the earliest other tokens sharing the token's description or links
+func (s *metadataScreenService) copiedMetadataSignals(ctx context.Context, token *models.Token) ([]models.MetadataSignal, error) {
+	description := strings.TrimSpace(models.StringValue(token.Description))
+	if utf8.RuneCountInString(description) < minCopiedDescriptionLength {
+		description = ""
+	}
+	var links []string
+	if website := strings.TrimSpace(models.StringValue(token.Website)); website != "" {
+		links = append(links, website)
+	}
+	for _, link := range []*string{token.Twitter, token.Telegram} {
+		if value := strings.TrimSpace(models.StringValue(link)); specificLink(value) {
+			links = append(links, value)
+		}
+	}
+
+	others, err := s.tokenRepo.FindByMetadata(ctx, token.MintAddress, description, links, copiedMetadataLookupLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tokens with the same metadata: %w", err)
+	}
+
+	var signals []models.MetadataSignal
+	for _, other := range others {
+		var shared []string
+		if description != "" && strings.TrimSpace(models.StringValue(other.Description)) == description {
+			shared = append(shared, "description")
+		}
+		for _, link := range links {
+			if link == models.StringValue(other.Website) || link == models.StringValue(other.Twitter) || link == models.StringValue(other.Telegram) {
+				shared = append(shared, link)
+			}
+		}
+		if len(shared) == 0 {
+			continue
+		}
+		signals = append(signals, models.MetadataSignal{
+			Kind:   models.MetadataSignalCopied,
+			Detail: fmt.Sprintf("shares %s with %s (%s)", strings.Join(shared, ", "), other.Symbol, other.MintAddress),
+		})
+	}
+	return signals, nil
+}
+
+// impersonationSignals reports a symbol or name of a major project on a mint other than the project's
+func impersonationSignals(token *models.Token) []models.MetadataSignal {
+	symbol := strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(token.Symbol), "$"))
+	name := strings.ToLower(strings.TrimSpace(token.Name))
+
+	for _, project := range majorProjects {
+		if token.MintAddress == project.Mint {
+			return nil
+		}
+	}
+	for _, project := range majorProjects {
+		matched := symbol == project.Symbol
+		for _, projectName := range project.Names {
+			matched = matched || name == projectName
+		}
+		if matched {
+			return []models.MetadataSignal{{
+				Kind:   models.MetadataSignalImpersonation,
+				Detail: fmt.Sprintf("uses the symbol or name of %s, whose mint is %s", project.Symbol, project.Mint),
+			}}
+		}
+	}
+	return nil
+}
+
+// suspiciousURLSignals reports links hiding their target, lookalikes of major project domains, drainer pages
+// and social links pointing off their network
+func suspiciousURLSignals(token *models.Token) []models.MetadataSignal {
+	var signals []models.MetadataSignal
+	add := func(link, reason string) {
+		signals = append(signals, models.MetadataSignal{
+			Kind:   models.MetadataSignalSuspiciousURL,
+			Detail: fmt.Sprintf("%s: %s", link, reason),
+		})
+	}
+
+	check := func(link string, allowedHosts []string) {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			return
+		}
+		parsed, err := url.Parse(link)
+		if err != nil || parsed.Host == "" {
+			add(link, "not a valid URL")
+			return
+		}
+		host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+		lowered := strings.ToLower(link)
+
+		switch {
+		case len(allowedHosts) > 0 && !hostIn(host, allowedHosts):
+			add(link, fmt.Sprintf("does not point to %s", strings.Join(allowedHosts, " or ")))
+		case net.ParseIP(host) != nil:
+			add(link, "points to an IP address")
+		case strings.Contains(host, "xn--"):
+			add(link, "uses a punycode domain")
+		case urlShorteners[host]:
+			add(link, "uses a URL shortener")
+		case parsed.Scheme != "https":
+			add(link, "is not served over HTTPS")
+		}
+		for _, keyword := range drainerKeywords {
+			if strings.Contains(lowered, keyword) {
+				add(link, fmt.Sprintf("mentions %q", keyword))
+				break
+			}
+		}
+		if len(allowedHosts) == 0 {
+			for _, project := range majorProjects {
+				if strings.Contains(host, project.Brand) && !hostIn(host, project.Domains) {
+					add(link, fmt.Sprintf("looks like a %s domain", project.Symbol))
+					break
+				}
+			}
+		}
+	}
+
+	check(models.StringValue(token.Website), nil)
+	check(models.StringValue(token.Twitter), twitterHosts)
+	check(models.StringValue(token.Telegram), telegramHosts)
+	return signals
+}
+
+// metadataWarnings turns a stored screen into risk warnings
+func metadataWarnings(screen *models.TokenMetadataScreen) []string {
+	var signals []models.MetadataSignal
+	if err := json.Unmarshal([]byte(screen.Signals), &signals); err != nil {
+		return nil
+	}
+	warnings := make([]string, len(signals))
+	for i, signal := range signals {
+		warnings[i] = fmt.Sprintf("Token metadata %s: %s", strings.ReplaceAll(string(signal.Kind), "_", " "), signal.Detail)
+	}
+	return warnings
+}
+
+// flagsMetadata reports whether the signals are strong enough to flag the token as a scam
+func flagsMetadata(signals []models.MetadataSignal) bool {
+	for _, signal := range signals {
+		if signal.Kind == models.MetadataSignalImpersonation {
+			return true
+		}
+	}
+	return len(signals) >= metadataFlagSignals
+}
+
+func metadataFlagReason(signals []models.MetadataSignal) string {
+	details := make([]string, len(signals))
+	for i, signal := range signals {
+		details[i] = fmt.Sprintf("%s (%s)", signal.Kind, signal.Detail)
+	}
+	return "Metadata screen: " + strings.Join(details, "; ")
+}
+
+func hasMetadata(token *models.Token) bool {
+	return models.StringValue(token.Description) != "" || models.StringValue(token.Website) != "" ||
+		models.StringValue(token.Twitter) != "" || models.StringValue(token.Telegram) != ""
+}
+
+// specificLink reports whether a social link names an account rather than the bare network
+func specificLink(link string) bool {
+	parsed, err := url.Parse(link)
+	return err == nil && parsed.Host != "" && strings.Trim(parsed.Path, "/") != ""
+}
+
+// hostIn reports whether host is one of the domains or a subdomain of one
+func hostIn(host string, domains []string) bool {
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
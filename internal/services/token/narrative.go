@@ -0,0 +1,121 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// NarrativeClassifier tags a token with narratives from its symbol, name and description
+type NarrativeClassifier interface {
+	ClassifyNarratives(ctx context.Context, token *models.Token) ([]models.TokenNarrative, error)
+}
+
+// narrativeKeywords are matched as whole words when the classifier is unavailable or undecided
+var narrativeKeywords = map[models.TokenNarrative][]string{
+	models.TokenNarrativeMeme:   {"meme", "dog", "doge", "inu", "cat", "pepe", "frog", "shib", "bonk", "wif", "moon"},
+	models.TokenNarrativeDeFi:   {"defi", "swap", "dex", "lend", "lending", "yield", "stake", "staking", "vault", "finance", "amm", "perp", "perps"},
+	models.TokenNarrativeAI:     {"ai", "agent", "agents", "gpt", "llm", "neural", "intelligence", "bot"},
+	models.TokenNarrativeInfra:  {"infra", "oracle", "bridge", "rpc", "node", "layer", "network", "protocol", "storage", "compute", "depin"},
+	models.TokenNarrativeGaming: {"game", "gaming", "play", "metaverse", "nft", "arena", "quest"},
+}
+
+// heuristicNarratives matches narrative keywords against the token's symbol, name and description
+func heuristicNarratives(token *models.Token) []models.TokenNarrative {
+	words := make(map[string]bool)
+	text := strings.ToLower(strings.Join([]string{token.Symbol, token.Name, models.StringValue(token.Description)}, " "))
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		words[word] = true
+	}
+
+	var narratives []models.TokenNarrative
+	for _, narrative := range models.TokenNarratives {
+		for _, keyword := range narrativeKeywords[narrative] {
+			if words[keyword] {
+				narratives = append(narratives, narrative)
+				break
+			}
+		}
+	}
+	return narratives
+}
+
+// tagNarratives classifies a token and stores its narratives, preferring the AI classifier over keyword heuristics.
+// Failures are logged; a token without narratives is retried on its next sync.
+func (s *marketService) tagNarratives(ctx context.Context, token *models.Token) {
+	var narratives []models.TokenNarrative
+	source := models.TokenNarrativeSourceHeuristic
+	if s.classifier != nil {
+		classified, err := s.classifier.ClassifyNarratives(ctx, token)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": token.MintAddress,
+			}).Warn("Failed to classify token narratives, falling back to keywords")
+		} else if len(classified) > 0 {
+			narratives = classified
+			source = models.TokenNarrativeSourceAI
+		}
+	}
+	if len(narratives) == 0 {
+		narratives = heuristicNarratives(token)
+	}
+	if len(narratives) == 0 {
+		narratives = []models.TokenNarrative{models.TokenNarrativeOther}
+	}
+
+	tags := make([]*models.TokenNarrativeTag, len(narratives))
+	for i, narrative := range narratives {
+		tags[i] = &models.TokenNarrativeTag{Narrative: narrative, Source: source}
+	}
+	if err := s.tokenRepo.SaveNarratives(ctx, token.ID, tags); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": token.MintAddress,
+		}).Warn("Failed to save token narratives")
+		return
+	}
+	token.Narratives = narratives
+}
+
+// ensureNarratives tags the token unless it already has narratives
+func (s *marketService) ensureNarratives(ctx context.Context, token *models.Token) {
+	tags, err := s.tokenRepo.GetNarratives(ctx, []uuid.UUID{token.ID})
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err,
+			"mint_address": token.MintAddress,
+		}).Warn("Failed to get token narratives")
+		return
+	}
+	if len(tags) == 0 {
+		s.tagNarratives(ctx, token)
+	}
+}
+
+// fillNarratives sets the stored narratives of the given tokens
+func (s *marketService) fillNarratives(ctx context.Context, tokens []*models.Token) error {
+	ids := make([]uuid.UUID, len(tokens))
+	for i, token := range tokens {
+		ids[i] = token.ID
+	}
+	tags, err := s.tokenRepo.GetNarratives(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get token narratives: %w", err)
+	}
+
+	byToken := make(map[uuid.UUID][]models.TokenNarrative, len(tokens))
+	for _, tag := range tags {
+		byToken[tag.TokenID] = append(byToken[tag.TokenID], tag.Narrative)
+	}
+	for _, token := range tokens {
+		token.Narratives = byToken[token.ID]
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+)
+
+// ErrPriceUnavailable is returned when the price feed has not recorded a recent price yet
+var ErrPriceUnavailable = errors.New("price not available")
+
+const (
+	defaultPythBaseURL  = "https://hermes.pyth.network"
+	defaultPythTimeout  = 10 * time.Second
+	assetPriceMaxAge    = 5 * time.Minute    // older prices are reported stale
+	assetPriceRetention = 7 * 24 * time.Hour // price history kept by the feed
+	priceFeedSourcePyth = "pyth"
+	priceFeedSourceJup  = "jupiter"
+	usdcMint            = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	usdtMint            = "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"
+	solQuoteLamports    = 1_000_000_000 // 1 SOL quoted into USDC for the Jupiter fallback
+	usdcBaseUnits       = 1_000_000
+	solQuoteSlippageBps = 50
+)
+
+// majorAsset is an asset the price feed tracks, with its Pyth USD price feed ID
+type majorAsset struct {
+	Symbol     string
+	Mint       string
+	PythFeedID string
+}
+
+var majorAssets = []majorAsset{
+	{Symbol: "SOL", Mint: wrappedSOLMint, PythFeedID: "ef0d8b6fda2ceba41da15d4095d1da392a0d2f8ed0c6c7bc0f4cfac8c280b56d"},
+	{Symbol: "USDC", Mint: usdcMint, PythFeedID: "eaa020c61cc479712813461ce153894a96a6c00b21ed0cfc2798d1f9a9e9c94a"},
+	{Symbol: "USDT", Mint: usdtMint, PythFeedID: "2b89b9dc8fdf9f34709a5b106b472f0f39bb6ca9ce04b0fd7f2e971688e2e53b"},
+}
+
+// MajorAssetPrice is the latest recorded USD price of a major asset
+type MajorAssetPrice struct {
+	Symbol      string    `json:"symbol"`
+	MintAddress string    `json:"mint_address"`
+	PriceUSD    float64   `json:"price_usd"`
+	Confidence  float64   `json:"confidence"` // Pyth confidence interval in USD, 0 for Jupiter quotes
+	Source      string    `json:"source"`
+	FetchedAt   time.Time `json:"fetched_at"`
+	Stale       bool      `json:"stale"` // older than five minutes, e.g. while the feed is failing
+}
+
+// SOLPrice is the SOL price with the stablecoin prices it is quoted against
+type SOLPrice struct {
+	*MajorAssetPrice
+	Stablecoins []*MajorAssetPrice `json:"stablecoins"`
+}
+
+// PriceFeedService records USD prices of SOL, USDC, USDT and the configured reference feeds from Pyth,
+// falling back to a Jupiter quote for SOL
+type PriceFeedService interface {
+	Refresh(ctx context.Context) ([]*models.AssetPrice, error) // fetches and stores the prices, pruning old history
+	GetSOLPrice(ctx context.Context) (*SOLPrice, error)        // ErrPriceUnavailable until SOL was priced
+}
+
+type priceFeedService struct {
+	tokenRepo  repositories.TokenRepository
+	jupiter    JupiterService
+	config     *config.PythConfig
+	feeds      []majorAsset // major assets followed by the configured reference feeds
+	httpClient *http.Client
+	logger     *logrus.Logger
+}
+
+// NewPriceFeedService creates a new major asset price feed
+func NewPriceFeedService(tokenRepo repositories.TokenRepository, jupiter JupiterService, config *config.PythConfig, apiStats *apistats.Registry, logger *logrus.Logger) PriceFeedService {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultPythTimeout
+	}
+
+	feeds := append([]majorAsset{}, majorAssets...)
+	for _, feed := range config.Feeds {
+		feedID := strings.TrimPrefix(strings.ToLower(feed.FeedID), "0x")
+		if feed.Mint == "" || feedID == "" {
+			logger.WithField("symbol", feed.Symbol).Warn("Skipping Pyth feed without mint or feed ID")
+			continue
+		}
+		feeds = append(feeds, majorAsset{Symbol: feed.Symbol, Mint: feed.Mint, PythFeedID: feedID})
+	}
+
+	return &priceFeedService{
+		tokenRepo:  tokenRepo,
+		jupiter:    jupiter,
+		config:     config,
+		feeds:      feeds,
+		httpClient: &http.Client{Timeout: timeout, Transport: apiStats.Transport("pyth", nil)},
+		logger:     logger,
+	}
+}
+
+func (s *priceFeedService) Refresh(ctx context.Context) ([]*models.AssetPrice, error) {
+	prices, err := s.fetchPyth(ctx)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to fetch Pyth prices, quoting SOL through Jupiter")
+	}
+
+	// SOL prices trades server-side, so it is quoted through Jupiter when Pyth did not return it
+	hasSOL := false
+	for _, price := range prices {
+		if price.MintAddress == wrappedSOLMint {
+			hasSOL = true
+		}
+	}
+	if !hasSOL {
+		price, jupErr := s.quoteSOL(ctx)
+		if jupErr != nil {
+			return nil, fmt.Errorf("failed to price SOL: %w", errors.Join(err, jupErr))
+		}
+		prices = append(prices, price)
+	}
+
+	if err := s.tokenRepo.CreateAssetPrices(ctx, prices); err != nil {
+		return nil, fmt.Errorf("failed to store asset prices: %w", err)
+	}
+	if _, err := s.tokenRepo.DeleteAssetPricesBefore(ctx, time.Now().UTC().Add(-assetPriceRetention)); err != nil {
+		s.logger.WithError(err).Warn("Failed to prune asset price history")
+	}
+	return prices, nil
+}
+
+func (s *priceFeedService) GetSOLPrice(ctx context.Context) (*SOLPrice, error) {
+	var result SOLPrice
+	for _, asset := range majorAssets {
+		stored, err := s.tokenRepo.GetLatestAssetPrice(ctx, asset.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s price: %w", asset.Symbol, err)
+		}
+		if stored == nil {
+			continue
+		}
+
+		price := &MajorAssetPrice{
+			Symbol:      stored.Symbol,
+			MintAddress: stored.MintAddress,
+			PriceUSD:    stored.PriceUSD,
+			Confidence:  stored.Confidence,
+			Source:      stored.Source,
+			FetchedAt:   stored.FetchedAt,
+			Stale:       time.Since(stored.FetchedAt) > assetPriceMaxAge,
+		}
+		if asset.Mint == wrappedSOLMint {
+			result.MajorAssetPrice = price
+		} else {
+			result.Stablecoins = append(result.Stablecoins, price)
+		}
+	}
+	if result.MajorAssetPrice == nil {
+		return nil, ErrPriceUnavailable
+	}
+	return &result, nil
+}
+
+type pythPriceResponse struct {
+	Parsed []struct {
+		ID    string `json:"id"`
+		Price struct {
+			Price       string `json:"price"`
+			Conf        string `json:"conf"`
+			Expo        int    `json:"expo"`
+			PublishTime int64  `json:"publish_time"`
+		} `json:"price"`
+	} `json:"parsed"`
+}
+
+// fetchPyth reads the latest Hermes prices of every feed
+func (s *priceFeedService) fetchPyth(ctx context.Context) ([]*models.AssetPrice, error) {
+	baseURL := s.config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultPythBaseURL
+	}
+
+	query := url.Values{}
+	for _, asset := range s.feeds {
+		query.Add("ids[]", asset.PythFeedID)
+	}
+	query.Set("parsed", "true")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/v2/updates/price/latest?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "solana-wallet-service/1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Pyth returned status %d", resp.StatusCode)
+	}
+
+	var body pythPriceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode prices: %w", err)
+	}
+
+	var prices []*models.AssetPrice
+	for _, parsed := range body.Parsed {
+		feedID := strings.TrimPrefix(strings.ToLower(parsed.ID), "0x")
+		for _, asset := range s.feeds {
+			if asset.PythFeedID != feedID {
+				continue
+			}
+			mantissa, err := strconv.ParseInt(parsed.Price.Price, 10, 64)
+			if err != nil || mantissa <= 0 {
+				s.logger.WithField("symbol", asset.Symbol).Warn("Pyth returned an invalid price")
+				break
+			}
+			conf, _ := strconv.ParseInt(parsed.Price.Conf, 10, 64)
+			prices = append(prices, &models.AssetPrice{
+				MintAddress: asset.Mint,
+				Symbol:      asset.Symbol,
+				PriceUSD:    float64(mantissa) * math.Pow10(parsed.Price.Expo),
+				Confidence:  float64(conf) * math.Pow10(parsed.Price.Expo),
+				Source:      priceFeedSourcePyth,
+				FetchedAt:   time.Unix(parsed.Price.PublishTime, 0).UTC(),
+			})
+		}
+	}
+	return prices, nil
+}
+
+// quoteSOL prices SOL by quoting 1 SOL into USDC
+func (s *priceFeedService) quoteSOL(ctx context.Context) (*models.AssetPrice, error) {
+	quote, err := s.jupiter.GetQuote(ctx, wrappedSOLMint, usdcMint, solQuoteLamports, solQuoteSlippageBps)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AssetPrice{
+		MintAddress: wrappedSOLMint,
+		Symbol:      "SOL",
+		PriceUSD:    float64(quote.OutAmount) / usdcBaseUnits,
+		Source:      priceFeedSourceJup,
+		FetchedAt:   time.Now().UTC(),
+	}, nil
+}
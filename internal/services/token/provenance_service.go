@@ -0,0 +1,235 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+	"github.com/emiyaio/solana-wallet-service/internal/services/label"
+)
+
+const (
+	// Maximum signature pages (1000 each) scanned when searching for a mint's creation
+	provenanceMaxSignaturePages = 20
+	// How long a deployer's token list is served from cache before being refreshed
+	deployerTokensCacheTTL = 6 * time.Hour
+	// Maximum number of sibling tokens returned for a deployer
+	deployerTokensLimit = 100
+)
+
+// ProvenanceService resolves who deployed a token and what else they deployed
+type ProvenanceService interface {
+	GetProvenance(ctx context.Context, mintAddress string) (*TokenProvenanceResult, error)
+}
+
+type provenanceService struct {
+	tokenRepo            repositories.TokenRepository
+	labelRepo            repositories.WalletLabelRepository
+	transactionProcessor blockchain.TransactionProcessor
+	solanaTrackerService SolanaTrackerService
+	logger               *logrus.Logger
+}
+
+// NewProvenanceService creates a new provenance service instance
+func NewProvenanceService(
+	tokenRepo repositories.TokenRepository,
+	labelRepo repositories.WalletLabelRepository,
+	transactionProcessor blockchain.TransactionProcessor,
+	solanaTrackerService SolanaTrackerService,
+	logger *logrus.Logger,
+) ProvenanceService {
+	return &provenanceService{
+		tokenRepo:            tokenRepo,
+		labelRepo:            labelRepo,
+		transactionProcessor: transactionProcessor,
+		solanaTrackerService: solanaTrackerService,
+		logger:               logger,
+	}
+}
+
+// TokenProvenanceResult describes the origin of a token
+type TokenProvenanceResult struct {
+	MintAddress       string                    `json:"mint_address"`
+	DeployerAddress   string                    `json:"deployer_address"`
+	DeployerLabels    []string                  `json:"deployer_labels,omitempty"`
+	CreationSignature string                    `json:"creation_signature"`
+	CreationSlot      int64                     `json:"creation_slot"`
+	CreationTime      *time.Time                `json:"creation_time,omitempty"`
+	OtherTokens       []*models.TokenProvenance `json:"other_tokens"`
+	Timestamp         time.Time                 `json:"timestamp"`
+}
+
+func (s *provenanceService) GetProvenance(ctx context.Context, mintAddress string) (*TokenProvenanceResult, error) {
+	provenance, err := s.tokenRepo.GetProvenance(ctx, mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provenance: %w", err)
+	}
+
+	// Creation data never changes, so it is only resolved on-chain once
+	if provenance == nil || provenance.CreationSignature == "" {
+		provenance, err = s.resolveCreation(ctx, mintAddress, provenance)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if provenance.DeployerSyncedAt == nil || time.Since(*provenance.DeployerSyncedAt) > deployerTokensCacheTTL {
+		if err := s.syncDeployerTokens(ctx, provenance); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":    err,
+				"deployer": provenance.DeployerAddress,
+			}).Warn("Failed to refresh deployer tokens, serving cached data")
+		}
+	}
+
+	siblings, err := s.tokenRepo.GetProvenanceByDeployer(ctx, provenance.DeployerAddress, deployerTokensLimit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployer tokens: %w", err)
+	}
+	otherTokens := make([]*models.TokenProvenance, 0, len(siblings))
+	for _, sibling := range siblings {
+		if sibling.MintAddress != mintAddress && len(otherTokens) < deployerTokensLimit {
+			otherTokens = append(otherTokens, sibling)
+		}
+	}
+
+	labels, err := label.LookupLabels(ctx, s.labelRepo, []string{provenance.DeployerAddress})
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"deployer": provenance.DeployerAddress,
+		}).Warn("Failed to look up deployer labels")
+	}
+
+	return &TokenProvenanceResult{
+		MintAddress:       mintAddress,
+		DeployerAddress:   provenance.DeployerAddress,
+		DeployerLabels:    labels[provenance.DeployerAddress],
+		CreationSignature: provenance.CreationSignature,
+		CreationSlot:      provenance.CreationSlot,
+		CreationTime:      provenance.CreationTime,
+		OtherTokens:       otherTokens,
+		Timestamp:         time.Now(),
+	}, nil
+}
+
+// resolveCreation finds the mint's first transaction and records its fee payer as the deployer
+func (s *provenanceService) resolveCreation(ctx context.Context, mintAddress string, provenance *models.TokenProvenance) (*models.TokenProvenance, error) {
+	creation, err := s.transactionProcessor.FindCreationSignature(mintAddress, provenanceMaxSignaturePages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find creation signature: %w", err)
+	}
+	if creation == nil {
+		return nil, fmt.Errorf("no transactions found for mint %s", mintAddress)
+	}
+
+	details, err := s.transactionProcessor.GetTransactionDetails(creation.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get creation transaction: %w", err)
+	}
+	if len(details.Transaction.Message.AccountKeys) == 0 {
+		return nil, fmt.Errorf("creation transaction %s has no account keys", creation.Signature)
+	}
+
+	if provenance == nil {
+		provenance = &models.TokenProvenance{MintAddress: mintAddress}
+	}
+	provenance.DeployerAddress = details.Transaction.Message.AccountKeys[0]
+	provenance.CreationSignature = creation.Signature
+	provenance.CreationSlot = details.Slot
+	creationTime := time.Unix(details.BlockTime, 0)
+	provenance.CreationTime = &creationTime
+
+	if token, err := s.tokenRepo.GetByMintAddress(ctx, mintAddress); err == nil && token != nil {
+		provenance.Symbol = token.Symbol
+		provenance.Name = token.Name
+	}
+
+	if err := s.tokenRepo.SaveProvenance(ctx, provenance); err != nil {
+		return nil, fmt.Errorf("failed to save provenance: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"deployer":     provenance.DeployerAddress,
+		"slot":         provenance.CreationSlot,
+	}).Info("Resolved token provenance")
+
+	return provenance, nil
+}
+
+// syncDeployerTokens caches the deployer's other tokens as provenance records
+func (s *provenanceService) syncDeployerTokens(ctx context.Context, provenance *models.TokenProvenance) error {
+	response, err := s.solanaTrackerService.GetDeployerTokens(ctx, provenance.DeployerAddress)
+	if err != nil {
+		return err
+	}
+
+	for _, deployed := range response.Tokens {
+		if deployed.Mint == provenance.MintAddress {
+			continue
+		}
+
+		existing, err := s.tokenRepo.GetProvenance(ctx, deployed.Mint)
+		if err != nil {
+			return fmt.Errorf("failed to get provenance: %w", err)
+		}
+		if existing == nil {
+			existing = &models.TokenProvenance{MintAddress: deployed.Mint}
+		}
+		existing.DeployerAddress = provenance.DeployerAddress
+		existing.Symbol = deployed.Symbol
+		existing.Name = deployed.Name
+		if existing.CreationTime == nil && deployed.CreatedAt > 0 {
+			createdAt := time.UnixMilli(deployed.CreatedAt)
+			existing.CreationTime = &createdAt
+		}
+
+		if err := s.tokenRepo.SaveProvenance(ctx, existing); err != nil {
+			return fmt.Errorf("failed to save provenance for %s: %w", deployed.Mint, err)
+		}
+	}
+
+	now := time.Now()
+	provenance.DeployerSyncedAt = &now
+	return s.tokenRepo.SaveProvenance(ctx, provenance)
+}
+
+// ProvenanceRisk scores how suspicious a token's origin is, from 0 (clean) to 1
+func ProvenanceRisk(result *TokenProvenanceResult) (float64, []string) {
+	var risk float64
+	var warnings []string
+
+	for _, l := range result.DeployerLabels {
+		if models.WalletLabelType(l) == models.WalletLabelKnownScammer {
+			return 1.0, []string{"Deployer is a known scammer"}
+		}
+	}
+
+	switch deployed := len(result.OtherTokens); {
+	case deployed >= 20:
+		risk += 0.5
+		warnings = append(warnings, fmt.Sprintf("Serial deployer: %d other tokens from the same wallet", deployed))
+	case deployed >= 5:
+		risk += 0.3
+		warnings = append(warnings, fmt.Sprintf("Deployer has launched %d other tokens", deployed))
+	case deployed >= 1:
+		risk += 0.1
+	}
+
+	if result.CreationTime != nil {
+		age := time.Since(*result.CreationTime)
+		if age < 24*time.Hour {
+			risk += 0.3
+			warnings = append(warnings, "Token was created less than 24 hours ago")
+		} else if age < 7*24*time.Hour {
+			risk += 0.15
+		}
+	}
+
+	return risk, warnings
+}
@@ -0,0 +1,150 @@
+package token
+
+import (
+	"math"
+
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// ScoringWeights is the resolved weight set of the analysis scores; it is returned with each analysis so scores can be explained
+type ScoringWeights struct {
+	Overall struct {
+		Price    float64 `json:"price"`
+		Volume   float64 `json:"volume"`
+		Momentum float64 `json:"momentum"`
+	} `json:"overall"`
+	Momentum struct {
+		Change1h  float64 `json:"change_1h"`
+		Change24h float64 `json:"change_24h"`
+		Change7d  float64 `json:"change_7d"`
+	} `json:"momentum"`
+	Risk struct {
+		Liquidity  float64 `json:"liquidity"`
+		Volatility float64 `json:"volatility"`
+		Market     float64 `json:"market"`
+		Technical  float64 `json:"technical"`
+	} `json:"risk"`
+	PriceChangeFactor  float64 `json:"price_change_factor"`
+	VolumeChangeFactor float64 `json:"volume_change_factor"`
+	ProvenanceWeight   float64 `json:"provenance_weight"`
+	SocialWeight       float64 `json:"social_weight"`
+	BuyScore           float64 `json:"buy_score"`
+	SellScore          float64 `json:"sell_score"`
+	BuyMaxRisk         float64 `json:"buy_max_risk"`
+	SellMinRisk        float64 `json:"sell_min_risk"`
+	LowRisk            float64 `json:"low_risk"`
+	HighRisk           float64 `json:"high_risk"`
+	BullishSentiment   float64 `json:"bullish_sentiment"`
+}
+
+// NewScoringWeights resolves the scoring config, falling back to defaults for zero values and normalizing each weight group
+func NewScoringWeights(cfg *config.ScoringConfig) *ScoringWeights {
+	if cfg == nil {
+		cfg = &config.ScoringConfig{}
+	}
+	w := &ScoringWeights{}
+
+	w.Overall.Price, w.Overall.Volume, w.Overall.Momentum = normalizeWeights3(
+		orDefault(cfg.Overall.Price, 0.3),
+		orDefault(cfg.Overall.Volume, 0.3),
+		orDefault(cfg.Overall.Momentum, 0.4),
+	)
+	w.Momentum.Change1h, w.Momentum.Change24h, w.Momentum.Change7d = normalizeWeights3(
+		orDefault(cfg.Momentum.Change1h, 0.2),
+		orDefault(cfg.Momentum.Change24h, 0.5),
+		orDefault(cfg.Momentum.Change7d, 0.3),
+	)
+
+	risk := []float64{
+		orDefault(cfg.Risk.Liquidity, 0.25),
+		orDefault(cfg.Risk.Volatility, 0.35),
+		orDefault(cfg.Risk.Market, 0.2),
+		orDefault(cfg.Risk.Technical, 0.2),
+	}
+	normalizeWeights(risk)
+	w.Risk.Liquidity, w.Risk.Volatility, w.Risk.Market, w.Risk.Technical = risk[0], risk[1], risk[2], risk[3]
+
+	w.PriceChangeFactor = orDefault(cfg.PriceChangeFactor, 2)
+	w.VolumeChangeFactor = orDefault(cfg.VolumeChangeFactor, 0.5)
+	w.ProvenanceWeight = clampWeight(orDefault(cfg.ProvenanceWeight, 0.2))
+	w.SocialWeight = clampWeight(orDefault(cfg.SocialWeight, 0.3))
+	w.BuyScore = orDefault(cfg.BuyScore, 70)
+	w.SellScore = orDefault(cfg.SellScore, 30)
+	w.BuyMaxRisk = orDefault(cfg.BuyMaxRisk, 50)
+	w.SellMinRisk = orDefault(cfg.SellMinRisk, 80)
+	w.LowRisk = orDefault(cfg.LowRisk, 30)
+	w.HighRisk = orDefault(cfg.HighRisk, 70)
+	w.BullishSentiment = orDefault(cfg.BullishSentiment, 0.3)
+
+	// Cutoffs that cross over would make every token a buy and a sell at once
+	if w.SellScore >= w.BuyScore {
+		w.BuyScore, w.SellScore = 70, 30
+	}
+	if w.LowRisk >= w.HighRisk {
+		w.LowRisk, w.HighRisk = 30, 70
+	}
+
+	return w
+}
+
+// marketScores computes the price, volume and momentum scores of market data and their weighted overall score, all 0-100
+func marketScores(data *models.TokenMarketData, weights *ScoringWeights) (price, volume, momentum, overall float64) {
+	// Price score: base 50, adjusted by the 24h price change
+	price = math.Max(0, math.Min(100, 50+data.PriceChange24h*weights.PriceChangeFactor))
+
+	// Volume score: base 50, adjusted by the 24h volume change
+	volume = math.Max(0, math.Min(100, 50+data.VolumeChange24h*weights.VolumeChangeFactor))
+
+	// Momentum score: base 50, adjusted by the weighted price changes
+	momentum = 50 + data.PriceChange1h*weights.Momentum.Change1h + data.PriceChange24h*weights.Momentum.Change24h +
+		data.PriceChange7d*weights.Momentum.Change7d
+	momentum = math.Max(0, math.Min(100, momentum))
+
+	overall = price*weights.Overall.Price + volume*weights.Overall.Volume + momentum*weights.Overall.Momentum
+	return price, volume, momentum, overall
+}
+
+// recommendAction maps an overall score to buy, sell or hold
+func recommendAction(score float64, weights *ScoringWeights) string {
+	if score >= weights.BuyScore {
+		return "buy"
+	} else if score <= weights.SellScore {
+		return "sell"
+	}
+	return "hold"
+}
+
+func orDefault(value, fallback float64) float64 {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func clampWeight(weight float64) float64 {
+	if weight > 1 {
+		return 1
+	}
+	return weight
+}
+
+func normalizeWeights3(a, b, c float64) (float64, float64, float64) {
+	weights := []float64{a, b, c}
+	normalizeWeights(weights)
+	return weights[0], weights[1], weights[2]
+}
+
+// normalizeWeights scales the weights in place to sum to 1
+func normalizeWeights(weights []float64) {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total <= 0 {
+		return
+	}
+	for i := range weights {
+		weights[i] /= total
+	}
+}
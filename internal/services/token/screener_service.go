@@ -0,0 +1,284 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+var (
+	ErrInvalidScreenerFilter  = errors.New("invalid screener filter")
+	ErrScreenerPresetNotFound = errors.New("screener preset not found")
+	ErrTooManyScreenerPresets = errors.New("too many screener presets")
+)
+
+const (
+	// Maximum number of conditions in one screener expression
+	maxScreenerConditions = 10
+	// Maximum number of presets a wallet may save
+	maxScreenerPresets = 20
+)
+
+// screenerMultipliers are the magnitude suffixes accepted on metric values, e.g. 1.5M
+var screenerMultipliers = map[byte]float64{
+	'k': 1e3,
+	'm': 1e6,
+	'b': 1e9,
+}
+
+// screenerAgeUnits are the duration suffixes accepted on age values, e.g. 7d
+var screenerAgeUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// ScreenerService screens tokens with composable metric filters and keeps per-wallet presets
+type ScreenerService interface {
+	Screen(ctx context.Context, filters, sort, order string, limit, offset int) ([]*models.Token, error)
+	RunPreset(ctx context.Context, walletAddress string, presetID uuid.UUID, limit, offset int) ([]*models.Token, *models.ScreenerPreset, error)
+	SavePreset(ctx context.Context, walletAddress string, req *SaveScreenerPresetRequest) (*models.ScreenerPreset, error)
+	ListPresets(ctx context.Context, walletAddress string) ([]*models.ScreenerPreset, error)
+	DeletePreset(ctx context.Context, walletAddress string, presetID uuid.UUID) error
+}
+
+type screenerService struct {
+	tokenRepo     repositories.TokenRepository
+	marketService MarketService
+	logger        *logrus.Logger
+}
+
+// NewScreenerService creates a new token screener service instance
+func NewScreenerService(tokenRepo repositories.TokenRepository, marketService MarketService, logger *logrus.Logger) ScreenerService {
+	return &screenerService{
+		tokenRepo:     tokenRepo,
+		marketService: marketService,
+		logger:        logger,
+	}
+}
+
+// SaveScreenerPresetRequest saves a screener under a name; saving an existing name overwrites it
+type SaveScreenerPresetRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	Filters string `json:"filters" binding:"required,max=500"` // e.g. marketCap>1M,volume24h>100k,age<7d
+	Sort    string `json:"sort"`
+	Order   string `json:"order"`
+}
+
+func (s *screenerService) Screen(ctx context.Context, filters, sort, order string, limit, offset int) ([]*models.Token, error) {
+	conditions, err := ParseScreenerFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	tokenSort, err := parseTokenSort(sort, order)
+	if err != nil {
+		return nil, err
+	}
+	return s.marketService.SearchTokens(ctx, repositories.TokenFilter{Conditions: conditions}, tokenSort, limit, offset)
+}
+
+func (s *screenerService) RunPreset(ctx context.Context, walletAddress string, presetID uuid.UUID, limit, offset int) ([]*models.Token, *models.ScreenerPreset, error) {
+	preset, err := s.getPreset(ctx, walletAddress, presetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	tokens, err := s.Screen(ctx, preset.Filters, preset.Sort, preset.SortOrder, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, preset, nil
+}
+
+func (s *screenerService) SavePreset(ctx context.Context, walletAddress string, req *SaveScreenerPresetRequest) (*models.ScreenerPreset, error) {
+	// Presets are validated on save so running one cannot fail on its own expression
+	if _, err := ParseScreenerFilters(req.Filters); err != nil {
+		return nil, err
+	}
+	if _, err := parseTokenSort(req.Sort, req.Order); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidScreenerFilter)
+	}
+
+	presets, err := s.tokenRepo.ListScreenerPresets(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list screener presets: %w", err)
+	}
+	overwrites := false
+	for _, existing := range presets {
+		if existing.Name == name {
+			overwrites = true
+			break
+		}
+	}
+	if !overwrites && len(presets) >= maxScreenerPresets {
+		return nil, fmt.Errorf("%w: at most %d per wallet", ErrTooManyScreenerPresets, maxScreenerPresets)
+	}
+
+	preset := &models.ScreenerPreset{
+		WalletAddress: walletAddress,
+		Name:          name,
+		Filters:       req.Filters,
+		Sort:          req.Sort,
+		SortOrder:     req.Order,
+	}
+	if err := s.tokenRepo.SaveScreenerPreset(ctx, preset); err != nil {
+		return nil, fmt.Errorf("failed to save screener preset: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"wallet":    walletAddress,
+		"preset_id": preset.ID,
+		"name":      name,
+	}).Info("Screener preset saved")
+
+	return preset, nil
+}
+
+func (s *screenerService) ListPresets(ctx context.Context, walletAddress string) ([]*models.ScreenerPreset, error) {
+	return s.tokenRepo.ListScreenerPresets(ctx, walletAddress)
+}
+
+func (s *screenerService) DeletePreset(ctx context.Context, walletAddress string, presetID uuid.UUID) error {
+	if _, err := s.getPreset(ctx, walletAddress, presetID); err != nil {
+		return err
+	}
+	return s.tokenRepo.DeleteScreenerPreset(ctx, presetID)
+}
+
+// getPreset loads a preset owned by the wallet; other wallets' presets are reported as not found
+func (s *screenerService) getPreset(ctx context.Context, walletAddress string, presetID uuid.UUID) (*models.ScreenerPreset, error) {
+	preset, err := s.tokenRepo.GetScreenerPreset(ctx, presetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get screener preset: %w", err)
+	}
+	if preset == nil || preset.WalletAddress != walletAddress {
+		return nil, ErrScreenerPresetNotFound
+	}
+	return preset, nil
+}
+
+// ParseScreenerFilters parses a comma-separated screener expression such as
+// "marketCap>1M,volume24h>=100k,priceChange24h>10,holderCount>500,age<7d".
+// Metric values accept k, M and B suffixes; age values need a unit of m, h, d or w.
+func ParseScreenerFilters(expr string) ([]repositories.ScreenerCondition, error) {
+	var conditions []repositories.ScreenerCondition
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		cond, err := parseScreenerCondition(term)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, cond)
+	}
+
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("%w: at least one condition is required", ErrInvalidScreenerFilter)
+	}
+	if len(conditions) > maxScreenerConditions {
+		return nil, fmt.Errorf("%w: at most %d conditions allowed", ErrInvalidScreenerFilter, maxScreenerConditions)
+	}
+	return conditions, nil
+}
+
+func parseScreenerCondition(term string) (repositories.ScreenerCondition, error) {
+	i := strings.IndexAny(term, "<>=")
+	if i < 0 {
+		return repositories.ScreenerCondition{}, fmt.Errorf("%w: %q has no comparison operator", ErrInvalidScreenerFilter, term)
+	}
+	op := term[i : i+1]
+	if strings.HasPrefix(term[i:], ">=") || strings.HasPrefix(term[i:], "<=") {
+		op = term[i : i+2]
+	}
+
+	field, ok := lookupScreenerField(strings.TrimSpace(term[:i]))
+	if !ok {
+		return repositories.ScreenerCondition{}, fmt.Errorf("%w: unknown field in %q, expected one of marketCap, volume24h, priceChange24h, holderCount, liquidity, age", ErrInvalidScreenerFilter, term)
+	}
+	value, err := parseScreenerValue(field, strings.TrimSpace(term[i+len(op):]))
+	if err != nil {
+		return repositories.ScreenerCondition{}, fmt.Errorf("%w: %q: %v", ErrInvalidScreenerFilter, term, err)
+	}
+	return repositories.ScreenerCondition{Field: field, Operator: op, Value: value}, nil
+}
+
+func lookupScreenerField(name string) (repositories.ScreenerField, bool) {
+	for _, field := range []repositories.ScreenerField{
+		repositories.ScreenerMarketCap,
+		repositories.ScreenerVolume24h,
+		repositories.ScreenerPriceChange24h,
+		repositories.ScreenerHolderCount,
+		repositories.ScreenerLiquidity,
+		repositories.ScreenerAge,
+	} {
+		if strings.EqualFold(name, string(field)) {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+// parseScreenerValue reads a metric value, or an age as seconds
+func parseScreenerValue(field repositories.ScreenerField, raw string) (float64, error) {
+	if raw == "" {
+		return 0, errors.New("missing value")
+	}
+	suffix := strings.ToLower(raw[len(raw)-1:])[0]
+
+	if field == repositories.ScreenerAge {
+		unit, ok := screenerAgeUnits[suffix]
+		if !ok {
+			return 0, errors.New("age needs a unit of m, h, d or w")
+		}
+		n, err := strconv.ParseFloat(raw[:len(raw)-1], 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q", raw)
+		}
+		return n * unit.Seconds(), nil
+	}
+
+	multiplier := 1.0
+	if m, ok := screenerMultipliers[suffix]; ok {
+		multiplier = m
+		raw = raw[:len(raw)-1]
+	}
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", raw)
+	}
+	return n * multiplier, nil
+}
+
+// parseTokenSort reads a sort field and order, defaulting to newest first
+func parseTokenSort(sort, order string) (repositories.TokenSort, error) {
+	tokenSort := repositories.TokenSort{Field: repositories.TokenSortField(sort)}
+	if sort == "" {
+		tokenSort.Field = repositories.TokenSortCreatedAt
+	}
+	if !tokenSort.Field.IsValid() {
+		return tokenSort, fmt.Errorf("%w: sort must be one of created_at, market_cap, volume_24h, price_change_24h", ErrInvalidScreenerFilter)
+	}
+	switch order {
+	case "", "desc":
+	case "asc":
+		tokenSort.Ascending = true
+	default:
+		return tokenSort, fmt.Errorf("%w: order must be asc or desc", ErrInvalidScreenerFilter)
+	}
+	return tokenSort, nil
+}
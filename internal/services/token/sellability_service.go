@@ -0,0 +1,157 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+// SellabilityStatus is the outcome of a sellability check
+type SellabilityStatus string
+
+const (
+	SellabilitySellable   SellabilityStatus = "sellable"
+	SellabilityUnsellable SellabilityStatus = "unsellable" // buyable but no route sells it back
+	SellabilityHighTax    SellabilityStatus = "high_tax"   // selling loses far more than price impact explains
+	SellabilityUntradable SellabilityStatus = "untradable" // no route buys it either, so nothing can be concluded
+)
+
+const (
+	// SOL spent on the simulated buy (0.1 SOL)
+	sellabilityProbeLamports = 100_000_000
+	sellabilitySlippageBps   = 500
+	// Round-trip loss beyond price impact above which a token is treated as taxed on sell
+	maxSellTax = 0.3
+	// How long a check result is served from cache
+	sellabilityCacheTTL = 10 * time.Minute
+)
+
+// SellabilityService detects honeypots by simulating a buy and the sell back through Jupiter routes
+type SellabilityService interface {
+	CheckSellability(ctx context.Context, mintAddress string) (*SellabilityResult, error)
+}
+
+type sellabilityService struct {
+	jupiter JupiterService
+	cache   *redis.Client // optional
+	logger  *logrus.Logger
+}
+
+// NewSellabilityService creates a new sellability service instance; cache may be nil
+func NewSellabilityService(jupiter JupiterService, cache *redis.Client, logger *logrus.Logger) SellabilityService {
+	return &sellabilityService{
+		jupiter: jupiter,
+		cache:   cache,
+		logger:  logger,
+	}
+}
+
+// SellabilityResult reports whether a token bought with SOL can be sold back
+type SellabilityResult struct {
+	MintAddress     string            `json:"mint_address"`
+	Status          SellabilityStatus `json:"status"`
+	Sellable        bool              `json:"sellable"`
+	Reason          string            `json:"reason,omitempty"`
+	ProbeSOL        float64           `json:"probe_sol"`         // SOL spent on the simulated buy
+	ReturnedSOL     float64           `json:"returned_sol"`      // SOL the simulated sell returns
+	RoundTripLoss   float64           `json:"round_trip_loss"`   // fraction of the probe lost buying then selling
+	EstimatedTax    float64           `json:"estimated_tax"`     // round-trip loss not explained by price impact
+	BuyPriceImpact  float64           `json:"buy_price_impact"`  // fraction
+	SellPriceImpact float64           `json:"sell_price_impact"` // fraction
+	CheckedAt       time.Time         `json:"checked_at"`
+}
+
+// CannotSell reports whether the check concluded the token cannot be sold
+func (r *SellabilityResult) CannotSell() bool {
+	return r.Status == SellabilityUnsellable || r.Status == SellabilityHighTax
+}
+
+// CheckSellability quotes a small SOL buy of the token, then quotes selling the bought amount back to SOL
+func (s *sellabilityService) CheckSellability(ctx context.Context, mintAddress string) (*SellabilityResult, error) {
+	cacheKey := fmt.Sprintf("token_sellability:%s", mintAddress)
+	if s.cache != nil {
+		var cached SellabilityResult
+		err := s.cache.GetJSON(ctx, cacheKey, &cached)
+		if err == nil {
+			return &cached, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			s.logger.WithFields(logrus.Fields{
+				"error": err,
+				"key":   cacheKey,
+			}).Warn("Failed to read sellability cache")
+		}
+	}
+
+	result := &SellabilityResult{
+		MintAddress: mintAddress,
+		ProbeSOL:    lamportsToSOL(sellabilityProbeLamports),
+		CheckedAt:   time.Now().UTC(),
+	}
+
+	buy, err := s.jupiter.GetQuote(ctx, wrappedSOLMint, mintAddress, sellabilityProbeLamports, sellabilitySlippageBps)
+	switch {
+	case errors.Is(err, ErrNoRoute):
+		result.Status = SellabilityUntradable
+		result.Reason = "No route buys the token with SOL"
+	case err != nil:
+		return nil, fmt.Errorf("failed to quote buy: %w", err)
+	default:
+		result.BuyPriceImpact = buy.PriceImpactPct
+
+		sell, err := s.jupiter.GetQuote(ctx, mintAddress, wrappedSOLMint, buy.OutAmount, sellabilitySlippageBps)
+		switch {
+		case errors.Is(err, ErrNoRoute):
+			result.Status = SellabilityUnsellable
+			result.Reason = "Token can be bought but no route sells it back"
+		case err != nil:
+			return nil, fmt.Errorf("failed to quote sell: %w", err)
+		default:
+			s.evaluateRoundTrip(result, sell)
+		}
+	}
+	result.Sellable = result.Status == SellabilitySellable
+
+	if result.CannotSell() {
+		s.logger.WithFields(logrus.Fields{
+			"mint_address": mintAddress,
+			"status":       result.Status,
+			"reason":       result.Reason,
+		}).Warn("Token failed sellability check")
+	}
+
+	if s.cache != nil {
+		if err := s.cache.SetJSON(ctx, cacheKey, result, sellabilityCacheTTL); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err,
+				"key":   cacheKey,
+			}).Warn("Failed to write sellability cache")
+		}
+	}
+
+	return result, nil
+}
+
+// evaluateRoundTrip compares the SOL returned by the sell quote against the probe
+func (s *sellabilityService) evaluateRoundTrip(result *SellabilityResult, sell *JupiterQuote) {
+	result.SellPriceImpact = sell.PriceImpactPct
+	result.ReturnedSOL = lamportsToSOL(sell.OutAmount)
+	result.RoundTripLoss = math.Max(1-float64(sell.OutAmount)/sellabilityProbeLamports, 0)
+	result.EstimatedTax = math.Max(result.RoundTripLoss-math.Abs(result.BuyPriceImpact)-math.Abs(result.SellPriceImpact), 0)
+
+	if result.EstimatedTax > maxSellTax {
+		result.Status = SellabilityHighTax
+		result.Reason = fmt.Sprintf("Selling loses about %.0f%% beyond price impact", result.EstimatedTax*100)
+		return
+	}
+	result.Status = SellabilitySellable
+}
+
+func lamportsToSOL(lamports uint64) float64 {
+	return float64(lamports) / 1e9
+}
@@ -10,31 +10,39 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/apistats"
+	"github.com/emiyaio/solana-wallet-service/pkg/ratelimit"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
 )
 
 // SolanaTrackerService handles data fetching from SolanaTracker API
 type SolanaTrackerService interface {
-	GetTrendingTokens(timeframe string) (*TrendingTokensResponse, error)
-	GetVolumeTokens(timeframe string) (*VolumeTokensResponse, error)
-	GetLatestTokens() (*LatestTokensResponse, error)
-	GetTokenInfo(mintAddress string) (*TokenInfoResponse, error)
-	GetTopTraders(page int, sortBy string, expandPnl bool) (*TopTradersResponse, error)
+	GetTrendingTokens(ctx context.Context, timeframe string) (*TrendingTokensResponse, error)
+	GetVolumeTokens(ctx context.Context, timeframe string) (*VolumeTokensResponse, error)
+	GetLatestTokens(ctx context.Context) (*LatestTokensResponse, error)
+	GetTokenInfo(ctx context.Context, mintAddress string) (*TokenInfoResponse, error)
+	GetTopTraders(ctx context.Context, page int, sortBy string, expandPnl bool) (*TopTradersResponse, error)
+	GetDeployerTokens(ctx context.Context, deployerAddress string) (*DeployerTokensResponse, error)
+	GetChart(ctx context.Context, mintAddress, candleType string, from, to time.Time) (*ChartResponse, error)
 }
 
 type solanaTrackerService struct {
 	config        *config.SolanaTrackerConfig
 	httpClient    *http.Client
 	logger        *logrus.Logger
-	rateLimiter   *RateLimiter
+	limits        *ratelimit.Registry
 	failedTokens  map[string]time.Time // Track failed requests
 	failedMutex   sync.RWMutex
 }
 
-// RateLimiter implements rate limiting for API calls
-type RateLimiter struct {
-	tokens   chan struct{}
-	interval time.Duration
-}
+// SolanaTracker endpoint classes, each of which may be given its own rate on top of the provider's
+const (
+	solanaTrackerRankings  = "rankings" // trending, volume and latest token lists
+	solanaTrackerTokenInfo = "token_info"
+	solanaTrackerTraders   = "traders"
+	solanaTrackerDeployer  = "deployer"
+	solanaTrackerChart     = "chart"
+)
 
 // SolanaTracker API response structures
 type TrendingTokensResponse struct {
@@ -89,6 +97,33 @@ type LatestToken struct {
 	CreatedAt   string  `json:"createdAt"`
 }
 
+type DeployerTokensResponse struct {
+	Total  int             `json:"total"`
+	Tokens []DeployerToken `json:"tokens"`
+}
+
+type DeployerToken struct {
+	Mint         string  `json:"mint"`
+	Symbol       string  `json:"symbol"`
+	Name         string  `json:"name"`
+	MarketCapUSD float64 `json:"marketCapUsd"`
+	LiquidityUSD float64 `json:"liquidityUsd"`
+	CreatedAt    int64   `json:"createdAt"` // unix milliseconds
+}
+
+type ChartResponse struct {
+	Candles []ChartCandle `json:"oclhv"`
+}
+
+type ChartCandle struct {
+	Open   float64 `json:"open"`
+	Close  float64 `json:"close"`
+	Low    float64 `json:"low"`
+	High   float64 `json:"high"`
+	Volume float64 `json:"volume"`
+	Time   int64   `json:"time"` // unix seconds
+}
+
 type TokenInfoResponse struct {
 	Data TokenInfo `json:"data"`
 }
@@ -118,10 +153,23 @@ type TokenInfo struct {
 	ATL               float64            `json:"atl"`
 	HolderCount       int                `json:"holderCount"`
 	TopHolders        []TokenTopHolder   `json:"topHolders"`
+	Pools             []TokenPool        `json:"pools"`
 	CreatedAt         string             `json:"createdAt"`
 	LastUpdated       string             `json:"lastUpdated"`
 }
 
+// TokenPool is a market the token trades on; Pump.fun bonding curves are listed with market "pumpfun". The
+// USD figures are filled by the pools provider.
+type TokenPool struct {
+	PoolID          string  `json:"poolId"`
+	Market          string  `json:"market"`
+	CurvePercentage float64 `json:"curvePercentage"` // bonding curve progress, Pump.fun pools only
+	CreatedAt       int64   `json:"createdAt"`       // unix milliseconds
+	PriceUSD        float64 `json:"priceUsd"`        // of the token in this pool
+	LiquidityUSD    float64 `json:"liquidityUsd"`
+	Volume24hUSD    float64 `json:"volume24hUsd"`
+}
+
 type TokenTopHolder struct {
 	Address    string  `json:"address"`
 	Balance    float64 `json:"balance"`
@@ -145,49 +193,24 @@ type TopTrader struct {
 }
 
 // NewSolanaTrackerService creates a new SolanaTracker service instance
-func NewSolanaTrackerService(config *config.SolanaTrackerConfig, logger *logrus.Logger) SolanaTrackerService {
-	rateLimiter := &RateLimiter{
-		tokens:   make(chan struct{}, 1), // 1 request per interval
-		interval: time.Second,            // 1 second interval
-	}
-	
-	// Initialize rate limiter
-	go rateLimiter.start()
-	
+func NewSolanaTrackerService(config *config.SolanaTrackerConfig, limits *ratelimit.Registry, usageTracker *usage.Tracker, apiStats *apistats.Registry, logger *logrus.Logger) SolanaTrackerService {
 	return &solanaTrackerService{
 		config:       config,
-		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		httpClient:   &http.Client{Timeout: 30 * time.Second, Transport: usageTracker.Transport(usage.ProviderSolanaTracker, apiStats.Transport("solana_tracker", nil))},
 		logger:       logger,
-		rateLimiter:  rateLimiter,
+		limits:       limits,
 		failedTokens: make(map[string]time.Time),
 	}
 }
 
-// start initializes the rate limiter
-func (rl *RateLimiter) start() {
-	ticker := time.NewTicker(rl.interval)
-	defer ticker.Stop()
-	
-	for range ticker.C {
-		select {
-		case rl.tokens <- struct{}{}:
-		default:
-			// Channel is full, skip this tick
-		}
-	}
-}
-
-// wait blocks until a token is available
-func (rl *RateLimiter) wait() {
-	<-rl.tokens
-}
-
 // GetTrendingTokens fetches trending tokens from SolanaTracker
-func (s *solanaTrackerService) GetTrendingTokens(timeframe string) (*TrendingTokensResponse, error) {
-	s.rateLimiter.wait()
+func (s *solanaTrackerService) GetTrendingTokens(ctx context.Context, timeframe string) (*TrendingTokensResponse, error) {
+	if err := s.limits.Wait(ctx, usage.ProviderSolanaTracker, solanaTrackerRankings); err != nil {
+		return nil, err
+	}
 	
 	url := fmt.Sprintf("%s/tokens/trending", s.config.BaseURL)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -216,11 +239,13 @@ func (s *solanaTrackerService) GetTrendingTokens(timeframe string) (*TrendingTok
 }
 
 // GetVolumeTokens fetches tokens with highest volume
-func (s *solanaTrackerService) GetVolumeTokens(timeframe string) (*VolumeTokensResponse, error) {
-	s.rateLimiter.wait()
+func (s *solanaTrackerService) GetVolumeTokens(ctx context.Context, timeframe string) (*VolumeTokensResponse, error) {
+	if err := s.limits.Wait(ctx, usage.ProviderSolanaTracker, solanaTrackerRankings); err != nil {
+		return nil, err
+	}
 	
 	url := fmt.Sprintf("%s/tokens/volume", s.config.BaseURL)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -248,11 +273,13 @@ func (s *solanaTrackerService) GetVolumeTokens(timeframe string) (*VolumeTokensR
 }
 
 // GetLatestTokens fetches latest tokens
-func (s *solanaTrackerService) GetLatestTokens() (*LatestTokensResponse, error) {
-	s.rateLimiter.wait()
+func (s *solanaTrackerService) GetLatestTokens(ctx context.Context) (*LatestTokensResponse, error) {
+	if err := s.limits.Wait(ctx, usage.ProviderSolanaTracker, solanaTrackerRankings); err != nil {
+		return nil, err
+	}
 	
 	url := fmt.Sprintf("%s/tokens/latest", s.config.BaseURL)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -270,16 +297,18 @@ func (s *solanaTrackerService) GetLatestTokens() (*LatestTokensResponse, error)
 }
 
 // GetTokenInfo fetches detailed info for a specific token
-func (s *solanaTrackerService) GetTokenInfo(mintAddress string) (*TokenInfoResponse, error) {
+func (s *solanaTrackerService) GetTokenInfo(ctx context.Context, mintAddress string) (*TokenInfoResponse, error) {
 	// Check if this token recently failed
 	if s.isTokenRecentlyFailed(mintAddress) {
 		return nil, fmt.Errorf("token %s recently failed, skipping", mintAddress)
 	}
 	
-	s.rateLimiter.wait()
+	if err := s.limits.Wait(ctx, usage.ProviderSolanaTracker, solanaTrackerTokenInfo); err != nil {
+		return nil, err
+	}
 	
 	url := fmt.Sprintf("%s/tokens/%s", s.config.BaseURL, mintAddress)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -302,11 +331,13 @@ func (s *solanaTrackerService) GetTokenInfo(mintAddress string) (*TokenInfoRespo
 }
 
 // GetTopTraders fetches top traders data
-func (s *solanaTrackerService) GetTopTraders(page int, sortBy string, expandPnl bool) (*TopTradersResponse, error) {
-	s.rateLimiter.wait()
+func (s *solanaTrackerService) GetTopTraders(ctx context.Context, page int, sortBy string, expandPnl bool) (*TopTradersResponse, error) {
+	if err := s.limits.Wait(ctx, usage.ProviderSolanaTracker, solanaTrackerTraders); err != nil {
+		return nil, err
+	}
 	
 	url := fmt.Sprintf("%s/traders/top", s.config.BaseURL)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -340,6 +371,67 @@ func (s *solanaTrackerService) GetTopTraders(page int, sortBy string, expandPnl
 	return &response, nil
 }
 
+// GetDeployerTokens fetches the tokens created by a deployer wallet
+func (s *solanaTrackerService) GetDeployerTokens(ctx context.Context, deployerAddress string) (*DeployerTokensResponse, error) {
+	if err := s.limits.Wait(ctx, usage.ProviderSolanaTracker, solanaTrackerDeployer); err != nil {
+		return nil, err
+	}
+	
+	url := fmt.Sprintf("%s/deployer/%s", s.config.BaseURL, deployerAddress)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	s.addAuthHeaders(req)
+	
+	var response DeployerTokensResponse
+	if err := s.makeRequest(req, &response); err != nil {
+		return nil, fmt.Errorf("failed to get deployer tokens: %w", err)
+	}
+	
+	s.logger.WithFields(logrus.Fields{
+		"deployer": deployerAddress,
+		"count":    len(response.Tokens),
+	}).Info("Fetched deployer tokens from SolanaTracker")
+	
+	return &response, nil
+}
+
+// GetChart fetches OHLCV candles of a token between from and to; candleType is e.g. 1m, 5m, 1h
+func (s *solanaTrackerService) GetChart(ctx context.Context, mintAddress, candleType string, from, to time.Time) (*ChartResponse, error) {
+	if err := s.limits.Wait(ctx, usage.ProviderSolanaTracker, solanaTrackerChart); err != nil {
+		return nil, err
+	}
+	
+	url := fmt.Sprintf("%s/chart/%s", s.config.BaseURL, mintAddress)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	q := req.URL.Query()
+	q.Add("type", candleType)
+	q.Add("time_from", fmt.Sprintf("%d", from.Unix()))
+	q.Add("time_to", fmt.Sprintf("%d", to.Unix()))
+	req.URL.RawQuery = q.Encode()
+	
+	s.addAuthHeaders(req)
+	
+	var response ChartResponse
+	if err := s.makeRequest(req, &response); err != nil {
+		return nil, fmt.Errorf("failed to get chart: %w", err)
+	}
+	
+	s.logger.WithFields(logrus.Fields{
+		"mint_address": mintAddress,
+		"type":         candleType,
+		"count":        len(response.Candles),
+	}).Debug("Fetched chart from SolanaTracker")
+	
+	return &response, nil
+}
+
 // addAuthHeaders adds authentication headers to the request
 func (s *solanaTrackerService) addAuthHeaders(req *http.Request) {
 	if s.config.APIKey != "" {
@@ -0,0 +1,219 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/pkg/clock"
+)
+
+// SyncTier is a market data refresh priority; each token is synced in the highest tier it belongs to
+type SyncTier string
+
+const (
+	SyncTierHot  SyncTier = "hot"  // bound to an active room or watched by an active limit watch
+	SyncTierWarm SyncTier = "warm" // in the 24h trending ranking
+	SyncTierCold SyncTier = "cold" // the long tail
+)
+
+const (
+	defaultHotTokensInterval  = time.Minute
+	defaultWarmTokensInterval = 5 * time.Minute
+	defaultColdTokensInterval = time.Hour
+
+	syncTierTrendingLimit = 200
+	syncTierPageSize      = 100
+	syncTierRequestDelay  = 100 * time.Millisecond // between market data requests, to stay under the rate limit
+)
+
+// ErrSyncInProgress is returned when a tier is synced while its previous sync is still running
+var ErrSyncInProgress = errors.New("tier sync already in progress")
+
+// SyncScheduler refreshes token market data at the rate of each token's priority tier
+type SyncScheduler interface {
+	SyncTier(ctx context.Context, tier SyncTier) (int, error) // returns the number of tokens synced
+	Interval(tier SyncTier) time.Duration
+}
+
+type syncScheduler struct {
+	tokenRepo      repositories.TokenRepository
+	roomRepo       repositories.RoomRepository
+	limitWatchRepo repositories.LimitWatchRepository
+	marketService  MarketService
+	intervals      map[SyncTier]time.Duration
+	clock          clock.Clock
+	logger         *logrus.Logger
+
+	mu      sync.Mutex
+	running map[SyncTier]bool
+}
+
+// NewSyncScheduler creates a new tiered market data sync scheduler
+func NewSyncScheduler(
+	tokenRepo repositories.TokenRepository,
+	roomRepo repositories.RoomRepository,
+	limitWatchRepo repositories.LimitWatchRepository,
+	marketService MarketService,
+	cfg *config.SyncSchedulerConfig,
+	clk clock.Clock,
+	logger *logrus.Logger,
+) SyncScheduler {
+	intervals := map[SyncTier]time.Duration{
+		SyncTierHot:  cfg.HotTokensInterval,
+		SyncTierWarm: cfg.WarmTokensInterval,
+		SyncTierCold: cfg.ColdTokensInterval,
+	}
+	if intervals[SyncTierHot] <= 0 {
+		intervals[SyncTierHot] = defaultHotTokensInterval
+	}
+	if intervals[SyncTierWarm] <= 0 {
+		intervals[SyncTierWarm] = defaultWarmTokensInterval
+	}
+	if intervals[SyncTierCold] <= 0 {
+		intervals[SyncTierCold] = defaultColdTokensInterval
+	}
+
+	return &syncScheduler{
+		tokenRepo:      tokenRepo,
+		roomRepo:       roomRepo,
+		limitWatchRepo: limitWatchRepo,
+		marketService:  marketService,
+		intervals:      intervals,
+		clock:          clk,
+		logger:         logger,
+		running:        make(map[SyncTier]bool),
+	}
+}
+
+func (s *syncScheduler) Interval(tier SyncTier) time.Duration {
+	return s.intervals[tier]
+}
+
+// SyncTier syncs the market data of the tier's tokens; a slow cold sweep does not hold up the hotter tiers,
+// but a tier is never synced twice at once
+func (s *syncScheduler) SyncTier(ctx context.Context, tier SyncTier) (int, error) {
+	s.mu.Lock()
+	if s.running[tier] {
+		s.mu.Unlock()
+		return 0, ErrSyncInProgress
+	}
+	s.running[tier] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running[tier] = false
+		s.mu.Unlock()
+	}()
+
+	hot, err := s.hotTokens(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	synced := 0
+	switch tier {
+	case SyncTierHot:
+		synced = s.syncTokens(ctx, tier, hot, nil)
+	case SyncTierWarm:
+		warm, err := s.warmTokens(ctx)
+		if err != nil {
+			return 0, err
+		}
+		synced = s.syncTokens(ctx, tier, warm, hot)
+	case SyncTierCold:
+		warm, err := s.warmTokens(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for mint := range warm {
+			hot[mint] = true
+		}
+		// The long tail is swept a page at a time rather than loaded whole
+		for offset := 0; ; offset += syncTierPageSize {
+			tokens, err := s.tokenRepo.List(ctx, syncTierPageSize, offset)
+			if err != nil {
+				return synced, fmt.Errorf("failed to get tokens: %w", err)
+			}
+			page := make(map[string]bool, len(tokens))
+			for _, token := range tokens {
+				page[token.MintAddress] = true
+			}
+			synced += s.syncTokens(ctx, tier, page, hot)
+			if len(tokens) < syncTierPageSize {
+				break
+			}
+		}
+	default:
+		return 0, fmt.Errorf("unknown sync tier %q", tier)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"tier":   tier,
+		"synced": synced,
+	}).Info("Token market data tier synced")
+	return synced, nil
+}
+
+// hotTokens returns the mints bound to active rooms and watched by active limit watches
+func (s *syncScheduler) hotTokens(ctx context.Context) (map[string]bool, error) {
+	bound, err := s.roomRepo.GetBoundTokenAddresses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room-bound tokens: %w", err)
+	}
+	watched, err := s.limitWatchRepo.GetActiveMints(ctx, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watched tokens: %w", err)
+	}
+
+	mints := make(map[string]bool, len(bound)+len(watched))
+	for _, mint := range append(bound, watched...) {
+		mints[mint] = true
+	}
+	return mints, nil
+}
+
+// warmTokens returns the mints of the current 24h trending ranking
+func (s *syncScheduler) warmTokens(ctx context.Context) (map[string]bool, error) {
+	rankings, err := s.tokenRepo.GetTrendingTokens(ctx, "trending", "24h", "", syncTierTrendingLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending tokens: %w", err)
+	}
+
+	mints := make(map[string]bool, len(rankings))
+	for _, ranking := range rankings {
+		if ranking.Token.MintAddress != "" {
+			mints[ranking.Token.MintAddress] = true
+		}
+	}
+	return mints, nil
+}
+
+// syncTokens syncs each mint not in skip, which holds the mints of hotter tiers, and returns how many succeeded
+func (s *syncScheduler) syncTokens(ctx context.Context, tier SyncTier, mints, skip map[string]bool) int {
+	synced := 0
+	for mint := range mints {
+		if skip[mint] {
+			continue
+		}
+		if ctx.Err() != nil {
+			return synced
+		}
+		if _, err := s.marketService.SyncMarketDataFromExternalAPI(ctx, mint); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": mint,
+				"tier":         tier,
+			}).Error("Failed to sync market data")
+			continue
+		}
+		synced++
+		time.Sleep(syncTierRequestDelay)
+	}
+	return synced
+}
@@ -0,0 +1,71 @@
+package trader
+
+import (
+	"context"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+)
+
+// ExecutionStats compares a wallet's swap execution with all tracked wallets, per DEX platform
+type ExecutionStats struct {
+	WalletAddress string                           `json:"wallet_address"`
+	Days          int                              `json:"days"`
+	Overall       *models.PlatformExecutionStats   `json:"overall"`    // the wallet across all platforms
+	Platforms     []*models.PlatformExecutionStats `json:"platforms"`  // the wallet per platform, most traded first
+	Benchmarks    []*models.PlatformExecutionStats `json:"benchmarks"` // all tracked wallets per platform
+}
+
+// GetExecutionStats averages fees, priority fees and realized slippage of a wallet's successful swaps over the
+// last days. Slippage is only known for swaps analyzed shortly after they landed.
+func (s *traderService) GetExecutionStats(ctx context.Context, walletAddress string, days int) (*ExecutionStats, error) {
+	since := time.Now().AddDate(0, 0, -days)
+
+	platforms, err := s.transactionRepo.GetExecutionStats(ctx, walletAddress, since)
+	if err != nil {
+		return nil, err
+	}
+	benchmarks, err := s.transactionRepo.GetExecutionStats(ctx, "", since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecutionStats{
+		WalletAddress: walletAddress,
+		Days:          days,
+		Overall:       combineExecutionStats(platforms),
+		Platforms:     platforms,
+		Benchmarks:    benchmarks,
+	}, nil
+}
+
+// combineExecutionStats merges per-platform stats, weighting each average by its sample count
+func combineExecutionStats(platforms []*models.PlatformExecutionStats) *models.PlatformExecutionStats {
+	overall := &models.PlatformExecutionStats{Platform: "all"}
+	var feeSum, prioritySum, slippageSum float64
+	for _, p := range platforms {
+		overall.Trades += p.Trades
+		feeSum += p.AvgFeeLamports * float64(p.Trades)
+		if p.AvgPriorityFeeLamports != nil {
+			overall.PriorityFeeSamples += p.PriorityFeeSamples
+			prioritySum += *p.AvgPriorityFeeLamports * float64(p.PriorityFeeSamples)
+		}
+		if p.AvgSlippagePercent != nil {
+			overall.SlippageSamples += p.SlippageSamples
+			slippageSum += *p.AvgSlippagePercent * float64(p.SlippageSamples)
+		}
+	}
+
+	if overall.Trades > 0 {
+		overall.AvgFeeLamports = feeSum / float64(overall.Trades)
+	}
+	if overall.PriorityFeeSamples > 0 {
+		avg := prioritySum / float64(overall.PriorityFeeSamples)
+		overall.AvgPriorityFeeLamports = &avg
+	}
+	if overall.SlippageSamples > 0 {
+		avg := slippageSum / float64(overall.SlippageSamples)
+		overall.AvgSlippagePercent = &avg
+	}
+	return overall
+}
@@ -0,0 +1,184 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+const (
+	signalEvaluationBatchSize = 200
+	// signalPriceGrace is how late a checkpoint may be evaluated from live market data
+	signalPriceGrace = 30 * time.Minute
+	// signalCandleResolution is the stored candle resolution used to price past checkpoints
+	signalCandleResolution = "5m"
+	signalCandleStep       = 5 * time.Minute
+)
+
+// SignalTracker follows the price performance of shared signals and scores their sharers
+type SignalTracker interface {
+	TrackSignal(ctx context.Context, info *models.SharedInfo) error
+	EvaluateSignals(ctx context.Context) (int, error)
+	GetAccuracy(ctx context.Context, walletAddress string) (*models.SignalAccuracy, error)
+	GetAccuracies(ctx context.Context, walletAddresses []string) (map[string]*models.SignalAccuracy, error)
+	GetOutcomes(ctx context.Context, walletAddress string, limit, offset int) ([]*models.SignalOutcome, error)
+}
+
+type signalTracker struct {
+	signalRepo repositories.SignalRepository
+	tokenRepo  repositories.TokenRepository
+	logger     *logrus.Logger
+}
+
+// NewSignalTracker creates a new signal tracker instance
+func NewSignalTracker(
+	signalRepo repositories.SignalRepository,
+	tokenRepo repositories.TokenRepository,
+	logger *logrus.Logger,
+) SignalTracker {
+	return &signalTracker{
+		signalRepo: signalRepo,
+		tokenRepo:  tokenRepo,
+		logger:     logger,
+	}
+}
+
+// TrackSignal starts tracking a signal share, recording the market price at share time
+func (t *signalTracker) TrackSignal(ctx context.Context, info *models.SharedInfo) error {
+	payload, err := info.SignalPayload()
+	if err != nil {
+		return fmt.Errorf("failed to decode signal: %w", err)
+	}
+
+	outcome := &models.SignalOutcome{
+		SharedInfoID:  info.ID,
+		RoomID:        info.RoomID,
+		SharerAddress: info.SharerAddress,
+		TokenAddress:  payload.Token,
+		Side:          payload.Side,
+		Entry:         payload.Entry,
+		Target:        payload.Target,
+		Stop:          payload.Stop,
+		SharedAt:      info.CreatedAt,
+		Result:        models.SignalResultPending,
+	}
+	if outcome.SharedAt.IsZero() {
+		outcome.SharedAt = time.Now()
+	}
+	outcome.SharePrice = t.priceAt(ctx, payload.Token, outcome.SharedAt)
+
+	if err := t.signalRepo.CreateOutcome(ctx, outcome); err != nil {
+		return fmt.Errorf("failed to create signal outcome: %w", err)
+	}
+	return nil
+}
+
+// EvaluateSignals prices every signal checkpoint that has come due and returns the number of signals updated
+func (t *signalTracker) EvaluateSignals(ctx context.Context) (int, error) {
+	now := time.Now()
+	updated := 0
+
+	// Evaluated signals move their next check past now, so the first page is refetched until it is short
+	for {
+		outcomes, err := t.signalRepo.GetDueOutcomes(ctx, now, signalEvaluationBatchSize)
+		if err != nil {
+			return updated, fmt.Errorf("failed to get due signals: %w", err)
+		}
+
+		saved := 0
+		for _, outcome := range outcomes {
+			for outcome.NextCheckAt != nil && !outcome.NextCheckAt.After(now) {
+				outcome.Evaluate(t.priceAt(ctx, outcome.TokenAddress, *outcome.NextCheckAt), now)
+			}
+
+			if err := t.signalRepo.UpdateOutcome(ctx, outcome); err != nil {
+				t.logger.WithFields(logrus.Fields{
+					"error":          err,
+					"shared_info_id": outcome.SharedInfoID,
+				}).Warn("Failed to update signal outcome")
+				continue
+			}
+			saved++
+		}
+		updated += saved
+
+		// A page that could not be saved would be fetched again forever
+		if len(outcomes) < signalEvaluationBatchSize || saved == 0 {
+			break
+		}
+	}
+
+	if updated > 0 {
+		t.logger.WithField("updated", updated).Info("Signal outcomes evaluated")
+	}
+	return updated, nil
+}
+
+// priceAt returns the token's USD price at time at, or nil if it is unknown. Stored candles
+// are preferred; live market data is only used close to at.
+func (t *signalTracker) priceAt(ctx context.Context, mintAddress string, at time.Time) *float64 {
+	token, err := t.tokenRepo.GetByMintAddress(ctx, mintAddress)
+	if err != nil || token == nil {
+		return nil
+	}
+
+	candles, err := t.tokenRepo.GetCandles(ctx, token.ID, signalCandleResolution, at.Add(-signalCandleStep), at.Add(signalCandleStep))
+	if err == nil && len(candles) > 0 {
+		price := candles[0].Close
+		for _, candle := range candles {
+			if candle.OpenTime.After(at) {
+				break
+			}
+			price = candle.Close
+		}
+		return &price
+	}
+
+	if time.Since(at) > signalPriceGrace {
+		return nil
+	}
+	marketData, err := t.tokenRepo.GetLatestMarketData(ctx, token.ID)
+	if err != nil || marketData == nil || marketData.PriceUSD == 0 {
+		return nil
+	}
+	price := marketData.PriceUSD
+	return &price
+}
+
+// GetAccuracy returns the signal accuracy of a wallet; wallets without signals get empty stats
+func (t *signalTracker) GetAccuracy(ctx context.Context, walletAddress string) (*models.SignalAccuracy, error) {
+	accuracies, err := t.GetAccuracies(ctx, []string{walletAddress})
+	if err != nil {
+		return nil, err
+	}
+	return accuracies[walletAddress], nil
+}
+
+// GetAccuracies returns the signal accuracy of each wallet, keyed by address
+func (t *signalTracker) GetAccuracies(ctx context.Context, walletAddresses []string) (map[string]*models.SignalAccuracy, error) {
+	rows, err := t.signalRepo.GetAccuracies(ctx, walletAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signal accuracy: %w", err)
+	}
+
+	accuracies := make(map[string]*models.SignalAccuracy, len(walletAddresses))
+	for _, address := range walletAddresses {
+		accuracies[address] = &models.SignalAccuracy{SharerAddress: address}
+	}
+	for _, accuracy := range rows {
+		if scored := accuracy.Hits + accuracy.Stopped + accuracy.Missed; scored > 0 {
+			accuracy.HitRate = float64(accuracy.Hits) / float64(scored)
+		}
+		accuracies[accuracy.SharerAddress] = accuracy
+	}
+	return accuracies, nil
+}
+
+// GetOutcomes returns a wallet's tracked signals, newest first
+func (t *signalTracker) GetOutcomes(ctx context.Context, walletAddress string, limit, offset int) ([]*models.SignalOutcome, error) {
+	return t.signalRepo.GetOutcomesBySharer(ctx, walletAddress, limit, offset)
+}
@@ -0,0 +1,293 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/blockchain"
+)
+
+// TraderService defines the interface for trader statistics and history maintenance
+type TraderService interface {
+	RecomputeTraderStats(ctx context.Context, walletAddress string) (*models.Trader, error)
+	BackfillWallet(ctx context.Context, walletAddress string, limit int) (int, error)
+	GetProfile(ctx context.Context, walletAddress string) (*TraderProfile, error)
+	GetExecutionStats(ctx context.Context, walletAddress string, days int) (*ExecutionStats, error)
+}
+
+// TraderProfile is the public profile of a wallet
+type TraderProfile struct {
+	WalletAddress  string                 `json:"wallet_address"`
+	Trader         *models.Trader         `json:"trader"` // nil until trading stats are computed
+	SignalAccuracy *models.SignalAccuracy `json:"signal_accuracy"`
+}
+
+type traderService struct {
+	traderRepo           repositories.TraderRepository
+	transactionRepo      repositories.TransactionRepository
+	transactionProcessor blockchain.TransactionProcessor
+	signalTracker        SignalTracker
+	logger               *logrus.Logger
+}
+
+// NewTraderService creates a new trader service instance
+func NewTraderService(
+	traderRepo repositories.TraderRepository,
+	transactionRepo repositories.TransactionRepository,
+	transactionProcessor blockchain.TransactionProcessor,
+	signalTracker SignalTracker,
+	logger *logrus.Logger,
+) TraderService {
+	return &traderService{
+		traderRepo:           traderRepo,
+		transactionRepo:      transactionRepo,
+		transactionProcessor: transactionProcessor,
+		signalTracker:        signalTracker,
+		logger:               logger,
+	}
+}
+
+// GetProfile returns a wallet's trading stats together with the accuracy of its shared signals
+func (s *traderService) GetProfile(ctx context.Context, walletAddress string) (*TraderProfile, error) {
+	trader, err := s.traderRepo.GetByWalletAddress(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trader: %w", err)
+	}
+
+	accuracy, err := s.signalTracker.GetAccuracy(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TraderProfile{
+		WalletAddress:  walletAddress,
+		Trader:         trader,
+		SignalAccuracy: accuracy,
+	}, nil
+}
+
+// tokenPosition accumulates buys and sells of a single token for PnL calculation
+type tokenPosition struct {
+	boughtUSD float64
+	soldUSD   float64
+	firstBuy  time.Time
+	lastSell  time.Time
+}
+
+// RecomputeTraderStats rebuilds a trader's aggregate statistics from stored transactions
+func (s *traderService) RecomputeTraderStats(ctx context.Context, walletAddress string) (*models.Trader, error) {
+	positions := make(map[string]*tokenPosition)
+	totalTrades := 0
+	var lastActive time.Time
+
+	limit := 500
+	offset := 0
+	for {
+		transactions, err := s.transactionRepo.GetByWallet(ctx, walletAddress, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wallet transactions: %w", err)
+		}
+
+		for _, tx := range transactions {
+			if tx.Status != models.TransactionStatusSuccess {
+				continue
+			}
+			if tx.BlockTime.After(lastActive) {
+				lastActive = tx.BlockTime
+			}
+			if tx.TransactionType != models.TransactionTypeBuy && tx.TransactionType != models.TransactionTypeSell {
+				continue
+			}
+			totalTrades++
+
+			position, exists := positions[tx.TokenAddress]
+			if !exists {
+				position = &tokenPosition{}
+				positions[tx.TokenAddress] = position
+			}
+
+			if tx.TransactionType == models.TransactionTypeBuy {
+				position.boughtUSD += tx.ValueUSD
+				if position.firstBuy.IsZero() || tx.BlockTime.Before(position.firstBuy) {
+					position.firstBuy = tx.BlockTime
+				}
+			} else {
+				position.soldUSD += tx.ValueUSD
+				if tx.BlockTime.After(position.lastSell) {
+					position.lastSell = tx.BlockTime
+				}
+			}
+		}
+
+		if len(transactions) < limit {
+			break
+		}
+		offset += limit
+	}
+
+	// Only positions with both a buy and a sell count as closed trades
+	var totalPnL, totalHoldHours float64
+	closed, wins := 0, 0
+	for _, position := range positions {
+		if position.boughtUSD == 0 || position.soldUSD == 0 {
+			continue
+		}
+		closed++
+		pnl := position.soldUSD - position.boughtUSD
+		totalPnL += pnl
+		if pnl > 0 {
+			wins++
+		}
+		if !position.firstBuy.IsZero() && position.lastSell.After(position.firstBuy) {
+			totalHoldHours += position.lastSell.Sub(position.firstBuy).Hours()
+		}
+	}
+
+	trader, err := s.traderRepo.GetByWalletAddress(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trader: %w", err)
+	}
+	isNew := trader == nil
+	if isNew {
+		trader = &models.Trader{WalletAddress: walletAddress}
+	}
+
+	trader.TotalTrades = totalTrades
+	trader.TotalPnL = totalPnL
+	trader.WinRate = 0
+	trader.AvgHoldTime = 0
+	if closed > 0 {
+		trader.WinRate = float64(wins) / float64(closed)
+		trader.AvgHoldTime = int(totalHoldHours / float64(closed))
+	}
+	if !lastActive.IsZero() {
+		trader.LastActiveAt = lastActive
+	}
+
+	if isNew {
+		err = s.traderRepo.Create(ctx, trader)
+	} else {
+		err = s.traderRepo.Update(ctx, trader)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save trader: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"wallet":       walletAddress,
+		"total_trades": totalTrades,
+		"win_rate":     trader.WinRate,
+		"total_pnl":    totalPnL,
+	}).Info("Trader stats recomputed")
+
+	return trader, nil
+}
+
+// BackfillWallet fetches recent on-chain transactions for a wallet and stores the missing ones
+func (s *traderService) BackfillWallet(ctx context.Context, walletAddress string, limit int) (int, error) {
+	signatures, err := s.transactionProcessor.GetSignaturesForAddress(walletAddress, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get signatures: %w", err)
+	}
+
+	stored := 0
+	for _, sig := range signatures {
+		existing, err := s.transactionRepo.GetBySignature(ctx, sig.Signature)
+		if err != nil {
+			return stored, fmt.Errorf("failed to check existing transaction: %w", err)
+		}
+		if existing != nil {
+			continue
+		}
+
+		details, err := s.transactionProcessor.GetTransactionDetailsAt(sig.Signature, s.transactionProcessor.Commitments().Persistence)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err,
+				"signature": sig.Signature,
+			}).Warn("Failed to fetch transaction during backfill")
+			continue
+		}
+
+		if !s.transactionProcessor.IsRelevantTransaction(details.Meta.LogMessages) {
+			continue
+		}
+
+		action, err := s.transactionProcessor.AnalyzeTransaction(ctx, details)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err,
+				"signature": sig.Signature,
+			}).Warn("Failed to analyze transaction during backfill")
+			continue
+		}
+
+		tx := buildSmartMoneyTransaction(walletAddress, action)
+		if tx == nil {
+			continue
+		}
+
+		if err := s.transactionRepo.Create(ctx, tx); err != nil {
+			return stored, fmt.Errorf("failed to store transaction %s: %w", sig.Signature, err)
+		}
+		stored++
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"wallet":  walletAddress,
+		"scanned": len(signatures),
+		"stored":  stored,
+	}).Info("Wallet backfill completed")
+
+	return stored, nil
+}
+
+// buildSmartMoneyTransaction converts an analyzed action into a persisted transaction record
+func buildSmartMoneyTransaction(walletAddress string, action *blockchain.AnalyzedWalletAction) *models.SmartMoneyTransaction {
+	// The traded token is whichever side is not a quote asset
+	traded := action.OutputToken
+	if action.TransactionType == string(models.TransactionTypeSell) || traded == nil || blockchain.IsQuoteAsset(traded.Mint) {
+		traded = action.InputToken
+	}
+	if traded == nil {
+		return nil
+	}
+
+	var price float64
+	if traded.Amount > 0 {
+		price = action.ValueUSD / traded.Amount
+	}
+
+	status := models.TransactionStatusSuccess
+	if !action.Success {
+		status = models.TransactionStatusFailed
+	}
+
+	return &models.SmartMoneyTransaction{
+		Signature:           action.Signature,
+		Slot:                action.Slot,
+		BlockTime:           action.BlockTime,
+		WalletAddress:       walletAddress,
+		TokenAddress:        traded.Mint,
+		TransactionType:     models.TransactionType(action.TransactionType),
+		Amount:              traded.Amount,
+		Price:               price,
+		ValueUSD:            action.ValueUSD,
+		FeeLamports:         action.Fee,
+		PriorityFeeLamports: &action.PriorityFee,
+		SlippagePercent:     action.SlippagePercent,
+		InstructionType:     action.Platform,
+		Status:              status,
+		Finalized:           !action.Provisional,
+		PreBalances:         "[]",
+		PostBalances:        "[]",
+		PreTokenBalances:    "[]",
+		PostTokenBalances:   "[]",
+		LogMessages:         strings.Join(action.LogMessages, "\n"),
+	}
+}
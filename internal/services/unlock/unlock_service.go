@@ -0,0 +1,316 @@
+package unlock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/internal/services/token"
+)
+
+var (
+	ErrUnlockNotFound = errors.New("unlock schedule not found")
+	ErrInvalidUnlock  = errors.New("invalid unlock schedule")
+)
+
+const (
+	// Rooms are warned once about unlocks of at least this share of supply coming within the warning window
+	largeUnlockPercent  = 1.0
+	unlockWarningWindow = 48 * time.Hour
+
+	defaultCalendarDays  = 30
+	maxCalendarDays      = 180
+	defaultCalendarLimit = 100
+	maxCalendarLimit     = 500
+	maxImportUnlocks     = 1000
+)
+
+// UnlockService maintains token vesting unlock schedules and warns rooms ahead of large unlocks
+type UnlockService interface {
+	CreateUnlock(ctx context.Context, req *UnlockRequest) (*models.TokenUnlockSchedule, error)
+	ImportUnlocks(ctx context.Context, reqs []*UnlockRequest) ([]*models.TokenUnlockSchedule, error)
+	UpdateUnlock(ctx context.Context, id uuid.UUID, req *UnlockRequest) (*models.TokenUnlockSchedule, error)
+	DeleteUnlock(ctx context.Context, id uuid.UUID) error
+	GetTokenUnlocks(ctx context.Context, mintAddress string) ([]*models.TokenUnlockSchedule, error)
+	GetCalendar(ctx context.Context, days, limit int) (*UnlockCalendar, error)
+	WarnUpcomingUnlocks(ctx context.Context) (int, error)
+}
+
+type unlockService struct {
+	tokenRepo repositories.TokenRepository
+	roomRepo  repositories.RoomRepository
+	wsService room.WebSocketService
+	logger    *logrus.Logger
+}
+
+// NewUnlockService creates a new unlock schedule service instance
+func NewUnlockService(
+	tokenRepo repositories.TokenRepository,
+	roomRepo repositories.RoomRepository,
+	wsService room.WebSocketService,
+	logger *logrus.Logger,
+) UnlockService {
+	return &unlockService{
+		tokenRepo: tokenRepo,
+		roomRepo:  roomRepo,
+		wsService: wsService,
+		logger:    logger,
+	}
+}
+
+// UnlockRequest describes an unlock; the share of supply is derived from the amount when left out
+type UnlockRequest struct {
+	MintAddress     string                `json:"mint_address" binding:"required"`
+	UnlockAt        time.Time             `json:"unlock_at" binding:"required"`
+	Amount          float64               `json:"amount"`
+	PercentOfSupply float64               `json:"percent_of_supply"`
+	Category        models.UnlockCategory `json:"category"`
+	Description     string                `json:"description"`
+	Source          string                `json:"source"`
+}
+
+// UnlockCalendar lists upcoming unlocks grouped by UTC day
+type UnlockCalendar struct {
+	From time.Time    `json:"from"`
+	To   time.Time    `json:"to"`
+	Days []*UnlockDay `json:"days"` // soonest first, days without unlocks left out
+}
+
+type UnlockDay struct {
+	Date    string         `json:"date"` // YYYY-MM-DD
+	Unlocks []*UnlockEntry `json:"unlocks"`
+}
+
+// UnlockEntry is an unlock valued at the token's latest price
+type UnlockEntry struct {
+	*models.TokenUnlockSchedule
+	EstimatedValueUSD float64 `json:"estimated_value_usd"` // 0 when the amount or price is unknown
+}
+
+func (s *unlockService) CreateUnlock(ctx context.Context, req *UnlockRequest) (*models.TokenUnlockSchedule, error) {
+	unlocks, err := s.ImportUnlocks(ctx, []*UnlockRequest{req})
+	if err != nil {
+		return nil, err
+	}
+	return unlocks[0], nil
+}
+
+// ImportUnlocks stores a batch of unlocks; nothing is stored unless every entry is valid
+func (s *unlockService) ImportUnlocks(ctx context.Context, reqs []*UnlockRequest) ([]*models.TokenUnlockSchedule, error) {
+	if len(reqs) == 0 || len(reqs) > maxImportUnlocks {
+		return nil, fmt.Errorf("%w: between 1 and %d unlocks can be imported at once", ErrInvalidUnlock, maxImportUnlocks)
+	}
+
+	unlocks := make([]*models.TokenUnlockSchedule, 0, len(reqs))
+	for i, req := range reqs {
+		unlock := &models.TokenUnlockSchedule{}
+		if err := s.apply(ctx, unlock, req); err != nil {
+			if len(reqs) > 1 {
+				return nil, fmt.Errorf("unlock %d: %w", i, err)
+			}
+			return nil, err
+		}
+		unlocks = append(unlocks, unlock)
+	}
+
+	if err := s.tokenRepo.CreateUnlocks(ctx, unlocks); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithField("count", len(unlocks)).Info("Token unlock schedules stored")
+	return unlocks, nil
+}
+
+func (s *unlockService) UpdateUnlock(ctx context.Context, id uuid.UUID, req *UnlockRequest) (*models.TokenUnlockSchedule, error) {
+	unlock, err := s.tokenRepo.GetUnlockByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if unlock == nil {
+		return nil, ErrUnlockNotFound
+	}
+
+	// A rescheduled unlock is warned about again
+	if !req.UnlockAt.Equal(unlock.UnlockAt) {
+		unlock.WarnedAt = nil
+	}
+	if err := s.apply(ctx, unlock, req); err != nil {
+		return nil, err
+	}
+	if err := s.tokenRepo.UpdateUnlock(ctx, unlock); err != nil {
+		return nil, err
+	}
+	return unlock, nil
+}
+
+func (s *unlockService) DeleteUnlock(ctx context.Context, id uuid.UUID) error {
+	unlock, err := s.tokenRepo.GetUnlockByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if unlock == nil {
+		return ErrUnlockNotFound
+	}
+	return s.tokenRepo.DeleteUnlock(ctx, id)
+}
+
+// apply validates a request and copies it onto the unlock
+func (s *unlockService) apply(ctx context.Context, unlock *models.TokenUnlockSchedule, req *UnlockRequest) error {
+	category := req.Category
+	if category == "" {
+		category = models.UnlockCategoryOther
+	}
+	if !category.IsValid() {
+		return fmt.Errorf("%w: category must be one of team, investors, ecosystem, community, treasury, other", ErrInvalidUnlock)
+	}
+	if req.UnlockAt.IsZero() {
+		return fmt.Errorf("%w: unlock_at is required", ErrInvalidUnlock)
+	}
+	if req.Amount < 0 || req.PercentOfSupply < 0 || req.PercentOfSupply > 100 {
+		return fmt.Errorf("%w: amount must not be negative and percent_of_supply must be between 0 and 100", ErrInvalidUnlock)
+	}
+	if req.Amount == 0 && req.PercentOfSupply == 0 {
+		return fmt.Errorf("%w: amount or percent_of_supply is required", ErrInvalidUnlock)
+	}
+
+	tok, err := s.tokenRepo.GetByMintAddress(ctx, req.MintAddress)
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return token.ErrTokenNotFound
+	}
+
+	percent := req.PercentOfSupply
+	if percent == 0 {
+		marketData, err := s.tokenRepo.GetLatestMarketData(ctx, tok.ID)
+		if err != nil {
+			return err
+		}
+		if marketData == nil || marketData.TotalSupply <= 0 {
+			return fmt.Errorf("%w: percent_of_supply is required while the token's total supply is unknown", ErrInvalidUnlock)
+		}
+		percent = req.Amount / marketData.TotalSupply * 100
+	}
+
+	unlock.TokenID = tok.ID
+	unlock.MintAddress = tok.MintAddress
+	unlock.UnlockAt = req.UnlockAt.UTC()
+	unlock.Amount = req.Amount
+	unlock.PercentOfSupply = percent
+	unlock.Category = category
+	unlock.Description = req.Description
+	unlock.Source = req.Source
+	return nil
+}
+
+// GetTokenUnlocks lists a token's unlocks that are still to come
+func (s *unlockService) GetTokenUnlocks(ctx context.Context, mintAddress string) ([]*models.TokenUnlockSchedule, error) {
+	tok, err := s.tokenRepo.GetByMintAddress(ctx, mintAddress)
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, token.ErrTokenNotFound
+	}
+	return s.tokenRepo.GetTokenUnlocks(ctx, tok.ID, time.Now())
+}
+
+// GetCalendar lists the unlocks of the coming days, valued at each token's latest price
+func (s *unlockService) GetCalendar(ctx context.Context, days, limit int) (*UnlockCalendar, error) {
+	if days <= 0 || days > maxCalendarDays {
+		days = defaultCalendarDays
+	}
+	if limit <= 0 || limit > maxCalendarLimit {
+		limit = defaultCalendarLimit
+	}
+
+	from := time.Now().UTC()
+	to := from.AddDate(0, 0, days)
+	unlocks, err := s.tokenRepo.GetUnlocksBetween(ctx, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &UnlockCalendar{From: from, To: to, Days: []*UnlockDay{}}
+	prices := make(map[uuid.UUID]float64)
+	var day *UnlockDay
+	for _, unlock := range unlocks {
+		price, ok := prices[unlock.TokenID]
+		if !ok {
+			if marketData, err := s.tokenRepo.GetLatestMarketData(ctx, unlock.TokenID); err == nil && marketData != nil {
+				price = marketData.PriceUSD
+			}
+			prices[unlock.TokenID] = price
+		}
+
+		date := unlock.UnlockAt.UTC().Format("2006-01-02")
+		if day == nil || day.Date != date {
+			day = &UnlockDay{Date: date}
+			calendar.Days = append(calendar.Days, day)
+		}
+		day.Unlocks = append(day.Unlocks, &UnlockEntry{
+			TokenUnlockSchedule: unlock,
+			EstimatedValueUSD:   unlock.Amount * price,
+		})
+	}
+	return calendar, nil
+}
+
+// WarnUpcomingUnlocks warns the active rooms of a token once about each large unlock within the warning
+// window and returns how many unlocks were warned about
+func (s *unlockService) WarnUpcomingUnlocks(ctx context.Context) (int, error) {
+	now := time.Now()
+	unlocks, err := s.tokenRepo.GetUnwarnedUnlocks(ctx, now, now.Add(unlockWarningWindow), largeUnlockPercent)
+	if err != nil {
+		return 0, err
+	}
+
+	warned := 0
+	for _, unlock := range unlocks {
+		rooms, err := s.roomRepo.GetActiveByToken(ctx, unlock.MintAddress)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err,
+				"mint_address": unlock.MintAddress,
+			}).Error("Failed to get rooms for unlock warning")
+			continue
+		}
+
+		for _, tradeRoom := range rooms {
+			message := &room.Message{
+				Type: room.MessageTypeUnlockWarning,
+				Data: unlock,
+			}
+			if err := s.wsService.BroadcastToRoom(tradeRoom.RoomID, message); err != nil {
+				s.logger.WithFields(logrus.Fields{
+					"error":   err,
+					"room_id": tradeRoom.RoomID,
+				}).Warn("Failed to broadcast unlock warning")
+			}
+		}
+
+		if err := s.tokenRepo.MarkUnlockWarned(ctx, unlock.ID, now); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err,
+				"unlock_id": unlock.ID,
+			}).Error("Failed to mark unlock warned")
+			continue
+		}
+		warned++
+
+		s.logger.WithFields(logrus.Fields{
+			"mint_address":      unlock.MintAddress,
+			"unlock_at":         unlock.UnlockAt,
+			"percent_of_supply": unlock.PercentOfSupply,
+			"rooms":             len(rooms),
+		}).Info("Rooms warned of upcoming token unlock")
+	}
+	return warned, nil
+}
@@ -0,0 +1,134 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+var (
+	ErrInvalidPurgeSignature = errors.New("invalid purge signature")
+	ErrPurgeSignatureExpired = errors.New("purge signature expired")
+	ErrPurgeSignatureUsed    = errors.New("purge signature was already used")
+	ErrWalletOwnsRooms       = errors.New("transfer or close the rooms the wallet owns before purging it")
+)
+
+const (
+	// purgeSignatureMaxAge bounds how old a signed purge request may be
+	purgeSignatureMaxAge = 5 * time.Minute
+
+	// Spent signatures are kept for the whole window their timestamp may be accepted in
+	usedPurgeSignaturePrefix = "purge_signature:"
+	usedPurgeSignatureTTL    = 2 * purgeSignatureMaxAge
+)
+
+// PurgeMessage is what a wallet signs to purge its data; the dry run flag is signed with the action, so a
+// signature for a dry run cannot purge
+func PurgeMessage(walletAddress, timestamp string, dryRun bool) string {
+	return fmt.Sprintf("solana-wallet-service purge\n%s\ndry_run: %t\n%s", walletAddress, dryRun, timestamp)
+}
+
+// PurgeRequest is a wallet's signed request to purge its data
+type PurgeRequest struct {
+	Signature string `json:"signature" binding:"required"` // base58 ed25519 signature of PurgeMessage
+	Timestamp string `json:"timestamp" binding:"required"` // unix seconds
+	DryRun    bool   `json:"dry_run"`                      // report what would be purged without purging it
+}
+
+// PurgeService removes a wallet's personal data at its signed request
+type PurgeService interface {
+	Purge(ctx context.Context, walletAddress string, req *PurgeRequest) (*repositories.WalletPurge, error)
+}
+
+type purgeService struct {
+	purgeRepo repositories.PurgeRepository
+	client    *redis.Client
+	listener  room.MembershipListener
+	logger    *logrus.Logger
+}
+
+// NewPurgeService creates a new purge service instance; the listener is told about the rooms a purged
+// wallet leaves, so its subscriptions are dropped. Signatures are single-use through Redis; a nil client
+// only checks their age.
+func NewPurgeService(purgeRepo repositories.PurgeRepository, client *redis.Client, listener room.MembershipListener, logger *logrus.Logger) PurgeService {
+	return &purgeService{
+		purgeRepo: purgeRepo,
+		client:    client,
+		listener:  listener,
+		logger:    logger,
+	}
+}
+
+// Purge removes the wallet's memberships, reactions, follows, settings and notification data and anonymizes
+// the room content it posted. Rooms it owns must be transferred or closed first.
+func (s *purgeService) Purge(ctx context.Context, walletAddress string, req *PurgeRequest) (*repositories.WalletPurge, error) {
+	if err := solana.ValidateAddress(walletAddress); err != nil {
+		return nil, err
+	}
+	seconds, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidPurgeSignature
+	}
+	age := time.Since(time.Unix(seconds, 0))
+	if age > purgeSignatureMaxAge || age < -purgeSignatureMaxAge {
+		return nil, ErrPurgeSignatureExpired
+	}
+	if !solana.VerifySignature(walletAddress, []byte(PurgeMessage(walletAddress, req.Timestamp, req.DryRun)), req.Signature) {
+		return nil, ErrInvalidPurgeSignature
+	}
+	if err := s.spendSignature(ctx, req.Signature); err != nil {
+		return nil, err
+	}
+
+	purge, err := s.purgeRepo.PurgeWallet(ctx, walletAddress, req.DryRun)
+	if errors.Is(err, repositories.ErrWalletOwnsRooms) {
+		return nil, ErrWalletOwnsRooms
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge wallet: %w", err)
+	}
+	if req.DryRun {
+		return purge, nil
+	}
+
+	for _, roomID := range purge.RoomIDs {
+		if err := s.listener.HandleUserLeftRoom(walletAddress, roomID); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":   err,
+				"wallet":  walletAddress,
+				"room_id": roomID,
+			}).Warn("Failed to drop purged wallet's room subscription")
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"wallet":        walletAddress,
+		"memberships":   purge.Memberships,
+		"shared_infos":  purge.SharedInfos,
+		"notifications": purge.Notifications,
+	}).Info("Wallet data purged")
+	return purge, nil
+}
+
+// spendSignature marks a verified signature as used, refusing one that was used before
+func (s *purgeService) spendSignature(ctx context.Context, signature string) error {
+	if s.client == nil {
+		return nil
+	}
+	fresh, err := s.client.SetNX(ctx, usedPurgeSignaturePrefix+signature, 1, usedPurgeSignatureTTL).Result()
+	if err != nil {
+		return fmt.Errorf("failed to record purge signature: %w", err)
+	}
+	if !fresh {
+		return ErrPurgeSignatureUsed
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+// maxHiddenTokens bounds the hidden token list stored per wallet
+const maxHiddenTokens = 500
+
+var (
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+	ErrInvalidSetting      = errors.New("invalid setting value")
+)
+
+// SettingsService defines the interface for per-wallet user settings
+type SettingsService interface {
+	GetSettings(ctx context.Context, walletAddress string) (*models.UserSettings, error)
+	UpdateSettings(ctx context.Context, walletAddress string, req *UpdateSettingsRequest) (*models.UserSettings, error)
+}
+
+type settingsService struct {
+	settingsRepo repositories.UserSettingsRepository
+	logger       *logrus.Logger
+}
+
+// NewSettingsService creates a new settings service instance
+func NewSettingsService(settingsRepo repositories.UserSettingsRepository, logger *logrus.Logger) SettingsService {
+	return &settingsService{
+		settingsRepo: settingsRepo,
+		logger:       logger,
+	}
+}
+
+// UpdateSettingsRequest holds a partial settings update; nil fields are left unchanged
+type UpdateSettingsRequest struct {
+	Language                *string   `json:"language,omitempty"`
+	Timezone                *string   `json:"timezone,omitempty"`
+	NotifyMemberActivity    *bool     `json:"notify_member_activity,omitempty"`
+	NotifySharedInfo        *bool     `json:"notify_shared_info,omitempty"`
+	NotifyTradeEvents       *bool     `json:"notify_trade_events,omitempty"`
+	NotifyMomentumAlerts    *bool     `json:"notify_momentum_alerts,omitempty"`
+	HiddenTokens            *[]string `json:"hidden_tokens,omitempty"`
+	AlertMinValueUSD        *float64  `json:"alert_min_value_usd,omitempty"`
+	AlertPriceChangePercent *float64  `json:"alert_price_change_percent,omitempty"`
+
+	NotificationRoutes *models.NotificationRoutes `json:"notification_routes,omitempty"`
+	TelegramChatID     *string                    `json:"telegram_chat_id,omitempty"`
+	WebhookURL         *string                    `json:"webhook_url,omitempty"` // empty clears it
+	EmailOptOuts       *[]models.EmailCategory    `json:"email_opt_outs,omitempty"`
+}
+
+// GetSettings returns the wallet's settings, or defaults if none have been saved
+func (s *settingsService) GetSettings(ctx context.Context, walletAddress string) (*models.UserSettings, error) {
+	settings, err := s.settingsRepo.GetByWallet(ctx, walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+	if settings == nil {
+		settings = models.NewDefaultUserSettings(walletAddress)
+	}
+	return settings, nil
+}
+
+func (s *settingsService) UpdateSettings(ctx context.Context, walletAddress string, req *UpdateSettingsRequest) (*models.UserSettings, error) {
+	settings, err := s.GetSettings(ctx, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Language != nil {
+		if !models.IsSupportedLanguage(*req.Language) {
+			return nil, ErrUnsupportedLanguage
+		}
+		settings.Language = *req.Language
+	}
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+			return nil, ErrInvalidTimezone
+		}
+		settings.Timezone = *req.Timezone
+	}
+	if req.NotifyMemberActivity != nil {
+		settings.NotifyMemberActivity = *req.NotifyMemberActivity
+	}
+	if req.NotifySharedInfo != nil {
+		settings.NotifySharedInfo = *req.NotifySharedInfo
+	}
+	if req.NotifyTradeEvents != nil {
+		settings.NotifyTradeEvents = *req.NotifyTradeEvents
+	}
+	if req.NotifyMomentumAlerts != nil {
+		settings.NotifyMomentumAlerts = *req.NotifyMomentumAlerts
+	}
+	if req.HiddenTokens != nil {
+		if len(*req.HiddenTokens) > maxHiddenTokens {
+			return nil, fmt.Errorf("%w: at most %d hidden tokens", ErrInvalidSetting, maxHiddenTokens)
+		}
+		hiddenBytes, err := json.Marshal(*req.HiddenTokens)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode hidden tokens: %w", err)
+		}
+		settings.HiddenTokens = string(hiddenBytes)
+	}
+	if req.AlertMinValueUSD != nil {
+		if *req.AlertMinValueUSD < 0 {
+			return nil, fmt.Errorf("%w: alert_min_value_usd must not be negative", ErrInvalidSetting)
+		}
+		settings.AlertMinValueUSD = *req.AlertMinValueUSD
+	}
+	if req.AlertPriceChangePercent != nil {
+		if *req.AlertPriceChangePercent <= 0 {
+			return nil, fmt.Errorf("%w: alert_price_change_percent must be positive", ErrInvalidSetting)
+		}
+		settings.AlertPriceChangePercent = *req.AlertPriceChangePercent
+	}
+	if req.NotificationRoutes != nil {
+		for notificationType, channels := range *req.NotificationRoutes {
+			if !notificationType.IsValid() {
+				return nil, fmt.Errorf("%w: unknown notification type %q", ErrInvalidSetting, notificationType)
+			}
+			for _, channel := range channels {
+				if !channel.IsValid() {
+					return nil, fmt.Errorf("%w: channel must be one of websocket, telegram, webhook, email", ErrInvalidSetting)
+				}
+			}
+		}
+		routesBytes, err := json.Marshal(*req.NotificationRoutes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode notification routes: %w", err)
+		}
+		settings.NotificationRoutes = string(routesBytes)
+	}
+	if req.TelegramChatID != nil {
+		settings.TelegramChatID = *req.TelegramChatID
+	}
+	if req.WebhookURL != nil {
+		if *req.WebhookURL != "" {
+			parsed, err := url.Parse(*req.WebhookURL)
+			if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+				return nil, fmt.Errorf("%w: webhook_url must be an http(s) URL", ErrInvalidSetting)
+			}
+		}
+		settings.WebhookURL = *req.WebhookURL
+	}
+	if req.EmailOptOuts != nil {
+		for _, category := range *req.EmailOptOuts {
+			if !category.IsValid() {
+				return nil, fmt.Errorf("%w: email category must be one of digest, price_alert, room_invite, whale_alert, follow", ErrInvalidSetting)
+			}
+		}
+		optOutsBytes, err := json.Marshal(*req.EmailOptOuts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode email opt-outs: %w", err)
+		}
+		settings.EmailOptOuts = string(optOutsBytes)
+	}
+
+	if err := s.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save user settings: %w", err)
+	}
+
+	s.logger.WithField("wallet", walletAddress).Info("User settings updated")
+
+	return settings, nil
+}
@@ -0,0 +1,109 @@
+package apistats
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Window is how far back call and error counts are kept, in one-minute buckets
+const Window = time.Hour
+
+const bucketCount = int(Window / time.Minute)
+
+// Stats are the calls made to one external API within the window and how many failed
+type Stats struct {
+	API       string  `json:"api"`
+	Calls     int64   `json:"calls"`
+	Errors    int64   `json:"errors"`
+	ErrorRate float64 `json:"error_rate"` // errors / calls, 0 without calls
+}
+
+type bucket struct {
+	minute int64 // unix minute the counts belong to
+	calls  int64
+	errors int64
+}
+
+// Registry counts external API calls and failures per API over a sliding window
+type Registry struct {
+	mu   sync.Mutex
+	apis map[string]*[bucketCount]bucket
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{apis: make(map[string]*[bucketCount]bucket)}
+}
+
+// Record counts one call to the API
+func (r *Registry) Record(api string, failed bool) {
+	minute := time.Now().Unix() / 60
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	buckets, ok := r.apis[api]
+	if !ok {
+		buckets = &[bucketCount]bucket{}
+		r.apis[api] = buckets
+	}
+	b := &buckets[minute%int64(bucketCount)]
+	if b.minute != minute {
+		*b = bucket{minute: minute}
+	}
+	b.calls++
+	if failed {
+		b.errors++
+	}
+}
+
+// Snapshot returns the counts of every API seen, by name
+func (r *Registry) Snapshot() []Stats {
+	oldest := time.Now().Unix()/60 - int64(bucketCount) + 1
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]Stats, 0, len(r.apis))
+	for api, buckets := range r.apis {
+		s := Stats{API: api}
+		for _, b := range buckets {
+			if b.minute >= oldest {
+				s.Calls += b.calls
+				s.Errors += b.errors
+			}
+		}
+		if s.Calls > 0 {
+			s.ErrorRate = float64(s.Errors) / float64(s.Calls)
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].API < stats[j].API
+	})
+	return stats
+}
+
+// Transport returns a transport recording every request made through base to the registry under api; a nil
+// base uses http.DefaultTransport. Transport errors, rate limiting and server errors count as failures.
+func (r *Registry) Transport(api string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{api: api, base: base, registry: r}
+}
+
+type transport struct {
+	api      string
+	base     http.RoundTripper
+	registry *Registry
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	failed := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	t.registry.Record(t.api, failed)
+	return resp, err
+}
@@ -0,0 +1,173 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and makes tickers and timers; services take one so expiries and heartbeats can be
+// driven by a Manual clock in tests
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Ticker delivers ticks like time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Timer runs a function once its duration passes, like the timer of time.AfterFunc
+type Timer interface {
+	Stop() bool
+}
+
+// Real is the wall clock
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// Manual is a clock that only moves when advanced; its tickers and timers fire as Advance passes their ticks
+type Manual struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*manualTicker
+	timers  []*manualTimer
+}
+
+// NewManual creates a manual clock set to now
+func NewManual(now time.Time) *Manual {
+	return &Manual{now: now}
+}
+
+func (m *Manual) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+func (m *Manual) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := &manualTicker{clock: m, interval: d, next: m.now.Add(d), c: make(chan time.Time, 1)}
+	m.tickers = append(m.tickers, t)
+	return t
+}
+
+func (m *Manual) AfterFunc(d time.Duration, f func()) Timer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := &manualTimer{clock: m, at: m.now.Add(d), f: f}
+	m.timers = append(m.timers, t)
+	return t
+}
+
+// Advance moves the clock forward, firing each tick and timer passed in time order; like time.Ticker, ticks a
+// slow receiver has not taken yet are dropped. Timer functions run on the caller's goroutine once the clock
+// has reached the end, so they may use the clock.
+func (m *Manual) Advance(d time.Duration) {
+	m.mu.Lock()
+
+	end := m.now.Add(d)
+	var due []func()
+	for {
+		sort.Slice(m.tickers, func(i, j int) bool {
+			return m.tickers[i].next.Before(m.tickers[j].next)
+		})
+		sort.Slice(m.timers, func(i, j int) bool {
+			return m.timers[i].at.Before(m.timers[j].at)
+		})
+		tickerDue := len(m.tickers) > 0 && !m.tickers[0].next.After(end)
+		timerDue := len(m.timers) > 0 && !m.timers[0].at.After(end)
+		if timerDue && (!tickerDue || m.timers[0].at.Before(m.tickers[0].next)) {
+			t := m.timers[0]
+			m.now = t.at
+			m.timers = m.timers[1:]
+			due = append(due, t.f)
+			continue
+		}
+		if !tickerDue {
+			break
+		}
+		t := m.tickers[0]
+		m.now = t.next
+		select {
+		case t.c <- t.next:
+		default:
+		}
+		t.next = t.next.Add(t.interval)
+	}
+	m.now = end
+	m.mu.Unlock()
+
+	for _, f := range due {
+		f()
+	}
+}
+
+type manualTicker struct {
+	clock    *Manual
+	interval time.Duration
+	next     time.Time
+	c        chan time.Time
+}
+
+func (t *manualTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, other := range t.clock.tickers {
+		if other == t {
+			t.clock.tickers = append(t.clock.tickers[:i], t.clock.tickers[i+1:]...)
+			return
+		}
+	}
+}
+
+type manualTimer struct {
+	clock *Manual
+	at    time.Time
+	f     func()
+}
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, other := range t.clock.timers {
+		if other == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -2,12 +2,11 @@ package database
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/logger"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type Database struct {
@@ -19,7 +18,7 @@ func NewPostgresConnection(cfg config.DatabaseConfig) (*Database, error) {
 		cfg.Host, cfg.User, cfg.Password, cfg.DBName, cfg.Port, cfg.SSLMode, cfg.TimeZone)
 
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
+		Logger: newSlowQueryLogger(logger.GetLogger(), cfg.SlowQueryThreshold),
 	}
 
 	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+const defaultSlowQueryThreshold = 200 * time.Millisecond
+
+// slowQueryLogger is a gorm logger reporting queries slower than the threshold to logrus; failed queries and
+// gorm's own messages are dropped like with the silent logger it replaces, as callers handle their errors
+type slowQueryLogger struct {
+	log       *logrus.Logger
+	threshold time.Duration
+	level     logger.LogLevel
+}
+
+func newSlowQueryLogger(log *logrus.Logger, threshold time.Duration) logger.Interface {
+	if threshold <= 0 {
+		threshold = defaultSlowQueryThreshold
+	}
+	return &slowQueryLogger{log: log, threshold: threshold, level: logger.Warn}
+}
+
+func (l *slowQueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *slowQueryLogger) Info(ctx context.Context, msg string, args ...interface{}) {}
+
+func (l *slowQueryLogger) Warn(ctx context.Context, msg string, args ...interface{}) {}
+
+func (l *slowQueryLogger) Error(ctx context.Context, msg string, args ...interface{}) {}
+
+func (l *slowQueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	if elapsed < l.threshold {
+		return
+	}
+
+	sql, rows := fc()
+	entry := l.log.WithFields(logrus.Fields{
+		"sql":        sql,
+		"rows":       rows,
+		"elapsed_ms": elapsed.Milliseconds(),
+		"caller":     utils.FileWithLineNum(),
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		entry = entry.WithError(err)
+	}
+	entry.WithField("threshold_ms", l.threshold.Milliseconds()).Warn("Slow database query")
+}
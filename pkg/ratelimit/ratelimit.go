@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/usage"
+	"golang.org/x/time/rate"
+)
+
+// ProviderGeckoTerminal is the keyless GeckoTerminal API; it is not metered, so usage has no name for it
+const ProviderGeckoTerminal = "geckoterminal"
+
+// Built-in provider rates, used when the provider has no configured rate
+var defaultLimits = map[string]config.APIRateLimitConfig{
+	usage.ProviderSolanaTracker: {RequestsPerSecond: 1, Burst: 1},
+	usage.ProviderBirdeye:       {RequestsPerSecond: 1, Burst: 1},
+	ProviderGeckoTerminal:       {RequestsPerSecond: 0.5, Burst: 1}, // 30 calls a minute on the public API
+}
+
+// Registry holds the token bucket limiters of the external APIs, keyed by provider and endpoint class. One
+// registry is shared by every client of the instance, so concurrent callers draw from the same buckets.
+type Registry struct {
+	mu       sync.Mutex
+	config   map[string]config.ProviderRateLimitConfig
+	limiters map[string][]*rate.Limiter // provider and endpoint class limiters of each provider/class
+}
+
+// NewRegistry creates a registry of the configured rates, keyed by provider
+func NewRegistry(cfg map[string]config.ProviderRateLimitConfig) *Registry {
+	return &Registry{
+		config:   cfg,
+		limiters: make(map[string][]*rate.Limiter),
+	}
+}
+
+// Wait blocks until both the provider's and the endpoint class's bucket allow a request. It returns an error
+// without waiting when ctx would expire first, and as soon as ctx is done while waiting.
+func (r *Registry) Wait(ctx context.Context, provider, class string) error {
+	for _, limiter := range r.limitersOf(provider, class) {
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("rate limit of %s %s: %w", provider, class, err)
+		}
+	}
+	return nil
+}
+
+// limitersOf returns the limiters a request of the endpoint class passes, creating them on first use. The
+// provider limiter is shared by all of its classes.
+func (r *Registry) limitersOf(provider, class string) []*rate.Limiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := provider + "/" + class
+	if limiters, ok := r.limiters[key]; ok {
+		return limiters
+	}
+
+	providerLimiters, ok := r.limiters[provider]
+	if !ok {
+		limit := r.config[provider].APIRateLimitConfig
+		if limit.RequestsPerSecond <= 0 {
+			limit = defaultLimits[provider]
+		}
+		if limit.RequestsPerSecond > 0 {
+			providerLimiters = []*rate.Limiter{newLimiter(limit)}
+		}
+		r.limiters[provider] = providerLimiters
+	}
+
+	limiters := providerLimiters
+	if limit := r.config[provider].Endpoints[class]; limit.RequestsPerSecond > 0 {
+		limiters = append([]*rate.Limiter{newLimiter(limit)}, providerLimiters...)
+	}
+	r.limiters[key] = limiters
+	return limiters
+}
+
+func newLimiter(limit config.APIRateLimitConfig) *rate.Limiter {
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -9,6 +10,18 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
+// Nil is the error returned when a key does not exist
+const Nil = redis.Nil
+
+// Stream argument types, so callers need not import the driver
+type (
+	XAddArgs       = redis.XAddArgs
+	XReadGroupArgs = redis.XReadGroupArgs
+)
+
+// IntCmd is the result of an integer command, such as one queued on a pipeline
+type IntCmd = redis.IntCmd
+
 type Client struct {
 	*redis.Client
 }
@@ -42,13 +55,17 @@ func (c *Client) SetWithExpiry(ctx context.Context, key string, value interface{
 }
 
 func (c *Client) GetJSON(ctx context.Context, key string, dest interface{}) error {
-	val, err := c.Get(ctx, key).Result()
+	val, err := c.Get(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(val, dest)
+}
+
+func (c *Client) SetJSON(ctx context.Context, key string, value interface{}, expiry time.Duration) error {
+	data, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
-	
-	// Simple JSON unmarshaling - in production, use json.Unmarshal
-	_ = val
-	_ = dest
-	return nil
-}
\ No newline at end of file
+	return c.Set(ctx, key, data, expiry).Err()
+}
@@ -0,0 +1,148 @@
+package solana
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// AddressLength is the size of a decoded public key
+	AddressLength = 32
+
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// ErrInvalidAddress is returned, wrapped with the reason, for malformed addresses
+var ErrInvalidAddress = errors.New("invalid Solana address")
+
+var (
+	// Ed25519 field prime 2^255 - 19 and curve constant d = -121665/121666
+	curveP = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	curveD = new(big.Int).Mod(
+		new(big.Int).Mul(big.NewInt(-121665), new(big.Int).ModInverse(big.NewInt(121666), curveP)),
+		curveP,
+	)
+)
+
+// DecodeBase58 decodes a base58 string using the Bitcoin alphabet Solana uses
+func DecodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i, r := range s {
+		digit := strings.IndexRune(base58Alphabet, r)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q at position %d", r, i)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	// Each leading '1' encodes a leading zero byte
+	zeros := len(s) - len(strings.TrimLeft(s, "1"))
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// EncodeBase58 encodes bytes using the Bitcoin alphabet Solana uses
+func EncodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// Each leading zero byte encodes as a leading '1'
+	for i := 0; i < len(b) && b[i] == 0; i++ {
+		out = append(out, '1')
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// ValidateAddress checks that address is a base58-encoded 32-byte public key. Solana addresses carry
+// no checksum, so the decoded length is what catches typos and truncated addresses.
+func ValidateAddress(address string) error {
+	_, err := decodeAddress(address)
+	return err
+}
+
+// VerifySignature reports whether signature, base58 encoded, is the ed25519 signature of message by the
+// address's key
+func VerifySignature(address string, message []byte, signature string) bool {
+	publicKey, err := decodeAddress(address)
+	if err != nil {
+		return false
+	}
+	decoded, err := DecodeBase58(strings.TrimSpace(signature))
+	if err != nil || len(decoded) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(publicKey, message, decoded)
+}
+
+// IsValidAddress reports whether address is a well-formed Solana address
+func IsValidAddress(address string) bool {
+	return ValidateAddress(address) == nil
+}
+
+// IsPDA reports whether address is a valid address that lies off the ed25519 curve. Program derived
+// addresses are always off the curve, so no private key exists for them; wallet keys are always on it.
+func IsPDA(address string) bool {
+	key, err := decodeAddress(address)
+	if err != nil {
+		return false
+	}
+	return !IsOnCurve(key)
+}
+
+// IsOnCurve reports whether a 32-byte public key decompresses to a point on the ed25519 curve
+func IsOnCurve(key []byte) bool {
+	if len(key) != AddressLength {
+		return false
+	}
+
+	// The key is y in little endian with the sign of x in the top bit
+	le := make([]byte, AddressLength)
+	for i, b := range key {
+		le[AddressLength-1-i] = b
+	}
+	le[0] &= 0x7f
+	y := new(big.Int).SetBytes(le)
+	y.Mod(y, curveP)
+
+	// x^2 = (y^2 - 1) / (d*y^2 + 1) must have a square root
+	y2 := new(big.Int).Mul(y, y)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	v := new(big.Int).Mul(curveD, y2)
+	v.Add(v, big.NewInt(1))
+	x2 := u.Mul(u, new(big.Int).ModInverse(v.Mod(v, curveP), curveP))
+	x2.Mod(x2, curveP)
+
+	return x2.Sign() == 0 || big.Jacobi(x2, curveP) == 1
+}
+
+func decodeAddress(address string) ([]byte, error) {
+	if address == "" {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidAddress)
+	}
+	// 32 bytes encode to at most 44 characters
+	if len(address) > 44 {
+		return nil, fmt.Errorf("%w: %d characters, at most 44 allowed", ErrInvalidAddress, len(address))
+	}
+
+	key, err := DecodeBase58(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	if len(key) != AddressLength {
+		return nil, fmt.Errorf("%w: decodes to %d bytes, want %d", ErrInvalidAddress, len(key), AddressLength)
+	}
+	return key, nil
+}
@@ -0,0 +1,99 @@
+package solana
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	usdcMint = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	// usdcATA is the associated token account of usdcMint for the wallet 9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM,
+	// found at bump 253
+	usdcATA = "G7259A6wU7iVKLJcaTgXf7Xuy1tp9H8EhBLPAP3aUE11"
+)
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		valid   bool
+		pda     bool
+	}{
+		{name: "wallet key", address: "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM", valid: true},
+		{name: "mint", address: usdcMint, valid: true},
+		{name: "leading zero bytes", address: "So11111111111111111111111111111111111111112", valid: true},
+		{name: "system program", address: "11111111111111111111111111111111", valid: true},
+		{name: "program derived address", address: usdcATA, valid: true, pda: true},
+		{name: "empty", address: ""},
+		{name: "truncated", address: usdcMint[:40]},
+		{name: "decodes past 32 bytes", address: strings.Repeat("z", 44)},
+		{name: "longer than 44 characters", address: usdcMint + "1"},
+		{name: "zero", address: "0" + usdcMint[1:]},
+		{name: "capital o", address: "O" + usdcMint[1:]},
+		{name: "capital i", address: "I" + usdcMint[1:]},
+		{name: "lowercase L", address: "l" + usdcMint[1:]},
+		{name: "surrounding space", address: " " + usdcMint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAddress(tt.address)
+			if tt.valid && err != nil {
+				t.Fatalf("ValidateAddress(%q) = %v, want nil", tt.address, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidAddress) {
+				t.Fatalf("ValidateAddress(%q) = %v, want ErrInvalidAddress", tt.address, err)
+			}
+			if got := IsValidAddress(tt.address); got != tt.valid {
+				t.Errorf("IsValidAddress(%q) = %v, want %v", tt.address, got, tt.valid)
+			}
+			if got := IsPDA(tt.address); got != tt.pda {
+				t.Errorf("IsPDA(%q) = %v, want %v", tt.address, got, tt.pda)
+			}
+		})
+	}
+}
+
+func TestIsOnCurve(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		onCurve bool
+	}{
+		{name: "wallet key", address: "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM", onCurve: true},
+		{name: "mint", address: usdcMint, onCurve: true},
+		{name: "program derived address", address: usdcATA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := DecodeBase58(tt.address)
+			if err != nil {
+				t.Fatalf("DecodeBase58(%q): %v", tt.address, err)
+			}
+			if got := IsOnCurve(key); got != tt.onCurve {
+				t.Errorf("IsOnCurve(%s) = %v, want %v", tt.address, got, tt.onCurve)
+			}
+		})
+	}
+
+	t.Run("wrong length", func(t *testing.T) {
+		key, _ := DecodeBase58(usdcMint)
+		if IsOnCurve(key[:31]) || IsOnCurve(append(key, 0)) {
+			t.Error("IsOnCurve accepted a key that is not 32 bytes")
+		}
+	})
+}
+
+func TestBase58RoundTrip(t *testing.T) {
+	for _, address := range []string{usdcMint, usdcATA, "So11111111111111111111111111111111111111112", "11111111111111111111111111111111"} {
+		key, err := DecodeBase58(address)
+		if err != nil {
+			t.Fatalf("DecodeBase58(%q): %v", address, err)
+		}
+		if got := EncodeBase58(key); got != address {
+			t.Errorf("EncodeBase58(DecodeBase58(%q)) = %q", address, got)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package usage
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+)
+
+// Metered providers
+const (
+	ProviderSolanaTracker = "solana_tracker"
+	ProviderQuickNode     = "quicknode"
+	ProviderOpenAI        = "openai"
+	ProviderBirdeye       = "birdeye"
+)
+
+const (
+	keyPrefix = "usage:" // string per provider and UTC day: the units used that day
+	keyTTL    = 40 * 24 * time.Hour
+
+	defaultSoftLimitPercent = 80
+	defaultMaxDelay         = 5 * time.Second
+	defaultQuickNodeCredits = 20 // most Solana RPC methods cost 20 QuickNode credits
+
+	usedRefreshInterval = 30 * time.Second // how long the month's usage read from Redis is trusted
+)
+
+// ProviderUsage is a provider's usage in the current UTC month
+type ProviderUsage struct {
+	Provider  string       `json:"provider"`
+	Unit      string       `json:"unit"`
+	Today     int64        `json:"today"`
+	Month     int64        `json:"month"`
+	Quota     int64        `json:"quota"`              // 0 without a quota
+	QuotaUsed float64      `json:"quota_used_percent"` // 0 without a quota
+	Throttled bool         `json:"throttled"`          // requests are being paced
+	Daily     []DailyUsage `json:"daily"`              // days of the month with usage, oldest first
+}
+
+// DailyUsage is a provider's usage on one UTC day
+type DailyUsage struct {
+	Day   string `json:"day"` // YYYY-MM-DD
+	Units int64  `json:"units"`
+}
+
+type provider struct {
+	name            string
+	unit            string
+	monthly         int64
+	unitsPerRequest int64
+
+	// Local view of the month, refreshed from Redis
+	month    string
+	used     int64
+	usedAt   time.Time
+	days     map[string]int64 // usage per day without Redis
+	requests int64            // requests and units seen by this instance, to estimate the units of a request
+	units    int64
+	next     time.Time // earliest start of the next paced request
+}
+
+// Tracker counts provider usage per UTC day in Redis and paces requests of providers approaching their
+// monthly quota. Without Redis usage is only counted by the local instance.
+type Tracker struct {
+	mu        sync.Mutex
+	client    *redis.Client
+	providers map[string]*provider
+	softLimit float64
+	maxDelay  time.Duration
+	logger    *logrus.Logger
+}
+
+// NewTracker creates a usage tracker; with a nil client usage is kept in memory
+func NewTracker(client *redis.Client, cfg *config.QuotaConfig, logger *logrus.Logger) *Tracker {
+	t := &Tracker{
+		client:    client,
+		providers: make(map[string]*provider),
+		softLimit: cfg.SoftLimitPercent / 100,
+		maxDelay:  cfg.MaxDelay,
+		logger:    logger,
+	}
+	if t.softLimit <= 0 {
+		t.softLimit = defaultSoftLimitPercent / 100.0
+	}
+	if t.maxDelay <= 0 {
+		t.maxDelay = defaultMaxDelay
+	}
+
+	t.addProvider(ProviderSolanaTracker, "requests", cfg.SolanaTracker, 1)
+	t.addProvider(ProviderQuickNode, "credits", cfg.QuickNode, defaultQuickNodeCredits)
+	t.addProvider(ProviderOpenAI, "tokens", cfg.OpenAI, 0)
+	t.addProvider(ProviderBirdeye, "requests", cfg.Birdeye, 1)
+	return t
+}
+
+func (t *Tracker) addProvider(name, unit string, cfg config.ProviderQuotaConfig, defaultUnitsPerRequest int64) {
+	unitsPerRequest := cfg.UnitsPerRequest
+	if unitsPerRequest <= 0 {
+		unitsPerRequest = defaultUnitsPerRequest
+	}
+	t.providers[name] = &provider{
+		name:            name,
+		unit:            unit,
+		monthly:         cfg.Monthly,
+		unitsPerRequest: unitsPerRequest,
+		days:            make(map[string]int64),
+	}
+}
+
+// Add counts units used of the provider today
+func (t *Tracker) Add(ctx context.Context, name string, units int64) {
+	if units <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	p, ok := t.providers[name]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+	now := time.Now().UTC()
+	day := now.Format("2006-01-02")
+	t.resetMonth(p, now)
+	p.used += units
+	p.units += units
+	p.days[day] += units
+	client := t.client
+	t.mu.Unlock()
+
+	if client == nil {
+		return
+	}
+	key := keyPrefix + name + ":" + day
+	pipe := client.Pipeline()
+	pipe.IncrBy(ctx, key, units)
+	pipe.Expire(ctx, key, keyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		t.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"provider": name,
+		}).Warn("Failed to record provider usage")
+	}
+}
+
+// Wait holds a request to the provider back while it is past the soft limit, pacing requests so the rest of
+// the quota lasts to the end of the month; past the quota every request waits the longest delay
+func (t *Tracker) Wait(ctx context.Context, name string) error {
+	delay := t.reserve(ctx, name)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes the provider's next request slot and returns how long to wait for it
+func (t *Tracker) reserve(ctx context.Context, name string) time.Duration {
+	t.mu.Lock()
+	p, ok := t.providers[name]
+	if !ok || p.monthly <= 0 {
+		t.mu.Unlock()
+		return 0
+	}
+	p.requests++
+	t.mu.Unlock()
+
+	used := t.monthUsed(ctx, p)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if float64(used) < t.softLimit*float64(p.monthly) {
+		return 0
+	}
+
+	now := time.Now().UTC()
+	interval := t.maxDelay
+	if remaining := p.monthly - used; remaining > 0 {
+		perRequest := p.unitsPerRequest
+		if perRequest <= 0 && p.requests > 0 {
+			perRequest = p.units / p.requests
+		}
+		if perRequest <= 0 {
+			perRequest = 1
+		}
+		monthEnd := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		interval = monthEnd.Sub(now) / time.Duration(remaining/perRequest+1)
+		if interval > t.maxDelay {
+			interval = t.maxDelay
+		}
+	}
+
+	slot := p.next
+	if slot.Before(now) {
+		slot = now
+	}
+	p.next = slot.Add(interval)
+	delay := slot.Sub(now)
+	if delay > t.maxDelay {
+		delay = t.maxDelay
+	}
+	return delay
+}
+
+// monthUsed returns the provider's usage in the current month, read from Redis at most every refresh interval
+func (t *Tracker) monthUsed(ctx context.Context, p *provider) int64 {
+	t.mu.Lock()
+	now := time.Now().UTC()
+	t.resetMonth(p, now)
+	if t.client == nil || time.Since(p.usedAt) < usedRefreshInterval {
+		used := p.used
+		t.mu.Unlock()
+		return used
+	}
+	p.usedAt = now
+	t.mu.Unlock()
+
+	days, err := t.monthDays(ctx, p.name, now)
+	if err != nil {
+		t.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"provider": p.name,
+		}).Warn("Failed to read provider usage")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err == nil {
+		p.used = 0
+		for _, day := range days {
+			p.used += day.Units
+		}
+	}
+	return p.used
+}
+
+// resetMonth starts the provider's local counts over when the month changed; the caller holds the lock
+func (t *Tracker) resetMonth(p *provider, now time.Time) {
+	month := now.Format("2006-01")
+	if p.month == month {
+		return
+	}
+	p.month = month
+	p.used = 0
+	p.usedAt = time.Time{}
+	p.days = make(map[string]int64)
+	p.next = time.Time{}
+}
+
+// monthDays reads the provider's daily usage of the month up to today from Redis
+func (t *Tracker) monthDays(ctx context.Context, name string, now time.Time) ([]DailyUsage, error) {
+	var keys, labels []string
+	for day := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC); !day.After(now); day = day.AddDate(0, 0, 1) {
+		label := day.Format("2006-01-02")
+		keys = append(keys, keyPrefix+name+":"+label)
+		labels = append(labels, label)
+	}
+
+	values, err := t.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	var days []DailyUsage
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue // no usage that day
+		}
+		units, _ := strconv.ParseInt(raw, 10, 64)
+		if units > 0 {
+			days = append(days, DailyUsage{Day: labels[i], Units: units})
+		}
+	}
+	return days, nil
+}
+
+// Snapshot returns the usage of every metered provider in the current month, by name
+func (t *Tracker) Snapshot(ctx context.Context) ([]ProviderUsage, error) {
+	now := time.Now().UTC()
+	today := now.Format("2006-01-02")
+
+	t.mu.Lock()
+	providers := make([]*provider, 0, len(t.providers))
+	for _, p := range t.providers {
+		providers = append(providers, p)
+	}
+	client := t.client
+	t.mu.Unlock()
+
+	snapshot := make([]ProviderUsage, 0, len(providers))
+	for _, p := range providers {
+		var days []DailyUsage
+		if client != nil {
+			var err error
+			if days, err = t.monthDays(ctx, p.name, now); err != nil {
+				return nil, err
+			}
+		}
+
+		t.mu.Lock()
+		t.resetMonth(p, now)
+		if client == nil {
+			for day, units := range p.days {
+				days = append(days, DailyUsage{Day: day, Units: units})
+			}
+		}
+		usage := ProviderUsage{
+			Provider:  p.name,
+			Unit:      p.unit,
+			Quota:     p.monthly,
+			Throttled: p.next.After(now),
+		}
+		t.mu.Unlock()
+
+		sort.Slice(days, func(i, j int) bool {
+			return days[i].Day < days[j].Day
+		})
+		for _, day := range days {
+			usage.Month += day.Units
+			if day.Day == today {
+				usage.Today = day.Units
+			}
+		}
+		if usage.Quota > 0 {
+			usage.QuotaUsed = float64(usage.Month) / float64(usage.Quota) * 100
+		}
+		usage.Daily = days
+		snapshot = append(snapshot, usage)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].Provider < snapshot[j].Provider
+	})
+	return snapshot, nil
+}
+
+// Transport returns a transport pacing requests to the provider through the tracker and counting the
+// provider's units per request; a nil base uses http.DefaultTransport
+func (t *Tracker) Transport(name string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{provider: name, base: base, tracker: t}
+}
+
+type transport struct {
+	provider string
+	base     http.RoundTripper
+	tracker  *Tracker
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.tracker.Wait(req.Context(), t.provider); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.tracker.Add(req.Context(), t.provider, t.tracker.unitsPerRequest(t.provider))
+	}
+	return resp, err
+}
+
+func (t *Tracker) unitsPerRequest(name string) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if p, ok := t.providers[name]; ok {
+		return p.unitsPerRequest
+	}
+	return 0
+}
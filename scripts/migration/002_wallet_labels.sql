@@ -0,0 +1,14 @@
+-- Create wallet_labels table
+CREATE TABLE wallet_labels (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL,
+    label VARCHAR(30) NOT NULL,
+    entity_name VARCHAR(100),
+    source VARCHAR(100),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_wallet_labels_wallet_label UNIQUE (wallet_address, label)
+);
+
+CREATE TRIGGER update_wallet_labels_updated_at BEFORE UPDATE ON wallet_labels FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
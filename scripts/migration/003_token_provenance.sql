@@ -0,0 +1,18 @@
+-- Create token_provenances table
+CREATE TABLE token_provenances (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mint_address VARCHAR(64) UNIQUE NOT NULL,
+    deployer_address VARCHAR(64),
+    symbol VARCHAR(50),
+    name VARCHAR(255),
+    creation_signature VARCHAR(128),
+    creation_slot BIGINT,
+    creation_time TIMESTAMP WITH TIME ZONE,
+    deployer_synced_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_provenances_deployer_address ON token_provenances(deployer_address);
+
+CREATE TRIGGER update_token_provenances_updated_at BEFORE UPDATE ON token_provenances FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,17 @@
+-- Create portfolio_snapshots table
+CREATE TABLE portfolio_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL,
+    snapshot_date DATE NOT NULL,
+    sol_balance DECIMAL(20,9),
+    sol_value_usd DECIMAL(20,4),
+    token_value_usd DECIMAL(20,4),
+    total_value_usd DECIMAL(20,4),
+    token_count INTEGER,
+    unpriced_count INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_portfolio_snapshots_wallet_date UNIQUE (wallet_address, snapshot_date)
+);
+
+CREATE TRIGGER update_portfolio_snapshots_updated_at BEFORE UPDATE ON portfolio_snapshots FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
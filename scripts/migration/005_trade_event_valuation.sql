@@ -0,0 +1,8 @@
+-- Add server-side valuation columns to trade_events
+ALTER TABLE trade_events
+    ADD COLUMN server_price DECIMAL(20,10),
+    ADD COLUMN server_value_usd DECIMAL(20,4),
+    ADD COLUMN value_deviation DECIMAL(10,4),
+    ADD COLUMN value_flagged BOOLEAN DEFAULT FALSE;
+
+CREATE INDEX idx_trade_events_value_flagged ON trade_events(value_flagged) WHERE value_flagged;
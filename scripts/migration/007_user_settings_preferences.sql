@@ -0,0 +1,9 @@
+-- Add notification preferences, timezone, hidden tokens and alert defaults to user_settings
+ALTER TABLE user_settings
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN notify_member_activity BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN notify_shared_info BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN notify_trade_events BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN hidden_tokens JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN alert_min_value_usd DECIMAL(20,4) NOT NULL DEFAULT 0,
+    ADD COLUMN alert_price_change_percent DECIMAL(10,4) NOT NULL DEFAULT 10;
@@ -0,0 +1,21 @@
+-- Create room_digests table
+CREATE TABLE room_digests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL REFERENCES trade_rooms(id) ON DELETE CASCADE,
+    digest_date DATE NOT NULL,
+    token_address VARCHAR(64),
+    member_count INTEGER DEFAULT 0,
+    member_count_change INTEGER DEFAULT 0,
+    trade_count INTEGER DEFAULT 0,
+    trade_volume_usd DECIMAL(20,4) DEFAULT 0,
+    top_trades JSONB,
+    top_shares JSONB,
+    market_summary TEXT,
+    shared_info_id UUID REFERENCES shared_infos(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_room_digests_room_date ON room_digests(room_id, digest_date);
+
+CREATE TRIGGER update_room_digests_updated_at BEFORE UPDATE ON room_digests FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,22 @@
+-- Create data_exports table
+CREATE TABLE data_exports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(30) NOT NULL,
+    subject_id VARCHAR(64) NOT NULL,
+    requested_by VARCHAR(64) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    file_path VARCHAR(512),
+    file_size BIGINT DEFAULT 0,
+    row_count BIGINT DEFAULT 0,
+    error TEXT,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_data_exports_subject_id ON data_exports(subject_id);
+CREATE INDEX idx_data_exports_expires_at ON data_exports(expires_at);
+
+CREATE TRIGGER update_data_exports_updated_at BEFORE UPDATE ON data_exports FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
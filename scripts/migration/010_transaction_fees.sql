@@ -0,0 +1,5 @@
+-- Record network fees on smart money transactions
+ALTER TABLE smart_money_transactions
+    ADD COLUMN fee_lamports BIGINT DEFAULT 0;
+
+CREATE INDEX idx_smart_money_transactions_wallet_block_time ON smart_money_transactions(wallet_address, block_time);
@@ -0,0 +1,15 @@
+-- Create token_candles table
+CREATE TABLE token_candles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_id UUID NOT NULL REFERENCES tokens(id) ON DELETE CASCADE,
+    resolution VARCHAR(10) NOT NULL,
+    open_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    open DECIMAL(30,18),
+    high DECIMAL(30,18),
+    low DECIMAL(30,18),
+    close DECIMAL(30,18),
+    volume DECIMAL(30,4),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_token_candles_token_res_time ON token_candles(token_id, resolution, open_time);
@@ -0,0 +1,2 @@
+-- Index typed shared info payloads for structured queries
+CREATE INDEX idx_shared_infos_type_token ON shared_infos(type, (metadata->>'token'));
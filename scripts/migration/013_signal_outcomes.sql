@@ -0,0 +1,29 @@
+-- Create signal_outcomes table tracking shared signal performance
+CREATE TABLE signal_outcomes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shared_info_id UUID NOT NULL UNIQUE REFERENCES shared_infos(id) ON DELETE CASCADE,
+    room_id UUID NOT NULL,
+    sharer_address VARCHAR(64) NOT NULL,
+    token_address VARCHAR(64) NOT NULL,
+    side VARCHAR(10) NOT NULL,
+    entry DECIMAL(30,12),
+    target DECIMAL(30,12),
+    stop DECIMAL(30,12),
+    share_price DECIMAL(30,12),
+    shared_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    price_1h DECIMAL(30,12),
+    price_24h DECIMAL(30,12),
+    price_7d DECIMAL(30,12),
+    checkpoints INTEGER DEFAULT 0,
+    next_check_at TIMESTAMP WITH TIME ZONE,
+    result VARCHAR(20) NOT NULL DEFAULT 'pending',
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_signal_outcomes_sharer_address ON signal_outcomes(sharer_address);
+CREATE INDEX idx_signal_outcomes_next_check_at ON signal_outcomes(next_check_at);
+CREATE INDEX idx_signal_outcomes_result ON signal_outcomes(result);
+
+CREATE TRIGGER update_signal_outcomes_updated_at BEFORE UPDATE ON signal_outcomes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
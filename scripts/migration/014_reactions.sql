@@ -0,0 +1,13 @@
+-- Create reactions table; shared_infos.like_count now counts reactions
+CREATE TABLE reactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shared_info_id UUID NOT NULL REFERENCES shared_infos(id) ON DELETE CASCADE,
+    wallet_address VARCHAR(64) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_reactions_info_wallet_type ON reactions(shared_info_id, wallet_address, type);
+
+-- Anonymous likes cannot be attributed to a wallet
+UPDATE shared_infos SET like_count = 0;
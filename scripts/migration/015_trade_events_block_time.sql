@@ -0,0 +1,2 @@
+-- Index trade events by block time for token transaction stats rollups
+CREATE INDEX idx_trade_events_block_time ON trade_events(block_time);
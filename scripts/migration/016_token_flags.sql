@@ -0,0 +1,30 @@
+-- Create token_flags table holding the current scam/honeypot flag of each mint
+CREATE TABLE token_flags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mint_address VARCHAR(64) NOT NULL UNIQUE,
+    type VARCHAR(20) NOT NULL,
+    reason TEXT,
+    source VARCHAR(20) NOT NULL,
+    flagged_by VARCHAR(100),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_flags_active ON token_flags(active);
+
+CREATE TRIGGER update_token_flags_updated_at BEFORE UPDATE ON token_flags FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create token_flag_events table keeping the reason history of every flag change
+CREATE TABLE token_flag_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mint_address VARCHAR(64) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    reason TEXT,
+    source VARCHAR(20) NOT NULL,
+    actor VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_flag_events_mint_address ON token_flag_events(mint_address);
@@ -0,0 +1,23 @@
+-- Create liquidity_snapshots table sampling pool liquidity of room-bound tokens
+CREATE TABLE liquidity_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mint_address VARCHAR(64) NOT NULL,
+    liquidity_usd DECIMAL(20,4),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_liquidity_snapshots_mint_created ON liquidity_snapshots(mint_address, created_at);
+
+-- Create liquidity_alerts table recording liquidity pulls announced to rooms
+CREATE TABLE liquidity_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mint_address VARCHAR(64) NOT NULL,
+    peak_liquidity_usd DECIMAL(20,4),
+    peak_at TIMESTAMP WITH TIME ZONE,
+    liquidity_usd DECIMAL(20,4),
+    drop_percent DECIMAL(10,4),
+    window_minutes INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_liquidity_alerts_mint_address ON liquidity_alerts(mint_address);
@@ -0,0 +1,45 @@
+-- Create wallet_fundings table recording each scanned wallet's funder
+CREATE TABLE wallet_fundings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL UNIQUE,
+    funder_address VARCHAR(64),
+    funding_signature VARCHAR(128),
+    funded_at TIMESTAMP WITH TIME ZONE,
+    scanned_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_wallet_fundings_funder_address ON wallet_fundings(funder_address);
+
+CREATE TRIGGER update_wallet_fundings_updated_at BEFORE UPDATE ON wallet_fundings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create wallet_links table pairing wallets likely controlled by the same entity
+CREATE TABLE wallet_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL,
+    related_address VARCHAR(64) NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    confidence DECIMAL(5,4),
+    evidence TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_wallet_links_pair_reason UNIQUE (wallet_address, related_address, reason)
+);
+
+CREATE INDEX idx_wallet_links_related_address ON wallet_links(related_address);
+
+CREATE TRIGGER update_wallet_links_updated_at BEFORE UPDATE ON wallet_links FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create wallet_cluster_members table assigning linked wallets to clusters
+CREATE TABLE wallet_cluster_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cluster_id UUID NOT NULL,
+    wallet_address VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_wallet_cluster_members_cluster_id ON wallet_cluster_members(cluster_id);
+
+CREATE TRIGGER update_wallet_cluster_members_updated_at BEFORE UPDATE ON wallet_cluster_members FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
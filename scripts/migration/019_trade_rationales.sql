@@ -0,0 +1,22 @@
+-- Add the per-room AI rationale toggle and the rationale of recorded trade events
+ALTER TABLE trade_rooms
+    ADD COLUMN ai_rationale BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE trade_events
+    ADD COLUMN rationale TEXT;
+
+-- Create trade_rationales table recording every generated rationale and its token cost
+CREATE TABLE trade_rationales (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL REFERENCES trade_rooms(id) ON DELETE CASCADE,
+    wallet_address VARCHAR(64) NOT NULL,
+    token_address VARCHAR(64),
+    tx_signature VARCHAR(128),
+    source VARCHAR(20) NOT NULL,
+    rationale TEXT NOT NULL,
+    tokens_used INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_trade_rationales_room_created ON trade_rationales(room_id, created_at);
+CREATE INDEX idx_trade_rationales_tx_signature ON trade_rationales(tx_signature);
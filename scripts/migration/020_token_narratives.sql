@@ -0,0 +1,14 @@
+-- Create token_narrative_tags table assigning tokens to market narratives
+CREATE TABLE token_narrative_tags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_id UUID NOT NULL REFERENCES tokens(id) ON DELETE CASCADE,
+    narrative VARCHAR(20) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_token_narrative_tags_token_narrative UNIQUE (token_id, narrative)
+);
+
+CREATE INDEX idx_token_narrative_tags_narrative ON token_narrative_tags(narrative);
+
+CREATE TRIGGER update_token_narrative_tags_updated_at BEFORE UPDATE ON token_narrative_tags FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
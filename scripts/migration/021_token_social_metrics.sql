@@ -0,0 +1,18 @@
+-- Create token_social_metrics table holding hourly social mention counts per provider
+CREATE TABLE token_social_metrics (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mint_address VARCHAR(64) NOT NULL,
+    hour TIMESTAMP WITH TIME ZONE NOT NULL,
+    provider VARCHAR(30) NOT NULL,
+    mentions INTEGER NOT NULL DEFAULT 0,
+    positive_mentions INTEGER NOT NULL DEFAULT 0,
+    negative_mentions INTEGER NOT NULL DEFAULT 0,
+    sentiment_score DECIMAL(5,4),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_token_social_metrics_mint_hour_provider UNIQUE (mint_address, hour, provider)
+);
+
+CREATE INDEX idx_token_social_metrics_hour ON token_social_metrics(hour);
+
+CREATE TRIGGER update_token_social_metrics_updated_at BEFORE UPDATE ON token_social_metrics FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+-- Create token_recommendation_records table keeping issued recommendations for backtesting
+CREATE TABLE token_recommendation_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_id UUID NOT NULL REFERENCES tokens(id) ON DELETE CASCADE,
+    action VARCHAR(10) NOT NULL,
+    overall_score DECIMAL(10,4),
+    risk_score DECIMAL(10,4),
+    confidence DECIMAL(5,4),
+    price_usd DECIMAL(30,18),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_recommendation_records_token_time ON token_recommendation_records(token_id, created_at);
@@ -0,0 +1,22 @@
+-- Create limit_watches table holding per-wallet price targets evaluated against streamed prices
+CREATE TABLE limit_watches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL,
+    mint_address VARCHAR(64) NOT NULL,
+    target_price_usd DECIMAL(30,18) NOT NULL,
+    direction VARCHAR(10) NOT NULL,
+    reference_price_usd DECIMAL(30,18),
+    swap_side VARCHAR(10),
+    note VARCHAR(200),
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    triggered_price_usd DECIMAL(30,18),
+    triggered_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_limit_watches_wallet_status ON limit_watches(wallet_address, status);
+CREATE INDEX idx_limit_watches_mint_status ON limit_watches(mint_address, status);
+
+CREATE TRIGGER update_limit_watches_updated_at BEFORE UPDATE ON limit_watches FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+-- Add the per-room inactivity pruning policy
+ALTER TABLE trade_rooms
+    ADD COLUMN prune_inactive_days INTEGER NOT NULL DEFAULT 0;
+
+-- Track member activity counts and inactivity warnings
+ALTER TABLE room_members
+    ADD COLUMN message_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN share_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN trade_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN last_active_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN inactivity_warned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_room_members_room_role ON room_members(room_id, role);
@@ -0,0 +1,13 @@
+-- Create room_events table, the append-only lifecycle log clients replay after disconnects
+CREATE TABLE room_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sequence BIGSERIAL NOT NULL,
+    room_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    wallet_address VARCHAR(64),
+    payload JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_room_events_sequence UNIQUE (sequence)
+);
+
+CREATE INDEX idx_room_events_room_sequence ON room_events(room_id, sequence);
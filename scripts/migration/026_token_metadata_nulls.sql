@@ -0,0 +1,7 @@
+-- Optional token metadata is stored as NULL when unknown; clear the empty strings written before
+UPDATE tokens SET
+    logo_uri = NULLIF(logo_uri, ''),
+    description = NULLIF(description, ''),
+    website = NULLIF(website, ''),
+    twitter = NULLIF(twitter, ''),
+    telegram = NULLIF(telegram, '');
@@ -0,0 +1,2 @@
+-- Serve latest-market-data lookups used when sorting token listings
+CREATE INDEX idx_token_market_data_token_created ON token_market_data(token_id, created_at DESC);
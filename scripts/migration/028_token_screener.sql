@@ -0,0 +1,19 @@
+-- Record holder counts with market data so the screener can filter on them
+ALTER TABLE token_market_data
+    ADD COLUMN holder_count INTEGER NOT NULL DEFAULT 0;
+
+-- Create screener_presets table holding per-wallet saved screeners
+CREATE TABLE screener_presets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    filters VARCHAR(500) NOT NULL,
+    sort VARCHAR(30),
+    sort_order VARCHAR(4),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_screener_presets_wallet_name ON screener_presets(wallet_address, name);
+
+CREATE TRIGGER update_screener_presets_updated_at BEFORE UPDATE ON screener_presets FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
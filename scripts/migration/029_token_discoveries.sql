@@ -0,0 +1,17 @@
+-- Create token_discoveries table recording new listings from the latest-tokens feed with their risk pre-screen
+CREATE TABLE token_discoveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_id UUID NOT NULL REFERENCES tokens(id) ON DELETE CASCADE,
+    mint_address VARCHAR(64) UNIQUE NOT NULL,
+    price_usd DECIMAL(30,18),
+    market_cap_usd DECIMAL(20,4),
+    liquidity_usd DECIMAL(20,4),
+    holder_count INTEGER,
+    listed_at TIMESTAMP WITH TIME ZONE,
+    risk_level VARCHAR(10) NOT NULL,
+    risk_reasons JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_discoveries_risk_level ON token_discoveries(risk_level);
+CREATE INDEX idx_token_discoveries_created_at ON token_discoveries(created_at);
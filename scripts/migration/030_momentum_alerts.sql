@@ -0,0 +1,24 @@
+-- Record the price of each trending ranking so rank moves can be compared with price moves
+ALTER TABLE token_trending_ranking
+    ADD COLUMN price_usd DECIMAL(30,18);
+
+-- Let wallets opt in to momentum alerts of tokens outside their rooms
+ALTER TABLE user_settings
+    ADD COLUMN notify_momentum_alerts BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create momentum_alerts table recording tokens climbing the trending ranking while their price is flat
+CREATE TABLE momentum_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_id UUID NOT NULL REFERENCES tokens(id) ON DELETE CASCADE,
+    mint_address VARCHAR(64) NOT NULL,
+    symbol VARCHAR(50),
+    timeframe VARCHAR(10) NOT NULL,
+    previous_rank INTEGER,
+    rank INTEGER,
+    price_usd DECIMAL(30,18),
+    price_change_percent DECIMAL(10,4),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_momentum_alerts_mint_address ON momentum_alerts(mint_address);
+CREATE INDEX idx_momentum_alerts_created_at ON momentum_alerts(created_at);
@@ -0,0 +1,12 @@
+-- Create token_holder_snapshots table keeping every top holder sync for holder-change tracking
+CREATE TABLE token_holder_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_id UUID NOT NULL REFERENCES tokens(id) ON DELETE CASCADE,
+    taken_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    holder_address VARCHAR(64) NOT NULL,
+    balance DECIMAL(20,4),
+    percentage DECIMAL(6,4),
+    rank INTEGER NOT NULL
+);
+
+CREATE INDEX idx_token_holder_snapshots_token_taken ON token_holder_snapshots(token_id, taken_at);
@@ -0,0 +1,14 @@
+-- Create room_feed_messages table keeping WebSocket room broadcasts for the room feed endpoint
+CREATE TABLE room_feed_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sequence BIGSERIAL NOT NULL,
+    room_id VARCHAR(20) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    from_wallet VARCHAR(64),
+    data JSONB,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT idx_room_feed_messages_sequence UNIQUE (sequence)
+);
+
+CREATE INDEX idx_room_feed_messages_room_sequence ON room_feed_messages(room_id, sequence);
+CREATE INDEX idx_room_feed_messages_sent_at ON room_feed_messages(sent_at);
@@ -0,0 +1,12 @@
+-- Track whether stored transactions reached finalized commitment; existing rows were read at confirmed and are kept as final
+ALTER TABLE smart_money_transactions
+    ADD COLUMN finalized BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX idx_smart_money_transactions_unfinalized ON smart_money_transactions(created_at) WHERE finalized = FALSE;
+
+-- Mark room trade events provisional until their signature finalizes, and reverted if it never does
+ALTER TABLE trade_events
+    ADD COLUMN provisional BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN reverted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_trade_events_provisional ON trade_events(created_at) WHERE provisional = TRUE;
@@ -0,0 +1,4 @@
+-- Record priority fees and realized slippage of smart money swaps; NULL where unknown, e.g. rows stored before
+ALTER TABLE smart_money_transactions
+    ADD COLUMN priority_fee_lamports BIGINT,
+    ADD COLUMN slippage_percent DECIMAL(10,4);
@@ -0,0 +1,14 @@
+-- Create dex_daily_stats table rolling up smart money trades per UTC day and DEX platform
+CREATE TABLE dex_daily_stats (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    day DATE NOT NULL,
+    platform VARCHAR(50) NOT NULL,
+    trades INTEGER NOT NULL DEFAULT 0,
+    buy_count INTEGER NOT NULL DEFAULT 0,
+    sell_count INTEGER NOT NULL DEFAULT 0,
+    volume_usd DECIMAL(20,4) NOT NULL DEFAULT 0,
+    wallets INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_dex_daily_stats_day_platform ON dex_daily_stats(day, platform);
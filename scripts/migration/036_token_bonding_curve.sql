@@ -0,0 +1,8 @@
+-- Track the Pump.fun launch stage of tokens: bonding curve progress and graduation to an AMM
+ALTER TABLE tokens
+    ADD COLUMN bonding_curve_stage VARCHAR(20),
+    ADD COLUMN bonding_curve_progress DECIMAL(7,4) DEFAULT 0,
+    ADD COLUMN graduated_to VARCHAR(50),
+    ADD COLUMN graduated_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_tokens_bonding_curve_stage ON tokens(bonding_curve_stage) WHERE bonding_curve_stage IS NOT NULL;
@@ -0,0 +1,18 @@
+-- Create token_unlock_schedules table recording vesting unlocks of tokens
+CREATE TABLE token_unlock_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_id UUID NOT NULL REFERENCES tokens(id) ON DELETE CASCADE,
+    mint_address VARCHAR(64) NOT NULL,
+    unlock_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    amount DECIMAL(30,6),
+    percent_of_supply DECIMAL(10,4),
+    category VARCHAR(20) NOT NULL DEFAULT 'other',
+    description TEXT,
+    source VARCHAR(255),
+    warned_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_unlock_schedules_token_id ON token_unlock_schedules(token_id);
+CREATE INDEX idx_token_unlock_schedules_unlock_at ON token_unlock_schedules(unlock_at);
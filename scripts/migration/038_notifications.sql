@@ -0,0 +1,22 @@
+-- Create notifications table backing the per-wallet notification center
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT,
+    data JSONB NOT NULL DEFAULT '{}',
+    channels JSONB NOT NULL DEFAULT '[]',
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_wallet_created ON notifications(wallet_address, created_at);
+CREATE INDEX idx_notifications_created_at ON notifications(created_at);
+CREATE INDEX idx_notifications_read_at ON notifications(read_at);
+
+-- Per-wallet routing of notification types to delivery channels
+ALTER TABLE user_settings ADD COLUMN notification_routes JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE user_settings ADD COLUMN telegram_chat_id VARCHAR(64);
+ALTER TABLE user_settings ADD COLUMN webhook_url VARCHAR(500);
+ALTER TABLE user_settings ADD COLUMN notification_email VARCHAR(255);
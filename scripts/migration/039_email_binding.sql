@@ -0,0 +1,13 @@
+-- Create email_verifications table holding pending wallet-to-email bindings
+CREATE TABLE email_verifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Email categories a wallet opted out of
+ALTER TABLE user_settings ADD COLUMN email_opt_outs JSONB NOT NULL DEFAULT '[]';
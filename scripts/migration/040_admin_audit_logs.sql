@@ -0,0 +1,17 @@
+-- Create admin_audit_logs table recording every admin action
+CREATE TABLE admin_audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor VARCHAR(64) NOT NULL,
+    actor_type VARCHAR(20) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    route VARCHAR(200) NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    query VARCHAR(500),
+    status INTEGER NOT NULL,
+    client_ip VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_admin_audit_logs_actor ON admin_audit_logs(actor);
+CREATE INDEX idx_admin_audit_logs_created_at ON admin_audit_logs(created_at);
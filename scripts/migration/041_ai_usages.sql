@@ -0,0 +1,15 @@
+-- Create ai_usages table recording OpenAI token usage per wallet for quotas
+CREATE TABLE ai_usages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL,
+    session_id VARCHAR(64),
+    feature VARCHAR(20) NOT NULL,
+    model VARCHAR(64),
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    total_tokens INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_ai_usages_wallet_created ON ai_usages(wallet_address, created_at);
+CREATE INDEX idx_ai_usages_session_id ON ai_usages(session_id);
@@ -0,0 +1,2 @@
+-- Price AI usage by the model it ran on
+ALTER TABLE ai_usages ADD COLUMN cost_usd DECIMAL(20,8) NOT NULL DEFAULT 0;
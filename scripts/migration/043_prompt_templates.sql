@@ -0,0 +1,15 @@
+-- Create prompt_templates table holding versioned AI system prompts, globally or per room
+CREATE TABLE prompt_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key VARCHAR(50) NOT NULL,
+    room_id VARCHAR(64) NOT NULL DEFAULT '',
+    version INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    note VARCHAR(200),
+    created_by VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_prompt_templates_version ON prompt_templates(key, room_id, version);
+CREATE UNIQUE INDEX idx_prompt_templates_active ON prompt_templates(key, room_id) WHERE active;
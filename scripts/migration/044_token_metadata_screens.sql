@@ -0,0 +1,21 @@
+-- Create token_metadata_screens table holding the scam screen of each token's description, website and socials
+CREATE TABLE token_metadata_screens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mint_address VARCHAR(64) NOT NULL UNIQUE,
+    signals JSONB NOT NULL DEFAULT '[]',
+    suspicious BOOLEAN NOT NULL DEFAULT FALSE,
+    ai_checked BOOLEAN NOT NULL DEFAULT FALSE,
+    screened_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_token_metadata_screens_suspicious ON token_metadata_screens(suspicious);
+
+CREATE TRIGGER update_token_metadata_screens_updated_at BEFORE UPDATE ON token_metadata_screens FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Lookups of tokens reusing another token's description or links
+CREATE INDEX idx_tokens_description_hash ON tokens USING HASH (description);
+CREATE INDEX idx_tokens_website ON tokens(website);
+CREATE INDEX idx_tokens_twitter ON tokens(twitter);
+CREATE INDEX idx_tokens_telegram ON tokens(telegram);
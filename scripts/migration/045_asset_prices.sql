@@ -0,0 +1,17 @@
+-- Create asset_prices table holding the SOL, USDC and USDT price feed history
+CREATE TABLE asset_prices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mint_address VARCHAR(64) NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    price_usd DECIMAL(20,10) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_asset_prices_mint_fetched ON asset_prices(mint_address, fetched_at DESC);
+CREATE INDEX idx_asset_prices_fetched_at ON asset_prices(fetched_at);
+
+-- Native SOL a trade event settled in, valued server-side at the SOL price
+ALTER TABLE trade_events
+    ADD COLUMN sol_amount DECIMAL(20,9);
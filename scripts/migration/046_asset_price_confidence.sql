@@ -0,0 +1,3 @@
+-- Pyth confidence interval of recorded prices, used to judge how far DEX prices may drift from the reference
+ALTER TABLE asset_prices
+    ADD COLUMN confidence DECIMAL(20,10) NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+-- Create room_tokens table, the watch basket of tokens a room follows besides its own token
+CREATE TABLE room_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL REFERENCES trade_rooms(id) ON DELETE CASCADE,
+    mint_address VARCHAR(64) NOT NULL,
+    added_by VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_room_tokens_room_mint UNIQUE (room_id, mint_address)
+);
+
+CREATE INDEX idx_room_tokens_mint_address ON room_tokens(mint_address);
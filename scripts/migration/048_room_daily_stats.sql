@@ -0,0 +1,16 @@
+-- Create room_daily_stats table rolling up room events per room and UTC day for creator analytics
+CREATE TABLE room_daily_stats (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL REFERENCES trade_rooms(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    joins INTEGER NOT NULL DEFAULT 0,
+    leaves INTEGER NOT NULL DEFAULT 0,
+    messages INTEGER NOT NULL DEFAULT 0,
+    shares INTEGER NOT NULL DEFAULT 0,
+    trades INTEGER NOT NULL DEFAULT 0,
+    trade_volume_usd DECIMAL(20,4) NOT NULL DEFAULT 0,
+    active_members INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_room_daily_stats_room_day ON room_daily_stats(room_id, day);
@@ -0,0 +1,39 @@
+-- Create token_market_latest table holding a copy of each token's most recent market data row,
+-- with the column names AutoMigrate gives token_market_data
+CREATE TABLE token_market_latest (
+    token_id UUID PRIMARY KEY REFERENCES tokens(id) ON DELETE CASCADE,
+    market_data_id UUID NOT NULL,
+    price DECIMAL(20,10),
+    price_usd DECIMAL(20,10),
+    volume24h DECIMAL(20,4),
+    volume_change24h DECIMAL(10,4),
+    market_cap DECIMAL(20,4),
+    market_cap_rank BIGINT,
+    price_change1h DECIMAL(10,4),
+    price_change24h DECIMAL(10,4),
+    price_change7d DECIMAL(10,4),
+    circulating_supply DECIMAL(20,4),
+    total_supply DECIMAL(20,4),
+    max_supply DECIMAL(20,4),
+    ath DECIMAL(20,10),
+    atl DECIMAL(20,10),
+    holder_count BIGINT,
+    last_updated TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_token_market_latest_updated_at ON token_market_latest(updated_at);
+
+-- Backfill from the market data history
+INSERT INTO token_market_latest (
+    token_id, market_data_id, price, price_usd, volume24h, volume_change24h, market_cap, market_cap_rank,
+    price_change1h, price_change24h, price_change7d, circulating_supply, total_supply, max_supply,
+    ath, atl, holder_count, last_updated, created_at, updated_at
+)
+SELECT DISTINCT ON (token_id)
+    token_id, id, price, price_usd, volume24h, volume_change24h, market_cap, market_cap_rank,
+    price_change1h, price_change24h, price_change7d, circulating_supply, total_supply, max_supply,
+    ath, atl, holder_count, last_updated, created_at, updated_at
+FROM token_market_data
+ORDER BY token_id, created_at DESC;
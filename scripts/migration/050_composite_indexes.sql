@@ -0,0 +1,13 @@
+-- Composite indexes for the room feed, trade history and wallet activity queries; the models declare the same
+-- indexes so AutoMigrate creates them too
+CREATE INDEX IF NOT EXISTS idx_trade_events_room_created ON trade_events(room_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_smart_money_transactions_wallet_block_time ON smart_money_transactions(wallet_address, block_time);
+CREATE INDEX IF NOT EXISTS idx_shared_infos_room_sticky_created ON shared_infos(room_id, is_sticky, created_at);
+
+-- Name the room membership uniqueness like the model does; it replaces the unnamed table constraint
+CREATE UNIQUE INDEX IF NOT EXISTS idx_room_members_room_wallet ON room_members(room_id, wallet_address);
+ALTER TABLE room_members DROP CONSTRAINT IF EXISTS room_members_room_id_wallet_address_key;
+
+-- The single-column room indexes are prefixes of the composite ones
+DROP INDEX IF EXISTS idx_trade_events_room;
+DROP INDEX IF EXISTS idx_shared_infos_room;
@@ -0,0 +1,13 @@
+-- Create room_password_failures table auditing wrong passwords given to join rooms
+CREATE TABLE room_password_failures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL REFERENCES trade_rooms(id) ON DELETE CASCADE,
+    wallet_address VARCHAR(64) NOT NULL,
+    client_ip VARCHAR(64),
+    attempts INTEGER NOT NULL,
+    locked_out BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_room_password_failures_room_created ON room_password_failures(room_id, created_at);
+CREATE INDEX idx_room_password_failures_wallet_address ON room_password_failures(wallet_address);
@@ -0,0 +1,4 @@
+-- Add the pending ownership transfer of trade rooms
+ALTER TABLE trade_rooms
+    ADD COLUMN pending_owner_address VARCHAR(64),
+    ADD COLUMN transfer_expires_at TIMESTAMP WITH TIME ZONE;
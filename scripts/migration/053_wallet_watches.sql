@@ -0,0 +1,10 @@
+-- Create wallet_watches table backing personal wallet trade subscriptions outside of rooms
+CREATE TABLE wallet_watches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_address VARCHAR(64) NOT NULL,
+    watched_address VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_wallet_watches_wallet_watched ON wallet_watches(wallet_address, watched_address);
+CREATE INDEX idx_wallet_watches_watched_address ON wallet_watches(watched_address);
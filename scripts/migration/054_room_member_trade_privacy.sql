@@ -0,0 +1,2 @@
+-- Let members broadcast their detected trades with a size bucket instead of exact amounts
+ALTER TABLE room_members ADD COLUMN hide_trade_sizes BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+-- Let members show an alias in place of their address in a room
+ALTER TABLE room_members ADD COLUMN alias VARCHAR(24);
+
+CREATE UNIQUE INDEX idx_room_members_room_alias ON room_members(room_id, LOWER(alias)) WHERE alias IS NOT NULL;
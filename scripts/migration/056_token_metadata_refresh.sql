@@ -0,0 +1,2 @@
+-- Track when token metadata was last re-fetched, so stale logos and socials are refreshed during sync
+ALTER TABLE tokens ADD COLUMN metadata_refreshed_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,10 @@
+-- Store top holder balances in whole tokens and their USD value next to the raw balance
+ALTER TABLE token_top_holders
+    ADD COLUMN ui_balance DECIMAL(30,9) NOT NULL DEFAULT 0,
+    ADD COLUMN value_usd DECIMAL(20,2) NOT NULL DEFAULT 0;
+
+-- Existing rows get their whole-token balance now and their value on the token's next sync
+UPDATE token_top_holders h
+SET ui_balance = h.balance / POWER(10, t.decimals)
+FROM tokens t
+WHERE t.id = h.token_id;
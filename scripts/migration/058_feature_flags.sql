@@ -0,0 +1,12 @@
+-- Create feature_flags table gating risky features at runtime, globally or per room
+CREATE TABLE feature_flags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key VARCHAR(50) NOT NULL,
+    room_id VARCHAR(64) NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL,
+    updated_by VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_feature_flags_key_room ON feature_flags(key, room_id);
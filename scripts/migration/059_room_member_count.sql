@@ -0,0 +1,7 @@
+-- Rooms start empty; the creator's seat is counted when their membership is added
+ALTER TABLE trade_rooms
+    ALTER COLUMN current_members SET DEFAULT 0;
+
+-- Rooms created so far counted their creator twice
+UPDATE trade_rooms r
+SET current_members = (SELECT COUNT(*) FROM room_members m WHERE m.room_id = r.id);
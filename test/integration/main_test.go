@@ -0,0 +1,209 @@
+//go:build integration
+
+// Package integration runs the service against Postgres and Redis in Docker containers. Run it with
+// go test ./... -tags=integration; Docker must be reachable.
+package integration
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/gorm"
+	"github.com/emiyaio/solana-wallet-service/internal/config"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+	"github.com/emiyaio/solana-wallet-service/internal/handlers"
+	"github.com/emiyaio/solana-wallet-service/internal/services"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+	"github.com/emiyaio/solana-wallet-service/pkg/database"
+	"github.com/emiyaio/solana-wallet-service/pkg/redis"
+	"github.com/emiyaio/solana-wallet-service/pkg/solana"
+)
+
+const (
+	postgresImage = "postgres:16-alpine"
+	redisImage    = "redis:7-alpine"
+	postgresPort  = "5432/tcp"
+	redisPort     = "6379/tcp"
+	startTimeout  = 2 * time.Minute
+)
+
+// env is the service under test, wired as in cmd/server against the containers started by TestMain
+var env struct {
+	db       *gorm.DB
+	repos    *repositories.Repositories
+	services *services.Services
+	server   *httptest.Server
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run starts the containers and the in-process server, and tears them down after the tests
+func run(m *testing.M) int {
+	ctx := context.Background()
+
+	pg, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        postgresImage,
+			ExposedPorts: []string{postgresPort},
+			Env: map[string]string{
+				"POSTGRES_DB":       "wallet",
+				"POSTGRES_USER":     "wallet",
+				"POSTGRES_PASSWORD": "wallet",
+			},
+			// The server restarts once after initdb, so it is only ready on the second announcement
+			WaitingFor: wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(startTimeout),
+		},
+		Started: true,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start postgres: %v\n", err)
+		return 1
+	}
+	defer pg.Terminate(ctx)
+
+	rc, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        redisImage,
+			ExposedPorts: []string{redisPort},
+			WaitingFor: wait.ForLog("Ready to accept connections").
+				WithStartupTimeout(startTimeout),
+		},
+		Started: true,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start redis: %v\n", err)
+		return 1
+	}
+	defer rc.Terminate(ctx)
+
+	cfg, err := containerConfig(ctx, pg, rc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read container addresses: %v\n", err)
+		return 1
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	gin.SetMode(gin.TestMode)
+
+	dbConn, err := database.NewPostgresConnection(cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to postgres: %v\n", err)
+		return 1
+	}
+	defer dbConn.Close()
+	if err := dbConn.AutoMigrate(models.All()...); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to migrate: %v\n", err)
+		return 1
+	}
+
+	redisClient, err := redis.NewRedisClient(cfg.Redis)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to redis: %v\n", err)
+		return 1
+	}
+	defer redisClient.Close()
+
+	env.db = dbConn.DB
+	env.repos = repositories.NewRepositories(dbConn.DB)
+	env.services = services.NewServices(env.repos, redisClient, cfg, logger)
+	defer env.services.SubscriptionManager.Stop()
+
+	router := handlers.NewRouter(env.services, redisClient, &cfg.Server, &cfg.WebSocket, &cfg.RateLimit, logger)
+	router.SetupRoutes()
+	env.server = httptest.NewServer(router.GetEngine())
+	defer env.server.Close()
+
+	return m.Run()
+}
+
+// containerConfig points the database and Redis settings at the containers; everything else keeps its defaults
+func containerConfig(ctx context.Context, pg, rc testcontainers.Container) (*config.Config, error) {
+	pgHost, err := pg.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pgPort, err := pg.MappedPort(ctx, postgresPort)
+	if err != nil {
+		return nil, err
+	}
+	redisHost, err := rc.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+	redisPort, err := rc.MappedPort(ctx, redisPort)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config.Config{}
+	cfg.Database = config.DatabaseConfig{
+		Host:         pgHost,
+		Port:         pgPort.Int(),
+		User:         "wallet",
+		Password:     "wallet",
+		DBName:       "wallet",
+		SSLMode:      "disable",
+		TimeZone:     "UTC",
+		MaxIdleConns: 5,
+		MaxOpenConns: 20,
+	}
+	cfg.Redis = config.RedisConfig{
+		Host:     redisHost,
+		Port:     redisPort.Int(),
+		PoolSize: 10,
+	}
+	return cfg, nil
+}
+
+// newWallet returns a fresh wallet address
+func newWallet(t *testing.T) string {
+	t.Helper()
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate wallet key: %v", err)
+	}
+	return solana.EncodeBase58(publicKey)
+}
+
+// createRoom opens a room for a new creator wallet and returns it with the creator's address
+func createRoom(t *testing.T, maxMembers int) (*models.TradeRoom, string) {
+	t.Helper()
+	creator := newWallet(t)
+	tradeRoom, err := env.services.Room.CreateRoom(context.Background(), &room.CreateRoomRequest{
+		CreatorAddress: creator,
+		MaxMembers:     maxMembers,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	return tradeRoom, creator
+}
+
+// storedRoom reads the room back from the database
+func storedRoom(t *testing.T, roomID string) *models.TradeRoom {
+	t.Helper()
+	tradeRoom, err := env.repos.Room.GetByRoomID(context.Background(), roomID)
+	if err != nil {
+		t.Fatalf("get room %s: %v", roomID, err)
+	}
+	if tradeRoom == nil {
+		t.Fatalf("room %s not found", roomID)
+	}
+	return tradeRoom
+}
@@ -0,0 +1,216 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/domain/repositories"
+)
+
+func TestRoomRepositoryMemberCount(t *testing.T) {
+	ctx := context.Background()
+	tradeRoom, _ := createRoom(t, 3)
+
+	// The creator holds the only seat
+	if got := storedRoom(t, tradeRoom.RoomID).CurrentMembers; got != 1 {
+		t.Fatalf("current_members after create = %d, want 1", got)
+	}
+
+	member := &models.RoomMember{RoomID: tradeRoom.ID, WalletAddress: newWallet(t), Role: models.MemberRoleMember}
+	added, err := env.repos.Room.AddMember(ctx, member)
+	if err != nil || !added {
+		t.Fatalf("AddMember = %v, %v; want added", added, err)
+	}
+
+	// Adding a member twice keeps one row and one seat
+	again := &models.RoomMember{RoomID: tradeRoom.ID, WalletAddress: member.WalletAddress, Role: models.MemberRoleMember}
+	added, err = env.repos.Room.AddMember(ctx, again)
+	if err != nil || added {
+		t.Fatalf("AddMember of an existing member = %v, %v; want not added", added, err)
+	}
+	if got := storedRoom(t, tradeRoom.RoomID).CurrentMembers; got != 2 {
+		t.Fatalf("current_members after join = %d, want 2", got)
+	}
+
+	if err := env.repos.Room.RemoveMember(ctx, tradeRoom.ID, member.WalletAddress); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	if got := storedRoom(t, tradeRoom.RoomID).CurrentMembers; got != 1 {
+		t.Fatalf("current_members after leave = %d, want 1", got)
+	}
+
+	// Removing a wallet that already left frees no seat
+	if err := env.repos.Room.RemoveMember(ctx, tradeRoom.ID, member.WalletAddress); err != nil {
+		t.Fatalf("RemoveMember of a former member: %v", err)
+	}
+	if got := storedRoom(t, tradeRoom.RoomID).CurrentMembers; got != 1 {
+		t.Fatalf("current_members after repeated leave = %d, want 1", got)
+	}
+}
+
+func TestRoomRepositoryAddMembersCountsInserted(t *testing.T) {
+	ctx := context.Background()
+	tradeRoom, creator := createRoom(t, 4)
+
+	// The creator and a repeated wallet are skipped and take no seat
+	joined, fresh := newWallet(t), newWallet(t)
+	if added, err := env.repos.Room.AddMember(ctx, &models.RoomMember{RoomID: tradeRoom.ID, WalletAddress: joined, Role: models.MemberRoleMember}); err != nil || !added {
+		t.Fatalf("AddMember = %v, %v; want added", added, err)
+	}
+	added, fits, err := env.repos.Room.AddMembers(ctx, tradeRoom.ID, []string{creator, joined, fresh})
+	if err != nil || !fits {
+		t.Fatalf("AddMembers = %v, %v; want fits", fits, err)
+	}
+	if len(added) != 1 || added[0].WalletAddress != fresh {
+		t.Fatalf("AddMembers added %d members, want only %s", len(added), fresh)
+	}
+	if got := storedRoom(t, tradeRoom.RoomID).CurrentMembers; got != 3 {
+		t.Fatalf("current_members = %d, want 3", got)
+	}
+
+	// A batch that does not fit adds no one
+	added, fits, err = env.repos.Room.AddMembers(ctx, tradeRoom.ID, []string{newWallet(t), newWallet(t)})
+	if err != nil || fits || len(added) != 0 {
+		t.Fatalf("AddMembers past capacity = %d added, %v, %v; want none, not fitting", len(added), fits, err)
+	}
+	members, err := env.repos.Room.GetMembers(ctx, tradeRoom.ID)
+	if err != nil {
+		t.Fatalf("GetMembers: %v", err)
+	}
+	if len(members) != 3 || storedRoom(t, tradeRoom.RoomID).CurrentMembers != 3 {
+		t.Fatalf("room has %d member rows after a batch that did not fit, want 3", len(members))
+	}
+}
+
+func TestRoomRepositoryUpdateKeepsCapacity(t *testing.T) {
+	ctx := context.Background()
+	tradeRoom, _ := createRoom(t, 3)
+
+	// A copy read before a join must not write its member count back
+	stale := storedRoom(t, tradeRoom.RoomID)
+	member := &models.RoomMember{RoomID: tradeRoom.ID, WalletAddress: newWallet(t), Role: models.MemberRoleMember}
+	if added, err := env.repos.Room.AddMember(ctx, member); err != nil || !added {
+		t.Fatalf("AddMember = %v, %v; want added", added, err)
+	}
+	stale.AIRationale = true
+	if err := env.repos.Room.Update(ctx, stale); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	stored := storedRoom(t, tradeRoom.RoomID)
+	if stored.CurrentMembers != 2 || !stored.AIRationale {
+		t.Fatalf("room after stale update has %d members and ai_rationale %t, want 2 and true", stored.CurrentMembers, stored.AIRationale)
+	}
+
+	// The room cannot shrink below its members
+	if resized, err := env.repos.Room.UpdateMaxMembers(ctx, tradeRoom.ID, 1); err != nil || resized {
+		t.Fatalf("UpdateMaxMembers below the members = %v, %v; want not resized", resized, err)
+	}
+	if resized, err := env.repos.Room.UpdateMaxMembers(ctx, tradeRoom.ID, 2); err != nil || !resized {
+		t.Fatalf("UpdateMaxMembers to the members = %v, %v; want resized", resized, err)
+	}
+	if got := storedRoom(t, tradeRoom.RoomID).MaxMembers; got != 2 {
+		t.Fatalf("max_members = %d, want 2", got)
+	}
+}
+
+func TestTokenRepositoryFindByLatestMarketData(t *testing.T) {
+	ctx := context.Background()
+	name := "Integration " + newWallet(t)[:8]
+
+	// Each token's newest market data row decides its position, not the older ones
+	volumes := map[string][]float64{
+		"LOW":  {900, 100},
+		"MID":  {200},
+		"HIGH": {50, 300},
+	}
+	for symbol, history := range volumes {
+		tok := &models.Token{MintAddress: newWallet(t), Symbol: symbol, Name: name}
+		if err := env.repos.Token.Create(ctx, tok); err != nil {
+			t.Fatalf("create token %s: %v", symbol, err)
+		}
+		for _, volume := range history {
+			data := &models.TokenMarketData{TokenID: tok.ID, Volume24h: volume, PriceChange24h: volume / 10}
+			if err := env.repos.Token.CreateMarketData(ctx, data); err != nil {
+				t.Fatalf("create market data of %s: %v", symbol, err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter repositories.TokenFilter
+		sort   repositories.TokenSort
+		want   []string
+	}{
+		{
+			name:   "by volume",
+			filter: repositories.TokenFilter{Query: name},
+			sort:   repositories.TokenSort{Field: repositories.TokenSortVolume24h},
+			want:   []string{"HIGH", "MID", "LOW"},
+		},
+		{
+			name:   "by price change ascending",
+			filter: repositories.TokenFilter{Query: name},
+			sort:   repositories.TokenSort{Field: repositories.TokenSortPriceChange24h, Ascending: true},
+			want:   []string{"LOW", "MID", "HIGH"},
+		},
+		{
+			name: "screened on volume",
+			filter: repositories.TokenFilter{Query: name, Conditions: []repositories.ScreenerCondition{
+				{Field: repositories.ScreenerVolume24h, Operator: ">=", Value: 200},
+				{Field: repositories.ScreenerPriceChange24h, Operator: "<", Value: 25},
+			}},
+			sort: repositories.TokenSort{Field: repositories.TokenSortVolume24h},
+			want: []string{"MID"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := env.repos.Token.Find(ctx, tt.filter, tt.sort, 10, 0)
+			if err != nil {
+				t.Fatalf("Find: %v", err)
+			}
+			var got []string
+			for _, tok := range tokens {
+				got = append(got, tok.Symbol)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Find = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Find = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWalletLabelRepositoryDuplicate(t *testing.T) {
+	ctx := context.Background()
+	wallet := newWallet(t)
+
+	label := &models.WalletLabel{WalletAddress: wallet, Label: models.WalletLabelExchange, EntityName: "Exchange"}
+	if err := env.repos.WalletLabel.Create(ctx, label); err != nil {
+		t.Fatalf("create label: %v", err)
+	}
+
+	duplicate := &models.WalletLabel{WalletAddress: wallet, Label: models.WalletLabelExchange, EntityName: "Other"}
+	if err := env.repos.WalletLabel.Create(ctx, duplicate); !errors.Is(err, repositories.ErrLabelExists) {
+		t.Fatalf("create duplicate label = %v, want ErrLabelExists", err)
+	}
+
+	// A different label of the same wallet is allowed
+	other := &models.WalletLabel{WalletAddress: wallet, Label: models.WalletLabelMarketMaker}
+	if err := env.repos.WalletLabel.Create(ctx, other); err != nil {
+		t.Fatalf("create second label: %v", err)
+	}
+	other.Label = models.WalletLabelExchange
+	if err := env.repos.WalletLabel.Update(ctx, other); !errors.Is(err, repositories.ErrLabelExists) {
+		t.Fatalf("update to a duplicate label = %v, want ErrLabelExists", err)
+	}
+}
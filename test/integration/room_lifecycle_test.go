@@ -0,0 +1,337 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emiyaio/solana-wallet-service/internal/domain/models"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+func TestRoomLifecycle(t *testing.T) {
+	ctx := context.Background()
+	roomService := env.services.Room
+
+	// Create
+	tradeRoom, creator := createRoom(t, 10)
+	if tradeRoom.Status != models.RoomStatusActive || tradeRoom.CurrentMembers != 1 {
+		t.Fatalf("created room = %s with %d members, want active with 1", tradeRoom.Status, tradeRoom.CurrentMembers)
+	}
+
+	// Join
+	member := newWallet(t)
+	if _, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, member, "", "127.0.0.1"); err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	if _, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, member, "", "127.0.0.1"); !errors.Is(err, room.ErrAlreadyMember) {
+		t.Fatalf("second join = %v, want ErrAlreadyMember", err)
+	}
+	members, err := roomService.GetRoomMembers(ctx, tradeRoom.RoomID)
+	if err != nil {
+		t.Fatalf("get members: %v", err)
+	}
+	if len(members) != 2 || storedRoom(t, tradeRoom.RoomID).CurrentMembers != 2 {
+		t.Fatalf("room has %d member rows and %d seats taken, want 2 and 2", len(members), storedRoom(t, tradeRoom.RoomID).CurrentMembers)
+	}
+
+	// Share
+	info, err := roomService.ShareInfo(ctx, &room.ShareInfoRequest{
+		RoomID:        tradeRoom.RoomID,
+		SharerAddress: member,
+		Type:          models.SharedInfoTypeAnalysis,
+		Title:         "Liquidity is thin",
+		Content:       "Most of the pool was added in the last hour",
+	})
+	if err != nil {
+		t.Fatalf("share: %v", err)
+	}
+	if _, err := roomService.ShareInfo(ctx, &room.ShareInfoRequest{
+		RoomID:        tradeRoom.RoomID,
+		SharerAddress: newWallet(t),
+		Type:          models.SharedInfoTypeAnalysis,
+		Title:         "Not a member",
+		Content:       "Outsiders cannot share",
+	}); !errors.Is(err, room.ErrNotMember) {
+		t.Fatalf("share by a non-member = %v, want ErrNotMember", err)
+	}
+	shares, err := roomService.GetSharedInfos(ctx, tradeRoom.RoomID, 10, 0)
+	if err != nil {
+		t.Fatalf("get shares: %v", err)
+	}
+	if len(shares) != 1 || shares[0].ID != info.ID {
+		t.Fatalf("room shares = %d, want the one shared", len(shares))
+	}
+
+	// Expire, once the recycle period has passed
+	if err := env.db.Model(&models.TradeRoom{}).
+		Where("id = ?", tradeRoom.ID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("move expiry into the past: %v", err)
+	}
+	if err := roomService.CleanupExpiredRooms(ctx); err != nil {
+		t.Fatalf("cleanup expired rooms: %v", err)
+	}
+	if status := storedRoom(t, tradeRoom.RoomID).Status; status != models.RoomStatusExpired {
+		t.Fatalf("room status after cleanup = %s, want expired", status)
+	}
+	if _, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, newWallet(t), "", "127.0.0.1"); !errors.Is(err, room.ErrRoomClosed) {
+		t.Fatalf("join after expiry = %v, want ErrRoomClosed", err)
+	}
+
+	// The event log keeps the whole lifecycle
+	replay, err := roomService.ReplayEvents(ctx, tradeRoom.RoomID, 0, 100)
+	if err != nil {
+		t.Fatalf("replay events: %v", err)
+	}
+	want := []struct {
+		eventType models.RoomEventType
+		wallet    string
+	}{
+		{models.RoomEventCreated, creator},
+		{models.RoomEventJoined, creator},
+		{models.RoomEventJoined, member},
+		{models.RoomEventShare, member},
+		{models.RoomEventClosed, ""},
+	}
+	if len(replay.Events) != len(want) {
+		t.Fatalf("replayed %d events, want %d", len(replay.Events), len(want))
+	}
+	for i, event := range replay.Events {
+		if event.Type != want[i].eventType || event.WalletAddress != want[i].wallet {
+			t.Errorf("event %d = %s by %q, want %s by %q", i, event.Type, event.WalletAddress, want[i].eventType, want[i].wallet)
+		}
+	}
+}
+
+func TestRoomFillsToMaxMembers(t *testing.T) {
+	ctx := context.Background()
+	roomService := env.services.Room
+	tradeRoom, creator := createRoom(t, 5)
+
+	// The creator holds one seat, so a batch of four fills the room exactly
+	wallets := make([]string, 4)
+	for i := range wallets {
+		wallets[i] = newWallet(t)
+	}
+	result, err := roomService.BatchMembers(ctx, &room.BatchMembersRequest{
+		RoomID:          tradeRoom.RoomID,
+		CreatorAddress:  creator,
+		Action:          room.BatchActionAdd,
+		WalletAddresses: wallets,
+	})
+	if err != nil {
+		t.Fatalf("batch add to capacity: %v", err)
+	}
+	if len(result.Added) != len(wallets) {
+		t.Fatalf("batch added %d members, want %d", len(result.Added), len(wallets))
+	}
+	if got := storedRoom(t, tradeRoom.RoomID).CurrentMembers; got != 5 {
+		t.Fatalf("current_members at capacity = %d, want 5", got)
+	}
+
+	// A full room takes no one more, by batch or by join
+	if _, err := roomService.BatchMembers(ctx, &room.BatchMembersRequest{
+		RoomID:          tradeRoom.RoomID,
+		CreatorAddress:  creator,
+		Action:          room.BatchActionAdd,
+		WalletAddresses: []string{newWallet(t)},
+	}); !errors.Is(err, room.ErrRoomFull) {
+		t.Fatalf("batch add to a full room = %v, want ErrRoomFull", err)
+	}
+	if _, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, newWallet(t), "", "127.0.0.1"); !errors.Is(err, room.ErrRoomFull) {
+		t.Fatalf("join a full room = %v, want ErrRoomFull", err)
+	}
+	members, err := roomService.GetRoomMembers(ctx, tradeRoom.RoomID)
+	if err != nil {
+		t.Fatalf("get members: %v", err)
+	}
+	if len(members) != 5 || storedRoom(t, tradeRoom.RoomID).CurrentMembers != 5 {
+		t.Fatalf("full room has %d member rows, want 5 with 5 seats taken", len(members))
+	}
+
+	// Nor can it shrink below its members
+	smaller := 4
+	if _, err := roomService.UpdateRoom(ctx, tradeRoom.RoomID, &room.UpdateRoomRequest{MaxMembers: &smaller}); !errors.Is(err, room.ErrMaxMembersTooLow) {
+		t.Fatalf("shrink a full room = %v, want ErrMaxMembersTooLow", err)
+	}
+}
+
+func TestRoomConcurrentJoins(t *testing.T) {
+	ctx := context.Background()
+	roomService := env.services.Room
+	tradeRoom, _ := createRoom(t, 5)
+
+	// More wallets than seats join at once; the seat count is taken in the database, so exactly the four
+	// seats left by the creator are given out
+	const joiners = 20
+	errs := make(chan error, joiners)
+	var wg sync.WaitGroup
+	for i := 0; i < joiners; i++ {
+		wallet := newWallet(t)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, wallet, "", "127.0.0.1")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	joined := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			joined++
+		case !errors.Is(err, room.ErrRoomFull):
+			t.Fatalf("parallel join = %v, want nil or ErrRoomFull", err)
+		}
+	}
+	if joined != 4 {
+		t.Fatalf("%d of %d parallel joins succeeded, want 4", joined, joiners)
+	}
+
+	members, err := roomService.GetRoomMembers(ctx, tradeRoom.RoomID)
+	if err != nil {
+		t.Fatalf("get members: %v", err)
+	}
+	if got := storedRoom(t, tradeRoom.RoomID).CurrentMembers; len(members) != 5 || got != 5 {
+		t.Fatalf("room has %d member rows and %d seats taken, want 5 and 5", len(members), got)
+	}
+}
+
+func TestRoomPasswordParallelGuesses(t *testing.T) {
+	ctx := context.Background()
+	roomService := env.services.Room
+	password := "correct horse"
+	tradeRoom, err := roomService.CreateRoom(ctx, &room.CreateRoomRequest{
+		CreatorAddress: newWallet(t),
+		Password:       &password,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+
+	// Every guess is counted before it is checked, so a burst cannot outrun the delays. With the default
+	// three free attempts, the fourth guess sets a delay that refuses the rest unchecked.
+	const guesses = 30
+	wallet := newWallet(t)
+	errs := make(chan error, guesses)
+	var wg sync.WaitGroup
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, wallet, "wrong", "127.0.0.1")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	checked := 0
+	for err := range errs {
+		var attemptErr *room.PasswordAttemptError
+		switch {
+		case errors.Is(err, room.ErrInvalidPassword):
+			checked++
+		case errors.As(err, &attemptErr):
+			if attemptErr.Attempts > 0 {
+				checked++
+			}
+		default:
+			t.Fatalf("wrong guess = %v, want ErrInvalidPassword or a PasswordAttemptError", err)
+		}
+	}
+	if checked == 0 || checked > 4 {
+		t.Fatalf("%d of %d parallel guesses were checked, want 1 to 4", checked, guesses)
+	}
+
+	// The delay holds even for the right password
+	if _, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, wallet, password, "127.0.0.1"); !errors.Is(err, room.ErrPasswordAttempts) {
+		t.Fatalf("join during the delay = %v, want ErrPasswordAttempts", err)
+	}
+}
+
+func TestRoomOwnershipTransfer(t *testing.T) {
+	ctx := context.Background()
+	roomService := env.services.Room
+	tradeRoom, creator := createRoom(t, 10)
+	member := newWallet(t)
+	if _, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, member, "", "127.0.0.1"); err != nil {
+		t.Fatalf("join: %v", err)
+	}
+
+	// A declined offer cannot be accepted afterwards
+	if _, err := roomService.RequestTransfer(ctx, tradeRoom.RoomID, creator, member); err != nil {
+		t.Fatalf("request transfer: %v", err)
+	}
+	if err := roomService.CancelTransfer(ctx, tradeRoom.RoomID, member); err != nil {
+		t.Fatalf("decline transfer: %v", err)
+	}
+	if _, err := roomService.AcceptTransfer(ctx, tradeRoom.RoomID, member); !errors.Is(err, room.ErrNoPendingTransfer) {
+		t.Fatalf("accept a declined transfer = %v, want ErrNoPendingTransfer", err)
+	}
+	if owner := storedRoom(t, tradeRoom.RoomID).CreatorAddress; owner != creator {
+		t.Fatalf("owner after a declined transfer = %s, want %s", owner, creator)
+	}
+
+	// An accepted offer moves the room, and the former owner can no longer cancel or offer it
+	if _, err := roomService.RequestTransfer(ctx, tradeRoom.RoomID, creator, member); err != nil {
+		t.Fatalf("request transfer again: %v", err)
+	}
+	if _, err := roomService.AcceptTransfer(ctx, tradeRoom.RoomID, member); err != nil {
+		t.Fatalf("accept transfer: %v", err)
+	}
+	stored := storedRoom(t, tradeRoom.RoomID)
+	if stored.CreatorAddress != member || stored.PendingOwnerAddress != nil {
+		t.Fatalf("room after accept is owned by %s with pending owner %v, want %s with none", stored.CreatorAddress, stored.PendingOwnerAddress, member)
+	}
+	if err := roomService.CancelTransfer(ctx, tradeRoom.RoomID, creator); !errors.Is(err, room.ErrNoPendingTransfer) {
+		t.Fatalf("cancel an accepted transfer = %v, want ErrNoPendingTransfer", err)
+	}
+	if _, err := roomService.RequestTransfer(ctx, tradeRoom.RoomID, creator, member); !errors.Is(err, room.ErrInsufficientPermission) {
+		t.Fatalf("offer by the former owner = %v, want ErrInsufficientPermission", err)
+	}
+}
+
+func TestSetRoomTokenKeepsMembers(t *testing.T) {
+	ctx := context.Background()
+	roomService := env.services.Room
+	tradeRoom, creator := createRoom(t, 10)
+
+	// Rebinding the token leaves the member count alone
+	if _, err := roomService.JoinRoom(ctx, tradeRoom.RoomID, newWallet(t), "", "127.0.0.1"); err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	mint := newWallet(t)
+	if _, err := roomService.SetRoomToken(ctx, &room.SetRoomTokenRequest{
+		RoomID:         tradeRoom.RoomID,
+		CreatorAddress: creator,
+		TokenAddress:   &mint,
+	}); err != nil {
+		t.Fatalf("set room token: %v", err)
+	}
+	stored := storedRoom(t, tradeRoom.RoomID)
+	if stored.TokenAddress == nil || *stored.TokenAddress != mint || stored.CurrentMembers != 2 {
+		t.Fatalf("room after rebinding has token %v and %d members, want %s and 2", stored.TokenAddress, stored.CurrentMembers, mint)
+	}
+
+	// An empty address clears the token
+	empty := ""
+	if _, err := roomService.SetRoomToken(ctx, &room.SetRoomTokenRequest{
+		RoomID:         tradeRoom.RoomID,
+		CreatorAddress: creator,
+		TokenAddress:   &empty,
+	}); err != nil {
+		t.Fatalf("clear room token: %v", err)
+	}
+	if stored := storedRoom(t, tradeRoom.RoomID); stored.TokenAddress != nil || stored.TokenID != nil {
+		t.Fatalf("room after clearing is bound to %v / %v, want no token", stored.TokenAddress, stored.TokenID)
+	}
+}
@@ -0,0 +1,106 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/emiyaio/solana-wallet-service/internal/services/room"
+)
+
+// messageWait bounds how long a client waits for an expected message
+const messageWait = 5 * time.Second
+
+// dialRoom opens the room's WebSocket as the wallet
+func dialRoom(t *testing.T, roomID, wallet string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(env.server.URL, "http") + "/api/v1/ws/rooms/" + roomID + "?wallet=" + wallet
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial room %s as %s: %v", roomID, wallet, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readUntil reads messages until one of the given type arrives, skipping others
+func readUntil(t *testing.T, conn *websocket.Conn, messageType room.MessageType) map[string]interface{} {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(messageWait))
+	for {
+		var message struct {
+			Type room.MessageType       `json:"type"`
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("waiting for %s: %v", messageType, err)
+		}
+		if message.Type == messageType {
+			return message.Data
+		}
+	}
+}
+
+func TestRoomWebSocket(t *testing.T) {
+	ctx := context.Background()
+	tradeRoom, creator := createRoom(t, 10)
+	member := newWallet(t)
+	if _, err := env.services.Room.JoinRoom(ctx, tradeRoom.RoomID, member, "", "127.0.0.1"); err != nil {
+		t.Fatalf("join: %v", err)
+	}
+
+	creatorConn := dialRoom(t, tradeRoom.RoomID, creator)
+	memberConn := dialRoom(t, tradeRoom.RoomID, member)
+
+	// The creator sees the member come online
+	readUntil(t, creatorConn, room.MessageTypeMemberJoined)
+
+	// Pings are answered
+	if err := memberConn.WriteJSON(map[string]interface{}{"type": room.MessageTypePing}); err != nil {
+		t.Fatalf("send ping: %v", err)
+	}
+	readUntil(t, memberConn, room.MessageTypePong)
+
+	// A share made over the API reaches every connected member
+	body, _ := json.Marshal(map[string]interface{}{
+		"sharer_address": creator,
+		"type":           "analysis",
+		"title":          "Deployer sold",
+		"content":        "The deployer wallet sold its remaining supply",
+	})
+	resp, err := http.Post(env.server.URL+"/api/v1/rooms/"+tradeRoom.RoomID+"/share", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("share: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("share status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	for name, conn := range map[string]*websocket.Conn{"creator": creatorConn, "member": memberConn} {
+		if data := readUntil(t, conn, room.MessageTypeSharedInfo); data["title"] != "Deployer sold" {
+			t.Errorf("%s received shared info %v", name, data)
+		}
+	}
+
+	// The member leaving is broadcast to the others
+	memberConn.Close()
+	readUntil(t, creatorConn, room.MessageTypeMemberLeft)
+}
+
+func TestRoomWebSocketRejectsNonMembers(t *testing.T) {
+	tradeRoom, _ := createRoom(t, 10)
+
+	// The upgrade succeeds, but the connection is closed before any message
+	conn := dialRoom(t, tradeRoom.RoomID, newWallet(t))
+	conn.SetReadDeadline(time.Now().Add(messageWait))
+	if _, data, err := conn.ReadMessage(); err == nil {
+		t.Fatalf("non-member received %s, want the connection closed", data)
+	}
+}